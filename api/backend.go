@@ -41,6 +41,8 @@ type StatusBackend interface {
 	ResetChainData() error
 	SendTransaction(sendArgs transactions.SendTxArgs, password string) (hash types.Hash, err error)
 	SendTransactionWithSignature(sendArgs transactions.SendTxArgs, sig []byte) (hash types.Hash, err error)
+	SpeedUpTransaction(args transactions.ReplaceTxArgs, password string) (hash types.Hash, err error)
+	CancelTransaction(args transactions.ReplaceTxArgs, password string) (hash types.Hash, err error)
 	SignHash(hexEncodedHash string) (string, error)
 	SignMessage(rpcParams personal.SignParams) (types.HexBytes, error)
 	SignTypedData(typed typeddata.TypedData, address string, password string) (types.HexBytes, error)
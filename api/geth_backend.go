@@ -14,6 +14,7 @@ import (
 
 	"github.com/imdario/mergo"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -30,6 +31,8 @@ import (
 	"github.com/planq-network/status-go/node"
 	"github.com/planq-network/status-go/nodecfg"
 	"github.com/planq-network/status-go/params"
+	"github.com/planq-network/status-go/protocol"
+	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/rpc"
 	"github.com/planq-network/status-go/services/personal"
 	"github.com/planq-network/status-go/services/typeddata"
@@ -51,6 +54,8 @@ var (
 	ErrRPCClientUnavailable = errors.New("JSON-RPC client is unavailable")
 	// ErrDBNotAvailable is returned if a method is called before the DB is available for usage
 	ErrDBNotAvailable = errors.New("DB is unavailable")
+	// ErrMessengerUnavailable is returned if a method is called before the messenger is initialized
+	ErrMessengerUnavailable = errors.New("messenger is unavailable")
 )
 
 var _ StatusBackend = (*GethStatusBackend)(nil)
@@ -515,6 +520,35 @@ func (b *GethStatusBackend) ImportUnencryptedDatabase(acc multiaccounts.Account,
 	return nil
 }
 
+// ExportArchive writes the data of the logged in account to a password encrypted archive
+func (b *GethStatusBackend) ExportArchive(request *requests.ExportArchive) error {
+	messenger, err := b.messenger()
+	if err != nil {
+		return err
+	}
+	return messenger.ExportArchive(context.Background(), request)
+}
+
+// ImportArchive merges a password encrypted archive into the logged in account
+func (b *GethStatusBackend) ImportArchive(request *requests.ImportArchive) (*protocol.MessengerResponse, error) {
+	messenger, err := b.messenger()
+	if err != nil {
+		return nil, err
+	}
+	return messenger.ImportArchive(context.Background(), request)
+}
+
+func (b *GethStatusBackend) messenger() (*protocol.Messenger, error) {
+	if b.statusNode == nil {
+		return nil, node.ErrNoRunningNode
+	}
+	wakuext := b.statusNode.WakuExtService()
+	if wakuext == nil || wakuext.Messenger() == nil {
+		return nil, ErrMessengerUnavailable
+	}
+	return wakuext.Messenger(), nil
+}
+
 func (b *GethStatusBackend) ChangeDatabasePassword(keyUID string, password string, newPassword string) error {
 	dbPath := filepath.Join(b.rootDataDir, fmt.Sprintf("%s.db", keyUID))
 	config := b.StatusNode().Config()
@@ -862,6 +896,45 @@ func (b *GethStatusBackend) SendTransactionWithSignature(sendArgs transactions.S
 	return
 }
 
+// SpeedUpTransaction replaces a pending transaction by the same transaction with higher fees.
+func (b *GethStatusBackend) SpeedUpTransaction(args transactions.ReplaceTxArgs, password string) (types.Hash, error) {
+	return b.replaceTransaction(args, password, false)
+}
+
+// CancelTransaction replaces a pending transaction by an empty transfer to its sender with higher fees.
+func (b *GethStatusBackend) CancelTransaction(args transactions.ReplaceTxArgs, password string) (types.Hash, error) {
+	return b.replaceTransaction(args, password, true)
+}
+
+func (b *GethStatusBackend) replaceTransaction(args transactions.ReplaceTxArgs, password string, cancel bool) (hash types.Hash, err error) {
+	verifiedAccount, err := b.getVerifiedWalletAccount(args.From.String(), password)
+	if err != nil {
+		return hash, err
+	}
+
+	var sendArgs transactions.SendTxArgs
+	if cancel {
+		sendArgs, hash, err = b.transactor.CancelTransaction(args, verifiedAccount)
+	} else {
+		sendArgs, hash, err = b.transactor.SpeedUpTransaction(args, verifiedAccount)
+	}
+	if err != nil {
+		return hash, err
+	}
+
+	go b.statusNode.RPCFiltersService().TriggerTransactionSentToUpstreamEvent(hash)
+
+	// the replacement is sent already, failing to track it is not an error of the call
+	if walletService := b.statusNode.WalletService(); walletService != nil {
+		err := walletService.ReplacePendingTransaction(b.transactor.NetworkID(), gethcommon.Hash(args.Hash), gethcommon.Hash(hash), sendArgs, cancel)
+		if err != nil {
+			log.Error("failed to track replacement transaction", "hash", hash, "replaced", args.Hash, "error", err)
+		}
+	}
+
+	return hash, nil
+}
+
 // HashTransaction validate the transaction and returns new sendArgs and the transaction hash.
 func (b *GethStatusBackend) HashTransaction(sendArgs transactions.SendTxArgs) (transactions.SendTxArgs, types.Hash, error) {
 	return b.transactor.HashTransaction(sendArgs)
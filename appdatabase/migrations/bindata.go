@@ -3,6 +3,17 @@
 // 1640111208_dummy.up.sql (258B)
 // 1642666031_add_removed_clock_to_bookmarks.up.sql (117B)
 // 1643644541_gif_api_key_setting.up.sql (108B)
+// 1643700000_read_receipts_typing_indicators_settings.up.sql (152B)
+// 1644232000_add_balance_history.up.sql (329B)
+// 1644233000_add_price_cache.up.sql (913B)
+// 1644234000_add_token_id_to_transfers.up.sql (105B)
+// 1644235000_add_collectibles_metadata.up.sql (935B)
+// 1644236000_add_pending_transactions_fees.up.sql (353B)
+// 1644237000_add_waku_db_backends.up.sql (271B)
+// 1644238000_add_mailserver_retention_quota.up.sql (265B)
+// 1644239000_add_mailserver_admin_token.up.sql (87B)
+// 1644240000_add_mailserver_replication.up.sql (322B)
+// 1644241000_add_push_notification_server_dispatchers.up.sql (866B)
 // doc.go (74B)
 
 package migrations
@@ -87,7 +98,7 @@ func _1640111208_dummyUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "1640111208_dummy.up.sql", size: 258, mode: os.FileMode(0664), modTime: time.Unix(1642389456, 0)}
+	info := bindataFileInfo{name: "1640111208_dummy.up.sql", size: 258, mode: os.FileMode(0644), modTime: time.Unix(1642389456, 0)}
 	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3e, 0xf0, 0xae, 0x20, 0x6e, 0x75, 0xd1, 0x36, 0x14, 0xf2, 0x40, 0xe5, 0xd6, 0x7a, 0xc4, 0xa5, 0x72, 0xaa, 0xb5, 0x4d, 0x71, 0x97, 0xb8, 0xe8, 0x95, 0x22, 0x95, 0xa2, 0xac, 0xaf, 0x48, 0x58}}
 	return a, nil
 }
@@ -107,7 +118,7 @@ func _1642666031_add_removed_clock_to_bookmarksUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "1642666031_add_removed_clock_to_bookmarks.up.sql", size: 117, mode: os.FileMode(0664), modTime: time.Unix(1644902983, 0)}
+	info := bindataFileInfo{name: "1642666031_add_removed_clock_to_bookmarks.up.sql", size: 117, mode: os.FileMode(0644), modTime: time.Unix(1644902983, 0)}
 	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x84, 0x4e, 0x38, 0x99, 0x7a, 0xc, 0x90, 0x13, 0xec, 0xfe, 0x2f, 0x55, 0xff, 0xb7, 0xb6, 0xaa, 0x96, 0xc6, 0x92, 0x79, 0xcc, 0xee, 0x4e, 0x99, 0x53, 0xfe, 0x1c, 0xbb, 0x32, 0x2, 0xa4, 0x27}}
 	return a, nil
 }
@@ -127,11 +138,231 @@ func _1643644541_gif_api_key_settingUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "1643644541_gif_api_key_setting.up.sql", size: 108, mode: os.FileMode(0664), modTime: time.Unix(1644197385, 0)}
+	info := bindataFileInfo{name: "1643644541_gif_api_key_setting.up.sql", size: 108, mode: os.FileMode(0644), modTime: time.Unix(1644197385, 0)}
 	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1b, 0x94, 0x28, 0xfb, 0x66, 0xd1, 0x7c, 0xb8, 0x89, 0xe2, 0xb4, 0x71, 0x65, 0x24, 0x57, 0x22, 0x95, 0x38, 0x97, 0x3, 0x9b, 0xc6, 0xa4, 0x41, 0x7b, 0xba, 0xf7, 0xdb, 0x70, 0xf7, 0x20, 0x3a}}
 	return a, nil
 }
 
+var __1643700000_read_receipts_typing_indicators_settingsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4e\x2d\x29\xc9\xcc\x4b\x2f\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x4e\xcd\x4b\x89\x2f\x4a\x4d\x04\x11\xc9\xa9\x99\x05\x25\xc5\x0a\x4e\xfe\xfe\x3e\xae\x8e\x7e\x0a\x2e\xae\x6e\x8e\xa1\x3e\x21\x0a\x6e\x8e\x3e\xc1\xae\xd6\x5c\x44\x99\x54\x52\x59\x90\x99\x97\x1e\x9f\x99\x97\x92\x99\x9c\x58\x92\x5f\x84\xd3\x34\x40\x00\x00\x00\xff\xff\x0b\xc7\xc5\x62\x98\x00\x00\x00")
+
+func _1643700000_read_receipts_typing_indicators_settingsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1643700000_read_receipts_typing_indicators_settingsUpSql,
+		"1643700000_read_receipts_typing_indicators_settings.up.sql",
+	)
+}
+
+func _1643700000_read_receipts_typing_indicators_settingsUpSql() (*asset, error) {
+	bytes, err := _1643700000_read_receipts_typing_indicators_settingsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1643700000_read_receipts_typing_indicators_settings.up.sql", size: 152, mode: os.FileMode(0644), modTime: time.Unix(1792281571, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x60, 0xd6, 0x7b, 0xb7, 0x7c, 0xca, 0xe5, 0xd, 0x7b, 0x2d, 0xfb, 0x16, 0xcd, 0xcc, 0x77, 0x84, 0xc6, 0x37, 0x5e, 0x95, 0x54, 0xb6, 0x57, 0xf5, 0x77, 0xc6, 0x2f, 0xd8, 0x19, 0xc2, 0x3a, 0xb0}}
+	return a, nil
+}
+
+var __1644232000_add_balance_historyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcf\xcf\x4a\x03\x31\x10\x06\xf0\xfb\x3e\xc5\x77\x6c\x61\xdf\xc0\x53\x36\x9d\xb6\x83\x31\x29\xd9\x59\x6b\x4f\x65\x75\xa3\x86\xda\x6c\xc9\x06\xc4\xb7\x17\x5a\xa5\x20\x14\xaf\xf3\xcd\x9f\xdf\x68\x4f\x4a\x08\xa2\x1a\x43\xe0\x25\xac\x13\xd0\x13\xb7\xd2\xe2\xb9\xff\xe8\xd3\x4b\xd8\xbf\xc7\xa9\x8c\xf9\x0b\xb3\x0a\x00\x52\x28\x9f\x63\x3e\xec\xe3\x80\xce\xb6\xbc\xb2\xb4\x40\xc3\x2b\xb6\x72\x9e\xb5\x9d\x31\xf5\xb9\xb1\x1f\x86\x1c\xa6\x09\x8f\xca\xeb\xb5\xf2\x7f\xd2\x32\x1e\x42\xba\x95\xc5\x63\x98\x4a\x7f\x3c\xfd\x73\xe1\x07\x88\xc6\xb8\xe6\x52\x79\x8d\xe9\x2d\xe4\x53\x8e\xa9\xdc\xd8\xbd\xf1\xfc\xa0\xfc\x0e\xf7\xb4\xc3\xec\xfa\x4b\xfd\xcb\xad\x2f\xb2\xfa\x8a\x98\xc3\x59\x68\x67\x97\x86\xb5\xc0\xd3\xc6\x28\x4d\xd5\x1c\x5b\x96\xb5\xeb\x04\xde\x6d\x79\x71\x57\x7d\x07\x00\x00\xff\xff\x91\xfe\x19\xa9\x49\x01\x00\x00")
+
+func _1644232000_add_balance_historyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644232000_add_balance_historyUpSql,
+		"1644232000_add_balance_history.up.sql",
+	)
+}
+
+func _1644232000_add_balance_historyUpSql() (*asset, error) {
+	bytes, err := _1644232000_add_balance_historyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644232000_add_balance_history.up.sql", size: 329, mode: os.FileMode(0644), modTime: time.Unix(1792282802, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x10, 0xe9, 0xa, 0xd3, 0x58, 0x33, 0xa, 0x28, 0x10, 0x94, 0x5c, 0xf6, 0x7, 0xf7, 0x65, 0xc0, 0xaa, 0x99, 0xd9, 0xc8, 0xfa, 0xfb, 0x48, 0x41, 0x99, 0x6a, 0xd6, 0x16, 0x7d, 0x8a, 0x11, 0x64}}
+	return a, nil
+}
+
+var __1644233000_add_price_cacheUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x92\xb1\x6e\xea\x30\x18\x85\xf7\x3c\xc5\x19\x41\xca\x74\x75\xb7\x4e\x26\x38\x60\xd5\xb5\x91\xe3\x94\x32\x45\xae\x31\x4d\xd4\x86\x44\x8e\x19\xf2\xf6\x95\x12\xa9\x95\x10\x34\x48\x45\x9d\xbf\x63\xfb\xfc\x9f\xff\x44\x51\xa2\x29\x34\x59\x70\x0a\x96\x42\x48\x0d\xfa\xc2\x32\x9d\xa1\xf5\x95\x75\x85\x35\xb6\x74\x98\x45\x00\xd0\xf5\xf5\x6b\xf3\x81\x67\xa2\x92\x35\x51\x43\x56\xe4\x9c\xc7\x03\xb4\x27\xef\xdd\xd1\xf6\x57\xf0\x70\x1b\x52\x2e\x89\x3e\x3f\x58\x9a\xe3\x9b\x2b\xfe\xfd\x2f\x2f\xe2\xda\xf8\x77\x17\x0a\x6b\xda\x8b\xf8\xd4\xee\x4d\x70\xfb\xc2\x04\xe4\x22\x63\x2b\x41\x97\x58\xb0\x15\x13\xe7\xc1\x8d\x62\x4f\x44\xed\xf0\x48\x77\x98\x8d\x93\xc4\x5f\xa5\xe7\x90\x02\x89\x14\x29\x67\x89\x86\xa2\x1b\x4e\x12\x1a\xcd\xb1\x65\x7a\x2d\x73\x0d\x25\xb7\x6c\xf9\x10\x45\x93\xba\xca\xaa\x0b\x8d\xef\xef\xa6\xad\x0b\xae\x9d\x18\x2c\x54\xb5\xeb\x82\xa9\xa7\x72\xd7\x7f\xe0\x47\x35\xf1\xd0\x21\xfe\x7e\xe6\xee\xae\x0e\x2e\xd8\xd2\x75\x7f\x63\xeb\xe0\x9b\xba\xb8\x55\xd9\xd8\xec\xb7\xcb\x35\x1a\xbc\x51\xdb\x67\x00\x00\x00\xff\xff\x2a\xd5\x42\x0e\x91\x03\x00\x00")
+
+func _1644233000_add_price_cacheUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644233000_add_price_cacheUpSql,
+		"1644233000_add_price_cache.up.sql",
+	)
+}
+
+func _1644233000_add_price_cacheUpSql() (*asset, error) {
+	bytes, err := _1644233000_add_price_cacheUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644233000_add_price_cache.up.sql", size: 913, mode: os.FileMode(0644), modTime: time.Unix(1792282986, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x54, 0x4e, 0xdc, 0xb9, 0xeb, 0xdd, 0x4f, 0xff, 0xd4, 0x8d, 0x2a, 0x6c, 0x6f, 0x66, 0xf1, 0xe8, 0x67, 0xc7, 0x52, 0xf0, 0x6a, 0x64, 0x57, 0xde, 0x9c, 0x18, 0xba, 0xe7, 0xb7, 0x8b, 0x97, 0x56}}
+	return a, nil
+}
+
+var __1644234000_add_token_id_to_transfersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x29\x4a\xcc\x2b\x4e\x4b\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\xc9\xcf\x4e\xcd\x8b\xcf\x4c\x51\x08\x73\x0c\x72\xf6\x70\x0c\xb2\xe6\x22\x52\x4b\x59\x62\x4e\x69\x2a\x42\x17\x20\x00\x00\xff\xff\x11\x1e\x38\x70\x69\x00\x00\x00")
+
+func _1644234000_add_token_id_to_transfersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644234000_add_token_id_to_transfersUpSql,
+		"1644234000_add_token_id_to_transfers.up.sql",
+	)
+}
+
+func _1644234000_add_token_id_to_transfersUpSql() (*asset, error) {
+	bytes, err := _1644234000_add_token_id_to_transfersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644234000_add_token_id_to_transfers.up.sql", size: 105, mode: os.FileMode(0644), modTime: time.Unix(1792283204, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb6, 0xad, 0xb3, 0xa1, 0xe, 0x57, 0x68, 0xf0, 0x7a, 0x11, 0x12, 0x6, 0xfc, 0x81, 0x48, 0x2a, 0xfb, 0x28, 0xc1, 0x3e, 0x33, 0x68, 0xc2, 0xed, 0xa8, 0x47, 0xc5, 0x62, 0x64, 0x13, 0x9, 0x4a}}
+	return a, nil
+}
+
+var __1644235000_add_collectibles_metadataUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x41\x6e\xc2\x30\x14\x44\xf7\x39\xc5\x17\x2b\x90\xb8\x41\x57\x4e\x70\xc0\xaa\x6b\xa3\xc4\x29\x65\x15\x19\xe7\x97\x5a\x24\x76\xe5\x98\xb6\xc7\xaf\x00\x95\x4a\x45\x6a\xb2\x60\xeb\x79\x7f\x2c\xcd\x4c\x56\x50\xa2\x28\x28\x92\x72\x0a\x2c\x07\x21\x15\xd0\x17\x56\xaa\x12\x8c\x6f\x5b\x34\xd1\xee\x5a\xec\xeb\x0e\xa3\x6e\x74\xd4\x30\x4d\x00\x00\x1c\xc6\x4f\x1f\x0e\xb5\x6d\xa0\x12\x25\x5b\x0a\xba\x80\x94\x2d\x99\x50\x67\x07\x51\x71\x3e\x3f\x83\xc6\xbb\x18\xb4\x89\xf0\x4c\x8a\x6c\x45\x8a\x3f\x72\xf4\x07\x74\x27\x97\xff\xe4\x63\xb0\x37\x3a\x2c\x68\x4e\x2a\xae\x60\x32\xb9\xa0\x4e\x77\x38\x4c\x35\xd8\x9b\x60\xdf\xa3\xf5\x6e\x18\xb6\x9d\xde\x63\x7d\x0c\xed\x30\xaa\x9d\xed\xf4\xc9\x75\x1c\xbe\xd3\xe6\xb0\x0f\xfe\xe8\x9a\xda\xf8\xd6\x87\xe1\x0b\xfc\x8a\x18\x9c\x6e\xc7\xf9\xc7\xa0\x6d\xec\x21\xe5\x32\xbd\x3c\x04\xec\x7d\xfb\x81\x0d\xa4\x52\x72\x4a\xc4\xed\x69\x4e\x78\x49\x2f\xf0\x2b\x46\xf3\x86\x4d\xad\xe3\x40\xb9\xeb\x82\x3d\x91\x62\x0b\x8f\x74\x0b\xd3\xdf\x49\xcc\xaf\xad\xcf\xaf\x05\xcf\x40\x0a\xc8\xa4\xc8\x39\xcb\x14\x14\x74\xcd\x49\x46\x93\x19\x6c\x98\x5a\xc9\x4a\x41\x21\x37\x6c\xf1\x90\x24\x63\xf7\xf8\xf3\x43\x7f\xe7\x41\x8e\x9b\xd1\xfd\x12\x1a\x99\xcb\x77\x00\x00\x00\xff\xff\x31\x4f\xf0\x7f\xa7\x03\x00\x00")
+
+func _1644235000_add_collectibles_metadataUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644235000_add_collectibles_metadataUpSql,
+		"1644235000_add_collectibles_metadata.up.sql",
+	)
+}
+
+func _1644235000_add_collectibles_metadataUpSql() (*asset, error) {
+	bytes, err := _1644235000_add_collectibles_metadataUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644235000_add_collectibles_metadata.up.sql", size: 935, mode: os.FileMode(0644), modTime: time.Unix(1792283503, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0xdd, 0xa1, 0x5f, 0xa0, 0x2e, 0xef, 0x92, 0xd8, 0x9c, 0x9d, 0x3b, 0x81, 0xd4, 0x40, 0x74, 0xac, 0x19, 0xd9, 0x4f, 0x51, 0xe0, 0x7c, 0xe7, 0x8a, 0x4e, 0x9f, 0xd1, 0xd8, 0xb7, 0x37, 0xe7}}
+	return a, nil
+}
+
+var __1644236000_add_pending_transactions_feesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcc\x41\x0e\x83\x20\x10\x00\xc0\xbb\xaf\xd8\x7f\x78\x5a\x04\xad\xc9\x16\x12\x85\x5e\x09\xa1\x5b\x43\x62\x91\x80\x87\xf6\xf7\x7d\x83\xe9\x03\x66\x90\xac\x5a\xc0\xa2\x20\x05\x85\xf3\x33\xe5\xcd\x9f\x35\xe4\x16\xe2\x99\x8e\xdc\x00\xa5\x84\xc1\x90\xbb\x6b\xc8\x47\x8e\x0c\x4e\xaf\xf3\xa4\x95\x04\x31\x4f\xb3\xb6\x7d\x77\xa5\x78\x87\x8f\x7f\x31\xfb\xc2\xd5\x6f\xa1\x81\x20\x23\xae\x0f\xa5\xa6\xa3\xa6\xf3\xfb\x67\x55\xb9\xec\x21\x72\x83\x07\x2e\xc3\x0d\x97\x6b\x3a\x86\x1c\x79\x07\x61\x0c\x29\xd4\xa0\x8d\x05\xed\x88\x40\xaa\x11\x1d\x59\x18\x91\x56\xd5\x77\xbf\x00\x00\x00\xff\xff\xa1\xb4\x63\x02\x61\x01\x00\x00")
+
+func _1644236000_add_pending_transactions_feesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644236000_add_pending_transactions_feesUpSql,
+		"1644236000_add_pending_transactions_fees.up.sql",
+	)
+}
+
+func _1644236000_add_pending_transactions_feesUpSql() (*asset, error) {
+	bytes, err := _1644236000_add_pending_transactions_feesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644236000_add_pending_transactions_fees.up.sql", size: 353, mode: os.FileMode(0644), modTime: time.Unix(1792283957, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0, 0xc3, 0x72, 0x43, 0x68, 0xb6, 0x29, 0x8d, 0x3e, 0x4f, 0xe1, 0xa3, 0x82, 0xac, 0x84, 0x2d, 0x74, 0x34, 0x7c, 0xc9, 0x3b, 0xea, 0x39, 0x6e, 0xad, 0x9d, 0xa2, 0x4a, 0x9, 0x25, 0xa0, 0x45}}
+	return a, nil
+}
+
+var __1644237000_add_waku_db_backendsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\xce\x31\x0e\x82\x30\x14\x06\xe0\xbd\xa7\xf8\x37\x34\xf1\x06\x4e\x0f\xa8\x81\x88\xd6\x34\x45\xc2\xd4\x14\xfa\xc0\x46\x82\x51\x6a\x8c\xb7\x77\x73\x74\x74\xff\x86\x2f\xd3\x92\x8c\x84\xa1\xb4\x92\x78\xb9\xeb\xd3\xf6\xb7\x79\x08\xa3\xf5\x9d\x5d\xee\x53\x88\x8c\x95\x00\x78\x76\xdd\xc4\x1e\xa9\x52\x95\xa4\x23\x72\xb9\xa3\xba\x32\x18\xdc\xb4\xf0\x46\x00\xcb\x7b\x8e\x17\x8e\xa1\xb7\xc1\xe3\x4c\x3a\x2b\x48\x7f\x55\x12\x7c\x82\x93\x2e\x0f\xa4\x5b\xec\x65\x2b\xd6\x68\x4a\x53\xa8\xda\x40\xab\xa6\xcc\xb7\x42\xfc\x7a\x74\xce\x8f\xfc\xf8\xcb\xe3\x13\x00\x00\xff\xff\x99\x62\x16\xe7\x0f\x01\x00\x00")
+
+func _1644237000_add_waku_db_backendsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644237000_add_waku_db_backendsUpSql,
+		"1644237000_add_waku_db_backends.up.sql",
+	)
+}
+
+func _1644237000_add_waku_db_backendsUpSql() (*asset, error) {
+	bytes, err := _1644237000_add_waku_db_backendsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644237000_add_waku_db_backends.up.sql", size: 271, mode: os.FileMode(0644), modTime: time.Unix(1792285058, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd9, 0x3b, 0x34, 0xc5, 0x4a, 0xd9, 0xe0, 0xbb, 0xba, 0xec, 0xf4, 0x9f, 0x4e, 0x73, 0x1b, 0x8, 0xd4, 0x2, 0x1e, 0x1b, 0xcc, 0x23, 0xc5, 0xaa, 0xca, 0x6f, 0xed, 0x9a, 0xd2, 0x6e, 0x2, 0x6a}}
+	return a, nil
+}
+
+var __1644238000_add_mailserver_retention_quotaUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8d\xc1\x4a\x03\x31\x14\x45\xf7\xf9\x8a\xbb\x6b\x0b\x5d\xb8\xef\xea\x39\x13\xe9\x60\x3a\x23\xe1\xc5\xd2\x55\x08\x33\xb1\x06\x35\xd1\x24\x55\xfa\xf7\xd2\x82\x85\xc1\xed\xe5\x9e\x73\x48\xb1\xd4\x60\xba\x57\x12\x3f\xee\xed\x64\xc7\x14\x5f\xc2\x11\xd4\xb6\x68\x06\x65\x76\x3d\x3e\x5c\x78\x2f\x3e\x7f\xfb\x6c\x4b\x4d\xd9\x1d\xbd\xfd\x3a\xa5\xea\xd0\xf5\x8c\x7e\x60\xf4\x46\x29\xb4\xf2\x81\x8c\x62\xdc\x6d\x84\x68\xb4\x24\x96\xff\xad\xb6\xa6\xcf\x30\xda\xec\xab\x8f\x35\xa4\x88\xa5\x00\xae\x1b\x9e\x49\x37\x5b\xd2\x37\xdf\x5a\x00\x93\x3b\x97\x59\xe4\x32\x96\x73\xac\xaf\xbe\x86\xd1\x86\xe9\x46\xfd\xc5\x17\x61\x5a\x5c\x4e\x4f\xba\xdb\x91\x3e\xe0\x51\x1e\xb0\xbc\x06\xd6\x33\x70\x25\x56\xd8\x77\xbc\x1d\x0c\x43\x0f\xfb\xae\xdd\x88\xdf\x00\x00\x00\xff\xff\x5e\xc6\xb8\x20\x09\x01\x00\x00")
+
+func _1644238000_add_mailserver_retention_quotaUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644238000_add_mailserver_retention_quotaUpSql,
+		"1644238000_add_mailserver_retention_quota.up.sql",
+	)
+}
+
+func _1644238000_add_mailserver_retention_quotaUpSql() (*asset, error) {
+	bytes, err := _1644238000_add_mailserver_retention_quotaUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644238000_add_mailserver_retention_quota.up.sql", size: 265, mode: os.FileMode(0644), modTime: time.Unix(1792285597, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xac, 0xf2, 0x66, 0xa3, 0x8, 0x47, 0xf7, 0x84, 0x91, 0x70, 0x63, 0x49, 0x7e, 0xfb, 0x16, 0xd7, 0x3c, 0x74, 0x11, 0x7, 0xff, 0x7, 0x53, 0x7f, 0x54, 0xd7, 0x34, 0x8c, 0xdb, 0xbb, 0xb0, 0xf6}}
+	return a, nil
+}
+
+var __1644239000_add_mailserver_admin_tokenUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x04\xc0\x4d\x0a\x42\x21\x10\x07\xf0\x7d\xa7\xf8\xef\x3c\x44\xab\x49\x8d\x16\x93\x82\x8c\x6d\x45\xca\x42\x4c\x05\xfb\x78\xd7\x7f\x3f\x62\xb1\x01\x42\x27\xb6\xd8\x72\xfb\xa5\xfb\x1c\xcf\xfa\x02\x19\x03\xed\x39\x5e\x1d\x7a\xae\xef\x4f\x59\xff\xb2\x52\x7e\xf4\x3a\xd2\x77\xb6\x32\x70\xa3\xa0\x2f\x14\xe0\xbc\xc0\x45\x66\x18\x7b\xa6\xc8\x02\xa5\x8e\x87\x3d\x00\x00\xff\xff\x50\x68\x9a\x42\x57\x00\x00\x00")
+
+func _1644239000_add_mailserver_admin_tokenUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644239000_add_mailserver_admin_tokenUpSql,
+		"1644239000_add_mailserver_admin_token.up.sql",
+	)
+}
+
+func _1644239000_add_mailserver_admin_tokenUpSql() (*asset, error) {
+	bytes, err := _1644239000_add_mailserver_admin_tokenUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644239000_add_mailserver_admin_token.up.sql", size: 87, mode: os.FileMode(0644), modTime: time.Unix(1792285890, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcf, 0xa1, 0xdf, 0x52, 0x98, 0x12, 0x8c, 0x85, 0x79, 0xc4, 0xf5, 0x2, 0xa8, 0xf, 0x7f, 0xed, 0x49, 0xe9, 0xbc, 0x2, 0x36, 0x23, 0x78, 0xa4, 0x82, 0xdf, 0xa2, 0x4f, 0x44, 0x3f, 0x5d, 0x54}}
+	return a, nil
+}
+
+var __1644240000_add_mailserver_replicationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xce\xb1\xaa\x83\x30\x14\xc6\xf1\x3d\x4f\xf1\x6d\x2a\xf8\x06\x77\xca\xd5\x5c\x94\x9b\x6a\x09\xb1\xe2\x14\x24\xa6\x6d\x68\x49\x44\x53\x4a\xdf\xbe\x68\xa1\xd0\xa9\x1d\xba\x9d\xe1\x3b\x7f\x7e\x99\x60\x54\x32\x48\xfa\xcb\x19\xae\xfd\xe9\xa2\xb4\x77\x7b\x7b\x50\x93\x19\xcf\x56\xf7\xc1\x7a\xa7\x46\x63\xa6\x19\x31\x01\x9c\x1f\x0c\x76\x54\x64\x05\x15\xa8\x6a\x89\xaa\xe1\x3c\x25\xc0\x7c\x73\xe1\x68\x82\xd5\xca\x0e\xcf\x41\xce\xfe\x68\xc3\x25\x22\x3b\x44\xcb\x68\x2b\xca\x0d\x15\x1d\xfe\x59\x87\x78\x49\xa5\x2f\x7f\x09\x49\xd0\x96\xb2\xa8\x1b\x09\x51\xb7\x65\xfe\x43\xc8\x47\xbe\xe0\x47\xab\x1f\xc0\xf5\xfc\x92\x70\x6d\xbd\x25\xde\x03\x00\x00\xff\xff\x67\xf8\xcb\x46\x42\x01\x00\x00")
+
+func _1644240000_add_mailserver_replicationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644240000_add_mailserver_replicationUpSql,
+		"1644240000_add_mailserver_replication.up.sql",
+	)
+}
+
+func _1644240000_add_mailserver_replicationUpSql() (*asset, error) {
+	bytes, err := _1644240000_add_mailserver_replicationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644240000_add_mailserver_replication.up.sql", size: 322, mode: os.FileMode(0644), modTime: time.Unix(1792286234, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x62, 0x81, 0xae, 0xd7, 0x6c, 0xd6, 0x65, 0x1d, 0xe6, 0x31, 0xf6, 0x95, 0x5d, 0x1f, 0x4c, 0x6d, 0xee, 0x74, 0x2c, 0x19, 0xc7, 0xc3, 0x2d, 0x5, 0x84, 0x23, 0xfb, 0x51, 0x7e, 0xff, 0x35, 0xe}}
+	return a, nil
+}
+
+var __1644241000_add_push_notification_server_dispatchersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\xd2\x31\x4e\x05\x21\x10\x06\xe0\xde\x53\x4c\xf7\x0e\x61\x35\xef\x2d\x2f\x16\x23\x24\x2b\x6b\x4b\x10\x06\x25\xb2\xb0\x01\x76\x13\x6f\x6f\xb6\xb1\xb5\x50\x2e\xf0\x7f\x93\xf9\x7f\x24\x2d\x66\xd0\x78\x25\x01\xdb\xde\x3e\x4c\x2e\x3d\x86\xe8\x6c\x8f\x25\x37\xd3\xb8\x1e\x5c\x8d\x2b\x39\xc4\x77\xc0\x69\x82\x9b\xa2\xe5\x59\x82\xdd\x72\x33\x9c\xed\x5b\x62\x0f\x57\xa5\x48\xa0\x84\x49\xdc\x71\x21\x0d\x77\xa4\x17\xf1\xf8\xf0\xa7\xec\x4f\xfe\x32\x21\x26\x86\x57\x9c\x6f\x4f\x38\x83\x54\x1a\xe4\x42\xf4\xa3\x5c\x2e\xff\x40\x44\x3f\x10\xe8\x6c\xd7\xb1\x82\xe7\x83\x53\xd9\x56\xce\x7d\x48\x0b\x7b\x4d\x63\xae\x0f\x6e\x1d\x35\x9f\x33\xda\x55\xf6\x9c\x7b\xb4\xa9\x0d\x5c\xd1\x29\xfd\xfa\xa1\xef\x00\x00\x00\xff\xff\x17\xb3\xc0\x37\x62\x03\x00\x00")
+
+func _1644241000_add_push_notification_server_dispatchersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644241000_add_push_notification_server_dispatchersUpSql,
+		"1644241000_add_push_notification_server_dispatchers.up.sql",
+	)
+}
+
+func _1644241000_add_push_notification_server_dispatchersUpSql() (*asset, error) {
+	bytes, err := _1644241000_add_push_notification_server_dispatchersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644241000_add_push_notification_server_dispatchers.up.sql", size: 866, mode: os.FileMode(0644), modTime: time.Unix(1792287070, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb7, 0xcc, 0x6, 0x24, 0xb9, 0xef, 0x39, 0xd9, 0xb, 0x5f, 0x11, 0x2, 0xd5, 0x50, 0x7c, 0x1, 0xa4, 0xdb, 0x6c, 0x8f, 0x77, 0xb7, 0xab, 0x56, 0xc5, 0x60, 0x14, 0x62, 0x27, 0x78, 0x91, 0x45}}
+	return a, nil
+}
+
 var _docGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2c\xc9\xb1\x0d\xc4\x20\x0c\x05\xd0\x9e\x29\xfe\x02\xd8\xfd\x6d\xe3\x4b\xac\x2f\x44\x82\x09\x78\x7f\xa5\x49\xfd\xa6\x1d\xdd\xe8\xd8\xcf\x55\x8a\x2a\xe3\x47\x1f\xbe\x2c\x1d\x8c\xfa\x6f\xe3\xb4\x34\xd4\xd9\x89\xbb\x71\x59\xb6\x18\x1b\x35\x20\xa2\x9f\x0a\x03\xa2\xe5\x0d\x00\x00\xff\xff\x60\xcd\x06\xbe\x4a\x00\x00\x00")
 
 func docGoBytes() ([]byte, error) {
@@ -249,6 +480,28 @@ var _bindata = map[string]func() (*asset, error){
 
 	"1643644541_gif_api_key_setting.up.sql": _1643644541_gif_api_key_settingUpSql,
 
+	"1643700000_read_receipts_typing_indicators_settings.up.sql": _1643700000_read_receipts_typing_indicators_settingsUpSql,
+
+	"1644232000_add_balance_history.up.sql": _1644232000_add_balance_historyUpSql,
+
+	"1644233000_add_price_cache.up.sql": _1644233000_add_price_cacheUpSql,
+
+	"1644234000_add_token_id_to_transfers.up.sql": _1644234000_add_token_id_to_transfersUpSql,
+
+	"1644235000_add_collectibles_metadata.up.sql": _1644235000_add_collectibles_metadataUpSql,
+
+	"1644236000_add_pending_transactions_fees.up.sql": _1644236000_add_pending_transactions_feesUpSql,
+
+	"1644237000_add_waku_db_backends.up.sql": _1644237000_add_waku_db_backendsUpSql,
+
+	"1644238000_add_mailserver_retention_quota.up.sql": _1644238000_add_mailserver_retention_quotaUpSql,
+
+	"1644239000_add_mailserver_admin_token.up.sql": _1644239000_add_mailserver_admin_tokenUpSql,
+
+	"1644240000_add_mailserver_replication.up.sql": _1644240000_add_mailserver_replicationUpSql,
+
+	"1644241000_add_push_notification_server_dispatchers.up.sql": _1644241000_add_push_notification_server_dispatchersUpSql,
+
 	"doc.go": docGo,
 }
 
@@ -293,10 +546,21 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"1640111208_dummy.up.sql":                          &bintree{_1640111208_dummyUpSql, map[string]*bintree{}},
-	"1642666031_add_removed_clock_to_bookmarks.up.sql": &bintree{_1642666031_add_removed_clock_to_bookmarksUpSql, map[string]*bintree{}},
-	"1643644541_gif_api_key_setting.up.sql":            &bintree{_1643644541_gif_api_key_settingUpSql, map[string]*bintree{}},
-	"doc.go":                                           &bintree{docGo, map[string]*bintree{}},
+	"1640111208_dummy.up.sql":                                    &bintree{_1640111208_dummyUpSql, map[string]*bintree{}},
+	"1642666031_add_removed_clock_to_bookmarks.up.sql":           &bintree{_1642666031_add_removed_clock_to_bookmarksUpSql, map[string]*bintree{}},
+	"1643644541_gif_api_key_setting.up.sql":                      &bintree{_1643644541_gif_api_key_settingUpSql, map[string]*bintree{}},
+	"1643700000_read_receipts_typing_indicators_settings.up.sql": &bintree{_1643700000_read_receipts_typing_indicators_settingsUpSql, map[string]*bintree{}},
+	"1644232000_add_balance_history.up.sql":                      &bintree{_1644232000_add_balance_historyUpSql, map[string]*bintree{}},
+	"1644233000_add_price_cache.up.sql":                          &bintree{_1644233000_add_price_cacheUpSql, map[string]*bintree{}},
+	"1644234000_add_token_id_to_transfers.up.sql":                &bintree{_1644234000_add_token_id_to_transfersUpSql, map[string]*bintree{}},
+	"1644235000_add_collectibles_metadata.up.sql":                &bintree{_1644235000_add_collectibles_metadataUpSql, map[string]*bintree{}},
+	"1644236000_add_pending_transactions_fees.up.sql":            &bintree{_1644236000_add_pending_transactions_feesUpSql, map[string]*bintree{}},
+	"1644237000_add_waku_db_backends.up.sql":                     &bintree{_1644237000_add_waku_db_backendsUpSql, map[string]*bintree{}},
+	"1644238000_add_mailserver_retention_quota.up.sql":           &bintree{_1644238000_add_mailserver_retention_quotaUpSql, map[string]*bintree{}},
+	"1644239000_add_mailserver_admin_token.up.sql":               &bintree{_1644239000_add_mailserver_admin_tokenUpSql, map[string]*bintree{}},
+	"1644240000_add_mailserver_replication.up.sql":               &bintree{_1644240000_add_mailserver_replicationUpSql, map[string]*bintree{}},
+	"1644241000_add_push_notification_server_dispatchers.up.sql": &bintree{_1644241000_add_push_notification_server_dispatchersUpSql, map[string]*bintree{}},
+	"doc.go": &bintree{docGo, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
@@ -0,0 +1,2 @@
+ALTER TABLE settings ADD COLUMN send_read_receipts BOOLEAN DEFAULT FALSE;
+ALTER TABLE settings ADD COLUMN send_typing_indicators BOOLEAN DEFAULT FALSE;
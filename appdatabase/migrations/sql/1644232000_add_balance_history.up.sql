@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS balance_history (
+    network_id UNSIGNED BIGINT NOT NULL,
+    address VARCHAR NOT NULL,
+    token VARCHAR NOT NULL,
+    timestamp UNSIGNED BIGINT NOT NULL,
+    balance BLOB,
+    fingerprint VARCHAR NOT NULL,
+    PRIMARY KEY (network_id, address, token, timestamp) ON CONFLICT REPLACE
+) WITHOUT ROWID;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS price_cache (
+    symbol VARCHAR NOT NULL,
+    currency VARCHAR NOT NULL,
+    price FLOAT NOT NULL,
+    change_24h FLOAT NOT NULL,
+    market_cap FLOAT NOT NULL,
+    updated_at UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (symbol, currency) ON CONFLICT REPLACE
+) WITHOUT ROWID;
+
+CREATE TABLE IF NOT EXISTS price_history_cache (
+    symbol VARCHAR NOT NULL,
+    currency VARCHAR NOT NULL,
+    step UNSIGNED BIGINT NOT NULL,
+    timestamp UNSIGNED BIGINT NOT NULL,
+    price FLOAT NOT NULL,
+    PRIMARY KEY (symbol, currency, step, timestamp) ON CONFLICT REPLACE
+) WITHOUT ROWID;
+
+CREATE TABLE IF NOT EXISTS price_history_fetches (
+    symbol VARCHAR NOT NULL,
+    currency VARCHAR NOT NULL,
+    step UNSIGNED BIGINT NOT NULL,
+    from_timestamp UNSIGNED BIGINT NOT NULL,
+    fetched_at UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (symbol, currency, step) ON CONFLICT REPLACE
+) WITHOUT ROWID;
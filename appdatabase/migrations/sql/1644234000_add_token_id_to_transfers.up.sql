@@ -0,0 +1,2 @@
+ALTER TABLE transfers ADD COLUMN token_id VARCHAR;
+ALTER TABLE transfers ADD COLUMN token_value VARCHAR;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS collectibles_metadata (
+    network_id UNSIGNED BIGINT NOT NULL,
+    contract VARCHAR NOT NULL,
+    token_id VARCHAR NOT NULL,
+    token_uri VARCHAR NOT NULL DEFAULT "",
+    name VARCHAR NOT NULL DEFAULT "",
+    description VARCHAR NOT NULL DEFAULT "",
+    image_url VARCHAR NOT NULL DEFAULT "",
+    animation_url VARCHAR NOT NULL DEFAULT "",
+    background_color VARCHAR NOT NULL DEFAULT "",
+    external_url VARCHAR NOT NULL DEFAULT "",
+    traits BLOB,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    fetched_at UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (network_id, contract, token_id) ON CONFLICT REPLACE
+) WITHOUT ROWID;
+
+CREATE TABLE IF NOT EXISTS collectibles_contracts (
+    network_id UNSIGNED BIGINT NOT NULL,
+    contract VARCHAR NOT NULL,
+    name VARCHAR NOT NULL DEFAULT "",
+    fetched_at UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (network_id, contract) ON CONFLICT REPLACE
+) WITHOUT ROWID;
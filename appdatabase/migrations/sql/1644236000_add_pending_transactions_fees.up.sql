@@ -0,0 +1,5 @@
+ALTER TABLE pending_transactions ADD COLUMN nonce UNSIGNED BIGINT;
+ALTER TABLE pending_transactions ADD COLUMN max_fee_per_gas BLOB;
+ALTER TABLE pending_transactions ADD COLUMN max_priority_fee_per_gas BLOB;
+ALTER TABLE pending_transactions ADD COLUMN replaces VARCHAR;
+ALTER TABLE pending_transactions ADD COLUMN cancel BOOLEAN NOT NULL DEFAULT FALSE;
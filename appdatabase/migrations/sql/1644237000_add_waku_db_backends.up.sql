@@ -0,0 +1,9 @@
+CREATE TABLE waku_config_db_sqlite (
+  enabled BOOLEAN DEFAULT false,
+  synthetic_id VARCHAR DEFAULT 'id' PRIMARY KEY
+) WITHOUT ROWID;
+
+CREATE TABLE waku_config_db_badger (
+  enabled BOOLEAN DEFAULT false,
+  synthetic_id VARCHAR DEFAULT 'id' PRIMARY KEY
+) WITHOUT ROWID;
@@ -0,0 +1,8 @@
+ALTER TABLE waku_config ADD COLUMN mailserver_storage_quota INT NOT NULL DEFAULT 0;
+
+CREATE TABLE waku_config_topic_retention (
+  topic VARCHAR NOT NULL,
+  days INT NOT NULL,
+  synthetic_id VARCHAR DEFAULT 'id',
+  PRIMARY KEY (topic, synthetic_id)
+) WITHOUT ROWID;
@@ -0,0 +1 @@
+ALTER TABLE waku_config ADD COLUMN mailserver_admin_token VARCHAR NOT NULL DEFAULT '';
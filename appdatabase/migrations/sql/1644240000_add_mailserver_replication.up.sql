@@ -0,0 +1,11 @@
+CREATE TABLE waku_config_replication_peers (
+  node VARCHAR NOT NULL,
+  synthetic_id VARCHAR DEFAULT 'id',
+  PRIMARY KEY (node, synthetic_id)
+) WITHOUT ROWID;
+
+CREATE TABLE waku_config_replication_topics (
+  topic VARCHAR NOT NULL,
+  synthetic_id VARCHAR DEFAULT 'id',
+  PRIMARY KEY (topic, synthetic_id)
+) WITHOUT ROWID;
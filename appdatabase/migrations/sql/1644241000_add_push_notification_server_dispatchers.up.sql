@@ -0,0 +1,9 @@
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_enabled BOOLEAN DEFAULT FALSE;
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_key_file VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_key_id VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_team_id VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_development BOOLEAN DEFAULT FALSE;
+ALTER TABLE push_notifications_server_config ADD COLUMN apns_url VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE push_notifications_server_config ADD COLUMN fcm_enabled BOOLEAN DEFAULT FALSE;
+ALTER TABLE push_notifications_server_config ADD COLUMN fcm_credentials_file VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE push_notifications_server_config ADD COLUMN fcm_url VARCHAR NOT NULL DEFAULT '';
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/discv5"
 
 	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/nodecfg"
 	"github.com/planq-network/status-go/params"
 	"github.com/planq-network/status-go/protocol/pushnotificationserver"
@@ -72,6 +73,9 @@ func TestMigrateNodeConfig(t *testing.T) {
 		ShhextConfig: params.ShhextConfig{
 			PFSEnabled: true,
 		},
+		WakuConfig: params.WakuConfig{
+			MailServerTopicRetention: make(map[types.TopicType]int),
+		},
 		WakuV2Config: params.WakuV2Config{
 			CustomNodes: make(map[string]string),
 		},
@@ -160,6 +164,27 @@ func randomCustomNodes() map[string]string {
 	return result
 }
 
+func randomTopicRetention() map[types.TopicType]int {
+	result := make(map[types.TopicType]int)
+	m := randomInt(7)
+	for i := 0; i < m; i++ {
+		result[types.BytesToTopic([]byte(randomString()))] = randomInt(365)
+	}
+	return result
+}
+
+func randomTopicTypeSlice() []types.TopicType {
+	m := randomInt(7) + 1
+	var result []types.TopicType
+	for i := 0; i < m; i++ {
+		result = append(result, types.BytesToTopic([]byte(randomString())))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
 func randomNetworkSlice() []params.Network {
 	m := randomInt(7) + 1
 	var result []params.Network
@@ -247,6 +272,19 @@ func randomNodeConfig() *params.NodeConfig {
 			Enabled:   randomBool(),
 			GorushURL: randomString(),
 			Identity:  privK,
+			APNs: pushnotificationserver.APNsConfig{
+				Enabled:     randomBool(),
+				KeyFile:     randomString(),
+				KeyID:       randomString(),
+				TeamID:      randomString(),
+				Development: randomBool(),
+				URL:         randomString(),
+			},
+			FCM: pushnotificationserver.FCMConfig{
+				Enabled:         randomBool(),
+				CredentialsFile: randomString(),
+				URL:             randomString(),
+			},
 		},
 		ShhextConfig: params.ShhextConfig{
 			PFSEnabled:                   randomBool(),
@@ -291,22 +329,33 @@ func randomNodeConfig() *params.NodeConfig {
 			AutoUpdate:          randomBool(),
 		},
 		WakuConfig: params.WakuConfig{
-			Enabled:                 randomBool(),
-			LightClient:             randomBool(),
-			FullNode:                randomBool(),
-			EnableMailServer:        randomBool(),
-			DataDir:                 randomString(),
-			MinimumPoW:              randomFloat(math.MaxInt64),
-			MailServerPassword:      randomString(),
-			MailServerRateLimit:     randomInt(math.MaxInt64),
-			MailServerDataRetention: randomInt(math.MaxInt64),
-			TTL:                     randomInt(math.MaxInt64),
-			MaxMessageSize:          uint32(randomInt(math.MaxInt64)),
+			Enabled:                     randomBool(),
+			LightClient:                 randomBool(),
+			FullNode:                    randomBool(),
+			EnableMailServer:            randomBool(),
+			DataDir:                     randomString(),
+			MinimumPoW:                  randomFloat(math.MaxInt64),
+			MailServerPassword:          randomString(),
+			MailServerRateLimit:         randomInt(math.MaxInt64),
+			MailServerDataRetention:     randomInt(math.MaxInt64),
+			MailServerTopicRetention:    randomTopicRetention(),
+			MailServerStorageQuota:      int64(randomInt(math.MaxInt64)),
+			MailServerReplicationPeers:  randomStringSlice(),
+			MailServerReplicationTopics: randomTopicTypeSlice(),
+			MailServerAdminToken:        randomString(),
+			TTL:                         randomInt(math.MaxInt64),
+			MaxMessageSize:              uint32(randomInt(math.MaxInt64)),
 			DatabaseConfig: params.DatabaseConfig{
 				PGConfig: params.PGConfig{
 					Enabled: randomBool(),
 					URI:     randomString(),
 				},
+				SQLiteConfig: params.SQLiteConfig{
+					Enabled: randomBool(),
+				},
+				BadgerConfig: params.BadgerConfig{
+					Enabled: randomBool(),
+				},
 			},
 			EnableRateLimiter:      randomBool(),
 			PacketRateLimitIP:      int64(randomInt(math.MaxInt64)),
@@ -0,0 +1,63 @@
+// mailserver-db-migrate copies the envelopes of a mailserver from one database
+// backend to another. The mailserver must be stopped while it runs.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/planq-network/status-go/mailserver"
+)
+
+var (
+	fromBackend = flag.String("from-backend", mailserver.LevelDBBackend, "backend to copy envelopes from (leveldb, postgres, sqlite or badger)")
+	from        = flag.String("from", "", "directory, file or URI of the database to copy envelopes from")
+	toBackend   = flag.String("to-backend", mailserver.SQLiteBackend, "backend to copy envelopes to (leveldb, postgres, sqlite or badger)")
+	to          = flag.String("to", "", "directory, file or URI of the database to copy envelopes to")
+	batchSize   = flag.Int("batch", 1000, "number of envelopes copied at a time")
+	verbosity   = flag.Int("verbosity", int(log.LvlInfo), "log verbosity (0-9)")
+)
+
+func main() {
+	flag.Parse()
+
+	log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(*verbosity), log.StreamHandler(os.Stderr, log.TerminalFormat(false))))
+
+	if len(*from) == 0 || len(*to) == 0 {
+		log.Crit("both `from` and `to` must be provided")
+	}
+	if *batchSize <= 0 {
+		log.Crit("`batch` must be positive")
+	}
+
+	if err := run(); err != nil {
+		log.Crit("failed to copy envelopes", "error", err)
+	}
+}
+
+func run() error {
+	source, err := mailserver.OpenDB(*fromBackend, *from)
+	if err != nil {
+		return fmt.Errorf("open source database: %v", err)
+	}
+	defer source.Close()
+
+	destination, err := mailserver.OpenDB(*toBackend, *to)
+	if err != nil {
+		return fmt.Errorf("open destination database: %v", err)
+	}
+	defer destination.Close()
+
+	log.Info("copying envelopes", "from", *fromBackend, "to", *toBackend)
+	started := time.Now()
+	copied, err := mailserver.CopyEnvelopes(source, destination, *batchSize)
+	if err != nil {
+		return fmt.Errorf("copied %d envelopes: %v", copied, err)
+	}
+	log.Info("envelopes copied", "copied", copied, "duration", time.Since(started))
+	return nil
+}
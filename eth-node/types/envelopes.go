@@ -1,5 +1,10 @@
 package types
 
+// EphemeralEnvelopeTTL is the TTL in seconds of the envelopes only meant for
+// the peers that are online, mailservers don't archive envelopes with a TTL
+// up to it
+const EphemeralEnvelopeTTL = 5
+
 // Envelope represents a clear-text data packet to transmit through the Whisper
 // network. Its contents may or may not be encrypted and signed.
 type Envelope interface {
@@ -0,0 +1,199 @@
+package mailserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/params"
+	wakucommon "github.com/planq-network/status-go/waku/common"
+)
+
+// AdminNamespace is the RPC namespace of the admin API of a mailserver.
+const AdminNamespace = params.MailServerAdminAPIModule
+
+// defaultTopPeersLimit is the number of peers returned by TopPeers by default
+const defaultTopPeersLimit = 10
+
+var (
+	errNoRetention        = errors.New("no retention policy configured, a time must be given")
+	errInvalidBlacklist   = errors.New("blacklist duration must be positive")
+	errAdminTokenRequired = errors.New("admin token is required")
+)
+
+// EnvelopeInfo describes an archived envelope.
+type EnvelopeInfo struct {
+	Hash      types.Hash      `json:"hash"`
+	Topic     types.TopicType `json:"topic"`
+	Timestamp uint32          `json:"timestamp"`
+	Expiry    uint32          `json:"expiry"`
+	TTL       uint32          `json:"ttl"`
+	Size      int             `json:"size"`
+	// Data is the RLP encoded envelope
+	Data types.HexBytes `json:"data"`
+}
+
+// LimitedPeer is a peer whose requests are rate limited.
+type LimitedPeer struct {
+	PeerID      string    `json:"peerId"`
+	LastRequest time.Time `json:"lastRequest"`
+	AllowedAt   time.Time `json:"allowedAt"`
+}
+
+// LimiterState is the state of the requests rate limiter.
+type LimiterState struct {
+	Enabled bool `json:"enabled"`
+	// Interval is the minimum number of seconds between requests of a peer
+	Interval int64         `json:"interval"`
+	Peers    []LimitedPeer `json:"peers"`
+}
+
+// AdminAPI exposes the operations and the state of a running mailserver.
+type AdminAPI struct {
+	ms *mailServer
+}
+
+func NewAdminAPI(s *WakuMailServer) *AdminAPI {
+	return &AdminAPI{ms: s.ms}
+}
+
+// Stats returns the number and size of the archived envelopes by topic and day.
+func (api *AdminAPI) Stats(ctx context.Context) ([]EnvelopesStats, error) {
+	stats, err := api.ms.db.Stats()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Day != stats[j].Day {
+			return stats[i].Day < stats[j].Day
+		}
+		return stats[i].Topic.String() < stats[j].Topic.String()
+	})
+	return stats, nil
+}
+
+// TopPeers returns the peers which sent the most requests during the last day.
+func (api *AdminAPI) TopPeers(ctx context.Context, limit *int) []PeerRequests {
+	n := defaultTopPeersLimit
+	if limit != nil {
+		n = *limit
+	}
+	return api.ms.requests.Top(n, time.Now())
+}
+
+// Limiter returns the peers which are currently rate limited.
+func (api *AdminAPI) Limiter(ctx context.Context) LimiterState {
+	api.ms.muRateLimiter.RLock()
+	defer api.ms.muRateLimiter.RUnlock()
+
+	state := LimiterState{Peers: []LimitedPeer{}}
+	if api.ms.rateLimiter == nil {
+		return state
+	}
+
+	state.Enabled = true
+	state.Interval = int64(api.ms.rateLimiter.lifespan / time.Second)
+	now := time.Now()
+	for id, lastRequest := range api.ms.rateLimiter.Peers() {
+		allowedAt := lastRequest.Add(api.ms.rateLimiter.lifespan)
+		if !allowedAt.After(now) {
+			continue
+		}
+		state.Peers = append(state.Peers, LimitedPeer{
+			PeerID:      id,
+			LastRequest: lastRequest,
+			AllowedAt:   allowedAt,
+		})
+	}
+	sort.Slice(state.Peers, func(i, j int) bool {
+		return state.Peers[i].AllowedAt.Before(state.Peers[j].AllowedAt)
+	})
+	return state
+}
+
+// Prune removes the envelopes sent before a unix time. Without a time, the
+// configured retention policies and quota are applied. It returns the number
+// of envelopes removed.
+func (api *AdminAPI) Prune(ctx context.Context, before *int64) (int, error) {
+	if before == nil {
+		if api.ms.cleaner == nil {
+			return 0, errNoRetention
+		}
+		return api.ms.cleaner.Clean(time.Now())
+	}
+	count, err := api.ms.db.Prune(time.Unix(*before, 0), dbCleanerBatchSize)
+	prunedEnvelopesCounter.WithLabelValues("manual").Add(float64(count))
+	return count, err
+}
+
+// GetEnvelope returns an archived envelope by its hash.
+func (api *AdminAPI) GetEnvelope(ctx context.Context, hash types.Hash) (*EnvelopeInfo, error) {
+	key, rawValue, err := FindEnvelope(api.ms.db, hash, dbCleanerBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	var envelope wakucommon.Envelope
+	if err := rlp.DecodeBytes(rawValue, &envelope); err != nil {
+		return nil, err
+	}
+	return &EnvelopeInfo{
+		Hash:      hash,
+		Topic:     key.Topic(),
+		Timestamp: key.Timestamp(),
+		Expiry:    envelope.Expiry,
+		TTL:       envelope.TTL,
+		Size:      len(rawValue),
+		Data:      rawValue,
+	}, nil
+}
+
+// BlacklistPeer refuses the requests of a peer for a number of seconds.
+func (api *AdminAPI) BlacklistPeer(ctx context.Context, peerID types.Hash, seconds uint32) (time.Time, error) {
+	if seconds == 0 {
+		return time.Time{}, errInvalidBlacklist
+	}
+	until := time.Now().Add(time.Duration(seconds) * time.Second)
+	api.ms.blacklist.Add(peerID.String(), until)
+	return until, nil
+}
+
+// UnblacklistPeer accepts the requests of a blacklisted peer again. It returns
+// false if the peer wasn't blacklisted.
+func (api *AdminAPI) UnblacklistPeer(ctx context.Context, peerID types.Hash) bool {
+	return api.ms.blacklist.Remove(peerID.String())
+}
+
+// BlacklistedPeers returns the peers whose requests are currently refused.
+func (api *AdminAPI) BlacklistedPeers(ctx context.Context) []BlacklistedPeer {
+	return api.ms.blacklist.List(time.Now())
+}
+
+// NewAdminHandler returns an HTTP handler serving the admin API to the clients
+// sending the token as a bearer token.
+func NewAdminHandler(api *AdminAPI, token string) (http.Handler, error) {
+	if len(token) == 0 {
+		return nil, errAdminTokenRequired
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName(AdminNamespace, api); err != nil {
+		return nil, err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}), nil
+}
@@ -0,0 +1,152 @@
+package mailserver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+func TestAdminAPIStatsAndGetEnvelope(t *testing.T) {
+	now := time.Now()
+	server := setupTestServer(t)
+	defer server.Close()
+	api := NewAdminAPI(server)
+
+	env := archiveEnvelope(t, now.Add(-2*time.Second), server)
+	archiveEnvelope(t, now.Add(-1*time.Second), server)
+
+	stats, err := api.Stats(context.Background())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, types.TopicType(env.Topic), stats[0].Topic)
+	require.Equal(t, 2, stats[0].Count)
+
+	info, err := api.GetEnvelope(context.Background(), types.Hash(env.Hash()))
+	require.NoError(t, err)
+	require.Equal(t, types.Hash(env.Hash()), info.Hash)
+	require.Equal(t, types.TopicType(env.Topic), info.Topic)
+	require.Equal(t, env.Expiry-env.TTL, info.Timestamp)
+	require.Equal(t, env.TTL, info.TTL)
+
+	_, err = api.GetEnvelope(context.Background(), types.Hash{0x01})
+	require.Equal(t, errEnvelopeNotFound, err)
+}
+
+func TestAdminAPIPrune(t *testing.T) {
+	now := time.Now()
+	server := setupTestServer(t)
+	defer server.Close()
+	api := NewAdminAPI(server)
+
+	archiveEnvelope(t, now.Add(-2*time.Hour), server)
+	archiveEnvelope(t, now.Add(-1*time.Second), server)
+
+	_, err := api.Prune(context.Background(), nil)
+	require.Equal(t, errNoRetention, err)
+
+	before := now.Add(-time.Hour).Unix()
+	removed, err := api.Prune(context.Background(), &before)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	testMessagesCount(t, 1, server)
+
+	server.ms.cleaner = newDBCleaner(server.ms.db, time.Millisecond)
+	removed, err = api.Prune(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	testMessagesCount(t, 0, server)
+}
+
+func TestAdminAPIBlacklist(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	api := NewAdminAPI(server)
+	peerID := types.BytesToHash([]byte("peerID"))
+
+	_, err := api.BlacklistPeer(context.Background(), peerID, 0)
+	require.Equal(t, errInvalidBlacklist, err)
+
+	until, err := api.BlacklistPeer(context.Background(), peerID, 60)
+	require.NoError(t, err)
+	require.True(t, until.After(time.Now()))
+
+	require.Equal(t, errPeerBlacklisted, server.ms.SyncMail(peerID, MessagesRequestPayload{}))
+	blacklisted := api.BlacklistedPeers(context.Background())
+	require.Len(t, blacklisted, 1)
+	require.Equal(t, peerID.String(), blacklisted[0].PeerID)
+
+	top := api.TopPeers(context.Background(), nil)
+	require.Len(t, top, 1)
+	require.Equal(t, peerID.String(), top[0].PeerID)
+	require.Equal(t, 1, top[0].Requests)
+
+	require.True(t, api.UnblacklistPeer(context.Background(), peerID))
+	require.False(t, api.UnblacklistPeer(context.Background(), peerID))
+	require.Empty(t, api.BlacklistedPeers(context.Background()))
+	require.False(t, server.ms.isBlacklisted(peerID))
+}
+
+func TestAdminAPILimiter(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	api := NewAdminAPI(server)
+	peerID := types.BytesToHash([]byte("peerID"))
+
+	require.False(t, api.Limiter(context.Background()).Enabled)
+
+	server.ms.rateLimiter = newRateLimiter(time.Minute)
+	require.False(t, server.ms.exceedsPeerRequests(peerID))
+
+	state := api.Limiter(context.Background())
+	require.True(t, state.Enabled)
+	require.Equal(t, int64(60), state.Interval)
+	require.Len(t, state.Peers, 1)
+	require.Equal(t, peerID.String(), state.Peers[0].PeerID)
+}
+
+func TestAdminHandler(t *testing.T) {
+	server := setupTestServer(t)
+	defer server.Close()
+	api := NewAdminAPI(server)
+
+	_, err := NewAdminHandler(api, "")
+	require.Equal(t, errAdminTokenRequired, err)
+
+	handler, err := NewAdminHandler(api, "secret")
+	require.NoError(t, err)
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	call := func(token string) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(
+			`{"jsonrpc":"2.0","id":1,"method":"mailserveradmin_blacklistedPeers","params":[]}`,
+		))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, _ := call("")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, _ = call("wrong")
+	require.Equal(t, http.StatusUnauthorized, status)
+	status, body := call("secret")
+	require.Equal(t, http.StatusOK, status)
+	require.Contains(t, body, `"result":[]`)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/planq-network/status-go/eth-node/types"
 )
 
 const (
@@ -18,7 +20,12 @@ type dbCleaner struct {
 
 	db        DB
 	batchSize int
+	// retention is how long envelopes are kept, forever if 0
 	retention time.Duration
+	// topicRetention overrides the retention of some topics
+	topicRetention map[types.TopicType]time.Duration
+	// quota is the maximum size in bytes of the envelopes kept, unlimited if 0
+	quota int64
 
 	period time.Duration
 	cancel chan struct{}
@@ -37,7 +44,7 @@ func newDBCleaner(db DB, retention time.Duration) *dbCleaner {
 
 // Start starts a loop that cleans up old messages.
 func (c *dbCleaner) Start() {
-	log.Info("Starting cleaning envelopes", "period", c.period, "retention", c.retention)
+	log.Info("Starting cleaning envelopes", "period", c.period, "retention", c.retention, "quota", c.quota)
 
 	cancel := make(chan struct{})
 
@@ -67,7 +74,7 @@ func (c *dbCleaner) schedule(period time.Duration, cancel <-chan struct{}) {
 	for {
 		select {
 		case <-t.C:
-			count, err := c.PruneEntriesOlderThan(time.Now().Add(-c.retention))
+			count, err := c.Clean(time.Now())
 			if err != nil {
 				log.Error("failed to prune data", "err", err)
 			}
@@ -83,3 +90,86 @@ func (c *dbCleaner) schedule(period time.Duration, cancel <-chan struct{}) {
 func (c *dbCleaner) PruneEntriesOlderThan(t time.Time) (int, error) {
 	return c.db.Prune(t, c.batchSize)
 }
+
+// Clean removes the messages past their retention period and the oldest ones
+// exceeding the quota, and updates the topic metrics. It returns how many have
+// been removed.
+func (c *dbCleaner) Clean(now time.Time) (int, error) {
+	if len(c.topicRetention) == 0 && c.quota == 0 {
+		count, err := c.PruneEntriesOlderThan(now.Add(-c.retention))
+		prunedEnvelopesCounter.WithLabelValues("retention").Add(float64(count))
+		if err != nil {
+			return count, err
+		}
+		stats, err := c.db.Stats()
+		if err != nil {
+			return count, err
+		}
+		updateTopicMetrics(stats)
+		return count, nil
+	}
+
+	count, err := c.db.PruneExpired(c.pruneCutoffs(now), c.batchSize)
+	prunedEnvelopesCounter.WithLabelValues("retention").Add(float64(count))
+	if err != nil {
+		return count, err
+	}
+
+	stats, err := c.db.Stats()
+	if err != nil {
+		return count, err
+	}
+	if size := envelopesSize(stats); c.quota > 0 && size > c.quota {
+		removed, err := c.db.PruneOldest(size-c.quota, c.batchSize)
+		prunedEnvelopesCounter.WithLabelValues("quota").Add(float64(removed))
+		count += removed
+		if err != nil {
+			return count, err
+		}
+		// the stats are only scanned again when the quota has been exceeded
+		if stats, err = c.db.Stats(); err != nil {
+			return count, err
+		}
+	}
+	updateTopicMetrics(stats)
+
+	return count, nil
+}
+
+// pruneCutoffs returns the times before which the messages of each topic are
+// past their retention period
+func (c *dbCleaner) pruneCutoffs(now time.Time) PruneCutoffs {
+	cutoff := func(retention time.Duration) time.Time {
+		if retention == 0 {
+			return time.Time{}
+		}
+		return now.Add(-retention)
+	}
+
+	cutoffs := PruneCutoffs{
+		Default: cutoff(c.retention),
+		Topics:  make(map[types.TopicType]time.Time, len(c.topicRetention)),
+	}
+	for topic, retention := range c.topicRetention {
+		cutoffs.Topics[topic] = cutoff(retention)
+	}
+	return cutoffs
+}
+
+func envelopesSize(stats []EnvelopesStats) int64 {
+	var size int64
+	for _, s := range stats {
+		size += s.Size
+	}
+	return size
+}
+
+// updateTopicMetrics sets the number and size of the envelopes stored by topic
+func updateTopicMetrics(stats []EnvelopesStats) {
+	topicArchivedEnvelopesGauge.Reset()
+	topicArchivedEnvelopesSizeGauge.Reset()
+	for _, s := range stats {
+		topicArchivedEnvelopesGauge.WithLabelValues(s.Topic.String()).Add(float64(s.Count))
+		topicArchivedEnvelopesSizeGauge.WithLabelValues(s.Topic.String()).Add(float64(s.Size))
+	}
+}
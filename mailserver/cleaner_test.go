@@ -5,10 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/storage"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/planq-network/status-go/eth-node/types"
@@ -53,6 +56,98 @@ func TestCleanerSchedule(t *testing.T) {
 	testMessagesCount(t, 1, server)
 }
 
+func TestCleanerTopicRetention(t *testing.T) {
+	now := time.Now()
+	server := setupTestServer(t)
+	defer server.Close()
+
+	defaultTopic := types.TopicType{0x1F, 0x7E, 0xA1, 0x7F}
+	keptTopic := types.TopicType{0x01, 0x02, 0x03, 0x04}
+	otherTopic := types.TopicType{0x05, 0x06, 0x07, 0x08}
+
+	cleaner := newDBCleaner(server.ms.db, time.Hour)
+	cleaner.topicRetention = map[types.TopicType]time.Duration{
+		defaultTopic: 3 * time.Hour,
+		keptTopic:    0,
+	}
+
+	archiveEnvelope(t, now.Add(-5*time.Hour), server)
+	archiveEnvelope(t, now.Add(-2*time.Hour), server)
+	archiveTopicEnvelope(t, now.Add(-5*time.Hour), keptTopic, server)
+	archiveTopicEnvelope(t, now.Add(-2*time.Hour), otherTopic, server)
+	archiveTopicEnvelope(t, now.Add(-30*time.Minute), otherTopic, server)
+
+	removed, err := cleaner.Clean(now)
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+	testMessagesCount(t, 3, server)
+
+	require.Equal(t, float64(1), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(defaultTopic.String())))
+	require.Equal(t, float64(1), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(keptTopic.String())))
+	require.Equal(t, float64(1), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(otherTopic.String())))
+}
+
+func TestCleanerRetentionMetrics(t *testing.T) {
+	now := time.Now()
+	server := setupTestServer(t)
+	defer server.Close()
+
+	defaultTopic := types.TopicType{0x1F, 0x7E, 0xA1, 0x7F}
+	otherTopic := types.TopicType{0x05, 0x06, 0x07, 0x08}
+
+	// only the global retention is configured
+	cleaner := newDBCleaner(server.ms.db, time.Hour)
+
+	archiveEnvelope(t, now.Add(-2*time.Hour), server)
+	archiveEnvelope(t, now.Add(-30*time.Minute), server)
+	archiveTopicEnvelope(t, now.Add(-30*time.Minute), otherTopic, server)
+
+	removed, err := cleaner.Clean(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+
+	stats, err := server.ms.db.Stats()
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	for _, s := range stats {
+		require.Equal(t, float64(s.Count), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(s.Topic.String())))
+		require.Equal(t, float64(s.Size), gaugeValue(t, topicArchivedEnvelopesSizeGauge.WithLabelValues(s.Topic.String())))
+	}
+	require.Equal(t, float64(1), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(defaultTopic.String())))
+	require.Equal(t, float64(1), gaugeValue(t, topicArchivedEnvelopesGauge.WithLabelValues(otherTopic.String())))
+}
+
+func TestCleanerQuota(t *testing.T) {
+	now := time.Now()
+	server := setupTestServer(t)
+	defer server.Close()
+
+	oldest := archiveEnvelope(t, now.Add(-3*time.Hour), server)
+	archiveEnvelope(t, now.Add(-2*time.Hour), server)
+	archiveEnvelope(t, now.Add(-1*time.Hour), server)
+
+	stats, err := server.ms.db.Stats()
+	require.NoError(t, err)
+	size := envelopesSize(stats)
+
+	cleaner := newDBCleaner(server.ms.db, 0)
+	cleaner.quota = size - 1
+
+	removed, err := cleaner.Clean(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	testMessagesCount(t, 2, server)
+
+	key := NewDBKey(oldest.Expiry-oldest.TTL, types.TopicType(oldest.Topic), types.Hash(oldest.Hash()))
+	_, err = server.ms.db.GetEnvelope(key)
+	require.Error(t, err)
+
+	// below the quota, nothing is removed
+	removed, err = cleaner.Clean(now)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+}
+
 func benchmarkCleanerPrune(b *testing.B, messages int, batchSize int) {
 	t := &testing.T{}
 	now := time.Now()
@@ -97,7 +192,9 @@ func setupTestServer(t *testing.T) *WakuMailServer {
 			ldb:  db,
 			done: make(chan struct{}),
 		},
-		adapter: &wakuAdapter{},
+		adapter:   &wakuAdapter{},
+		requests:  newPeerRequests(peerRequestsWindow),
+		blacklist: newPeerBlacklist(),
 	}
 	s.minRequestPoW = powRequirement
 	return &s
@@ -111,6 +208,30 @@ func archiveEnvelope(t *testing.T, sentTime time.Time, server *WakuMailServer) *
 	return env
 }
 
+func archiveTopicEnvelope(t *testing.T, sentTime time.Time, topic types.TopicType, server *WakuMailServer) *waku.Envelope {
+	h := crypto.Keccak256Hash([]byte("test sample data"))
+	params := &waku.MessageParams{
+		Topic:    waku.TopicType(topic),
+		Payload:  testPayload,
+		PoW:      powRequirement,
+		WorkTime: 2,
+		KeySym:   h[:],
+	}
+	msg, err := waku.NewSentMessage(params)
+	require.NoError(t, err)
+	env, err := msg.Wrap(params, sentTime)
+	require.NoError(t, err)
+	server.Archive(env)
+
+	return env
+}
+
+func gaugeValue(t *testing.T, gauge prometheus.Gauge) float64 {
+	var metric dto.Metric
+	require.NoError(t, gauge.Write(&metric))
+	return metric.GetGauge().GetValue()
+}
+
 func testPrune(t *testing.T, u time.Time, expected int, c *dbCleaner) {
 	n, err := c.PruneEntriesOlderThan(u)
 	require.NoError(t, err)
@@ -29,6 +29,10 @@ func (k *DBKey) Bytes() []byte {
 	return k.raw
 }
 
+func (k *DBKey) Timestamp() uint32 {
+	return binary.BigEndian.Uint32(k.raw[:timestampLength])
+}
+
 func (k *DBKey) Topic() types.TopicType {
 	return types.BytesToTopic(k.raw[timestampLength+types.HashLength:])
 }
@@ -86,3 +86,15 @@ func (l *rateLimiter) deleteExpired() {
 		}
 	}
 }
+
+// Peers returns the peers which are limited and when they sent their last request.
+func (l *rateLimiter) Peers() map[string]time.Time {
+	l.RLock()
+	defer l.RUnlock()
+
+	peers := make(map[string]time.Time, len(l.db))
+	for id, lastRequestTime := range l.db {
+		peers[id] = lastRequestTime
+	}
+	return peers
+}
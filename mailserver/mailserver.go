@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -49,6 +50,9 @@ const (
 var (
 	errDirectoryNotProvided        = errors.New("data directory not provided")
 	errDecryptionMethodNotProvided = errors.New("decryption method is not provided")
+	errEnvelopeNotFound            = errors.New("envelope not found")
+	errPeerBlacklisted             = errors.New("peer is blacklisted")
+	errNegativeRetention           = errors.New("data retention can't be negative")
 )
 
 const (
@@ -58,6 +62,13 @@ const (
 	processRequestTimeout  = time.Minute
 )
 
+const (
+	// sqliteDBName is the name of the SQLite database in the data directory.
+	sqliteDBName = "envelopes.sqlite"
+	// badgerDBName is the name of the Badger directory in the data directory.
+	badgerDBName = "badger"
+)
+
 type Config struct {
 	// DataDir points to a directory where mailserver's data is stored.
 	DataDir string
@@ -70,9 +81,23 @@ type Config struct {
 	// RateLimit is a maximum number of requests per second from a peer.
 	RateLimit int
 	// DataRetention specifies a number of days an envelope should be stored for.
-	DataRetention   int
+	DataRetention int
+	// TopicRetention overrides DataRetention for some topics, 0 keeps envelopes forever.
+	TopicRetention map[types.TopicType]int
+	// StorageQuota is a maximum size in bytes of the stored envelopes, the oldest are
+	// removed above it.
+	StorageQuota    int64
 	PostgresEnabled bool
 	PostgresURI     string
+	// SQLiteEnabled stores envelopes in an SQLite database in DataDir.
+	SQLiteEnabled bool
+	// BadgerEnabled stores envelopes in a Badger database in DataDir.
+	BadgerEnabled bool
+	// ReplicationPeers are the enode URLs of the mail servers the missing envelopes
+	// are pulled from.
+	ReplicationPeers []string
+	// ReplicationTopics are the topics pulled from ReplicationPeers, all if empty.
+	ReplicationTopics []types.TopicType
 }
 
 // --------------
@@ -86,6 +111,8 @@ type WakuMailServer struct {
 
 	symFilter  *wakucommon.Filter
 	asymFilter *wakucommon.Filter
+
+	replicator *replicator // pulls missing envelopes from other mail servers
 }
 
 func (s *WakuMailServer) Init(waku *waku.Waku, cfg *params.WakuConfig) error {
@@ -97,9 +124,16 @@ func (s *WakuMailServer) Init(waku *waku.Waku, cfg *params.WakuConfig) error {
 		Password:        cfg.MailServerPassword,
 		MinimumPoW:      cfg.MinimumPoW,
 		DataRetention:   cfg.MailServerDataRetention,
+		TopicRetention:  cfg.MailServerTopicRetention,
+		StorageQuota:    cfg.MailServerStorageQuota,
 		RateLimit:       cfg.MailServerRateLimit,
 		PostgresEnabled: cfg.DatabaseConfig.PGConfig.Enabled,
 		PostgresURI:     cfg.DatabaseConfig.PGConfig.URI,
+		SQLiteEnabled:   cfg.DatabaseConfig.SQLiteConfig.Enabled,
+		BadgerEnabled:   cfg.DatabaseConfig.BadgerConfig.Enabled,
+
+		ReplicationPeers:  cfg.MailServerReplicationPeers,
+		ReplicationTopics: cfg.MailServerReplicationTopics,
 	}
 	var err error
 	s.ms, err = newMailServer(
@@ -115,13 +149,56 @@ func (s *WakuMailServer) Init(waku *waku.Waku, cfg *params.WakuConfig) error {
 		return err
 	}
 
+	if len(config.ReplicationPeers) > 0 {
+		if err := s.setupReplicator(config); err != nil {
+			return fmt.Errorf("setup replication: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *WakuMailServer) setupReplicator(config Config) error {
+	peers, err := parseReplicationPeers(config.ReplicationPeers)
+	if err != nil {
+		return err
+	}
+	history := defaultReplicationHistory
+	if config.DataRetention > 0 {
+		history = time.Duration(config.DataRetention) * time.Hour * 24
+	}
+	s.replicator, err = newReplicator(
+		s.ms.db,
+		s.shh,
+		peers,
+		config.ReplicationTopics,
+		history,
+		filepath.Join(config.DataDir, replicationStateFileName),
+	)
+	if err != nil {
+		return err
+	}
+	s.replicator.interval = time.Duration(config.RateLimit) * time.Second
+	s.replicator.Start()
 	return nil
 }
 
 func (s *WakuMailServer) Close() {
+	if s.replicator != nil {
+		s.replicator.Stop()
+	}
 	s.ms.Close()
 }
 
+// Replicate archives the envelopes sent by a mail server in response to
+// a replication request.
+func (s *WakuMailServer) Replicate(peerID []byte, envelopes []*wakucommon.Envelope) bool {
+	if s.replicator == nil {
+		return false
+	}
+	return s.replicator.Replicate(peerID, envelopes)
+}
+
 func (s *WakuMailServer) Archive(env *wakucommon.Envelope) {
 	s.ms.Archive(gethbridge.NewWakuEnvelope(env))
 }
@@ -375,6 +452,8 @@ type mailServer struct {
 	cleaner       *dbCleaner // removes old envelopes
 	muRateLimiter sync.RWMutex
 	rateLimiter   *rateLimiter
+	requests      *peerRequests  // counts requests by peer
+	blacklist     *peerBlacklist // refuses requests of peers for a while
 }
 
 func newMailServer(cfg Config, adapter adapter, service service) (*mailServer, error) {
@@ -387,9 +466,20 @@ func newMailServer(cfg Config, adapter adapter, service service) (*mailServer, e
 		return nil, errDecryptionMethodNotProvided
 	}
 
+	if cfg.DataRetention < 0 {
+		return nil, errNegativeRetention
+	}
+	for _, days := range cfg.TopicRetention {
+		if days < 0 {
+			return nil, errNegativeRetention
+		}
+	}
+
 	s := mailServer{
-		adapter: adapter,
-		service: service,
+		adapter:   adapter,
+		service:   service,
+		requests:  newPeerRequests(peerRequestsWindow),
+		blacklist: newPeerBlacklist(),
 	}
 
 	if cfg.RateLimit > 0 {
@@ -406,6 +496,18 @@ func newMailServer(cfg Config, adapter adapter, service service) (*mailServer, e
 		}
 		s.db = database
 		log.Info("Connected to postgres database")
+	} else if cfg.SQLiteEnabled {
+		database, err := NewSQLiteDB(filepath.Join(cfg.DataDir, sqliteDBName))
+		if err != nil {
+			return nil, fmt.Errorf("open DB: %s", err)
+		}
+		s.db = database
+	} else if cfg.BadgerEnabled {
+		database, err := NewBadgerDB(filepath.Join(cfg.DataDir, badgerDBName))
+		if err != nil {
+			return nil, fmt.Errorf("open DB: %s", err)
+		}
+		s.db = database
 	} else {
 		// Defaults to LevelDB
 		database, err := NewLevelDB(cfg.DataDir)
@@ -415,9 +517,9 @@ func newMailServer(cfg Config, adapter adapter, service service) (*mailServer, e
 		s.db = database
 	}
 
-	if cfg.DataRetention > 0 {
+	if cfg.DataRetention > 0 || len(cfg.TopicRetention) > 0 || cfg.StorageQuota > 0 {
 		// MailServerDataRetention is a number of days.
-		s.setupCleaner(time.Duration(cfg.DataRetention) * time.Hour * 24)
+		s.setupCleaner(time.Duration(cfg.DataRetention)*time.Hour*24, cfg.TopicRetention, cfg.StorageQuota)
 	}
 
 	return &s, nil
@@ -430,12 +532,23 @@ func (s *mailServer) setupRateLimiter(limit time.Duration) {
 	s.rateLimiter.Start()
 }
 
-func (s *mailServer) setupCleaner(retention time.Duration) {
+func (s *mailServer) setupCleaner(retention time.Duration, topicRetention map[types.TopicType]int, quota int64) {
 	s.cleaner = newDBCleaner(s.db, retention)
+	if len(topicRetention) > 0 {
+		s.cleaner.topicRetention = make(map[types.TopicType]time.Duration, len(topicRetention))
+		for topic, days := range topicRetention {
+			s.cleaner.topicRetention[topic] = time.Duration(days) * time.Hour * 24
+		}
+	}
+	s.cleaner.quota = quota
 	s.cleaner.Start()
 }
 
 func (s *mailServer) Archive(env types.Envelope) {
+	if env.TTL() <= types.EphemeralEnvelopeTTL {
+		return
+	}
+
 	err := s.db.SaveEnvelope(env)
 	if err != nil {
 		log.Error("Could not save envelope", "hash", env.Hash().String())
@@ -480,6 +593,17 @@ func (s *mailServer) DeliverMail(peerID, reqID types.Hash, req MessagesRequestPa
 		return
 	}
 
+	if s.isBlacklisted(peerID) {
+		deliveryFailuresCounter.WithLabelValues("peer_blacklisted").Inc()
+		log.Error(
+			"[mailserver:DeliverMail] peer is blacklisted",
+			"peerID", peerID.String(),
+			"requestID", reqID.String(),
+		)
+		s.sendHistoricMessageErrorResponse(peerID, reqID, errPeerBlacklisted)
+		return
+	}
+
 	if s.exceedsPeerRequests(peerID) {
 		deliveryFailuresCounter.WithLabelValues("peer_req_limit").Inc()
 		log.Error(
@@ -585,6 +709,12 @@ func (s *mailServer) SyncMail(peerID types.Hash, req MessagesRequestPayload) err
 
 	syncAttemptsCounter.Inc()
 
+	if s.isBlacklisted(peerID) {
+		syncFailuresCounter.WithLabelValues("peer_blacklisted").Inc()
+		log.Error("Peer is blacklisted", "peerID", peerID.String())
+		return errPeerBlacklisted
+	}
+
 	// Check rate limiting for a requesting peer.
 	if s.exceedsPeerRequests(peerID) {
 		syncFailuresCounter.WithLabelValues("req_per_sec_limit").Inc()
@@ -682,6 +812,15 @@ func (s *mailServer) Close() {
 	}
 }
 
+// isBlacklisted counts the request of a peer and checks if it's blacklisted.
+func (s *mailServer) isBlacklisted(peerID types.Hash) bool {
+	now := time.Now()
+	if s.requests != nil {
+		s.requests.Add(peerID.String(), now)
+	}
+	return s.blacklist != nil && s.blacklist.IsBlacklisted(peerID.String(), now)
+}
+
 func (s *mailServer) exceedsPeerRequests(peerID types.Hash) bool {
 	s.muRateLimiter.RLock()
 	defer s.muRateLimiter.RUnlock()
@@ -1,14 +1,35 @@
 package mailserver
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rlp"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
 	"github.com/planq-network/status-go/eth-node/types"
+	wakucommon "github.com/planq-network/status-go/waku/common"
 )
 
 // every this many seconds check real envelopes count
 const envelopeCountCheckInterval = 60
 
+const secondsPerDay = 24 * 60 * 60
+
+// Backends envelopes can be stored in.
+const (
+	LevelDBBackend  = "leveldb"
+	PostgresBackend = "postgres"
+	SQLiteBackend   = "sqlite"
+	BadgerBackend   = "badger"
+)
+
+// ErrBadgerNotBuilt is returned when opening a Badger database in a build
+// without the badger tag.
+var ErrBadgerNotBuilt = errors.New("badger backend requires building with the badger tag")
+
 // DB is an interface to abstract interactions with the db so that the mailserver
 // is agnostic to the underlying technology used
 type DB interface {
@@ -19,6 +40,12 @@ type DB interface {
 	GetEnvelope(*DBKey) ([]byte, error)
 	// Prune removes envelopes older than time
 	Prune(time.Time, int) (int, error)
+	// PruneExpired removes envelopes older than the cutoff of their topic
+	PruneExpired(PruneCutoffs, int) (int, error)
+	// PruneOldest removes the oldest envelopes until at least size bytes are removed
+	PruneOldest(size int64, batchSize int) (int, error)
+	// Stats returns the number and size of the envelopes stored by topic and day
+	Stats() ([]EnvelopesStats, error)
 	// BuildIterator returns an iterator over envelopes
 	BuildIterator(query CursorQuery) (Iterator, error)
 }
@@ -32,6 +59,78 @@ type Iterator interface {
 	GetEnvelopeByTopicsMap(topics map[types.TopicType]bool) ([]byte, error)
 }
 
+// EnvelopesStats are the number and size of the envelopes of a topic sent on a day
+type EnvelopesStats struct {
+	Topic types.TopicType `json:"topic"`
+	// Day is the unix time of the start of the day in UTC
+	Day   uint32 `json:"day"`
+	Count int    `json:"count"`
+	Size  int64  `json:"size"`
+}
+
+// statsDay returns the start of the day of a timestamp
+func statsDay(timestamp uint32) uint32 {
+	return timestamp - timestamp%secondsPerDay
+}
+
+type statsKey struct {
+	topic types.TopicType
+	day   uint32
+}
+
+// addStats adds an envelope to the stats of its topic and day
+func addStats(stats map[statsKey]*EnvelopesStats, key *DBKey, size int64) {
+	k := statsKey{topic: key.Topic(), day: statsDay(key.Timestamp())}
+	if stats[k] == nil {
+		stats[k] = &EnvelopesStats{Topic: k.topic, Day: k.day}
+	}
+	stats[k].Count++
+	stats[k].Size += size
+}
+
+func statsList(stats map[statsKey]*EnvelopesStats) []EnvelopesStats {
+	rst := make([]EnvelopesStats, 0, len(stats))
+	for _, s := range stats {
+		rst = append(rst, *s)
+	}
+	return rst
+}
+
+// PruneCutoffs are the times before which envelopes are removed, the zero
+// time keeps envelopes forever
+type PruneCutoffs struct {
+	// Default applies to the topics not in Topics
+	Default time.Time
+	Topics  map[types.TopicType]time.Time
+}
+
+// cutoff returns the cutoff of a topic
+func (c PruneCutoffs) cutoff(topic types.TopicType) time.Time {
+	if t, ok := c.Topics[topic]; ok {
+		return t
+	}
+	return c.Default
+}
+
+// Expired returns whether the envelope of a key is older than the cutoff of
+// its topic
+func (c PruneCutoffs) Expired(key *DBKey) bool {
+	cutoff := c.cutoff(key.Topic())
+	return !cutoff.IsZero() && int64(key.Timestamp()) < cutoff.Unix()
+}
+
+// Latest returns the latest of the cutoffs, envelopes sent after it are
+// never expired
+func (c PruneCutoffs) Latest() time.Time {
+	latest := c.Default
+	for _, t := range c.Topics {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
 type CursorQuery struct {
 	start  []byte
 	end    []byte
@@ -40,3 +139,137 @@ type CursorQuery struct {
 	bloom  []byte
 	topics [][]byte
 }
+
+// OpenDB opens the envelopes store of a backend. The location is a directory
+// for LevelDB and Badger, a file for SQLite and a URI for Postgres.
+func OpenDB(backend, location string) (DB, error) {
+	var (
+		db  DB
+		err error
+	)
+	switch backend {
+	case LevelDBBackend:
+		db, err = NewLevelDB(location)
+	case PostgresBackend:
+		db, err = NewPostgresDB(location)
+	case SQLiteBackend:
+		db, err = NewSQLiteDB(location)
+	case BadgerBackend:
+		db, err = NewBadgerDB(location)
+	default:
+		return nil, fmt.Errorf("unknown database backend: %s", backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// CopyEnvelopes copies all envelopes from one store to another, batchSize
+// envelopes at a time. It returns the number of envelopes copied.
+func CopyEnvelopes(from, to DB, batchSize int) (int, error) {
+	copied := 0
+	err := forEachEnvelope(from, batchSize, func(_ *DBKey, rawValue []byte) (bool, error) {
+		var envelope wakucommon.Envelope
+		if err := rlp.DecodeBytes(rawValue, &envelope); err != nil {
+			return false, err
+		}
+		if err := to.SaveEnvelope(gethbridge.NewWakuEnvelope(&envelope)); err != nil {
+			return false, err
+		}
+		copied++
+		return true, nil
+	})
+	return copied, err
+}
+
+// FindEnvelope returns the key and the envelope of a hash. The keys are sorted
+// by timestamp so all envelopes may need to be visited.
+func FindEnvelope(db DB, hash types.Hash, batchSize int) (*DBKey, []byte, error) {
+	var (
+		key      *DBKey
+		envelope []byte
+	)
+	err := forEachEnvelope(db, batchSize, func(k *DBKey, rawValue []byte) (bool, error) {
+		if k.EnvelopeHash() != hash {
+			return true, nil
+		}
+		// the key and the value are only valid until the iterator is released
+		key = &DBKey{raw: append([]byte(nil), k.Bytes()...)}
+		envelope = append([]byte(nil), rawValue...)
+		return false, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if key == nil {
+		return nil, nil, errEnvelopeNotFound
+	}
+	return key, envelope, nil
+}
+
+// forEachEnvelope calls fn with every envelope of a store, batchSize envelopes
+// are read at a time. The iteration stops when fn returns false or an error.
+func forEachEnvelope(db DB, batchSize int, fn func(key *DBKey, rawValue []byte) (bool, error)) error {
+	query := CursorQuery{
+		start: NewDBKey(0, types.TopicType{}, types.Hash{}).Bytes(),
+		end:   bytes.Repeat([]byte{0xff}, DBKeyLength),
+		bloom: types.MakeFullNodeBloom(),
+		limit: uint32(batchSize),
+	}
+
+	for {
+		cursor, err := forEachEnvelopeInBatch(db, query, batchSize, fn)
+		if err != nil || cursor == nil {
+			return err
+		}
+		query.cursor = cursor
+	}
+}
+
+// forEachEnvelopeInBatch calls fn with up to batchSize envelopes, it returns
+// the cursor of the next batch or nil if there are no envelopes left
+func forEachEnvelopeInBatch(db DB, query CursorQuery, batchSize int, fn func(key *DBKey, rawValue []byte) (bool, error)) ([]byte, error) {
+	iter, err := db.BuildIterator(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = iter.Release() }()
+
+	visited := 0
+	for iter.Next() {
+		rawValue, err := iter.GetEnvelopeByBloomFilter(query.bloom)
+		if err != nil {
+			return nil, err
+		}
+		if rawValue == nil {
+			continue
+		}
+		key, err := iter.DBKey()
+		if err != nil {
+			return nil, err
+		}
+		next, err := fn(key, rawValue)
+		if err != nil || !next {
+			return nil, err
+		}
+		visited++
+
+		if visited == batchSize {
+			// the key is only valid until the iterator is released
+			return append([]byte(nil), key.Cursor()...), nil
+		}
+	}
+	return nil, iter.Error()
+}
+
+// cursorLimit returns the greatest key of the envelope a cursor points to.
+// Iterations with a cursor continue with the keys after it.
+func cursorLimit(cursor []byte) []byte {
+	limit := make([]byte, DBKeyLength)
+	copy(limit, cursor)
+	for i := CursorLength; i < DBKeyLength; i++ {
+		limit[i] = 0xff
+	}
+	return limit
+}
@@ -0,0 +1,382 @@
+//go:build badger
+// +build badger
+
+package mailserver
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	waku "github.com/planq-network/status-go/waku/common"
+)
+
+// valueLogGCDiscardRatio is the ratio of stale data a value log file needs
+// to be rewritten by the garbage collection
+const valueLogGCDiscardRatio = 0.5
+
+// Badger keeps up to 5 memtables of 64MB by default, a mailserver writes
+// envelopes at a far lower rate, so fewer and smaller memtables are flushed
+// often enough while keeping the memory used under 50MB.
+const (
+	badgerNumMemtables = 2
+	badgerMaxTableSize = 16 << 20
+)
+
+// BadgerDB stores envelopes in a Badger database using the same keys as
+// LevelDB.
+type BadgerDB struct {
+	bdb  *badger.DB
+	name string
+	done chan struct{}
+}
+
+type BadgerIterator struct {
+	txn   *badger.Txn
+	it    *badger.Iterator
+	seek  []byte
+	end   []byte
+	skip  []byte
+	moved bool
+	key   []byte
+	value []byte
+	err   error
+}
+
+func (i *BadgerIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	if i.moved {
+		i.it.Next()
+	} else {
+		i.it.Seek(i.seek)
+		i.moved = true
+	}
+	// the envelope of the cursor has been returned already
+	if i.skip != nil && i.it.Valid() && bytes.Equal(i.it.Item().Key(), i.skip) {
+		i.it.Next()
+	}
+	if !i.it.Valid() {
+		return false
+	}
+
+	item := i.it.Item()
+	if len(i.end) > 0 && bytes.Compare(item.Key(), i.end) >= 0 {
+		return false
+	}
+	i.key = item.KeyCopy(nil)
+	i.value, i.err = item.ValueCopy(nil)
+	return i.err == nil
+}
+
+func (i *BadgerIterator) DBKey() (*DBKey, error) {
+	return &DBKey{
+		raw: i.key,
+	}, nil
+}
+
+func (i *BadgerIterator) GetEnvelopeByTopicsMap(topics map[types.TopicType]bool) ([]byte, error) {
+	key, err := i.DBKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if !topics[key.Topic()] {
+		return nil, nil
+	}
+
+	return i.value, nil
+}
+
+func (i *BadgerIterator) GetEnvelopeByBloomFilter(bloom []byte) ([]byte, error) {
+	key, err := i.DBKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if !types.BloomFilterMatch(bloom, types.TopicToBloom(key.Topic())) {
+		return nil, nil
+	}
+	return i.value, nil
+}
+
+func (i *BadgerIterator) Error() error {
+	return i.err
+}
+
+func (i *BadgerIterator) Release() error {
+	i.it.Close()
+	i.txn.Discard()
+	return nil
+}
+
+func NewBadgerDB(dataDir string) (*BadgerDB, error) {
+	opts := badger.DefaultOptions(dataDir).
+		WithLogger(badgerLogger{}).
+		WithNumMemtables(badgerNumMemtables).
+		WithMaxTableSize(badgerMaxTableSize).
+		// envelopes are encrypted, they don't compress
+		WithCompression(options.None)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &BadgerDB{
+		bdb:  db,
+		name: dataDir, // name is used for metrics labels
+		done: make(chan struct{}),
+	}
+
+	// initialize the metric value
+	instance.updateArchivedEnvelopesCount()
+	// checking count on every insert is inefficient
+	go func() {
+		for {
+			select {
+			case <-instance.done:
+				return
+			case <-time.After(time.Second * envelopeCountCheckInterval):
+				instance.updateArchivedEnvelopesCount()
+				instance.collectValueLog()
+			}
+		}
+	}()
+	return instance, nil
+}
+
+// GetEnvelope get an envelope by its key
+func (db *BadgerDB) GetEnvelope(key *DBKey) ([]byte, error) {
+	var envelope []byte
+	err := db.bdb.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key.Bytes())
+		if err != nil {
+			return err
+		}
+		envelope, err = item.ValueCopy(nil)
+		return err
+	})
+	return envelope, err
+}
+
+func (db *BadgerDB) updateArchivedEnvelopesCount() {
+	if count, err := db.envelopesCount(); err != nil {
+		log.Warn("db query for envelopes count failed", "err", err)
+	} else {
+		archivedEnvelopesGauge.WithLabelValues(db.name).Set(float64(count))
+	}
+}
+
+// collectValueLog rewrites the value log files with mostly pruned envelopes
+func (db *BadgerDB) collectValueLog() {
+	for {
+		if err := db.bdb.RunValueLogGC(valueLogGCDiscardRatio); err != nil {
+			if err != badger.ErrNoRewrite {
+				log.Warn("badger value log garbage collection failed", "err", err)
+			}
+			return
+		}
+	}
+}
+
+// BuildIterator returns an iterator over the envelopes between start and end
+// in ascending order. With a cursor, it starts after the envelope of the cursor.
+func (db *BadgerDB) BuildIterator(query CursorQuery) (Iterator, error) {
+	txn := db.bdb.NewTransaction(false)
+	i := &BadgerIterator{
+		txn:  txn,
+		it:   txn.NewIterator(badger.DefaultIteratorOptions),
+		seek: query.start,
+		end:  query.end,
+	}
+	if len(query.cursor) == CursorLength {
+		i.seek = cursorLimit(query.cursor)
+		i.skip = i.seek
+	}
+	return i, nil
+}
+
+// Prune removes envelopes older than time
+func (db *BadgerDB) Prune(t time.Time, batchSize int) (int, error) {
+	return db.prune(t, batchSize, func(*DBKey, int64) (bool, bool) {
+		return true, false
+	})
+}
+
+// PruneExpired removes envelopes older than the cutoff of their topic
+func (db *BadgerDB) PruneExpired(cutoffs PruneCutoffs, batchSize int) (int, error) {
+	return db.prune(cutoffs.Latest(), batchSize, func(key *DBKey, _ int64) (bool, bool) {
+		return cutoffs.Expired(key), false
+	})
+}
+
+// PruneOldest removes the oldest envelopes until at least size bytes are removed
+func (db *BadgerDB) PruneOldest(size int64, batchSize int) (int, error) {
+	var removed int64
+	return db.prune(time.Unix(math.MaxUint32, 0), batchSize, func(_ *DBKey, envelopeSize int64) (bool, bool) {
+		if removed >= size {
+			return false, true
+		}
+		removed += envelopeSize
+		return true, false
+	})
+}
+
+// prune removes envelopes older than time selected by the remove function,
+// which can stop the iteration as well
+func (db *BadgerDB) prune(t time.Time, batchSize int, remove func(key *DBKey, size int64) (bool, bool)) (int, error) {
+	var zero types.Hash
+	var emptyTopic types.TopicType
+	ku := NewDBKey(uint32(t.Unix()), emptyTopic, zero)
+
+	// deleting keys while iterating in the same transaction is not supported,
+	// the keys are collected in batches first
+	var (
+		seek    []byte
+		stop    bool
+		removed int
+	)
+	for !stop {
+		var keys [][]byte
+		err := db.bdb.View(func(txn *badger.Txn) error {
+			opts := badger.DefaultIteratorOptions
+			opts.PrefetchValues = false
+			it := txn.NewIterator(opts)
+			defer it.Close()
+			for it.Seek(seek); it.Valid() && len(keys) < batchSize; it.Next() {
+				item := it.Item()
+				if bytes.Compare(item.Key(), ku.Bytes()) >= 0 {
+					stop = true
+					return nil
+				}
+				// continue after the last key visited in the next batch
+				seek = append(item.KeyCopy(nil), 0)
+
+				ok, end := remove(&DBKey{raw: item.Key()}, item.ValueSize())
+				if end {
+					stop = true
+					return nil
+				}
+				if ok {
+					keys = append(keys, item.KeyCopy(nil))
+				}
+			}
+			stop = stop || !it.Valid()
+			return nil
+		})
+		if err != nil {
+			return removed, err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		batch := db.bdb.NewWriteBatch()
+		for _, key := range keys {
+			if err := batch.Delete(key); err != nil {
+				batch.Cancel()
+				return removed, err
+			}
+		}
+		if err := batch.Flush(); err != nil {
+			return removed, err
+		}
+		removed += len(keys)
+	}
+	return removed, nil
+}
+
+// Stats returns the number and size of the envelopes stored by topic and day
+func (db *BadgerDB) Stats() ([]EnvelopesStats, error) {
+	stats := make(map[statsKey]*EnvelopesStats)
+	err := db.bdb.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			addStats(stats, &DBKey{raw: it.Item().Key()}, it.Item().ValueSize())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statsList(stats), nil
+}
+
+func (db *BadgerDB) envelopesCount() (int, error) {
+	var count int
+	err := db.bdb.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// SaveEnvelope stores an envelope in badger and increments the metrics
+func (db *BadgerDB) SaveEnvelope(env types.Envelope) error {
+	key := NewDBKey(env.Expiry()-env.TTL(), env.Topic(), env.Hash())
+	rawEnvelope, err := rlp.EncodeToBytes(env.Unwrap())
+	if err != nil {
+		log.Error(fmt.Sprintf("rlp.EncodeToBytes failed: %s", err))
+		archivedErrorsCounter.WithLabelValues(db.name).Inc()
+		return err
+	}
+
+	err = db.bdb.Update(func(txn *badger.Txn) error {
+		return txn.Set(key.Bytes(), rawEnvelope)
+	})
+	if err != nil {
+		log.Error(fmt.Sprintf("Writing to DB failed: %s", err))
+		archivedErrorsCounter.WithLabelValues(db.name).Inc()
+		return err
+	}
+	archivedEnvelopesGauge.WithLabelValues(db.name).Inc()
+	archivedEnvelopeSizeMeter.WithLabelValues(db.name).Observe(
+		float64(waku.EnvelopeHeaderLength + env.Size()))
+	return nil
+}
+
+func (db *BadgerDB) Close() error {
+	select {
+	case <-db.done:
+	default:
+		close(db.done)
+	}
+	return db.bdb.Close()
+}
+
+// badgerLogger forwards badger logs to the node logger
+type badgerLogger struct{}
+
+func (badgerLogger) Errorf(format string, args ...interface{}) {
+	log.Error(fmt.Sprintf("badger: "+format, args...))
+}
+
+func (badgerLogger) Warningf(format string, args ...interface{}) {
+	log.Warn(fmt.Sprintf("badger: "+format, args...))
+}
+
+func (badgerLogger) Infof(format string, args ...interface{}) {
+	log.Debug(fmt.Sprintf("badger: "+format, args...))
+}
+
+func (badgerLogger) Debugf(format string, args ...interface{}) {
+	log.Trace(fmt.Sprintf("badger: "+format, args...))
+}
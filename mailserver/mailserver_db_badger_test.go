@@ -0,0 +1,26 @@
+//go:build badger
+// +build badger
+
+package mailserver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerDB_Conformance(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "status-go-test-badger-db")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewBadgerDB(dir)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	testDBConformance(t, db)
+}
@@ -2,6 +2,7 @@ package mailserver
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
@@ -139,6 +140,37 @@ func (db *LevelDB) BuildIterator(query CursorQuery) (Iterator, error) {
 func (db *LevelDB) Prune(t time.Time, batchSize int) (int, error) {
 	defer recoverLevelDBPanics("Prune")
 
+	return db.prune(t, batchSize, func(*DBKey, int) (bool, bool) {
+		return true, false
+	})
+}
+
+// PruneExpired removes envelopes older than the cutoff of their topic
+func (db *LevelDB) PruneExpired(cutoffs PruneCutoffs, batchSize int) (int, error) {
+	defer recoverLevelDBPanics("PruneExpired")
+
+	return db.prune(cutoffs.Latest(), batchSize, func(key *DBKey, _ int) (bool, bool) {
+		return len(key.Bytes()) == DBKeyLength && cutoffs.Expired(key), false
+	})
+}
+
+// PruneOldest removes the oldest envelopes until at least size bytes are removed
+func (db *LevelDB) PruneOldest(size int64, batchSize int) (int, error) {
+	defer recoverLevelDBPanics("PruneOldest")
+
+	var removed int64
+	return db.prune(time.Unix(math.MaxUint32, 0), batchSize, func(_ *DBKey, envelopeSize int) (bool, bool) {
+		if removed >= size {
+			return false, true
+		}
+		removed += int64(envelopeSize)
+		return true, false
+	})
+}
+
+// prune removes envelopes older than time selected by the remove function,
+// which can stop the iteration as well
+func (db *LevelDB) prune(t time.Time, batchSize int, remove func(key *DBKey, size int) (bool, bool)) (int, error) {
 	var zero types.Hash
 	var emptyTopic types.TopicType
 	kl := NewDBKey(0, emptyTopic, zero)
@@ -152,16 +184,25 @@ func (db *LevelDB) Prune(t time.Time, batchSize int) (int, error) {
 		return 0, err
 	}
 	defer func() { _ = i.Release() }()
+	iter := i.(*LevelDBIterator)
 
 	batch := leveldb.Batch{}
 	removed := 0
 
-	for i.Next() {
-		dbKey, err := i.DBKey()
+	for iter.Next() {
+		dbKey, err := iter.DBKey()
 		if err != nil {
 			return 0, err
 		}
 
+		ok, stop := remove(dbKey, len(iter.Value()))
+		if stop {
+			break
+		}
+		if !ok {
+			continue
+		}
+
 		batch.Delete(dbKey.Bytes())
 
 		if batch.Len() == batchSize {
@@ -185,6 +226,23 @@ func (db *LevelDB) Prune(t time.Time, batchSize int) (int, error) {
 	return removed, nil
 }
 
+// Stats returns the number and size of the envelopes stored by topic and day
+func (db *LevelDB) Stats() ([]EnvelopesStats, error) {
+	defer recoverLevelDBPanics("Stats")
+
+	i := db.ldb.NewIterator(nil, nil)
+	defer i.Release()
+
+	stats := make(map[statsKey]*EnvelopesStats)
+	for i.Next() {
+		addStats(stats, &DBKey{raw: i.Key()}, int64(len(i.Value())))
+	}
+	if err := i.Error(); err != nil {
+		return nil, err
+	}
+	return statsList(stats), nil
+}
+
 func (db *LevelDB) envelopesCount() (int, error) {
 	defer recoverLevelDBPanics("envelopesCount")
 	iterator, err := db.BuildIterator(CursorQuery{})
@@ -55,3 +55,17 @@ func TestLevelDB_BuildIteratorWithTopic(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, iter.Error())
 }
+
+func TestLevelDB_Conformance(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "status-go-test-level-db")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewLevelDB(dir)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	testDBConformance(t, db)
+}
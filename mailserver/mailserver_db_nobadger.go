@@ -0,0 +1,10 @@
+//go:build !badger
+// +build !badger
+
+package mailserver
+
+// NewBadgerDB fails in builds without the badger tag. Badger links the cgo
+// zstd library, which mobile builds of status-go don't need.
+func NewBadgerDB(dataDir string) (DB, error) {
+	return nil, ErrBadgerNotBuilt
+}
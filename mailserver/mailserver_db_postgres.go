@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -246,6 +247,114 @@ func (i *PostgresDB) Prune(t time.Time, batch int) (int, error) {
 	return int(rows), nil
 }
 
+// PruneExpired removes envelopes older than the cutoff of their topic
+func (i *PostgresDB) PruneExpired(cutoffs PruneCutoffs, batch int) (int, error) {
+	var (
+		zero       types.Hash
+		emptyTopic types.TopicType
+		conditions []string
+		args       []interface{}
+		topics     []string
+	)
+	placeholder := func(arg interface{}) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	for topic, cutoff := range cutoffs.Topics {
+		if !cutoff.IsZero() {
+			ku := NewDBKey(uint32(cutoff.Unix()), emptyTopic, zero)
+			conditions = append(conditions, fmt.Sprintf("(topic = %s AND id < %s)", placeholder(topicToByte(topic)), placeholder(ku.Bytes())))
+		}
+		topics = append(topics, placeholder(topicToByte(topic)))
+	}
+	if !cutoffs.Default.IsZero() {
+		ku := NewDBKey(uint32(cutoffs.Default.Unix()), emptyTopic, zero)
+		condition := "id < " + placeholder(ku.Bytes())
+		if len(topics) > 0 {
+			condition += " AND topic NOT IN (" + strings.Join(topics, ", ") + ")"
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+
+	result, err := i.db.Exec("DELETE FROM envelopes WHERE "+strings.Join(conditions, " OR "), args...) // nolint: gosec
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
+func (i *PostgresDB) PruneOldest(size int64, batch int) (int, error) {
+	rows, err := i.db.Query("SELECT id, octet_length(data) FROM envelopes ORDER BY id ASC")
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		last    []byte
+		removed int64
+	)
+	for removed < size && rows.Next() {
+		var envelopeSize int64
+		if err := rows.Scan(&last, &envelopeSize); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		removed += envelopeSize
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+
+	result, err := i.db.Exec("DELETE FROM envelopes WHERE id <= $1", last)
+	if err != nil {
+		return 0, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (i *PostgresDB) Stats() ([]EnvelopesStats, error) {
+	// the timestamp is stored in the first bytes of the id
+	statement := fmt.Sprintf(`SELECT topic, day, count(*), sum(size) FROM (
+		SELECT topic, octet_length(data) AS size,
+			((get_byte(id, 0)::bigint << 24) | (get_byte(id, 1) << 16) | (get_byte(id, 2) << 8) | get_byte(id, 3)) / %[1]d * %[1]d AS day
+		FROM envelopes
+	) e GROUP BY topic, day`, secondsPerDay)
+
+	rows, err := i.db.Query(statement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rst []EnvelopesStats
+	for rows.Next() {
+		var (
+			stats EnvelopesStats
+			topic []byte
+		)
+		if err := rows.Scan(&topic, &stats.Day, &stats.Count, &stats.Size); err != nil {
+			return nil, err
+		}
+		stats.Topic = types.BytesToTopic(topic)
+		rst = append(rst, stats)
+	}
+	return rst, rows.Err()
+}
+
 func (i *PostgresDB) SaveEnvelope(env types.Envelope) error {
 	topic := env.Topic()
 	key := NewDBKey(env.Expiry()-env.TTL(), topic, env.Hash())
@@ -0,0 +1,384 @@
+package mailserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	bindata "github.com/status-im/migrate/v4/source/go_bindata"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/mailserver/sqlitemigrations"
+	"github.com/planq-network/status-go/sqlite"
+	waku "github.com/planq-network/status-go/waku/common"
+)
+
+// bloomBitsCount is the number of bits set in the bloom filter of a topic
+const bloomBitsCount = 3
+
+// SQLiteDB stores envelopes in an SQLite database. Envelopes are indexed by
+// topic, by the bits of their bloom filter and by timestamp.
+type SQLiteDB struct {
+	db   *sql.DB
+	name string
+	done chan struct{}
+}
+
+func NewSQLiteDB(path string) (*SQLiteDB, error) {
+	db, err := sqlite.OpenUnecryptedDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sqlite.Migrate(db, bindata.Resource(
+		sqlitemigrations.AssetNames(),
+		sqlitemigrations.Asset,
+	))
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	instance := &SQLiteDB{
+		db:   db,
+		name: path, // name is used for metrics labels
+		done: make(chan struct{}),
+	}
+
+	// initialize the metric value
+	instance.updateArchivedEnvelopesCount()
+	// checking count on every insert is inefficient
+	go func() {
+		for {
+			select {
+			case <-instance.done:
+				return
+			case <-time.After(time.Second * envelopeCountCheckInterval):
+				instance.updateArchivedEnvelopesCount()
+			}
+		}
+	}()
+	return instance, nil
+}
+
+type sqliteIterator struct {
+	*sql.Rows
+}
+
+func (i *sqliteIterator) DBKey() (*DBKey, error) {
+	var value []byte
+	var id []byte
+	if err := i.Scan(&id, &value); err != nil {
+		return nil, err
+	}
+	return &DBKey{raw: id}, nil
+}
+
+func (i *sqliteIterator) Error() error {
+	return i.Err()
+}
+
+func (i *sqliteIterator) Release() error {
+	return i.Close()
+}
+
+// GetEnvelopeByBloomFilter returns the current envelope, the query filters
+// envelopes by bloom filter already
+func (i *sqliteIterator) GetEnvelopeByBloomFilter(bloom []byte) ([]byte, error) {
+	var value []byte
+	var id []byte
+	if err := i.Scan(&id, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// GetEnvelopeByTopicsMap returns the current envelope, the query filters
+// envelopes by topic already
+func (i *sqliteIterator) GetEnvelopeByTopicsMap(topics map[types.TopicType]bool) ([]byte, error) {
+	var value []byte
+	var id []byte
+	if err := i.Scan(&id, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (db *SQLiteDB) envelopesCount() (int, error) {
+	var count int
+	return count, db.db.QueryRow("SELECT count(*) FROM envelopes").Scan(&count)
+}
+
+func (db *SQLiteDB) updateArchivedEnvelopesCount() {
+	if count, err := db.envelopesCount(); err != nil {
+		log.Warn("db query for envelopes count failed", "err", err)
+	} else {
+		archivedEnvelopesGauge.WithLabelValues(db.name).Set(float64(count))
+	}
+}
+
+// BuildIterator returns an iterator over the envelopes between start and end
+// in ascending order. With a cursor, it starts after the envelope of the cursor.
+func (db *SQLiteDB) BuildIterator(query CursorQuery) (Iterator, error) {
+	var (
+		args       []interface{}
+		conditions []string
+	)
+
+	if len(query.start) > 0 {
+		args = append(args, query.start)
+		conditions = append(conditions, "id >= ?")
+	}
+	if len(query.end) > 0 {
+		args = append(args, query.end)
+		conditions = append(conditions, "id < ?")
+	}
+	if len(query.cursor) == CursorLength {
+		args = append(args, cursorLimit(query.cursor))
+		conditions = append(conditions, "id > ?")
+	}
+
+	// the limit can only be applied if all envelopes are filtered by the query
+	filtered := true
+	if len(query.topics) > 0 {
+		conditions = append(conditions, "topic IN (?"+strings.Repeat(", ?", len(query.topics)-1)+")")
+		for _, topic := range query.topics {
+			args = append(args, topic)
+		}
+	} else if len(query.bloom) > 0 {
+		// a topic matches a bloom filter if all of its bits are set in it
+		var bits []string
+		for _, bit := range bloomBits(query.bloom) {
+			bits = append(bits, strconv.Itoa(bit))
+		}
+		set := strings.Join(bits, ", ")
+		for i := 0; i < bloomBitsCount; i++ {
+			conditions = append(conditions, fmt.Sprintf("bloom%d IN (%s)", i, set))
+		}
+	} else {
+		filtered = false
+	}
+
+	stmtString := "SELECT id, data FROM envelopes"
+	if len(conditions) > 0 {
+		stmtString += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	stmtString += " ORDER BY id ASC"
+	if filtered && query.limit > 0 {
+		args = append(args, query.limit)
+		stmtString += " LIMIT ?"
+	}
+
+	rows, err := db.db.Query(stmtString, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteIterator{rows}, nil
+}
+
+// GetEnvelope get an envelope by its key
+func (db *SQLiteDB) GetEnvelope(key *DBKey) ([]byte, error) {
+	var envelope []byte
+	err := db.db.QueryRow("SELECT data FROM envelopes WHERE id = ?", key.Bytes()).Scan(&envelope)
+	if err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// Prune removes envelopes older than time
+func (db *SQLiteDB) Prune(t time.Time, batch int) (int, error) {
+	result, err := db.db.Exec("DELETE FROM envelopes WHERE timestamp < ?", t.Unix())
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
+// PruneExpired removes envelopes older than the cutoff of their topic
+func (db *SQLiteDB) PruneExpired(cutoffs PruneCutoffs, batch int) (int, error) {
+	var (
+		conditions []string
+		args       []interface{}
+		topics     []interface{}
+	)
+	for topic, cutoff := range cutoffs.Topics {
+		topics = append(topics, topicToByte(topic))
+		if !cutoff.IsZero() {
+			conditions = append(conditions, "(topic = ? AND timestamp < ?)")
+			args = append(args, topicToByte(topic), cutoff.Unix())
+		}
+	}
+	if !cutoffs.Default.IsZero() {
+		condition := "timestamp < ?"
+		args = append(args, cutoffs.Default.Unix())
+		if len(topics) > 0 {
+			condition += " AND topic NOT IN (" + strings.Repeat("?, ", len(topics)-1) + "?)"
+			args = append(args, topics...)
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	if len(conditions) == 0 {
+		return 0, nil
+	}
+
+	result, err := db.db.Exec("DELETE FROM envelopes WHERE "+strings.Join(conditions, " OR "), args...) // nolint: gosec
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rows), nil
+}
+
+// PruneOldest removes the oldest envelopes until at least size bytes are removed
+func (db *SQLiteDB) PruneOldest(size int64, batch int) (int, error) {
+	rows, err := db.db.Query("SELECT id, length(data) FROM envelopes ORDER BY id ASC")
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		last    []byte
+		removed int64
+	)
+	for removed < size && rows.Next() {
+		var envelopeSize int64
+		if err := rows.Scan(&last, &envelopeSize); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		removed += envelopeSize
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+
+	result, err := db.db.Exec("DELETE FROM envelopes WHERE id <= ?", last)
+	if err != nil {
+		return 0, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// Stats returns the number and size of the envelopes stored by topic and day
+func (db *SQLiteDB) Stats() ([]EnvelopesStats, error) {
+	rows, err := db.db.Query(fmt.Sprintf(
+		"SELECT topic, timestamp / %[1]d * %[1]d AS day, count(*), sum(length(data)) FROM envelopes GROUP BY topic, day",
+		secondsPerDay,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rst []EnvelopesStats
+	for rows.Next() {
+		var (
+			stats EnvelopesStats
+			topic []byte
+		)
+		if err := rows.Scan(&topic, &stats.Day, &stats.Count, &stats.Size); err != nil {
+			return nil, err
+		}
+		stats.Topic = types.BytesToTopic(topic)
+		rst = append(rst, stats)
+	}
+	return rst, rows.Err()
+}
+
+// SaveEnvelope stores an envelope in sqlite and increments the metrics
+func (db *SQLiteDB) SaveEnvelope(env types.Envelope) error {
+	timestamp := env.Expiry() - env.TTL()
+	topic := env.Topic()
+	key := NewDBKey(timestamp, topic, env.Hash())
+	rawEnvelope, err := rlp.EncodeToBytes(env.Unwrap())
+	if err != nil {
+		log.Error(fmt.Sprintf("rlp.EncodeToBytes failed: %s", err))
+		archivedErrorsCounter.WithLabelValues(db.name).Inc()
+		return err
+	}
+	if rawEnvelope == nil {
+		archivedErrorsCounter.WithLabelValues(db.name).Inc()
+		return errors.New("failed to encode envelope to bytes")
+	}
+
+	bits := envelopeBloomBits(env.Bloom())
+	_, err = db.db.Exec(
+		"INSERT OR IGNORE INTO envelopes (id, data, topic, bloom0, bloom1, bloom2, timestamp) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		key.Bytes(),
+		rawEnvelope,
+		topicToByte(topic),
+		bits[0],
+		bits[1],
+		bits[2],
+		timestamp,
+	)
+	if err != nil {
+		log.Error(fmt.Sprintf("Writing to DB failed: %s", err))
+		archivedErrorsCounter.WithLabelValues(db.name).Inc()
+		return err
+	}
+
+	archivedEnvelopesGauge.WithLabelValues(db.name).Inc()
+	archivedEnvelopeSizeMeter.WithLabelValues(db.name).Observe(
+		float64(waku.EnvelopeHeaderLength + env.Size()))
+	return nil
+}
+
+func (db *SQLiteDB) Close() error {
+	select {
+	case <-db.done:
+	default:
+		close(db.done)
+	}
+	return db.db.Close()
+}
+
+// bloomBits returns the indexes of the bits set in a bloom filter
+func bloomBits(bloom []byte) []int {
+	var bits []int
+	for i, b := range bloom {
+		for j := 0; j < 8; j++ {
+			if b&(1<<uint(j)) != 0 {
+				bits = append(bits, i*8+j)
+			}
+		}
+	}
+	return bits
+}
+
+// envelopeBloomBits returns the bits set in the bloom filter of an envelope.
+// A topic sets one to bloomBitsCount bits, fewer if its bits share a byte, in
+// which case the last one is repeated.
+func envelopeBloomBits(bloom []byte) [bloomBitsCount]int {
+	var rst [bloomBitsCount]int
+	bits := bloomBits(bloom)
+	for i := range rst {
+		if i < len(bits) {
+			rst[i] = bits[i]
+		} else if i > 0 {
+			rst[i] = rst[i-1]
+		}
+	}
+	return rst
+}
@@ -0,0 +1,34 @@
+package mailserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+func TestSQLiteDB_Conformance(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "status-go-test-sqlite-db")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	db, err := NewSQLiteDB(filepath.Join(dir, sqliteDBName))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	testDBConformance(t, db)
+}
+
+func TestEnvelopeBloomBits(t *testing.T) {
+	topic := types.TopicType{0x01, 0x02, 0x03, 0x04}
+	require.Equal(t, [bloomBitsCount]int{2, 259, 259}, envelopeBloomBits(types.TopicToBloom(topic)))
+
+	topic = types.TopicType{0x01, 0x09, 0x11, 0x00}
+	require.Equal(t, [bloomBitsCount]int{1, 9, 17}, envelopeBloomBits(types.TopicToBloom(topic)))
+}
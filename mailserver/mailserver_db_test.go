@@ -0,0 +1,250 @@
+package mailserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	waku "github.com/planq-network/status-go/waku/common"
+)
+
+// testDBConformance checks the behaviour every DB implementation shares. The
+// order envelopes are returned in is left to the implementation.
+func testDBConformance(t *testing.T, db DB) {
+	topic := []byte{0x01, 0x02, 0x03, 0x04}
+	otherTopic := []byte{0x05, 0x06, 0x07, 0x08}
+
+	var saved []types.Envelope
+	for _, tp := range [][]byte{topic, topic, topic, otherTopic} {
+		envelope, err := newTestEnvelope(tp)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveEnvelope(envelope))
+		saved = append(saved, envelope)
+	}
+	// saving an envelope twice stores it once
+	require.NoError(t, db.SaveEnvelope(saved[0]))
+
+	start := NewDBKey(uint32(time.Now().Add(-time.Hour).Unix()), types.TopicType{}, types.Hash{}).Bytes()
+	end := NewDBKey(uint32(time.Now().Add(time.Hour).Unix()), types.TopicType{}, types.Hash{}).Bytes()
+
+	t.Run("GetEnvelope", func(t *testing.T) {
+		key := NewDBKey(saved[0].Expiry()-saved[0].TTL(), saved[0].Topic(), saved[0].Hash())
+		rawValue, err := db.GetEnvelope(key)
+		require.NoError(t, err)
+		var receivedEnvelope waku.Envelope
+		require.NoError(t, rlp.DecodeBytes(rawValue, &receivedEnvelope))
+		require.Equal(t, saved[0].Hash(), types.Hash(receivedEnvelope.Hash()))
+
+		_, err = db.GetEnvelope(NewDBKey(0, types.TopicType{}, types.Hash{}))
+		require.Error(t, err)
+	})
+
+	t.Run("BuildIteratorWithTopic", func(t *testing.T) {
+		query := CursorQuery{start: start, end: end, topics: [][]byte{topic}, limit: 10}
+		hashes := collectEnvelopes(t, db, query, map[types.TopicType]bool{types.BytesToTopic(topic): true}, nil)
+		require.Len(t, hashes, 3)
+		for _, envelope := range saved[:3] {
+			require.True(t, hashes[envelope.Hash()])
+		}
+	})
+
+	t.Run("BuildIteratorWithBloom", func(t *testing.T) {
+		bloom := types.TopicToBloom(types.BytesToTopic(otherTopic))
+		query := CursorQuery{start: start, end: end, bloom: bloom, limit: 10}
+		hashes := collectEnvelopes(t, db, query, nil, bloom)
+		require.Len(t, hashes, 1)
+		require.True(t, hashes[saved[3].Hash()])
+
+		query.bloom = types.MakeFullNodeBloom()
+		require.Len(t, collectEnvelopes(t, db, query, nil, query.bloom), 4)
+	})
+
+	t.Run("BuildIteratorWithTimeRange", func(t *testing.T) {
+		query := CursorQuery{start: end, end: NewDBKey(uint32(time.Now().Add(2*time.Hour).Unix()), types.TopicType{}, types.Hash{}).Bytes(), topics: [][]byte{topic}, limit: 10}
+		require.Empty(t, collectEnvelopes(t, db, query, map[types.TopicType]bool{types.BytesToTopic(topic): true}, nil))
+	})
+
+	t.Run("BuildIteratorWithCursor", func(t *testing.T) {
+		topics := map[types.TopicType]bool{types.BytesToTopic(topic): true}
+		query := CursorQuery{start: start, end: end, topics: [][]byte{topic}, limit: 1}
+		hashes := map[types.Hash]bool{}
+		for page := 0; page < len(saved); page++ {
+			iter, err := db.BuildIterator(query)
+			require.NoError(t, err)
+			var key *DBKey
+			for iter.Next() {
+				rawValue, err := iter.GetEnvelopeByTopicsMap(topics)
+				require.NoError(t, err)
+				if rawValue == nil {
+					continue
+				}
+				key, err = iter.DBKey()
+				require.NoError(t, err)
+				require.False(t, hashes[key.EnvelopeHash()])
+				hashes[key.EnvelopeHash()] = true
+				break
+			}
+			require.NoError(t, iter.Release())
+			if key == nil {
+				break
+			}
+			query.cursor = append([]byte(nil), key.Cursor()...)
+		}
+		require.Len(t, hashes, 3)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		removed, err := db.Prune(time.Now().Add(-time.Hour), 2)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+
+		removed, err = db.Prune(time.Now().Add(time.Hour), 2)
+		require.NoError(t, err)
+		require.Equal(t, 4, removed)
+
+		query := CursorQuery{start: start, end: end, bloom: types.MakeFullNodeBloom(), limit: 10}
+		require.Empty(t, collectEnvelopes(t, db, query, nil, query.bloom))
+	})
+
+	// the envelopes have been pruned, they are saved again
+	var size int64
+	for _, envelope := range saved {
+		require.NoError(t, db.SaveEnvelope(envelope))
+		rawEnvelope, err := rlp.EncodeToBytes(envelope.Unwrap())
+		require.NoError(t, err)
+		size += int64(len(rawEnvelope))
+	}
+
+	t.Run("Stats", func(t *testing.T) {
+		stats, err := db.Stats()
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+
+		var total int64
+		for _, s := range stats {
+			require.Equal(t, statsDay(saved[0].Expiry()-saved[0].TTL()), s.Day)
+			switch s.Topic {
+			case types.BytesToTopic(topic):
+				require.Equal(t, 3, s.Count)
+			case types.BytesToTopic(otherTopic):
+				require.Equal(t, 1, s.Count)
+			default:
+				t.Fatalf("unexpected topic %s", s.Topic.String())
+			}
+			total += s.Size
+		}
+		require.Equal(t, size, total)
+	})
+
+	t.Run("PruneExpired", func(t *testing.T) {
+		removed, err := db.PruneExpired(PruneCutoffs{Default: time.Now().Add(-time.Hour)}, 2)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+
+		// the envelopes of the other topic are kept forever, only the one of
+		// otherTopic is expired
+		cutoffs := PruneCutoffs{
+			Topics: map[types.TopicType]time.Time{
+				types.BytesToTopic(otherTopic): time.Now().Add(time.Hour),
+			},
+		}
+		removed, err = db.PruneExpired(cutoffs, 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+
+		query := CursorQuery{start: start, end: end, bloom: types.MakeFullNodeBloom(), limit: 10}
+		hashes := collectEnvelopes(t, db, query, nil, query.bloom)
+		require.Len(t, hashes, 3)
+		require.False(t, hashes[saved[3].Hash()])
+	})
+
+	t.Run("PruneOldest", func(t *testing.T) {
+		removed, err := db.PruneOldest(1, 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+
+		query := CursorQuery{start: start, end: end, bloom: types.MakeFullNodeBloom(), limit: 10}
+		require.Len(t, collectEnvelopes(t, db, query, nil, query.bloom), 2)
+
+		removed, err = db.PruneOldest(size, 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, removed)
+		require.Empty(t, collectEnvelopes(t, db, query, nil, query.bloom))
+	})
+}
+
+// collectEnvelopes returns the hashes of the envelopes returned for a query
+func collectEnvelopes(t *testing.T, db DB, query CursorQuery, topics map[types.TopicType]bool, bloom []byte) map[types.Hash]bool {
+	iter, err := db.BuildIterator(query)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, iter.Release()) }()
+
+	hashes := map[types.Hash]bool{}
+	for iter.Next() {
+		var rawValue []byte
+		if topics != nil {
+			rawValue, err = iter.GetEnvelopeByTopicsMap(topics)
+		} else {
+			rawValue, err = iter.GetEnvelopeByBloomFilter(bloom)
+		}
+		require.NoError(t, err)
+		if rawValue == nil {
+			continue
+		}
+		var receivedEnvelope waku.Envelope
+		require.NoError(t, rlp.DecodeBytes(rawValue, &receivedEnvelope))
+		hashes[types.Hash(receivedEnvelope.Hash())] = true
+	}
+	require.NoError(t, iter.Error())
+	return hashes
+}
+
+func TestCopyEnvelopes(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "status-go-test-copy-db")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	from, err := NewLevelDB(filepath.Join(dir, "leveldb"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, from.Close()) }()
+
+	topic := []byte{0x01, 0x02, 0x03, 0x04}
+	for i := 0; i < 5; i++ {
+		envelope, err := newTestEnvelope(topic)
+		require.NoError(t, err)
+		require.NoError(t, from.SaveEnvelope(envelope))
+	}
+
+	for _, backend := range []string{SQLiteBackend, BadgerBackend} {
+		to, err := OpenDB(backend, filepath.Join(dir, backend))
+		if err == ErrBadgerNotBuilt {
+			continue
+		}
+		require.NoError(t, err)
+
+		copied, err := CopyEnvelopes(from, to, 2)
+		require.NoError(t, err)
+		require.Equal(t, 5, copied)
+
+		query := CursorQuery{
+			start:  NewDBKey(0, types.TopicType{}, types.Hash{}).Bytes(),
+			end:    NewDBKey(uint32(time.Now().Add(time.Hour).Unix()), types.TopicType{}, types.Hash{}).Bytes(),
+			topics: [][]byte{topic},
+			limit:  10,
+		}
+		require.Len(t, collectEnvelopes(t, to, query, map[types.TopicType]bool{types.BytesToTopic(topic): true}, nil), 5)
+		require.NoError(t, to.Close())
+	}
+
+	_, err = OpenDB("unknown", dir)
+	require.Error(t, err)
+}
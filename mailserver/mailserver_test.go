@@ -112,6 +112,24 @@ func (s *MailserverSuite) TestInit() {
 			expectedError: nil,
 			info:          "config with rate limit",
 		},
+		{
+			config: params.WakuConfig{
+				DataDir:                 s.config.DataDir,
+				MailServerPassword:      "pwd",
+				MailServerDataRetention: -1,
+			},
+			expectedError: errNegativeRetention,
+			info:          "config with negative data retention",
+		},
+		{
+			config: params.WakuConfig{
+				DataDir:                  s.config.DataDir,
+				MailServerPassword:       "pwd",
+				MailServerTopicRetention: map[types.TopicType]int{{0x01, 0x02, 0x03, 0x04}: -1},
+			},
+			expectedError: errNegativeRetention,
+			info:          "config with negative topic retention",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -160,6 +178,23 @@ func (s *MailserverSuite) TestArchive() {
 	s.Equal(rawEnvelope, archivedEnvelope)
 }
 
+func (s *MailserverSuite) TestArchiveEphemeral() {
+	config := *s.config
+
+	err := s.server.Init(s.shh, &config)
+	s.Require().NoError(err)
+	defer s.server.Close()
+
+	env, err := generateEnvelope(time.Now())
+	s.NoError(err)
+	env.TTL = types.EphemeralEnvelopeTTL
+
+	s.server.Archive(env)
+	key := NewDBKey(env.Expiry-env.TTL, types.TopicType(env.Topic), types.Hash(env.Hash()))
+	_, err = s.server.ms.db.GetEnvelope(key)
+	s.Error(err)
+}
+
 func (s *MailserverSuite) TestManageLimits() {
 	err := s.server.Init(s.shh, s.config)
 	s.NoError(err)
@@ -60,6 +60,26 @@ var (
 		Help:    "Size of envelopes saved.",
 		Buckets: prom.ExponentialBuckets(1024, 2, 11),
 	}, []string{"db"})
+	topicArchivedEnvelopesGauge = prom.NewGaugeVec(prom.GaugeOpts{
+		Name: "mailserver_topic_archived_envelopes_total",
+		Help: "Number of envelopes of a topic saved in the DB.",
+	}, []string{"topic"})
+	topicArchivedEnvelopesSizeGauge = prom.NewGaugeVec(prom.GaugeOpts{
+		Name: "mailserver_topic_archived_envelopes_size_bytes",
+		Help: "Size of the envelopes of a topic saved in the DB.",
+	}, []string{"topic"})
+	prunedEnvelopesCounter = prom.NewCounterVec(prom.CounterOpts{
+		Name: "mailserver_pruned_envelopes_total",
+		Help: "Number of envelopes removed from the DB.",
+	}, []string{"reason"})
+	replicatedEnvelopesCounter = prom.NewCounterVec(prom.CounterOpts{
+		Name: "mailserver_replicated_envelopes_total",
+		Help: "Number of envelopes pulled from other mail servers.",
+	}, []string{"result"})
+	replicationFailuresCounter = prom.NewCounterVec(prom.CounterOpts{
+		Name: "mailserver_replication_failures_total",
+		Help: "Number of failed replication requests to other mail servers.",
+	}, []string{"type"})
 )
 
 func init() {
@@ -76,4 +96,9 @@ func init() {
 	prom.MustRegister(archivedErrorsCounter)
 	prom.MustRegister(archivedEnvelopesGauge)
 	prom.MustRegister(archivedEnvelopeSizeMeter)
+	prom.MustRegister(topicArchivedEnvelopesGauge)
+	prom.MustRegister(topicArchivedEnvelopesSizeGauge)
+	prom.MustRegister(prunedEnvelopesCounter)
+	prom.MustRegister(replicatedEnvelopesCounter)
+	prom.MustRegister(replicationFailuresCounter)
 }
@@ -0,0 +1,150 @@
+package mailserver
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// peerRequestsWindow is how long the requests of a peer are counted for
+	peerRequestsWindow = 24 * time.Hour
+	// peerRequestsCleanUpPeriod is how often the expired peers are removed
+	peerRequestsCleanUpPeriod = time.Minute
+)
+
+// PeerRequests is the number of requests a peer sent in the last day.
+type PeerRequests struct {
+	PeerID      string    `json:"peerId"`
+	Requests    int       `json:"requests"`
+	LastRequest time.Time `json:"lastRequest"`
+}
+
+// peerRequests counts the requests sent by peers.
+type peerRequests struct {
+	sync.Mutex
+
+	window  time.Duration
+	peers   map[string]*PeerRequests
+	cleaned time.Time
+}
+
+func newPeerRequests(window time.Duration) *peerRequests {
+	return &peerRequests{
+		window: window,
+		peers:  make(map[string]*PeerRequests),
+	}
+}
+
+func (r *peerRequests) Add(id string, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+
+	if now.Sub(r.cleaned) > peerRequestsCleanUpPeriod {
+		r.deleteExpired(now)
+		r.cleaned = now
+	}
+
+	peer, ok := r.peers[id]
+	if !ok {
+		peer = &PeerRequests{PeerID: id}
+		r.peers[id] = peer
+	}
+	peer.Requests++
+	peer.LastRequest = now
+}
+
+// Top returns the peers which sent the most requests, at most limit of them.
+func (r *peerRequests) Top(limit int, now time.Time) []PeerRequests {
+	r.Lock()
+	defer r.Unlock()
+
+	r.deleteExpired(now)
+
+	rst := make([]PeerRequests, 0, len(r.peers))
+	for _, peer := range r.peers {
+		rst = append(rst, *peer)
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		if rst[i].Requests != rst[j].Requests {
+			return rst[i].Requests > rst[j].Requests
+		}
+		return rst[i].LastRequest.After(rst[j].LastRequest)
+	})
+	if limit > 0 && len(rst) > limit {
+		rst = rst[:limit]
+	}
+	return rst
+}
+
+// deleteExpired removes the peers which didn't send any request during
+// the window. The count of a peer starts again once it's removed.
+func (r *peerRequests) deleteExpired(now time.Time) {
+	for id, peer := range r.peers {
+		if now.Sub(peer.LastRequest) > r.window {
+			delete(r.peers, id)
+		}
+	}
+}
+
+// BlacklistedPeer is a peer whose requests are refused until a time.
+type BlacklistedPeer struct {
+	PeerID string    `json:"peerId"`
+	Until  time.Time `json:"until"`
+}
+
+// peerBlacklist refuses requests from peers for a while.
+type peerBlacklist struct {
+	sync.RWMutex
+
+	peers map[string]time.Time
+}
+
+func newPeerBlacklist() *peerBlacklist {
+	return &peerBlacklist{
+		peers: make(map[string]time.Time),
+	}
+}
+
+func (b *peerBlacklist) Add(id string, until time.Time) {
+	b.Lock()
+	b.peers[id] = until
+	b.Unlock()
+}
+
+// Remove returns false if the peer wasn't blacklisted.
+func (b *peerBlacklist) Remove(id string) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	_, ok := b.peers[id]
+	delete(b.peers, id)
+	return ok
+}
+
+func (b *peerBlacklist) IsBlacklisted(id string, now time.Time) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	until, ok := b.peers[id]
+	return ok && now.Before(until)
+}
+
+// List returns the peers which are blacklisted at the given time.
+func (b *peerBlacklist) List(now time.Time) []BlacklistedPeer {
+	b.Lock()
+	defer b.Unlock()
+
+	rst := make([]BlacklistedPeer, 0, len(b.peers))
+	for id, until := range b.peers {
+		if !now.Before(until) {
+			delete(b.peers, id)
+			continue
+		}
+		rst = append(rst, BlacklistedPeer{PeerID: id, Until: until})
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].Until.Before(rst[j].Until)
+	})
+	return rst
+}
@@ -0,0 +1,46 @@
+package mailserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRequestsTop(t *testing.T) {
+	now := time.Now()
+	requests := newPeerRequests(time.Hour)
+
+	requests.Add("a", now.Add(-2*time.Hour))
+	requests.Add("b", now)
+	requests.Add("c", now)
+	requests.Add("c", now)
+
+	top := requests.Top(0, now)
+	require.Len(t, top, 2)
+	require.Equal(t, "c", top[0].PeerID)
+	require.Equal(t, 2, top[0].Requests)
+	require.Equal(t, "b", top[1].PeerID)
+
+	require.Len(t, requests.Top(1, now), 1)
+}
+
+func TestPeerBlacklist(t *testing.T) {
+	now := time.Now()
+	blacklist := newPeerBlacklist()
+
+	blacklist.Add("a", now.Add(time.Minute))
+	blacklist.Add("b", now.Add(-time.Minute))
+
+	require.True(t, blacklist.IsBlacklisted("a", now))
+	require.False(t, blacklist.IsBlacklisted("b", now))
+	require.False(t, blacklist.IsBlacklisted("c", now))
+	require.False(t, blacklist.IsBlacklisted("a", now.Add(time.Minute)))
+
+	list := blacklist.List(now)
+	require.Len(t, list, 1)
+	require.Equal(t, "a", list[0].PeerID)
+
+	require.True(t, blacklist.Remove("a"))
+	require.False(t, blacklist.IsBlacklisted("a", now))
+}
@@ -0,0 +1,445 @@
+package mailserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/waku"
+	wakucommon "github.com/planq-network/status-go/waku/common"
+)
+
+const (
+	// replicationPeriod is how often the peer mail servers are asked for
+	// the envelopes which are missing
+	replicationPeriod = 5 * time.Minute
+	// replicationDelay leaves recent envelopes some time to reach every mail
+	// server before they are pulled
+	replicationDelay = 5 * time.Minute
+	// replicationWindow is the largest time range requested at once
+	replicationWindow = 24 * time.Hour
+	// replicationRequestTimeout is how long a page of envelopes is waited for
+	replicationRequestTimeout = time.Minute
+	// defaultReplicationHistory is how far back envelopes are pulled without
+	// a data retention
+	defaultReplicationHistory = 30 * 24 * time.Hour
+	// replicationStateFileName is the file in DataDir the cursors are saved to
+	replicationStateFileName = "replication.json"
+	// allTopicsStream identifies the cursors of the envelopes of all topics
+	allTopicsStream = "*"
+	// replicationRateLimitMargin is added to the rate limit of the peers
+	// between two requests
+	replicationRateLimitMargin = 100 * time.Millisecond
+)
+
+var (
+	errReplicationTimeout     = errors.New("replication request timed out")
+	errReplicationStopped     = errors.New("replication stopped")
+	errReplicationCursorStuck = errors.New("replication peer returned the same cursor again")
+)
+
+// replicationService sends requests to other mail servers and notifies
+// about their responses.
+type replicationService interface {
+	SendMessagesRequest(peerID []byte, request wakucommon.MessagesRequest) error
+	SubscribeEnvelopeEvents(events chan<- wakucommon.EnvelopeEvent) event.Subscription
+}
+
+// replicationCursor is the progress of the replication of a topic from a peer.
+type replicationCursor struct {
+	// Synced is the time until which all envelopes have been pulled
+	Synced uint32 `json:"synced"`
+	// Cursor is the next page of envelopes sent after Synced
+	Cursor types.HexBytes `json:"cursor,omitempty"`
+}
+
+// replicator pulls the envelopes missing from the DB from other mail servers.
+// Each topic is pulled in time ranges from every peer and the progress is
+// saved, so that only new envelopes are requested after a restart.
+type replicator struct {
+	db      DB
+	service replicationService
+	peers   [][]byte
+	topics  []types.TopicType
+	history time.Duration
+	path    string
+
+	limit  uint32
+	period time.Duration
+	// interval is the time waited between two requests to a peer, so that
+	// they are not rejected by its rate limiter. The peers are expected to
+	// use the same rate limit as this mail server
+	interval     time.Duration
+	lastRequests map[string]time.Time
+
+	mu        sync.Mutex
+	cursors   map[string]*replicationCursor
+	pending   map[string]bool
+	responses map[types.Hash]chan *waku.MailServerResponse
+
+	sub    event.Subscription
+	cancel chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newReplicator(db DB, service replicationService, peers [][]byte, topics []types.TopicType, history time.Duration, path string) (*replicator, error) {
+	r := &replicator{
+		db:           db,
+		service:      service,
+		peers:        peers,
+		topics:       topics,
+		history:      history,
+		path:         path,
+		limit:        maxMessagesRequestPayloadLimit,
+		period:       replicationPeriod,
+		lastRequests: make(map[string]time.Time),
+		cursors:      make(map[string]*replicationCursor),
+		pending:      make(map[string]bool),
+		responses:    make(map[types.Hash]chan *waku.MailServerResponse),
+	}
+	if err := r.loadCursors(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// parseReplicationPeers returns the IDs of the peers of enode URLs.
+func parseReplicationPeers(urls []string) ([][]byte, error) {
+	peers := make([][]byte, 0, len(urls))
+	for _, url := range urls {
+		node, err := enode.ParseV4(url)
+		if err != nil {
+			return nil, err
+		}
+		id := node.ID()
+		peers = append(peers, id.Bytes())
+	}
+	return peers, nil
+}
+
+func (r *replicator) Start() {
+	log.Info("Starting replicating envelopes", "period", r.period, "peers", len(r.peers), "topics", len(r.topics))
+
+	events := make(chan wakucommon.EnvelopeEvent, 100)
+	r.sub = r.service.SubscribeEnvelopeEvents(events)
+	r.cancel = make(chan struct{})
+
+	r.wg.Add(2)
+	go func() {
+		defer r.wg.Done()
+		r.handleEvents(events)
+	}()
+	go func() {
+		defer r.wg.Done()
+		r.schedule()
+	}()
+}
+
+func (r *replicator) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	close(r.cancel)
+	r.sub.Unsubscribe()
+	r.wg.Wait()
+	r.cancel = nil
+}
+
+func (r *replicator) schedule() {
+	t := time.NewTicker(r.period)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			r.replicate()
+		case <-r.cancel:
+			return
+		}
+	}
+}
+
+// replicate pulls the missing envelopes of every topic from every peer
+func (r *replicator) replicate() {
+	for _, peer := range r.peers {
+		for _, stream := range r.streams() {
+			err := r.replicateStream(peer, stream)
+			if err == nil {
+				continue
+			}
+			log.Warn("failed to replicate envelopes", "peer", hex.EncodeToString(peer), "topic", stream, "err", err)
+			// the peer is likely to fail for the other topics too
+			break
+		}
+		select {
+		case <-r.cancel:
+			return
+		default:
+		}
+	}
+}
+
+// streams returns the topics replicated, all topics are replicated at once
+// if none is configured
+func (r *replicator) streams() []string {
+	if len(r.topics) == 0 {
+		return []string{allTopicsStream}
+	}
+	streams := make([]string, 0, len(r.topics))
+	for _, topic := range r.topics {
+		streams = append(streams, topic.String())
+	}
+	return streams
+}
+
+// replicateStream pulls the envelopes of a topic sent after the cursor of
+// the peer until they are all pulled
+func (r *replicator) replicateStream(peer []byte, stream string) error {
+	key := hex.EncodeToString(peer) + "/" + stream
+	cursor := r.cursor(key)
+
+	for {
+		upper := uint32(time.Now().Add(-replicationDelay).Unix())
+		if cursor.Synced >= upper && len(cursor.Cursor) == 0 {
+			return nil
+		}
+		to := cursor.Synced + uint32(replicationWindow/time.Second)
+		if to > upper {
+			to = upper
+		}
+
+		id := make([]byte, types.HashLength)
+		if _, err := rand.Read(id); err != nil {
+			return err
+		}
+		request := wakucommon.MessagesRequest{
+			ID:     id,
+			From:   cursor.Synced,
+			To:     to,
+			Limit:  r.limit,
+			Cursor: cursor.Cursor,
+		}
+		if stream == allTopicsStream {
+			request.Bloom = wakucommon.MakeFullNodeBloom()
+		} else {
+			var topic types.TopicType
+			if err := topic.UnmarshalText([]byte(stream)); err != nil {
+				return err
+			}
+			request.Topics = [][]byte{topic[:]}
+		}
+
+		response, err := r.request(peer, request)
+		if err != nil {
+			return err
+		}
+
+		// a peer returning the same page again would keep us requesting it
+		// forever, the stream is retried in the next period
+		if len(response.Cursor) > 0 && bytes.Equal(response.Cursor, cursor.Cursor) {
+			replicationFailuresCounter.WithLabelValues("cursor").Inc()
+			return errReplicationCursorStuck
+		}
+
+		if len(response.Cursor) > 0 {
+			cursor.Cursor = response.Cursor
+		} else {
+			// the envelopes sent at to may not have reached the peer yet, the
+			// next range starts at it
+			cursor.Synced = to
+			cursor.Cursor = nil
+		}
+		if err := r.setCursor(key, cursor); err != nil {
+			return err
+		}
+	}
+}
+
+// wait waits until the rate limit of a peer allows another request
+func (r *replicator) wait(peerKey string) error {
+	last, ok := r.lastRequests[peerKey]
+	if !ok || r.interval == 0 {
+		return nil
+	}
+
+	// the peer limits the time between the requests it receives, the time
+	// of the last response is used as it's received after the request
+	delay := time.Until(last.Add(r.interval + replicationRateLimitMargin))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-r.cancel:
+		return errReplicationStopped
+	}
+}
+
+// request sends a request to a peer and waits for its response
+func (r *replicator) request(peer []byte, request wakucommon.MessagesRequest) (*waku.MailServerResponse, error) {
+	id := types.BytesToHash(request.ID)
+	responses := make(chan *waku.MailServerResponse, 1)
+	peerKey := hex.EncodeToString(peer)
+
+	if err := r.wait(peerKey); err != nil {
+		return nil, err
+	}
+	defer func() {
+		r.lastRequests[peerKey] = time.Now()
+	}()
+
+	r.mu.Lock()
+	r.responses[id] = responses
+	r.pending[peerKey] = true
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.responses, id)
+		delete(r.pending, peerKey)
+		r.mu.Unlock()
+	}()
+
+	if err := r.service.SendMessagesRequest(peer, request); err != nil {
+		replicationFailuresCounter.WithLabelValues("send").Inc()
+		return nil, err
+	}
+
+	timeout := time.NewTimer(replicationRequestTimeout)
+	defer timeout.Stop()
+
+	select {
+	case response := <-responses:
+		if response.Error != nil {
+			replicationFailuresCounter.WithLabelValues("response").Inc()
+			return nil, response.Error
+		}
+		return response, nil
+	case <-timeout.C:
+		replicationFailuresCounter.WithLabelValues("timeout").Inc()
+		return nil, errReplicationTimeout
+	case <-r.cancel:
+		return nil, errReplicationStopped
+	}
+}
+
+// handleEvents passes the responses of the peers to the pending requests.
+// The events are read as long as the subscription exists so that they don't
+// block the other subscribers.
+func (r *replicator) handleEvents(events <-chan wakucommon.EnvelopeEvent) {
+	for {
+		select {
+		case ev := <-events:
+			if ev.Event != wakucommon.EventMailServerRequestCompleted {
+				continue
+			}
+			response, ok := ev.Data.(*waku.MailServerResponse)
+			if !ok {
+				continue
+			}
+			r.mu.Lock()
+			responses, ok := r.responses[types.Hash(ev.Hash)]
+			r.mu.Unlock()
+			if ok {
+				select {
+				case responses <- response:
+				default:
+				}
+			}
+		case <-r.sub.Err():
+			return
+		case <-r.cancel:
+			return
+		}
+	}
+}
+
+// Replicate saves the envelopes sent by a peer a request is pending for. The
+// envelopes already stored are skipped. It returns false if there is no request
+// pending for the peer.
+func (r *replicator) Replicate(peerID []byte, envelopes []*wakucommon.Envelope) bool {
+	r.mu.Lock()
+	pending := r.pending[hex.EncodeToString(peerID)]
+	r.mu.Unlock()
+	if !pending {
+		return false
+	}
+
+	for _, envelope := range envelopes {
+		env := gethbridge.NewWakuEnvelope(envelope)
+		key := NewDBKey(env.Expiry()-env.TTL(), env.Topic(), env.Hash())
+		if _, err := r.db.GetEnvelope(key); err == nil {
+			replicatedEnvelopesCounter.WithLabelValues("duplicate").Inc()
+			continue
+		}
+		if err := r.db.SaveEnvelope(env); err != nil {
+			replicatedEnvelopesCounter.WithLabelValues("error").Inc()
+			log.Error("failed to save replicated envelope", "hash", env.Hash().String(), "err", err)
+			continue
+		}
+		replicatedEnvelopesCounter.WithLabelValues("saved").Inc()
+	}
+	return true
+}
+
+// cursor returns the cursor of a stream, it starts at the oldest envelopes
+// kept if the stream has never been replicated
+func (r *replicator) cursor(key string) replicationCursor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cursor, ok := r.cursors[key]; ok {
+		return *cursor
+	}
+	return replicationCursor{Synced: uint32(time.Now().Add(-r.history).Unix())}
+}
+
+func (r *replicator) setCursor(key string, cursor replicationCursor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cursors[key] = &cursor
+	return r.saveCursors()
+}
+
+func (r *replicator) loadCursors() error {
+	data, err := ioutil.ReadFile(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &r.cursors)
+}
+
+// saveCursors writes the cursors to a temporary file first, so that they are
+// never left partially written
+func (r *replicator) saveCursors() error {
+	data, err := json.Marshal(r.cursors)
+	if err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
@@ -0,0 +1,251 @@
+package mailserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/waku"
+	wakucommon "github.com/planq-network/status-go/waku/common"
+)
+
+// replicationTestService connects a replicator to the mail server it pulls
+// envelopes from.
+type replicationTestService struct {
+	source     *mailServer
+	replicator *replicator
+	feed       event.Feed
+	requests   int
+}
+
+func (s *replicationTestService) SendMessagesRequest(peerID []byte, request wakucommon.MessagesRequest) error {
+	s.requests++
+	go s.source.DeliverMail(types.BytesToHash(peerID), types.BytesToHash(request.ID), MessagesRequestPayload{
+		Lower:  request.From,
+		Upper:  request.To,
+		Bloom:  request.Bloom,
+		Topics: request.Topics,
+		Limit:  request.Limit,
+		Cursor: request.Cursor,
+		Batch:  true,
+	})
+	return nil
+}
+
+func (s *replicationTestService) SubscribeEnvelopeEvents(events chan<- wakucommon.EnvelopeEvent) event.Subscription {
+	return s.feed.Subscribe(events)
+}
+
+func (s *replicationTestService) SendHistoricMessageResponse(peerID []byte, payload []byte) error {
+	ev, err := waku.CreateMailServerEvent(enode.ID(types.BytesToHash(peerID)), payload)
+	if err != nil {
+		return err
+	}
+	s.feed.Send(*ev)
+	return nil
+}
+
+func (s *replicationTestService) SendRawP2PDirect(peerID []byte, envelopes ...rlp.RawValue) error {
+	decoded := make([]*wakucommon.Envelope, 0, len(envelopes))
+	for _, raw := range envelopes {
+		var env *wakucommon.Envelope
+		if err := rlp.DecodeBytes(raw, &env); err != nil {
+			return err
+		}
+		decoded = append(decoded, env)
+	}
+	s.replicator.Replicate(peerID, decoded)
+	return nil
+}
+
+func (s *replicationTestService) MaxMessageSize() uint32 {
+	return wakucommon.DefaultMaxMessageSize
+}
+
+func (s *replicationTestService) SendRawSyncResponse(peerID []byte, data interface{}) error {
+	return nil
+}
+
+func (s *replicationTestService) SendSyncResponse(peerID []byte, data interface{}) error {
+	return nil
+}
+
+func setupReplication(t *testing.T, source, target *WakuMailServer, topics []types.TopicType, path string) (*replicationTestService, *replicator) {
+	service := &replicationTestService{source: source.ms}
+	source.ms.service = service
+
+	r, err := newReplicator(target.ms.db, service, [][]byte{peerID[:]}, topics, 3*time.Hour, path)
+	require.NoError(t, err)
+	r.limit = 2
+	r.period = time.Hour
+	service.replicator = r
+	return service, r
+}
+
+var peerID = types.BytesToHash([]byte("replication peer"))
+
+func TestReplication(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailserver-replication")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	path := filepath.Join(dir, replicationStateFileName)
+
+	now := time.Now()
+	source := setupTestServer(t)
+	defer source.Close()
+	target := setupTestServer(t)
+	defer target.Close()
+
+	archiveEnvelope(t, now.Add(-2*time.Hour), source)
+	archiveEnvelope(t, now.Add(-90*time.Minute), source)
+	archiveEnvelope(t, now.Add(-time.Hour), source)
+	archiveTopicEnvelope(t, now.Add(-time.Hour), types.TopicType{0x01, 0x02, 0x03, 0x04}, source)
+	// too old to be replicated
+	archiveEnvelope(t, now.Add(-4*time.Hour), source)
+	// too recent to be replicated
+	archiveEnvelope(t, now.Add(-time.Minute), source)
+
+	service, r := setupReplication(t, source, target, nil, path)
+	r.Start()
+	r.replicate()
+	r.Stop()
+
+	testMessagesCount(t, 4, target)
+	// the envelopes are pulled two at a time
+	require.Equal(t, 3, service.requests)
+
+	// the progress is restored
+	_, r = setupReplication(t, source, target, nil, path)
+	cursor := r.cursor(peerID.String()[2:] + "/" + allTopicsStream)
+	require.Empty(t, cursor.Cursor)
+	require.InDelta(t, now.Add(-replicationDelay).Unix(), int64(cursor.Synced), 5)
+
+	// the envelopes already stored are skipped
+	require.NoError(t, os.Remove(path))
+	service, r = setupReplication(t, source, target, nil, path)
+	r.Start()
+	r.replicate()
+	r.Stop()
+
+	testMessagesCount(t, 4, target)
+	require.Equal(t, 3, service.requests)
+}
+
+func TestReplicationTopics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailserver-replication")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	now := time.Now()
+	source := setupTestServer(t)
+	defer source.Close()
+	target := setupTestServer(t)
+	defer target.Close()
+
+	topic := types.TopicType{0x01, 0x02, 0x03, 0x04}
+	archiveEnvelope(t, now.Add(-time.Hour), source)
+	env := archiveTopicEnvelope(t, now.Add(-time.Hour), topic, source)
+
+	_, r := setupReplication(t, source, target, []types.TopicType{topic}, filepath.Join(dir, replicationStateFileName))
+	r.Start()
+	r.replicate()
+	r.Stop()
+
+	testMessagesCount(t, 1, target)
+	key, _, err := FindEnvelope(target.ms.db, types.Hash(env.Hash()), dbCleanerBatchSize)
+	require.NoError(t, err)
+	require.Equal(t, topic, key.Topic())
+}
+
+func TestReplicateWithoutRequest(t *testing.T) {
+	target := setupTestServer(t)
+	defer target.Close()
+
+	env, err := generateEnvelope(time.Now())
+	require.NoError(t, err)
+
+	r, err := newReplicator(target.ms.db, &replicationTestService{}, [][]byte{peerID[:]}, nil, time.Hour, "")
+	require.NoError(t, err)
+	require.False(t, r.Replicate(peerID[:], []*wakucommon.Envelope{env}))
+	testMessagesCount(t, 0, target)
+}
+
+func TestReplicationRateLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailserver-replication")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	now := time.Now()
+	source := setupTestServer(t)
+	defer source.Close()
+	target := setupTestServer(t)
+	defer target.Close()
+
+	rateLimit := 200 * time.Millisecond
+	source.ms.setupRateLimiter(rateLimit)
+
+	archiveEnvelope(t, now.Add(-2*time.Hour), source)
+	archiveEnvelope(t, now.Add(-90*time.Minute), source)
+	archiveEnvelope(t, now.Add(-time.Hour), source)
+
+	// the second page would be rejected by the rate limiter of the source
+	// without pacing the requests
+	service, r := setupReplication(t, source, target, nil, filepath.Join(dir, replicationStateFileName))
+	r.interval = rateLimit
+	r.Start()
+	r.replicate()
+	r.Stop()
+
+	testMessagesCount(t, 3, target)
+	// an empty range may be requested at the end, as the requests take time
+	require.GreaterOrEqual(t, service.requests, 2)
+}
+
+// stuckReplicationService always responds with the same cursor
+type stuckReplicationService struct {
+	feed     event.Feed
+	requests int
+}
+
+func (s *stuckReplicationService) SendMessagesRequest(peerID []byte, request wakucommon.MessagesRequest) error {
+	s.requests++
+	go s.feed.Send(wakucommon.EnvelopeEvent{
+		Event: wakucommon.EventMailServerRequestCompleted,
+		Hash:  common.BytesToHash(request.ID),
+		Data:  &waku.MailServerResponse{Cursor: []byte("stuck cursor")},
+	})
+	return nil
+}
+
+func (s *stuckReplicationService) SubscribeEnvelopeEvents(events chan<- wakucommon.EnvelopeEvent) event.Subscription {
+	return s.feed.Subscribe(events)
+}
+
+func TestReplicationStuckCursor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mailserver-replication")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	target := setupTestServer(t)
+	defer target.Close()
+
+	service := &stuckReplicationService{}
+	r, err := newReplicator(target.ms.db, service, [][]byte{peerID[:]}, nil, time.Hour, filepath.Join(dir, replicationStateFileName))
+	require.NoError(t, err)
+	r.period = time.Hour
+	r.Start()
+	defer r.Stop()
+
+	require.Equal(t, errReplicationCursorStuck, r.replicateStream(peerID[:], allTopicsStream))
+	require.Equal(t, 2, service.requests)
+}
@@ -0,0 +1,319 @@
+// Code generated by go-bindata. DO NOT EDIT.
+// sources:
+// 1644237000_initialize_db.down.sql (98B)
+// 1644237000_initialize_db.up.sql (404B)
+// static.go (190B)
+
+package sqlitemigrations
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes  []byte
+	info   os.FileInfo
+	digest [sha256.Size]byte
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var __1644237000_initialize_dbDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x28\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x88\xcf\x4c\xa9\xb0\xe6\x42\x92\xc9\x4c\x89\x4f\xca\xc9\xcf\xcf\xc5\x26\x51\x92\x5f\x90\x99\x8c\x24\x11\xe2\xe8\xe4\xe3\xaa\x90\x9a\x57\x96\x9a\x93\x5f\x90\x5a\x6c\xcd\x05\x08\x00\x00\xff\xff\x15\x5a\x5b\x45\x62\x00\x00\x00")
+
+func _1644237000_initialize_dbDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644237000_initialize_dbDownSql,
+		"1644237000_initialize_db.down.sql",
+	)
+}
+
+func _1644237000_initialize_dbDownSql() (*asset, error) {
+	bytes, err := _1644237000_initialize_dbDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644237000_initialize_db.down.sql", size: 98, mode: os.FileMode(0644), modTime: time.Unix(1792284976, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0xe6, 0x6e, 0xaf, 0xe3, 0xe0, 0x62, 0xb6, 0x5a, 0xe4, 0x57, 0xcf, 0xb0, 0xb5, 0x0, 0xd2, 0xb7, 0x2f, 0x8b, 0xe9, 0xd9, 0xd1, 0xae, 0x78, 0x38, 0xb1, 0x3a, 0x6e, 0x3a, 0x3f, 0x27, 0xb3}}
+	return a, nil
+}
+
+var __1644237000_initialize_dbUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x8f\x41\x6b\xc3\x20\x00\x85\xef\xfe\x8a\x77\x5c\x20\x87\x2d\xd7\x9c\x92\x45\x36\x99\xd3\x21\x86\x2c\xa7\x90\x4d\x0f\x42\x32\x03\x91\xd1\x9f\x5f\x9a\xa6\xa5\x45\x7b\x12\xde\xe7\xd3\xf7\xbd\x2a\x5a\x69\x0a\x5d\xd5\x9c\xc2\xfe\xfd\xdb\xc9\x2f\x76\xc5\x13\x01\x9c\x41\xcd\x65\x0d\x21\x35\x44\xcb\x39\xbe\x14\xfb\xac\x54\x8f\x0f\xda\xe7\x04\x30\x63\x18\xef\x6f\x9c\xd2\xe0\x17\xf7\x1b\xc7\x3f\x93\xf7\xf3\x33\x98\xd0\xf4\x8d\xaa\x18\xbd\x3c\x46\x45\x12\x05\x37\xdb\x35\x8c\xf3\x12\x51\x92\xa1\x63\xfa\x5d\xb6\x1a\x4a\x76\xac\x29\x09\xd9\x2d\x99\x68\xe8\x37\x9c\x19\xb6\x91\x83\x33\x07\x48\x71\x6b\xbd\xe5\x39\x9c\xc9\xca\xa8\xb3\x6d\x49\x74\xce\x66\xf9\xae\xb1\x9f\x45\xea\x91\xeb\xe4\xd4\xcf\x17\x96\x95\xe4\x18\x00\x00\xff\xff\x4a\x91\xdd\x56\x94\x01\x00\x00")
+
+func _1644237000_initialize_dbUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1644237000_initialize_dbUpSql,
+		"1644237000_initialize_db.up.sql",
+	)
+}
+
+func _1644237000_initialize_dbUpSql() (*asset, error) {
+	bytes, err := _1644237000_initialize_dbUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1644237000_initialize_db.up.sql", size: 404, mode: os.FileMode(0644), modTime: time.Unix(1792284976, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x49, 0xdb, 0xc6, 0xe6, 0x38, 0xe7, 0xe0, 0xfd, 0xad, 0x69, 0xb0, 0xb1, 0x11, 0x4a, 0xe, 0x39, 0xf7, 0x3f, 0x10, 0x53, 0x68, 0xb7, 0x3, 0x25, 0xd1, 0x85, 0x3e, 0xc8, 0xc9, 0x29, 0x71, 0x28}}
+	return a, nil
+}
+
+var _staticGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xcc\x41\x6a\xc3\x40\x0c\x46\xe1\xfd\x9c\xe2\x5f\xb6\x50\x8f\xf6\x3d\x41\x29\x2d\x14\x9a\x0b\xc8\xb6\x90\x85\xed\x19\x47\x52\x72\xfe\x6c\x92\x45\xb2\x7c\xf0\xf8\x88\xf0\xc7\xd3\xca\x2a\x88\xe4\xb4\x09\xb2\x8f\x32\xc7\xa3\xde\xbe\xff\x3f\xf0\x75\xfa\xfd\x79\x87\x4b\xf4\x8b\x4f\x12\x70\xd3\x25\x61\x2d\x3b\x72\x11\x8c\xd6\xd8\x4d\xa2\x1c\x4f\x52\x29\x44\xda\x3f\x55\x9a\x38\xa7\x40\xfb\x30\x5a\x9b\x39\x19\xc3\xb1\x2a\xe2\xbc\x59\xca\x6e\xea\x9c\xd6\x5b\x60\xe8\xa8\x95\x6a\xa5\x9d\x6d\x0b\xf1\xab\x38\xbd\x4e\x74\x27\xaa\x76\xd4\x72\x0b\x00\x00\xff\xff\xea\x2e\x67\x99\xbe\x00\x00\x00")
+
+func staticGoBytes() ([]byte, error) {
+	return bindataRead(
+		_staticGo,
+		"static.go",
+	)
+}
+
+func staticGo() (*asset, error) {
+	bytes, err := staticGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "static.go", size: 190, mode: os.FileMode(0644), modTime: time.Unix(1792284976, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaa, 0x1a, 0x10, 0x68, 0xa2, 0x7f, 0x93, 0x4f, 0xf8, 0x5f, 0xae, 0xb4, 0x8e, 0xd6, 0xb6, 0x75, 0x2f, 0x34, 0x0, 0xd1, 0xb9, 0xf1, 0xa6, 0xb2, 0x31, 0x4c, 0x97, 0xd4, 0xf5, 0x47, 0xca, 0x7b}}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// AssetString returns the asset contents as a string (instead of a []byte).
+func AssetString(name string) (string, error) {
+	data, err := Asset(name)
+	return string(data), err
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// MustAssetString is like AssetString but panics when Asset would return an
+// error. It simplifies safe initialization of global variables.
+func MustAssetString(name string) string {
+	return string(MustAsset(name))
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetDigest returns the digest of the file with the given name. It returns an
+// error if the asset could not be found or the digest could not be loaded.
+func AssetDigest(name string) ([sha256.Size]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s can't read by error: %v", name, err)
+		}
+		return a.digest, nil
+	}
+	return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s not found", name)
+}
+
+// Digests returns a map of all known files and their checksums.
+func Digests() (map[string][sha256.Size]byte, error) {
+	mp := make(map[string][sha256.Size]byte, len(_bindata))
+	for name := range _bindata {
+		a, err := _bindata[name]()
+		if err != nil {
+			return nil, err
+		}
+		mp[name] = a.digest
+	}
+	return mp, nil
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"1644237000_initialize_db.down.sql": _1644237000_initialize_dbDownSql,
+
+	"1644237000_initialize_db.up.sql": _1644237000_initialize_dbUpSql,
+
+	"static.go": staticGo,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"},
+// AssetDir("data/img") would return []string{"a.png", "b.png"},
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error, and
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		canonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(canonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+
+var _bintree = &bintree{nil, map[string]*bintree{
+	"1644237000_initialize_db.down.sql": &bintree{_1644237000_initialize_dbDownSql, map[string]*bintree{}},
+	"1644237000_initialize_db.up.sql":   &bintree{_1644237000_initialize_dbUpSql, map[string]*bintree{}},
+	"static.go":                         &bintree{staticGo, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory.
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+}
+
+// RestoreAssets restores an asset under the given directory recursively.
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(canonicalName, "/")...)...)
+}
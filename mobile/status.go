@@ -23,6 +23,7 @@ import (
 	"github.com/planq-network/status-go/profiling"
 	protocol "github.com/planq-network/status-go/protocol"
 	"github.com/planq-network/status-go/protocol/images"
+	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/services/personal"
 	"github.com/planq-network/status-go/services/typeddata"
 	"github.com/planq-network/status-go/signal"
@@ -490,6 +491,36 @@ func SendTransactionWithSignature(txArgsJSON, sigString string) string {
 	return prepareJSONResponseWithCode(hash.String(), err, code)
 }
 
+// SpeedUpTransaction converts RPC args and calls backend.SpeedUpTransaction.
+func SpeedUpTransaction(txArgsJSON, password string) string {
+	var params transactions.ReplaceTxArgs
+	err := json.Unmarshal([]byte(txArgsJSON), &params)
+	if err != nil {
+		return prepareJSONResponseWithCode(nil, err, codeFailedParseParams)
+	}
+	hash, err := statusBackend.SpeedUpTransaction(params, password)
+	code := codeUnknown
+	if c, ok := errToCodeMap[err]; ok {
+		code = c
+	}
+	return prepareJSONResponseWithCode(hash.String(), err, code)
+}
+
+// CancelTransaction converts RPC args and calls backend.CancelTransaction.
+func CancelTransaction(txArgsJSON, password string) string {
+	var params transactions.ReplaceTxArgs
+	err := json.Unmarshal([]byte(txArgsJSON), &params)
+	if err != nil {
+		return prepareJSONResponseWithCode(nil, err, codeFailedParseParams)
+	}
+	hash, err := statusBackend.CancelTransaction(params, password)
+	code := codeUnknown
+	if c, ok := errToCodeMap[err]; ok {
+		code = c
+	}
+	return prepareJSONResponseWithCode(hash.String(), err, code)
+}
+
 // HashTransaction validate the transaction and returns new txArgs and the transaction hash.
 func HashTransaction(txArgsJSON string) string {
 	var params transactions.SendTxArgs
@@ -701,6 +732,39 @@ func ImportUnencryptedDatabase(accountData, password, databasePath string) strin
 	return makeJSONResponse(nil)
 }
 
+// ExportArchive exports the contacts, chats, messages, communities and settings
+// of the logged in account to a password encrypted archive
+func ExportArchive(requestJSON string) string {
+	var request requests.ExportArchive
+	err := json.Unmarshal([]byte(requestJSON), &request)
+	if err != nil {
+		return makeJSONResponse(err)
+	}
+	err = statusBackend.ExportArchive(&request)
+	if err != nil {
+		return makeJSONResponse(err)
+	}
+	return makeJSONResponse(nil)
+}
+
+// ImportArchive merges a password encrypted archive into the logged in account
+func ImportArchive(requestJSON string) string {
+	var request requests.ImportArchive
+	err := json.Unmarshal([]byte(requestJSON), &request)
+	if err != nil {
+		return makeJSONResponse(err)
+	}
+	response, err := statusBackend.ImportArchive(&request)
+	if err != nil {
+		return makeJSONResponse(err)
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return makeJSONResponse(err)
+	}
+	return string(data)
+}
+
 func ChangeDatabasePassword(keyUID, password, newPassword string) string {
 	err := statusBackend.ChangeDatabasePassword(keyUID, password, newPassword)
 	if err != nil {
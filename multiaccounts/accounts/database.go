@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"reflect"
+	"strings"
 
 	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/nodecfg"
@@ -143,6 +145,12 @@ type Settings struct {
 	BackupEnabled                  bool                          `json:"backup-enabled?,omitempty"`
 	AutoMessageEnabled             bool                          `json:"auto-message-enabled?,omitempty"`
 	GifAPIKey                      string                        `json:"gifs/api-key"`
+	// SendReadReceipts indicates whether we let the other members of a chat
+	// know which of their messages we read, it can be overridden per chat
+	SendReadReceipts bool `json:"send-read-receipts?,omitempty"`
+	// SendTypingIndicators indicates whether we let the other members of a
+	// chat know when we are typing, it can be overridden per chat
+	SendTypingIndicators bool `json:"send-typing-indicators?,omitempty"`
 }
 
 func NewDB(db *sql.DB) *Database {
@@ -487,6 +495,18 @@ func (db *Database) SaveSetting(setting string, value interface{}) error {
 			return ErrInvalidConfig
 		}
 		update, err = db.db.Prepare("UPDATE settings SET gif_api_key = ? WHERE synthetic_id = 'id'")
+	case "send-read-receipts?":
+		_, ok := value.(bool)
+		if !ok {
+			return ErrInvalidConfig
+		}
+		update, err = db.db.Prepare("UPDATE settings SET send_read_receipts = ? WHERE synthetic_id = 'id'")
+	case "send-typing-indicators?":
+		_, ok := value.(bool)
+		if !ok {
+			return ErrInvalidConfig
+		}
+		update, err = db.db.Prepare("UPDATE settings SET send_typing_indicators = ? WHERE synthetic_id = 'id'")
 	default:
 		return ErrInvalidConfig
 	}
@@ -500,7 +520,7 @@ func (db *Database) SaveSetting(setting string, value interface{}) error {
 
 func (db *Database) GetSettings() (Settings, error) {
 	var s Settings
-	err := db.db.QueryRow("SELECT address, anon_metrics_should_send, chaos_mode, currency, current_network, custom_bootnodes, custom_bootnodes_enabled, dapps_address, eip1581_address, fleet, hide_home_tooltip, installation_id, key_uid, keycard_instance_uid, keycard_paired_on, keycard_pairing, last_updated, latest_derived_path, link_preview_request_enabled, link_previews_enabled_sites, log_level, mnemonic, name, networks, notifications_enabled, push_notifications_server_enabled, push_notifications_from_contacts_only, remote_push_notifications_enabled, send_push_notifications, push_notifications_block_mentions, photo_path, pinned_mailservers, preferred_name, preview_privacy, public_key, remember_syncing_choice, signing_phrase, stickers_packs_installed, stickers_packs_pending, stickers_recent_stickers, syncing_on_mobile_network, default_sync_period, use_mailservers, messages_from_contacts_only, usernames, appearance, profile_pictures_show_to, profile_pictures_visibility, wallet_root_address, wallet_set_up_passed, wallet_visible_tokens, waku_bloom_filter_mode, webview_allow_permission_requests, current_user_status, send_status_updates, gif_recents, gif_favorites, opensea_enabled, last_backup, backup_enabled, telemetry_server_url, auto_message_enabled, gif_api_key FROM settings WHERE synthetic_id = 'id'").Scan(
+	err := db.db.QueryRow("SELECT address, anon_metrics_should_send, chaos_mode, currency, current_network, custom_bootnodes, custom_bootnodes_enabled, dapps_address, eip1581_address, fleet, hide_home_tooltip, installation_id, key_uid, keycard_instance_uid, keycard_paired_on, keycard_pairing, last_updated, latest_derived_path, link_preview_request_enabled, link_previews_enabled_sites, log_level, mnemonic, name, networks, notifications_enabled, push_notifications_server_enabled, push_notifications_from_contacts_only, remote_push_notifications_enabled, send_push_notifications, push_notifications_block_mentions, photo_path, pinned_mailservers, preferred_name, preview_privacy, public_key, remember_syncing_choice, signing_phrase, stickers_packs_installed, stickers_packs_pending, stickers_recent_stickers, syncing_on_mobile_network, default_sync_period, use_mailservers, messages_from_contacts_only, usernames, appearance, profile_pictures_show_to, profile_pictures_visibility, wallet_root_address, wallet_set_up_passed, wallet_visible_tokens, waku_bloom_filter_mode, webview_allow_permission_requests, current_user_status, send_status_updates, gif_recents, gif_favorites, opensea_enabled, last_backup, backup_enabled, telemetry_server_url, auto_message_enabled, gif_api_key, send_read_receipts, send_typing_indicators FROM settings WHERE synthetic_id = 'id'").Scan(
 		&s.Address,
 		&s.AnonMetricsShouldSend,
 		&s.ChaosMode,
@@ -564,6 +584,8 @@ func (db *Database) GetSettings() (Settings, error) {
 		&s.TelemetryServerURL,
 		&s.AutoMessageEnabled,
 		&s.GifAPIKey,
+		&s.SendReadReceipts,
+		&s.SendTypingIndicators,
 	)
 
 	return s, err
@@ -853,6 +875,26 @@ func (db *Database) AutoMessageEnabled() (bool, error) {
 	return result, err
 }
 
+func (db *Database) SendReadReceipts() (bool, error) {
+	var result bool
+	err := db.db.QueryRow("SELECT send_read_receipts FROM settings WHERE synthetic_id = 'id'").Scan(&result)
+	// Read receipts are opt in
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return result, err
+}
+
+func (db *Database) SendTypingIndicators() (bool, error) {
+	var result bool
+	err := db.db.QueryRow("SELECT send_typing_indicators FROM settings WHERE synthetic_id = 'id'").Scan(&result)
+	// Typing indicators are opt in
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return result, err
+}
+
 func (db *Database) LastBackup() (uint64, error) {
 	var result uint64
 	err := db.db.QueryRow("SELECT last_backup FROM settings WHERE synthetic_id = 'id'").Scan(&result)
@@ -881,6 +923,89 @@ func (db *Database) BackupFetched() (bool, error) {
 	return result, err
 }
 
+// backupSettings are the settings included in the backups of the account,
+// settings specific to a device and secrets are left out
+var backupSettings = []string{
+	"appearance",
+	"auto-message-enabled?",
+	"currency",
+	"default-sync-period",
+	"gifs/favorite-gifs",
+	"gifs/recent-gifs",
+	"link-preview-request-enabled",
+	"link-previews-enabled-sites",
+	"messages-from-contacts-only",
+	"opensea-enabled?",
+	"preferred-name",
+	"preview-privacy?",
+	"profile-pictures-show-to",
+	"profile-pictures-visibility",
+	"push-notifications-block-mentions?",
+	"push-notifications-from-contacts-only?",
+	"send-read-receipts?",
+	"send-status-updates?",
+	"send-typing-indicators?",
+	"stickers/packs-installed",
+	"stickers/recent-stickers",
+	"usernames",
+	"wallet/visible-tokens",
+}
+
+func isBackupSetting(setting string) bool {
+	for _, s := range backupSettings {
+		if s == setting {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBackupSettings returns the JSON encoded values of the settings included
+// in the backups, unset settings are omitted
+func (db *Database) GetBackupSettings() (map[string]json.RawMessage, error) {
+	settings, err := db.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]json.RawMessage)
+	value := reflect.ValueOf(settings)
+	for i := 0; i < value.NumField(); i++ {
+		setting := strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0]
+		if !isBackupSetting(setting) {
+			continue
+		}
+
+		field := value.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			continue
+		}
+
+		encoded, err := json.Marshal(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+		result[setting] = encoded
+	}
+	return result, nil
+}
+
+// SaveBackupSetting restores a setting from a backup, settings that are not
+// included in the backups are rejected
+func (db *Database) SaveBackupSetting(setting string, value json.RawMessage) error {
+	if !isBackupSetting(setting) {
+		return ErrInvalidConfig
+	}
+
+	var decoded interface{}
+	err := json.Unmarshal(value, &decoded)
+	if err != nil {
+		return err
+	}
+
+	return db.SaveSetting(setting, decoded)
+}
+
 func (db *Database) ENSName() (string, error) {
 	var result sql.NullString
 	err := db.db.QueryRow("SELECT preferred_name FROM settings WHERE synthetic_id = 'id'").Scan(&result)
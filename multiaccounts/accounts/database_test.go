@@ -80,6 +80,65 @@ func TestSaveSetting(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBackupSettings(t *testing.T) {
+	db, stop := setupTestDB(t)
+	defer stop()
+
+	require.NoError(t, db.CreateSettings(settings, config))
+	require.NoError(t, db.SaveSetting("currency", "eur"))
+
+	values, err := db.GetBackupSettings()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`"eur"`), values["currency"])
+	require.Equal(t, json.RawMessage(`1`), values["profile-pictures-show-to"])
+	// Device specific settings are not backed up
+	_, ok := values["installation-id"]
+	require.False(t, ok)
+
+	restored, stopRestored := setupTestDB(t)
+	defer stopRestored()
+
+	require.NoError(t, restored.CreateSettings(settings, config))
+	for setting, value := range values {
+		require.NoError(t, restored.SaveBackupSetting(setting, value))
+	}
+
+	s, err := restored.GetSettings()
+	require.NoError(t, err)
+	require.Equal(t, "eur", s.Currency)
+	require.Equal(t, ProfilePicturesShowToContactsOnly, s.ProfilePicturesShowTo)
+
+	require.Equal(t, ErrInvalidConfig, restored.SaveBackupSetting("mnemonic", json.RawMessage(`"words"`)))
+}
+
+func TestReadReceiptsAndTypingIndicatorsSettings(t *testing.T) {
+	db, stop := setupTestDB(t)
+	defer stop()
+
+	require.NoError(t, db.CreateSettings(settings, config))
+
+	// Both are opt in
+	enabled, err := db.SendReadReceipts()
+	require.NoError(t, err)
+	require.False(t, enabled)
+	enabled, err = db.SendTypingIndicators()
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	require.NoError(t, db.SaveSetting("send-read-receipts?", true))
+	require.NoError(t, db.SaveSetting("send-typing-indicators?", true))
+	require.Equal(t, ErrInvalidConfig, db.SaveSetting("send-read-receipts?", "yes"))
+
+	enabled, err = db.SendReadReceipts()
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	s, err := db.GetSettings()
+	require.NoError(t, err)
+	require.True(t, s.SendReadReceipts)
+	require.True(t, s.SendTypingIndicators)
+}
+
 func TestSaveAccounts(t *testing.T) {
 	type testCase struct {
 		description string
@@ -25,6 +25,7 @@ import (
 	"github.com/planq-network/status-go/connection"
 	"github.com/planq-network/status-go/db"
 	"github.com/planq-network/status-go/discovery"
+	"github.com/planq-network/status-go/mailserver"
 	"github.com/planq-network/status-go/multiaccounts"
 	"github.com/planq-network/status-go/params"
 	"github.com/planq-network/status-go/peers"
@@ -108,6 +109,7 @@ type StatusNode struct {
 	personalSrvc           *personal.Service
 	timeSourceSrvc         *timesource.NTPTimeSource
 	wakuSrvc               *waku.Waku
+	wakuMailServer         *mailserver.WakuMailServer
 	wakuExtSrvc            *wakuext.Service
 	wakuV2Srvc             *wakuv2.Waku
 	wakuV2ExtSrvc          *wakuv2ext.Service
@@ -418,6 +420,7 @@ func (n *StatusNode) stop() error {
 	n.personalSrvc = nil
 	n.timeSourceSrvc = nil
 	n.wakuSrvc = nil
+	n.wakuMailServer = nil
 	n.wakuExtSrvc = nil
 	n.wakuV2Srvc = nil
 	n.wakuV2ExtSrvc = nil
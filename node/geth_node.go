@@ -118,6 +118,11 @@ func newGethNodeConfig(config *params.NodeConfig) (*node.Config, error) {
 		nc.P2P.StaticNodes = parseNodes(config.ClusterConfig.StaticNodes)
 	}
 
+	if config.WakuConfig.EnableMailServer {
+		// the mail servers envelopes are replicated from must stay connected
+		nc.P2P.StaticNodes = append(nc.P2P.StaticNodes, parseNodes(config.WakuConfig.MailServerReplicationPeers)...)
+	}
+
 	if config.NodeKey != "" {
 		sk, err := crypto.HexToECDSA(config.NodeKey)
 		if err != nil {
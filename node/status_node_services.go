@@ -82,6 +82,12 @@ func (b *StatusNode) initServices(config *params.NodeConfig) error {
 
 		services = append(services, wakuService)
 
+		if b.wakuMailServer != nil {
+			if err := b.registerMailServerAdmin(&config.WakuConfig); err != nil {
+				return err
+			}
+		}
+
 		wakuext, err := b.wakuExtService(config)
 		if err != nil {
 			return err
@@ -110,7 +116,7 @@ func (b *StatusNode) initServices(config *params.NodeConfig) error {
 	}
 
 	if config.WalletConfig.Enabled {
-		walletService := b.walletService(accountsFeed, config.WalletConfig.OpenseaAPIKey)
+		walletService := b.walletService(accountsFeed, config.WalletConfig.OpenseaAPIKey, config.WalletConfig.IPFSGateway)
 		services = append(services, walletService)
 	}
 
@@ -155,7 +161,7 @@ func (b *StatusNode) wakuExtService(config *params.NodeConfig) (*wakuext.Service
 	}
 
 	if b.wakuExtSrvc == nil {
-		b.wakuExtSrvc = wakuext.New(*config, b.nodeBridge(), ext.EnvelopeSignalHandler{}, b.db)
+		b.wakuExtSrvc = wakuext.New(*config, b.nodeBridge(), b.rpcClient, ext.EnvelopeSignalHandler{}, b.db)
 	}
 
 	b.wakuExtSrvc.SetP2PServer(b.gethNode.Server())
@@ -167,7 +173,7 @@ func (b *StatusNode) wakuV2ExtService(config *params.NodeConfig) (*wakuv2ext.Ser
 		return nil, errors.New("geth node not initialized")
 	}
 	if b.wakuV2ExtSrvc == nil {
-		b.wakuV2ExtSrvc = wakuv2ext.New(*config, b.nodeBridge(), ext.EnvelopeSignalHandler{}, b.db)
+		b.wakuV2ExtSrvc = wakuv2ext.New(*config, b.nodeBridge(), b.rpcClient, ext.EnvelopeSignalHandler{}, b.db)
 	}
 
 	b.wakuV2ExtSrvc.SetP2PServer(b.gethNode.Server())
@@ -231,9 +237,11 @@ func (b *StatusNode) wakuService(wakuCfg *params.WakuConfig, clusterCfg *params.
 
 		// enable mail service
 		if wakuCfg.EnableMailServer {
-			if err := registerWakuMailServer(w, wakuCfg); err != nil {
+			mailServer, err := registerWakuMailServer(w, wakuCfg)
+			if err != nil {
 				return nil, fmt.Errorf("failed to register WakuMailServer: %v", err)
 			}
+			b.wakuMailServer = mailServer
 		}
 
 		if wakuCfg.LightClient {
@@ -406,9 +414,9 @@ func (b *StatusNode) appmetricsService() common.StatusService {
 	return b.appMetricsSrvc
 }
 
-func (b *StatusNode) walletService(accountsFeed *event.Feed, openseaAPIKey string) common.StatusService {
+func (b *StatusNode) walletService(accountsFeed *event.Feed, openseaAPIKey string, ipfsGateway string) common.StatusService {
 	if b.walletSrvc == nil {
-		b.walletSrvc = wallet.NewService(b.appDB, b.rpcClient, accountsFeed, openseaAPIKey)
+		b.walletSrvc = wallet.NewService(b.appDB, b.rpcClient, accountsFeed, openseaAPIKey, ipfsGateway)
 	}
 	return b.walletSrvc
 }
@@ -427,11 +435,35 @@ func (b *StatusNode) peerService() *peer.Service {
 	return b.peerSrvc
 }
 
-func registerWakuMailServer(wakuService *waku.Waku, config *params.WakuConfig) (err error) {
+func registerWakuMailServer(wakuService *waku.Waku, config *params.WakuConfig) (*mailserver.WakuMailServer, error) {
 	var mailServer mailserver.WakuMailServer
 	wakuService.RegisterMailServer(&mailServer)
 
-	return mailServer.Init(wakuService, config)
+	return &mailServer, mailServer.Init(wakuService, config)
+}
+
+// registerMailServerAdmin serves the mailserver admin API over IPC and, with
+// a token, over HTTP to authenticated clients
+func (b *StatusNode) registerMailServerAdmin(wakuCfg *params.WakuConfig) error {
+	api := mailserver.NewAdminAPI(b.wakuMailServer)
+	b.gethNode.RegisterAPIs([]gethrpc.API{
+		{
+			Namespace: mailserver.AdminNamespace,
+			Version:   "1.0",
+			Service:   api,
+			Public:    false,
+		},
+	})
+
+	if wakuCfg.MailServerAdminToken == "" {
+		return nil
+	}
+	handler, err := mailserver.NewAdminHandler(api, wakuCfg.MailServerAdminToken)
+	if err != nil {
+		return err
+	}
+	b.gethNode.RegisterHandler("mailserver admin", "/"+mailserver.AdminNamespace, handler)
+	return nil
 }
 
 func appendIf(condition bool, services []common.StatusService, service common.StatusService) []common.StatusService {
@@ -445,6 +477,10 @@ func (b *StatusNode) RPCFiltersService() *rpcfilters.Service {
 	return b.rpcFiltersSrvc
 }
 
+func (b *StatusNode) WalletService() *wallet.Service {
+	return b.walletSrvc
+}
+
 func (b *StatusNode) StopLocalNotifications() error {
 	if b.localNotificationsSrvc == nil {
 		return nil
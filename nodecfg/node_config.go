@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/p2p/discv5"
 	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/params"
 	"github.com/planq-network/status-go/sqlite"
 )
@@ -249,12 +250,12 @@ func insertWakuConfig(tx *sql.Tx, c *params.NodeConfig) error {
 	INSERT OR REPLACE INTO waku_config (
 		enabled, light_client, full_node, enable_mailserver, data_dir, minimum_pow, mailserver_password, mailserver_rate_limit, mailserver_data_retention,
 		ttl, max_message_size, enable_rate_limiter, packet_rate_limit_ip, packet_rate_limit_peer_id, bytes_rate_limit_ip, bytes_rate_limit_peer_id,
-		rate_limit_tolerance, bloom_filter_mode, enable_confirmations, synthetic_id
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'id')`,
+		rate_limit_tolerance, bloom_filter_mode, enable_confirmations, mailserver_storage_quota, mailserver_admin_token, synthetic_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'id')`,
 		c.WakuConfig.Enabled, c.WakuConfig.LightClient, c.WakuConfig.FullNode, c.WakuConfig.EnableMailServer, c.WakuConfig.DataDir, c.WakuConfig.MinimumPoW,
 		c.WakuConfig.MailServerPassword, c.WakuConfig.MailServerRateLimit, c.WakuConfig.MailServerDataRetention, c.WakuConfig.TTL, c.WakuConfig.MaxMessageSize,
 		c.WakuConfig.EnableRateLimiter, c.WakuConfig.PacketRateLimitIP, c.WakuConfig.PacketRateLimitPeerID, c.WakuConfig.BytesRateLimitIP, c.WakuConfig.BytesRateLimitPeerID,
-		c.WakuConfig.RateLimitTolerance, c.WakuConfig.BloomFilterMode, c.WakuConfig.EnableConfirmations,
+		c.WakuConfig.RateLimitTolerance, c.WakuConfig.BloomFilterMode, c.WakuConfig.EnableConfirmations, c.WakuConfig.MailServerStorageQuota, c.WakuConfig.MailServerAdminToken,
 	)
 	if err != nil {
 		return err
@@ -264,6 +265,14 @@ func insertWakuConfig(tx *sql.Tx, c *params.NodeConfig) error {
 		return err
 	}
 
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO waku_config_db_sqlite (enabled, synthetic_id) VALUES (?, 'id')`, c.WakuConfig.DatabaseConfig.SQLiteConfig.Enabled); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO waku_config_db_badger (enabled, synthetic_id) VALUES (?, 'id')`, c.WakuConfig.DatabaseConfig.BadgerConfig.Enabled); err != nil {
+		return err
+	}
+
 	if _, err := tx.Exec(`DELETE FROM waku_softblacklisted_peers WHERE synthetic_id = 'id'`); err != nil {
 		return err
 	}
@@ -274,6 +283,39 @@ func insertWakuConfig(tx *sql.Tx, c *params.NodeConfig) error {
 			return err
 		}
 	}
+
+	if _, err := tx.Exec(`DELETE FROM waku_config_topic_retention WHERE synthetic_id = 'id'`); err != nil {
+		return err
+	}
+
+	for topic, days := range c.WakuConfig.MailServerTopicRetention {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO waku_config_topic_retention (topic, days, synthetic_id) VALUES (?, ?, 'id')`, topic.String(), days)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM waku_config_replication_peers WHERE synthetic_id = 'id'`); err != nil {
+		return err
+	}
+
+	for _, node := range c.WakuConfig.MailServerReplicationPeers {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO waku_config_replication_peers (node, synthetic_id) VALUES (?, 'id')`, node)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM waku_config_replication_topics WHERE synthetic_id = 'id'`); err != nil {
+		return err
+	}
+
+	for _, topic := range c.WakuConfig.MailServerReplicationTopics {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO waku_config_replication_topics (topic, synthetic_id) VALUES (?, 'id')`, topic.String())
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -282,7 +324,16 @@ func insertPushNotificationsServerConfig(tx *sql.Tx, c *params.NodeConfig) error
 	if c.PushNotificationServerConfig.Identity != nil {
 		hexPrivKey = hexutil.Encode(crypto.FromECDSA(c.PushNotificationServerConfig.Identity))
 	}
-	_, err := tx.Exec(`INSERT OR REPLACE INTO push_notifications_server_config (enabled, identity, gorush_url, synthetic_id) VALUES (?, ?, ?, 'id')`, c.PushNotificationServerConfig.Enabled, hexPrivKey, c.PushNotificationServerConfig.GorushURL)
+	apns := c.PushNotificationServerConfig.APNs
+	fcm := c.PushNotificationServerConfig.FCM
+	_, err := tx.Exec(`
+	INSERT OR REPLACE INTO push_notifications_server_config (
+		enabled, identity, gorush_url, apns_enabled, apns_key_file, apns_key_id, apns_team_id, apns_development, apns_url,
+		fcm_enabled, fcm_credentials_file, fcm_url, synthetic_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'id')`,
+		c.PushNotificationServerConfig.Enabled, hexPrivKey, c.PushNotificationServerConfig.GorushURL, apns.Enabled, apns.KeyFile, apns.KeyID, apns.TeamID, apns.Development, apns.URL,
+		fcm.Enabled, fcm.CredentialsFile, fcm.URL,
+	)
 	return err
 }
 
@@ -560,7 +611,16 @@ func loadNodeConfig(tx *sql.Tx) (*params.NodeConfig, error) {
 	}
 
 	var pushNotifHexIdentity string
-	err = tx.QueryRow("SELECT enabled, identity, gorush_url FROM push_notifications_server_config WHERE synthetic_id = 'id'").Scan(&nodecfg.PushNotificationServerConfig.Enabled, &pushNotifHexIdentity, &nodecfg.PushNotificationServerConfig.GorushURL)
+	apns := &nodecfg.PushNotificationServerConfig.APNs
+	fcm := &nodecfg.PushNotificationServerConfig.FCM
+	err = tx.QueryRow(`
+	SELECT enabled, identity, gorush_url, apns_enabled, apns_key_file, apns_key_id, apns_team_id, apns_development, apns_url,
+	fcm_enabled, fcm_credentials_file, fcm_url
+	FROM push_notifications_server_config WHERE synthetic_id = 'id'
+	`).Scan(
+		&nodecfg.PushNotificationServerConfig.Enabled, &pushNotifHexIdentity, &nodecfg.PushNotificationServerConfig.GorushURL, &apns.Enabled, &apns.KeyFile, &apns.KeyID, &apns.TeamID, &apns.Development, &apns.URL,
+		&fcm.Enabled, &fcm.CredentialsFile, &fcm.URL,
+	)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -650,13 +710,13 @@ func loadNodeConfig(tx *sql.Tx) (*params.NodeConfig, error) {
 	err = tx.QueryRow(`
 	SELECT enabled, light_client, full_node, enable_mailserver, data_dir, minimum_pow, mailserver_password, mailserver_rate_limit, mailserver_data_retention,
 	ttl, max_message_size, enable_rate_limiter, packet_rate_limit_ip, packet_rate_limit_peer_id, bytes_rate_limit_ip, bytes_rate_limit_peer_id,
-	rate_limit_tolerance, bloom_filter_mode, enable_confirmations
+	rate_limit_tolerance, bloom_filter_mode, enable_confirmations, mailserver_storage_quota, mailserver_admin_token
 	FROM waku_config WHERE synthetic_id = 'id'
 	`).Scan(
 		&nodecfg.WakuConfig.Enabled, &nodecfg.WakuConfig.LightClient, &nodecfg.WakuConfig.FullNode, &nodecfg.WakuConfig.EnableMailServer, &nodecfg.WakuConfig.DataDir, &nodecfg.WakuConfig.MinimumPoW,
 		&nodecfg.WakuConfig.MailServerPassword, &nodecfg.WakuConfig.MailServerRateLimit, &nodecfg.WakuConfig.MailServerDataRetention, &nodecfg.WakuConfig.TTL, &nodecfg.WakuConfig.MaxMessageSize,
 		&nodecfg.WakuConfig.EnableRateLimiter, &nodecfg.WakuConfig.PacketRateLimitIP, &nodecfg.WakuConfig.PacketRateLimitPeerID, &nodecfg.WakuConfig.BytesRateLimitIP, &nodecfg.WakuConfig.BytesRateLimitPeerID,
-		&nodecfg.WakuConfig.RateLimitTolerance, &nodecfg.WakuConfig.BloomFilterMode, &nodecfg.WakuConfig.EnableConfirmations,
+		&nodecfg.WakuConfig.RateLimitTolerance, &nodecfg.WakuConfig.BloomFilterMode, &nodecfg.WakuConfig.EnableConfirmations, &nodecfg.WakuConfig.MailServerStorageQuota, &nodecfg.WakuConfig.MailServerAdminToken,
 	)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
@@ -667,6 +727,16 @@ func loadNodeConfig(tx *sql.Tx) (*params.NodeConfig, error) {
 		return nil, err
 	}
 
+	err = tx.QueryRow("SELECT enabled FROM waku_config_db_sqlite WHERE synthetic_id = 'id'").Scan(&nodecfg.WakuConfig.DatabaseConfig.SQLiteConfig.Enabled)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	err = tx.QueryRow("SELECT enabled FROM waku_config_db_badger WHERE synthetic_id = 'id'").Scan(&nodecfg.WakuConfig.DatabaseConfig.BadgerConfig.Enabled)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
 	rows, err = tx.Query(`SELECT peer_id FROM waku_softblacklisted_peers WHERE synthetic_id = 'id' ORDER BY peer_id ASC`)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
@@ -681,6 +751,58 @@ func loadNodeConfig(tx *sql.Tx) (*params.NodeConfig, error) {
 		nodecfg.WakuConfig.SoftBlacklistedPeerIDs = append(nodecfg.WakuConfig.SoftBlacklistedPeerIDs, peerID)
 	}
 
+	rows, err = tx.Query(`SELECT topic, days FROM waku_config_topic_retention WHERE synthetic_id = 'id' ORDER BY topic ASC`)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	defer rows.Close()
+	nodecfg.WakuConfig.MailServerTopicRetention = make(map[types.TopicType]int)
+	for rows.Next() {
+		var topic string
+		var days int
+		err = rows.Scan(&topic, &days)
+		if err != nil {
+			return nil, err
+		}
+		var t types.TopicType
+		if err = t.UnmarshalText([]byte(topic)); err != nil {
+			return nil, err
+		}
+		nodecfg.WakuConfig.MailServerTopicRetention[t] = days
+	}
+
+	rows, err = tx.Query(`SELECT node FROM waku_config_replication_peers WHERE synthetic_id = 'id' ORDER BY node ASC`)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var node string
+		err = rows.Scan(&node)
+		if err != nil {
+			return nil, err
+		}
+		nodecfg.WakuConfig.MailServerReplicationPeers = append(nodecfg.WakuConfig.MailServerReplicationPeers, node)
+	}
+
+	rows, err = tx.Query(`SELECT topic FROM waku_config_replication_topics WHERE synthetic_id = 'id' ORDER BY topic ASC`)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var topic string
+		err = rows.Scan(&topic)
+		if err != nil {
+			return nil, err
+		}
+		var t types.TopicType
+		if err = t.UnmarshalText([]byte(topic)); err != nil {
+			return nil, err
+		}
+		nodecfg.WakuConfig.MailServerReplicationTopics = append(nodecfg.WakuConfig.MailServerReplicationTopics, t)
+	}
+
 	return nodecfg, nil
 }
 
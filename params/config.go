@@ -52,7 +52,9 @@ type LightEthConfig struct {
 // ----------
 
 type DatabaseConfig struct {
-	PGConfig PGConfig
+	PGConfig     PGConfig
+	SQLiteConfig SQLiteConfig
+	BadgerConfig BadgerConfig
 }
 
 // ----------
@@ -66,6 +68,25 @@ type PGConfig struct {
 	URI string
 }
 
+// ----------
+// SQLiteConfig
+// ----------
+
+type SQLiteConfig struct {
+	// Enabled whether we should use an SQLite database in the data directory
+	Enabled bool
+}
+
+// ----------
+// BadgerConfig
+// ----------
+
+type BadgerConfig struct {
+	// Enabled whether we should use a Badger database in the data directory,
+	// status-go must be built with the badger tag
+	Enabled bool
+}
+
 // ----------
 // WakuConfig
 // ----------
@@ -102,6 +123,27 @@ type WakuConfig struct {
 	// MailServerDataRetention is a number of days data should be stored by MailServer.
 	MailServerDataRetention int
 
+	// MailServerTopicRetention is a number of days data of a topic should be stored by
+	// MailServer, overriding MailServerDataRetention. 0 keeps data of the topic forever.
+	MailServerTopicRetention map[types.TopicType]int
+
+	// MailServerStorageQuota is the maximum size in bytes of the data stored by MailServer,
+	// the oldest data is removed when it's exceeded. 0 means no quota.
+	MailServerStorageQuota int64
+
+	// MailServerAdminToken is a bearer token authenticating requests to the mailserver admin
+	// API over HTTP. Without it, the admin API is only available over IPC.
+	MailServerAdminToken string
+
+	// MailServerReplicationPeers are enode URLs of mail servers whose envelopes missing from
+	// MailServer are pulled periodically. The requests to each of them are paced by
+	// MailServerRateLimit, which is expected to be the same on the peers.
+	MailServerReplicationPeers []string
+
+	// MailServerReplicationTopics are the topics pulled from MailServerReplicationPeers.
+	// All topics are pulled if empty.
+	MailServerReplicationTopics []types.TopicType
+
 	// TTL time to live for messages, in seconds
 	TTL int
 
@@ -505,6 +547,8 @@ type Network struct {
 type WalletConfig struct {
 	Enabled       bool
 	OpenseaAPIKey string `json:"OpenseaAPIKey"`
+	// IPFSGateway is the gateway used to fetch collectibles metadata stored on IPFS
+	IPFSGateway string `json:"IPFSGateway"`
 }
 
 // LocalNotificationsConfig extra configuration for localnotifications.Service.
@@ -887,14 +931,13 @@ func loadConfigFromAsset(name string, config *NodeConfig) error {
 //
 // A single error for a struct:
 //
-//   type TestStruct struct {
-//       TestField string `validate:"required"`
-//   }
+//	type TestStruct struct {
+//	    TestField string `validate:"required"`
+//	}
 //
 // has the following format:
 //
-//   Key: 'TestStruct.TestField' Error:Field validation for 'TestField' failed on the 'required' tag
-//
+//	Key: 'TestStruct.TestField' Error:Field validation for 'TestField' failed on the 'required' tag
 func (c *NodeConfig) Validate() error {
 	validate := NewValidator()
 
@@ -938,6 +981,13 @@ func (c *NodeConfig) Validate() error {
 		return fmt.Errorf("PFSEnabled is true, but InstallationID is empty")
 	}
 
+	// the mailserver admin API is served with authentication only, see MailServerAdminToken
+	for _, module := range c.FormatAPIModules() {
+		if module == MailServerAdminAPIModule {
+			return fmt.Errorf("APIModules can't include %s, it's served with MailServerAdminToken", MailServerAdminAPIModule)
+		}
+	}
+
 	if len(c.ClusterConfig.RendezvousNodes) == 0 && c.Rendezvous {
 		return fmt.Errorf("Rendezvous is enabled, but ClusterConfig.RendezvousNodes is empty")
 	}
@@ -227,6 +227,17 @@ func TestNodeConfigValidate(t *testing.T) {
 			}`,
 			Error: "PFSEnabled is true, but InstallationID is empty",
 		},
+		{
+			Name: "Validate that the mailserver admin API is not in APIModules",
+			Config: `{
+				"NetworkId": 1,
+				"DataDir": "/some/dir",
+				"KeyStoreDir": "/some/dir",
+				"NoDiscovery": true,
+				"APIModules": "eth,net,mailserveradmin"
+			}`,
+			Error: "APIModules can't include mailserveradmin, it's served with MailServerAdminToken",
+		},
 		{
 			Name: "Default HTTP virtual hosts is localhost and CORS is empty",
 			Config: `{
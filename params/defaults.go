@@ -18,6 +18,9 @@ const (
 	// PersonalRecoverMethodName defines the name for `personal.recover` API.
 	PersonalRecoverMethodName = "personal_ecRecover"
 
+	// MailServerAdminAPIModule is the namespace of the mailserver admin API.
+	MailServerAdminAPIModule = "mailserveradmin"
+
 	// DefaultGas default amount of gas used for transactions
 	DefaultGas = 180000
 
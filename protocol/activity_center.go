@@ -20,6 +20,7 @@ const (
 	ActivityCenterNotificationTypeNewPrivateGroupChat
 	ActivityCenterNotificationTypeMention
 	ActivityCenterNotificationTypeReply
+	ActivityCenterNotificationTypeThreadReply
 )
 
 var ErrInvalidActivityCenterNotification = errors.New("invalid activity center notification")
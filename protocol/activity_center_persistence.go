@@ -297,6 +297,18 @@ func (db sqlitePersistence) GetToProcessActivityCenterNotificationIds() ([][]byt
 	return db.runActivityCenterIDQuery("SELECT a.id FROM activity_center_notifications a WHERE NOT a.dismissed AND NOT a.accepted")
 }
 
+func (db sqlitePersistence) GetReadActivityCenterNotificationIds() ([][]byte, error) {
+	return db.runActivityCenterIDQuery("SELECT a.id FROM activity_center_notifications a WHERE a.read")
+}
+
+func (db sqlitePersistence) GetAcceptedActivityCenterNotificationIds() ([][]byte, error) {
+	return db.runActivityCenterIDQuery("SELECT a.id FROM activity_center_notifications a WHERE a.accepted")
+}
+
+func (db sqlitePersistence) GetDismissedActivityCenterNotificationIds() ([][]byte, error) {
+	return db.runActivityCenterIDQuery("SELECT a.id FROM activity_center_notifications a WHERE a.dismissed")
+}
+
 func (db sqlitePersistence) HasPendingNotificationsForChat(chatID string) (bool, error) {
 	rows, err := db.db.Query("SELECT 1 FROM activity_center_notifications a WHERE a.chat_id = ? AND NOT a.dismissed AND NOT a.accepted", chatID)
 	if err != nil {
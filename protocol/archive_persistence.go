@@ -0,0 +1,140 @@
+package protocol
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/planq-network/status-go/protocol/common"
+)
+
+// ArchiveMessages returns the messages sent in the given interval, as unix
+// timestamps in milliseconds, 0 means unbounded
+func (db sqlitePersistence) ArchiveMessages(from, to uint64) ([]*common.Message, error) {
+	conditions := []string{"NOT(m1.hide)"}
+	var args []interface{}
+	if from != 0 {
+		conditions = append(conditions, "m1.timestamp >= ?")
+		args = append(args, from)
+	}
+	if to != 0 {
+		conditions = append(conditions, "m1.timestamp <= ?")
+		args = append(args, to)
+	}
+
+	// nolint: gosec
+	rows, err := db.db.Query(fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				user_messages m1
+			LEFT JOIN
+				user_messages m2
+			ON
+			m1.response_to = m2.id
+
+			LEFT JOIN
+			      contacts c
+			ON
+
+			m1.source = c.id
+			WHERE %s
+			ORDER BY m1.clock_value ASC, m1.id ASC`, db.tableUserMessagesAllFieldsJoin(), strings.Join(conditions, " AND ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*common.Message
+	for rows.Next() {
+		var message common.Message
+		if err := db.tableUserMessagesScanAllFields(rows, &message); err != nil {
+			return nil, err
+		}
+		result = append(result, &message)
+	}
+
+	return result, rows.Err()
+}
+
+// MergeArchiveMessages saves the messages imported from an archive.
+// Messages we don't have are inserted, the ones we have are replaced only
+// if the archive has a later edit or a deletion, the seen state of the
+// messages we have is kept. The unviewed counts of the chats are updated,
+// and the ids of the chats of the saved messages are returned
+func (db sqlitePersistence) MergeArchiveMessages(messages []*common.Message) (chatIDs []string, err error) {
+	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	allFields := db.tableUserMessagesAllFields()
+	valuesVector := strings.Repeat("?, ", db.tableUserMessagesAllFieldsCount()-1) + "?"
+	query := "INSERT INTO user_messages(" + allFields + ") VALUES (" + valuesVector + ")" // nolint: gosec
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	chats := make(map[string]bool)
+	for _, message := range messages {
+		var editedAt uint64
+		var deleted, seen bool
+		err = tx.QueryRow(`SELECT edited_at, deleted, seen FROM user_messages WHERE id = ?`, message.ID).Scan(&editedAt, &deleted, &seen)
+		switch err {
+		case sql.ErrNoRows:
+		case nil:
+			if message.EditedAt <= editedAt && (!message.Deleted || deleted) {
+				continue
+			}
+			message.Seen = seen
+		default:
+			return nil, err
+		}
+
+		var allValues []interface{}
+		allValues, err = db.tableUserMessagesAllValues(message)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = stmt.Exec(allValues...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !chats[message.LocalChatID] {
+			chats[message.LocalChatID] = true
+			chatIDs = append(chatIDs, message.LocalChatID)
+		}
+	}
+
+	for _, chatID := range chatIDs {
+		_, err = tx.Exec(
+			`UPDATE chats
+			   SET unviewed_message_count =
+			   (SELECT COUNT(1)
+			   FROM user_messages
+			   WHERE local_chat_id = ? AND seen = 0),
+			   unviewed_mentions_count =
+			   (SELECT COUNT(1)
+			   FROM user_messages
+			   WHERE local_chat_id = ? AND seen = 0 AND mentioned)
+			WHERE id = ?`, chatID, chatID, chatID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return chatIDs, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/planq-network/status-go/protocol/common"
 	"github.com/planq-network/status-go/protocol/communities"
 	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
 	v1protocol "github.com/planq-network/status-go/protocol/v1"
 )
 
@@ -34,6 +35,29 @@ const (
 	ChatTypeCommunityChat
 )
 
+// ChatPrivacySetting overrides for a chat a privacy setting of the account
+type ChatPrivacySetting int
+
+const (
+	// ChatPrivacySettingDefault follows the setting of the account
+	ChatPrivacySettingDefault  ChatPrivacySetting = requests.ChatPrivacySettingDefault
+	ChatPrivacySettingEnabled  ChatPrivacySetting = requests.ChatPrivacySettingEnabled
+	ChatPrivacySettingDisabled ChatPrivacySetting = requests.ChatPrivacySettingDisabled
+)
+
+// Enabled returns whether the setting is enabled given the setting of the
+// account
+func (s ChatPrivacySetting) Enabled(accountSetting bool) bool {
+	switch s {
+	case ChatPrivacySettingEnabled:
+		return true
+	case ChatPrivacySettingDisabled:
+		return false
+	default:
+		return accountSetting
+	}
+}
+
 const pkStringLength = 68
 
 // timelineChatID is a magic constant id for your own timeline
@@ -109,6 +133,19 @@ type Chat struct {
 
 	// Highlight is used for highlight chats
 	Highlight bool `json:"highlight,omitempty"`
+
+	// MessagesTTL is the number of seconds after which messages sent in the
+	// chat are deleted, 0 if messages don't expire
+	MessagesTTL uint64 `json:"messagesTTL,omitempty"`
+	// MessagesTTLClockValue is the clock value of the last update of MessagesTTL
+	MessagesTTLClockValue uint64 `json:"-"`
+
+	// ReadReceipts is whether we let the other members of the chat know
+	// which of their messages we read
+	ReadReceipts ChatPrivacySetting `json:"readReceipts,omitempty"`
+	// TypingIndicators is whether we let the other members of the chat
+	// know when we are typing
+	TypingIndicators ChatPrivacySetting `json:"typingIndicators,omitempty"`
 }
 
 type ChatPreview struct {
@@ -0,0 +1,23 @@
+package protocol
+
+import (
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// ChatDraft is the text of a message being composed in a chat and not sent
+// yet, it's synced with the paired devices
+type ChatDraft struct {
+	ChatID     string `json:"chatId"`
+	Text       string `json:"text"`
+	ResponseTo string `json:"responseTo,omitempty"`
+	Clock      uint64 `json:"clock"`
+}
+
+func (d *ChatDraft) toSyncProtobuf() *protobuf.SyncChatDraft {
+	return &protobuf.SyncChatDraft{
+		Clock:      d.Clock,
+		ChatId:     d.ChatID,
+		Text:       d.Text,
+		ResponseTo: d.ResponseTo,
+	}
+}
@@ -0,0 +1,68 @@
+package protocol
+
+import (
+	"database/sql"
+)
+
+// SaveChatDraft saves the draft of a chat unless a more recent one has already
+// been saved, it returns whether the draft has been saved
+func (db sqlitePersistence) SaveChatDraft(draft *ChatDraft) (bool, error) {
+	result, err := db.db.Exec(`
+		INSERT INTO chat_drafts(chat_id, text, response_to, clock_value)
+		SELECT ?, ?, ?, ?
+		WHERE NOT EXISTS (SELECT 1 FROM chat_drafts WHERE chat_id = ? AND clock_value >= ?)`,
+		draft.ChatID,
+		draft.Text,
+		draft.ResponseTo,
+		draft.Clock,
+		draft.ChatID,
+		draft.Clock,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ChatDraft returns the draft of a chat, nil if there's none
+func (db sqlitePersistence) ChatDraft(chatID string) (*ChatDraft, error) {
+	draft := &ChatDraft{}
+	err := db.db.QueryRow(`SELECT chat_id, text, response_to, clock_value FROM chat_drafts WHERE chat_id = ?`, chatID).Scan(
+		&draft.ChatID,
+		&draft.Text,
+		&draft.ResponseTo,
+		&draft.Clock,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// ChatDrafts returns the drafts of all the chats, including the cleared ones
+// as they are needed to resolve conflicts when syncing
+func (db sqlitePersistence) ChatDrafts() ([]*ChatDraft, error) {
+	rows, err := db.db.Query(`SELECT chat_id, text, response_to, clock_value FROM chat_drafts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drafts []*ChatDraft
+	for rows.Next() {
+		draft := &ChatDraft{}
+		if err := rows.Scan(&draft.ChatID, &draft.Text, &draft.ResponseTo, &draft.Clock); err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, draft)
+	}
+	return drafts, rows.Err()
+}
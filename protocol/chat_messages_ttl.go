@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// ChatMessagesTTL represents an update of the time after which the messages of
+// a chat are deleted
+type ChatMessagesTTL struct {
+	protobuf.ChatMessagesTTL
+
+	// SigPubKey is the ecdsa encoded public key of the update author
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (c ChatMessagesTTL) GetSigPubKey() *ecdsa.PublicKey {
+	return c.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (c ChatMessagesTTL) GetProtobuf() proto.Message {
+	return &c.ChatMessagesTTL
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (c *ChatMessagesTTL) SetMessageType(messageType protobuf.MessageType) {
+	c.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (c ChatMessagesTTL) WrapGroupMessage() bool {
+	return false
+}
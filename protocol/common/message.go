@@ -17,6 +17,7 @@ import (
 	"github.com/status-im/markdown/ast"
 
 	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/images"
 	"github.com/planq-network/status-go/protocol/protobuf"
 )
@@ -75,6 +76,18 @@ type GapParameters struct {
 	To   uint32 `json:"to,omitempty"`
 }
 
+// ThreadParameters are the replies metadata of a message that is the root of a thread
+type ThreadParameters struct {
+	// ReplyCount is the number of replies in the thread
+	ReplyCount uint64 `json:"replyCount"`
+	// UnreadCount is the number of replies that haven't been seen
+	UnreadCount uint64 `json:"unreadCount"`
+	// LastReplyFrom is the public key of the author of the last reply
+	LastReplyFrom string `json:"lastReplyFrom"`
+	// LastReplyTimestamp is the timestamp of the last reply
+	LastReplyTimestamp uint64 `json:"lastReplyTimestamp"`
+}
+
 func (c *CommandParameters) IsTokenTransfer() bool {
 	return len(c.Contract) != 0
 }
@@ -83,6 +96,9 @@ const (
 	OutgoingStatusSending   = "sending"
 	OutgoingStatusSent      = "sent"
 	OutgoingStatusDelivered = "delivered"
+	// OutgoingStatusRead is set when a recipient let us know they read the
+	// message through a read receipt
+	OutgoingStatusRead = "read"
 )
 
 // Message represents a message record in the database,
@@ -114,6 +130,9 @@ type Message struct {
 	// GapParameters is the value from/to related to the gap
 	GapParameters *GapParameters `json:"gapParameters,omitempty"`
 
+	// ThreadParameters is set if the message is the root of a thread
+	ThreadParameters *ThreadParameters `json:"threadParameters,omitempty"`
+
 	// Computed fields
 	// RTL is whether this is a right-to-left message (arabic/hebrew script etc)
 	RTL bool `json:"rtl"`
@@ -133,6 +152,13 @@ type Message struct {
 	AudioPath string `json:"audioPath,omitempty"`
 	// ImageLocalURL is the local url of the image
 	ImageLocalURL string `json:"imageLocalUrl,omitempty"`
+	// FilePath is the path of the file to be sent
+	FilePath string `json:"filePath,omitempty"`
+	// FileLocalURL is the local url of the file
+	FileLocalURL string `json:"fileLocalUrl,omitempty"`
+	// FileAvailable is whether all the chunks of the file have been received
+	// and its content matches its hash
+	FileAvailable bool `json:"fileAvailable,omitempty"`
 
 	// CommunityID is the id of the community to advertise
 	CommunityID string `json:"communityId,omitempty"`
@@ -158,11 +184,17 @@ type Message struct {
 
 	// Deleted indicates if a message was deleted
 	Deleted bool `json:"deleted"`
+
+	// PollClosedAt indicates the clock value the poll was closed by its author
+	PollClosedAt uint64 `json:"pollClosedAt,omitempty"`
 }
 
 func (m *Message) PrepareImageURL(port int) {
 	m.ImageLocalURL = fmt.Sprintf("https://localhost:%d/messages/images?messageId=%s", port, m.ID)
 	m.Identicon = fmt.Sprintf("https://localhost:%d/messages/identicons?publicKey=%s", port, m.From)
+	if m.ContentType == protobuf.ChatMessage_FILE {
+		m.FileLocalURL = fmt.Sprintf("https://localhost:%d/messages/files?messageId=%s", port, m.ID)
+	}
 }
 
 func (m *Message) MarshalJSON() ([]byte, error) {
@@ -170,6 +202,20 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		Hash string `json:"hash"`
 		Pack int32  `json:"pack"`
 	}
+	type PollAlias struct {
+		Options        []string `json:"options"`
+		ExpiresAt      uint64   `json:"expiresAt,omitempty"`
+		MultipleChoice bool     `json:"multipleChoice"`
+		ClosedAt       uint64   `json:"closedAt,omitempty"`
+	}
+	type FileAlias struct {
+		Name      string `json:"name"`
+		MimeType  string `json:"mimeType"`
+		Size      uint64 `json:"size"`
+		Hash      string `json:"hash"`
+		Available bool   `json:"available"`
+		URL       string `json:"url,omitempty"`
+	}
 	item := struct {
 		ID                string                           `json:"id"`
 		WhisperTimestamp  uint64                           `json:"whisperTimestamp"`
@@ -195,8 +241,12 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		AudioDurationMs   uint64                           `json:"audioDurationMs,omitempty"`
 		CommunityID       string                           `json:"communityId,omitempty"`
 		Sticker           *StickerAlias                    `json:"sticker,omitempty"`
+		Poll              *PollAlias                       `json:"poll,omitempty"`
+		File              *FileAlias                       `json:"file,omitempty"`
 		CommandParameters *CommandParameters               `json:"commandParameters,omitempty"`
 		GapParameters     *GapParameters                   `json:"gapParameters,omitempty"`
+		ThreadID          string                           `json:"threadId,omitempty"`
+		ThreadParameters  *ThreadParameters                `json:"threadParameters,omitempty"`
 		Timestamp         uint64                           `json:"timestamp"`
 		ContentType       protobuf.ChatMessage_ContentType `json:"contentType"`
 		MessageType       protobuf.MessageType             `json:"messageType"`
@@ -205,6 +255,7 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		Links             []string                         `json:"links,omitempty"`
 		EditedAt          uint64                           `json:"editedAt,omitempty"`
 		Deleted           bool                             `json:"deleted,omitempty"`
+		ExpiresAt         uint64                           `json:"expiresAt,omitempty"`
 	}{
 		ID:                m.ID,
 		WhisperTimestamp:  m.WhisperTimestamp,
@@ -236,8 +287,11 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		MessageType:       m.MessageType,
 		CommandParameters: m.CommandParameters,
 		GapParameters:     m.GapParameters,
+		ThreadID:          m.ThreadId,
+		ThreadParameters:  m.ThreadParameters,
 		EditedAt:          m.EditedAt,
 		Deleted:           m.Deleted,
+		ExpiresAt:         m.ExpiresAt,
 	}
 	if sticker := m.GetSticker(); sticker != nil {
 		item.Sticker = &StickerAlias{
@@ -250,18 +304,46 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 		item.AudioDurationMs = audio.DurationMs
 	}
 
+	if poll := m.GetPoll(); poll != nil {
+		item.Poll = &PollAlias{
+			Options:        poll.Options,
+			ExpiresAt:      poll.ExpiresAt,
+			MultipleChoice: poll.MultipleChoice,
+			ClosedAt:       m.PollClosedAt,
+		}
+	}
+
+	if file := m.GetFile(); file != nil {
+		item.File = &FileAlias{
+			Name:      file.Name,
+			MimeType:  file.MimeType,
+			Size:      file.Size,
+			Hash:      types.EncodeHex(file.Hash),
+			Available: m.FileAvailable,
+			URL:       m.FileLocalURL,
+		}
+	}
+
 	return json.Marshal(item)
 }
 
 func (m *Message) UnmarshalJSON(data []byte) error {
 	type Alias Message
+	type PollAlias struct {
+		Options        []string `json:"options"`
+		ExpiresAt      uint64   `json:"expiresAt"`
+		MultipleChoice bool     `json:"multipleChoice"`
+	}
 	aux := struct {
 		*Alias
 		ResponseTo      string                           `json:"responseTo"`
+		ThreadID        string                           `json:"threadId"`
 		EnsName         string                           `json:"ensName"`
 		ChatID          string                           `json:"chatId"`
 		Sticker         *protobuf.StickerMessage         `json:"sticker"`
 		AudioDurationMs uint64                           `json:"audioDurationMs"`
+		Poll            *PollAlias                       `json:"poll"`
+		ExpiresAt       uint64                           `json:"expiresAt"`
 		ParsedText      json.RawMessage                  `json:"parsedText"`
 		ContentType     protobuf.ChatMessage_ContentType `json:"contentType"`
 	}{
@@ -278,11 +360,22 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 			Audio: &protobuf.AudioMessage{DurationMs: aux.AudioDurationMs},
 		}
 	}
+	if aux.ContentType == protobuf.ChatMessage_POLL && aux.Poll != nil {
+		m.Payload = &protobuf.ChatMessage_Poll{
+			Poll: &protobuf.PollMessage{
+				Options:        aux.Poll.Options,
+				ExpiresAt:      aux.Poll.ExpiresAt,
+				MultipleChoice: aux.Poll.MultipleChoice,
+			},
+		}
+	}
 	m.ResponseTo = aux.ResponseTo
+	m.ThreadId = aux.ThreadID
 	m.EnsName = aux.EnsName
 	m.ChatId = aux.ChatID
 	m.ContentType = aux.ContentType
 	m.ParsedText = aux.ParsedText
+	m.ExpiresAt = aux.ExpiresAt
 	return nil
 }
 
@@ -162,6 +162,10 @@ func (s *MessageSender) SendPrivate(
 		return nil, errors.New("setting identity, skip-encryption or personal topic and datasync not supported")
 	}
 
+	if rawMessage.ResendAutomatically && rawMessage.Ephemeral {
+		return nil, errors.New("ephemeral messages can't be sent through datasync")
+	}
+
 	// Set sender identity if not specified
 	if rawMessage.Sender == nil {
 		rawMessage.Sender = s.identity
@@ -306,7 +310,9 @@ func (s *MessageSender) sendPrivate(
 
 		s.logger.Debug("sent private message skipEncryption", zap.String("messageID", messageID.String()), zap.String("hash", types.EncodeHex(hash)))
 
-		s.transport.Track(messageIDs, hash, newMessage)
+		if !rawMessage.Ephemeral {
+			s.transport.Track(messageIDs, hash, newMessage)
+		}
 
 	} else {
 		messageSpec, err := s.protocol.BuildEncryptedMessage(rawMessage.Sender, recipient, wrappedMessage)
@@ -325,7 +331,7 @@ func (s *MessageSender) sendPrivate(
 		}
 
 		messageIDs := [][]byte{messageID}
-		hash, newMessage, err := s.sendMessageSpec(ctx, recipient, messageSpec, messageIDs)
+		hash, newMessage, err := s.sendMessageSpecWithTTL(ctx, recipient, messageSpec, messageIDs, messageTTL(rawMessage))
 		if err != nil {
 			s.logger.Error("failed to send a private message", zap.Error(err))
 			return nil, errors.Wrap(err, "failed to send a message spec")
@@ -333,7 +339,11 @@ func (s *MessageSender) sendPrivate(
 
 		s.logger.Debug("sent private message without datasync", zap.String("messageID", messageID.String()), zap.String("hash", types.EncodeHex(hash)))
 
-		s.transport.Track(messageIDs, hash, newMessage)
+		// Ephemeral messages are not resent, so there is no need to track
+		// whether their envelopes expired
+		if !rawMessage.Ephemeral {
+			s.transport.Track(messageIDs, hash, newMessage)
+		}
 	}
 
 	return messageID, nil
@@ -370,6 +380,37 @@ func (s *MessageSender) SendPairInstallation(
 	return messageID, nil
 }
 
+// SendHashRatchetKey sends the hash ratchet key of a group to the recipient,
+// encrypted with the double ratchet and signed by the owner of the group
+func (s *MessageSender) SendHashRatchetKey(
+	ctx context.Context,
+	recipient *ecdsa.PublicKey,
+	groupID []byte,
+	keyID uint32,
+	signer *ecdsa.PrivateKey,
+) error {
+	s.logger.Debug("sending hash ratchet key", zap.String("recipient", types.EncodeHex(crypto.FromECDSAPub(recipient))))
+
+	messageSpec, err := s.protocol.BuildSignedHashRatchetKeyExchangeMessage(s.identity, recipient, string(groupID), keyID, signer)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt hash ratchet key")
+	}
+
+	if s.handleSharedSecrets != nil {
+		err := s.handleSharedSecrets([]*sharedsecret.Secret{messageSpec.SharedSecret})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err = s.sendMessageSpec(ctx, recipient, messageSpec, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to send a message spec")
+	}
+
+	return nil
+}
+
 func (s *MessageSender) encodeMembershipUpdate(
 	message v1protocol.MembershipUpdateMessage,
 	chatEntity ChatEntity,
@@ -439,7 +480,12 @@ func (s *MessageSender) SendPublic(
 
 	var newMessage *types.NewMessage
 
-	messageSpec, err := s.protocol.BuildPublicMessage(s.identity, wrappedMessage)
+	var messageSpec *encryption.ProtocolMessageSpec
+	if len(rawMessage.HashRatchetGroupID) != 0 {
+		messageSpec, err = s.protocol.BuildHashRatchetMessage(rawMessage.HashRatchetGroupID, wrappedMessage)
+	} else {
+		messageSpec, err = s.protocol.BuildPublicMessage(s.identity, wrappedMessage)
+	}
 	if err != nil {
 		s.logger.Error("failed to send a public message", zap.Error(err))
 		return nil, errors.Wrap(err, "failed to wrap a public message in the encryption layer")
@@ -528,6 +574,9 @@ func (s *MessageSender) HandleMessages(shhMessage *types.Message, applicationLay
 	err = s.handleEncryptionLayer(context.Background(), &statusMessage)
 	if err != nil {
 		hlogger.Debug("failed to handle an encryption message", zap.Error(err))
+	} else if len(statusMessage.DecryptedPayload) == 0 {
+		// Nothing left to process, e.g. a hash ratchet key exchange
+		return nil, nil, nil
 	}
 
 	statusMessages, acks, err := unwrapDatasyncMessage(&statusMessage, s.datasync)
@@ -691,7 +740,7 @@ func (s *MessageSender) sendDataSync(ctx context.Context, publicKey *ecdsa.Publi
 // sendPrivateRawMessage sends a message not wrapped in an encryption layer
 func (s *MessageSender) sendPrivateRawMessage(ctx context.Context, rawMessage *RawMessage, publicKey *ecdsa.PublicKey, payload []byte, messageIDs [][]byte) ([]byte, *types.NewMessage, error) {
 	newMessage := &types.NewMessage{
-		TTL:       whisperTTL,
+		TTL:       messageTTL(rawMessage),
 		Payload:   payload,
 		PowTarget: calculatePoW(payload),
 		PowTime:   whisperPoWTime,
@@ -731,10 +780,16 @@ func (s *MessageSender) sendCommunityRawMessage(ctx context.Context, publicKey *
 
 // sendMessageSpec analyses the spec properties and selects a proper transport method.
 func (s *MessageSender) sendMessageSpec(ctx context.Context, publicKey *ecdsa.PublicKey, messageSpec *encryption.ProtocolMessageSpec, messageIDs [][]byte) ([]byte, *types.NewMessage, error) {
+	return s.sendMessageSpecWithTTL(ctx, publicKey, messageSpec, messageIDs, whisperTTL)
+}
+
+// sendMessageSpecWithTTL sends a message spec in an envelope with the given TTL
+func (s *MessageSender) sendMessageSpecWithTTL(ctx context.Context, publicKey *ecdsa.PublicKey, messageSpec *encryption.ProtocolMessageSpec, messageIDs [][]byte, ttl uint32) ([]byte, *types.NewMessage, error) {
 	newMessage, err := MessageSpecToWhisper(messageSpec)
 	if err != nil {
 		return nil, nil, err
 	}
+	newMessage.TTL = ttl
 
 	logger := s.logger.With(zap.String("site", "sendMessageSpec"))
 
@@ -816,6 +871,15 @@ func (s *MessageSender) AddEphemeralKey(privateKey *ecdsa.PrivateKey) (*transpor
 	return s.transport.LoadKeyFilters(privateKey)
 }
 
+// messageTTL returns the TTL of the envelope of a message, ephemeral
+// messages use a TTL short enough for mailservers not to archive them
+func messageTTL(rawMessage *RawMessage) uint32 {
+	if rawMessage.Ephemeral {
+		return types.EphemeralEnvelopeTTL
+	}
+	return whisperTTL
+}
+
 func MessageSpecToWhisper(spec *encryption.ProtocolMessageSpec) (*types.NewMessage, error) {
 	var newMessage *types.NewMessage
 
@@ -0,0 +1,384 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// OutboxState is the delivery state of an outgoing message
+type OutboxState string
+
+const (
+	// OutboxStateQueued is set when the message is waiting to be resent
+	OutboxStateQueued OutboxState = "queued"
+	// OutboxStateSent is set when the message has been passed to the transport
+	OutboxStateSent OutboxState = "sent"
+	// OutboxStateAcked is set when the envelope of the message has been
+	// confirmed by a peer, usually a mailserver
+	OutboxStateAcked OutboxState = "acked"
+	// OutboxStateDelivered is set when the recipient acknowledged the
+	// message through datasync
+	OutboxStateDelivered OutboxState = "delivered"
+	// OutboxStateFailed is set when the message couldn't be sent and it's
+	// not resent automatically anymore
+	OutboxStateFailed OutboxState = "failed"
+)
+
+var ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+
+// errOutboxEnvelopeExpired is the error of the messages whose envelope
+// expired before reaching any peer
+var errOutboxEnvelopeExpired = errors.New("envelope expired")
+
+// OutboxEntry tracks the delivery of a RawMessage
+type OutboxEntry struct {
+	// ID is the id of the RawMessage
+	ID          string                                   `json:"id"`
+	LocalChatID string                                   `json:"localChatId"`
+	MessageType protobuf.ApplicationMetadataMessage_Type `json:"messageType"`
+	State       OutboxState                              `json:"state"`
+	// ResendAutomatically is whether the message is resent until it's acked
+	ResendAutomatically bool `json:"resendAutomatically"`
+	// Attempts is the number of times the message has been sent
+	Attempts int `json:"attempts"`
+	// LastAttempt is the time of the last attempt, in milliseconds
+	LastAttempt uint64 `json:"lastAttempt"`
+	// NextAttempt is the time the message is resent at if it's not acked
+	// by then, in milliseconds, 0 if it won't be resent
+	NextAttempt uint64 `json:"nextAttempt,omitempty"`
+	// Error is the reason of the last failure
+	Error string `json:"error,omitempty"`
+}
+
+// Outbox persists the delivery state of the outgoing messages and
+// schedules their resends with an exponential backoff
+type Outbox struct {
+	db         *sql.DB
+	timesource TimeSource
+	// minBackoff is the delay before the first resend, it's doubled at
+	// each attempt up to maxBackoff
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+}
+
+func NewOutbox(db *sql.DB, timesource TimeSource, minBackoff, maxBackoff time.Duration, maxAttempts int) *Outbox {
+	return &Outbox{
+		db:          db,
+		timesource:  timesource,
+		minBackoff:  minBackoff,
+		maxBackoff:  maxBackoff,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// backoff returns the delay in milliseconds before retrying a message sent
+// the given number of times
+func (o *Outbox) backoff(attempts int) uint64 {
+	backoff := o.minBackoff
+	for i := 1; i < attempts && backoff < o.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > o.maxBackoff {
+		backoff = o.maxBackoff
+	}
+	return uint64(backoff.Milliseconds())
+}
+
+// nextAttempt returns when an entry is retried, 0 if it's not retried
+func (o *Outbox) nextAttempt(entry *OutboxEntry) uint64 {
+	if !entry.ResendAutomatically || entry.Attempts >= o.maxAttempts {
+		return 0
+	}
+	return entry.LastAttempt + o.backoff(entry.Attempts)
+}
+
+// Sent records that a message has been passed to the transport, the message
+// is resent once its backoff elapsed if it's not acked by then
+func (o *Outbox) Sent(message *RawMessage) (*OutboxEntry, error) {
+	if message.ID == "" {
+		return nil, nil
+	}
+
+	entry := &OutboxEntry{
+		ID:                  message.ID,
+		LocalChatID:         message.LocalChatID,
+		MessageType:         message.MessageType,
+		State:               OutboxStateSent,
+		ResendAutomatically: message.ResendAutomatically,
+		Attempts:            message.SendCount,
+		LastAttempt:         o.timesource.GetCurrentTime(),
+	}
+
+	// Messages without recipients are not really sent
+	if message.Sent {
+		entry.State = OutboxStateAcked
+	} else {
+		entry.NextAttempt = o.nextAttempt(entry)
+	}
+
+	return entry, o.save(o.db, entry)
+}
+
+// Acked records that the envelopes of the messages have been confirmed by a
+// peer and returns the entries that changed
+func (o *Outbox) Acked(ids []string) ([]*OutboxEntry, error) {
+	return o.update(ids, func(entry *OutboxEntry) bool {
+		if entry.State == OutboxStateAcked || entry.State == OutboxStateDelivered {
+			return false
+		}
+		entry.State = OutboxStateAcked
+		entry.NextAttempt = 0
+		entry.Error = ""
+		return true
+	})
+}
+
+// Delivered records that the recipient of a message acknowledged it
+func (o *Outbox) Delivered(id string) (*OutboxEntry, error) {
+	entries, err := o.update([]string{id}, func(entry *OutboxEntry) bool {
+		if entry.State == OutboxStateDelivered {
+			return false
+		}
+		entry.State = OutboxStateDelivered
+		entry.NextAttempt = 0
+		entry.Error = ""
+		return true
+	})
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// Expired records that the envelopes of the messages expired before
+// reaching any peer, the messages are queued for a resend or marked as
+// failed if they ran out of attempts
+func (o *Outbox) Expired(ids []string) ([]*OutboxEntry, error) {
+	return o.update(ids, func(entry *OutboxEntry) bool {
+		if entry.State != OutboxStateSent {
+			return false
+		}
+		o.fail(entry, errOutboxEnvelopeExpired)
+		return true
+	})
+}
+
+// AttemptFailed records that a message couldn't be resent
+func (o *Outbox) AttemptFailed(id string, err error) (*OutboxEntry, error) {
+	entries, updateErr := o.update([]string{id}, func(entry *OutboxEntry) bool {
+		entry.Attempts++
+		entry.LastAttempt = o.timesource.GetCurrentTime()
+		o.fail(entry, err)
+		return true
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	if len(entries) == 0 {
+		return nil, ErrOutboxEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (o *Outbox) fail(entry *OutboxEntry, err error) {
+	entry.Error = err.Error()
+	entry.NextAttempt = o.nextAttempt(entry)
+	if entry.NextAttempt == 0 {
+		entry.State = OutboxStateFailed
+	} else {
+		entry.State = OutboxStateQueued
+	}
+}
+
+// Due returns the entries that should be resent now. The entries that
+// weren't acked and ran out of attempts are marked as failed and returned
+// separately
+func (o *Outbox) Due() (due []*OutboxEntry, failed []*OutboxEntry, err error) {
+	now := o.timesource.GetCurrentTime()
+
+	tx, err := o.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	entries, err := o.query(tx, `WHERE state IN (?, ?) AND resend_automatically AND next_attempt != 0 AND next_attempt <= ?`, OutboxStateQueued, OutboxStateSent, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Entries still in the sent state are the ones whose envelopes were
+	// neither acked nor reported as expired in time, which is the case
+	// when we went offline or restarted after sending them
+	for _, entry := range entries {
+		if entry.Attempts < o.maxAttempts {
+			due = append(due, entry)
+			continue
+		}
+
+		entry.State = OutboxStateFailed
+		entry.NextAttempt = 0
+		if entry.Error == "" {
+			entry.Error = errOutboxEnvelopeExpired.Error()
+		}
+		err = o.save(tx, entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		failed = append(failed, entry)
+	}
+
+	return due, failed, nil
+}
+
+// Retry makes the queued messages due now, regardless of their backoff
+func (o *Outbox) Retry() error {
+	now := o.timesource.GetCurrentTime()
+	_, err := o.db.Exec(`UPDATE outbox SET next_attempt = ? WHERE state = ? AND next_attempt > ?`, now, OutboxStateQueued, now)
+	return err
+}
+
+// Prune removes the entries that were last sent before the retention period
+// and won't change anymore on their own, that is the ones that have been
+// acked, delivered, that failed or that are not resent. It returns the
+// number of entries removed
+func (o *Outbox) Prune(retention time.Duration) (int64, error) {
+	now := o.timesource.GetCurrentTime()
+	if uint64(retention.Milliseconds()) >= now {
+		return 0, nil
+	}
+
+	result, err := o.db.Exec(`DELETE FROM outbox WHERE last_attempt < ? AND (state IN (?, ?, ?) OR (state = ? AND next_attempt = 0))`,
+		now-uint64(retention.Milliseconds()),
+		OutboxStateAcked,
+		OutboxStateDelivered,
+		OutboxStateFailed,
+		OutboxStateSent,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Entry returns the entry of a message, nil if it's not tracked
+func (o *Outbox) Entry(id string) (*OutboxEntry, error) {
+	entries, err := o.query(o.db, `WHERE id = ?`, id)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// Entries returns the entries of a chat that are not delivered yet
+func (o *Outbox) Entries(chatID string) ([]*OutboxEntry, error) {
+	return o.query(o.db, `WHERE local_chat_id = ? AND state != ? ORDER BY last_attempt ASC`, chatID, OutboxStateDelivered)
+}
+
+// update applies a change to the entries of the given messages and returns
+// the ones that changed
+func (o *Outbox) update(ids []string, change func(*OutboxEntry) bool) (changed []*OutboxEntry, err error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	tx, err := o.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	inVector := strings.Repeat("?, ", len(ids)-1) + "?"
+
+	entries, err := o.query(tx, `WHERE id IN (`+inVector+`)`, args...) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !change(entry) {
+			continue
+		}
+		err = o.save(tx, entry)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, entry)
+	}
+
+	return changed, nil
+}
+
+type outboxExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (o *Outbox) save(db outboxExecutor, entry *OutboxEntry) error {
+	_, err := db.Exec(`INSERT INTO outbox (id, local_chat_id, message_type, state, resend_automatically, attempts, last_attempt, next_attempt, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID,
+		entry.LocalChatID,
+		entry.MessageType,
+		entry.State,
+		entry.ResendAutomatically,
+		entry.Attempts,
+		entry.LastAttempt,
+		entry.NextAttempt,
+		entry.Error,
+	)
+	return err
+}
+
+func (o *Outbox) query(db outboxExecutor, where string, args ...interface{}) ([]*OutboxEntry, error) {
+	rows, err := db.Query(`SELECT id, local_chat_id, message_type, state, resend_automatically, attempts, last_attempt, next_attempt, error FROM outbox `+where, args...) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*OutboxEntry
+	for rows.Next() {
+		entry := &OutboxEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.LocalChatID,
+			&entry.MessageType,
+			&entry.State,
+			&entry.ResendAutomatically,
+			&entry.Attempts,
+			&entry.LastAttempt,
+			&entry.NextAttempt,
+			&entry.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
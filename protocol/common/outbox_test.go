@@ -0,0 +1,223 @@
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/sqlite"
+)
+
+type testOutboxTimeSource struct {
+	now uint64
+}
+
+func (t *testOutboxTimeSource) GetCurrentTime() uint64 {
+	return t.now
+}
+
+func TestOutboxSuite(t *testing.T) {
+	suite.Run(t, new(OutboxSuite))
+}
+
+type OutboxSuite struct {
+	suite.Suite
+
+	outbox     *Outbox
+	timesource *testOutboxTimeSource
+	tmpDir     string
+}
+
+func (s *OutboxSuite) SetupTest() {
+	var err error
+	s.tmpDir, err = ioutil.TempDir("", "")
+	s.Require().NoError(err)
+
+	database, err := sqlite.Open(filepath.Join(s.tmpDir, "outbox-test.sql"), "some-key")
+	s.Require().NoError(err)
+
+	s.timesource = &testOutboxTimeSource{now: 1000000}
+	s.outbox = NewOutbox(database, s.timesource, 30*time.Second, 2*time.Minute, 4)
+}
+
+func (s *OutboxSuite) TearDownTest() {
+	os.RemoveAll(s.tmpDir)
+}
+
+func (s *OutboxSuite) send(id string, sendCount int) *OutboxEntry {
+	entry, err := s.outbox.Sent(&RawMessage{
+		ID:                  id,
+		LocalChatID:         "chat-id",
+		MessageType:         protobuf.ApplicationMetadataMessage_CHAT_MESSAGE,
+		ResendAutomatically: true,
+		SendCount:           sendCount,
+	})
+	s.Require().NoError(err)
+	return entry
+}
+
+func (s *OutboxSuite) TestBackoff() {
+	s.Require().Equal(uint64(30000), s.outbox.backoff(1))
+	s.Require().Equal(uint64(60000), s.outbox.backoff(2))
+	s.Require().Equal(uint64(120000), s.outbox.backoff(3))
+	// Capped to the max backoff
+	s.Require().Equal(uint64(120000), s.outbox.backoff(4))
+}
+
+func (s *OutboxSuite) TestSentAndAcked() {
+	entry := s.send("0x01", 1)
+	s.Require().Equal(OutboxStateSent, entry.State)
+	s.Require().Equal(uint64(1030000), entry.NextAttempt)
+
+	// Nothing to resend before the backoff elapsed
+	due, failed, err := s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Empty(due)
+	s.Require().Empty(failed)
+
+	entries, err := s.outbox.Acked([]string{"0x01", "0x02"})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(OutboxStateAcked, entries[0].State)
+	s.Require().Zero(entries[0].NextAttempt)
+
+	// Acked messages are not resent
+	s.timesource.now += 60000
+	due, _, err = s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Empty(due)
+
+	delivered, err := s.outbox.Delivered("0x01")
+	s.Require().NoError(err)
+	s.Require().Equal(OutboxStateDelivered, delivered.State)
+
+	// Delivered messages can't go back to acked
+	entries, err = s.outbox.Acked([]string{"0x01"})
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+
+	entries, err = s.outbox.Entries("chat-id")
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+}
+
+func (s *OutboxSuite) TestResendUnacked() {
+	s.send("0x01", 1)
+
+	// Not acked in time, as we restarted for example
+	s.timesource.now += 30000
+	due, failed, err := s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Empty(failed)
+	s.Require().Len(due, 1)
+	s.Require().Equal("0x01", due[0].ID)
+
+	// The resend doubles the backoff
+	entry := s.send("0x01", 2)
+	s.Require().Equal(s.timesource.now+60000, entry.NextAttempt)
+}
+
+func (s *OutboxSuite) TestExpired() {
+	s.send("0x01", 1)
+
+	entries, err := s.outbox.Expired([]string{"0x01"})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(OutboxStateQueued, entries[0].State)
+	s.Require().NotEmpty(entries[0].Error)
+
+	// Going back online makes it due right away
+	s.Require().NoError(s.outbox.Retry())
+	due, _, err := s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Len(due, 1)
+}
+
+func (s *OutboxSuite) TestFailed() {
+	s.send("0x01", 3)
+
+	entry, err := s.outbox.AttemptFailed("0x01", errors.New("chat not found"))
+	s.Require().NoError(err)
+	s.Require().Equal(4, entry.Attempts)
+	s.Require().Equal(OutboxStateFailed, entry.State)
+	s.Require().Equal("chat not found", entry.Error)
+
+	s.send("0x02", 4)
+	s.timesource.now += 120000
+	due, failed, err := s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Empty(due)
+	s.Require().Empty(failed)
+
+	entries, err := s.outbox.Entries("chat-id")
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+
+	// Messages that are not resent automatically are not queued
+	_, err = s.outbox.Sent(&RawMessage{ID: "0x03", LocalChatID: "chat-id", SendCount: 1})
+	s.Require().NoError(err)
+	entries, err = s.outbox.Expired([]string{"0x03"})
+	s.Require().NoError(err)
+	s.Require().Equal(OutboxStateFailed, entries[0].State)
+
+	_, err = s.outbox.AttemptFailed("unknown", errors.New("error"))
+	s.Require().Equal(ErrOutboxEntryNotFound, err)
+}
+
+func (s *OutboxSuite) TestRunningOutOfAttempts() {
+	s.send("0x01", 3)
+	s.timesource.now += 120000
+
+	due, _, err := s.outbox.Due()
+	s.Require().NoError(err)
+	s.Require().Len(due, 1)
+
+	s.send("0x01", 4)
+	s.timesource.now += 120000
+
+	// The last attempt has no next attempt, it stays sent until acked
+	entry, err := s.outbox.Entry("0x01")
+	s.Require().NoError(err)
+	s.Require().Equal(OutboxStateSent, entry.State)
+	s.Require().Zero(entry.NextAttempt)
+}
+
+func (s *OutboxSuite) TestPrune() {
+	s.send("acked", 1)
+	_, err := s.outbox.Acked([]string{"acked"})
+	s.Require().NoError(err)
+
+	s.send("failed", 3)
+	_, err = s.outbox.AttemptFailed("failed", errors.New("chat not found"))
+	s.Require().NoError(err)
+
+	_, err = s.outbox.Sent(&RawMessage{ID: "not-resent", LocalChatID: "chat-id", SendCount: 1})
+	s.Require().NoError(err)
+
+	s.send("pending", 1)
+
+	// Nothing is pruned within the retention period
+	pruned, err := s.outbox.Prune(time.Hour)
+	s.Require().NoError(err)
+	s.Require().Zero(pruned)
+
+	s.timesource.now += uint64(time.Hour.Milliseconds()) + 1
+	pruned, err = s.outbox.Prune(time.Hour)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(3), pruned)
+
+	// Messages that are still being resent are kept
+	entry, err := s.outbox.Entry("pending")
+	s.Require().NoError(err)
+	s.Require().NotNil(entry)
+
+	entry, err = s.outbox.Entry("acked")
+	s.Require().NoError(err)
+	s.Require().Nil(entry)
+}
@@ -23,4 +23,10 @@ type RawMessage struct {
 	Recipients           []*ecdsa.PublicKey
 	SkipGroupMessageWrap bool
 	SendOnPersonalTopic  bool
+	// Ephemeral messages are only delivered to the peers that are online,
+	// they are not archived by mailservers nor resent
+	Ephemeral bool
+	// HashRatchetGroupID is set for public messages that are encrypted with
+	// the current hash ratchet key of the group
+	HashRatchetGroupID []byte
 }
@@ -61,16 +61,18 @@ func New(config Config) (*Community, error) {
 }
 
 type CommunityChat struct {
-	ID          string                               `json:"id"`
-	Name        string                               `json:"name"`
-	Color       string                               `json:"color"`
-	Emoji       string                               `json:"emoji"`
-	Description string                               `json:"description"`
-	Members     map[string]*protobuf.CommunityMember `json:"members"`
-	Permissions *protobuf.CommunityPermissions       `json:"permissions"`
-	CanPost     bool                                 `json:"canPost"`
-	Position    int                                  `json:"position"`
-	CategoryID  string                               `json:"categoryID"`
+	ID                string                                           `json:"id"`
+	Name              string                                           `json:"name"`
+	Color             string                                           `json:"color"`
+	Emoji             string                                           `json:"emoji"`
+	Description       string                                           `json:"description"`
+	Members           map[string]*protobuf.CommunityMember             `json:"members"`
+	Permissions       *protobuf.CommunityPermissions                   `json:"permissions"`
+	RoleOverrides     map[string]*protobuf.CommunityPermissionOverride `json:"roleOverrides"`
+	CanPost           bool                                             `json:"canPost"`
+	CanDeleteMessages bool                                             `json:"canDeleteMessages"`
+	Position          int                                              `json:"position"`
+	CategoryID        string                                           `json:"categoryID"`
 }
 
 type CommunityCategory struct {
@@ -116,16 +118,18 @@ func (o *Community) MarshalPublicAPIJSON() ([]byte, error) {
 				return nil, err
 			}
 			chat := CommunityChat{
-				ID:          id,
-				Name:        c.Identity.DisplayName,
-				Color:       c.Identity.Color,
-				Emoji:       c.Identity.Emoji,
-				Description: c.Identity.Description,
-				Permissions: c.Permissions,
-				Members:     c.Members,
-				CanPost:     canPost,
-				CategoryID:  c.CategoryId,
-				Position:    int(c.Position),
+				ID:                id,
+				Name:              c.Identity.DisplayName,
+				Color:             c.Identity.Color,
+				Emoji:             c.Identity.Emoji,
+				Description:       c.Identity.Description,
+				Permissions:       c.Permissions,
+				Members:           c.Members,
+				CanPost:           canPost,
+				CanDeleteMessages: o.hasPermissionBit(o.config.MemberIdentity, id, protobuf.CommunityRole_DELETE_MESSAGES),
+				RoleOverrides:     c.RoleOverrides,
+				CategoryID:        c.CategoryId,
+				Position:          int(c.Position),
 			}
 			communityItem.Chats[id] = chat
 		}
@@ -165,8 +169,10 @@ func (o *Community) MarshalJSON() ([]byte, error) {
 		Images            map[string]images.IdentityImage      `json:"images"`
 		Permissions       *protobuf.CommunityPermissions       `json:"permissions"`
 		Members           map[string]*protobuf.CommunityMember `json:"members"`
+		Roles             map[string]*protobuf.CommunityRole   `json:"roles"`
 		CanRequestAccess  bool                                 `json:"canRequestAccess"`
 		CanManageUsers    bool                                 `json:"canManageUsers"`
+		CanBanUsers       bool                                 `json:"canBanUsers"`
 		CanJoin           bool                                 `json:"canJoin"`
 		Color             string                               `json:"color"`
 		RequestedToJoinAt uint64                               `json:"requestedToJoinAt,omitempty"`
@@ -182,6 +188,7 @@ func (o *Community) MarshalJSON() ([]byte, error) {
 		CanRequestAccess:  o.CanRequestAccess(o.config.MemberIdentity),
 		CanJoin:           o.canJoin(),
 		CanManageUsers:    o.CanManageUsers(o.config.MemberIdentity),
+		CanBanUsers:       o.IsAdmin() || o.hasPermissionBit(o.config.MemberIdentity, "", protobuf.CommunityRole_BAN_USERS),
 		RequestedToJoinAt: o.RequestedToJoinAt(),
 		IsMember:          o.isMember(),
 		Muted:             o.config.Muted,
@@ -201,20 +208,23 @@ func (o *Community) MarshalJSON() ([]byte, error) {
 				return nil, err
 			}
 			chat := CommunityChat{
-				ID:          id,
-				Name:        c.Identity.DisplayName,
-				Emoji:       c.Identity.Emoji,
-				Color:       c.Identity.Color,
-				Description: c.Identity.Description,
-				Permissions: c.Permissions,
-				Members:     c.Members,
-				CanPost:     canPost,
-				CategoryID:  c.CategoryId,
-				Position:    int(c.Position),
+				ID:                id,
+				Name:              c.Identity.DisplayName,
+				Emoji:             c.Identity.Emoji,
+				Color:             c.Identity.Color,
+				Description:       c.Identity.Description,
+				Permissions:       c.Permissions,
+				Members:           c.Members,
+				CanPost:           canPost,
+				CanDeleteMessages: o.hasPermissionBit(o.config.MemberIdentity, id, protobuf.CommunityRole_DELETE_MESSAGES),
+				RoleOverrides:     c.RoleOverrides,
+				CategoryID:        c.CategoryId,
+				Position:          int(c.Position),
 			}
 			communityItem.Chats[id] = chat
 		}
 		communityItem.Members = o.config.CommunityDescription.Members
+		communityItem.Roles = o.config.CommunityDescription.Roles
 		communityItem.Permissions = o.config.CommunityDescription.Permissions
 		if o.config.CommunityDescription.Identity != nil {
 			communityItem.Name = o.Name()
@@ -590,8 +600,13 @@ func (o *Community) RemoveUserFromChat(pk *ecdsa.PublicKey, chatID string) (*pro
 	}
 
 	key := common.PubkeyToHex(pk)
+	if _, ok := chat.Members[key]; !ok {
+		return o.config.CommunityDescription, nil
+	}
 	delete(chat.Members, key)
 
+	o.increaseClock()
+
 	return o.config.CommunityDescription, nil
 }
 
@@ -659,6 +674,7 @@ func (o *Community) Edit(description *protobuf.CommunityDescription) {
 	o.config.CommunityDescription.Identity.Color = description.Identity.Color
 	o.config.CommunityDescription.Identity.Emoji = description.Identity.Emoji
 	o.config.CommunityDescription.Identity.Images = description.Identity.Images
+	o.config.CommunityDescription.Permissions.TokenRequirements = description.Permissions.TokenRequirements
 	o.increaseClock()
 }
 
@@ -881,12 +897,6 @@ func (o *Community) IsMemberAdmin(publicKey *ecdsa.PublicKey) bool {
 	return o.hasPermission(publicKey, adminRolePermissions())
 }
 
-func canManageUsersRolePermissions() map[protobuf.CommunityMember_Roles]bool {
-	roles := adminRolePermissions()
-	roles[protobuf.CommunityMember_ROLE_MANAGE_USERS] = true
-	return roles
-}
-
 func adminRolePermissions() map[protobuf.CommunityMember_Roles]bool {
 	roles := make(map[protobuf.CommunityMember_Roles]bool)
 	roles[protobuf.CommunityMember_ROLE_ALL] = true
@@ -921,6 +931,12 @@ func (o *Community) InvitationOnly() bool {
 	return o.config.CommunityDescription.Permissions.Access == protobuf.CommunityPermissions_INVITATION_ONLY
 }
 
+func (o *Community) TokenRequirements() []*protobuf.TokenRequirement {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.config.CommunityDescription.Permissions.TokenRequirements
+}
+
 func (o *Community) validateRequestToJoinWithoutChatID(request *protobuf.CommunityRequestToJoin) error {
 
 	// If they want access to the org only, check that the org is ON_REQUEST
@@ -1213,9 +1229,7 @@ func (o *Community) CanManageUsers(pk *ecdsa.PublicKey) bool {
 		return false
 	}
 
-	roles := canManageUsersRolePermissions()
-	return o.hasPermission(pk, roles)
-
+	return o.hasPermissionBit(pk, "", protobuf.CommunityRole_MANAGE_USERS)
 }
 func (o *Community) isMember() bool {
 	return o.hasMember(o.config.MemberIdentity)
@@ -1248,14 +1262,13 @@ func (o *Community) nextClock() uint64 {
 
 func (o *Community) CanManageUsersPublicKeys() ([]*ecdsa.PublicKey, error) {
 	var response []*ecdsa.PublicKey
-	roles := canManageUsersRolePermissions()
-	for pkString, member := range o.config.CommunityDescription.Members {
-		if o.hasMemberPermission(member, roles) {
-			pk, err := common.HexToPubkey(pkString)
-			if err != nil {
-				return nil, err
-			}
+	for pkString := range o.config.CommunityDescription.Members {
+		pk, err := common.HexToPubkey(pkString)
+		if err != nil {
+			return nil, err
+		}
 
+		if o.hasPermissionBit(pk, "", protobuf.CommunityRole_MANAGE_USERS) {
 			response = append(response, pk)
 		}
 
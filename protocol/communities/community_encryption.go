@@ -0,0 +1,62 @@
+package communities
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// IsChatEncrypted returns whether the messages of the chat are encrypted with
+// a group key shared with the members of the chat
+func (o *Community) IsChatEncrypted(chatID string) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	chat, ok := o.config.CommunityDescription.Chats[chatID]
+	return ok && chat.Permissions != nil && chat.Permissions.Private
+}
+
+// EncryptedChats returns the IDs of the chats whose messages are encrypted
+func (o *Community) EncryptedChats() []string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	var response []string
+	for chatID, chat := range o.config.CommunityDescription.Chats {
+		if chat.Permissions != nil && chat.Permissions.Private {
+			response = append(response, chatID)
+		}
+	}
+	return response
+}
+
+// ChatMembers returns the members that can read the chat. Chats with no
+// membership can be read by every member of the community
+func (o *Community) ChatMembers(chatID string) ([]*ecdsa.PublicKey, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	chat, ok := o.config.CommunityDescription.Chats[chatID]
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+
+	members := chat.Members
+	if chat.Permissions == nil || chat.Permissions.Access == protobuf.CommunityPermissions_NO_MEMBERSHIP {
+		members = o.config.CommunityDescription.Members
+	}
+
+	var response []*ecdsa.PublicKey
+	for pkString := range members {
+		pk, err := common.HexToPubkey(pkString)
+		if err != nil {
+			return nil, err
+		}
+		if o.isBanned(pk) {
+			continue
+		}
+		response = append(response, pk)
+	}
+	return response, nil
+}
@@ -0,0 +1,39 @@
+package communities
+
+import (
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+func (s *CommunitySuite) TestChatMembers() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+
+	s.Require().False(org.IsChatEncrypted(testChatID1))
+	s.Require().Empty(org.EncryptedChats())
+
+	org.config.CommunityDescription.Chats[testChatID1].Permissions.Private = true
+	s.Require().True(org.IsChatEncrypted(testChatID1))
+	s.Require().Equal([]string{testChatID1}, org.EncryptedChats())
+	s.Require().False(org.IsChatEncrypted("unknown-chat"))
+
+	members, err := org.ChatMembers(testChatID1)
+	s.Require().NoError(err)
+	s.Require().Len(members, 1)
+	s.Require().True(common.IsPubKeyEqual(&s.member1.PublicKey, members[0]))
+
+	// Chats with no membership can be read by any member of the community
+	org.config.CommunityDescription.Chats[testChatID1].Permissions.Access = protobuf.CommunityPermissions_NO_MEMBERSHIP
+	members, err = org.ChatMembers(testChatID1)
+	s.Require().NoError(err)
+	s.Require().Len(members, 2)
+
+	_, err = org.BanUserFromCommunity(&s.member1.PublicKey)
+	s.Require().NoError(err)
+	members, err = org.ChatMembers(testChatID1)
+	s.Require().NoError(err)
+	s.Require().Len(members, 1)
+	s.Require().True(common.IsPubKeyEqual(&s.member2.PublicKey, members[0]))
+
+	_, err = org.ChatMembers("unknown-chat")
+	s.Require().Equal(ErrChatNotFound, err)
+}
@@ -0,0 +1,339 @@
+package communities
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// allPermissions is the permissions bitset of the owner and of members with ROLE_ALL
+const allPermissions = ^uint64(0)
+
+// legacyRolePermissions maps the roles of CommunityMember.roles to permissions
+func legacyRolePermissions(role protobuf.CommunityMember_Roles) uint64 {
+	switch role {
+	case protobuf.CommunityMember_ROLE_ALL:
+		return allPermissions
+	case protobuf.CommunityMember_ROLE_MANAGE_USERS:
+		return uint64(protobuf.CommunityRole_MANAGE_USERS)
+	}
+	return 0
+}
+
+// memberPermissions returns the permissions bitset of a member. If chatID is
+// not empty, the roles scoped to that chat and the chat overrides are applied
+func (o *Community) memberPermissions(pk *ecdsa.PublicKey, chatID string) uint64 {
+	if common.IsPubKeyEqual(pk, o.config.ID) {
+		return allPermissions
+	}
+
+	if o.isBanned(pk) {
+		return 0
+	}
+
+	member := o.getMember(pk)
+	if member == nil {
+		return 0
+	}
+
+	var permissions uint64
+	for _, r := range member.Roles {
+		permissions |= legacyRolePermissions(r)
+	}
+
+	// Admins can't be restricted
+	if permissions == allPermissions {
+		return permissions
+	}
+
+	roleIDs := make([]string, len(member.RoleIds))
+	copy(roleIDs, member.RoleIds)
+
+	if len(chatID) != 0 {
+		if chat, ok := o.config.CommunityDescription.Chats[chatID]; ok {
+			if chatMember, ok := chat.Members[common.PubkeyToHex(pk)]; ok && chatMember != nil {
+				roleIDs = append(roleIDs, chatMember.RoleIds...)
+			}
+		}
+	}
+
+	for _, roleID := range roleIDs {
+		if role, ok := o.config.CommunityDescription.Roles[roleID]; ok {
+			permissions |= role.Permissions
+		}
+	}
+
+	if len(chatID) == 0 {
+		return permissions
+	}
+
+	chat, ok := o.config.CommunityDescription.Chats[chatID]
+	if !ok {
+		return permissions
+	}
+
+	var allow, deny uint64
+	for _, roleID := range roleIDs {
+		if override, ok := chat.RoleOverrides[roleID]; ok && override != nil {
+			allow |= override.Allow
+			deny |= override.Deny
+		}
+	}
+
+	return (permissions | allow) &^ deny
+}
+
+func (o *Community) hasPermissionBit(pk *ecdsa.PublicKey, chatID string, permission protobuf.CommunityRole_Permission) bool {
+	return o.memberPermissions(pk, chatID)&uint64(permission) != 0
+}
+
+// HasPermission returns whether the member has the given permission in the
+// community, or in the chat if chatID is not empty
+func (o *Community) HasPermission(pk *ecdsa.PublicKey, chatID string, permission protobuf.CommunityRole_Permission) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.hasPermissionBit(pk, chatID, permission)
+}
+
+func (o *Community) Roles() map[string]*protobuf.CommunityRole {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	response := make(map[string]*protobuf.CommunityRole)
+	for k, v := range o.config.CommunityDescription.Roles {
+		response[k] = v
+	}
+	return response
+}
+
+func (o *Community) CreateRole(roleID string, name string, permissions uint64) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	if o.config.CommunityDescription.Roles == nil {
+		o.config.CommunityDescription.Roles = make(map[string]*protobuf.CommunityRole)
+	}
+	if _, ok := o.config.CommunityDescription.Roles[roleID]; ok {
+		return nil, ErrRoleAlreadyExists
+	}
+
+	role := &protobuf.CommunityRole{
+		RoleId:      roleID,
+		Name:        name,
+		Permissions: permissions,
+	}
+	if err := validateCommunityRole(o.config.CommunityDescription, roleID, role); err != nil {
+		return nil, err
+	}
+
+	o.config.CommunityDescription.Roles[roleID] = role
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+func (o *Community) EditRole(roleID string, name string, permissions uint64) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	if _, ok := o.config.CommunityDescription.Roles[roleID]; !ok {
+		return nil, ErrRoleNotFound
+	}
+
+	role := &protobuf.CommunityRole{
+		RoleId:      roleID,
+		Name:        name,
+		Permissions: permissions,
+	}
+	if err := validateCommunityRole(o.config.CommunityDescription, roleID, role); err != nil {
+		return nil, err
+	}
+
+	o.config.CommunityDescription.Roles[roleID] = role
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+// DeleteRole deletes the role and removes it from any member and chat override
+func (o *Community) DeleteRole(roleID string) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	if _, ok := o.config.CommunityDescription.Roles[roleID]; !ok {
+		return nil, ErrRoleNotFound
+	}
+
+	delete(o.config.CommunityDescription.Roles, roleID)
+
+	for _, member := range o.config.CommunityDescription.Members {
+		member.RoleIds = removeRoleID(member.RoleIds, roleID)
+	}
+
+	for _, chat := range o.config.CommunityDescription.Chats {
+		for _, member := range chat.Members {
+			member.RoleIds = removeRoleID(member.RoleIds, roleID)
+		}
+		delete(chat.RoleOverrides, roleID)
+	}
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+// AssignRole assigns a role to a member, if chatID is not empty the role
+// will only apply to that chat
+func (o *Community) AssignRole(pk *ecdsa.PublicKey, roleID string, chatID string) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	member, err := o.roleMember(pk, roleID, chatID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range member.RoleIds {
+		if id == roleID {
+			return o.config.CommunityDescription, nil
+		}
+	}
+	member.RoleIds = append(member.RoleIds, roleID)
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+// RevokeRole revokes a role from a member, if chatID is not empty only the
+// role scoped to that chat is revoked
+func (o *Community) RevokeRole(pk *ecdsa.PublicKey, roleID string, chatID string) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	member, err := o.roleMember(pk, roleID, chatID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := removeRoleID(member.RoleIds, roleID)
+	if len(roleIDs) == len(member.RoleIds) {
+		return o.config.CommunityDescription, nil
+	}
+	member.RoleIds = roleIDs
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+// SetChatRoleOverride overrides the permissions of a role in a chat, an
+// override with no allowed and no denied permissions is removed
+func (o *Community) SetChatRoleOverride(chatID string, roleID string, allow uint64, deny uint64) (*protobuf.CommunityDescription, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.config.PrivateKey == nil {
+		return nil, ErrNotAdmin
+	}
+
+	chat, ok := o.config.CommunityDescription.Chats[chatID]
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+
+	if _, ok := o.config.CommunityDescription.Roles[roleID]; !ok {
+		return nil, ErrRoleNotFound
+	}
+
+	if allow == 0 && deny == 0 {
+		delete(chat.RoleOverrides, roleID)
+	} else {
+		if chat.RoleOverrides == nil {
+			chat.RoleOverrides = make(map[string]*protobuf.CommunityPermissionOverride)
+		}
+		chat.RoleOverrides[roleID] = &protobuf.CommunityPermissionOverride{
+			Allow: allow,
+			Deny:  deny,
+		}
+	}
+
+	o.increaseClock()
+
+	return o.config.CommunityDescription, nil
+}
+
+// roleMember returns the member entry that holds the roles for the given
+// scope, creating the chat entry for chats with no membership if create is set
+func (o *Community) roleMember(pk *ecdsa.PublicKey, roleID string, chatID string, create bool) (*protobuf.CommunityMember, error) {
+	if _, ok := o.config.CommunityDescription.Roles[roleID]; !ok {
+		return nil, ErrRoleNotFound
+	}
+
+	member := o.getMember(pk)
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	if len(chatID) == 0 {
+		return member, nil
+	}
+
+	chat, ok := o.config.CommunityDescription.Chats[chatID]
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+
+	key := common.PubkeyToHex(pk)
+	chatMember, ok := chat.Members[key]
+	if ok && chatMember != nil {
+		return chatMember, nil
+	}
+
+	// Adding a member to a chat that requires membership would grant access
+	// to it, which is not what assigning a role is meant for
+	if !create || chat.Permissions.Access != protobuf.CommunityPermissions_NO_MEMBERSHIP {
+		return nil, ErrMemberNotFound
+	}
+
+	if chat.Members == nil {
+		chat.Members = make(map[string]*protobuf.CommunityMember)
+	}
+	chatMember = &protobuf.CommunityMember{}
+	chat.Members[key] = chatMember
+
+	return chatMember, nil
+}
+
+func removeRoleID(roleIDs []string, roleID string) []string {
+	var response []string
+	for _, id := range roleIDs {
+		if id != roleID {
+			response = append(response, id)
+		}
+	}
+	return response
+}
@@ -0,0 +1,195 @@
+package communities
+
+import (
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+const testRoleID1 = "role-id-1"
+const testRoleName1 = "role-name-1"
+const testRoleID2 = "role-id-2"
+const testRoleName2 = "role-name-2"
+
+func (s *CommunitySuite) TestCreateRole() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+	org.config.PrivateKey = nil
+
+	_, err := org.CreateRole(testRoleID1, testRoleName1, uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().Equal(ErrNotAdmin, err)
+
+	org.config.PrivateKey = s.identity
+	clock := org.Clock()
+
+	description, err := org.CreateRole(testRoleID1, testRoleName1, uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().NoError(err)
+	s.Require().Equal(clock+1, description.Clock)
+	s.Require().NotNil(description.Roles[testRoleID1])
+	s.Require().Equal(testRoleName1, description.Roles[testRoleID1].Name)
+	s.Require().Equal(uint64(protobuf.CommunityRole_DELETE_MESSAGES), description.Roles[testRoleID1].Permissions)
+
+	_, err = org.CreateRole(testRoleID1, testRoleName2, 0)
+	s.Require().Equal(ErrRoleAlreadyExists, err)
+
+	_, err = org.CreateRole(testRoleID2, testRoleName1, 0)
+	s.Require().Equal(ErrInvalidCommunityDescriptionDuplicatedRoleName, err)
+
+	_, err = org.CreateRole(testRoleID2, "", 0)
+	s.Require().Equal(ErrInvalidCommunityDescriptionRoleNoName, err)
+
+	description, err = org.EditRole(testRoleID1, testRoleName2, uint64(protobuf.CommunityRole_BAN_USERS))
+	s.Require().NoError(err)
+	s.Require().Equal(testRoleName2, description.Roles[testRoleID1].Name)
+	s.Require().Equal(uint64(protobuf.CommunityRole_BAN_USERS), description.Roles[testRoleID1].Permissions)
+
+	_, err = org.EditRole(testRoleID2, testRoleName2, 0)
+	s.Require().Equal(ErrRoleNotFound, err)
+}
+
+func (s *CommunitySuite) TestAssignAndRevokeRole() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+
+	_, err := org.AssignRole(&s.member1.PublicKey, testRoleID1, "")
+	s.Require().Equal(ErrRoleNotFound, err)
+
+	permissions := uint64(protobuf.CommunityRole_DELETE_MESSAGES | protobuf.CommunityRole_BAN_USERS)
+	_, err = org.CreateRole(testRoleID1, testRoleName1, permissions)
+	s.Require().NoError(err)
+
+	_, err = org.AssignRole(&s.member3.PublicKey, testRoleID1, "")
+	s.Require().Equal(ErrMemberNotFound, err)
+
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+
+	description, err := org.AssignRole(&s.member1.PublicKey, testRoleID1, "")
+	s.Require().NoError(err)
+	s.Require().Equal([]string{testRoleID1}, description.Members[s.member1Key].RoleIds)
+	s.Require().NoError(ValidateCommunityDescription(description))
+
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_MANAGE_USERS))
+	s.Require().False(org.HasPermission(&s.member2.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+
+	// The owner has every permission
+	s.Require().True(org.HasPermission(&s.identity.PublicKey, "", protobuf.CommunityRole_MANAGE_USERS))
+
+	description, err = org.RevokeRole(&s.member1.PublicKey, testRoleID1, "")
+	s.Require().NoError(err)
+	s.Require().Empty(description.Members[s.member1Key].RoleIds)
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+
+	// member2 is not in the chat, which requires an invitation
+	_, err = org.AssignRole(&s.member2.PublicKey, testRoleID1, testChatID1)
+	s.Require().Equal(ErrMemberNotFound, err)
+
+	description, err = org.AssignRole(&s.member1.PublicKey, testRoleID1, testChatID1)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{testRoleID1}, description.Chats[testChatID1].Members[s.member1Key].RoleIds)
+
+	// Chat roles only apply to the chat
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_DELETE_MESSAGES))
+
+	_, err = org.BanUserFromCommunity(&s.member1.PublicKey)
+	s.Require().NoError(err)
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_DELETE_MESSAGES))
+}
+
+func (s *CommunitySuite) TestChatRoleOverride() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+
+	_, err := org.CreateRole(testRoleID1, testRoleName1, uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().NoError(err)
+
+	_, err = org.CreateRole(testRoleID2, testRoleName2, 0)
+	s.Require().NoError(err)
+
+	_, err = org.AssignRole(&s.member1.PublicKey, testRoleID1, "")
+	s.Require().NoError(err)
+
+	_, err = org.AssignRole(&s.member1.PublicKey, testRoleID2, "")
+	s.Require().NoError(err)
+
+	_, err = org.SetChatRoleOverride("unknown-chat", testRoleID1, 0, 0)
+	s.Require().Equal(ErrChatNotFound, err)
+
+	_, err = org.SetChatRoleOverride(testChatID1, "unknown-role", 0, 0)
+	s.Require().Equal(ErrRoleNotFound, err)
+
+	description, err := org.SetChatRoleOverride(testChatID1, testRoleID2, uint64(protobuf.CommunityRole_BAN_USERS), uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().NoError(err)
+	s.Require().NoError(ValidateCommunityDescription(description))
+
+	// Deny takes precedence over the permissions of other roles
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_BAN_USERS))
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+
+	description, err = org.SetChatRoleOverride(testChatID1, testRoleID2, 0, 0)
+	s.Require().NoError(err)
+	s.Require().Empty(description.Chats[testChatID1].RoleOverrides)
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, testChatID1, protobuf.CommunityRole_DELETE_MESSAGES))
+}
+
+func (s *CommunitySuite) TestDeleteRole() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+
+	_, err := org.CreateRole(testRoleID1, testRoleName1, uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().NoError(err)
+
+	_, err = org.AssignRole(&s.member1.PublicKey, testRoleID1, "")
+	s.Require().NoError(err)
+
+	_, err = org.AssignRole(&s.member1.PublicKey, testRoleID1, testChatID1)
+	s.Require().NoError(err)
+
+	_, err = org.SetChatRoleOverride(testChatID1, testRoleID1, 0, uint64(protobuf.CommunityRole_DELETE_MESSAGES))
+	s.Require().NoError(err)
+
+	description, err := org.DeleteRole(testRoleID1)
+	s.Require().NoError(err)
+	s.Require().Empty(description.Roles)
+	s.Require().Empty(description.Members[s.member1Key].RoleIds)
+	s.Require().Empty(description.Chats[testChatID1].Members[s.member1Key].RoleIds)
+	s.Require().Empty(description.Chats[testChatID1].RoleOverrides)
+	s.Require().NoError(ValidateCommunityDescription(description))
+
+	_, err = org.DeleteRole(testRoleID1)
+	s.Require().Equal(ErrRoleNotFound, err)
+}
+
+func (s *CommunitySuite) TestLegacyRoles() {
+	org := s.buildCommunity(&s.identity.PublicKey)
+
+	org.config.CommunityDescription.Members[s.member1Key].Roles = []protobuf.CommunityMember_Roles{protobuf.CommunityMember_ROLE_MANAGE_USERS}
+	org.config.CommunityDescription.Members[s.member2Key].Roles = []protobuf.CommunityMember_Roles{protobuf.CommunityMember_ROLE_ALL}
+
+	s.Require().True(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_MANAGE_USERS))
+	s.Require().False(org.HasPermission(&s.member1.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+	s.Require().True(org.HasPermission(&s.member2.PublicKey, "", protobuf.CommunityRole_BAN_USERS))
+
+	pks, err := org.CanManageUsersPublicKeys()
+	s.Require().NoError(err)
+	s.Require().Len(pks, 2)
+}
+
+func (s *CommunitySuite) TestValidateCommunityDescriptionRoles() {
+	description := s.buildCommunityDescription()
+	description.Roles = map[string]*protobuf.CommunityRole{
+		testRoleID1: {RoleId: testRoleID1, Name: testRoleName1},
+	}
+	s.Require().NoError(ValidateCommunityDescription(description))
+
+	description.Members[s.member1Key].RoleIds = []string{testRoleID2}
+	s.Require().Equal(ErrInvalidCommunityDescriptionUnknownRole, ValidateCommunityDescription(description))
+
+	description.Members[s.member1Key].RoleIds = nil
+	description.Chats[testChatID1].RoleOverrides = map[string]*protobuf.CommunityPermissionOverride{
+		testRoleID2: {},
+	}
+	s.Require().Equal(ErrInvalidCommunityDescriptionUnknownRole, ValidateCommunityDescription(description))
+
+	description.Chats[testChatID1].RoleOverrides = nil
+	description.Roles[testRoleID2] = &protobuf.CommunityRole{RoleId: testRoleID1, Name: testRoleName2}
+	s.Require().Equal(ErrInvalidCommunityDescriptionRoleNoID, ValidateCommunityDescription(description))
+}
@@ -26,3 +26,14 @@ var ErrInvalidGrant = errors.New("invalid grant")
 var ErrNotAuthorized = errors.New("not authorized")
 var ErrAlreadyMember = errors.New("already a member")
 var ErrInvalidMessage = errors.New("invalid community description message")
+var ErrRoleNotFound = errors.New("role not found")
+var ErrRoleAlreadyExists = errors.New("role already exists")
+var ErrMemberNotFound = errors.New("member not found")
+var ErrInvalidCommunityDescriptionRoleNoID = errors.New("invalid community role id")
+var ErrInvalidCommunityDescriptionRoleNoName = errors.New("invalid community role name")
+var ErrInvalidCommunityDescriptionDuplicatedRoleName = errors.New("invalid community role name, duplicated")
+var ErrInvalidCommunityDescriptionUnknownRole = errors.New("invalid community description unknown role")
+var ErrInvalidCommunityDescriptionTokenRequirement = errors.New("invalid community token requirement")
+var ErrInvalidAddressOwnershipProof = errors.New("invalid address ownership proof")
+var ErrOldMemberAction = errors.New("old member action")
+var ErrTokenRequirementsNotMet = errors.New("token requirements not met")
@@ -1,10 +1,12 @@
 package communities
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -22,16 +24,20 @@ import (
 )
 
 type Manager struct {
-	persistence     *Persistence
-	ensSubscription chan []*ens.VerificationRecord
-	subscriptions   []chan *Subscription
-	ensVerifier     *ens.Verifier
-	identity        *ecdsa.PublicKey
-	logger          *zap.Logger
-	quit            chan struct{}
+	persistence         *Persistence
+	ensSubscription     chan []*ens.VerificationRecord
+	subscriptions       []chan *Subscription
+	ensVerifier         *ens.Verifier
+	tokenBalanceChecker TokenBalanceChecker
+	// tokenChecks limits the number of token requirements checked at once
+	tokenChecks chan struct{}
+	identity    *ecdsa.PublicKey
+	logger      *zap.Logger
+	quit        chan struct{}
+	wg          sync.WaitGroup
 }
 
-func NewManager(identity *ecdsa.PublicKey, db *sql.DB, logger *zap.Logger, verifier *ens.Verifier) (*Manager, error) {
+func NewManager(identity *ecdsa.PublicKey, db *sql.DB, logger *zap.Logger, verifier *ens.Verifier, tokenBalanceChecker TokenBalanceChecker) (*Manager, error) {
 	if identity == nil {
 		return nil, errors.New("empty identity")
 	}
@@ -44,9 +50,11 @@ func NewManager(identity *ecdsa.PublicKey, db *sql.DB, logger *zap.Logger, verif
 	}
 
 	manager := &Manager{
-		logger:   logger,
-		identity: identity,
-		quit:     make(chan struct{}),
+		logger:              logger,
+		identity:            identity,
+		tokenBalanceChecker: tokenBalanceChecker,
+		tokenChecks:         make(chan struct{}, maxConcurrentTokenChecks),
+		quit:                make(chan struct{}),
 		persistence: &Persistence{
 			logger: logger,
 			db:     db,
@@ -66,6 +74,12 @@ func NewManager(identity *ecdsa.PublicKey, db *sql.DB, logger *zap.Logger, verif
 type Subscription struct {
 	Community   *Community
 	Invitations []*protobuf.CommunityInvitation
+	// RemovedMembers are the members that have been removed or banned from the community
+	RemovedMembers []*ecdsa.PublicKey
+	// RemovedFromChatID is set when the members have only been removed from this chat
+	RemovedFromChatID string
+	// RequestToJoin is a request to join whose token requirements have been checked
+	RequestToJoin *RequestToJoin
 }
 
 type CommunityResponse struct {
@@ -107,6 +121,7 @@ func (m *Manager) runENSVerificationLoop() {
 
 func (m *Manager) Stop() error {
 	close(m.quit)
+	m.wg.Wait()
 	for _, c := range m.subscriptions {
 		close(c)
 	}
@@ -673,9 +688,101 @@ func (m *Manager) HandleCommunityRequestToJoin(signer *ecdsa.PublicKey, request
 		return nil, err
 	}
 
+	// Requests to join the community are accepted or declined automatically
+	// once the balances are checked, this calls the chain so it's done in
+	// the background
+	if m.ChecksTokenRequirements(community, request) {
+		checked := *requestToJoin
+		m.wg.Add(1)
+		go m.checkRequestToJoinTokenRequirements(signer, community.TokenRequirements(), request, &checked)
+	}
+
 	return requestToJoin, nil
 }
 
+// ChecksTokenRequirements returns whether a request to join the community is
+// accepted or declined automatically, depending on the token balances of the requester
+func (m *Manager) ChecksTokenRequirements(community *Community, request *protobuf.CommunityRequestToJoin) bool {
+	return len(request.ChatId) == 0 && len(community.TokenRequirements()) != 0 && m.tokenBalanceChecker != nil
+}
+
+// checkRequestToJoinTokenRequirements accepts or declines a pending request to
+// join, if the balances can't be checked the request is left to the owner
+func (m *Manager) checkRequestToJoinTokenRequirements(signer *ecdsa.PublicKey, requirements []*protobuf.TokenRequirement, request *protobuf.CommunityRequestToJoin, requestToJoin *RequestToJoin) {
+	defer m.wg.Done()
+
+	select {
+	case m.tokenChecks <- struct{}{}:
+		defer func() { <-m.tokenChecks }()
+	case <-m.quit:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenBalanceTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-m.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	meetsRequirements, err := checkTokenRequirements(ctx, m.tokenBalanceChecker, signer, requirements, request)
+	select {
+	case <-m.quit:
+		return
+	default:
+	}
+
+	switch {
+	case err == ErrInvalidAddressOwnershipProof:
+		requestToJoin.State = RequestToJoinStateDeclined
+	case err != nil:
+		m.logger.Warn("failed to check token requirements", zap.Error(err))
+	case meetsRequirements:
+		requestToJoin.State = RequestToJoinStateAccepted
+	default:
+		requestToJoin.State = RequestToJoinStateDeclined
+	}
+
+	err = m.completeRequestToJoin(signer, requestToJoin)
+	if err != nil {
+		m.logger.Warn("failed to complete request to join", zap.Error(err))
+		return
+	}
+
+	m.publish(&Subscription{RequestToJoin: requestToJoin})
+}
+
+// completeRequestToJoin applies the outcome of the token requirements check,
+// unless the request has been handled by the owner in the meantime
+func (m *Manager) completeRequestToJoin(signer *ecdsa.PublicKey, requestToJoin *RequestToJoin) error {
+	dbRequest, err := m.persistence.GetRequestToJoin(requestToJoin.ID)
+	if err != nil {
+		return err
+	}
+	if dbRequest.State != RequestToJoinStatePending {
+		return ErrOldRequestToJoin
+	}
+
+	switch requestToJoin.State {
+	case RequestToJoinStateAccepted:
+		community, err := m.GetByID(requestToJoin.CommunityID)
+		if err != nil {
+			return err
+		}
+		if community == nil {
+			return ErrOrgNotFound
+		}
+		_, err = m.inviteUsersToCommunity(community, []*ecdsa.PublicKey{signer})
+		return err
+	case RequestToJoinStateDeclined:
+		return m.persistence.SetRequestToJoinState(requestToJoin.PublicKey, requestToJoin.CommunityID, RequestToJoinStateDeclined)
+	}
+	return nil
+}
+
 func (m *Manager) HandleWrappedCommunityDescriptionMessage(payload []byte) (*CommunityResponse, error) {
 	m.logger.Debug("Handling wrapped community description message")
 
@@ -797,7 +904,40 @@ func (m *Manager) RemoveUserFromCommunity(id types.HexBytes, pk *ecdsa.PublicKey
 		return nil, err
 	}
 
-	m.publish(&Subscription{Community: community})
+	m.publish(&Subscription{Community: community, RemovedMembers: []*ecdsa.PublicKey{pk}})
+
+	return community, nil
+}
+
+// RemoveUserFromCommunityChat removes the user from a chat of the community,
+// which requires membership
+func (m *Manager) RemoveUserFromCommunityChat(id types.HexBytes, chatID string, pk *ecdsa.PublicKey) (*Community, error) {
+	community, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	// Remove communityID prefix from chatID if exists
+	chatID = strings.TrimPrefix(chatID, id.String())
+
+	if _, ok := community.Chats()[chatID]; !ok {
+		return nil, ErrChatNotFound
+	}
+
+	_, err = community.RemoveUserFromChat(pk, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.SaveCommunity(community)
+	if err != nil {
+		return nil, err
+	}
+
+	m.publish(&Subscription{Community: community, RemovedMembers: []*ecdsa.PublicKey{pk}, RemovedFromChatID: chatID})
 
 	return community, nil
 }
@@ -828,6 +968,206 @@ func (m *Manager) BanUserFromCommunity(request *requests.BanUserFromCommunity) (
 		return nil, err
 	}
 
+	m.publish(&Subscription{Community: community, RemovedMembers: []*ecdsa.PublicKey{publicKey}})
+
+	return community, nil
+}
+
+func (m *Manager) CreateRole(request *requests.CreateCommunityRole) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	_, err = community.CreateRole(uuid.New().String(), request.Name, request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+func (m *Manager) EditRole(request *requests.EditCommunityRole) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	_, err = community.EditRole(request.RoleID, request.Name, request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+func (m *Manager) DeleteRole(request *requests.DeleteCommunityRole) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	_, err = community.DeleteRole(request.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+func (m *Manager) AssignRole(request *requests.AssignCommunityRole) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	publicKey, err := common.HexToPubkey(request.User.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove communityID prefix from chatID if exists
+	chatID := strings.TrimPrefix(request.ChatID, request.CommunityID.String())
+
+	_, err = community.AssignRole(publicKey, request.RoleID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+func (m *Manager) RevokeRole(request *requests.RevokeCommunityRole) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	publicKey, err := common.HexToPubkey(request.User.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove communityID prefix from chatID if exists
+	chatID := strings.TrimPrefix(request.ChatID, request.CommunityID.String())
+
+	_, err = community.RevokeRole(publicKey, request.RoleID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+func (m *Manager) SetChatRoleOverride(request *requests.SetCommunityChatRoleOverride) (*Community, error) {
+	community, err := m.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	// Remove communityID prefix from chatID if exists
+	chatID := strings.TrimPrefix(request.ChatID, request.CommunityID.String())
+
+	_, err = community.SetChatRoleOverride(chatID, request.RoleID, request.Allow, request.Deny)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveAndPublish(community)
+}
+
+// HandleCommunityMemberAction applies a kick or a ban requested by a member
+// with the matching permission, only the owner of the community can apply it
+func (m *Manager) HandleCommunityMemberAction(signer *ecdsa.PublicKey, action *protobuf.CommunityMemberAction) (*Community, error) {
+	community, err := m.persistence.GetByID(m.identity, action.CommunityId)
+	if err != nil {
+		return nil, err
+	}
+	if community == nil {
+		return nil, ErrOrgNotFound
+	}
+
+	if !community.IsAdmin() {
+		return nil, ErrNotAdmin
+	}
+
+	publicKey, err := common.HexToPubkey(action.MemberId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins can't be kicked or banned by other members
+	if common.IsPubKeyEqual(publicKey, community.PublicKey()) || community.IsMemberAdmin(publicKey) {
+		return nil, ErrNotAuthorized
+	}
+
+	// Actions are only applied once and in order, a replayed action would
+	// undo a later decision of the owner
+	clock, err := m.persistence.MemberActionClock(community.ID(), action.MemberId)
+	if err != nil {
+		return nil, err
+	}
+	if action.Clock <= clock {
+		return nil, ErrOldMemberAction
+	}
+
+	switch action.Type {
+	case protobuf.CommunityMemberAction_KICK:
+		if !community.HasPermission(signer, "", protobuf.CommunityRole_MANAGE_USERS) {
+			return nil, ErrNotAuthorized
+		}
+		_, err = community.RemoveUserFromOrg(publicKey)
+	case protobuf.CommunityMemberAction_BAN:
+		if !community.HasPermission(signer, "", protobuf.CommunityRole_BAN_USERS) {
+			return nil, ErrNotAuthorized
+		}
+		_, err = community.BanUserFromCommunity(publicKey)
+	default:
+		return nil, ErrInvalidMessage
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.SaveCommunity(community)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.SaveMemberActionClock(community.ID(), action.MemberId, action.Clock)
+	if err != nil {
+		return nil, err
+	}
+
+	m.publish(&Subscription{Community: community, RemovedMembers: []*ecdsa.PublicKey{publicKey}})
+
+	return community, nil
+}
+
+func (m *Manager) saveAndPublish(community *Community) (*Community, error) {
+	err := m.persistence.SaveCommunity(community)
+	if err != nil {
+		return nil, err
+	}
+
 	m.publish(&Subscription{Community: community})
 
 	return community, nil
@@ -901,6 +1241,19 @@ func (m *Manager) CanPost(pk *ecdsa.PublicKey, communityID string, chatID string
 	return community.CanPost(pk, chatID, grant)
 }
 
+// HasPermission returns whether the member has the given permission in the
+// community, or in the chat if chatID is not empty
+func (m *Manager) HasPermission(pk *ecdsa.PublicKey, communityID string, chatID string, permission protobuf.CommunityRole_Permission) (bool, error) {
+	community, err := m.GetByIDString(communityID)
+	if err != nil {
+		return false, err
+	}
+	if community == nil {
+		return false, nil
+	}
+	return community.HasPermission(pk, chatID, permission), nil
+}
+
 func (m *Manager) ShouldHandleSyncCommunity(community *protobuf.SyncCommunity) (bool, error) {
 	return m.persistence.ShouldHandleSyncCommunity(community)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/protocol/common"
 	"github.com/planq-network/status-go/protocol/protobuf"
 	"github.com/planq-network/status-go/protocol/sqlite"
 )
@@ -31,7 +32,7 @@ func (s *ManagerSuite) SetupTest() {
 	key, err := crypto.GenerateKey()
 	s.Require().NoError(err)
 	s.Require().NoError(err)
-	m, err := NewManager(&key.PublicKey, db, nil, nil)
+	m, err := NewManager(&key.PublicKey, db, nil, nil, nil)
 	s.Require().NoError(err)
 	s.Require().NoError(m.Start())
 	s.manager = m
@@ -104,3 +105,61 @@ func (s *ManagerSuite) TestEditCommunity() {
 	s.Require().Equal(storedCommunity.config.CommunityDescription.Identity.DisplayName, update.CreateCommunity.Name)
 	s.Require().Equal(storedCommunity.config.CommunityDescription.Identity.Description, update.CreateCommunity.Description)
 }
+
+func (s *ManagerSuite) TestHandleCommunityMemberActionRejectsReplays() {
+	request := &requests.CreateCommunity{
+		Name:        "status",
+		Description: "status community description",
+		Membership:  protobuf.CommunityPermissions_NO_MEMBERSHIP,
+	}
+
+	community, err := s.manager.CreateCommunity(request)
+	s.Require().NoError(err)
+
+	moderator, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	member, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	memberID := common.PubkeyToHex(&member.PublicKey)
+
+	_, err = community.InviteUserToOrg(&moderator.PublicKey)
+	s.Require().NoError(err)
+	_, err = community.InviteUserToOrg(&member.PublicKey)
+	s.Require().NoError(err)
+	_, err = community.CreateRole(testRoleID1, testRoleName1, uint64(protobuf.CommunityRole_MANAGE_USERS))
+	s.Require().NoError(err)
+	_, err = community.AssignRole(&moderator.PublicKey, testRoleID1, "")
+	s.Require().NoError(err)
+	s.Require().NoError(s.manager.persistence.SaveCommunity(community))
+
+	action := &protobuf.CommunityMemberAction{
+		Clock:       2,
+		CommunityId: community.ID(),
+		MemberId:    memberID,
+		Type:        protobuf.CommunityMemberAction_KICK,
+	}
+	community, err = s.manager.HandleCommunityMemberAction(&moderator.PublicKey, action)
+	s.Require().NoError(err)
+	s.Require().False(community.HasMember(&member.PublicKey))
+
+	// The member is invited back by the owner
+	_, err = community.InviteUserToOrg(&member.PublicKey)
+	s.Require().NoError(err)
+	s.Require().NoError(s.manager.persistence.SaveCommunity(community))
+
+	_, err = s.manager.HandleCommunityMemberAction(&moderator.PublicKey, action)
+	s.Require().Equal(ErrOldMemberAction, err)
+
+	action.Clock = 1
+	_, err = s.manager.HandleCommunityMemberAction(&moderator.PublicKey, action)
+	s.Require().Equal(ErrOldMemberAction, err)
+
+	community, err = s.manager.GetByID(community.ID())
+	s.Require().NoError(err)
+	s.Require().True(community.HasMember(&member.PublicKey))
+
+	action.Clock = 3
+	community, err = s.manager.HandleCommunityMemberAction(&moderator.PublicKey, action)
+	s.Require().NoError(err)
+	s.Require().False(community.HasMember(&member.PublicKey))
+}
@@ -269,6 +269,21 @@ func (p *Persistence) SaveRequestToJoin(request *RequestToJoin) (err error) {
 	return err
 }
 
+// MemberActionClock returns the clock of the last kick or ban applied to a member
+func (p *Persistence) MemberActionClock(communityID []byte, memberID string) (uint64, error) {
+	var clock uint64
+	err := p.db.QueryRow(`SELECT clock FROM communities_member_actions WHERE community_id = ? AND member_id = ?`, communityID, memberID).Scan(&clock)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return clock, err
+}
+
+func (p *Persistence) SaveMemberActionClock(communityID []byte, memberID string, clock uint64) error {
+	_, err := p.db.Exec(`INSERT INTO communities_member_actions (community_id, member_id, clock) VALUES (?, ?, ?)`, communityID, memberID, clock)
+	return err
+}
+
 func (p *Persistence) PendingRequestsToJoinForUser(pk string) ([]*RequestToJoin, error) {
 	var requests []*RequestToJoin
 	rows, err := p.db.Query(`SELECT id,public_key,clock,ens_name,chat_id,community_id,state FROM communities_requests_to_join WHERE state = ? AND public_key = ?`, RequestToJoinStatePending, pk)
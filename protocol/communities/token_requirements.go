@@ -0,0 +1,139 @@
+package communities
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/rpc"
+	"github.com/planq-network/status-go/services/wallet/chain"
+	"github.com/planq-network/status-go/services/wallet/ierc20"
+)
+
+var tokenBalanceTimeout = 20 * time.Second
+
+// maxConcurrentTokenChecks is the number of requests to join whose token
+// requirements are checked at the same time
+const maxConcurrentTokenChecks = 5
+
+// TokenBalanceChecker returns the balance of an account for a token contract
+type TokenBalanceChecker interface {
+	BalanceOf(ctx context.Context, chainID uint64, contract gethcommon.Address, account gethcommon.Address) (*big.Int, error)
+}
+
+// contractTokenBalanceChecker calls balanceOf(address) on the contract, which
+// has the same signature for ERC-20 and ERC-721 tokens
+type contractTokenBalanceChecker struct {
+	caller func(chainID uint64) (bind.ContractCaller, error)
+}
+
+// NewTokenBalanceChecker returns a TokenBalanceChecker using the networks
+// configured in the rpc client
+func NewTokenBalanceChecker(client *rpc.Client) TokenBalanceChecker {
+	return &contractTokenBalanceChecker{
+		caller: func(chainID uint64) (bind.ContractCaller, error) {
+			return chain.NewClient(client, chainID)
+		},
+	}
+}
+
+func (c *contractTokenBalanceChecker) BalanceOf(ctx context.Context, chainID uint64, contract gethcommon.Address, account gethcommon.Address) (*big.Int, error) {
+	backend, err := c.caller(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ierc20.NewIERC20Caller(contract, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return caller.BalanceOf(&bind.CallOpts{Context: ctx}, account)
+}
+
+// AddressOwnershipProofMessage returns the message that needs to be signed
+// with personal_sign by each address used to meet the token requirements
+func AddressOwnershipProofMessage(communityID types.HexBytes, requester *ecdsa.PublicKey) []byte {
+	return []byte(fmt.Sprintf("Request to join community %s with chat key %s", communityID.String(), common.PubkeyToHex(requester)))
+}
+
+// verifyAddressOwnershipProof returns the address if the signature of the
+// proof was made by it
+func verifyAddressOwnershipProof(message []byte, proof *protobuf.AddressOwnershipProof) (gethcommon.Address, error) {
+	if proof == nil || !gethcommon.IsHexAddress(proof.Address) || len(proof.Signature) != 65 {
+		return gethcommon.Address{}, ErrInvalidAddressOwnershipProof
+	}
+
+	signature := make([]byte, len(proof.Signature))
+	copy(signature, proof.Signature)
+	// Transform yellow paper V from 27/28 to 0/1
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(crypto.TextHash(message), signature)
+	if err != nil {
+		return gethcommon.Address{}, ErrInvalidAddressOwnershipProof
+	}
+
+	address := gethcommon.HexToAddress(proof.Address)
+	if gethcommon.Address(crypto.PubkeyToAddress(*publicKey)) != address {
+		return gethcommon.Address{}, ErrInvalidAddressOwnershipProof
+	}
+
+	return address, nil
+}
+
+// checkTokenRequirements returns whether the addresses proven by the request
+// hold together at least the amount of every token required by the community
+func checkTokenRequirements(ctx context.Context, checker TokenBalanceChecker, requester *ecdsa.PublicKey, requirements []*protobuf.TokenRequirement, request *protobuf.CommunityRequestToJoin) (bool, error) {
+	message := AddressOwnershipProofMessage(request.CommunityId, requester)
+
+	var addresses []gethcommon.Address
+	seen := make(map[gethcommon.Address]bool)
+	for _, proof := range request.AddressProofs {
+		address, err := verifyAddressOwnershipProof(message, proof)
+		if err != nil {
+			return false, err
+		}
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	for _, requirement := range requirements {
+		required, ok := new(big.Int).SetString(requirement.Amount, 10)
+		if !ok {
+			return false, ErrInvalidCommunityDescriptionTokenRequirement
+		}
+
+		contract := gethcommon.HexToAddress(requirement.ContractAddress)
+		total := big.NewInt(0)
+		for _, address := range addresses {
+			balance, err := checker.BalanceOf(ctx, requirement.ChainId, contract, address)
+			if err != nil {
+				return false, err
+			}
+			total.Add(total, balance)
+			if total.Cmp(required) >= 0 {
+				break
+			}
+		}
+
+		if total.Cmp(required) < 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
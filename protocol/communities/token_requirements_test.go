@@ -0,0 +1,248 @@
+package communities
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/sqlite"
+	"github.com/planq-network/status-go/services/ens/erc20"
+)
+
+const testTokenChainID = 1337
+
+func TestTokenRequirementsSuite(t *testing.T) {
+	suite.Run(t, new(TokenRequirementsSuite))
+}
+
+type TokenRequirementsSuite struct {
+	suite.Suite
+
+	backend  *backends.SimulatedBackend
+	checker  TokenBalanceChecker
+	manager  *Manager
+	contract gethcommon.Address
+	holder1  *ecdsa.PrivateKey
+	holder2  *ecdsa.PrivateKey
+}
+
+func (s *TokenRequirementsSuite) SetupTest() {
+	deployer, err := gethcrypto.GenerateKey()
+	s.Require().NoError(err)
+	s.holder1, err = gethcrypto.GenerateKey()
+	s.Require().NoError(err)
+	s.holder2, err = gethcrypto.GenerateKey()
+	s.Require().NoError(err)
+
+	deployerAddress := gethcrypto.PubkeyToAddress(deployer.PublicKey)
+	s.backend = backends.NewSimulatedBackend(core.GenesisAlloc{
+		deployerAddress: {Balance: big.NewInt(1000000000000000000)},
+	}, 10000000)
+
+	auth, err := bind.NewKeyedTransactorWithChainID(deployer, big.NewInt(testTokenChainID))
+	s.Require().NoError(err)
+
+	contract, _, token, err := erc20.DeployMiniMeToken(auth, s.backend, gethcommon.Address{}, gethcommon.Address{}, big.NewInt(0), "Test", 18, "TST", true)
+	s.Require().NoError(err)
+	s.backend.Commit()
+	s.contract = contract
+
+	_, err = token.GenerateTokens(auth, gethcrypto.PubkeyToAddress(s.holder1.PublicKey), big.NewInt(100))
+	s.Require().NoError(err)
+	_, err = token.GenerateTokens(auth, gethcrypto.PubkeyToAddress(s.holder2.PublicKey), big.NewInt(60))
+	s.Require().NoError(err)
+	s.backend.Commit()
+
+	s.checker = &contractTokenBalanceChecker{
+		caller: func(chainID uint64) (bind.ContractCaller, error) {
+			return s.backend, nil
+		},
+	}
+
+	db, err := sqlite.OpenInMemory()
+	s.Require().NoError(err)
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	s.manager, err = NewManager(&key.PublicKey, db, nil, nil, s.checker)
+	s.Require().NoError(err)
+	s.Require().NoError(s.manager.Start())
+}
+
+func (s *TokenRequirementsSuite) TearDownTest() {
+	s.Require().NoError(s.backend.Close())
+	s.Require().NoError(s.manager.Stop())
+}
+
+func (s *TokenRequirementsSuite) requirement(amount string) *protobuf.TokenRequirement {
+	return &protobuf.TokenRequirement{
+		ChainId:         testTokenChainID,
+		ContractAddress: s.contract.Hex(),
+		Type:            protobuf.TokenRequirement_ERC20,
+		Amount:          amount,
+	}
+}
+
+func (s *TokenRequirementsSuite) proof(communityID []byte, requester *ecdsa.PublicKey, key *ecdsa.PrivateKey) *protobuf.AddressOwnershipProof {
+	signature, err := crypto.Sign(crypto.TextHash(AddressOwnershipProofMessage(communityID, requester)), key)
+	s.Require().NoError(err)
+	signature[64] += 27
+
+	return &protobuf.AddressOwnershipProof{
+		Address:   gethcrypto.PubkeyToAddress(key.PublicKey).Hex(),
+		Signature: signature,
+	}
+}
+
+func (s *TokenRequirementsSuite) TestCheckTokenRequirements() {
+	requester, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	communityID := []byte("community-id")
+	request := &protobuf.CommunityRequestToJoin{
+		CommunityId:   communityID,
+		AddressProofs: []*protobuf.AddressOwnershipProof{s.proof(communityID, &requester.PublicKey, s.holder1)},
+	}
+
+	ok, err := checkTokenRequirements(context.Background(), s.checker, &requester.PublicKey, []*protobuf.TokenRequirement{s.requirement("100")}, request)
+	s.Require().NoError(err)
+	s.Require().True(ok)
+
+	ok, err = checkTokenRequirements(context.Background(), s.checker, &requester.PublicKey, []*protobuf.TokenRequirement{s.requirement("150")}, request)
+	s.Require().NoError(err)
+	s.Require().False(ok)
+
+	// Balances of all the addresses are added up
+	request.AddressProofs = append(request.AddressProofs, s.proof(communityID, &requester.PublicKey, s.holder2))
+	ok, err = checkTokenRequirements(context.Background(), s.checker, &requester.PublicKey, []*protobuf.TokenRequirement{s.requirement("150")}, request)
+	s.Require().NoError(err)
+	s.Require().True(ok)
+
+	// Proving the same address twice doesn't count twice
+	request.AddressProofs = []*protobuf.AddressOwnershipProof{
+		s.proof(communityID, &requester.PublicKey, s.holder2),
+		s.proof(communityID, &requester.PublicKey, s.holder2),
+	}
+	ok, err = checkTokenRequirements(context.Background(), s.checker, &requester.PublicKey, []*protobuf.TokenRequirement{s.requirement("100")}, request)
+	s.Require().NoError(err)
+	s.Require().False(ok)
+
+	// A proof signed for someone else is rejected
+	other, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	request.AddressProofs = []*protobuf.AddressOwnershipProof{s.proof(communityID, &other.PublicKey, s.holder1)}
+	_, err = checkTokenRequirements(context.Background(), s.checker, &requester.PublicKey, []*protobuf.TokenRequirement{s.requirement("100")}, request)
+	s.Require().Equal(ErrInvalidAddressOwnershipProof, err)
+}
+
+// checkedRequestToJoin waits for the token requirements of a request to be checked
+func (s *TokenRequirementsSuite) checkedRequestToJoin(subscription chan *Subscription) *RequestToJoin {
+	for {
+		select {
+		case sub := <-subscription:
+			if sub.RequestToJoin != nil {
+				return sub.RequestToJoin
+			}
+		case <-time.After(5 * time.Second):
+			s.FailNow("token requirements not checked")
+		}
+	}
+}
+
+func (s *TokenRequirementsSuite) TestHandleCommunityRequestToJoin() {
+	community, err := s.manager.CreateCommunity(&requests.CreateCommunity{
+		Name:              "status",
+		Description:       "status community description",
+		Color:             "#ffffff",
+		Membership:        protobuf.CommunityPermissions_ON_REQUEST,
+		TokenRequirements: []*protobuf.TokenRequirement{s.requirement("80")},
+	})
+	s.Require().NoError(err)
+
+	subscription := s.manager.Subscribe()
+
+	accepted, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	// The request is pending until the balances are checked
+	requestToJoin, err := s.manager.HandleCommunityRequestToJoin(&accepted.PublicKey, &protobuf.CommunityRequestToJoin{
+		Clock:         1,
+		CommunityId:   community.ID(),
+		AddressProofs: []*protobuf.AddressOwnershipProof{s.proof(community.ID(), &accepted.PublicKey, s.holder1)},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(RequestToJoinStatePending, requestToJoin.State)
+
+	requestToJoin = s.checkedRequestToJoin(subscription)
+	s.Require().Equal(RequestToJoinStateAccepted, requestToJoin.State)
+
+	community, err = s.manager.GetByID(community.ID())
+	s.Require().NoError(err)
+	s.Require().True(community.HasMember(&accepted.PublicKey))
+
+	declined, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	_, err = s.manager.HandleCommunityRequestToJoin(&declined.PublicKey, &protobuf.CommunityRequestToJoin{
+		Clock:         1,
+		CommunityId:   community.ID(),
+		AddressProofs: []*protobuf.AddressOwnershipProof{s.proof(community.ID(), &declined.PublicKey, s.holder2)},
+	})
+	s.Require().NoError(err)
+
+	requestToJoin = s.checkedRequestToJoin(subscription)
+	s.Require().Equal(RequestToJoinStateDeclined, requestToJoin.State)
+
+	dbRequest, err := s.manager.persistence.GetRequestToJoin(requestToJoin.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(RequestToJoinStateDeclined, dbRequest.State)
+
+	community, err = s.manager.GetByID(community.ID())
+	s.Require().NoError(err)
+	s.Require().False(community.HasMember(&declined.PublicKey))
+
+	// A forged address proof declines the request
+	forged, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	_, err = s.manager.HandleCommunityRequestToJoin(&forged.PublicKey, &protobuf.CommunityRequestToJoin{
+		Clock:         1,
+		CommunityId:   community.ID(),
+		AddressProofs: []*protobuf.AddressOwnershipProof{s.proof(community.ID(), &accepted.PublicKey, s.holder1)},
+	})
+	s.Require().NoError(err)
+
+	requestToJoin = s.checkedRequestToJoin(subscription)
+	s.Require().Equal(RequestToJoinStateDeclined, requestToJoin.State)
+}
+
+func (s *TokenRequirementsSuite) TestValidateTokenRequirement() {
+	s.Require().NoError(validateTokenRequirement(s.requirement("1")))
+
+	requirement := s.requirement("0")
+	s.Require().Equal(ErrInvalidCommunityDescriptionTokenRequirement, validateTokenRequirement(requirement))
+
+	requirement = s.requirement("1")
+	requirement.ContractAddress = "not-an-address"
+	s.Require().Equal(ErrInvalidCommunityDescriptionTokenRequirement, validateTokenRequirement(requirement))
+
+	requirement = s.requirement("1")
+	requirement.ChainId = 0
+	s.Require().Equal(ErrInvalidCommunityDescriptionTokenRequirement, validateTokenRequirement(requirement))
+
+	requirement = s.requirement("1")
+	requirement.Type = protobuf.TokenRequirement_UNKNOWN_TOKEN_TYPE
+	s.Require().Equal(ErrInvalidCommunityDescriptionTokenRequirement, validateTokenRequirement(requirement))
+}
@@ -1,6 +1,10 @@
 package communities
 
 import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/planq-network/status-go/protocol/protobuf"
 )
 
@@ -25,7 +29,7 @@ func validateCommunityChat(desc *protobuf.CommunityDescription, chat *protobuf.C
 		return ErrInvalidCommunityDescriptionChatIdentity
 	}
 
-	for pk := range chat.Members {
+	for pk, member := range chat.Members {
 		if desc.Members == nil {
 			return ErrInvalidCommunityDescriptionMemberInChatButNotInOrg
 		}
@@ -33,6 +37,47 @@ func validateCommunityChat(desc *protobuf.CommunityDescription, chat *protobuf.C
 		if _, ok := desc.Members[pk]; !ok {
 			return ErrInvalidCommunityDescriptionMemberInChatButNotInOrg
 		}
+		if err := validateCommunityMemberRoles(desc, member); err != nil {
+			return err
+		}
+	}
+
+	for roleID := range chat.RoleOverrides {
+		if _, ok := desc.Roles[roleID]; !ok {
+			return ErrInvalidCommunityDescriptionUnknownRole
+		}
+	}
+
+	return nil
+}
+
+func validateCommunityRole(desc *protobuf.CommunityDescription, roleID string, role *protobuf.CommunityRole) error {
+	if role == nil || len(role.RoleId) == 0 || role.RoleId != roleID {
+		return ErrInvalidCommunityDescriptionRoleNoID
+	}
+
+	if len(role.Name) == 0 {
+		return ErrInvalidCommunityDescriptionRoleNoName
+	}
+
+	for id, r := range desc.Roles {
+		if id != roleID && r.Name == role.Name {
+			return ErrInvalidCommunityDescriptionDuplicatedRoleName
+		}
+	}
+
+	return nil
+}
+
+func validateCommunityMemberRoles(desc *protobuf.CommunityDescription, member *protobuf.CommunityMember) error {
+	if member == nil {
+		return nil
+	}
+
+	for _, roleID := range member.RoleIds {
+		if _, ok := desc.Roles[roleID]; !ok {
+			return ErrInvalidCommunityDescriptionUnknownRole
+		}
 	}
 
 	return nil
@@ -50,6 +95,27 @@ func validateCommunityCategory(category *protobuf.CommunityCategory) error {
 	return nil
 }
 
+func validateTokenRequirement(requirement *protobuf.TokenRequirement) error {
+	if requirement == nil || requirement.ChainId == 0 {
+		return ErrInvalidCommunityDescriptionTokenRequirement
+	}
+
+	if !common.IsHexAddress(requirement.ContractAddress) {
+		return ErrInvalidCommunityDescriptionTokenRequirement
+	}
+
+	if requirement.Type != protobuf.TokenRequirement_ERC20 && requirement.Type != protobuf.TokenRequirement_ERC721 {
+		return ErrInvalidCommunityDescriptionTokenRequirement
+	}
+
+	amount, ok := new(big.Int).SetString(requirement.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return ErrInvalidCommunityDescriptionTokenRequirement
+	}
+
+	return nil
+}
+
 func ValidateCommunityDescription(desc *protobuf.CommunityDescription) error {
 	if desc == nil {
 		return ErrInvalidCommunityDescription
@@ -61,6 +127,24 @@ func ValidateCommunityDescription(desc *protobuf.CommunityDescription) error {
 		return ErrInvalidCommunityDescriptionUnknownOrgAccess
 	}
 
+	for _, requirement := range desc.Permissions.TokenRequirements {
+		if err := validateTokenRequirement(requirement); err != nil {
+			return err
+		}
+	}
+
+	for roleID, role := range desc.Roles {
+		if err := validateCommunityRole(desc, roleID, role); err != nil {
+			return err
+		}
+	}
+
+	for _, member := range desc.Members {
+		if err := validateCommunityMemberRoles(desc, member); err != nil {
+			return err
+		}
+	}
+
 	for _, category := range desc.Categories {
 		if err := validateCommunityCategory(category); err != nil {
 			return err
@@ -526,6 +526,222 @@ func (s *MessengerCommunitiesSuite) TestPostToCommunityChat() {
 	s.Require().Equal(chatID, response.Chats()[0].ID)
 }
 
+func (s *MessengerCommunitiesSuite) TestPostToEncryptedCommunityChat() {
+	description := &requests.CreateCommunity{
+		Membership:  protobuf.CommunityPermissions_INVITATION_ONLY,
+		Name:        "status",
+		Color:       "#ffffff",
+		Description: "status community description",
+	}
+
+	response, err := s.bob.CreateCommunity(description)
+	s.Require().NoError(err)
+	s.Require().NotNil(response)
+	s.Require().Len(response.Communities(), 1)
+
+	community := response.Communities()[0]
+
+	// Create an encrypted chat
+	orgChat := &protobuf.CommunityChat{
+		Permissions: &protobuf.CommunityPermissions{
+			Access:  protobuf.CommunityPermissions_NO_MEMBERSHIP,
+			Private: true,
+		},
+		Identity: &protobuf.ChatIdentity{
+			DisplayName: "status-core",
+			Description: "status-core community chat",
+		},
+	}
+
+	response, err = s.bob.CreateCommunityChat(community.ID(), orgChat)
+	s.Require().NoError(err)
+	s.Require().NotNil(response)
+	s.Require().Len(response.Chats(), 1)
+
+	chatID := response.Chats()[0].ID
+	keyID, err := s.bob.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+	s.Require().NoError(err)
+	s.Require().NotZero(keyID)
+
+	response, err = s.bob.InviteUsersToCommunity(
+		&requests.InviteUsersToCommunity{
+			CommunityID: community.ID(),
+			Users:       []types.HexBytes{common.PubkeyToHexBytes(&s.alice.identity.PublicKey)},
+		},
+	)
+	s.Require().NoError(err)
+	s.Require().NotNil(response)
+
+	// Pull message and make sure org and the chat key are received
+	err = tt.RetryWithBackOff(func() error {
+		_, err = s.alice.RetrieveAll()
+		if err != nil {
+			return err
+		}
+		community, err := s.alice.communitiesManager.GetByID(community.ID())
+		if err != nil {
+			return err
+		}
+		if community == nil {
+			return errors.New("community not received")
+		}
+		aliceKeyID, err := s.alice.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+		if err != nil {
+			return err
+		}
+		if aliceKeyID != keyID {
+			return errors.New("chat key not received")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	ctx := context.Background()
+
+	response, err = s.alice.JoinCommunity(ctx, community.ID())
+	s.Require().NoError(err)
+	s.Require().NotNil(response)
+	s.Require().Len(response.Chats(), 1)
+
+	inputMessage := &common.Message{}
+	inputMessage.ChatId = chatID
+	inputMessage.ContentType = protobuf.ChatMessage_TEXT_PLAIN
+	inputMessage.Text = "some text"
+
+	_, err = s.alice.SendChatMessage(ctx, inputMessage)
+	s.Require().NoError(err)
+
+	err = tt.RetryWithBackOff(func() error {
+		response, err = s.bob.RetrieveAll()
+		if err != nil {
+			return err
+		}
+		if len(response.messages) == 0 {
+			return errors.New("message not received")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().Equal("some text", response.Messages()[0].Text)
+
+	// Banning alice rotates the key, which is not sent to her
+	_, err = s.bob.BanUserFromCommunity(
+		&requests.BanUserFromCommunity{
+			CommunityID: community.ID(),
+			User:        common.PubkeyToHexBytes(&s.alice.identity.PublicKey),
+		},
+	)
+	s.Require().NoError(err)
+
+	err = tt.RetryWithBackOff(func() error {
+		newKeyID, err := s.bob.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+		if err != nil {
+			return err
+		}
+		if newKeyID == keyID {
+			return errors.New("chat key not rotated")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	_, err = s.alice.RetrieveAll()
+	s.Require().NoError(err)
+	aliceKeyID, err := s.alice.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+	s.Require().NoError(err)
+	s.Require().Equal(keyID, aliceKeyID)
+}
+
+func (s *MessengerCommunitiesSuite) TestRemoveUserFromEncryptedCommunityChat() {
+	description := &requests.CreateCommunity{
+		Membership:  protobuf.CommunityPermissions_INVITATION_ONLY,
+		Name:        "status",
+		Color:       "#ffffff",
+		Description: "status community description",
+	}
+
+	response, err := s.bob.CreateCommunity(description)
+	s.Require().NoError(err)
+	s.Require().Len(response.Communities(), 1)
+
+	community := response.Communities()[0]
+
+	_, err = s.bob.InviteUsersToCommunity(
+		&requests.InviteUsersToCommunity{
+			CommunityID: community.ID(),
+			Users:       []types.HexBytes{common.PubkeyToHexBytes(&s.alice.identity.PublicKey)},
+		},
+	)
+	s.Require().NoError(err)
+
+	// Create an encrypted chat alice is a member of
+	orgChat := &protobuf.CommunityChat{
+		Permissions: &protobuf.CommunityPermissions{
+			Access:  protobuf.CommunityPermissions_INVITATION_ONLY,
+			Private: true,
+		},
+		Identity: &protobuf.ChatIdentity{
+			DisplayName: "status-core",
+			Description: "status-core community chat",
+		},
+		Members: map[string]*protobuf.CommunityMember{
+			common.PubkeyToHex(&s.alice.identity.PublicKey): {},
+		},
+	}
+
+	response, err = s.bob.CreateCommunityChat(community.ID(), orgChat)
+	s.Require().NoError(err)
+	s.Require().Len(response.Chats(), 1)
+
+	chatID := response.Chats()[0].ID
+	keyID, err := s.bob.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+	s.Require().NoError(err)
+	s.Require().NotZero(keyID)
+
+	// The key signed by the community is accepted
+	err = tt.RetryWithBackOff(func() error {
+		_, err = s.alice.RetrieveAll()
+		if err != nil {
+			return err
+		}
+		aliceKeyID, err := s.alice.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+		if err != nil {
+			return err
+		}
+		if aliceKeyID != keyID {
+			return errors.New("chat key not received")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	// Removing alice from the chat rotates its key, which is not sent to her
+	response, err = s.bob.RemoveUserFromCommunityChat(community.ID(), chatID, common.PubkeyToHex(&s.alice.identity.PublicKey))
+	s.Require().NoError(err)
+	s.Require().Len(response.Communities(), 1)
+	s.Require().True(response.Communities()[0].HasMember(&s.alice.identity.PublicKey))
+	s.Require().False(response.Communities()[0].IsMemberInChat(&s.alice.identity.PublicKey, chatID[len(community.IDString()):]))
+
+	err = tt.RetryWithBackOff(func() error {
+		newKeyID, err := s.bob.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+		if err != nil {
+			return err
+		}
+		if newKeyID == keyID {
+			return errors.New("chat key not rotated")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	_, err = s.alice.RetrieveAll()
+	s.Require().NoError(err)
+	aliceKeyID, err := s.alice.encryptor.GetCurrentKeyForGroup([]byte(chatID))
+	s.Require().NoError(err)
+	s.Require().Equal(keyID, aliceKeyID)
+}
+
 func (s *MessengerCommunitiesSuite) TestImportCommunity() {
 	ctx := context.Background()
 
@@ -1,6 +1,7 @@
 package encryption
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
@@ -204,6 +205,130 @@ func (s *EncryptionServiceTestSuite) TestHashRatchetSend() {
 	s.Equal(payload2, decryptedResponse10.DecryptedMessage)
 }
 
+func (s *EncryptionServiceTestSuite) TestHashRatchetKeyNotFound() {
+	aliceKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	bobKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	groupID := []byte("test_group_id")
+
+	// No key has been generated for the group
+	_, err = s.alice.BuildHashRatchetMessage(groupID, cleartext)
+	s.Require().Equal(ErrHashRatchetKeyNotFound, err)
+
+	_, err = s.alice.BuildHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), 1)
+	s.Require().Equal(ErrHashRatchetKeyNotFound, err)
+
+	keyID, err := s.alice.GenerateHashRatchetKey(groupID)
+	s.Require().NoError(err)
+
+	currentKeyID, err := s.alice.GetCurrentKeyForGroup(groupID)
+	s.Require().NoError(err)
+	s.Require().Equal(keyID, currentKeyID)
+
+	hashRatchetMsg, err := s.alice.BuildHashRatchetMessage(groupID, cleartext)
+	s.Require().NoError(err)
+
+	// Bob hasn't received the key
+	_, err = s.bob.HandleMessage(bobKey, nil, hashRatchetMsg.Message, defaultMessageID)
+	s.Require().Equal(ErrHashRatchetKeyNotFound, err)
+
+	keyExchangeMsg, err := s.alice.BuildHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), keyID)
+	s.Require().NoError(err)
+
+	response, err := s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, defaultMessageID)
+	s.Require().NoError(err)
+	s.Require().True(response.HashRatchetKeyReceived)
+
+	// Receiving the same key twice is not an error
+	response, err = s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, []byte("other"))
+	s.Require().NoError(err)
+	s.Require().True(response.HashRatchetKeyReceived)
+
+	response, err = s.bob.HandleMessage(bobKey, nil, hashRatchetMsg.Message, defaultMessageID)
+	s.Require().NoError(err)
+	s.Require().False(response.HashRatchetKeyReceived)
+	s.Require().Equal(cleartext, response.DecryptedMessage)
+}
+
+func (s *EncryptionServiceTestSuite) TestHashRatchetKeyVerifier() {
+	aliceKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	bobKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	ownerKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	groupID := []byte("test_group_id")
+	errNotOwner := errors.New("not signed by the owner")
+
+	s.bob.SetHashRatchetKeyVerifier(func(id []byte, signer *ecdsa.PublicKey) error {
+		s.Require().Equal(groupID, id)
+		if signer == nil || !bytes.Equal(crypto.FromECDSAPub(signer), crypto.FromECDSAPub(&ownerKey.PublicKey)) {
+			return errNotOwner
+		}
+		return nil
+	})
+
+	keyID, err := s.alice.GenerateHashRatchetKey(groupID)
+	s.Require().NoError(err)
+
+	// Unsigned keys are rejected
+	keyExchangeMsg, err := s.alice.BuildHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), keyID)
+	s.Require().NoError(err)
+	_, err = s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, defaultMessageID)
+	s.Require().Equal(errNotOwner, err)
+
+	// Keys signed by someone else are rejected
+	keyExchangeMsg, err = s.alice.BuildSignedHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), keyID, aliceKey)
+	s.Require().NoError(err)
+	_, err = s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, []byte("2"))
+	s.Require().Equal(errNotOwner, err)
+
+	currentKeyID, err := s.bob.GetCurrentKeyForGroup(groupID)
+	s.Require().NoError(err)
+	s.Require().Zero(currentKeyID)
+
+	keyExchangeMsg, err = s.alice.BuildSignedHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), keyID, ownerKey)
+	s.Require().NoError(err)
+	response, err := s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, []byte("3"))
+	s.Require().NoError(err)
+	s.Require().True(response.HashRatchetKeyReceived)
+
+	currentKeyID, err = s.bob.GetCurrentKeyForGroup(groupID)
+	s.Require().NoError(err)
+	s.Require().Equal(keyID, currentKeyID)
+}
+
+func (s *EncryptionServiceTestSuite) TestHashRatchetKeyConflict() {
+	aliceKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	bobKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	groupID := []byte("test_group_id")
+
+	keyID, err := s.alice.GenerateHashRatchetKey(groupID)
+	s.Require().NoError(err)
+
+	// Bob already has a different key with the same ID
+	s.Require().NoError(s.bob.encryptor.persistence.SaveHashRatchetKey(string(groupID), keyID, []byte("other key")))
+
+	keyExchangeMsg, err := s.alice.BuildHashRatchetKeyExchangeMessage(aliceKey, &bobKey.PublicKey, string(groupID), keyID)
+	s.Require().NoError(err)
+	_, err = s.bob.HandleMessage(bobKey, &aliceKey.PublicKey, keyExchangeMsg.Message, defaultMessageID)
+	s.Require().Equal(ErrHashRatchetKeyConflict, err)
+
+	keyData, err := s.bob.encryptor.persistence.GetHashRatchetKeyByID(groupID, keyID, 0)
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("other key"), keyData.Key)
+}
+
 // Alice sends Bob an encrypted message with DH using an ephemeral key
 // and Bob's identity key.
 // Bob is able to decrypt it.
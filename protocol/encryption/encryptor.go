@@ -27,6 +27,8 @@ var (
 	// non-paired devices, however, in theory it is possible to receive such a message.
 	ErrNotPairedDevice         = errors.New("received a message from not paired device")
 	ErrHashRatchetSeqNoTooHigh = errors.New("Hash ratchet seq no is too high")
+	ErrHashRatchetKeyNotFound  = errors.New("Hash ratchet key not found")
+	ErrHashRatchetKeyConflict  = errors.New("Hash ratchet key conflicts with a stored key")
 )
 
 // If we have no bundles, we use a constant so that the message can reach any device.
@@ -661,6 +663,9 @@ func (s *encryptor) encryptWithHR(groupID []byte, keyID uint32, payload []byte)
 	if err != nil {
 		return nil, err
 	}
+	if hrCache == nil {
+		return nil, ErrHashRatchetKeyNotFound
+	}
 
 	var dbHash []byte
 	if len(hrCache.Hash) == 0 {
@@ -696,6 +701,9 @@ func (s *encryptor) decryptWithHR(groupID []byte, keyID uint32, seqNo uint32, pa
 	if err != nil {
 		return nil, err
 	}
+	if hrCache == nil {
+		return nil, ErrHashRatchetKeyNotFound
+	}
 
 	// Handle mesages with seqNo less than the one in db
 	// 1. Check cache. If present for a particular seqNo, all good
@@ -1,6 +1,7 @@
 package encryption
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"database/sql"
 	"strings"
@@ -836,12 +837,26 @@ func (s *sqlitePersistence) SaveHashRatchetKeyHash(
 	return err
 }
 
-// SaveHashRatchetKey saves a hash ratchet key
+// SaveHashRatchetKey saves a hash ratchet key, saving the same key twice
+// is a no-op while a different key with the same ID is rejected
 func (s *sqlitePersistence) SaveHashRatchetKey(
 	groupID string,
 	keyID uint32,
 	key []byte,
 ) error {
+	var existing []byte
+	err := s.DB.QueryRow(`SELECT key FROM hash_ratchet_encryption WHERE group_id = ? AND key_id = ?`, []byte(groupID), keyID).Scan(&existing)
+	switch err {
+	case nil:
+		if !bytes.Equal(existing, key) {
+			return ErrHashRatchetKeyConflict
+		}
+		return nil
+	case sql.ErrNoRows:
+	default:
+		return err
+	}
+
 	stmt, err := s.DB.Prepare(`INSERT INTO hash_ratchet_encryption(group_id, key_id, key)
            VALUES(?,?,?)`)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -75,9 +76,15 @@ type Protocol struct {
 	publisher     *publisher.Publisher
 	subscriptions *Subscriptions
 
+	hashRatchetKeyVerifier HashRatchetKeyVerifier
+
 	logger *zap.Logger
 }
 
+// HashRatchetKeyVerifier checks that a received hash ratchet key has been
+// signed by the owner of the group, signer is nil if the key isn't signed
+type HashRatchetKeyVerifier func(groupID []byte, signer *ecdsa.PublicKey) error
+
 var (
 	// ErrNoPayload means that there was no payload found in the received protocol message.
 	ErrNoPayload = errors.New("no payload")
@@ -231,12 +238,28 @@ func (p *Protocol) BuildEncryptedMessage(myIdentityKey *ecdsa.PrivateKey, public
 // BuildHashRatchetKeyExchangeMessage builds a 1:1 message
 // containing newly generated hash ratchet key
 func (p *Protocol) BuildHashRatchetKeyExchangeMessage(myIdentityKey *ecdsa.PrivateKey, publicKey *ecdsa.PublicKey, groupID string, keyID uint32) (*ProtocolMessageSpec, error) {
+	return p.BuildSignedHashRatchetKeyExchangeMessage(myIdentityKey, publicKey, groupID, keyID, nil)
+}
+
+// BuildSignedHashRatchetKeyExchangeMessage returns a hash ratchet key exchange
+// message whose key is signed by the owner of the group, if signer is set
+func (p *Protocol) BuildSignedHashRatchetKeyExchangeMessage(myIdentityKey *ecdsa.PrivateKey, publicKey *ecdsa.PublicKey, groupID string, keyID uint32, signer *ecdsa.PrivateKey) (*ProtocolMessageSpec, error) {
 
 	logger := p.logger.With(zap.String("site", "BuildHashRatchetKeyExchangeMessage"))
 	keyData, err := p.encryptor.persistence.GetHashRatchetKeyByID([]byte(groupID), keyID, 0)
 	if err != nil {
 		return nil, err
 	}
+	if keyData == nil {
+		return nil, ErrHashRatchetKeyNotFound
+	}
+	var signature []byte
+	if signer != nil {
+		signature, err = crypto.Sign(hashRatchetKeyDigest(groupID, keyID, keyData.Key), signer)
+		if err != nil {
+			return nil, err
+		}
+	}
 	response, err := p.BuildEncryptedMessage(myIdentityKey, publicKey, keyData.Key)
 	if err != nil {
 		return nil, err
@@ -247,9 +270,10 @@ func (p *Protocol) BuildHashRatchetKeyExchangeMessage(myIdentityKey *ecdsa.Priva
 	// and signifies a message with hash ratchet key payload
 	for _, v := range response.Message.EncryptedMessage {
 		v.HRHeader = &HRHeader{
-			KeyId:   keyID,
-			SeqNo:   0,
-			GroupId: groupID,
+			KeyId:     keyID,
+			SeqNo:     0,
+			GroupId:   groupID,
+			Signature: signature,
 		}
 
 	}
@@ -258,6 +282,31 @@ func (p *Protocol) BuildHashRatchetKeyExchangeMessage(myIdentityKey *ecdsa.Priva
 	return response, err
 }
 
+// SetHashRatchetKeyVerifier sets the check of the received hash ratchet keys,
+// by default keys are accepted from anyone
+func (p *Protocol) SetHashRatchetKeyVerifier(verifier HashRatchetKeyVerifier) {
+	p.hashRatchetKeyVerifier = verifier
+}
+
+// hashRatchetKeyDigest is the hash signed by the owner of a group to vouch for a key
+func hashRatchetKeyDigest(groupID string, keyID uint32, key []byte) []byte {
+	keyIDBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(keyIDBytes, keyID)
+	return crypto.Keccak256([]byte(groupID), keyIDBytes, key)
+}
+
+// GenerateHashRatchetKey generates and stores a new hash ratchet key for the group,
+// which becomes the current key of the group
+func (p *Protocol) GenerateHashRatchetKey(groupID []byte) (uint32, error) {
+	return p.encryptor.GenerateHashRatchetKey(groupID)
+}
+
+// GetCurrentKeyForGroup returns the ID of the current hash ratchet key of the group,
+// or 0 if we don't have any key for the group
+func (p *Protocol) GetCurrentKeyForGroup(groupID []byte) (uint32, error) {
+	return p.encryptor.persistence.GetCurrentKeyForGroup(string(groupID))
+}
+
 // BuildHashRatchetMessage returns a hash ratchet chat message
 func (p *Protocol) BuildHashRatchetMessage(groupID []byte, payload []byte) (*ProtocolMessageSpec, error) {
 
@@ -421,6 +470,8 @@ type DecryptMessageResponse struct {
 	DecryptedMessage []byte
 	Installations    []*multidevice.Installation
 	SharedSecrets    []*sharedsecret.Secret
+	// HashRatchetKeyReceived is set when the decrypted message is a hash ratchet key
+	HashRatchetKeyReceived bool
 }
 
 // HandleMessage unmarshals a message and processes it, decrypting it if it is a 1:1 message.
@@ -481,10 +532,11 @@ func (p *Protocol) HandleMessage(
 			hrHeader := dmProtocol.HRHeader
 			if hrHeader != nil && hrHeader.SeqNo == 0 {
 				// Payload contains hash ratchet key
-				err = p.encryptor.persistence.SaveHashRatchetKey(hrHeader.GroupId, hrHeader.KeyId, message)
+				err = p.handleHashRatchetKey(hrHeader, message)
 				if err != nil {
 					return nil, err
 				}
+				response.HashRatchetKeyReceived = true
 			}
 		}
 
@@ -506,6 +558,26 @@ func (p *Protocol) HandleMessage(
 	return nil, ErrNoPayload
 }
 
+// handleHashRatchetKey saves a received hash ratchet key, once its signer
+// has been verified
+func (p *Protocol) handleHashRatchetKey(hrHeader *HRHeader, key []byte) error {
+	if p.hashRatchetKeyVerifier != nil {
+		var signer *ecdsa.PublicKey
+		if len(hrHeader.Signature) != 0 {
+			var err error
+			signer, err = crypto.SigToPub(hashRatchetKeyDigest(hrHeader.GroupId, hrHeader.KeyId, key), hrHeader.Signature)
+			if err != nil {
+				return err
+			}
+		}
+		if err := p.hashRatchetKeyVerifier([]byte(hrHeader.GroupId), signer); err != nil {
+			return err
+		}
+	}
+
+	return p.encryptor.persistence.SaveHashRatchetKey(hrHeader.GroupId, hrHeader.KeyId, key)
+}
+
 func (p *Protocol) ShouldAdvertiseBundle(publicKey *ecdsa.PublicKey, time int64) (bool, error) {
 	return p.publisher.ShouldAdvertiseBundle(publicKey, time)
 }
@@ -355,7 +355,9 @@ type HRHeader struct {
 	// Community message number for this key_id
 	SeqNo uint32 `protobuf:"varint,2,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
 	// Community ID
-	GroupId              string   `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupId string `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// Signature of the key by the owner of the group, set when the key is exchanged
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -407,6 +409,13 @@ func (m *HRHeader) GetGroupId() string {
 	return ""
 }
 
+func (m *HRHeader) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 // Direct message value
 type EncryptedMessageProtocol struct {
 	X3DHHeader *X3DHHeader `protobuf:"bytes,1,opt,name=X3DH_header,json=X3DHHeader,proto3" json:"X3DH_header,omitempty"`
@@ -568,45 +577,45 @@ func init() {
 }
 
 var fileDescriptor_4e37b52004a72e16 = []byte{
-	// 633 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xdd, 0x4e, 0xdb, 0x4c,
-	0x10, 0x95, 0x6d, 0xf2, 0xc3, 0xe4, 0xf7, 0xdb, 0xaf, 0x20, 0x17, 0x71, 0x11, 0x59, 0xa0, 0xa6,
-	0x55, 0xe5, 0x0a, 0xa8, 0xd4, 0x8a, 0xde, 0x51, 0x90, 0x02, 0x15, 0x15, 0x5a, 0xa4, 0xaa, 0xe2,
-	0xa2, 0x96, 0xc1, 0x03, 0x5d, 0x61, 0xd6, 0xc6, 0xeb, 0xa0, 0xfa, 0x05, 0x7a, 0xd3, 0x37, 0xec,
-	0xd3, 0x54, 0xde, 0xf5, 0xda, 0x4b, 0x08, 0x77, 0x99, 0xe3, 0x99, 0x39, 0x73, 0xce, 0xec, 0x04,
-	0xd6, 0xd3, 0x2c, 0xc9, 0x93, 0xab, 0x24, 0x0e, 0xee, 0x50, 0x88, 0xf0, 0x06, 0x7d, 0x09, 0x10,
-	0x40, 0x7e, 0x95, 0x15, 0x69, 0xce, 0x12, 0xee, 0x15, 0xd0, 0x3f, 0x67, 0x37, 0x1c, 0xa3, 0xb3,
-	0x0c, 0xbf, 0x60, 0x41, 0xb6, 0x60, 0x28, 0x64, 0x1c, 0xa4, 0x19, 0x06, 0xb7, 0x58, 0xb8, 0xd6,
-	0xc4, 0x9a, 0xf6, 0x69, 0x5f, 0x98, 0x59, 0x2e, 0x74, 0x1e, 0x30, 0x13, 0x2c, 0xe1, 0xae, 0x3d,
-	0xb1, 0xa6, 0x03, 0xaa, 0x43, 0xf2, 0x1a, 0xc6, 0x35, 0xab, 0x4e, 0x71, 0x64, 0xca, 0x48, 0xe3,
-	0xdf, 0x14, 0xec, 0xfd, 0xb1, 0xa1, 0x7d, 0x30, 0xe7, 0x51, 0x8c, 0x64, 0x03, 0xba, 0x2c, 0x42,
-	0x9e, 0xb3, 0x5c, 0xf3, 0xd5, 0x31, 0x39, 0x85, 0xd1, 0xe3, 0x89, 0x84, 0x6b, 0x4f, 0x9c, 0x69,
-	0x6f, 0x77, 0xdb, 0x6f, 0x74, 0xf8, 0xaa, 0x91, 0x6f, 0x6a, 0x11, 0x47, 0x3c, 0xcf, 0x0a, 0x3a,
-	0x30, 0x27, 0x17, 0x64, 0x13, 0x56, 0x4b, 0x20, 0xcc, 0xe7, 0x19, 0xba, 0x2b, 0x92, 0xab, 0x01,
-	0xca, 0xaf, 0x39, 0xbb, 0x43, 0x91, 0x87, 0x77, 0xa9, 0xdb, 0x9a, 0x58, 0x53, 0x87, 0x36, 0xc0,
-	0xc6, 0x05, 0x90, 0xa7, 0x04, 0x64, 0x0c, 0x8e, 0xf6, 0x69, 0x95, 0x96, 0x3f, 0x89, 0x0f, 0xad,
-	0x87, 0x30, 0x9e, 0xa3, 0x34, 0xa7, 0xb7, 0xeb, 0x9a, 0x83, 0x9a, 0x0d, 0xa8, 0x4a, 0xdb, 0xb7,
-	0x3f, 0x5a, 0xde, 0x2f, 0x18, 0x29, 0x0d, 0x9f, 0x13, 0x9e, 0x87, 0x8c, 0x63, 0x46, 0xde, 0x40,
-	0xfb, 0x52, 0x42, 0xb2, 0x77, 0x6f, 0x97, 0x3c, 0x15, 0x4c, 0xab, 0x0c, 0xb2, 0x57, 0x6e, 0x9b,
-	0x3d, 0x84, 0x39, 0x06, 0x0b, 0xfb, 0xb3, 0xa5, 0xc6, 0xff, 0xab, 0xaf, 0x26, 0xfd, 0xc9, 0x4a,
-	0xd7, 0x19, 0xaf, 0x78, 0x27, 0xd0, 0x3d, 0xa4, 0x33, 0x0c, 0x23, 0xcc, 0x4c, 0x2d, 0x7d, 0xa5,
-	0xa5, 0x0f, 0x96, 0x5e, 0xb2, 0xc5, 0xc9, 0x10, 0xec, 0x54, 0x2f, 0xd4, 0x4e, 0x65, 0xcc, 0xa2,
-	0xca, 0x46, 0x9b, 0x45, 0xde, 0x26, 0x74, 0x0f, 0x67, 0xcf, 0xf5, 0xf2, 0xde, 0x03, 0x7c, 0xdf,
-	0x7b, 0xfe, 0xfb, 0x62, 0xb7, 0x6a, 0xbe, 0x73, 0xe8, 0xce, 0xf4, 0x7c, 0x6b, 0xd0, 0xbe, 0xc5,
-	0x22, 0x60, 0x91, 0x2c, 0x1b, 0xd0, 0xd6, 0x2d, 0x16, 0xc7, 0x51, 0x09, 0x0b, 0xbc, 0x0f, 0x78,
-	0x52, 0x4d, 0xda, 0x12, 0x78, 0xff, 0x35, 0x21, 0x2f, 0xa1, 0x7b, 0x93, 0x25, 0xf3, 0xb4, 0xcc,
-	0x77, 0xe4, 0x7a, 0x3a, 0x32, 0x3e, 0x8e, 0xbc, 0xdf, 0x36, 0xb8, 0x47, 0xca, 0x4d, 0x8c, 0x4e,
-	0xd5, 0x79, 0x9c, 0x55, 0x0f, 0x94, 0x7c, 0x80, 0x5e, 0x39, 0x67, 0xf0, 0x53, 0x92, 0x56, 0xee,
-	0xaf, 0x9b, 0xee, 0x37, 0x32, 0xa8, 0x29, 0x69, 0x07, 0x56, 0x0f, 0xa9, 0x2e, 0x53, 0xcb, 0x7f,
-	0x61, 0x96, 0x69, 0x9f, 0x69, 0xe3, 0x78, 0x59, 0x52, 0x33, 0xe1, 0x92, 0x92, 0x59, 0x5d, 0x62,
-	0xb0, 0xcc, 0x6a, 0x96, 0xeb, 0xa7, 0x25, 0xb3, 0x9a, 0xa5, 0xf6, 0xcd, 0x85, 0x4e, 0x1a, 0x16,
-	0x71, 0x12, 0x2a, 0x23, 0xfa, 0x54, 0x87, 0xde, 0x5f, 0x1b, 0x46, 0x5a, 0x78, 0xe5, 0x03, 0x79,
-	0x05, 0x23, 0xc6, 0x45, 0x1e, 0xc6, 0x71, 0x58, 0x36, 0x2c, 0xed, 0xb3, 0xa5, 0x7d, 0x43, 0x13,
-	0x3e, 0x8e, 0xc8, 0x5b, 0xe8, 0xa8, 0xf7, 0x27, 0x5c, 0x47, 0xde, 0xe4, 0xb2, 0x27, 0xaa, 0x53,
-	0xc8, 0x0f, 0xf8, 0x0f, 0xb5, 0xe5, 0xfa, 0x2f, 0xc9, 0x45, 0x59, 0xb7, 0x63, 0xd6, 0x2d, 0x8c,
-	0xe3, 0x2f, 0xee, 0x49, 0xdd, 0xf5, 0x18, 0x17, 0x60, 0xb2, 0x0d, 0xc3, 0x74, 0x7e, 0x19, 0xb3,
-	0xab, 0xba, 0xf9, 0xb5, 0xd4, 0x3a, 0x50, 0x68, 0x95, 0xb6, 0xc1, 0x60, 0x6d, 0x69, 0xc7, 0x25,
-	0x87, 0xbc, 0xff, 0xf8, 0x90, 0xb7, 0xcc, 0x29, 0x9f, 0x7b, 0x3d, 0xc6, 0x51, 0x1f, 0x8c, 0x2e,
-	0x06, 0xfe, 0xbb, 0x4f, 0x4d, 0xd1, 0x65, 0x5b, 0xfe, 0x09, 0xee, 0xfd, 0x0b, 0x00, 0x00, 0xff,
-	0xff, 0x70, 0x61, 0x7a, 0xe1, 0x9b, 0x05, 0x00, 0x00,
+	// 636 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x5d, 0x4f, 0xd4, 0x4c,
+	0x14, 0x4e, 0x5b, 0xf6, 0x83, 0xb3, 0x9f, 0xef, 0xbc, 0x42, 0x2a, 0xe1, 0x62, 0xd3, 0x40, 0x5c,
+	0x8d, 0xa9, 0x01, 0x4c, 0x34, 0x78, 0x87, 0x90, 0x2c, 0x18, 0x0c, 0x19, 0x13, 0x63, 0xb8, 0xb0,
+	0x29, 0xf4, 0x80, 0x13, 0xca, 0xb4, 0x74, 0xba, 0xc4, 0xfe, 0x01, 0x6f, 0xfc, 0x87, 0xfe, 0x1a,
+	0xd3, 0x99, 0x4e, 0x3b, 0x2c, 0x8b, 0x77, 0x3d, 0xcf, 0x9c, 0xaf, 0xe7, 0x3c, 0xe7, 0x14, 0xd6,
+	0xd3, 0x2c, 0xc9, 0x93, 0xcb, 0x24, 0x0e, 0x6e, 0x51, 0x88, 0xf0, 0x1a, 0x7d, 0x09, 0x10, 0x40,
+	0x7e, 0x99, 0x15, 0x69, 0xce, 0x12, 0xee, 0x15, 0xd0, 0xff, 0xc2, 0xae, 0x39, 0x46, 0x67, 0x19,
+	0x7e, 0xc2, 0x82, 0x6c, 0xc1, 0x50, 0x48, 0x3b, 0x48, 0x33, 0x0c, 0x6e, 0xb0, 0x70, 0xad, 0x89,
+	0x35, 0xed, 0xd3, 0xbe, 0x30, 0xbd, 0x5c, 0xe8, 0xdc, 0x63, 0x26, 0x58, 0xc2, 0x5d, 0x7b, 0x62,
+	0x4d, 0x07, 0x54, 0x9b, 0xe4, 0x25, 0x8c, 0xeb, 0xaa, 0xda, 0xc5, 0x91, 0x2e, 0x23, 0x8d, 0x7f,
+	0x55, 0xb0, 0xf7, 0xdb, 0x86, 0xf6, 0xc1, 0x9c, 0x47, 0x31, 0x92, 0x0d, 0xe8, 0xb2, 0x08, 0x79,
+	0xce, 0x72, 0x5d, 0xaf, 0xb6, 0xc9, 0x29, 0x8c, 0x1e, 0x76, 0x24, 0x5c, 0x7b, 0xe2, 0x4c, 0x7b,
+	0xbb, 0xdb, 0x7e, 0xc3, 0xc3, 0x57, 0x89, 0x7c, 0x93, 0x8b, 0x38, 0xe2, 0x79, 0x56, 0xd0, 0x81,
+	0xd9, 0xb9, 0x20, 0x9b, 0xb0, 0x5a, 0x02, 0x61, 0x3e, 0xcf, 0xd0, 0x5d, 0x91, 0xb5, 0x1a, 0xa0,
+	0x7c, 0xcd, 0xd9, 0x2d, 0x8a, 0x3c, 0xbc, 0x4d, 0xdd, 0xd6, 0xc4, 0x9a, 0x3a, 0xb4, 0x01, 0x36,
+	0xce, 0x81, 0x3c, 0x2e, 0x40, 0xc6, 0xe0, 0xe8, 0x39, 0xad, 0xd2, 0xf2, 0x93, 0xf8, 0xd0, 0xba,
+	0x0f, 0xe3, 0x39, 0xca, 0xe1, 0xf4, 0x76, 0x5d, 0xb3, 0x51, 0x33, 0x01, 0x55, 0x6e, 0xfb, 0xf6,
+	0x7b, 0xcb, 0xfb, 0x09, 0x23, 0xc5, 0xe1, 0x63, 0xc2, 0xf3, 0x90, 0x71, 0xcc, 0xc8, 0x2b, 0x68,
+	0x5f, 0x48, 0x48, 0xe6, 0xee, 0xed, 0x92, 0xc7, 0x84, 0x69, 0xe5, 0x41, 0xf6, 0x4a, 0xb5, 0xd9,
+	0x7d, 0x98, 0x63, 0xb0, 0xa0, 0x9f, 0x2d, 0x39, 0xfe, 0x5f, 0xbd, 0x9a, 0xe5, 0x4f, 0x56, 0xba,
+	0xce, 0x78, 0xc5, 0x3b, 0x81, 0xee, 0x21, 0x9d, 0x61, 0x18, 0x61, 0x66, 0x72, 0xe9, 0x2b, 0x2e,
+	0x7d, 0xb0, 0xb4, 0xc8, 0x16, 0x27, 0x43, 0xb0, 0x53, 0x2d, 0xa8, 0x9d, 0x4a, 0x9b, 0x45, 0xd5,
+	0x18, 0x6d, 0x16, 0x79, 0x9b, 0xd0, 0x3d, 0x9c, 0x3d, 0x95, 0xcb, 0x7b, 0x0b, 0xf0, 0x6d, 0xef,
+	0xe9, 0xf7, 0xc5, 0x6c, 0x55, 0x7f, 0x77, 0xd0, 0x9d, 0xe9, 0xfe, 0xd6, 0xa0, 0x7d, 0x83, 0x45,
+	0xc0, 0x22, 0x19, 0x36, 0xa0, 0xad, 0x1b, 0x2c, 0x8e, 0xa3, 0x12, 0x16, 0x78, 0x17, 0xf0, 0xa4,
+	0xea, 0xb4, 0x25, 0xf0, 0xee, 0x73, 0x42, 0x9e, 0x43, 0xf7, 0x3a, 0x4b, 0xe6, 0x69, 0xe9, 0xef,
+	0x48, 0x79, 0x3a, 0xd2, 0x3e, 0x8e, 0xfe, 0xbd, 0x06, 0xde, 0x2f, 0x1b, 0xdc, 0x23, 0x35, 0x6b,
+	0x8c, 0x4e, 0xd5, 0xf1, 0x9c, 0x55, 0xeb, 0x4b, 0xde, 0x41, 0xaf, 0x64, 0x11, 0xfc, 0x90, 0x2d,
+	0x55, 0xda, 0xac, 0x9b, 0xda, 0x34, 0x24, 0xa9, 0x49, 0x78, 0x07, 0x56, 0x0f, 0xa9, 0x0e, 0x53,
+	0xab, 0xf1, 0xcc, 0x0c, 0xd3, 0x2a, 0xd0, 0x46, 0x8f, 0x32, 0xa4, 0xae, 0x84, 0x4b, 0x42, 0x66,
+	0x75, 0x88, 0x51, 0x65, 0x56, 0x57, 0xb9, 0x7a, 0x1c, 0x32, 0xab, 0xab, 0xd4, 0x53, 0x75, 0xa1,
+	0x93, 0x86, 0x45, 0x9c, 0x84, 0x6a, 0x4c, 0x7d, 0xaa, 0x4d, 0xef, 0x8f, 0x0d, 0x23, 0x4d, 0xbc,
+	0x9a, 0x03, 0x79, 0x01, 0x23, 0xc6, 0x45, 0x1e, 0xc6, 0x71, 0x58, 0x26, 0x2c, 0x87, 0x6b, 0xcb,
+	0xe1, 0x0e, 0x4d, 0xf8, 0x38, 0x22, 0xaf, 0xa1, 0xa3, 0xb6, 0x53, 0xb8, 0x8e, 0xbc, 0xd8, 0x65,
+	0x0b, 0xac, 0x5d, 0xc8, 0x77, 0xf8, 0x0f, 0xf5, 0xc8, 0xf5, 0x0f, 0xcb, 0x45, 0x19, 0xb7, 0x63,
+	0xc6, 0x2d, 0xb4, 0xe3, 0x2f, 0xea, 0xa4, 0xae, 0x7e, 0x8c, 0x0b, 0x30, 0xd9, 0x86, 0x61, 0x3a,
+	0xbf, 0x88, 0xd9, 0x65, 0x9d, 0xfc, 0x4a, 0x72, 0x1d, 0x28, 0xb4, 0x72, 0xdb, 0x60, 0xb0, 0xb6,
+	0x34, 0xe3, 0x92, 0x33, 0xdf, 0x7f, 0x78, 0xe6, 0x5b, 0x66, 0x97, 0x4f, 0x6d, 0x8f, 0x71, 0xf2,
+	0x07, 0xa3, 0xf3, 0x81, 0xff, 0xe6, 0x43, 0x13, 0x74, 0xd1, 0x96, 0xbf, 0xc8, 0xbd, 0xbf, 0x01,
+	0x00, 0x00, 0xff, 0xff, 0x44, 0x8d, 0xfc, 0x41, 0xb9, 0x05, 0x00, 0x00,
 }
@@ -9,4 +9,6 @@ var (
 	ErrChatNotFound    = errors.New("can't find chat")
 	ErrNotImplemented  = errors.New("not implemented")
 	ErrContactNotFound = errors.New("contact not found")
+	ErrThreadNotFound  = errors.New("thread not found")
+	ErrInvalidThread   = errors.New("invalid thread")
 )
@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// FileChunk represents a part of the content of a file sent in a chat, used for
+// reassembling the file on the receiver side
+type FileChunk struct {
+	protobuf.FileChunk
+
+	// SigPubKey is the ecdsa encoded public key of the chunk author
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (c FileChunk) GetSigPubKey() *ecdsa.PublicKey {
+	return c.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (c FileChunk) GetProtobuf() proto.Message {
+	return &c.FileChunk
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (c *FileChunk) SetMessageType(messageType protobuf.MessageType) {
+	c.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (c FileChunk) WrapGroupMessage() bool {
+	return false
+}
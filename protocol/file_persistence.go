@@ -0,0 +1,129 @@
+package protocol
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/planq-network/status-go/protocol/common"
+)
+
+// SaveFileChunk saves a chunk of a file sent by source, chunks already
+// received from the same source are ignored
+func (db sqlitePersistence) SaveFileChunk(hash string, source string, index uint32, chunksCount uint32, payload []byte, receivedAt uint64) error {
+	_, err := db.db.Exec(`INSERT INTO file_chunks(hash, source, chunk_index, chunks_count, payload, received_at) VALUES (?, ?, ?, ?, ?, ?)`, hash, source, index, chunksCount, payload, receivedAt)
+	return err
+}
+
+// FileChunksCount returns the number of chunks of the file that have been
+// received from source
+func (db sqlitePersistence) FileChunksCount(hash string, source string, chunksCount uint32) (uint32, error) {
+	var count uint32
+	err := db.db.QueryRow(`SELECT COUNT(1) FROM file_chunks WHERE hash = ? AND source = ? AND chunks_count = ?`, hash, source, chunksCount).Scan(&count)
+	return count, err
+}
+
+// FileChunks returns the chunks of the file received from source, ordered by
+// index
+func (db sqlitePersistence) FileChunks(hash string, source string, chunksCount uint32) ([][]byte, error) {
+	rows, err := db.db.Query(`SELECT payload FROM file_chunks WHERE hash = ? AND source = ? AND chunks_count = ? ORDER BY chunk_index ASC`, hash, source, chunksCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chunks [][]byte
+	for rows.Next() {
+		var chunk []byte
+		if err := rows.Scan(&chunk); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// DeleteFileChunks removes the chunks of the file received from source
+func (db sqlitePersistence) DeleteFileChunks(hash string, source string) error {
+	_, err := db.db.Exec(`DELETE FROM file_chunks WHERE hash = ? AND source = ?`, hash, source)
+	return err
+}
+
+// DeleteUnclaimedFileChunks removes the chunks received before the given
+// timestamp that no message has been sent with, it returns the number of
+// chunks removed
+func (db sqlitePersistence) DeleteUnclaimedFileChunks(receivedBefore uint64) (int64, error) {
+	result, err := db.db.Exec(`DELETE FROM file_chunks WHERE received_at < ? AND NOT EXISTS (SELECT 1 FROM user_messages WHERE file_hash = file_chunks.hash)`, receivedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SaveFile saves the content of a file and removes its chunks
+func (db sqlitePersistence) SaveFile(hash string, payload []byte) (err error) {
+	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`INSERT INTO files(hash, payload) VALUES (?, ?)`, hash, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM file_chunks WHERE hash = ?`, hash)
+	return err
+}
+
+// HasFile returns whether the content of the file has been saved
+func (db sqlitePersistence) HasFile(hash string) (bool, error) {
+	var count int
+	err := db.db.QueryRow(`SELECT COUNT(1) FROM files WHERE hash = ?`, hash).Scan(&count)
+	return count > 0, err
+}
+
+// MessagesByFileHash returns the messages the file has been sent with
+func (db sqlitePersistence) MessagesByFileHash(hash string) ([]*common.Message, error) {
+	rows, err := db.db.Query(
+		fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				user_messages m1
+			LEFT JOIN
+				user_messages m2
+			ON
+				m1.response_to = m2.id
+			LEFT JOIN
+				contacts c
+			ON
+				m1.source = c.id
+			WHERE
+				m1.file_hash = ? AND NOT(m1.hide)
+		`, db.tableUserMessagesAllFieldsJoin()),
+		hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*common.Message
+	for rows.Next() {
+		var message common.Message
+		if err := db.tableUserMessagesScanAllFields(rows, &message); err != nil {
+			return nil, err
+		}
+		result = append(result, &message)
+	}
+	return result, nil
+}
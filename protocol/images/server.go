@@ -12,13 +12,16 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"mime"
 	"net"
 	"net/http"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/planq-network/status-go/protocol/identity/identicon"
+	"github.com/planq-network/status-go/protocol/protobuf"
 )
 
 var globalCertificate *tls.Certificate = nil
@@ -155,6 +158,56 @@ func (s *messageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type fileHandler struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// ServeHTTP serves the content of the file sent with a message, once all its
+// chunks have been received and checked against its hash
+func (s *fileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	messageIDs, ok := r.URL.Query()["messageId"]
+	if !ok || len(messageIDs) == 0 {
+		s.logger.Error("no messageID")
+		http.Error(w, "no messageId", http.StatusBadRequest)
+		return
+	}
+	messageID := messageIDs[0]
+
+	var filePayload []byte
+	var payload []byte
+	err := s.db.QueryRow(`SELECT m.file_payload, f.payload FROM user_messages m JOIN files f ON f.hash = m.file_hash WHERE m.id = ?`, messageID).Scan(&filePayload, &payload)
+	if err == sql.ErrNoRows {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to find file", zap.Error(err))
+		http.Error(w, "failed to find file", http.StatusInternalServerError)
+		return
+	}
+
+	file := &protobuf.FileMessage{}
+	err = proto.Unmarshal(filePayload, file)
+	if err != nil {
+		s.logger.Error("failed to unmarshal file", zap.Error(err))
+		http.Error(w, "failed to find file", http.StatusInternalServerError)
+		return
+	}
+
+	// The mime type comes from the sender, don't let the client sniff the
+	// content into a different type
+	w.Header().Set("Content-Type", file.MimeType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	w.Header().Set("Cache-Control", "no-store")
+
+	_, err = w.Write(payload)
+	if err != nil {
+		s.logger.Error("failed to write file", zap.Error(err))
+	}
+}
+
 type Server struct {
 	Port   int
 	run    bool
@@ -210,6 +263,7 @@ func (s *Server) Start() error {
 	handler := http.NewServeMux()
 	handler.Handle("/messages/images", &messageHandler{db: s.db, logger: s.logger})
 	handler.Handle("/messages/identicons", &identiconHandler{logger: s.logger})
+	handler.Handle("/messages/files", &fileHandler{db: s.db, logger: s.logger})
 	s.server = &http.Server{Handler: handler}
 
 	go s.listenAndServe()
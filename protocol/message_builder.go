@@ -21,6 +21,9 @@ func extendMessageFromChat(message *common.Message, chat *Chat, key *ecdsa.Publi
 	message.WhisperTimestamp = timestamp
 	message.Seen = true
 	message.OutgoingStatus = common.OutgoingStatusSending
+	if chat.MessagesTTL != 0 {
+		message.ExpiresAt = timestamp + chat.MessagesTTL*1000
+	}
 
 	identicon, err := identicon.GenerateBase64(message.From)
 	if err != nil {
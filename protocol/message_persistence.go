@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/protocol/common"
 	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
 )
 
 func (db sqlitePersistence) tableUserMessagesAllFields() string {
@@ -54,7 +58,12 @@ func (db sqlitePersistence) tableUserMessagesAllFields() string {
 		response_to,
 		gap_from,
 		gap_to,
-		mentioned`
+		mentioned,
+		thread_id,
+		poll_payload,
+		file_payload,
+		file_hash,
+		expires_at`
 }
 
 func (db sqlitePersistence) tableUserMessagesAllFieldsJoin() string {
@@ -96,6 +105,12 @@ func (db sqlitePersistence) tableUserMessagesAllFieldsJoin() string {
 		m1.gap_from,
 		m1.gap_to,
 		m1.mentioned,
+		m1.thread_id,
+		m1.poll_payload,
+		(SELECT p.clock_value FROM poll_closes p WHERE p.message_id = m1.id AND p.source = m1.source),
+		m1.file_payload,
+		EXISTS(SELECT 1 FROM files f WHERE f.hash = m1.file_hash),
+		m1.expires_at,
 		m2.source,
 		m2.text,
 		m2.parsed_text,
@@ -130,6 +145,9 @@ func (db sqlitePersistence) tableUserMessagesScanAllFields(row scanner, message
 	var gapTo sql.NullInt64
 	var editedAt sql.NullInt64
 	var deleted sql.NullBool
+	var pollPayload []byte
+	var pollClosedAt sql.NullInt64
+	var filePayload []byte
 
 	sticker := &protobuf.StickerMessage{}
 	command := &common.CommandParameters{}
@@ -174,6 +192,12 @@ func (db sqlitePersistence) tableUserMessagesScanAllFields(row scanner, message
 		&gapFrom,
 		&gapTo,
 		&message.Mentioned,
+		&message.ThreadId,
+		&pollPayload,
+		&pollClosedAt,
+		&filePayload,
+		&message.FileAvailable,
+		&message.ExpiresAt,
 		&quotedFrom,
 		&quotedText,
 		&quotedParsedText,
@@ -242,6 +266,21 @@ func (db sqlitePersistence) tableUserMessagesScanAllFields(row scanner, message
 
 	case protobuf.ChatMessage_TRANSACTION_COMMAND:
 		message.CommandParameters = command
+
+	case protobuf.ChatMessage_POLL:
+		poll := &protobuf.PollMessage{}
+		if err := proto.Unmarshal(pollPayload, poll); err != nil {
+			return err
+		}
+		message.Payload = &protobuf.ChatMessage_Poll{Poll: poll}
+		message.PollClosedAt = uint64(pollClosedAt.Int64)
+
+	case protobuf.ChatMessage_FILE:
+		file := &protobuf.FileMessage{}
+		if err := proto.Unmarshal(filePayload, file); err != nil {
+			return err
+		}
+		message.Payload = &protobuf.ChatMessage_File{File: file}
 	}
 
 	return nil
@@ -275,8 +314,26 @@ func (db sqlitePersistence) tableUserMessagesAllValues(message *common.Message)
 		gapTo = message.GapParameters.To
 	}
 
-	var serializedMentions []byte
+	var pollPayload []byte
 	var err error
+	if poll := message.GetPoll(); poll != nil {
+		pollPayload, err = proto.Marshal(poll)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var filePayload []byte
+	var fileHash string
+	if file := message.GetFile(); file != nil {
+		filePayload, err = proto.Marshal(file)
+		if err != nil {
+			return nil, err
+		}
+		fileHash = types.EncodeHex(file.Hash)
+	}
+
+	var serializedMentions []byte
 	if len(message.Mentions) != 0 {
 		serializedMentions, err = json.Marshal(message.Mentions)
 		if err != nil {
@@ -336,6 +393,11 @@ func (db sqlitePersistence) tableUserMessagesAllValues(message *common.Message)
 		gapFrom,
 		gapTo,
 		message.Mentioned,
+		message.ThreadId,
+		pollPayload,
+		filePayload,
+		fileHash,
+		message.ExpiresAt,
 	}, nil
 }
 
@@ -432,7 +494,58 @@ func (db sqlitePersistence) MessageByCommandID(chatID, id string) (*common.Messa
 }
 
 func (db sqlitePersistence) MessageByID(id string) (*common.Message, error) {
-	return db.messageByID(nil, id)
+	message, err := db.messageByID(nil, id)
+	if err != nil {
+		return nil, err
+	}
+	err = db.loadThreadParameters([]*common.Message{message})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// loadThreadParameters sets the replies metadata of the messages that are the
+// root of a thread. Only top level messages can be the root of a thread, so
+// the replies of the other messages are not looked up.
+func (db sqlitePersistence) loadThreadParameters(messages []*common.Message) error {
+	roots := make(map[string]*common.Message)
+	var args []interface{}
+	for _, message := range messages {
+		if len(message.ThreadId) == 0 {
+			roots[message.ID] = message
+			args = append(args, message.ID)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	// The source and timestamp are the ones of the reply with the highest clock value
+	inVector := strings.Repeat("?, ", len(args)-1) + "?"
+	rows, err := db.db.Query(`
+		SELECT thread_id, COUNT(1), SUM(NOT(seen)), source, timestamp, MAX(clock_value)
+		FROM user_messages
+		WHERE thread_id IN (`+inVector+`) AND NOT(hide)
+		GROUP BY thread_id`, args...) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var threadID string
+		var clock uint64
+		thread := &common.ThreadParameters{}
+		err := rows.Scan(&threadID, &thread.ReplyCount, &thread.UnreadCount, &thread.LastReplyFrom, &thread.LastReplyTimestamp, &clock)
+		if err != nil {
+			return err
+		}
+		if root, ok := roots[threadID]; ok {
+			root.ThreadParameters = thread
+		}
+	}
+	return rows.Err()
 }
 
 func (db sqlitePersistence) MessagesExist(ids []string) (map[string]bool, error) {
@@ -510,10 +623,14 @@ func (db sqlitePersistence) MessagesByIDs(ids []string) ([]*common.Message, erro
 		result = append(result, &message)
 	}
 
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
 // MessageByChatID returns all messages for a given chatID in descending order.
+// Replies in threads are not part of the chat timeline, see MessagesByThreadID.
 // Ordering is accomplished using two concatenated values: ClockValue and ID.
 // These two values are also used to compose a cursor which is returned to the result.
 func (db sqlitePersistence) MessageByChatID(chatID string, currCursor string, limit int) ([]*common.Message, string, error) {
@@ -545,9 +662,179 @@ func (db sqlitePersistence) MessageByChatID(chatID string, currCursor string, li
 			      contacts c
 			ON
 
+			m1.source = c.id
+			WHERE
+				NOT(m1.hide) AND m1.local_chat_id = ? AND m1.thread_id = '' %s
+			ORDER BY cursor DESC
+			LIMIT ?
+		`, allFields, cursorWhere),
+		append(args, limit+1)..., // take one more to figure our whether a cursor should be returned
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		result  []*common.Message
+		cursors []string
+	)
+	for rows.Next() {
+		var (
+			message common.Message
+			cursor  string
+		)
+		if err := db.tableUserMessagesScanAllFields(rows, &message, &cursor); err != nil {
+			return nil, "", err
+		}
+		result = append(result, &message)
+		cursors = append(cursors, cursor)
+	}
+
+	var newCursor string
+	if len(result) > limit {
+		newCursor = cursors[limit]
+		result = result[:limit]
+	}
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, "", err
+	}
+	return result, newCursor, nil
+}
+
+// LoadMessageMedia sets the image or audio payload of a message, as they
+// are not loaded with the rest of the message
+func (db sqlitePersistence) LoadMessageMedia(message *common.Message) error {
+	switch message.ContentType {
+	case protobuf.ChatMessage_IMAGE:
+		var payload []byte
+		var imageType sql.NullInt64
+		err := db.db.QueryRow(`SELECT image_payload, image_type FROM user_messages WHERE id = ?`, message.ID).Scan(&payload, &imageType)
+		if err != nil {
+			return err
+		}
+		message.Payload = &protobuf.ChatMessage_Image{Image: &protobuf.ImageMessage{
+			Payload: payload,
+			Type:    protobuf.ImageType(imageType.Int64),
+		}}
+
+	case protobuf.ChatMessage_AUDIO:
+		var payload []byte
+		var audioType, durationMs sql.NullInt64
+		err := db.db.QueryRow(`SELECT audio_payload, audio_type, audio_duration_ms FROM user_messages WHERE id = ?`, message.ID).Scan(&payload, &audioType, &durationMs)
+		if err != nil {
+			return err
+		}
+		message.Payload = &protobuf.ChatMessage_Audio{Audio: &protobuf.AudioMessage{
+			Payload:    payload,
+			Type:       protobuf.AudioMessage_AudioType(audioType.Int64),
+			DurationMs: uint64(durationMs.Int64),
+		}}
+	}
+
+	return nil
+}
+
+// MessagesByChatIDAscending returns all messages for a given chatID,
+// including the replies in threads, in ascending order.
+// Ordering and cursor are the same as in MessageByChatID.
+func (db sqlitePersistence) MessagesByChatIDAscending(chatID string, currCursor string, limit int) ([]*common.Message, string, error) {
+	cursorWhere := ""
+	if currCursor != "" {
+		cursorWhere = "AND cursor >= ?" //nolint: goconst
+	}
+	allFields := db.tableUserMessagesAllFieldsJoin()
+	args := []interface{}{chatID}
+	if currCursor != "" {
+		args = append(args, currCursor)
+	}
+	rows, err := db.db.Query(
+		fmt.Sprintf(`
+			SELECT
+				%s,
+				substr('0000000000000000000000000000000000000000000000000000000000000000' || m1.clock_value, -64, 64) || m1.id as cursor
+			FROM
+				user_messages m1
+			LEFT JOIN
+				user_messages m2
+			ON
+			m1.response_to = m2.id
+
+			LEFT JOIN
+			      contacts c
+			ON
+
 			m1.source = c.id
 			WHERE
 				NOT(m1.hide) AND m1.local_chat_id = ? %s
+			ORDER BY cursor ASC
+			LIMIT ?
+		`, allFields, cursorWhere),
+		append(args, limit+1)..., // take one more to figure our whether a cursor should be returned
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		result  []*common.Message
+		cursors []string
+	)
+	for rows.Next() {
+		var (
+			message common.Message
+			cursor  string
+		)
+		if err := db.tableUserMessagesScanAllFields(rows, &message, &cursor); err != nil {
+			return nil, "", err
+		}
+		result = append(result, &message)
+		cursors = append(cursors, cursor)
+	}
+
+	var newCursor string
+	if len(result) > limit {
+		newCursor = cursors[limit]
+		result = result[:limit]
+	}
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, "", err
+	}
+	return result, newCursor, nil
+}
+
+// MessagesByThreadID returns all the replies in a thread in descending order.
+// Ordering and cursor are the same as in MessageByChatID.
+func (db sqlitePersistence) MessagesByThreadID(threadID string, currCursor string, limit int) ([]*common.Message, string, error) {
+	cursorWhere := ""
+	if currCursor != "" {
+		cursorWhere = "AND cursor <= ?" //nolint: goconst
+	}
+	allFields := db.tableUserMessagesAllFieldsJoin()
+	args := []interface{}{threadID}
+	if currCursor != "" {
+		args = append(args, currCursor)
+	}
+	rows, err := db.db.Query(
+		fmt.Sprintf(`
+			SELECT
+				%s,
+				substr('0000000000000000000000000000000000000000000000000000000000000000' || m1.clock_value, -64, 64) || m1.id as cursor
+			FROM
+				user_messages m1
+			LEFT JOIN
+				user_messages m2
+			ON
+			m1.response_to = m2.id
+
+			LEFT JOIN
+			      contacts c
+			ON
+
+			m1.source = c.id
+			WHERE
+				NOT(m1.hide) AND m1.thread_id = ? %s
 			ORDER BY cursor DESC
 			LIMIT ?
 		`, allFields, cursorWhere),
@@ -582,6 +869,91 @@ func (db sqlitePersistence) MessageByChatID(chatID string, currCursor string, li
 	return result, newCursor, nil
 }
 
+// ActiveThreadsByChatID returns the root messages of the threads in a chat,
+// ordered by the clock value of their last reply in descending order.
+// The cursor is composed of the clock value of the last reply and the ID of the root message.
+func (db sqlitePersistence) ActiveThreadsByChatID(chatID string, currCursor string, limit int) ([]*common.Message, string, error) {
+	cursorWhere := ""
+	if currCursor != "" {
+		cursorWhere = "AND cursor <= ?" //nolint: goconst
+	}
+	allFields := db.tableUserMessagesAllFieldsJoin()
+	args := []interface{}{chatID, chatID}
+	if currCursor != "" {
+		args = append(args, currCursor)
+	}
+	rows, err := db.db.Query(
+		fmt.Sprintf(`
+			SELECT
+				%s,
+				substr('0000000000000000000000000000000000000000000000000000000000000000' || th.last_clock_value, -64, 64) || m1.id as cursor
+			FROM
+				(SELECT thread_id, MAX(clock_value) AS last_clock_value
+				FROM user_messages
+				WHERE local_chat_id = ? AND thread_id != '' AND NOT(hide)
+				GROUP BY thread_id) th
+			JOIN
+				user_messages m1
+			ON
+			m1.id = th.thread_id
+
+			LEFT JOIN
+				user_messages m2
+			ON
+			m1.response_to = m2.id
+
+			LEFT JOIN
+			      contacts c
+			ON
+
+			m1.source = c.id
+			WHERE
+				NOT(m1.hide) AND m1.local_chat_id = ? %s
+			ORDER BY cursor DESC
+			LIMIT ?
+		`, allFields, cursorWhere),
+		append(args, limit+1)..., // take one more to figure our whether a cursor should be returned
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		result  []*common.Message
+		cursors []string
+	)
+	for rows.Next() {
+		var (
+			message common.Message
+			cursor  string
+		)
+		if err := db.tableUserMessagesScanAllFields(rows, &message, &cursor); err != nil {
+			return nil, "", err
+		}
+		result = append(result, &message)
+		cursors = append(cursors, cursor)
+	}
+
+	var newCursor string
+	if len(result) > limit {
+		newCursor = cursors[limit]
+		result = result[:limit]
+	}
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, "", err
+	}
+	return result, newCursor, nil
+}
+
+// HasParticipatedInThread returns whether the author of the root message
+// or of any reply of the thread is the given public key
+func (db sqlitePersistence) HasParticipatedInThread(threadID string, publicKey string) (bool, error) {
+	var participated bool
+	err := db.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM user_messages WHERE (id = ? OR thread_id = ?) AND source = ?)`, threadID, threadID, publicKey).Scan(&participated)
+	return participated, err
+}
+
 // AllMessageByChatIDWhichMatchPattern returns all messages which match the search
 // term, for a given chatID in descending order.
 // Ordering is accomplished using two concatenated values: ClockValue and ID.
@@ -643,6 +1015,9 @@ func (db sqlitePersistence) AllMessageByChatIDWhichMatchTerm(chatID string, sear
 		result = append(result, &message)
 	}
 
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -707,43 +1082,197 @@ func (db sqlitePersistence) AllMessagesFromChatsAndCommunitiesWhichMatchTerm(com
 			substr('0000000000000000000000000000000000000000000000000000000000000000' || m1.clock_value, -64, 64) || m1.id as cursor
 		FROM
 			user_messages m1
-		LEFT JOIN
-			user_messages m2
-		ON
-		m1.response_to = m2.id
-
-		LEFT JOIN
-			contacts c
+		LEFT JOIN
+			user_messages m2
+		ON
+		m1.response_to = m2.id
+
+		LEFT JOIN
+			contacts c
+		ON
+
+		m1.source = c.id
+		WHERE
+			NOT(m1.hide) %s
+		ORDER BY cursor DESC
+	`, allFields, finalCond)
+
+	rows, err := db.db.Query(finalQuery, idsArgs...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		result []*common.Message
+	)
+	for rows.Next() {
+		var (
+			message common.Message
+			cursor  string
+		)
+		if err := db.tableUserMessagesScanAllFields(rows, &message, &cursor); err != nil {
+			return nil, err
+		}
+		result = append(result, &message)
+	}
+
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SearchMessages returns the messages matching the full text search query,
+// ranked by relevance, with a snippet of their text highlighting the matches.
+// Only the most recent maxMessagesSearchCandidates matches are ranked
+func (db sqlitePersistence) SearchMessages(request *requests.SearchMessages) ([]*MessageSearchResult, string, error) {
+	match, err := ftsMatchQuery(request.Query, request.Prefix, request.Phrase)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conditions := []string{"user_messages_fts MATCH ?", "NOT(m1.hide)", "NOT(m1.deleted)"}
+	args := []interface{}{match}
+
+	var chatsConditions []string
+	if len(request.ChatIDs) > 0 {
+		chatsConditions = append(chatsConditions, "m1.local_chat_id IN ("+strings.Repeat("?, ", len(request.ChatIDs)-1)+"?)")
+		for _, chatID := range request.ChatIDs {
+			args = append(args, chatID)
+		}
+	}
+	if len(request.CommunityIDs) > 0 {
+		chatsConditions = append(chatsConditions, "m1.local_chat_id IN (SELECT id FROM chats WHERE community_id IN ("+strings.Repeat("?, ", len(request.CommunityIDs)-1)+"?))")
+		for _, communityID := range request.CommunityIDs {
+			args = append(args, communityID)
+		}
+	}
+	if len(chatsConditions) > 0 {
+		conditions = append(conditions, "("+strings.Join(chatsConditions, " OR ")+")")
+	}
+
+	if len(request.Senders) > 0 {
+		conditions = append(conditions, "m1.source IN ("+strings.Repeat("?, ", len(request.Senders)-1)+"?)")
+		for _, sender := range request.Senders {
+			args = append(args, sender)
+		}
+	}
+
+	if request.Since != 0 {
+		conditions = append(conditions, "m1.timestamp >= ?")
+		args = append(args, request.Since)
+	}
+
+	if request.Until != 0 {
+		conditions = append(conditions, "m1.timestamp <= ?")
+		args = append(args, request.Until)
+	}
+
+	// nolint: gosec
+	rows, err := db.db.Query(`
+		SELECT
+			m1.id,
+			m1.clock_value,
+			matchinfo(user_messages_fts, 'pcnalx')
+		FROM
+			user_messages_fts
+		JOIN
+			user_messages m1
+		ON
+			m1.rowid = user_messages_fts.docid
+		WHERE
+			`+strings.Join(conditions, " AND ")+`
+		ORDER BY
+			m1.clock_value DESC
+		LIMIT ?`, append(args, maxMessagesSearchCandidates)...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var candidates []*messageSearchCandidate
+	for rows.Next() {
+		var candidate messageSearchCandidate
+		var matchinfo []byte
+		if err := rows.Scan(&candidate.ID, &candidate.Clock, &matchinfo); err != nil {
+			return nil, "", err
+		}
+		candidate.Rank = bm25(matchinfo)
+		candidates = append(candidates, &candidate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	page, cursor, err := paginateMessageSearchCandidates(candidates, request.Cursor, request.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(page) == 0 {
+		return nil, "", nil
+	}
+
+	ids := make([]string, 0, len(page))
+	idsArgs := []interface{}{searchSnippetStart, searchSnippetEnd, searchSnippetEllipsis, searchSnippetTokens, match}
+	for _, candidate := range page {
+		ids = append(ids, candidate.ID)
+		idsArgs = append(idsArgs, candidate.ID)
+	}
+
+	// Snippets are only available in a full text query
+	// nolint: gosec
+	snippetRows, err := db.db.Query(`
+		SELECT
+			m1.id,
+			snippet(user_messages_fts, ?, ?, ?, -1, ?)
+		FROM
+			user_messages_fts
+		JOIN
+			user_messages m1
 		ON
-
-		m1.source = c.id
+			m1.rowid = user_messages_fts.docid
 		WHERE
-			NOT(m1.hide) %s
-		ORDER BY cursor DESC
-	`, allFields, finalCond)
+			user_messages_fts MATCH ? AND m1.id IN (`+strings.Repeat("?, ", len(ids)-1)+`?)`, idsArgs...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer snippetRows.Close()
 
-	rows, err := db.db.Query(finalQuery, idsArgs...)
+	snippets := make(map[string]string)
+	for snippetRows.Next() {
+		var id, snippet string
+		if err := snippetRows.Scan(&id, &snippet); err != nil {
+			return nil, "", err
+		}
+		snippets[id] = snippet
+	}
 
+	messages, err := db.MessagesByIDs(ids)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	defer rows.Close()
 
-	var (
-		result []*common.Message
-	)
-	for rows.Next() {
-		var (
-			message common.Message
-			cursor  string
-		)
-		if err := db.tableUserMessagesScanAllFields(rows, &message, &cursor); err != nil {
-			return nil, err
+	messagesByID := make(map[string]*common.Message, len(messages))
+	for _, message := range messages {
+		messagesByID[message.ID] = message
+	}
+
+	results := make([]*MessageSearchResult, 0, len(page))
+	for _, candidate := range page {
+		message, ok := messagesByID[candidate.ID]
+		if !ok {
+			continue
 		}
-		result = append(result, &message)
+		results = append(results, &MessageSearchResult{
+			Message: message,
+			Snippet: highlightSnippet(snippets[candidate.ID]),
+			Rank:    candidate.Rank,
+		})
 	}
 
-	return result, nil
+	return results, cursor, nil
 }
 
 func (db sqlitePersistence) AllChatIDsByCommunity(communityID string) ([]string, error) {
@@ -849,6 +1378,13 @@ func (db sqlitePersistence) PinnedMessageByChatIDs(chatIDs []string, currCursor
 		newCursor = cursors[limit]
 		result = result[:limit]
 	}
+	messages := make([]*common.Message, 0, len(result))
+	for _, pinnedMessage := range result {
+		messages = append(messages, pinnedMessage.Message)
+	}
+	if err := db.loadThreadParameters(messages); err != nil {
+		return nil, "", err
+	}
 	return result, newCursor, nil
 }
 
@@ -893,7 +1429,7 @@ func (db sqlitePersistence) MessageByChatIDs(chatIDs []string, currCursor string
 
 			m1.source = c.id
 			WHERE
-				NOT(m1.hide) AND m1.local_chat_id IN %s %s
+				NOT(m1.hide) AND m1.local_chat_id IN %s AND m1.thread_id = '' %s
 			ORDER BY cursor DESC
 			LIMIT ?
 		`, allFields, "(?"+strings.Repeat(",?", len(chatIDs)-1)+")", cursorWhere),
@@ -925,6 +1461,9 @@ func (db sqlitePersistence) MessageByChatIDs(chatIDs []string, currCursor string
 		newCursor = cursors[limit]
 		result = result[:limit]
 	}
+	if err := db.loadThreadParameters(result); err != nil {
+		return nil, "", err
+	}
 	return result, newCursor, nil
 }
 
@@ -1347,6 +1886,122 @@ func (db sqlitePersistence) deleteMessagesByChatIDAndClockValueLessThanOrEqual(i
 	return
 }
 
+// ExpiredMessages are the messages of a chat deleted because they expired
+type ExpiredMessages struct {
+	ChatID                string
+	MessageIDs            []string
+	UnviewedMessagesCount uint
+	UnviewedMentionsCount uint
+}
+
+// DeleteExpiredMessages deletes the messages that expired before the given
+// timestamp along with their emoji reactions, pins, polls, files and
+// notifications, it returns the deleted messages grouped by chat
+func (db sqlitePersistence) DeleteExpiredMessages(timestamp uint64) (result []*ExpiredMessages, err error) {
+	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query(`SELECT id, local_chat_id, file_hash FROM user_messages WHERE expires_at != 0 AND expires_at <= ?`, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	chats := make(map[string]*ExpiredMessages)
+	var fileHashes []string
+	for rows.Next() {
+		var id, chatID, fileHash string
+		if err = rows.Scan(&id, &chatID, &fileHash); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		expired, ok := chats[chatID]
+		if !ok {
+			expired = &ExpiredMessages{ChatID: chatID}
+			chats[chatID] = expired
+			result = append(result, expired)
+		}
+		expired.MessageIDs = append(expired.MessageIDs, id)
+
+		if fileHash != "" {
+			fileHashes = append(fileHashes, fileHash)
+		}
+	}
+	rows.Close()
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	expiredQuery := `SELECT id FROM user_messages WHERE expires_at != 0 AND expires_at <= ?`
+	for _, table := range []string{"emoji_reactions", "pin_messages", "poll_votes", "poll_closes"} {
+		_, err = tx.Exec(`DELETE FROM `+table+` WHERE message_id IN (`+expiredQuery+`)`, timestamp) // nolint: gosec
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(`DELETE FROM activity_center_notifications WHERE id IN (`+expiredQuery+`)`, timestamp) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_messages WHERE expires_at != 0 AND expires_at <= ?`, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are shared by messages with the same content, only delete the
+	// ones that are not referenced anymore
+	for _, hash := range fileHashes {
+		_, err = tx.Exec(`DELETE FROM files WHERE hash = ? AND NOT EXISTS (SELECT 1 FROM user_messages WHERE file_hash = ?)`, hash, hash)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`DELETE FROM file_chunks WHERE hash = ? AND NOT EXISTS (SELECT 1 FROM user_messages WHERE file_hash = ?)`, hash, hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, expired := range result {
+		_, err = tx.Exec(
+			`UPDATE chats
+			   SET unviewed_message_count =
+			   (SELECT COUNT(1)
+			   FROM user_messages
+			   WHERE local_chat_id = ? AND seen = 0),
+			   unviewed_mentions_count =
+			   (SELECT COUNT(1)
+			   FROM user_messages
+			   WHERE local_chat_id = ? AND seen = 0 AND mentioned)
+			WHERE id = ?`, expired.ChatID, expired.ChatID, expired.ChatID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.QueryRow(`SELECT unviewed_message_count, unviewed_mentions_count FROM chats WHERE id = ?`, expired.ChatID).Scan(&expired.UnviewedMessagesCount, &expired.UnviewedMentionsCount)
+		if err == sql.ErrNoRows {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
 func (db sqlitePersistence) MarkAllRead(chatID string, clock uint64) (int64, int64, error) {
 	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
 	if err != nil {
@@ -1495,15 +2150,150 @@ func (db sqlitePersistence) MarkMessagesSeen(chatID string, ids []string) (uint6
 	return countWithMentions + countNoMentions, countWithMentions, err
 }
 
+// DetachThreadReplies moves the replies in a thread to the top level of their
+// chat, the replies in chatID are kept in the thread unless all is set. It
+// returns the IDs of the replies moved.
+func (db sqlitePersistence) DetachThreadReplies(threadID string, chatID string, all bool) (ids []string, err error) {
+	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query(`SELECT id FROM user_messages WHERE thread_id = ? AND (? OR local_chat_id != ?)`, threadID, all, chatID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	_, err = tx.Exec(`UPDATE user_messages SET thread_id = '' WHERE thread_id = ? AND (? OR local_chat_id != ?)`, threadID, all, chatID)
+	return ids, err
+}
+
+// MarkThreadRead marks all the replies in a thread as seen and updates the
+// unviewed counts of the chat
+func (db sqlitePersistence) MarkThreadRead(chatID string, threadID string) (uint64, uint64, error) {
+	tx, err := db.db.BeginTx(context.Background(), &sql.TxOptions{})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		// don't shadow original error
+		_ = tx.Rollback()
+	}()
+
+	result, err := tx.Exec(`UPDATE user_messages SET seen = 1 WHERE NOT(seen) AND mentioned AND local_chat_id = ? AND thread_id = ?`, chatID, threadID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	countWithMentions, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	result, err = tx.Exec(`UPDATE user_messages SET seen = 1 WHERE NOT(seen) AND NOT(mentioned) AND local_chat_id = ? AND thread_id = ?`, chatID, threadID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	countNoMentions, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Update denormalized count
+	_, err = tx.Exec(
+		`UPDATE chats
+              	SET unviewed_message_count =
+		   (SELECT COUNT(1)
+		   FROM user_messages
+		   WHERE local_chat_id = ? AND seen = 0),
+		   unviewed_mentions_count =
+		   (SELECT COUNT(1)
+		   FROM user_messages
+		   WHERE local_chat_id = ? AND seen = 0 AND mentioned)
+		WHERE id = ?`, chatID, chatID, chatID)
+	return uint64(countWithMentions + countNoMentions), uint64(countWithMentions), err
+}
+
+// UpdateMessageOutgoingStatus updates the outgoing status of a message, read
+// messages are never downgraded
 func (db sqlitePersistence) UpdateMessageOutgoingStatus(id string, newOutgoingStatus string) error {
 	_, err := db.db.Exec(`
 		UPDATE user_messages
 		SET outgoing_status = ?
-		WHERE id = ?
-	`, newOutgoingStatus, id)
+		WHERE id = ? AND COALESCE(outgoing_status, "") != ?
+	`, newOutgoingStatus, id, common.OutgoingStatusRead)
 	return err
 }
 
+// UnseenMessageIDsByAuthor returns the ids of the given messages of a chat
+// that haven't been seen yet, grouped by author
+func (db sqlitePersistence) UnseenMessageIDsByAuthor(chatID string, ids []string) (map[string][]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{chatID}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	inVector := strings.Repeat("?, ", len(ids)-1) + "?"
+
+	return db.unseenMessageIDsByAuthor(`local_chat_id = ? AND NOT(seen) AND id IN (`+inVector+`)`, args...) // nolint: gosec
+}
+
+// UnseenMessageIDsByAuthorUntil returns the ids of the messages of a chat up
+// to the given clock that haven't been seen yet, grouped by author
+func (db sqlitePersistence) UnseenMessageIDsByAuthorUntil(chatID string, clock uint64) (map[string][]string, error) {
+	return db.unseenMessageIDsByAuthor(`local_chat_id = ? AND NOT(seen) AND clock_value <= ?`, chatID, clock)
+}
+
+func (db sqlitePersistence) unseenMessageIDsByAuthor(where string, args ...interface{}) (map[string][]string, error) {
+	rows, err := db.db.Query(`SELECT id, source FROM user_messages WHERE `+where, args...) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]string)
+	for rows.Next() {
+		var id, author string
+		err = rows.Scan(&id, &author)
+		if err != nil {
+			return nil, err
+		}
+		result[author] = append(result[author], id)
+	}
+	return result, rows.Err()
+}
+
 // BlockContact updates a contact, deletes all the messages and 1-to-1 chat, updates the unread messages count and returns a map with the new count
 func (db sqlitePersistence) BlockContact(contact *Contact) ([]*Chat, error) {
 	var chats []*Chat
@@ -1653,6 +2443,131 @@ func (db sqlitePersistence) EmojiReactionByID(id string) (*EmojiReaction, error)
 	}
 }
 
+// SavePollVote saves the vote unless a more recent vote of the same voter
+// has already been saved, so that the latest vote of each voter wins
+func (db sqlitePersistence) SavePollVote(vote *PollVote) error {
+	options, err := json.Marshal(vote.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(`
+		INSERT INTO poll_votes(id,clock_value,source,message_id,chat_id,local_chat_id,options,retracted)
+		SELECT ?,?,?,?,?,?,?,?
+		WHERE NOT EXISTS (SELECT 1 FROM poll_votes WHERE id = ? AND clock_value >= ?)`,
+		vote.ID(),
+		vote.Clock,
+		vote.From,
+		vote.MessageId,
+		vote.ChatId,
+		vote.LocalChatID,
+		options,
+		vote.Retracted,
+		vote.ID(),
+		vote.Clock,
+	)
+	return err
+}
+
+func (db sqlitePersistence) scanPollVotes(rows *sql.Rows) ([]*PollVote, error) {
+	defer rows.Close()
+
+	var result []*PollVote
+	for rows.Next() {
+		var vote PollVote
+		var options []byte
+		err := rows.Scan(&vote.Clock,
+			&vote.From,
+			&vote.MessageId,
+			&vote.ChatId,
+			&vote.LocalChatID,
+			&options,
+			&vote.Retracted)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(options) != 0 {
+			if err := json.Unmarshal(options, &vote.Options); err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, &vote)
+	}
+
+	return result, nil
+}
+
+// PollVotesByMessageID returns the latest vote of each voter of the poll, including retractions
+func (db sqlitePersistence) PollVotesByMessageID(messageID string) ([]*PollVote, error) {
+	rows, err := db.db.Query(`
+		SELECT
+			clock_value,
+			source,
+			message_id,
+			chat_id,
+			local_chat_id,
+			options,
+			retracted
+		FROM
+			poll_votes
+		WHERE
+			message_id = ?`, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.scanPollVotes(rows)
+}
+
+func (db sqlitePersistence) PollVoteByID(id string) (*PollVote, error) {
+	rows, err := db.db.Query(`
+		SELECT
+			clock_value,
+			source,
+			message_id,
+			chat_id,
+			local_chat_id,
+			options,
+			retracted
+		FROM
+			poll_votes
+		WHERE
+			id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := db.scanPollVotes(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) == 0 {
+		return nil, common.ErrRecordNotFound
+	}
+	return votes[0], nil
+}
+
+// SavePollClose records that the poll has been closed by source. Only the
+// earliest close is kept, as votes sent after it are not counted.
+// The source is checked against the author of the poll when it's loaded, so
+// that the close can be saved before the poll is received
+func (db sqlitePersistence) SavePollClose(pollClose *PollClose) error {
+	_, err := db.db.Exec(`
+		INSERT INTO poll_closes(message_id,source,clock_value)
+		SELECT ?,?,?
+		WHERE NOT EXISTS (SELECT 1 FROM poll_closes WHERE message_id = ? AND source = ? AND clock_value <= ?)`,
+		pollClose.MessageId,
+		pollClose.From,
+		pollClose.Clock,
+		pollClose.MessageId,
+		pollClose.From,
+		pollClose.Clock,
+	)
+	return err
+}
+
 func (db sqlitePersistence) SaveInvitation(invitation *GroupChatInvitation) (err error) {
 	query := "INSERT INTO group_chat_invitations(id,source,chat_id,message,state,clock) VALUES (?,?,?,?,?,?)"
 	stmt, err := db.db.Prepare(query)
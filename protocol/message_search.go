@@ -0,0 +1,225 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/planq-network/status-go/protocol/common"
+)
+
+const (
+	// searchSnippetStart and searchSnippetEnd delimit the matching terms in
+	// the snippets returned by SQLite, they are replaced with <b> tags once
+	// the text has been escaped
+	searchSnippetStart    = "\x02"
+	searchSnippetEnd      = "\x03"
+	searchSnippetEllipsis = "…"
+	searchSnippetTokens   = 16
+
+	defaultMessagesSearchLimit = 20
+
+	// maxMessagesSearchCandidates is the number of most recent matches that
+	// are ranked, FTS4 can't rank in the query so every candidate is loaded
+	maxMessagesSearchCandidates = 1000
+
+	// bm25 parameters, the same defaults used by FTS5
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var ErrInvalidSearchCursor = errors.New("invalid search cursor")
+
+// MessageSearchResult is a message matching a search, along with a snippet
+// of its text where the matching terms are highlighted. The snippet is HTML
+// escaped, the only tags it contains are the <b> tags around the matches.
+type MessageSearchResult struct {
+	Message *common.Message `json:"message"`
+	Snippet string          `json:"snippet"`
+	Rank    float64         `json:"rank"`
+}
+
+// highlightSnippet escapes the text of a snippet and wraps the matching terms
+// in <b> tags. Markers found in the text of the message itself can't be told
+// apart from the ones of SQLite, the tags are kept balanced so that they can
+// at most widen a highlight.
+func highlightSnippet(snippet string) string {
+	var b strings.Builder
+	open := false
+	for _, part := range strings.SplitAfter(html.EscapeString(snippet), searchSnippetEnd) {
+		for i, text := range strings.Split(strings.TrimSuffix(part, searchSnippetEnd), searchSnippetStart) {
+			if i > 0 && !open {
+				b.WriteString("<b>")
+				open = true
+			}
+			b.WriteString(text)
+		}
+		if strings.HasSuffix(part, searchSnippetEnd) && open {
+			b.WriteString("</b>")
+			open = false
+		}
+	}
+	if open {
+		b.WriteString("</b>")
+	}
+	return b.String()
+}
+
+type messageSearchCandidate struct {
+	ID    string
+	Clock uint64
+	Rank  float64
+}
+
+// before returns whether the candidate is ranked before the other one,
+// candidates are ordered by rank and then by clock value
+func (c *messageSearchCandidate) before(other *messageSearchCandidate) bool {
+	if c.Rank != other.Rank {
+		return c.Rank > other.Rank
+	}
+	if c.Clock != other.Clock {
+		return c.Clock > other.Clock
+	}
+	return c.ID > other.ID
+}
+
+func (c *messageSearchCandidate) cursor() string {
+	return fmt.Sprintf("%s_%d_%s", strconv.FormatFloat(c.Rank, 'g', -1, 64), c.Clock, c.ID)
+}
+
+func parseMessageSearchCursor(cursor string) (*messageSearchCandidate, error) {
+	parts := strings.SplitN(cursor, "_", 3)
+	if len(parts) != 3 {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	rank, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	clock, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSearchCursor
+	}
+
+	return &messageSearchCandidate{Rank: rank, Clock: clock, ID: parts[2]}, nil
+}
+
+// paginateMessageSearchCandidates sorts the candidates and returns the page
+// following the cursor, along with the cursor of the next page
+func paginateMessageSearchCandidates(candidates []*messageSearchCandidate, cursor string, limit int) ([]*messageSearchCandidate, string, error) {
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].before(candidates[j])
+	})
+
+	start := 0
+	if cursor != "" {
+		last, err := parseMessageSearchCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(len(candidates), func(i int) bool {
+			return last.before(candidates[i])
+		})
+	}
+
+	end := start + limit
+	if end >= len(candidates) {
+		return candidates[start:], "", nil
+	}
+
+	return candidates[start:end], candidates[end-1].cursor(), nil
+}
+
+// ftsMatchQuery builds the full text search query from the terms typed by
+// the user, each term is quoted so that it's not interpreted as an operator
+func ftsMatchQuery(query string, prefix bool, phrase bool) (string, error) {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		term = strings.NewReplacer(`"`, "", "*", "").Replace(term)
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	if len(terms) == 0 {
+		return "", errors.New("empty search query")
+	}
+
+	if prefix {
+		terms[len(terms)-1] += "*"
+	}
+
+	if phrase {
+		return `"` + strings.Join(terms, " ") + `"`, nil
+	}
+
+	for i, term := range terms {
+		terms[i] = `"` + term + `"`
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// bm25 ranks a match from the output of the FTS4 matchinfo function
+// called with the 'pcnalx' format, higher is more relevant.
+// The output is an array of unsigned 32 bit integers in the machine byte
+// order, which is little endian on all the supported platforms
+func bm25(matchinfo []byte) float64 {
+	values := make([]uint32, len(matchinfo)/4)
+	for i := range values {
+		values[i] = binary.LittleEndian.Uint32(matchinfo[i*4:])
+	}
+
+	if len(values) < 3 {
+		return 0
+	}
+
+	phrasesCount := int(values[0])
+	columnsCount := int(values[1])
+	rowsCount := float64(values[2])
+
+	if len(values) < 3+2*columnsCount {
+		return 0
+	}
+
+	averageLengths := values[3 : 3+columnsCount]
+	lengths := values[3+columnsCount : 3+2*columnsCount]
+	hits := values[3+2*columnsCount:]
+
+	if len(hits) < 3*phrasesCount*columnsCount {
+		return 0
+	}
+
+	var score float64
+	for phrase := 0; phrase < phrasesCount; phrase++ {
+		for column := 0; column < columnsCount; column++ {
+			offset := 3 * (phrase*columnsCount + column)
+			frequency := float64(hits[offset])
+			documents := float64(hits[offset+2])
+			if frequency == 0 {
+				continue
+			}
+
+			idf := math.Log((rowsCount - documents + 0.5) / (documents + 0.5))
+			// Very common terms would otherwise lower the rank
+			if idf < 1e-6 {
+				idf = 1e-6
+			}
+
+			lengthRatio := 1.0
+			if averageLengths[column] != 0 {
+				lengthRatio = float64(lengths[column]) / float64(averageLengths[column])
+			}
+
+			score += idf * (frequency * (bm25K1 + 1)) / (frequency + bm25K1*(1-bm25B+bm25B*lengthRatio))
+		}
+	}
+
+	return score
+}
@@ -1,16 +1,20 @@
 package protocol
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/protocol/v1"
 )
 
 const maxChatMessageTextLength = 4096
+const maxPollOptions = 20
+const maxPollOptionLength = 256
 const maxStatusMessageText = 128
 
 // maxWhisperDrift is how many milliseconds we allow the clock value to differ
@@ -270,6 +274,29 @@ func ValidateReceivedChatMessage(message *protobuf.ChatMessage, whisperTimestamp
 		if image.Type == protobuf.ImageType_UNKNOWN_IMAGE_TYPE {
 			return errors.New("image type unknown")
 		}
+
+	case protobuf.ChatMessage_POLL:
+		if err := ValidatePoll(message.GetPoll()); err != nil {
+			return err
+		}
+
+	case protobuf.ChatMessage_FILE:
+		file := message.GetFile()
+		if file == nil {
+			return errors.New("no file content")
+		}
+		if len(file.Name) == 0 {
+			return errors.New("file name can't be empty")
+		}
+		if len(file.Hash) != sha256.Size {
+			return errors.New("invalid file hash")
+		}
+		if file.Size == 0 || file.Size > maxFileSize {
+			return errors.New("invalid file size")
+		}
+		if file.ChunksCount == 0 || file.ChunksCount > maxFileChunks {
+			return errors.New("invalid file chunks count")
+		}
 	}
 
 	if message.ContentType == protobuf.ChatMessage_AUDIO {
@@ -292,6 +319,159 @@ func ValidateReceivedChatMessage(message *protobuf.ChatMessage, whisperTimestamp
 	return nil
 }
 
+func ValidatePoll(poll *protobuf.PollMessage) error {
+	if poll == nil {
+		return errors.New("no poll content")
+	}
+
+	if len(poll.Options) < 2 {
+		return errors.New("poll needs at least two options")
+	}
+
+	if len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("poll can't have more than %d options", maxPollOptions)
+	}
+
+	for _, option := range poll.Options {
+		if len(strings.TrimSpace(option)) == 0 {
+			return errors.New("poll option can't be empty")
+		}
+		if len([]rune(option)) > maxPollOptionLength {
+			return fmt.Errorf("poll option shouldn't be longer than %d", maxPollOptionLength)
+		}
+	}
+
+	return nil
+}
+
+func ValidatePollVote(vote protobuf.PollVote, whisperTimestamp uint64) error {
+	if err := validateClockValue(vote.Clock, whisperTimestamp); err != nil {
+		return err
+	}
+
+	if len(vote.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if len(vote.MessageId) == 0 {
+		return errors.New("message-id can't be empty")
+	}
+
+	if !vote.Retracted && len(vote.Options) == 0 {
+		return errors.New("no option voted")
+	}
+
+	if vote.MessageType == protobuf.MessageType_UNKNOWN_MESSAGE_TYPE {
+		return errors.New("unknown message type")
+	}
+
+	return nil
+}
+
+func ValidatePollClose(pollClose protobuf.PollClose) error {
+	if pollClose.Clock == 0 {
+		return errors.New("clock can't be 0")
+	}
+
+	if len(pollClose.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if len(pollClose.MessageId) == 0 {
+		return errors.New("message-id can't be empty")
+	}
+
+	if pollClose.MessageType == protobuf.MessageType_UNKNOWN_MESSAGE_TYPE {
+		return errors.New("unknown message type")
+	}
+
+	return nil
+}
+
+func ValidateFileChunk(chunk protobuf.FileChunk) error {
+	if len(chunk.Hash) != sha256.Size {
+		return errors.New("invalid file hash")
+	}
+
+	if chunk.ChunksCount == 0 || chunk.ChunksCount > maxFileChunks {
+		return errors.New("invalid file chunks count")
+	}
+
+	if chunk.Index >= chunk.ChunksCount {
+		return errors.New("invalid file chunk index")
+	}
+
+	if len(chunk.Payload) == 0 || len(chunk.Payload) > fileChunkSize {
+		return errors.New("invalid file chunk payload")
+	}
+
+	if len(chunk.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if chunk.MessageType == protobuf.MessageType_UNKNOWN_MESSAGE_TYPE {
+		return errors.New("unknown message type")
+	}
+
+	return nil
+}
+
+func ValidateChatMessagesTTL(ttl protobuf.ChatMessagesTTL, whisperTimestamp uint64) error {
+	if err := validateClockValue(ttl.Clock, whisperTimestamp); err != nil {
+		return err
+	}
+
+	if len(ttl.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if ttl.Ttl > requests.MaxChatMessagesTTL {
+		return errors.New("invalid messages ttl")
+	}
+
+	if ttl.MessageType == protobuf.MessageType_UNKNOWN_MESSAGE_TYPE {
+		return errors.New("unknown message type")
+	}
+
+	return nil
+}
+
+func ValidateReadReceipt(receipt protobuf.ReadReceipt, whisperTimestamp uint64) error {
+	if err := validateClockValue(receipt.Clock, whisperTimestamp); err != nil {
+		return err
+	}
+
+	if len(receipt.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if len(receipt.MessageIds) == 0 {
+		return errors.New("message-ids can't be empty")
+	}
+
+	if receipt.MessageType != protobuf.MessageType_ONE_TO_ONE && receipt.MessageType != protobuf.MessageType_PRIVATE_GROUP {
+		return errors.New("invalid message type")
+	}
+
+	return nil
+}
+
+func ValidateTypingIndicator(indicator protobuf.TypingIndicator, whisperTimestamp uint64) error {
+	if err := validateClockValue(indicator.Clock, whisperTimestamp); err != nil {
+		return err
+	}
+
+	if len(indicator.ChatId) == 0 {
+		return errors.New("chat-id can't be empty")
+	}
+
+	if indicator.MessageType != protobuf.MessageType_ONE_TO_ONE && indicator.MessageType != protobuf.MessageType_PRIVATE_GROUP {
+		return errors.New("invalid message type")
+	}
+
+	return nil
+}
+
 func ValidateReceivedEmojiReaction(emoji *protobuf.EmojiReaction, whisperTimestamp uint64) error {
 	if err := validateClockValue(emoji.Clock, whisperTimestamp); err != nil {
 		return err
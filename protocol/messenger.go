@@ -8,7 +8,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
-	"math"
 	"math/rand"
 	"os"
 	"reflect"
@@ -52,6 +51,7 @@ import (
 	"github.com/planq-network/status-go/protocol/protobuf"
 	"github.com/planq-network/status-go/protocol/pushnotificationclient"
 	"github.com/planq-network/status-go/protocol/pushnotificationserver"
+	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/protocol/sqlite"
 	"github.com/planq-network/status-go/protocol/transport"
 	v1protocol "github.com/planq-network/status-go/protocol/v1"
@@ -73,9 +73,6 @@ const (
 	privateChat chatContext = "private-chat"
 )
 
-const messageResendMinDelay = 30
-const messageResendMaxCount = 3
-
 var communityAdvertiseIntervalSecond int64 = 60 * 60
 
 // messageCacheIntervalMs is how long we should keep processed messages in the cache, in ms
@@ -98,6 +95,7 @@ type Messenger struct {
 	transport                  *transport.Transport
 	encryptor                  *encryption.Protocol
 	sender                     *common.MessageSender
+	outbox                     *common.Outbox
 	ensVerifier                *ens.Verifier
 	anonMetricsClient          *anonmetrics.Client
 	anonMetricsServer          *anonmetrics.Server
@@ -132,6 +130,16 @@ type Messenger struct {
 
 	// TODO(samyoul) Determine if/how the remaining usage of this mutex can be removed
 	mutex sync.Mutex
+
+	// scheduledMessagesMutex makes sure a due scheduled message is sent once
+	scheduledMessagesMutex sync.Mutex
+	// outboxMutex makes sure a due message of the outbox is resent once
+	outboxMutex sync.Mutex
+
+	// typingIndicatorsSent is when we last let the members of a chat know
+	// we are typing, by chat id
+	typingIndicatorsSent  map[string]uint64
+	typingIndicatorsMutex sync.Mutex
 }
 
 type connStatus int
@@ -191,7 +199,13 @@ func (interceptor EnvelopeEventsInterceptor) EnvelopeSent(identifiers [][]byte)
 
 // EnvelopeExpired triggered when envelope is expired but wasn't delivered to any peer.
 func (interceptor EnvelopeEventsInterceptor) EnvelopeExpired(identifiers [][]byte, err error) {
-	//we don't track expired events in Messenger, so just redirect to handler
+	if interceptor.Messenger != nil {
+		var ids []string
+		for _, identifierBytes := range identifiers {
+			ids = append(ids, types.EncodeHex(identifierBytes))
+		}
+		interceptor.Messenger.processExpiredMessages(ids)
+	}
 	interceptor.EnvelopeEventsHandler.EnvelopeExpired(identifiers, err)
 }
 
@@ -379,7 +393,7 @@ func NewMessenger(
 
 	ensVerifier := ens.New(node, logger, transp, database, c.verifyENSURL, c.verifyENSContractAddress)
 
-	communitiesManager, err := communities.NewManager(&identity.PublicKey, database, logger, ensVerifier)
+	communitiesManager, err := communities.NewManager(&identity.PublicKey, database, logger, ensVerifier, c.tokenBalanceChecker)
 	if err != nil {
 		return nil, err
 	}
@@ -400,6 +414,8 @@ func NewMessenger(
 		transport:                  transp,
 		encryptor:                  encryptionProtocol,
 		sender:                     sender,
+		outbox:                     common.NewOutbox(database, transp, outboxMinBackoff, outboxMaxBackoff, outboxMaxAttempts),
+		typingIndicatorsSent:       make(map[string]uint64),
 		anonMetricsClient:          anonMetricsClient,
 		anonMetricsServer:          anonMetricsServer,
 		telemetryClient:            telemetryClient,
@@ -492,71 +508,12 @@ func (m *Messenger) processSentMessages(ids []string) error {
 		}
 	}
 
-	return nil
-}
-
-func shouldResendMessage(message *common.RawMessage, t common.TimeSource) (bool, error) {
-	if !(message.MessageType == protobuf.ApplicationMetadataMessage_EMOJI_REACTION ||
-		message.MessageType == protobuf.ApplicationMetadataMessage_CHAT_MESSAGE) {
-		return false, errors.Errorf("Should resend only specific types of messages, can't resend %v", message.MessageType)
-	}
-
-	if message.Sent {
-		return false, errors.New("Should resend only non-sent messages")
-	}
-
-	if message.SendCount > messageResendMaxCount {
-		return false, nil
-	}
-
-	//exponential backoff depends on how many attempts to send message already made
-	backoff := uint64(math.Pow(2, float64(message.SendCount-1))) * messageResendMinDelay * uint64(time.Second.Milliseconds())
-	backoffElapsed := t.GetCurrentTime() > (message.LastSent + backoff)
-	return backoffElapsed, nil
-}
-
-func (m *Messenger) resendExpiredMessages() error {
-	if m.connectionState.Offline {
-		return errors.New("offline")
-	}
-
-	ids, err := m.persistence.ExpiredMessagesIDs(messageResendMaxCount)
+	entries, err := m.outbox.Acked(ids)
 	if err != nil {
-		return errors.Wrapf(err, "Can't get expired reactions from db")
+		return err
 	}
+	m.outboxStateChanged(entries...)
 
-	for _, id := range ids {
-		rawMessage, err := m.persistence.RawMessageByID(id)
-		if err != nil {
-			return errors.Wrapf(err, "Can't get raw message with id %v", id)
-		}
-
-		chat, ok := m.allChats.Load(rawMessage.LocalChatID)
-		if !ok {
-			return ErrChatNotFound
-		}
-
-		if !(chat.Public() || chat.CommunityChat()) {
-			return errors.New("Only public chats and community chats messages are resent")
-		}
-
-		ok, err = shouldResendMessage(rawMessage, m.getTimesource())
-		if err != nil {
-			return err
-		}
-
-		if ok {
-			err = m.persistence.SaveRawMessage(rawMessage)
-			if err != nil {
-				return errors.Wrapf(err, "Can't save raw message marked as non-expired")
-			}
-
-			err = m.reSendRawMessage(context.Background(), rawMessage.ID)
-			if err != nil {
-				return errors.Wrapf(err, "Can't resend expired message with id %v", rawMessage.ID)
-			}
-		}
-	}
 	return nil
 }
 
@@ -610,6 +567,7 @@ func (m *Messenger) Start() (*MessengerResponse, error) {
 
 	// set shared secret handles
 	m.sender.SetHandleSharedSecrets(m.handleSharedSecrets)
+	m.encryptor.SetHashRatchetKeyVerifier(m.verifyCommunityChatKey)
 
 	subscriptions, err := m.encryptor.Start(m.identity)
 	if err != nil {
@@ -627,7 +585,10 @@ func (m *Messenger) Start() (*MessengerResponse, error) {
 	m.handleConnectionChange(m.online())
 	m.handleENSVerificationSubscription(ensSubscription)
 	m.watchConnectionChange()
-	m.watchExpiredMessages()
+	m.watchOutbox()
+	m.watchDisappearingMessages()
+	m.watchFileChunks()
+	m.watchScheduledMessages()
 	m.watchIdentityImageChanges()
 	m.broadcastLatestUserStatus()
 	m.startBackupLoop()
@@ -685,6 +646,8 @@ func (m *Messenger) cleanTopics() error {
 // handle connection change is called each time we go from offline/online or viceversa
 func (m *Messenger) handleConnectionChange(online bool) {
 	if online {
+		m.retryOutbox()
+
 		if m.pushNotificationClient != nil {
 			m.pushNotificationClient.Online()
 		}
@@ -1118,26 +1081,6 @@ func (m *Messenger) watchConnectionChange() {
 	}()
 }
 
-// watchExpiredMessages regularly checks for expired emojis and invoke their resending
-func (m *Messenger) watchExpiredMessages() {
-	m.logger.Debug("watching expired messages")
-	go func() {
-		for {
-			select {
-			case <-time.After(time.Second):
-				if m.online() {
-					err := m.resendExpiredMessages()
-					if err != nil {
-						m.logger.Debug("Error when resending expired emoji reactions", zap.Error(err))
-					}
-				}
-			case <-m.quit:
-				return
-			}
-		}
-	}()
-}
-
 // watchIdentityImageChanges checks for identity images changes and publishes to the contact code when it happens
 func (m *Messenger) watchIdentityImageChanges() {
 	m.logger.Debug("watching identity image changes")
@@ -1752,6 +1695,7 @@ func (m *Messenger) SendGroupChatInvitationRequest(ctx context.Context, chatID s
 	if err != nil {
 		return nil, err
 	}
+	m.outboxSent(&spec)
 
 	response.Invitations = []*GroupChatInvitation{invitationR}
 
@@ -1820,6 +1764,7 @@ func (m *Messenger) SendGroupChatInvitationRejection(ctx context.Context, invita
 	if err != nil {
 		return nil, err
 	}
+	m.outboxSent(&spec)
 
 	var response MessengerResponse
 
@@ -2083,12 +2028,16 @@ func (m *Messenger) reSendRawMessage(ctx context.Context, messageID string) erro
 	}
 
 	_, err = m.dispatchMessage(ctx, common.RawMessage{
-		LocalChatID:         chat.ID,
-		Payload:             message.Payload,
-		MessageType:         message.MessageType,
-		Recipients:          message.Recipients,
-		ResendAutomatically: message.ResendAutomatically,
-		SendCount:           message.SendCount,
+		LocalChatID:          chat.ID,
+		Payload:              message.Payload,
+		MessageType:          message.MessageType,
+		Recipients:           message.Recipients,
+		ResendAutomatically:  message.ResendAutomatically,
+		SendCount:            message.SendCount,
+		SkipEncryption:       message.SkipEncryption,
+		SendPushNotification: message.SendPushNotification,
+		SkipGroupMessageWrap: message.SkipGroupMessageWrap,
+		SendOnPersonalTopic:  message.SendOnPersonalTopic,
 	})
 	return err
 }
@@ -2142,6 +2091,7 @@ func (m *Messenger) dispatchPairInstallationMessage(ctx context.Context, spec co
 	if err != nil {
 		return nil, err
 	}
+	m.outboxSent(&spec)
 
 	return id, nil
 }
@@ -2173,10 +2123,13 @@ func (m *Messenger) dispatchMessage(ctx context.Context, spec common.RawMessage)
 			}
 		}
 
-		err = m.sendToPairedDevices(ctx, specCopyForPairedDevices)
+		// Ephemeral messages are only meant for the other party
+		if !spec.Ephemeral {
+			err = m.sendToPairedDevices(ctx, specCopyForPairedDevices)
 
-		if err != nil {
-			return spec, err
+			if err != nil {
+				return spec, err
+			}
 		}
 
 	case ChatTypePublic, ChatTypeProfile:
@@ -2199,6 +2152,20 @@ func (m *Messenger) dispatchMessage(ctx context.Context, spec common.RawMessage)
 			return spec, errors.New("can't post on chat")
 		}
 
+		community, err := m.communitiesManager.GetByIDString(chat.CommunityID)
+		if err != nil {
+			return spec, err
+		}
+		if community != nil && community.IsChatEncrypted(chat.CommunityChatID()) {
+			if community.IsAdmin() {
+				err = m.ensureCommunityChatKey(community, chat.CommunityChatID())
+				if err != nil {
+					return spec, err
+				}
+			}
+			spec.HashRatchetGroupID = communityChatGroupID(community, chat.CommunityChatID())
+		}
+
 		logger.Debug("sending community chat message", zap.String("chatName", chat.Name))
 		id, err = m.sender.SendPublic(ctx, chat.ID, spec)
 		if err != nil {
@@ -2226,7 +2193,7 @@ func (m *Messenger) dispatchMessage(ctx context.Context, spec common.RawMessage)
 
 		hasPairedDevices := m.hasPairedDevices()
 
-		if !hasPairedDevices {
+		if !hasPairedDevices || spec.Ephemeral {
 
 			// Filter out my key from the recipients
 			n := 0
@@ -2260,10 +2227,17 @@ func (m *Messenger) dispatchMessage(ctx context.Context, spec common.RawMessage)
 	spec.ID = types.EncodeHex(id)
 	spec.SendCount++
 	spec.LastSent = m.getTimesource().GetCurrentTime()
+
+	// Ephemeral messages are never resent
+	if spec.Ephemeral {
+		return spec, nil
+	}
+
 	err = m.persistence.SaveRawMessage(&spec)
 	if err != nil {
 		return spec, err
 	}
+	m.outboxSent(&spec)
 
 	return spec, nil
 }
@@ -2293,6 +2267,7 @@ func (m *Messenger) SendChatMessages(ctx context.Context, messages []*common.Mes
 
 // SendChatMessage takes a minimal message and sends it based on the corresponding chat
 func (m *Messenger) sendChatMessage(ctx context.Context, message *common.Message) (*MessengerResponse, error) {
+	var filePayload []byte
 	if len(message.ImagePath) != 0 {
 		file, err := os.Open(message.ImagePath)
 		if err != nil {
@@ -2352,6 +2327,17 @@ func (m *Messenger) sendChatMessage(ctx context.Context, message *common.Message
 		if err != nil {
 			return nil, err
 		}
+	} else if len(message.FilePath) != 0 {
+		payload, err := m.prepareFileMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		filePayload = payload
+	} else if message.ContentType == protobuf.ChatMessage_POLL {
+		err := ValidatePoll(message.GetPoll())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var response MessengerResponse
@@ -2372,6 +2358,11 @@ func (m *Messenger) sendChatMessage(ctx context.Context, message *common.Message
 		return nil, err
 	}
 
+	err = m.validateThread(message, chat)
+	if err != nil {
+		return nil, err
+	}
+
 	encodedMessage, err := m.encodeChatEntity(chat, message)
 	if err != nil {
 		return nil, err
@@ -2393,6 +2384,14 @@ func (m *Messenger) sendChatMessage(ctx context.Context, message *common.Message
 		message.OutgoingStatus = common.OutgoingStatusSent
 	}
 	message.ID = rawMessage.ID
+
+	if filePayload != nil {
+		err = m.sendFileChunks(ctx, chat, message.GetFile(), filePayload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = message.PrepareContent(common.PubkeyToHex(&m.identity.PublicKey))
 	if err != nil {
 		return nil, err
@@ -2853,6 +2852,13 @@ func (m *Messenger) markDeliveredMessages(acks [][]byte) {
 			m.logger.Debug("Can't set message status as delivered", zap.Error(err))
 		}
 
+		entry, err := m.outbox.Delivered(messageID)
+		if err != nil {
+			m.logger.Debug("Can't set outbox entry as delivered", zap.Error(err))
+		} else if entry != nil {
+			m.outboxStateChanged(entry)
+		}
+
 		//send signal to client that message status updated
 		if m.config.messengerSignalsHandler != nil {
 			message, err := m.persistence.MessageByID(messageID)
@@ -2912,6 +2918,15 @@ func (r *ReceivedMessageState) addNewActivityCenterNotification(publicKey ecdsa.
 	}
 
 	isNotification, notificationType := showMentionOrReplyActivityCenterNotification(publicKey, message, chat, responseTo)
+	if !isNotification && len(message.ThreadId) != 0 && chat.Active && message.From != common.PubkeyToHex(&publicKey) {
+		participated, err := m.persistence.HasParticipatedInThread(message.ThreadId, common.PubkeyToHex(&publicKey))
+		if err != nil {
+			return err
+		}
+		if participated {
+			isNotification, notificationType = true, ActivityCenterNotificationTypeThreadReply
+		}
+	}
 	if isNotification {
 		notification := &ActivityCenterNotification{
 			ID:           types.FromHex(message.ID),
@@ -3162,6 +3177,21 @@ func (m *Messenger) handleRetrievedMessages(chatWithMessages map[transport.Filte
 							continue
 						}
 
+					case protobuf.SyncChatDraft:
+						if !common.IsPubKeyEqual(messageState.CurrentMessageState.PublicKey, &m.identity.PublicKey) {
+							logger.Warn("not coming from us, ignoring")
+							continue
+						}
+
+						p := msg.ParsedMessage.Interface().(protobuf.SyncChatDraft)
+						logger.Debug("Handling SyncChatDraft", zap.Any("message", p))
+						err = m.HandleSyncChatDraft(messageState, p)
+						if err != nil {
+							logger.Warn("failed to handle SyncChatDraft", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+
 					case protobuf.Backup:
 						if !common.IsPubKeyEqual(messageState.CurrentMessageState.PublicKey, &m.identity.PublicKey) {
 							logger.Warn("not coming from us, ignoring")
@@ -3461,6 +3491,54 @@ func (m *Messenger) handleRetrievedMessages(chatWithMessages map[transport.Filte
 							allMessagesProcessed = false
 							continue
 						}
+					case protobuf.PollVote:
+						logger.Debug("Handling PollVote")
+						err = m.HandlePollVote(messageState, msg.ParsedMessage.Interface().(protobuf.PollVote))
+						if err != nil {
+							logger.Warn("failed to handle PollVote", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+					case protobuf.FileChunk:
+						logger.Debug("Handling FileChunk")
+						err = m.HandleFileChunk(messageState, msg.ParsedMessage.Interface().(protobuf.FileChunk))
+						if err != nil {
+							logger.Warn("failed to handle FileChunk", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+					case protobuf.PollClose:
+						logger.Debug("Handling PollClose")
+						err = m.HandlePollClose(messageState, msg.ParsedMessage.Interface().(protobuf.PollClose))
+						if err != nil {
+							logger.Warn("failed to handle PollClose", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+					case protobuf.ChatMessagesTTL:
+						logger.Debug("Handling ChatMessagesTTL")
+						err = m.HandleChatMessagesTTL(messageState, msg.ParsedMessage.Interface().(protobuf.ChatMessagesTTL))
+						if err != nil {
+							logger.Warn("failed to handle ChatMessagesTTL", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+					case protobuf.ReadReceipt:
+						logger.Debug("Handling ReadReceipt")
+						err = m.HandleReadReceipt(messageState, msg.ParsedMessage.Interface().(protobuf.ReadReceipt))
+						if err != nil {
+							logger.Warn("failed to handle ReadReceipt", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
+					case protobuf.TypingIndicator:
+						logger.Debug("Handling TypingIndicator")
+						err = m.HandleTypingIndicator(messageState, msg.ParsedMessage.Interface().(protobuf.TypingIndicator))
+						if err != nil {
+							logger.Warn("failed to handle TypingIndicator", zap.Error(err))
+							allMessagesProcessed = false
+							continue
+						}
 					case protobuf.GroupChatInvitation:
 						logger.Debug("Handling GroupChatInvitation")
 						err = m.HandleGroupChatInvitation(messageState, msg.ParsedMessage.Interface().(protobuf.GroupChatInvitation))
@@ -3511,6 +3589,15 @@ func (m *Messenger) handleRetrievedMessages(chatWithMessages map[transport.Filte
 							continue
 						}
 
+					case protobuf.CommunityMemberAction:
+						logger.Debug("Handling CommunityMemberAction")
+						action := msg.ParsedMessage.Interface().(protobuf.CommunityMemberAction)
+						err = m.HandleCommunityMemberAction(messageState, publicKey, action)
+						if err != nil {
+							logger.Warn("failed to handle CommunityMemberAction", zap.Error(err))
+							continue
+						}
+
 					case protobuf.AnonymousMetricBatch:
 						logger.Debug("Handling AnonymousMetricBatch")
 						if m.anonMetricsServer == nil {
@@ -3835,6 +3922,29 @@ func (m *Messenger) AllMessagesFromChatsAndCommunitiesWhichMatchTerm(communityId
 	return m.persistence.AllMessagesFromChatsAndCommunitiesWhichMatchTerm(communityIds, chatIds, searchTerm, caseSensitive)
 }
 
+// SearchMessages returns the messages matching the query ranked by relevance,
+// using the full text search index
+func (m *Messenger) SearchMessages(request *requests.SearchMessages) ([]*MessageSearchResult, string, error) {
+	if err := request.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	if request.Limit <= 0 {
+		request.Limit = defaultMessagesSearchLimit
+	}
+
+	results, cursor, err := m.persistence.SearchMessages(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, result := range results {
+		result.Message.PrepareImageURL(m.imageServer.Port)
+	}
+
+	return results, cursor, nil
+}
+
 func (m *Messenger) SaveMessages(messages []*common.Message) error {
 	return m.persistence.SaveMessages(messages)
 }
@@ -3851,6 +3961,15 @@ func (m *Messenger) DeleteMessagesByChatID(id string) error {
 // It returns the number of affected messages or error. If there is an error,
 // the number of affected messages is always zero.
 func (m *Messenger) MarkMessagesSeen(chatID string, ids []string) (uint64, uint64, error) {
+	var unseen map[string][]string
+	if chat, ok := m.allChats.Load(chatID); ok && m.readReceiptsEnabled(chat) {
+		var err error
+		unseen, err = m.persistence.UnseenMessageIDsByAuthor(chatID, ids)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
 	count, countWithMentions, err := m.persistence.MarkMessagesSeen(chatID, ids)
 	if err != nil {
 		return 0, 0, err
@@ -3860,6 +3979,9 @@ func (m *Messenger) MarkMessagesSeen(chatID string, ids []string) (uint64, uint6
 		return 0, 0, err
 	}
 	m.allChats.Store(chatID, chat)
+
+	m.sendReadReceipts(context.Background(), chat, unseen)
+
 	return count, countWithMentions, nil
 }
 
@@ -3934,7 +4056,23 @@ func (m *Messenger) MarkAllRead(chatID string) error {
 	}
 	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
 
-	return m.markAllRead(chatID, clock, true)
+	var unseen map[string][]string
+	if m.readReceiptsEnabled(chat) {
+		var err error
+		unseen, err = m.persistence.UnseenMessageIDsByAuthorUntil(chatID, clock)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := m.markAllRead(chatID, clock, true)
+	if err != nil {
+		return err
+	}
+
+	m.sendReadReceipts(context.Background(), chat, unseen)
+
+	return nil
 }
 
 func (m *Messenger) MarkAllReadInCommunity(communityID string) ([]string, error) {
@@ -4770,6 +4908,10 @@ func (m *Messenger) pullMessagesAndResponsesFromDB(messages []*common.Message) (
 		if len(message.ResponseTo) != 0 {
 			messageIDs = append(messageIDs, message.ResponseTo)
 		}
+		// The root of the thread has its replies metadata updated
+		if len(message.ThreadId) != 0 {
+			messageIDs = append(messageIDs, message.ThreadId)
+		}
 
 	}
 	// We pull from the database all the messages & replies involved,
@@ -0,0 +1,470 @@
+package protocol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	v1protocol "github.com/planq-network/status-go/protocol/v1"
+)
+
+// ArchiveVersion is the version of the content of the archives
+const ArchiveVersion = 1
+
+// An archive file starts with archiveMagic, followed by the salt used to
+// derive the key from the password, followed by the gzipped protobuf
+// encoded archive encrypted with AES-GCM
+var archiveMagic = []byte("STATUSARCHIVE1")
+
+const (
+	archiveSaltLength = 32
+	archiveKeyLength  = 32
+
+	// scrypt parameters used to derive the key from the password
+	archiveScryptN = 1 << 15
+	archiveScryptR = 8
+	archiveScryptP = 1
+)
+
+var ErrInvalidArchive = errors.New("invalid archive")
+var ErrInvalidArchivePassword = errors.New("invalid archive password")
+var ErrArchiveWrongAccount = errors.New("archive has been exported by another account")
+
+// ExportArchive writes the contacts, chats, messages, communities and
+// settings of the account to a password encrypted archive file
+func (m *Messenger) ExportArchive(ctx context.Context, request *requests.ExportArchive) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	archive, err := m.buildArchive(ctx, request.From, request.To)
+	if err != nil {
+		return err
+	}
+
+	encodedArchive, err := proto.Marshal(archive)
+	if err != nil {
+		return err
+	}
+
+	archiveFile, err := encryptArchive(encodedArchive, request.Password)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(request.Path, archiveFile, 0600)
+}
+
+// ImportArchive merges the content of an archive file into the account,
+// the most recent data wins according to their clock values
+func (m *Messenger) ImportArchive(ctx context.Context, request *requests.ImportArchive) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	archiveFile, err := ioutil.ReadFile(request.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedArchive, err := decryptArchive(archiveFile, request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var archive protobuf.Archive
+	err = proto.Unmarshal(encodedArchive, &archive)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+
+	return m.importArchive(&archive)
+}
+
+func (m *Messenger) buildArchive(ctx context.Context, from, to uint64) (*protobuf.Archive, error) {
+	clock := m.getTimesource().GetCurrentTime()
+	archive := &protobuf.Archive{
+		Version:   ArchiveVersion,
+		Clock:     clock,
+		PublicKey: contactIDFromPublicKey(&m.identity.PublicKey),
+	}
+
+	m.allContacts.Range(func(contactID string, contact *Contact) (shouldContinue bool) {
+		syncContact := m.syncBackupContact(ctx, contact)
+		if syncContact != nil {
+			archive.Contacts = append(archive.Contacts, syncContact)
+		}
+		return true
+	})
+
+	m.allChats.Range(func(chatID string, chat *Chat) (shouldContinue bool) {
+		if chat.Timeline() || chat.ProfileUpdates() {
+			return true
+		}
+
+		archivedChat := &protobuf.ArchiveChat{
+			Id:                       chat.ID,
+			Name:                     chat.Name,
+			ChatType:                 uint32(chat.ChatType),
+			Color:                    chat.Color,
+			Active:                   chat.Active,
+			Muted:                    chat.Muted,
+			CommunityId:              chat.CommunityID,
+			Joined:                   chat.Joined,
+			LastClockValue:           chat.LastClockValue,
+			DeletedAtClockValue:      chat.DeletedAtClockValue,
+			ReadMessagesAtClockValue: chat.ReadMessagesAtClockValue,
+			MessagesTtl:              chat.MessagesTTL,
+			MessagesTtlClockValue:    chat.MessagesTTLClockValue,
+		}
+		for _, event := range chat.MembershipUpdates {
+			archivedChat.MembershipUpdateEvents = append(archivedChat.MembershipUpdateEvents, append(event.Signature, event.RawPayload...))
+		}
+
+		archive.Chats = append(archive.Chats, archivedChat)
+		return true
+	})
+
+	messages, err := m.persistence.ArchiveMessages(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		err := m.persistence.LoadMessageMedia(message)
+		if err != nil {
+			return nil, err
+		}
+
+		chatMessage := message.ChatMessage
+		archive.Messages = append(archive.Messages, &protobuf.ArchiveMessage{
+			Id:               message.ID,
+			LocalChatId:      message.LocalChatID,
+			From:             message.From,
+			WhisperTimestamp: message.WhisperTimestamp,
+			Seen:             message.Seen,
+			OutgoingStatus:   message.OutgoingStatus,
+			EditedAt:         message.EditedAt,
+			Deleted:          message.Deleted,
+			Message:          &chatMessage,
+		})
+	}
+
+	archive.Communities, err = m.backupCommunities(clock)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := m.settings.GetBackupSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range settings {
+		archive.Settings = append(archive.Settings, &protobuf.BackupSetting{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	return archive, nil
+}
+
+func (m *Messenger) importArchive(archive *protobuf.Archive) (*MessengerResponse, error) {
+	// The content of the archive is trusted as if it was synced from one of
+	// our devices, so it can't come from another account
+	if archive.PublicKey != contactIDFromPublicKey(&m.identity.PublicKey) {
+		return nil, ErrArchiveWrongAccount
+	}
+
+	response := &MessengerResponse{}
+	state := &ReceivedMessageState{
+		AllChats:              m.allChats,
+		AllContacts:           m.allContacts,
+		ModifiedContacts:      new(stringBoolMap),
+		AllInstallations:      m.allInstallations,
+		ModifiedInstallations: m.modifiedInstallations,
+		ExistingMessagesMap:   make(map[string]bool),
+		EmojiReactions:        make(map[string]*EmojiReaction),
+		GroupChatInvitations:  make(map[string]*GroupChatInvitation),
+		Response:              response,
+		Timesource:            m.getTimesource(),
+	}
+
+	for _, contact := range archive.Contacts {
+		err := m.HandleSyncInstallationContact(state, *contact)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var contactsToSave []*Contact
+	state.ModifiedContacts.Range(func(id string, value bool) (shouldContinue bool) {
+		contact, ok := state.AllContacts.Load(id)
+		if ok {
+			contactsToSave = append(contactsToSave, contact)
+			response.Contacts = append(response.Contacts, contact)
+		}
+		return true
+	})
+
+	if len(contactsToSave) > 0 {
+		err := m.persistence.SaveContacts(contactsToSave)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Communities are imported before the chats, as their chats are
+	// created when joining them
+	for _, community := range archive.Communities {
+		err := m.handleSyncCommunity(state, *community)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, chat := range archive.Chats {
+		err := m.importArchiveChat(chat, response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := m.importArchiveMessages(archive.Messages, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archive.Settings) != 0 {
+		err := m.handleBackupSettings(protobuf.Backup{Clock: archive.Clock, Settings: archive.Settings})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (m *Messenger) importArchiveChat(archivedChat *protobuf.ArchiveChat, response *MessengerResponse) error {
+	chatType := ChatType(archivedChat.ChatType)
+	chat, ok := m.allChats.Load(archivedChat.Id)
+	if !ok {
+		switch chatType {
+		case ChatTypePublic:
+			// There's nothing to restore for the public chats we left
+			if !archivedChat.Active {
+				return nil
+			}
+			_, err := m.createPublicChat(archivedChat.Id, response)
+			if err != nil {
+				return err
+			}
+			chat, _ = m.allChats.Load(archivedChat.Id)
+
+		case ChatTypeOneToOne:
+			publicKey, err := common.HexToPubkey(archivedChat.Id)
+			if err != nil {
+				return err
+			}
+			chat = CreateOneToOneChat(archivedChat.Name, publicKey, m.getTimesource())
+
+		case ChatTypePrivateGroupChat:
+			newChat := CreateGroupChat(m.getTimesource())
+			newChat.ID = archivedChat.Id
+			chat = &newChat
+
+		default:
+			// Community chats are created when joining the community
+			return nil
+		}
+
+		chat.Active = archivedChat.Active
+		chat.Muted = archivedChat.Muted
+		chat.Joined = archivedChat.Joined
+		if archivedChat.Color != "" {
+			chat.Color = archivedChat.Color
+		}
+	}
+
+	if chatType == ChatTypePrivateGroupChat && len(archivedChat.MembershipUpdateEvents) != 0 {
+		var events []v1protocol.MembershipUpdateEvent
+		for _, rawEvent := range archivedChat.MembershipUpdateEvents {
+			event, err := v1protocol.MembershipUpdateEventFromProtobuf(archivedChat.Id, rawEvent)
+			if err != nil {
+				return err
+			}
+			events = append(events, *event)
+		}
+
+		chat.MembershipUpdates = v1protocol.MergeMembershipUpdateEvents(chat.MembershipUpdates, events)
+		group, err := v1protocol.NewGroupWithEvents(chat.ID, chat.MembershipUpdates)
+		if err != nil {
+			return err
+		}
+		chat.updateChatFromGroupMembershipChanges(group)
+	}
+
+	if !ok && chat.Active && !chat.Public() {
+		_, err := m.Join(chat)
+		if err != nil {
+			return err
+		}
+	}
+
+	if archivedChat.LastClockValue > chat.LastClockValue {
+		chat.LastClockValue = archivedChat.LastClockValue
+	}
+	if archivedChat.DeletedAtClockValue > chat.DeletedAtClockValue {
+		chat.DeletedAtClockValue = archivedChat.DeletedAtClockValue
+	}
+	if archivedChat.ReadMessagesAtClockValue > chat.ReadMessagesAtClockValue {
+		chat.ReadMessagesAtClockValue = archivedChat.ReadMessagesAtClockValue
+	}
+	if archivedChat.MessagesTtlClockValue > chat.MessagesTTLClockValue {
+		chat.MessagesTTL = archivedChat.MessagesTtl
+		chat.MessagesTTLClockValue = archivedChat.MessagesTtlClockValue
+	}
+
+	err := m.saveChat(chat)
+	if err != nil {
+		return err
+	}
+
+	response.AddChat(chat)
+	return nil
+}
+
+func (m *Messenger) importArchiveMessages(archivedMessages []*protobuf.ArchiveMessage, response *MessengerResponse) error {
+	identity := common.PubkeyToHex(&m.identity.PublicKey)
+
+	var messages []*common.Message
+	for _, archivedMessage := range archivedMessages {
+		if archivedMessage.Message == nil {
+			continue
+		}
+
+		// Messages of the chats we don't have are dropped
+		if _, ok := m.allChats.Load(archivedMessage.LocalChatId); !ok {
+			continue
+		}
+
+		message := &common.Message{
+			ChatMessage:      *archivedMessage.Message,
+			ID:               archivedMessage.Id,
+			LocalChatID:      archivedMessage.LocalChatId,
+			From:             archivedMessage.From,
+			WhisperTimestamp: archivedMessage.WhisperTimestamp,
+			Seen:             archivedMessage.Seen,
+			OutgoingStatus:   archivedMessage.OutgoingStatus,
+			EditedAt:         archivedMessage.EditedAt,
+			Deleted:          archivedMessage.Deleted,
+		}
+
+		err := message.PrepareContent(identity)
+		if err != nil {
+			m.logger.Warn("failed to prepare archived message", zap.String("id", message.ID), zap.Error(err))
+			continue
+		}
+
+		messages = append(messages, message)
+	}
+
+	chatIDs, err := m.persistence.MergeArchiveMessages(messages)
+	if err != nil {
+		return err
+	}
+
+	for _, chatID := range chatIDs {
+		chat, ok := m.allChats.Load(chatID)
+		if !ok {
+			continue
+		}
+
+		savedChat, err := m.persistence.Chat(chatID)
+		if err != nil {
+			return err
+		}
+		if savedChat != nil {
+			chat.UnviewedMessagesCount = savedChat.UnviewedMessagesCount
+			chat.UnviewedMentionsCount = savedChat.UnviewedMentionsCount
+		}
+
+		err = m.updateLastMessage(chat)
+		if err != nil {
+			return err
+		}
+		response.AddChat(chat)
+	}
+
+	return nil
+}
+
+func deriveArchiveKey(password string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(password), salt, archiveScryptN, archiveScryptR, archiveScryptP, archiveKeyLength)
+}
+
+func encryptArchive(encodedArchive []byte, password string) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(encodedArchive); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, archiveSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	key, err := deriveArchiveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := common.Encrypt(compressed.Bytes(), key, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveFile := append([]byte{}, archiveMagic...)
+	archiveFile = append(archiveFile, salt...)
+	return append(archiveFile, encrypted...), nil
+}
+
+func decryptArchive(archiveFile []byte, password string) ([]byte, error) {
+	if len(archiveFile) < len(archiveMagic)+archiveSaltLength || !bytes.Equal(archiveFile[:len(archiveMagic)], archiveMagic) {
+		return nil, ErrInvalidArchive
+	}
+
+	salt := archiveFile[len(archiveMagic) : len(archiveMagic)+archiveSaltLength]
+	key, err := deriveArchiveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := common.Decrypt(archiveFile[len(archiveMagic)+archiveSaltLength:], key)
+	if err != nil {
+		return nil, ErrInvalidArchivePassword
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
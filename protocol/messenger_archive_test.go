@@ -0,0 +1,266 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerArchiveSuite(t *testing.T) {
+	suite.Run(t, new(MessengerArchiveSuite))
+}
+
+type MessengerArchiveSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	tmpDir string
+	logger *zap.Logger
+}
+
+func (s *MessengerArchiveSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	s.m, err = newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	s.privateKey = s.m.identity
+	_, err = s.m.Start()
+	s.Require().NoError(err)
+
+	s.tmpDir, err = ioutil.TempDir("", "archive-tests-")
+	s.Require().NoError(err)
+}
+
+func (s *MessengerArchiveSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+	s.Require().NoError(os.RemoveAll(s.tmpDir))
+}
+
+func (s *MessengerArchiveSuite) saveMessages(chat *Chat, timestamps ...uint64) []*common.Message {
+	var messages []*common.Message
+	for i, timestamp := range timestamps {
+		message := buildTestMessage(*chat)
+		message.ID = types.EncodeHex(crypto.Keccak256([]byte(chat.ID), []byte{byte(i)}))
+		message.From = common.PubkeyToHex(&s.privateKey.PublicKey)
+		message.Clock = timestamp
+		message.Timestamp = timestamp
+		message.WhisperTimestamp = timestamp
+		s.Require().NoError(message.PrepareContent(message.From))
+		messages = append(messages, message)
+	}
+	s.Require().NoError(s.m.SaveMessages(messages))
+	return messages
+}
+
+func (s *MessengerArchiveSuite) TestExportImportArchive() {
+	contactKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	contactID := types.EncodeHex(crypto.FromECDSAPub(&contactKey.PublicKey))
+	_, err = s.m.AddContact(context.Background(), &requests.AddContact{ID: types.Hex2Bytes(contactID)})
+	s.Require().NoError(err)
+
+	_, err = s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+	chat, ok := s.m.allChats.Load(publicChatName)
+	s.Require().True(ok)
+
+	messages := s.saveMessages(chat, 1000, 2000, 3000)
+
+	path := filepath.Join(s.tmpDir, "archive")
+	err = s.m.ExportArchive(context.Background(), &requests.ExportArchive{
+		Path:     path,
+		Password: "password",
+		From:     1500,
+	})
+	s.Require().NoError(err)
+
+	restored, err := newMessengerWithKey(s.shh, s.privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	defer func() {
+		s.Require().NoError(restored.Shutdown())
+	}()
+
+	_, err = restored.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "wrong-password",
+	})
+	s.Require().Equal(ErrInvalidArchivePassword, err)
+
+	response, err := restored.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(response.Contacts, 1)
+	s.Require().Equal(contactID, response.Contacts[0].ID)
+
+	restoredChat, ok := restored.allChats.Load(publicChatName)
+	s.Require().True(ok)
+	s.Require().True(restoredChat.Active)
+
+	// Only the messages in the range are exported
+	restoredMessages, _, err := restored.MessageByChatID(publicChatName, "", 10)
+	s.Require().NoError(err)
+	s.Require().Len(restoredMessages, 2)
+	s.Require().Equal(messages[2].ID, restoredMessages[0].ID)
+	s.Require().Equal(messages[1].ID, restoredMessages[1].ID)
+	s.Require().NotNil(restoredChat.LastMessage)
+	s.Require().Equal(messages[2].ID, restoredChat.LastMessage.ID)
+
+	// Importing the same archive again is a no-op
+	_, err = restored.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+	restoredMessages, _, err = restored.MessageByChatID(publicChatName, "", 10)
+	s.Require().NoError(err)
+	s.Require().Len(restoredMessages, 2)
+}
+
+func (s *MessengerArchiveSuite) TestImportArchiveKeepsNewerData() {
+	_, err := s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+	chat, ok := s.m.allChats.Load(publicChatName)
+	s.Require().True(ok)
+
+	messages := s.saveMessages(chat, 1000)
+
+	path := filepath.Join(s.tmpDir, "archive")
+	err = s.m.ExportArchive(context.Background(), &requests.ExportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+
+	// The message is edited after the archive has been made
+	edited := messages[0]
+	edited.Text = "edited"
+	edited.EditedAt = 2000
+	s.Require().NoError(edited.PrepareContent(edited.From))
+	s.Require().NoError(s.m.SaveMessages([]*common.Message{edited}))
+
+	_, err = s.m.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+
+	message, err := s.m.MessageByID(edited.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("edited", message.Text)
+	s.Require().Equal(uint64(2000), message.EditedAt)
+}
+
+func (s *MessengerArchiveSuite) TestImportArchiveFromAnotherAccount() {
+	_, err := s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+
+	path := filepath.Join(s.tmpDir, "archive")
+	err = s.m.ExportArchive(context.Background(), &requests.ExportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+
+	otherKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	other, err := newMessengerWithKey(s.shh, otherKey, s.logger, nil)
+	s.Require().NoError(err)
+	defer func() {
+		s.Require().NoError(other.Shutdown())
+	}()
+
+	_, err = other.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().Equal(ErrArchiveWrongAccount, err)
+
+	_, ok := other.allChats.Load(publicChatName)
+	s.Require().False(ok)
+}
+
+func (s *MessengerArchiveSuite) TestImportInvalidArchive() {
+	path := filepath.Join(s.tmpDir, "archive")
+	s.Require().NoError(ioutil.WriteFile(path, []byte("not an archive"), 0600))
+
+	_, err := s.m.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().Equal(ErrInvalidArchive, err)
+}
+
+func (s *MessengerArchiveSuite) TestArchiveImages() {
+	_, err := s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+	chat, ok := s.m.allChats.Load(publicChatName)
+	s.Require().True(ok)
+
+	imagePayload, err := ioutil.ReadFile("../_assets/tests/test.jpg")
+	s.Require().NoError(err)
+
+	message := buildTestMessage(*chat)
+	message.ID = "0x01"
+	message.From = common.PubkeyToHex(&s.privateKey.PublicKey)
+	message.ContentType = protobuf.ChatMessage_IMAGE
+	message.Payload = &protobuf.ChatMessage_Image{Image: &protobuf.ImageMessage{Payload: imagePayload, Type: protobuf.ImageType_JPEG}}
+	s.Require().NoError(message.PrepareContent(message.From))
+	s.Require().NoError(s.m.SaveMessages([]*common.Message{message}))
+
+	path := filepath.Join(s.tmpDir, "archive")
+	err = s.m.ExportArchive(context.Background(), &requests.ExportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+
+	restored, err := newMessengerWithKey(s.shh, s.privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	defer func() {
+		s.Require().NoError(restored.Shutdown())
+	}()
+
+	_, err = restored.ImportArchive(context.Background(), &requests.ImportArchive{
+		Path:     path,
+		Password: "password",
+	})
+	s.Require().NoError(err)
+
+	restoredMessage, err := restored.MessageByID(message.ID)
+	s.Require().NoError(err)
+	s.Require().NoError(restored.persistence.LoadMessageMedia(restoredMessage))
+	s.Require().NotNil(restoredMessage.GetImage())
+	s.Require().Equal(imagePayload, restoredMessage.GetImage().Payload)
+	s.Require().Equal(protobuf.ImageType_JPEG, restoredMessage.GetImage().Type)
+}
@@ -12,9 +12,18 @@ import (
 )
 
 const (
-	BackupContactsPerBatch = 20
+	BackupContactsPerBatch    = 20
+	BackupDraftsPerBatch      = 50
+	BackupPublicChatsPerBatch = 50
+	BackupCommunitiesPerBatch = 5
+	BackupBookmarksPerBatch   = 50
 )
 
+// BackupVersion is the version of the backup format.
+// Version 1 added chats, communities, bookmarks, settings, mute state and
+// activity center state to the contacts and drafts
+const BackupVersion = 1
+
 // backupTickerInterval is how often we should check for backups
 var backupTickerInterval = 120 * time.Second
 
@@ -89,37 +98,108 @@ func (m *Messenger) BackupData(ctx context.Context) (uint64, error) {
 
 	clock, chat := m.getLastClockWithRelatedChat()
 
-	for i := 0; i < len(contacts); i += BackupContactsPerBatch {
-		j := i + BackupContactsPerBatch
-		if j > len(contacts) {
-			j = len(contacts)
-		}
+	err := m.dispatchBackupBatches(ctx, chat, clock, len(contacts), BackupContactsPerBatch, func(i, j int) *protobuf.Backup {
+		return &protobuf.Backup{Contacts: contacts[i:j]}
+	})
+	if err != nil {
+		return 0, err
+	}
 
-		contactsToAdd := contacts[i:j]
+	chatDrafts, err := m.persistence.ChatDrafts()
+	if err != nil {
+		return 0, err
+	}
+
+	var drafts []*protobuf.SyncChatDraft
+	for _, draft := range chatDrafts {
+		drafts = append(drafts, draft.toSyncProtobuf())
+	}
 
-		backupMessage := &protobuf.Backup{
-			Contacts: contactsToAdd,
+	err = m.dispatchBackupBatches(ctx, chat, clock, len(drafts), BackupDraftsPerBatch, func(i, j int) *protobuf.Backup {
+		return &protobuf.Backup{Drafts: drafts[i:j]}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var publicChats []*protobuf.SyncInstallationPublicChat
+	var mutedChats []string
+	m.allChats.Range(func(chatID string, c *Chat) (shouldContinue bool) {
+		if !c.Active {
+			return true
 		}
 
-		encodedMessage, err := proto.Marshal(backupMessage)
-		if err != nil {
-			return 0, err
+		if !c.Timeline() && !c.ProfileUpdates() && c.Public() {
+			publicChats = append(publicChats, &protobuf.SyncInstallationPublicChat{
+				Clock: clock,
+				Id:    chatID,
+			})
 		}
 
-		_, err = m.dispatchMessage(ctx, common.RawMessage{
-			LocalChatID:         chat.ID,
-			Payload:             encodedMessage,
-			SkipEncryption:      true,
-			SendOnPersonalTopic: true,
-			MessageType:         protobuf.ApplicationMetadataMessage_BACKUP,
-		})
-		if err != nil {
-			return 0, err
+		// The mute state of one to one chats is backed up with the contacts
+		if c.Muted && !c.OneToOne() {
+			mutedChats = append(mutedChats, chatID)
 		}
+		return true
+	})
+
+	err = m.dispatchBackupBatches(ctx, chat, clock, len(publicChats), BackupPublicChatsPerBatch, func(i, j int) *protobuf.Backup {
+		return &protobuf.Backup{PublicChats: publicChats[i:j]}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	communities, err := m.backupCommunities(clock)
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.dispatchBackupBatches(ctx, chat, clock, len(communities), BackupCommunitiesPerBatch, func(i, j int) *protobuf.Backup {
+		return &protobuf.Backup{Communities: communities[i:j]}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	storedBookmarks, err := m.browserDatabase.GetBookmarks()
+	if err != nil {
+		return 0, err
+	}
+
+	var bookmarks []*protobuf.SyncBookmark
+	for _, b := range storedBookmarks {
+		bookmarks = append(bookmarks, &protobuf.SyncBookmark{
+			Clock:    b.Clock,
+			Url:      b.URL,
+			Name:     b.Name,
+			ImageUrl: b.ImageURL,
+			Removed:  b.Removed,
+		})
+	}
+
+	err = m.dispatchBackupBatches(ctx, chat, clock, len(bookmarks), BackupBookmarksPerBatch, func(i, j int) *protobuf.Backup {
+		return &protobuf.Backup{Bookmarks: bookmarks[i:j]}
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Settings, mute state and activity center state are small enough to
+	// fit in a single message
+	backupMessage, err := m.backupSettingsAndState(clock)
+	if err != nil {
+		return 0, err
+	}
+	backupMessage.MutedChats = mutedChats
 
+	err = m.dispatchBackup(ctx, chat, clock, backupMessage)
+	if err != nil {
+		return 0, err
 	}
+
 	chat.LastClockValue = clock
-	err := m.saveChat(chat)
+	err = m.saveChat(chat)
 	if err != nil {
 		return 0, err
 	}
@@ -134,6 +214,107 @@ func (m *Messenger) BackupData(ctx context.Context) (uint64, error) {
 	return clock, nil
 }
 
+// backupCommunities returns the communities we joined, requested to join or
+// created, along with the private keys of the ones we control
+func (m *Messenger) backupCommunities(clock uint64) ([]*protobuf.SyncCommunity, error) {
+	joined, err := m.communitiesManager.JoinedAndPendingCommunitiesWithRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := m.communitiesManager.Created()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*protobuf.SyncCommunity
+	added := make(map[string]bool)
+	for _, c := range append(joined, created...) {
+		if added[c.IDString()] {
+			continue
+		}
+		added[c.IDString()] = true
+
+		syncCommunity, err := c.ToSyncCommunityProtobuf(clock)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, syncCommunity)
+	}
+	return result, nil
+}
+
+func (m *Messenger) backupSettingsAndState(clock uint64) (*protobuf.Backup, error) {
+	settings, err := m.settings.GetBackupSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	backupMessage := &protobuf.Backup{}
+	for name, value := range settings {
+		backupMessage.Settings = append(backupMessage.Settings, &protobuf.BackupSetting{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	readIDs, err := m.persistence.GetReadActivityCenterNotificationIds()
+	if err != nil {
+		return nil, err
+	}
+	backupMessage.ActivityCenterRead = &protobuf.SyncActivityCenterRead{Clock: clock, Ids: readIDs}
+
+	acceptedIDs, err := m.persistence.GetAcceptedActivityCenterNotificationIds()
+	if err != nil {
+		return nil, err
+	}
+	backupMessage.ActivityCenterAccepted = &protobuf.SyncActivityCenterAccepted{Clock: clock, Ids: acceptedIDs}
+
+	dismissedIDs, err := m.persistence.GetDismissedActivityCenterNotificationIds()
+	if err != nil {
+		return nil, err
+	}
+	backupMessage.ActivityCenterDismissed = &protobuf.SyncActivityCenterDismissed{Clock: clock, Ids: dismissedIDs}
+
+	return backupMessage, nil
+}
+
+// dispatchBackupBatches splits count items in batches of batchSize and
+// dispatches the backup message built for each batch
+func (m *Messenger) dispatchBackupBatches(ctx context.Context, chat *Chat, clock uint64, count int, batchSize int, batch func(i, j int) *protobuf.Backup) error {
+	for i := 0; i < count; i += batchSize {
+		j := i + batchSize
+		if j > count {
+			j = count
+		}
+
+		err := m.dispatchBackup(ctx, chat, clock, batch(i, j))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Messenger) dispatchBackup(ctx context.Context, chat *Chat, clock uint64, backupMessage *protobuf.Backup) error {
+	backupMessage.Clock = clock
+	backupMessage.Version = BackupVersion
+
+	encodedMessage, err := proto.Marshal(backupMessage)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:         chat.ID,
+		Payload:             encodedMessage,
+		SkipEncryption:      true,
+		SendOnPersonalTopic: true,
+		MessageType:         protobuf.ApplicationMetadataMessage_BACKUP,
+	})
+	return err
+}
+
 // syncContact sync as contact with paired devices
 func (m *Messenger) syncBackupContact(ctx context.Context, contact *Contact) *protobuf.SyncInstallationContactV2 {
 	if contact.IsSyncing {
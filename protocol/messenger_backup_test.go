@@ -11,8 +11,10 @@ import (
 	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
 	"github.com/planq-network/status-go/eth-node/crypto"
 	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/protobuf"
 	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/services/browsers"
 	"github.com/planq-network/status-go/waku"
 )
 
@@ -384,3 +386,134 @@ func (s *MessengerBackupSuite) TestBackupBlockedContacts() {
 	s.Require().NoError(err)
 	s.Require().Len(bob2.BlockedContacts(), 1)
 }
+
+func (s *MessengerBackupSuite) TestBackupChatDrafts() {
+	bob1 := s.m
+	// Create bob2
+	bob2, err := newMessengerWithKey(s.shh, bob1.identity, s.logger, nil)
+	s.Require().NoError(err)
+	_, err = bob2.Start()
+	s.Require().NoError(err)
+
+	_, err = bob1.CreatePublicChat(&requests.CreatePublicChat{ID: "status"})
+	s.Require().NoError(err)
+
+	_, err = bob1.SaveChatDraft(context.Background(), &requests.SaveChatDraft{
+		ChatID: "status",
+		Text:   "half written",
+	})
+	s.Require().NoError(err)
+
+	// Backup
+	_, err = bob1.BackupData(context.Background())
+	s.Require().NoError(err)
+
+	// Wait for the message to reach its destination
+	_, err = WaitOnMessengerResponse(
+		bob2,
+		func(r *MessengerResponse) bool {
+			return len(r.ChatDrafts()) > 0
+		},
+		"drafts not backed up",
+	)
+	s.Require().NoError(err)
+
+	draft, err := bob2.ChatDraft("status")
+	s.Require().NoError(err)
+	s.Require().NotNil(draft)
+	s.Require().Equal("half written", draft.Text)
+}
+
+func (s *MessengerBackupSuite) TestBackupChatsBookmarksAndSettings() {
+	bob1 := s.m
+	// Create bob2
+	bob2, err := newMessengerWithKey(s.shh, bob1.identity, s.logger, nil)
+	s.Require().NoError(err)
+	_, err = bob2.Start()
+	s.Require().NoError(err)
+
+	_, err = bob1.CreatePublicChat(&requests.CreatePublicChat{ID: "status"})
+	s.Require().NoError(err)
+	s.Require().NoError(bob1.MuteChat("status"))
+
+	err = bob1.browserDatabase.StoreBookmarkWithoutFetchIcon(&browsers.Bookmark{
+		URL:   "https://status.im",
+		Name:  "status",
+		Clock: 1,
+	}, nil)
+	s.Require().NoError(err)
+
+	s.Require().NoError(bob1.settings.SaveSetting("currency", "eur"))
+
+	// Backup
+	_, err = bob1.BackupData(context.Background())
+	s.Require().NoError(err)
+
+	// Wait for the messages to reach their destination
+	_, err = WaitOnMessengerResponse(
+		bob2,
+		func(r *MessengerResponse) bool {
+			chat, ok := bob2.allChats.Load("status")
+			if !ok || !chat.Active || !chat.Muted {
+				return false
+			}
+
+			bookmarks, err := bob2.browserDatabase.GetBookmarks()
+			if err != nil || len(bookmarks) == 0 {
+				return false
+			}
+
+			settings, err := bob2.settings.GetSettings()
+			return err == nil && settings.Currency == "eur"
+		},
+		"chats, bookmarks and settings not backed up",
+	)
+	s.Require().NoError(err)
+
+	bookmarks, err := bob2.browserDatabase.GetBookmarks()
+	s.Require().NoError(err)
+	s.Require().Len(bookmarks, 1)
+	s.Require().Equal("https://status.im", bookmarks[0].URL)
+	s.Require().Equal("status", bookmarks[0].Name)
+}
+
+func (s *MessengerBackupSuite) TestBackupCommunities() {
+	bob1 := s.m
+	// Create bob2
+	bob2, err := newMessengerWithKey(s.shh, bob1.identity, s.logger, nil)
+	s.Require().NoError(err)
+	_, err = bob2.Start()
+	s.Require().NoError(err)
+
+	description := &requests.CreateCommunity{
+		Membership:  protobuf.CommunityPermissions_NO_MEMBERSHIP,
+		Name:        "status",
+		Color:       "#ffffff",
+		Description: "status community description",
+	}
+
+	response, err := bob1.CreateCommunity(description)
+	s.Require().NoError(err)
+	s.Require().Len(response.Communities(), 1)
+	communityID := response.Communities()[0].ID()
+
+	// Backup
+	_, err = bob1.BackupData(context.Background())
+	s.Require().NoError(err)
+
+	// Wait for the message to reach its destination
+	_, err = WaitOnMessengerResponse(
+		bob2,
+		func(r *MessengerResponse) bool {
+			community, err := bob2.communitiesManager.GetByID(communityID)
+			return err == nil && community != nil && community.Joined()
+		},
+		"communities not backed up",
+	)
+	s.Require().NoError(err)
+
+	community, err := bob2.communitiesManager.GetByID(communityID)
+	s.Require().NoError(err)
+	// We control the community on the restored device as well
+	s.Require().True(community.IsAdmin())
+}
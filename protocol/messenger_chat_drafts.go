@@ -0,0 +1,112 @@
+package protocol
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+)
+
+// SaveChatDraft saves the draft of a chat and syncs it with the paired
+// devices
+func (m *Messenger) SaveChatDraft(ctx context.Context, request *requests.SaveChatDraft) (*ChatDraft, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, ok := m.allChats.Load(request.ChatID); !ok {
+		return nil, ErrChatNotFound
+	}
+
+	clock, chat := m.getLastClockWithRelatedChat()
+
+	draft := &ChatDraft{
+		ChatID:     request.ChatID,
+		Text:       request.Text,
+		ResponseTo: request.ResponseTo,
+		Clock:      clock,
+	}
+
+	_, err := m.persistence.SaveChatDraft(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.syncChatDraft(ctx, chat, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	chat.LastClockValue = clock
+	err = m.saveChat(chat)
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// ChatDraft returns the draft of a chat, nil if there's none
+func (m *Messenger) ChatDraft(chatID string) (*ChatDraft, error) {
+	return m.persistence.ChatDraft(chatID)
+}
+
+// ChatDrafts returns the drafts of all the chats
+func (m *Messenger) ChatDrafts() ([]*ChatDraft, error) {
+	drafts, err := m.persistence.ChatDrafts()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*ChatDraft
+	for _, draft := range drafts {
+		if draft.Text != "" {
+			result = append(result, draft)
+		}
+	}
+	return result, nil
+}
+
+func (m *Messenger) syncChatDraft(ctx context.Context, chat *Chat, draft *ChatDraft) error {
+	if !m.hasPairedDevices() {
+		return nil
+	}
+
+	encodedMessage, err := proto.Marshal(draft.toSyncProtobuf())
+	if err != nil {
+		return err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:         chat.ID,
+		Payload:             encodedMessage,
+		MessageType:         protobuf.ApplicationMetadataMessage_SYNC_CHAT_DRAFT,
+		ResendAutomatically: true,
+	})
+	return err
+}
+
+// HandleSyncChatDraft saves a draft received from a paired device, the most
+// recent draft wins
+func (m *Messenger) HandleSyncChatDraft(state *ReceivedMessageState, message protobuf.SyncChatDraft) error {
+	draft := &ChatDraft{
+		ChatID:     message.ChatId,
+		Text:       message.Text,
+		ResponseTo: message.ResponseTo,
+		Clock:      message.Clock,
+	}
+
+	saved, err := m.persistence.SaveChatDraft(draft)
+	if err != nil {
+		return err
+	}
+
+	if saved {
+		state.Response.AddChatDraft(draft)
+	}
+
+	return nil
+}
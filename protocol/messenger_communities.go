@@ -84,6 +84,38 @@ func (m *Messenger) handleCommunitiesSubscription(c chan *communities.Subscripti
 					err := m.publishOrgInvitation(sub.Community, invitation)
 					if err != nil {
 						m.logger.Warn("failed to publish org invitation", zap.Error(err))
+						continue
+					}
+
+					pk, err := crypto.DecompressPubkey(invitation.PublicKey)
+					if err != nil {
+						m.logger.Warn("failed to decompress invitation public key", zap.Error(err))
+						continue
+					}
+					err = m.sendCommunityChatKeys(sub.Community, pk)
+					if err != nil {
+						m.logger.Warn("failed to send community chat keys", zap.Error(err))
+					}
+				}
+
+				if sub.RequestToJoin != nil {
+					err := m.handleCheckedRequestToJoin(sub.RequestToJoin)
+					if err != nil {
+						m.logger.Warn("failed to handle checked request to join", zap.Error(err))
+					}
+				}
+
+				if len(sub.RemovedMembers) != 0 && sub.Community.IsAdmin() {
+					var err error
+					if len(sub.RemovedFromChatID) != 0 {
+						if sub.Community.IsChatEncrypted(sub.RemovedFromChatID) {
+							err = m.rotateCommunityChatKey(sub.Community, sub.RemovedFromChatID)
+						}
+					} else {
+						err = m.rotateCommunityChatKeys(sub.Community)
+					}
+					if err != nil {
+						m.logger.Warn("failed to rotate community chat keys", zap.Error(err))
 					}
 				}
 
@@ -250,6 +282,13 @@ func (m *Messenger) RequestToJoinCommunity(request *requests.RequestToJoinCommun
 		CommunityId: community.ID(),
 	}
 
+	for _, proof := range request.AddressProofs {
+		requestToJoinProto.AddressProofs = append(requestToJoinProto.AddressProofs, &protobuf.AddressOwnershipProof{
+			Address:   proof.Address.Hex(),
+			Signature: proof.Signature,
+		})
+	}
+
 	payload, err := proto.Marshal(requestToJoinProto)
 	if err != nil {
 		return nil, err
@@ -290,6 +329,12 @@ func (m *Messenger) RequestToJoinCommunity(request *requests.RequestToJoinCommun
 	return response, nil
 }
 
+// CommunityAddressOwnershipProofMessage returns the message to be signed by
+// the addresses used to meet the token requirements of a community
+func (m *Messenger) CommunityAddressOwnershipProofMessage(communityID types.HexBytes) string {
+	return string(communities.AddressOwnershipProofMessage(communityID, &m.identity.PublicKey))
+}
+
 func (m *Messenger) CreateCommunityCategory(request *requests.CreateCommunityCategory) (*MessengerResponse, error) {
 	if err := request.Validate(); err != nil {
 		return nil, err
@@ -458,6 +503,13 @@ func (m *Messenger) CreateCommunityChat(communityID types.HexBytes, c *protobuf.
 		chats = append(chats, c)
 		chatIDs = append(chatIDs, c.ID)
 		response.AddChat(c)
+
+		if community.IsChatEncrypted(chatID) {
+			err = m.ensureCommunityChatKey(community, chatID)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Load filters
@@ -499,6 +551,13 @@ func (m *Messenger) EditCommunityChat(communityID types.HexBytes, chatID string,
 		chats = append(chats, c)
 		chatIDs = append(chatIDs, c.ID)
 		response.AddChat(c)
+
+		if community.IsChatEncrypted(chatID) {
+			err = m.ensureCommunityChatKey(community, chatID)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Load filters
@@ -719,7 +778,34 @@ func (m *Messenger) RemoveUserFromCommunity(id types.HexBytes, pkString string)
 		return nil, err
 	}
 
-	community, err := m.communitiesManager.RemoveUserFromCommunity(id, publicKey)
+	community, err := m.communitiesManager.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we don't own the community, we ask the owner to remove the user
+	if community != nil && !community.IsAdmin() {
+		return m.sendCommunityMemberAction(community, pkString, protobuf.CommunityMemberAction_KICK)
+	}
+
+	community, err = m.communitiesManager.RemoveUserFromCommunity(id, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+// RemoveUserFromCommunityChat removes the user from a chat of a community we own
+func (m *Messenger) RemoveUserFromCommunityChat(id types.HexBytes, chatID string, pkString string) (*MessengerResponse, error) {
+	publicKey, err := common.HexToPubkey(pkString)
+	if err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.RemoveUserFromCommunityChat(id, chatID, publicKey)
 	if err != nil {
 		return nil, err
 	}
@@ -730,7 +816,21 @@ func (m *Messenger) RemoveUserFromCommunity(id types.HexBytes, pkString string)
 }
 
 func (m *Messenger) BanUserFromCommunity(request *requests.BanUserFromCommunity) (*MessengerResponse, error) {
-	community, err := m.communitiesManager.BanUserFromCommunity(request)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.GetByID(request.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we don't own the community, we ask the owner to ban the user
+	if community != nil && !community.IsAdmin() {
+		return m.sendCommunityMemberAction(community, request.User.String(), protobuf.CommunityMemberAction_BAN)
+	}
+
+	community, err = m.communitiesManager.BanUserFromCommunity(request)
 	if err != nil {
 		return nil, err
 	}
@@ -745,6 +845,161 @@ func (m *Messenger) BanUserFromCommunity(request *requests.BanUserFromCommunity)
 	return response, nil
 }
 
+// handleCheckedRequestToJoin notifies the client of a request to join whose
+// token requirements have been checked, the owner is asked to handle it
+// if they couldn't be checked
+func (m *Messenger) handleCheckedRequestToJoin(requestToJoin *communities.RequestToJoin) error {
+	community, err := m.communitiesManager.GetByID(requestToJoin.CommunityID)
+	if err != nil {
+		return err
+	}
+
+	response := &MessengerResponse{}
+	response.RequestsToJoinCommunity = append(response.RequestsToJoinCommunity, requestToJoin)
+
+	switch requestToJoin.State {
+	case communities.RequestToJoinStateAccepted:
+		response.AddCommunity(community)
+	case communities.RequestToJoinStatePending:
+		contact, _ := m.allContacts.Load(requestToJoin.PublicKey)
+		response.AddNotification(NewCommunityRequestToJoinNotification(requestToJoin.ID.String(), community, contact))
+	}
+
+	if m.config.messengerSignalsHandler != nil {
+		m.config.messengerSignalsHandler.MessengerResponse(response)
+	}
+	return nil
+}
+
+// sendCommunityMemberAction sends a kick or ban of a member to the owner of
+// the community, which applies it if we have the required permission
+func (m *Messenger) sendCommunityMemberAction(community *communities.Community, memberID string, actionType protobuf.CommunityMemberAction_ActionType) (*MessengerResponse, error) {
+	permission := protobuf.CommunityRole_MANAGE_USERS
+	if actionType == protobuf.CommunityMemberAction_BAN {
+		permission = protobuf.CommunityRole_BAN_USERS
+	}
+
+	if !community.HasPermission(&m.identity.PublicKey, "", permission) {
+		return nil, communities.ErrNotAuthorized
+	}
+
+	action := &protobuf.CommunityMemberAction{
+		Clock:       m.getTimesource().GetCurrentTime(),
+		CommunityId: community.ID(),
+		MemberId:    memberID,
+		Type:        actionType,
+	}
+
+	payload, err := proto.Marshal(action)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMessage := common.RawMessage{
+		Payload:        payload,
+		SkipEncryption: true,
+		MessageType:    protobuf.ApplicationMetadataMessage_COMMUNITY_MEMBER_ACTION,
+	}
+	_, err = m.sender.SendCommunityMessage(context.Background(), community.PublicKey(), rawMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) CreateCommunityRole(request *requests.CreateCommunityRole) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.CreateRole(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) EditCommunityRole(request *requests.EditCommunityRole) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.EditRole(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) DeleteCommunityRole(request *requests.DeleteCommunityRole) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.DeleteRole(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) AssignCommunityRole(request *requests.AssignCommunityRole) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.AssignRole(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) RevokeCommunityRole(request *requests.RevokeCommunityRole) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.RevokeRole(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
+func (m *Messenger) SetCommunityChatRoleOverride(request *requests.SetCommunityChatRoleOverride) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := m.communitiesManager.SetChatRoleOverride(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddCommunity(community)
+	return response, nil
+}
+
 // RequestCommunityInfoFromMailserver installs filter for community and requests its details
 // from mailserver. It waits until it  has the community before returning it
 func (m *Messenger) RequestCommunityInfoFromMailserver(communityID string) (*communities.Community, error) {
@@ -0,0 +1,144 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/communities"
+)
+
+// ErrCommunityChatKeyNotSigned is returned when a community chat key hasn't
+// been signed by the key of the community
+var ErrCommunityChatKeyNotSigned = errors.New("community chat key not signed by the community")
+
+// communityChatGroupID returns the ID of the hash ratchet group used to
+// encrypt the messages of a community chat, which is the ID of the chat
+func communityChatGroupID(community *communities.Community, chatID string) []byte {
+	return []byte(community.IDString() + chatID)
+}
+
+// rotateCommunityChatKeys generates a new key for every encrypted chat of the
+// community, so that removed members can't read any further message
+func (m *Messenger) rotateCommunityChatKeys(community *communities.Community) error {
+	for _, chatID := range community.EncryptedChats() {
+		if err := m.rotateCommunityChatKey(community, chatID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateCommunityChatKey generates a new key for the chat and sends it to its members
+func (m *Messenger) rotateCommunityChatKey(community *communities.Community, chatID string) error {
+	groupID := communityChatGroupID(community, chatID)
+	keyID, err := m.encryptor.GenerateHashRatchetKey(groupID)
+	if err != nil {
+		return err
+	}
+
+	members, err := community.ChatMembers(chatID)
+	if err != nil {
+		return err
+	}
+
+	m.sendCommunityChatKey(community, groupID, keyID, members)
+	return nil
+}
+
+// ensureCommunityChatKey generates a key for the chat if there's none yet
+func (m *Messenger) ensureCommunityChatKey(community *communities.Community, chatID string) error {
+	keyID, err := m.encryptor.GetCurrentKeyForGroup(communityChatGroupID(community, chatID))
+	if err != nil {
+		return err
+	}
+	if keyID != 0 {
+		return nil
+	}
+	return m.rotateCommunityChatKey(community, chatID)
+}
+
+// sendCommunityChatKeys sends the current keys of the encrypted chats the
+// member has access to
+func (m *Messenger) sendCommunityChatKeys(community *communities.Community, pk *ecdsa.PublicKey) error {
+	for _, chatID := range community.EncryptedChats() {
+		members, err := community.ChatMembers(chatID)
+		if err != nil {
+			return err
+		}
+
+		isMember := false
+		for _, member := range members {
+			if common.IsPubKeyEqual(member, pk) {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			continue
+		}
+
+		groupID := communityChatGroupID(community, chatID)
+		keyID, err := m.encryptor.GetCurrentKeyForGroup(groupID)
+		if err != nil {
+			return err
+		}
+
+		// No key yet, a new one is sent to every member
+		if keyID == 0 {
+			if err := m.rotateCommunityChatKey(community, chatID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		m.sendCommunityChatKey(community, groupID, keyID, []*ecdsa.PublicKey{pk})
+	}
+	return nil
+}
+
+// sendCommunityChatKey sends the key, signed by the community, to the members
+// and to our paired devices
+func (m *Messenger) sendCommunityChatKey(community *communities.Community, groupID []byte, keyID uint32, members []*ecdsa.PublicKey) {
+	recipients := []*ecdsa.PublicKey{&m.identity.PublicKey}
+	for _, member := range members {
+		if !common.IsPubKeyEqual(member, &m.identity.PublicKey) {
+			recipients = append(recipients, member)
+		}
+	}
+
+	for _, pk := range recipients {
+		err := m.sender.SendHashRatchetKey(context.Background(), pk, groupID, keyID, community.PrivateKey())
+		if err != nil {
+			m.logger.Warn("failed to send community chat key", zap.Error(err))
+		}
+	}
+}
+
+// verifyCommunityChatKey only accepts the keys of community chats signed by
+// the community, so that no one else can replace the key of a chat. The ID of
+// the group starts with the ID of the community, which is its public key
+func (m *Messenger) verifyCommunityChatKey(groupID []byte, signer *ecdsa.PublicKey) error {
+	if signer == nil || len(groupID) <= pkStringLength {
+		return ErrCommunityChatKeyNotSigned
+	}
+
+	communityID, err := types.DecodeHex(string(groupID[:pkStringLength]))
+	if err != nil {
+		return err
+	}
+	communityPublicKey, err := crypto.DecompressPubkey(communityID)
+	if err != nil {
+		return err
+	}
+
+	if !common.IsPubKeyEqual(signer, communityPublicKey) {
+		return ErrCommunityChatKeyNotSigned
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ type MessageDeliveredHandler func(string, string)
 
 type MessengerSignalsHandler interface {
 	MessageDelivered(chatID string, messageID string)
+	MessageRead(chatID string, messageID string, from string)
 	CommunityInfoFound(community *communities.Community)
 	MessengerResponse(response *MessengerResponse)
 	HistoryRequestStarted(requestID string, numBatches int)
@@ -33,6 +34,7 @@ type MessengerSignalsHandler interface {
 	HistoryRequestCompleted(requestID string)
 	HistoryRequestFailed(requestID string, err error)
 	BackupPerformed(uint64)
+	OutboxStateChanged(entries []*common.OutboxEntry)
 }
 
 type config struct {
@@ -63,6 +65,8 @@ type config struct {
 	verifyENSURL             string
 	verifyENSContractAddress string
 
+	tokenBalanceChecker communities.TokenBalanceChecker
+
 	anonMetricsClientConfig *anonmetrics.ClientConfig
 	anonMetricsServerConfig *anonmetrics.ServerConfig
 
@@ -108,6 +112,13 @@ func WithVerifyTransactionClient(client EthClient) Option {
 	}
 }
 
+func WithTokenBalanceChecker(checker communities.TokenBalanceChecker) Option {
+	return func(c *config) error {
+		c.tokenBalanceChecker = checker
+		return nil
+	}
+}
+
 func WithDatabase(db *sql.DB) Option {
 	return func(c *config) error {
 		c.db = db
@@ -0,0 +1,177 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/signal"
+)
+
+// expiredMessagesSweepInterval is how often expired messages are deleted
+const expiredMessagesSweepInterval = 30 * time.Second
+
+var ErrChatMessagesTTLNotAllowed = errors.New("disappearing messages are only allowed in one-to-one and group chats")
+
+func chatMessagesTTLAllowed(chat *Chat) bool {
+	return chat.OneToOne() || chat.PrivateGroupChat()
+}
+
+// SetChatMessagesTTL sets the time after which the messages sent in the chat
+// are deleted, the timer is synced with the other members of the chat
+func (m *Messenger) SetChatMessagesTTL(ctx context.Context, request *requests.SetChatMessagesTTL) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	chat, ok := m.allChats.Load(request.ChatID)
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+
+	if !chatMessagesTTLAllowed(chat) {
+		return nil, ErrChatMessagesTTLNotAllowed
+	}
+
+	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
+
+	ttl := &ChatMessagesTTL{
+		ChatMessagesTTL: protobuf.ChatMessagesTTL{
+			Clock:  clock,
+			ChatId: chat.ID,
+			Ttl:    request.TTL,
+		},
+	}
+
+	encodedMessage, err := m.encodeChatEntity(chat, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:          chat.ID,
+		Payload:              encodedMessage,
+		SkipGroupMessageWrap: true,
+		MessageType:          protobuf.ApplicationMetadataMessage_CHAT_MESSAGES_TTL,
+		ResendAutomatically:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chat.MessagesTTL = request.TTL
+	chat.MessagesTTLClockValue = clock
+
+	err = m.saveChat(chat)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddChat(chat)
+
+	return response, nil
+}
+
+// HandleChatMessagesTTL updates the time after which the messages of a chat
+// are deleted, older updates are ignored
+func (m *Messenger) HandleChatMessagesTTL(state *ReceivedMessageState, pbTTL protobuf.ChatMessagesTTL) error {
+	logger := m.logger.With(zap.String("site", "HandleChatMessagesTTL"))
+	if err := ValidateChatMessagesTTL(pbTTL, state.CurrentMessageState.WhisperTimestamp); err != nil {
+		logger.Error("invalid chat messages ttl", zap.Error(err))
+		return err
+	}
+
+	ttl := &ChatMessagesTTL{
+		ChatMessagesTTL: pbTTL,
+		SigPubKey:       state.CurrentMessageState.PublicKey,
+	}
+
+	chat, err := m.matchChatEntity(ttl)
+	if err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	if !chatMessagesTTLAllowed(chat) {
+		return ErrChatMessagesTTLNotAllowed
+	}
+
+	if chat.MessagesTTLClockValue >= ttl.Clock {
+		return nil
+	}
+
+	chat.MessagesTTL = ttl.Ttl
+	chat.MessagesTTLClockValue = ttl.Clock
+	if chat.LastClockValue < ttl.Clock {
+		chat.LastClockValue = ttl.Clock
+	}
+
+	err = m.saveChat(chat)
+	if err != nil {
+		return err
+	}
+
+	state.Response.AddChat(chat)
+
+	return nil
+}
+
+// deleteExpiredMessages deletes the messages that have expired and updates
+// the chats they belonged to
+func (m *Messenger) deleteExpiredMessages() (*MessengerResponse, error) {
+	expiredMessages, err := m.persistence.DeleteExpiredMessages(m.getTimesource().GetCurrentTime())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	for _, expired := range expiredMessages {
+		for _, messageID := range expired.MessageIDs {
+			response.AddRemovedMessage(&RemovedMessage{ChatID: expired.ChatID, MessageID: messageID})
+		}
+
+		chat, ok := m.allChats.Load(expired.ChatID)
+		if !ok {
+			continue
+		}
+
+		chat.UnviewedMessagesCount = expired.UnviewedMessagesCount
+		chat.UnviewedMentionsCount = expired.UnviewedMentionsCount
+
+		if err := m.updateLastMessage(chat); err != nil {
+			return nil, err
+		}
+
+		response.AddChat(chat)
+	}
+
+	return response, nil
+}
+
+// watchDisappearingMessages regularly deletes the messages that have expired
+// and notifies the client
+func (m *Messenger) watchDisappearingMessages() {
+	m.logger.Debug("watching disappearing messages")
+	go func() {
+		for {
+			select {
+			case <-time.After(expiredMessagesSweepInterval):
+				response, err := m.deleteExpiredMessages()
+				if err != nil {
+					m.logger.Error("failed to delete expired messages", zap.Error(err))
+					continue
+				}
+				if !response.IsEmpty() {
+					signal.SendNewMessages(response)
+				}
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
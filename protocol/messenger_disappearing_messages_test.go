@@ -0,0 +1,158 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerDisappearingMessagesSuite(t *testing.T) {
+	suite.Run(t, new(MessengerDisappearingMessagesSuite))
+}
+
+type MessengerDisappearingMessagesSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerDisappearingMessagesSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	s.m = s.newMessenger()
+	s.privateKey = s.m.identity
+	_, err := s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerDisappearingMessagesSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerDisappearingMessagesSuite) newMessenger() *Messenger {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	messenger, err := newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	return messenger
+}
+
+func (s *MessengerDisappearingMessagesSuite) TestDisappearingMessages() {
+	alice := s.m
+	bob := s.newMessenger()
+	_, err := bob.Start()
+	s.Require().NoError(err)
+	defer bob.Shutdown() // nolint: errcheck
+
+	publicChat := CreatePublicChat(statusChatID, alice.transport)
+	s.Require().NoError(alice.SaveChat(publicChat))
+	_, err = alice.SetChatMessagesTTL(context.Background(), &requests.SetChatMessagesTTL{ChatID: publicChat.ID, TTL: 1})
+	s.Require().Equal(ErrChatMessagesTTLNotAllowed, err)
+
+	aliceChat := CreateOneToOneChat("bob", &bob.identity.PublicKey, alice.transport)
+	s.Require().NoError(alice.SaveChat(aliceChat))
+	bobChat := CreateOneToOneChat("alice", &alice.identity.PublicKey, bob.transport)
+	s.Require().NoError(bob.SaveChat(bobChat))
+
+	response, err := alice.SetChatMessagesTTL(context.Background(), &requests.SetChatMessagesTTL{ChatID: aliceChat.ID, TTL: 1})
+	s.Require().NoError(err)
+	s.Require().Len(response.Chats(), 1)
+	s.Require().Equal(uint64(1), response.Chats()[0].MessagesTTL)
+
+	// The timer is synced with bob
+	_, err = WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool {
+			return len(r.Chats()) == 1 && r.Chats()[0].MessagesTTL == 1
+		},
+		"no chat messages ttl",
+	)
+	s.Require().NoError(err)
+
+	sendResponse, err := bob.SendChatMessage(context.Background(), buildTestMessage(*bobChat))
+	s.Require().NoError(err)
+	s.Require().Len(sendResponse.Messages(), 1)
+	sentMessage := sendResponse.Messages()[0]
+	s.Require().Equal(sentMessage.Timestamp+1000, sentMessage.ExpiresAt)
+
+	response, err = WaitOnMessengerResponse(
+		alice,
+		func(r *MessengerResponse) bool { return len(r.Messages()) > 0 },
+		"no message",
+	)
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().Equal(sentMessage.ExpiresAt, response.Messages()[0].ExpiresAt)
+
+	time.Sleep(time.Duration(int64(sentMessage.ExpiresAt)-int64(alice.getTimesource().GetCurrentTime())+1) * time.Millisecond)
+
+	response, err = alice.deleteExpiredMessages()
+	s.Require().NoError(err)
+	s.Require().Len(response.RemovedMessages(), 1)
+	s.Require().Equal(sentMessage.ID, response.RemovedMessages()[0].MessageID)
+	s.Require().Len(response.Chats(), 1)
+	s.Require().Nil(response.Chats()[0].LastMessage)
+
+	_, err = alice.MessageByID(sentMessage.ID)
+	s.Require().Equal(common.ErrRecordNotFound, err)
+
+	// Disable the timer
+	_, err = alice.SetChatMessagesTTL(context.Background(), &requests.SetChatMessagesTTL{ChatID: aliceChat.ID, TTL: 0})
+	s.Require().NoError(err)
+
+	_, err = WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool {
+			return len(r.Chats()) == 1 && r.Chats()[0].MessagesTTL == 0
+		},
+		"no chat messages ttl",
+	)
+	s.Require().NoError(err)
+
+	// Messages that have already expired when received are ignored
+	expiredMessage := buildTestMessage(*bobChat)
+	expiredMessage.ExpiresAt = 1
+	_, err = bob.SendChatMessage(context.Background(), expiredMessage)
+	s.Require().NoError(err)
+
+	sendResponse, err = bob.SendChatMessage(context.Background(), buildTestMessage(*bobChat))
+	s.Require().NoError(err)
+	sentMessage = sendResponse.Messages()[0]
+
+	response, err = WaitOnMessengerResponse(
+		alice,
+		func(r *MessengerResponse) bool { return len(r.Messages()) > 0 },
+		"no message",
+	)
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().Equal(sentMessage.ID, response.Messages()[0].ID)
+
+	_, err = alice.MessageByID(expiredMessage.ID)
+	s.Require().Equal(common.ErrRecordNotFound, err)
+}
@@ -0,0 +1,255 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// fileChunkSize is the size of the chunks the content of a file is split in,
+// so that each of them fits in a single envelope
+const fileChunkSize = 256 * 1024
+
+const maxFileSize = 20 * 1024 * 1024
+
+const maxFileChunks = maxFileSize / fileChunkSize
+
+const (
+	// fileChunksTTL is how long the chunks of a file whose message hasn't been
+	// received are kept
+	fileChunksTTL = time.Hour
+	// fileChunksPruneInterval is how often the unclaimed chunks are removed
+	fileChunksPruneInterval = 10 * time.Minute
+)
+
+var ErrFileTooLarge = errors.New("file is too large")
+var ErrFileHashMismatch = errors.New("file content doesn't match its hash")
+var ErrFileChunkMismatch = errors.New("file chunk doesn't match the file it belongs to")
+
+// prepareFileMessage reads the file to be sent and sets its description as the
+// payload of the message, it returns the content of the file
+func (m *Messenger) prepareFileMessage(message *common.Message) ([]byte, error) {
+	payload, err := ioutil.ReadFile(message.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	if len(payload) > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(message.FilePath))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(payload)
+	}
+
+	hash := sha256.Sum256(payload)
+
+	message.ContentType = protobuf.ChatMessage_FILE
+	message.Payload = &protobuf.ChatMessage_File{
+		File: &protobuf.FileMessage{
+			Name:        filepath.Base(message.FilePath),
+			MimeType:    mimeType,
+			Size:        uint64(len(payload)),
+			Hash:        hash[:],
+			ChunksCount: uint32((len(payload) + fileChunkSize - 1) / fileChunkSize),
+		},
+	}
+
+	return payload, nil
+}
+
+// sendFileChunks sends the content of the file in chunks to the chat and
+// stores it locally
+func (m *Messenger) sendFileChunks(ctx context.Context, chat *Chat, file *protobuf.FileMessage, payload []byte) error {
+	for index := uint32(0); index < file.ChunksCount; index++ {
+		start := int(index) * fileChunkSize
+		end := start + fileChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		chunk := &FileChunk{
+			FileChunk: protobuf.FileChunk{
+				Hash:        file.Hash,
+				Index:       index,
+				ChunksCount: file.ChunksCount,
+				Payload:     payload[start:end],
+				ChatId:      chat.ID,
+			},
+		}
+
+		encodedMessage, err := m.encodeChatEntity(chat, chunk)
+		if err != nil {
+			return err
+		}
+
+		_, err = m.dispatchMessage(ctx, common.RawMessage{
+			LocalChatID:          chat.ID,
+			Payload:              encodedMessage,
+			SkipGroupMessageWrap: true,
+			MessageType:          protobuf.ApplicationMetadataMessage_FILE_CHUNK,
+			ResendAutomatically:  true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.persistence.SaveFile(types.EncodeHex(file.Hash), payload)
+}
+
+// HandleFileChunk saves a chunk of a file, once all the chunks have been
+// received the file is reassembled and checked against its hash before
+// being made available
+func (m *Messenger) HandleFileChunk(state *ReceivedMessageState, pbChunk protobuf.FileChunk) error {
+	logger := m.logger.With(zap.String("site", "HandleFileChunk"))
+	if err := ValidateFileChunk(pbChunk); err != nil {
+		logger.Error("invalid file chunk", zap.Error(err))
+		return err
+	}
+
+	chunk := &FileChunk{
+		FileChunk: pbChunk,
+		SigPubKey: state.CurrentMessageState.PublicKey,
+	}
+
+	if _, err := m.matchChatEntity(chunk); err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	hash := types.EncodeHex(chunk.Hash)
+	available, err := m.persistence.HasFile(hash)
+	if err != nil {
+		return err
+	}
+	if available {
+		return nil
+	}
+
+	messages, err := m.persistence.MessagesByFileHash(hash)
+	if err != nil {
+		return err
+	}
+
+	// The chunks have to come from the author of the file, if the message
+	// hasn't been received yet they are kept apart from the chunks of other
+	// senders until they can be checked against the hash
+	source := contactIDFromPublicKey(chunk.SigPubKey)
+	if !fileChunkMatches(append(messages, state.Response.Messages()...), source, chunk) {
+		return ErrFileChunkMismatch
+	}
+
+	err = m.persistence.SaveFileChunk(hash, source, chunk.Index, chunk.ChunksCount, chunk.Payload, m.getTimesource().GetCurrentTime())
+	if err != nil {
+		return err
+	}
+
+	count, err := m.persistence.FileChunksCount(hash, source, chunk.ChunksCount)
+	if err != nil {
+		return err
+	}
+	if count < chunk.ChunksCount {
+		return nil
+	}
+
+	chunks, err := m.persistence.FileChunks(hash, source, chunk.ChunksCount)
+	if err != nil {
+		return err
+	}
+
+	payload := bytes.Join(chunks, nil)
+	sum := sha256.Sum256(payload)
+	if !bytes.Equal(sum[:], chunk.Hash) {
+		// The chunks of this sender don't make up the file, there is no
+		// point in keeping them
+		if err := m.persistence.DeleteFileChunks(hash, source); err != nil {
+			return err
+		}
+		return ErrFileHashMismatch
+	}
+
+	err = m.persistence.SaveFile(hash, payload)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		state.Response.AddMessage(message)
+	}
+
+	// The messages received in the same batch haven't been saved yet
+	for _, message := range state.Response.Messages() {
+		if file := message.GetFile(); file != nil && bytes.Equal(file.Hash, chunk.Hash) {
+			message.FileAvailable = true
+		}
+	}
+
+	return nil
+}
+
+// fileChunkMatches returns whether the chunk could belong to the file sent
+// with any of the messages, that is whether one of them has been sent by the
+// sender of the chunk with the same number of chunks. It returns true if
+// none of the messages carries the file, as it might not have been received
+// yet
+func fileChunkMatches(messages []*common.Message, source string, chunk *FileChunk) bool {
+	found := false
+	for _, message := range messages {
+		file := message.GetFile()
+		if file == nil || !bytes.Equal(file.Hash, chunk.Hash) {
+			continue
+		}
+		if message.From == source && file.ChunksCount == chunk.ChunksCount {
+			return true
+		}
+		found = true
+	}
+	return !found
+}
+
+// pruneFileChunks removes the chunks of files no message has been received
+// for within fileChunksTTL, so that senders can't fill the database with
+// chunks of files that are never sent
+func (m *Messenger) pruneFileChunks(now uint64) {
+	pruned, err := m.persistence.DeleteUnclaimedFileChunks(now - uint64(fileChunksTTL.Milliseconds()))
+	if err != nil {
+		m.logger.Error("failed to prune file chunks", zap.Error(err))
+		return
+	}
+	m.logger.Debug("pruned file chunks", zap.Int64("chunks", pruned))
+}
+
+// watchFileChunks regularly removes the unclaimed file chunks
+func (m *Messenger) watchFileChunks() {
+	m.logger.Debug("watching file chunks")
+	go func() {
+		ticker := time.NewTicker(fileChunksPruneInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.pruneFileChunks(m.getTimesource().GetCurrentTime())
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,266 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerFilesSuite(t *testing.T) {
+	suite.Run(t, new(MessengerFilesSuite))
+}
+
+type MessengerFilesSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerFilesSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	s.m = s.newMessenger()
+	s.privateKey = s.m.identity
+	_, err := s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerFilesSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerFilesSuite) newMessenger() *Messenger {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	messenger, err := newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	return messenger
+}
+
+func (s *MessengerFilesSuite) TestSendFile() {
+	theirMessenger := s.newMessenger()
+	_, err := theirMessenger.Start()
+	s.Require().NoError(err)
+	defer theirMessenger.Shutdown() // nolint: errcheck
+
+	dir, err := ioutil.TempDir("", "files")
+	s.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	// Large enough to be sent in multiple chunks
+	content := make([]byte, 2*fileChunkSize+1024)
+	_, err = rand.Read(content)
+	s.Require().NoError(err)
+	path := filepath.Join(dir, "report.pdf")
+	s.Require().NoError(ioutil.WriteFile(path, content, 0600))
+
+	theirChat := CreateOneToOneChat("Their 1TO1", &s.privateKey.PublicKey, s.m.transport)
+	s.Require().NoError(theirMessenger.SaveChat(theirChat))
+
+	inputMessage := buildTestMessage(*theirChat)
+	inputMessage.FilePath = path
+	sendResponse, err := theirMessenger.SendChatMessage(context.Background(), inputMessage)
+	s.Require().NoError(err)
+	s.Require().Len(sendResponse.Messages(), 1)
+
+	sentMessage := sendResponse.Messages()[0]
+	s.Require().Equal(protobuf.ChatMessage_FILE, sentMessage.ContentType)
+	s.Require().True(sentMessage.FileAvailable)
+
+	file := sentMessage.GetFile()
+	s.Require().NotNil(file)
+	s.Require().Equal("report.pdf", file.Name)
+	s.Require().Equal("application/pdf", file.MimeType)
+	s.Require().Equal(uint64(len(content)), file.Size)
+	s.Require().Equal(uint32(3), file.ChunksCount)
+	hash := sha256.Sum256(content)
+	s.Require().Equal(hash[:], file.Hash)
+
+	// Wait until the file has been reassembled
+	_, err = WaitOnMessengerResponse(
+		s.m,
+		func(r *MessengerResponse) bool {
+			for _, message := range r.Messages() {
+				if message.ID == sentMessage.ID && message.FileAvailable {
+					return true
+				}
+			}
+			return false
+		},
+		"file not received",
+	)
+	s.Require().NoError(err)
+
+	message, err := s.m.MessageByID(sentMessage.ID)
+	s.Require().NoError(err)
+	s.Require().True(message.FileAvailable)
+	s.Require().Equal(file.Hash, message.GetFile().Hash)
+
+	chunksCount, err := s.m.persistence.FileChunksCount(types.EncodeHex(file.Hash), sentMessage.From, file.ChunksCount)
+	s.Require().NoError(err)
+	s.Require().Zero(chunksCount)
+}
+
+func (s *MessengerFilesSuite) TestForgedFileChunk() {
+	authorKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	forgerKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	content := make([]byte, fileChunkSize+1024)
+	_, err = rand.Read(content)
+	s.Require().NoError(err)
+	hash := sha256.Sum256(content)
+
+	handleChunk := func(key *ecdsa.PrivateKey, index uint32, chunksCount uint32, payload []byte) (*MessengerResponse, error) {
+		state := &ReceivedMessageState{
+			Response:            &MessengerResponse{},
+			CurrentMessageState: &CurrentMessageState{PublicKey: &key.PublicKey},
+		}
+		err := s.m.HandleFileChunk(state, protobuf.FileChunk{
+			Hash:        hash[:],
+			Index:       index,
+			ChunksCount: chunksCount,
+			Payload:     payload,
+			ChatId:      contactIDFromPublicKey(&s.privateKey.PublicKey),
+			MessageType: protobuf.MessageType_ONE_TO_ONE,
+		})
+		return state.Response, err
+	}
+
+	// Chunks received before the message are kept apart for each sender
+	forged := make([]byte, fileChunkSize)
+	_, err = handleChunk(forgerKey, 0, 2, forged)
+	s.Require().NoError(err)
+
+	authorChatID := types.EncodeHex(crypto.FromECDSAPub(&authorKey.PublicKey))
+	message := &common.Message{
+		ID:          "file-message",
+		LocalChatID: authorChatID,
+		From:        authorChatID,
+		ChatMessage: protobuf.ChatMessage{
+			ChatId:      authorChatID,
+			ContentType: protobuf.ChatMessage_FILE,
+			MessageType: protobuf.MessageType_ONE_TO_ONE,
+			Payload: &protobuf.ChatMessage_File{
+				File: &protobuf.FileMessage{
+					Name:        "report.pdf",
+					MimeType:    "application/pdf",
+					Size:        uint64(len(content)),
+					Hash:        hash[:],
+					ChunksCount: 2,
+				},
+			},
+		},
+	}
+	s.Require().NoError(s.m.persistence.SaveMessages([]*common.Message{message}))
+
+	// Once the message is known, chunks from anyone else are dropped
+	_, err = handleChunk(forgerKey, 1, 2, forged[:1024])
+	s.Require().Equal(ErrFileChunkMismatch, err)
+
+	// And so are chunks from the author with a different number of chunks
+	_, err = handleChunk(authorKey, 0, 3, content[:fileChunkSize])
+	s.Require().Equal(ErrFileChunkMismatch, err)
+
+	_, err = handleChunk(authorKey, 0, 2, content[:fileChunkSize])
+	s.Require().NoError(err)
+	response, err := handleChunk(authorKey, 1, 2, content[fileChunkSize:])
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().True(response.Messages()[0].FileAvailable)
+
+	available, err := s.m.persistence.HasFile(types.EncodeHex(hash[:]))
+	s.Require().NoError(err)
+	s.Require().True(available)
+
+	stored, err := s.m.MessageByID(message.ID)
+	s.Require().NoError(err)
+	s.Require().True(stored.FileAvailable)
+}
+
+func (s *MessengerFilesSuite) TestUnclaimedFileChunksPruned() {
+	senderKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	source := contactIDFromPublicKey(&senderKey.PublicKey)
+
+	handleChunk := func(hash []byte) {
+		state := &ReceivedMessageState{
+			Response:            &MessengerResponse{},
+			CurrentMessageState: &CurrentMessageState{PublicKey: &senderKey.PublicKey},
+		}
+		s.Require().NoError(s.m.HandleFileChunk(state, protobuf.FileChunk{
+			Hash:        hash,
+			Index:       0,
+			ChunksCount: 2,
+			Payload:     make([]byte, fileChunkSize),
+			ChatId:      contactIDFromPublicKey(&s.privateKey.PublicKey),
+			MessageType: protobuf.MessageType_ONE_TO_ONE,
+		}))
+	}
+
+	// Chunks of a file no message has been sent with
+	unclaimed := sha256.Sum256([]byte("unclaimed"))
+	handleChunk(unclaimed[:])
+
+	// Chunks of a file whose message has been received
+	claimed := sha256.Sum256([]byte("claimed"))
+	handleChunk(claimed[:])
+	s.Require().NoError(s.m.persistence.SaveMessages([]*common.Message{{
+		ID:          "file-message",
+		LocalChatID: source,
+		From:        source,
+		ChatMessage: protobuf.ChatMessage{
+			ChatId:      source,
+			ContentType: protobuf.ChatMessage_FILE,
+			MessageType: protobuf.MessageType_ONE_TO_ONE,
+			Payload: &protobuf.ChatMessage_File{
+				File: &protobuf.FileMessage{Name: "report.pdf", Hash: claimed[:], ChunksCount: 2},
+			},
+		},
+	}}))
+
+	count := func(hash []byte) uint32 {
+		count, err := s.m.persistence.FileChunksCount(types.EncodeHex(hash), source, 2)
+		s.Require().NoError(err)
+		return count
+	}
+
+	// The chunks are kept for a while as the message might not have arrived yet
+	now := s.m.getTimesource().GetCurrentTime()
+	s.m.pruneFileChunks(now)
+	s.Require().Equal(uint32(1), count(unclaimed[:]))
+
+	s.m.pruneFileChunks(now + uint64(fileChunksTTL.Milliseconds()) + 1000)
+	s.Require().Equal(uint32(0), count(unclaimed[:]))
+	s.Require().Equal(uint32(1), count(claimed[:]))
+}
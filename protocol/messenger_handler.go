@@ -302,6 +302,99 @@ func (m *Messenger) HandleBackup(state *ReceivedMessageState, message protobuf.B
 			return err
 		}
 	}
+	for _, draft := range message.Drafts {
+		err := m.HandleSyncChatDraft(state, *draft)
+		if err != nil {
+			return err
+		}
+	}
+	for _, publicChat := range message.PublicChats {
+		addedChat := m.HandleSyncInstallationPublicChat(state, *publicChat)
+		if addedChat != nil {
+			_, err := m.createPublicChat(addedChat.ID, state.Response)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, community := range message.Communities {
+		err := m.handleSyncCommunity(state, *community)
+		if err != nil {
+			return err
+		}
+	}
+	for _, bookmark := range message.Bookmarks {
+		err := m.handleSyncBookmark(state, *bookmark)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(message.Settings) != 0 {
+		err := m.handleBackupSettings(message)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, chatID := range message.MutedChats {
+		chat, ok := state.AllChats.Load(chatID)
+		if !ok || chat.Muted {
+			continue
+		}
+		err := m.muteChat(chat, nil)
+		if err != nil {
+			return err
+		}
+		state.Response.AddChat(chat)
+	}
+
+	if message.ActivityCenterRead != nil && len(message.ActivityCenterRead.Ids) != 0 {
+		err := m.handleActivityCenterRead(state, *message.ActivityCenterRead)
+		if err != nil {
+			return err
+		}
+	}
+	if message.ActivityCenterAccepted != nil && len(message.ActivityCenterAccepted.Ids) != 0 {
+		err := m.handleActivityCenterAccepted(state, *message.ActivityCenterAccepted)
+		if err != nil {
+			return err
+		}
+	}
+	if message.ActivityCenterDismissed != nil && len(message.ActivityCenterDismissed.Ids) != 0 {
+		err := m.handleActivityCenterDismissed(state, *message.ActivityCenterDismissed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleBackupSettings restores the settings of a backup, unless we made a
+// more recent backup ourselves
+func (m *Messenger) handleBackupSettings(message protobuf.Backup) error {
+	lastBackup, err := m.lastBackup()
+	if err != nil {
+		return err
+	}
+
+	if message.Clock <= lastBackup {
+		return nil
+	}
+
+	for _, setting := range message.Settings {
+		err := m.settings.SaveBackupSetting(setting.Name, setting.Value)
+		// Settings added by later versions are unknown to us
+		if err == accounts.ErrInvalidConfig {
+			m.logger.Debug("ignoring unknown backup setting", zap.String("name", setting.Name))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -644,6 +737,11 @@ func (m *Messenger) HandleCommunityRequestToJoin(state *ReceivedMessageState, si
 		return err
 	}
 
+	// The request is accepted or declined once the token requirements are checked
+	if m.communitiesManager.ChecksTokenRequirements(community, &requestToJoinProto) {
+		return nil
+	}
+
 	contactID := contactIDFromPublicKey(signer)
 
 	contact, _ := state.AllContacts.Load(contactID)
@@ -653,6 +751,29 @@ func (m *Messenger) HandleCommunityRequestToJoin(state *ReceivedMessageState, si
 	return nil
 }
 
+// HandleCommunityMemberAction handles a kick or ban requested by a moderator of a community we own
+func (m *Messenger) HandleCommunityMemberAction(state *ReceivedMessageState, signer *ecdsa.PublicKey, action protobuf.CommunityMemberAction) error {
+	if action.CommunityId == nil {
+		return errors.New("invalid community id")
+	}
+
+	community, err := m.communitiesManager.HandleCommunityMemberAction(signer, &action)
+	if err != nil {
+		return err
+	}
+
+	if action.Type == protobuf.CommunityMemberAction_BAN {
+		_, err = m.DeclineAllPendingGroupInvitesFromUser(state.Response, action.MemberId)
+		if err != nil {
+			return err
+		}
+	}
+
+	state.Response.AddCommunity(community)
+
+	return nil
+}
+
 // handleWrappedCommunityDescriptionMessage handles a wrapped community description
 func (m *Messenger) handleWrappedCommunityDescriptionMessage(payload []byte) (*communities.CommunityResponse, error) {
 	return m.communitiesManager.HandleWrappedCommunityDescriptionMessage(payload)
@@ -741,15 +862,19 @@ func (m *Messenger) HandleDeleteMessage(state *ReceivedMessageState, deleteMessa
 		return errors.New("chat not found")
 	}
 
-	// Check edit is valid
-	if originalMessage.From != deleteMessage.From {
+	// Check delete is valid
+	canDelete, err := m.canDeleteMessage(deleteMessage.From, originalMessage, chat)
+	if err != nil {
+		return err
+	}
+	if !canDelete {
 		return errors.New("invalid delete, not the right author")
 	}
 
 	// Update message and return it
 	originalMessage.Deleted = true
 
-	err := m.persistence.SaveMessages([]*common.Message{originalMessage})
+	err = m.persistence.SaveMessages([]*common.Message{originalMessage})
 	if err != nil {
 		return err
 	}
@@ -845,6 +970,16 @@ func (m *Messenger) HandleChatMessage(state *ReceivedMessageState) error {
 			return errors.New("images are not allowed in public chats")
 		case protobuf.ChatMessage_AUDIO:
 			return errors.New("audio messages are not allowed in public chats")
+		case protobuf.ChatMessage_FILE:
+			return errors.New("files are not allowed in public chats")
+		}
+	}
+
+	// The content of the file might have been received before the message
+	if file := receivedMessage.GetFile(); file != nil {
+		receivedMessage.FileAvailable, err = m.persistence.HasFile(types.EncodeHex(file.Hash))
+		if err != nil {
+			return err
 		}
 	}
 
@@ -868,6 +1003,28 @@ func (m *Messenger) HandleChatMessage(state *ReceivedMessageState) error {
 	// Set the LocalChatID for the message
 	receivedMessage.LocalChatID = chat.ID
 
+	err = m.checkReceivedThread(state, receivedMessage)
+	if err != nil {
+		return err
+	}
+
+	err = m.checkPendingThreadReplies(state, receivedMessage)
+	if err != nil {
+		return err
+	}
+
+	// Messages sent before the author knew about the timer of the chat
+	// expire according to the local one
+	if receivedMessage.ExpiresAt == 0 && chat.MessagesTTL != 0 {
+		receivedMessage.ExpiresAt = receivedMessage.Timestamp + chat.MessagesTTL*1000
+	}
+
+	// If the message has already expired, ignore it, this happens when
+	// messages are received late from the mailservers
+	if receivedMessage.ExpiresAt != 0 && receivedMessage.ExpiresAt <= m.getTimesource().GetCurrentTime() {
+		return nil
+	}
+
 	// Increase unviewed count
 	if !common.IsPubKeyEqual(receivedMessage.SigPubKey, &m.identity.PublicKey) {
 		if !receivedMessage.Seen {
@@ -1240,9 +1397,9 @@ func (m *Messenger) matchChatEntity(chatEntity common.ChatEntity) (*Chat, error)
 		}
 
 		var emojiReaction bool
-		// We allow emoji reactions from anyone
+		// We allow emoji reactions and poll votes from anyone
 		switch chatEntity.(type) {
-		case *EmojiReaction:
+		case *EmojiReaction, *PollVote:
 			emojiReaction = true
 		}
 
@@ -1362,6 +1519,86 @@ func (m *Messenger) HandleEmojiReaction(state *ReceivedMessageState, pbEmojiR pr
 	return nil
 }
 
+func (m *Messenger) HandlePollVote(state *ReceivedMessageState, pbVote protobuf.PollVote) error {
+	logger := m.logger.With(zap.String("site", "HandlePollVote"))
+	if err := ValidatePollVote(pbVote, state.Timesource.GetCurrentTime()); err != nil {
+		logger.Error("invalid poll vote", zap.Error(err))
+		return err
+	}
+
+	vote := &PollVote{
+		PollVote:  pbVote,
+		From:      state.CurrentMessageState.Contact.ID,
+		SigPubKey: state.CurrentMessageState.PublicKey,
+	}
+
+	chat, err := m.matchChatEntity(vote)
+	if err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	vote.LocalChatID = chat.ID
+
+	if chat.LastClockValue < pbVote.Clock {
+		chat.LastClockValue = pbVote.Clock
+	}
+
+	state.Response.AddChat(chat)
+	state.AllChats.Store(chat.ID, chat)
+
+	// Votes are saved even if the poll hasn't been received yet, and are
+	// checked against it when tallying
+	err = m.persistence.SavePollVote(vote)
+	if err != nil {
+		return err
+	}
+
+	state.Response.AddPollVote(vote)
+
+	return nil
+}
+
+func (m *Messenger) HandlePollClose(state *ReceivedMessageState, pbPollClose protobuf.PollClose) error {
+	if err := ValidatePollClose(pbPollClose); err != nil {
+		return err
+	}
+
+	pollClose := &PollClose{
+		PollClose: pbPollClose,
+		From:      state.CurrentMessageState.Contact.ID,
+		SigPubKey: state.CurrentMessageState.PublicKey,
+	}
+
+	chat, err := m.matchChatEntity(pollClose)
+	if err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	// The author is checked against the poll when it's loaded, so that the
+	// close is applied even if the poll is received later
+	err = m.persistence.SavePollClose(pollClose)
+	if err != nil {
+		return err
+	}
+
+	poll, err := m.persistence.MessageByID(pollClose.MessageId)
+	if err == common.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if poll.From != pollClose.From {
+		return errors.New("invalid poll close, not the right author")
+	}
+
+	state.Response.AddMessage(poll)
+	state.Response.AddChat(chat)
+
+	return nil
+}
+
 func (m *Messenger) HandleGroupChatInvitation(state *ReceivedMessageState, pbGHInvitations protobuf.GroupChatInvitation) error {
 	allowed, err := m.isMessageAllowedFrom(state.CurrentMessageState.Contact.ID, nil)
 	if err != nil {
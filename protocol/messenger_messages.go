@@ -90,7 +90,18 @@ func (m *Messenger) DeleteMessageAndSend(ctx context.Context, messageID string)
 	}
 
 	if message.From != common.PubkeyToHex(&m.identity.PublicKey) {
-		return nil, ErrInvalidEditOrDeleteAuthor
+		localChat, ok := m.allChats.Load(message.LocalChatID)
+		if !ok {
+			return nil, ErrInvalidEditOrDeleteAuthor
+		}
+
+		canDelete, err := m.canDeleteMessage(common.PubkeyToHex(&m.identity.PublicKey), message, localChat)
+		if err != nil {
+			return nil, err
+		}
+		if !canDelete {
+			return nil, ErrInvalidEditOrDeleteAuthor
+		}
 	}
 
 	// A valid added chat is required.
@@ -104,7 +115,8 @@ func (m *Messenger) DeleteMessageAndSend(ctx context.Context, messageID string)
 		message.ContentType != protobuf.ChatMessage_STICKER &&
 		message.ContentType != protobuf.ChatMessage_EMOJI &&
 		message.ContentType != protobuf.ChatMessage_IMAGE &&
-		message.ContentType != protobuf.ChatMessage_AUDIO {
+		message.ContentType != protobuf.ChatMessage_AUDIO &&
+		message.ContentType != protobuf.ChatMessage_POLL {
 		return nil, ErrInvalidDeleteTypeAuthor
 	}
 
@@ -190,7 +202,18 @@ func (m *Messenger) applyEditMessage(editMessage *protobuf.EditMessage, message
 
 func (m *Messenger) applyDeleteMessage(messageDeletes []*DeleteMessage, message *common.Message) error {
 	if messageDeletes[0].From != message.From {
-		return ErrInvalidEditOrDeleteAuthor
+		chat, ok := m.allChats.Load(message.LocalChatID)
+		if !ok {
+			return ErrInvalidEditOrDeleteAuthor
+		}
+
+		canDelete, err := m.canDeleteMessage(messageDeletes[0].From, message, chat)
+		if err != nil {
+			return err
+		}
+		if !canDelete {
+			return ErrInvalidEditOrDeleteAuthor
+		}
 	}
 
 	message.Deleted = true
@@ -207,3 +230,22 @@ func (m *Messenger) applyDeleteMessage(messageDeletes []*DeleteMessage, message
 
 	return m.persistence.HideMessage(message.ID)
 }
+
+// canDeleteMessage returns whether the message can be deleted by from, which
+// is either the author or a member allowed to delete messages in the community chat
+func (m *Messenger) canDeleteMessage(from string, message *common.Message, chat *Chat) (bool, error) {
+	if message.From == from {
+		return true, nil
+	}
+
+	if !chat.CommunityChat() {
+		return false, nil
+	}
+
+	publicKey, err := common.HexToPubkey(from)
+	if err != nil {
+		return false, err
+	}
+
+	return m.communitiesManager.HasPermission(publicKey, chat.CommunityID, chat.CommunityChatID(), protobuf.CommunityRole_DELETE_MESSAGES)
+}
@@ -0,0 +1,154 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+const (
+	// outboxMinBackoff is the delay before resending a message that
+	// hasn't been acked, it's doubled at each attempt
+	outboxMinBackoff = 30 * time.Second
+	outboxMaxBackoff = time.Hour
+	// outboxMaxAttempts is the number of times a message is sent before
+	// it's marked as failed
+	outboxMaxAttempts = 8
+	// outboxInterval is how often we check for messages to resend
+	outboxInterval = time.Second
+	// outboxRetention is how long the entries of the messages that are not
+	// resent anymore are kept, so that the client can still show their state
+	outboxRetention     = 7 * 24 * time.Hour
+	outboxPruneInterval = time.Hour
+)
+
+// OutboxEntries returns the delivery state of the messages of a chat that
+// haven't been delivered yet
+func (m *Messenger) OutboxEntries(chatID string) ([]*common.OutboxEntry, error) {
+	return m.outbox.Entries(chatID)
+}
+
+// outboxSent records that a message has been sent
+func (m *Messenger) outboxSent(message *common.RawMessage) {
+	if !message.ResendAutomatically && m.resendInPublicChat(message) {
+		resent := *message
+		resent.ResendAutomatically = true
+		message = &resent
+	}
+
+	entry, err := m.outbox.Sent(message)
+	if err != nil {
+		m.logger.Error("failed to save outbox entry", zap.String("id", message.ID), zap.Error(err))
+		return
+	}
+	if entry != nil {
+		m.outboxStateChanged(entry)
+	}
+}
+
+// resendInPublicChat returns whether a message is resent until acked
+// because it has no other way to reach its recipients, which is the case of
+// messages and emoji reactions in public and community chats
+func (m *Messenger) resendInPublicChat(message *common.RawMessage) bool {
+	if message.MessageType != protobuf.ApplicationMetadataMessage_CHAT_MESSAGE &&
+		message.MessageType != protobuf.ApplicationMetadataMessage_EMOJI_REACTION {
+		return false
+	}
+	chat, ok := m.allChats.Load(message.LocalChatID)
+	return ok && (chat.Public() || chat.CommunityChat())
+}
+
+// outboxStateChanged notifies the client of the changes of the outbox
+func (m *Messenger) outboxStateChanged(entries ...*common.OutboxEntry) {
+	if len(entries) == 0 || m.config.messengerSignalsHandler == nil {
+		return
+	}
+	m.config.messengerSignalsHandler.OutboxStateChanged(entries)
+}
+
+// processExpiredMessages queues for a resend the messages whose envelopes
+// expired before reaching any peer
+func (m *Messenger) processExpiredMessages(ids []string) {
+	entries, err := m.outbox.Expired(ids)
+	if err != nil {
+		m.logger.Error("failed to process expired messages", zap.Error(err))
+		return
+	}
+	m.outboxStateChanged(entries...)
+}
+
+// resendOutbox resends the messages whose backoff elapsed without being
+// acked, including the ones that were pending when the node stopped
+func (m *Messenger) resendOutbox() {
+	m.outboxMutex.Lock()
+	defer m.outboxMutex.Unlock()
+
+	due, failed, err := m.outbox.Due()
+	if err != nil {
+		m.logger.Error("failed to get outbox entries to resend", zap.Error(err))
+		return
+	}
+	m.outboxStateChanged(failed...)
+
+	for _, entry := range due {
+		err := m.reSendRawMessage(context.Background(), entry.ID)
+		if err == nil {
+			continue
+		}
+
+		m.logger.Debug("failed to resend message", zap.String("id", entry.ID), zap.Error(err))
+		failedEntry, err := m.outbox.AttemptFailed(entry.ID, err)
+		if err != nil {
+			m.logger.Error("failed to update outbox entry", zap.String("id", entry.ID), zap.Error(err))
+			continue
+		}
+		m.outboxStateChanged(failedEntry)
+	}
+}
+
+// retryOutbox resends right away the queued messages, as they most likely
+// failed because we were offline
+func (m *Messenger) retryOutbox() {
+	err := m.outbox.Retry()
+	if err != nil {
+		m.logger.Error("failed to retry outbox", zap.Error(err))
+	}
+}
+
+// pruneOutbox removes the entries that are past the retention period
+func (m *Messenger) pruneOutbox() {
+	pruned, err := m.outbox.Prune(outboxRetention)
+	if err != nil {
+		m.logger.Error("failed to prune outbox", zap.Error(err))
+		return
+	}
+	m.logger.Debug("pruned outbox", zap.Int64("entries", pruned))
+}
+
+// watchOutbox regularly resends the messages of the outbox that are due and
+// prunes the old entries
+func (m *Messenger) watchOutbox() {
+	m.logger.Debug("watching outbox")
+	go func() {
+		m.pruneOutbox()
+		pruneTicker := time.NewTicker(outboxPruneInterval)
+		defer pruneTicker.Stop()
+
+		for {
+			select {
+			case <-time.After(outboxInterval):
+				if m.online() {
+					m.resendOutbox()
+				}
+			case <-pruneTicker.C:
+				m.pruneOutbox()
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,204 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+)
+
+var ErrNotAPoll = errors.New("message is not a poll")
+var ErrPollClosed = errors.New("poll is closed")
+var ErrInvalidPollVote = errors.New("invalid poll vote")
+
+// CreatePoll sends a poll to the chat, the question is sent as the text of the message
+func (m *Messenger) CreatePoll(ctx context.Context, request *requests.CreatePoll) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	message := &common.Message{}
+	message.ChatId = request.ChatID
+	message.Text = request.Question
+	message.ContentType = protobuf.ChatMessage_POLL
+	message.Payload = &protobuf.ChatMessage_Poll{
+		Poll: &protobuf.PollMessage{
+			Options:        request.Options,
+			ExpiresAt:      request.ExpiresAt,
+			MultipleChoice: request.MultipleChoice,
+		},
+	}
+
+	return m.sendChatMessage(ctx, message)
+}
+
+// SendPollVote votes the options of a poll, replacing any previous vote
+func (m *Messenger) SendPollVote(ctx context.Context, request *requests.SendPollVote) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m.sendPollVote(ctx, request.ID.String(), request.Options, false)
+}
+
+// RetractPollVote removes the vote of the user from a poll
+func (m *Messenger) RetractPollVote(ctx context.Context, messageID string) (*MessengerResponse, error) {
+	return m.sendPollVote(ctx, messageID, nil, true)
+}
+
+func (m *Messenger) sendPollVote(ctx context.Context, messageID string, options []uint32, retracted bool) (*MessengerResponse, error) {
+	poll, err := m.pollByID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.pollClosed(poll) {
+		return nil, ErrPollClosed
+	}
+
+	chat, ok := m.allChats.Load(poll.LocalChatID)
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
+
+	vote := &PollVote{
+		PollVote: protobuf.PollVote{
+			Clock:     clock,
+			ChatId:    chat.ID,
+			MessageId: poll.ID,
+			Options:   options,
+			Retracted: retracted,
+		},
+		LocalChatID: chat.ID,
+		From:        common.PubkeyToHex(&m.identity.PublicKey),
+	}
+
+	if !retracted && !validPollVote(poll, vote) {
+		return nil, ErrInvalidPollVote
+	}
+
+	encodedMessage, err := m.encodeChatEntity(chat, vote)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:          chat.ID,
+		Payload:              encodedMessage,
+		SkipGroupMessageWrap: true,
+		MessageType:          protobuf.ApplicationMetadataMessage_POLL_VOTE,
+		ResendAutomatically:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.SavePollVote(vote)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddPollVote(vote)
+	response.AddChat(chat)
+
+	return response, nil
+}
+
+// ClosePoll stops accepting votes on a poll, only the author of the poll can close it
+func (m *Messenger) ClosePoll(ctx context.Context, messageID string) (*MessengerResponse, error) {
+	poll, err := m.pollByID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.From != common.PubkeyToHex(&m.identity.PublicKey) {
+		return nil, ErrInvalidEditOrDeleteAuthor
+	}
+
+	if poll.PollClosedAt != 0 {
+		return nil, ErrPollClosed
+	}
+
+	chat, ok := m.allChats.Load(poll.LocalChatID)
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
+
+	pollClose := &PollClose{
+		PollClose: protobuf.PollClose{
+			Clock:     clock,
+			ChatId:    chat.ID,
+			MessageId: poll.ID,
+		},
+		From: poll.From,
+	}
+
+	encodedMessage, err := m.encodeChatEntity(chat, pollClose)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:          chat.ID,
+		Payload:              encodedMessage,
+		SkipGroupMessageWrap: true,
+		MessageType:          protobuf.ApplicationMetadataMessage_POLL_CLOSE,
+		ResendAutomatically:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.SavePollClose(pollClose)
+	if err != nil {
+		return nil, err
+	}
+
+	poll.PollClosedAt = clock
+
+	response := &MessengerResponse{}
+	response.AddMessage(poll)
+	response.AddChat(chat)
+
+	return response, nil
+}
+
+// PollResults returns the tally of the votes of a poll
+func (m *Messenger) PollResults(messageID string) (*PollResults, error) {
+	poll, err := m.pollByID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := m.persistence.PollVotesByMessageID(poll.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := tallyPollVotes(poll, votes, common.PubkeyToHex(&m.identity.PublicKey))
+	results.Closed = m.pollClosed(poll)
+	return results, nil
+}
+
+func (m *Messenger) pollByID(messageID string) (*common.Message, error) {
+	message, err := m.persistence.MessageByID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.ContentType != protobuf.ChatMessage_POLL || message.GetPoll() == nil {
+		return nil, ErrNotAPoll
+	}
+
+	return message, nil
+}
+
+func (m *Messenger) pollClosed(poll *common.Message) bool {
+	expiresAt := poll.GetPoll().ExpiresAt
+	return poll.PollClosedAt != 0 || (expiresAt != 0 && m.getTimesource().GetCurrentTime() > expiresAt)
+}
@@ -0,0 +1,218 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerPollsSuite(t *testing.T) {
+	suite.Run(t, new(MessengerPollsSuite))
+}
+
+type MessengerPollsSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerPollsSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	s.m = s.newMessenger()
+	s.privateKey = s.m.identity
+	_, err := s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerPollsSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerPollsSuite) newMessenger() *Messenger {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	messenger, err := newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	return messenger
+}
+
+func (s *MessengerPollsSuite) TestPoll() {
+	alice := s.m
+	bob := s.newMessenger()
+	_, err := bob.Start()
+	s.Require().NoError(err)
+	defer bob.Shutdown() // nolint: errcheck
+
+	chat := CreatePublicChat(statusChatID, alice.transport)
+
+	s.Require().NoError(alice.SaveChat(chat))
+	_, err = alice.Join(chat)
+	s.Require().NoError(err)
+
+	s.Require().NoError(bob.SaveChat(chat))
+	_, err = bob.Join(chat)
+	s.Require().NoError(err)
+
+	_, err = alice.CreatePoll(context.Background(), &requests.CreatePoll{
+		ChatID:   chat.ID,
+		Question: "Which day?",
+		Options:  []string{"Monday"},
+	})
+	s.Require().Equal(requests.ErrCreatePollInvalidOptions, err)
+
+	response, err := alice.CreatePoll(context.Background(), &requests.CreatePoll{
+		ChatID:   chat.ID,
+		Question: "Which day?",
+		Options:  []string{"Monday", "Tuesday"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	pollID := response.Messages()[0].ID
+	pollHexID, err := types.DecodeHex(pollID)
+	s.Require().NoError(err)
+
+	// Wait for the poll to arrive to bob
+	response, err = WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool { return len(r.Messages()) > 0 },
+		"no poll",
+	)
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().Equal(protobuf.ChatMessage_POLL, response.Messages()[0].ContentType)
+	s.Require().Equal([]string{"Monday", "Tuesday"}, response.Messages()[0].GetPoll().Options)
+
+	// Single choice polls only accept one option
+	_, err = bob.SendPollVote(context.Background(), &requests.SendPollVote{
+		ID:      pollHexID,
+		Options: []uint32{0, 1},
+	})
+	s.Require().Equal(ErrInvalidPollVote, err)
+
+	_, err = bob.SendPollVote(context.Background(), &requests.SendPollVote{
+		ID:      pollHexID,
+		Options: []uint32{1},
+	})
+	s.Require().NoError(err)
+
+	response, err = WaitOnMessengerResponse(
+		alice,
+		func(r *MessengerResponse) bool { return len(r.PollVotes()) > 0 },
+		"no vote",
+	)
+	s.Require().NoError(err)
+	s.Require().Len(response.PollVotes(), 1)
+
+	results, err := alice.PollResults(pollID)
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{0, 1}, results.Votes)
+	s.Require().Equal(uint64(1), results.Voters)
+	s.Require().False(results.Closed)
+
+	// Changing the vote replaces the previous one
+	_, err = bob.SendPollVote(context.Background(), &requests.SendPollVote{
+		ID:      pollHexID,
+		Options: []uint32{0},
+	})
+	s.Require().NoError(err)
+
+	_, err = WaitOnMessengerResponse(
+		alice,
+		func(r *MessengerResponse) bool { return len(r.PollVotes()) > 0 },
+		"no vote",
+	)
+	s.Require().NoError(err)
+
+	results, err = alice.PollResults(pollID)
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{1, 0}, results.Votes)
+	s.Require().Equal(uint64(1), results.Voters)
+
+	// Only the author can close the poll
+	_, err = bob.ClosePoll(context.Background(), pollID)
+	s.Require().Equal(ErrInvalidEditOrDeleteAuthor, err)
+
+	response, err = alice.ClosePoll(context.Background(), pollID)
+	s.Require().NoError(err)
+	s.Require().Len(response.Messages(), 1)
+	s.Require().NotZero(response.Messages()[0].PollClosedAt)
+
+	response, err = WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool { return len(r.Messages()) > 0 },
+		"no poll close",
+	)
+	s.Require().NoError(err)
+	s.Require().NotZero(response.Messages()[0].PollClosedAt)
+
+	_, err = bob.RetractPollVote(context.Background(), pollID)
+	s.Require().Equal(ErrPollClosed, err)
+
+	results, err = bob.PollResults(pollID)
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{1, 0}, results.Votes)
+	s.Require().Equal([]uint32{0}, results.OwnVote)
+	s.Require().True(results.Closed)
+}
+
+func TestTallyPollVotes(t *testing.T) {
+	poll := &common.Message{ID: "poll", PollClosedAt: 100}
+	poll.Payload = &protobuf.ChatMessage_Poll{
+		Poll: &protobuf.PollMessage{
+			Options:        []string{"a", "b", "c"},
+			MultipleChoice: true,
+		},
+	}
+
+	vote := func(from string, clock uint64, retracted bool, options ...uint32) *PollVote {
+		return &PollVote{
+			PollVote: protobuf.PollVote{Clock: clock, MessageId: "poll", Options: options, Retracted: retracted},
+			From:     from,
+		}
+	}
+
+	results := tallyPollVotes(poll, []*PollVote{
+		vote("alice", 10, false, 0, 2),
+		vote("bob", 20, false, 2),
+		// Retracted
+		vote("carol", 30, true),
+		// Sent after the poll was closed
+		vote("dave", 100, false, 1),
+		// Unknown option
+		vote("eve", 40, false, 3),
+		// Duplicated option
+		vote("frank", 50, false, 1, 1),
+	}, "bob")
+
+	require.Equal(t, "poll", results.MessageID)
+	require.Equal(t, []uint64{1, 0, 2}, results.Votes)
+	require.Equal(t, uint64(2), results.Voters)
+	require.Equal(t, []uint32{2}, results.OwnVote)
+}
@@ -0,0 +1,169 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+)
+
+var ErrChatPrivacySettingsNotAllowed = errors.New("read receipts and typing indicators are only allowed in one-to-one and group chats")
+
+func chatPrivacySettingsAllowed(chat *Chat) bool {
+	return chat.OneToOne() || chat.PrivateGroupChat()
+}
+
+// SetChatPrivacySettings overrides for a chat whether we send read receipts
+// and typing indicators
+func (m *Messenger) SetChatPrivacySettings(request *requests.SetChatPrivacySettings) (*MessengerResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	chat, ok := m.allChats.Load(request.ChatID)
+	if !ok {
+		return nil, ErrChatNotFound
+	}
+
+	if !chatPrivacySettingsAllowed(chat) {
+		return nil, ErrChatPrivacySettingsNotAllowed
+	}
+
+	chat.ReadReceipts = ChatPrivacySetting(request.ReadReceipts)
+	chat.TypingIndicators = ChatPrivacySetting(request.TypingIndicators)
+
+	err := m.saveChat(chat)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	response.AddChat(chat)
+
+	return response, nil
+}
+
+// readReceiptsEnabled returns whether we send read receipts in a chat
+func (m *Messenger) readReceiptsEnabled(chat *Chat) bool {
+	if !chatPrivacySettingsAllowed(chat) {
+		return false
+	}
+
+	enabled, err := m.settings.SendReadReceipts()
+	if err != nil {
+		m.logger.Error("failed to get read receipts setting", zap.Error(err))
+		return false
+	}
+
+	return chat.ReadReceipts.Enabled(enabled)
+}
+
+// sendReadReceipts lets the authors of the given messages know we read them,
+// each author only learns about their own messages
+func (m *Messenger) sendReadReceipts(ctx context.Context, chat *Chat, messageIDsByAuthor map[string][]string) {
+	ourID := contactIDFromPublicKey(&m.identity.PublicKey)
+	for author, messageIDs := range messageIDsByAuthor {
+		if author == ourID {
+			continue
+		}
+
+		err := m.sendReadReceipt(ctx, chat, author, messageIDs)
+		if err != nil {
+			m.logger.Warn("failed to send read receipt", zap.String("chatID", chat.ID), zap.Error(err))
+		}
+	}
+}
+
+func (m *Messenger) sendReadReceipt(ctx context.Context, chat *Chat, author string, messageIDs []string) error {
+	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
+
+	receipt := &ReadReceipt{
+		ReadReceipt: protobuf.ReadReceipt{
+			Clock:      clock,
+			ChatId:     chat.ID,
+			MessageIds: messageIDs,
+		},
+	}
+
+	encodedMessage, err := m.encodeChatEntity(chat, receipt)
+	if err != nil {
+		return err
+	}
+
+	rawMessage := common.RawMessage{
+		LocalChatID:          chat.ID,
+		Payload:              encodedMessage,
+		SkipGroupMessageWrap: true,
+		MessageType:          protobuf.ApplicationMetadataMessage_READ_RECEIPT,
+		ResendAutomatically:  true,
+	}
+
+	if chat.PrivateGroupChat() {
+		publicKey, err := common.HexToPubkey(author)
+		if err != nil {
+			return err
+		}
+		rawMessage.Recipients = []*ecdsa.PublicKey{publicKey}
+	}
+
+	_, err = m.dispatchMessage(ctx, rawMessage)
+	return err
+}
+
+// HandleReadReceipt marks as read the messages we sent that a recipient read.
+// In group chats a message is read as soon as one of the members read it
+func (m *Messenger) HandleReadReceipt(state *ReceivedMessageState, pbReceipt protobuf.ReadReceipt) error {
+	logger := m.logger.With(zap.String("site", "HandleReadReceipt"))
+	if err := ValidateReadReceipt(pbReceipt, state.CurrentMessageState.WhisperTimestamp); err != nil {
+		logger.Error("invalid read receipt", zap.Error(err))
+		return err
+	}
+
+	receipt := &ReadReceipt{
+		ReadReceipt: pbReceipt,
+		SigPubKey:   state.CurrentMessageState.PublicKey,
+	}
+
+	// Our other devices sync what we read through SyncChatMessagesRead
+	if common.IsPubKeyEqual(receipt.SigPubKey, &m.identity.PublicKey) {
+		return nil
+	}
+
+	chat, err := m.matchChatEntity(receipt)
+	if err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	if _, ok := m.allChats.Load(chat.ID); !ok {
+		return nil
+	}
+
+	messages, err := m.persistence.MessagesByIDs(receipt.MessageIds)
+	if err != nil {
+		return err
+	}
+
+	ourID := contactIDFromPublicKey(&m.identity.PublicKey)
+	reader := contactIDFromPublicKey(receipt.SigPubKey)
+	for _, message := range messages {
+		if message.LocalChatID != chat.ID || message.From != ourID || message.OutgoingStatus == common.OutgoingStatusRead {
+			continue
+		}
+
+		err = m.persistence.UpdateMessageOutgoingStatus(message.ID, common.OutgoingStatusRead)
+		if err != nil {
+			return err
+		}
+
+		if m.config.messengerSignalsHandler != nil {
+			m.config.messengerSignalsHandler.MessageRead(chat.ID, message.ID, reader)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerReadReceiptsSuite(t *testing.T) {
+	suite.Run(t, new(MessengerReadReceiptsSuite))
+}
+
+type MessengerReadReceiptsSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerReadReceiptsSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	s.m = s.newMessenger()
+	s.privateKey = s.m.identity
+	_, err := s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerReadReceiptsSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerReadReceiptsSuite) newMessenger() *Messenger {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	messenger, err := newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	return messenger
+}
+
+func (s *MessengerReadReceiptsSuite) TestReadReceipts() {
+	alice := s.m
+	bob := s.newMessenger()
+	_, err := bob.Start()
+	s.Require().NoError(err)
+	defer bob.Shutdown() // nolint: errcheck
+
+	aliceChat := CreateOneToOneChat("bob", &bob.identity.PublicKey, alice.transport)
+	s.Require().NoError(alice.SaveChat(aliceChat))
+	bobChat := CreateOneToOneChat("alice", &alice.identity.PublicKey, bob.transport)
+	s.Require().NoError(bob.SaveChat(bobChat))
+
+	// Read receipts are opt in
+	s.Require().False(alice.readReceiptsEnabled(aliceChat))
+	s.Require().NoError(alice.settings.SaveSetting("send-read-receipts?", true))
+	s.Require().True(alice.readReceiptsEnabled(aliceChat))
+
+	sendResponse, err := bob.SendChatMessage(context.Background(), buildTestMessage(*bobChat))
+	s.Require().NoError(err)
+	s.Require().Len(sendResponse.Messages(), 1)
+	sentMessage := sendResponse.Messages()[0]
+
+	_, err = WaitOnMessengerResponse(
+		alice,
+		func(r *MessengerResponse) bool { return len(r.Messages()) > 0 },
+		"no message",
+	)
+	s.Require().NoError(err)
+
+	_, _, err = alice.MarkMessagesSeen(aliceChat.ID, []string{sentMessage.ID})
+	s.Require().NoError(err)
+
+	err = tt.RetryWithBackOff(func() error {
+		_, err := bob.RetrieveAll()
+		if err != nil {
+			return err
+		}
+		message, err := bob.MessageByID(sentMessage.ID)
+		if err != nil {
+			return err
+		}
+		if message.OutgoingStatus != common.OutgoingStatusRead {
+			return errors.New("message not read")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	// A chat can override the setting of the account
+	response, err := alice.SetChatPrivacySettings(&requests.SetChatPrivacySettings{
+		ChatID:       aliceChat.ID,
+		ReadReceipts: requests.ChatPrivacySettingDisabled,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(response.Chats(), 1)
+	s.Require().Equal(ChatPrivacySettingDisabled, response.Chats()[0].ReadReceipts)
+	s.Require().False(alice.readReceiptsEnabled(response.Chats()[0]))
+
+	chat, err := alice.persistence.Chat(aliceChat.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(ChatPrivacySettingDisabled, chat.ReadReceipts)
+
+	_, err = alice.SetChatPrivacySettings(&requests.SetChatPrivacySettings{ChatID: aliceChat.ID, ReadReceipts: 3})
+	s.Require().Equal(requests.ErrSetChatPrivacySettingsInvalidSetting, err)
+
+	publicChat := CreatePublicChat(statusChatID, alice.transport)
+	s.Require().NoError(alice.SaveChat(publicChat))
+	_, err = alice.SetChatPrivacySettings(&requests.SetChatPrivacySettings{ChatID: publicChat.ID})
+	s.Require().Equal(ErrChatPrivacySettingsNotAllowed, err)
+}
+
+func (s *MessengerReadReceiptsSuite) TestTypingIndicators() {
+	alice := s.m
+	bob := s.newMessenger()
+	_, err := bob.Start()
+	s.Require().NoError(err)
+	defer bob.Shutdown() // nolint: errcheck
+
+	aliceChat := CreateOneToOneChat("bob", &bob.identity.PublicKey, alice.transport)
+	s.Require().NoError(alice.SaveChat(aliceChat))
+	bobChat := CreateOneToOneChat("alice", &alice.identity.PublicKey, bob.transport)
+	s.Require().NoError(bob.SaveChat(bobChat))
+
+	s.Require().NoError(alice.settings.SaveSetting("send-typing-indicators?", true))
+
+	s.Require().NoError(alice.SendTypingIndicator(context.Background(), aliceChat.ID, true))
+
+	response, err := WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool { return len(r.TypingIndicators()) > 0 },
+		"no typing indicator",
+	)
+	s.Require().NoError(err)
+	indicator := response.TypingIndicators()[0]
+	s.Require().True(indicator.Typing)
+	s.Require().Equal(bobChat.ID, indicator.LocalChatID)
+	s.Require().Equal(common.PubkeyToHex(&alice.identity.PublicKey), indicator.From)
+
+	// Typing indicators are throttled
+	s.Require().False(alice.shouldSendTypingIndicator(aliceChat.ID, true))
+
+	s.Require().NoError(alice.SendTypingIndicator(context.Background(), aliceChat.ID, false))
+
+	response, err = WaitOnMessengerResponse(
+		bob,
+		func(r *MessengerResponse) bool { return len(r.TypingIndicators()) > 0 },
+		"no typing indicator",
+	)
+	s.Require().NoError(err)
+	s.Require().False(response.TypingIndicators()[0].Typing)
+
+	// Typing indicators are not resent
+	rawMessages, err := alice.persistence.RawMessagesIDsByType(protobuf.ApplicationMetadataMessage_TYPING_INDICATOR)
+	s.Require().NoError(err)
+	s.Require().Empty(rawMessages)
+
+	publicChat := CreatePublicChat(statusChatID, alice.transport)
+	s.Require().NoError(alice.SaveChat(publicChat))
+	s.Require().Equal(ErrChatPrivacySettingsNotAllowed, alice.SendTypingIndicator(context.Background(), publicChat.ID, true))
+}
@@ -44,6 +44,11 @@ type MessengerResponse struct {
 	activityCenterNotifications map[string]*ActivityCenterNotification
 	messages                    map[string]*common.Message
 	pinMessages                 map[string]*common.PinMessage
+	pollVotes                   map[string]*PollVote
+	scheduledMessages           map[string]*ScheduledMessage
+	removedScheduledMessages    map[string]bool
+	chatDrafts                  map[string]*ChatDraft
+	typingIndicators            map[string]*TypingIndicator
 	currentStatus               *UserStatus
 	statusUpdates               map[string]UserStatus
 	clearedHistories            map[string]*ClearedHistory
@@ -59,6 +64,7 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		Installations           []*multidevice.Installation     `json:"installations,omitempty"`
 		PinMessages             []*common.PinMessage            `json:"pinMessages,omitempty"`
 		EmojiReactions          []*EmojiReaction                `json:"emojiReactions,omitempty"`
+		PollVotes               []*PollVote                     `json:"pollVotes,omitempty"`
 		Invitations             []*GroupChatInvitation          `json:"invitations,omitempty"`
 		CommunityChanges        []*communities.CommunityChanges `json:"communityChanges,omitempty"`
 		RequestsToJoinCommunity []*communities.RequestToJoin    `json:"requestsToJoinCommunity,omitempty"`
@@ -70,6 +76,10 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		Notifications               []*localnotifications.Notification `json:"notifications"`
 		Communities                 []*communities.Community           `json:"communities,omitempty"`
 		ActivityCenterNotifications []*ActivityCenterNotification      `json:"activityCenterNotifications,omitempty"`
+		ScheduledMessages           []*ScheduledMessage                `json:"scheduledMessages,omitempty"`
+		RemovedScheduledMessages    []string                           `json:"removedScheduledMessages,omitempty"`
+		ChatDrafts                  []*ChatDraft                       `json:"chatDrafts,omitempty"`
+		TypingIndicators            []*TypingIndicator                 `json:"typingIndicators,omitempty"`
 		CurrentStatus               *UserStatus                        `json:"currentStatus,omitempty"`
 		StatusUpdates               []UserStatus                       `json:"statusUpdates,omitempty"`
 	}{
@@ -93,6 +103,11 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 	responseItem.ClearedHistories = r.ClearedHistories()
 	responseItem.ActivityCenterNotifications = r.ActivityCenterNotifications()
 	responseItem.PinMessages = r.PinMessages()
+	responseItem.PollVotes = r.PollVotes()
+	responseItem.ScheduledMessages = r.ScheduledMessages()
+	responseItem.RemovedScheduledMessages = r.RemovedScheduledMessages()
+	responseItem.ChatDrafts = r.ChatDrafts()
+	responseItem.TypingIndicators = r.TypingIndicators()
 	responseItem.StatusUpdates = r.StatusUpdates()
 
 	return json.Marshal(responseItem)
@@ -154,6 +169,46 @@ func (r *MessengerResponse) PinMessages() []*common.PinMessage {
 	return pinMessages
 }
 
+func (r *MessengerResponse) PollVotes() []*PollVote {
+	var pollVotes []*PollVote
+	for _, v := range r.pollVotes {
+		pollVotes = append(pollVotes, v)
+	}
+	return pollVotes
+}
+
+func (r *MessengerResponse) ScheduledMessages() []*ScheduledMessage {
+	var scheduledMessages []*ScheduledMessage
+	for _, sm := range r.scheduledMessages {
+		scheduledMessages = append(scheduledMessages, sm)
+	}
+	return scheduledMessages
+}
+
+func (r *MessengerResponse) RemovedScheduledMessages() []string {
+	var ids []string
+	for id := range r.removedScheduledMessages {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r *MessengerResponse) ChatDrafts() []*ChatDraft {
+	var drafts []*ChatDraft
+	for _, d := range r.chatDrafts {
+		drafts = append(drafts, d)
+	}
+	return drafts
+}
+
+func (r *MessengerResponse) TypingIndicators() []*TypingIndicator {
+	var indicators []*TypingIndicator
+	for _, t := range r.typingIndicators {
+		indicators = append(indicators, t)
+	}
+	return indicators
+}
+
 func (r *MessengerResponse) StatusUpdates() []UserStatus {
 	var userStatus []UserStatus
 	for pk, s := range r.statusUpdates {
@@ -167,6 +222,11 @@ func (r *MessengerResponse) IsEmpty() bool {
 	return len(r.chats)+
 		len(r.messages)+
 		len(r.pinMessages)+
+		len(r.pollVotes)+
+		len(r.scheduledMessages)+
+		len(r.removedScheduledMessages)+
+		len(r.chatDrafts)+
+		len(r.typingIndicators)+
 		len(r.Contacts)+
 		len(r.Bookmarks)+
 		len(r.clearedHistories)+
@@ -208,6 +268,11 @@ func (r *MessengerResponse) Merge(response *MessengerResponse) error {
 	r.AddMessages(response.Messages())
 	r.AddCommunities(response.Communities())
 	r.AddPinMessages(response.PinMessages())
+	r.AddPollVotes(response.PollVotes())
+	r.AddScheduledMessages(response.ScheduledMessages())
+	r.AddRemovedScheduledMessages(response.RemovedScheduledMessages())
+	r.AddChatDrafts(response.ChatDrafts())
+	r.AddTypingIndicators(response.TypingIndicators())
 	r.AddActivityCenterNotifications(response.ActivityCenterNotifications())
 
 	return nil
@@ -349,6 +414,82 @@ func (r *MessengerResponse) AddPinMessages(pms []*common.PinMessage) {
 	}
 }
 
+func (r *MessengerResponse) AddPollVote(v *PollVote) {
+	if r.pollVotes == nil {
+		r.pollVotes = make(map[string]*PollVote)
+	}
+
+	r.pollVotes[v.ID()] = v
+}
+
+func (r *MessengerResponse) AddPollVotes(vs []*PollVote) {
+	for _, v := range vs {
+		r.AddPollVote(v)
+	}
+}
+
+func (r *MessengerResponse) AddScheduledMessage(sm *ScheduledMessage) {
+	if r.scheduledMessages == nil {
+		r.scheduledMessages = make(map[string]*ScheduledMessage)
+	}
+
+	r.scheduledMessages[sm.ID] = sm
+}
+
+func (r *MessengerResponse) AddScheduledMessages(sms []*ScheduledMessage) {
+	for _, sm := range sms {
+		r.AddScheduledMessage(sm)
+	}
+}
+
+func (r *MessengerResponse) AddRemovedScheduledMessage(id string) {
+	if r.removedScheduledMessages == nil {
+		r.removedScheduledMessages = make(map[string]bool)
+	}
+
+	r.removedScheduledMessages[id] = true
+}
+
+func (r *MessengerResponse) AddRemovedScheduledMessages(ids []string) {
+	for _, id := range ids {
+		r.AddRemovedScheduledMessage(id)
+	}
+}
+
+func (r *MessengerResponse) AddChatDraft(d *ChatDraft) {
+	if r.chatDrafts == nil {
+		r.chatDrafts = make(map[string]*ChatDraft)
+	}
+
+	r.chatDrafts[d.ChatID] = d
+}
+
+func (r *MessengerResponse) AddChatDrafts(ds []*ChatDraft) {
+	for _, d := range ds {
+		r.AddChatDraft(d)
+	}
+}
+
+// AddTypingIndicator adds a typing indicator, replacing an older one of the
+// same member in the same chat
+func (r *MessengerResponse) AddTypingIndicator(t *TypingIndicator) {
+	if r.typingIndicators == nil {
+		r.typingIndicators = make(map[string]*TypingIndicator)
+	}
+
+	if existing, ok := r.typingIndicators[t.ID()]; ok && existing.Clock > t.Clock {
+		return
+	}
+
+	r.typingIndicators[t.ID()] = t
+}
+
+func (r *MessengerResponse) AddTypingIndicators(ts []*TypingIndicator) {
+	for _, t := range ts {
+		r.AddTypingIndicator(t)
+	}
+}
+
 func (r *MessengerResponse) SetCurrentStatus(status UserStatus) {
 	r.currentStatus = &status
 }
@@ -0,0 +1,185 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/signal"
+)
+
+// scheduledMessagesInterval is how often we check for scheduled messages that
+// are due
+const scheduledMessagesInterval = 5 * time.Second
+
+var ErrScheduledMessageNotFound = errors.New("scheduled message not found")
+var ErrScheduledMessageInPast = errors.New("scheduled messages must be sent in the future")
+
+// ScheduledMessage is a message composed by the user that is sent to the chat
+// at a later time.
+// Images, audio and files attached to the message are read from their path
+// when the message is sent
+type ScheduledMessage struct {
+	ID     string `json:"id"`
+	ChatID string `json:"chatId"`
+	// ScheduledAt is the unix timestamp in milliseconds at which the message
+	// is sent
+	ScheduledAt uint64          `json:"scheduledAt"`
+	Message     *common.Message `json:"message"`
+	// Error is the reason the message couldn't be sent, the message is not
+	// sent again until it's edited
+	Error string `json:"error,omitempty"`
+}
+
+// ScheduleMessage stores a message to be sent to the chat at a later time
+func (m *Messenger) ScheduleMessage(request *requests.ScheduleMessage) (*ScheduledMessage, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	if request.ScheduledAt <= m.getTimesource().GetCurrentTime() {
+		return nil, ErrScheduledMessageInPast
+	}
+
+	if _, ok := m.allChats.Load(request.Message.ChatId); !ok {
+		return nil, ErrChatNotFound
+	}
+
+	scheduledMessage := &ScheduledMessage{
+		ID:          uuid.New().String(),
+		ChatID:      request.Message.ChatId,
+		ScheduledAt: request.ScheduledAt,
+		Message:     request.Message,
+	}
+
+	err := m.persistence.SaveScheduledMessage(scheduledMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledMessage, nil
+}
+
+// ScheduledMessages returns the messages scheduled in the chat, or in all the
+// chats if chatID is empty
+func (m *Messenger) ScheduledMessages(chatID string) ([]*ScheduledMessage, error) {
+	return m.persistence.ScheduledMessages(chatID)
+}
+
+// EditScheduledMessage changes the text or the time of a scheduled message,
+// messages that failed to be sent are scheduled again
+func (m *Messenger) EditScheduledMessage(request *requests.EditScheduledMessage) (*ScheduledMessage, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Don't change a message that is being sent
+	m.scheduledMessagesMutex.Lock()
+	defer m.scheduledMessagesMutex.Unlock()
+
+	scheduledMessage, err := m.persistence.ScheduledMessage(request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledMessage == nil {
+		return nil, ErrScheduledMessageNotFound
+	}
+
+	if request.ScheduledAt != 0 {
+		if request.ScheduledAt <= m.getTimesource().GetCurrentTime() {
+			return nil, ErrScheduledMessageInPast
+		}
+		scheduledMessage.ScheduledAt = request.ScheduledAt
+	}
+
+	if len(request.Text) != 0 {
+		scheduledMessage.Message.Text = request.Text
+	}
+
+	scheduledMessage.Error = ""
+
+	err = m.persistence.UpdateScheduledMessage(scheduledMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledMessage, nil
+}
+
+// CancelScheduledMessage removes a scheduled message before it is sent
+func (m *Messenger) CancelScheduledMessage(id string) error {
+	m.scheduledMessagesMutex.Lock()
+	defer m.scheduledMessagesMutex.Unlock()
+
+	return m.persistence.DeleteScheduledMessage(id)
+}
+
+// sendScheduledMessages sends the scheduled messages that are due, including
+// the ones that became due while the node was offline
+func (m *Messenger) sendScheduledMessages(ctx context.Context) (*MessengerResponse, error) {
+	m.scheduledMessagesMutex.Lock()
+	defer m.scheduledMessagesMutex.Unlock()
+
+	scheduledMessages, err := m.persistence.DueScheduledMessages(m.getTimesource().GetCurrentTime())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MessengerResponse{}
+	for _, scheduledMessage := range scheduledMessages {
+		sendResponse, err := m.sendChatMessage(ctx, scheduledMessage.Message)
+		if err != nil {
+			m.logger.Warn("failed to send scheduled message", zap.String("id", scheduledMessage.ID), zap.Error(err))
+
+			scheduledMessage.Error = err.Error()
+			if err := m.persistence.SetScheduledMessageError(scheduledMessage.ID, scheduledMessage.Error); err != nil {
+				return nil, err
+			}
+			response.AddScheduledMessage(scheduledMessage)
+			continue
+		}
+
+		if err := m.persistence.DeleteScheduledMessage(scheduledMessage.ID); err != nil {
+			return nil, err
+		}
+
+		if err := response.Merge(sendResponse); err != nil {
+			return nil, err
+		}
+		response.AddRemovedScheduledMessage(scheduledMessage.ID)
+	}
+
+	return response, nil
+}
+
+// watchScheduledMessages regularly sends the scheduled messages that are due
+// and notifies the client
+func (m *Messenger) watchScheduledMessages() {
+	m.logger.Debug("watching scheduled messages")
+	go func() {
+		// Send right away the messages that were due while we were offline
+		wait := time.Duration(0)
+		for {
+			select {
+			case <-time.After(wait):
+				wait = scheduledMessagesInterval
+				response, err := m.sendScheduledMessages(context.Background())
+				if err != nil {
+					m.logger.Error("failed to send scheduled messages", zap.Error(err))
+					continue
+				}
+				if !response.IsEmpty() {
+					signal.SendNewMessages(response)
+				}
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,242 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerScheduledMessagesSuite(t *testing.T) {
+	suite.Run(t, new(MessengerScheduledMessagesSuite))
+}
+
+type MessengerScheduledMessagesSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerScheduledMessagesSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	s.m = s.newMessenger()
+	s.privateKey = s.m.identity
+	_, err := s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerScheduledMessagesSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerScheduledMessagesSuite) newMessenger() *Messenger {
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	messenger, err := newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	return messenger
+}
+
+func (s *MessengerScheduledMessagesSuite) TestScheduleMessage() {
+	chat := CreatePublicChat(statusChatID, s.m.transport)
+	s.Require().NoError(s.m.SaveChat(chat))
+
+	now := s.m.getTimesource().GetCurrentTime()
+
+	_, err := s.m.ScheduleMessage(&requests.ScheduleMessage{
+		Message:     buildTestMessage(*chat),
+		ScheduledAt: now - 1000,
+	})
+	s.Require().Equal(ErrScheduledMessageInPast, err)
+
+	scheduledMessage, err := s.m.ScheduleMessage(&requests.ScheduleMessage{
+		Message:     buildTestMessage(*chat),
+		ScheduledAt: now + 3600*1000,
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(scheduledMessage.ID)
+
+	scheduledMessage, err = s.m.EditScheduledMessage(&requests.EditScheduledMessage{
+		ID:   scheduledMessage.ID,
+		Text: "edited",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("edited", scheduledMessage.Message.Text)
+
+	scheduledMessages, err := s.m.ScheduledMessages(chat.ID)
+	s.Require().NoError(err)
+	s.Require().Len(scheduledMessages, 1)
+	s.Require().Equal("edited", scheduledMessages[0].Message.Text)
+	s.Require().Equal(now+3600*1000, scheduledMessages[0].ScheduledAt)
+
+	// Not due yet
+	response, err := s.m.sendScheduledMessages(context.Background())
+	s.Require().NoError(err)
+	s.Require().True(response.IsEmpty())
+
+	s.Require().NoError(s.m.CancelScheduledMessage(scheduledMessage.ID))
+	s.Require().Equal(ErrScheduledMessageNotFound, s.m.CancelScheduledMessage(scheduledMessage.ID))
+
+	scheduledMessages, err = s.m.ScheduledMessages("")
+	s.Require().NoError(err)
+	s.Require().Len(scheduledMessages, 0)
+}
+
+func (s *MessengerScheduledMessagesSuite) TestSendDueScheduledMessages() {
+	chat := CreatePublicChat(statusChatID, s.m.transport)
+	s.Require().NoError(s.m.SaveChat(chat))
+
+	now := s.m.getTimesource().GetCurrentTime()
+
+	// Messages that became due while the node was offline
+	message := buildTestMessage(*chat)
+	message.Text = "scheduled"
+	s.Require().NoError(s.m.persistence.SaveScheduledMessage(&ScheduledMessage{
+		ID:          "due",
+		ChatID:      chat.ID,
+		ScheduledAt: now - 1000,
+		Message:     message,
+	}))
+
+	missingChat := CreatePublicChat("missing", s.m.transport)
+	s.Require().NoError(s.m.persistence.SaveScheduledMessage(&ScheduledMessage{
+		ID:          "failing",
+		ChatID:      missingChat.ID,
+		ScheduledAt: now - 1000,
+		Message:     buildTestMessage(*missingChat),
+	}))
+
+	// The scheduler might have already sent them in the background
+	_, err := s.m.sendScheduledMessages(context.Background())
+	s.Require().NoError(err)
+
+	messages, _, err := s.m.MessageByChatID(chat.ID, "", 10)
+	s.Require().NoError(err)
+	s.Require().Len(messages, 1)
+	s.Require().Equal("scheduled", messages[0].Text)
+
+	scheduledMessages, err := s.m.ScheduledMessages("")
+	s.Require().NoError(err)
+	s.Require().Len(scheduledMessages, 1)
+	s.Require().Equal("failing", scheduledMessages[0].ID)
+	s.Require().NotEmpty(scheduledMessages[0].Error)
+
+	// Failed messages are not sent again
+	response, err := s.m.sendScheduledMessages(context.Background())
+	s.Require().NoError(err)
+	s.Require().True(response.IsEmpty())
+
+	// Unless they are edited
+	scheduledMessage, err := s.m.EditScheduledMessage(&requests.EditScheduledMessage{
+		ID:          "failing",
+		ScheduledAt: now + 3600*1000,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(scheduledMessage.Error)
+}
+
+func (s *MessengerScheduledMessagesSuite) TestEditSentScheduledMessage() {
+	chat := CreatePublicChat(statusChatID, s.m.transport)
+	s.Require().NoError(s.m.SaveChat(chat))
+
+	scheduledMessage := &ScheduledMessage{
+		ID:          "due",
+		ChatID:      chat.ID,
+		ScheduledAt: s.m.getTimesource().GetCurrentTime() - 1000,
+		Message:     buildTestMessage(*chat),
+	}
+	s.Require().NoError(s.m.persistence.SaveScheduledMessage(scheduledMessage))
+
+	_, err := s.m.sendScheduledMessages(context.Background())
+	s.Require().NoError(err)
+
+	// A message that has been sent can't be scheduled again
+	s.Require().Equal(ErrScheduledMessageNotFound, s.m.persistence.UpdateScheduledMessage(scheduledMessage))
+	s.Require().Equal(ErrScheduledMessageNotFound, s.m.CancelScheduledMessage(scheduledMessage.ID))
+
+	scheduledMessages, err := s.m.ScheduledMessages("")
+	s.Require().NoError(err)
+	s.Require().Len(scheduledMessages, 0)
+}
+
+func (s *MessengerScheduledMessagesSuite) TestSendScheduledMessagesAfterRestart() {
+	tmpFile, err := ioutil.TempFile("", "scheduled-messages-")
+	s.Require().NoError(err)
+	defer os.Remove(tmpFile.Name())
+
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	options := []Option{WithDatabaseConfig(tmpFile.Name(), "some-key")}
+
+	m, err := newMessengerWithKey(s.shh, privateKey, s.logger, options)
+	s.Require().NoError(err)
+
+	chat := CreatePublicChat(statusChatID, m.transport)
+	s.Require().NoError(m.SaveChat(chat))
+
+	message := buildTestMessage(*chat)
+	message.Text = "scheduled"
+	_, err = m.ScheduleMessage(&requests.ScheduleMessage{
+		Message:     message,
+		ScheduledAt: m.getTimesource().GetCurrentTime() + 1000,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(m.Shutdown())
+
+	// The message becomes due while the node is offline, it's sent as soon
+	// as the messenger is started again
+	time.Sleep(time.Second)
+
+	// The settings have already been created by the first instance
+	restartOptions := append(options, func(c *config) error {
+		c.afterDbCreatedHooks = nil
+		return nil
+	}, WithToplevelDatabaseMigrations())
+	m, err = newMessengerWithKey(s.shh, privateKey, s.logger, restartOptions)
+	s.Require().NoError(err)
+	defer m.Shutdown() // nolint: errcheck
+
+	err = tt.RetryWithBackOff(func() error {
+		scheduledMessages, err := m.ScheduledMessages("")
+		if err != nil {
+			return err
+		}
+		if len(scheduledMessages) != 0 {
+			return errors.New("scheduled message not sent")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	messages, _, err := m.MessageByChatID(chat.ID, "", 10)
+	s.Require().NoError(err)
+	s.Require().Len(messages, 1)
+	s.Require().Equal("scheduled", messages[0].Text)
+}
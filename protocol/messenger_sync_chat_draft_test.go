@@ -0,0 +1,139 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/encryption/multidevice"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerSyncChatDraftSuite(t *testing.T) {
+	suite.Run(t, new(MessengerSyncChatDraftSuite))
+}
+
+type MessengerSyncChatDraftSuite struct {
+	suite.Suite
+	m          *Messenger        // main instance of Messenger
+	privateKey *ecdsa.PrivateKey // private key for the main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	logger *zap.Logger
+}
+
+func (s *MessengerSyncChatDraftSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	s.m, err = newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	s.privateKey = s.m.identity
+	// We start the messenger in order to receive installations
+	_, err = s.m.Start()
+	s.Require().NoError(err)
+}
+
+func (s *MessengerSyncChatDraftSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+}
+
+func (s *MessengerSyncChatDraftSuite) pair() *Messenger {
+	theirMessenger, err := newMessengerWithKey(s.shh, s.privateKey, s.logger, nil)
+	s.Require().NoError(err)
+
+	err = theirMessenger.SetInstallationMetadata(theirMessenger.installationID, &multidevice.InstallationMetadata{
+		Name:       "their-name",
+		DeviceType: "their-device-type",
+	})
+	s.Require().NoError(err)
+	_, err = theirMessenger.SendPairInstallation(context.Background())
+	s.Require().NoError(err)
+
+	// Wait for the message to reach its destination
+	_, err = WaitOnMessengerResponse(
+		s.m,
+		func(r *MessengerResponse) bool { return len(r.Installations) > 0 },
+		"installation not received",
+	)
+	s.Require().NoError(err)
+
+	err = s.m.EnableInstallation(theirMessenger.installationID)
+	s.Require().NoError(err)
+
+	return theirMessenger
+}
+
+func (s *MessengerSyncChatDraftSuite) TestSyncChatDraft() {
+	theirMessenger := s.pair()
+	defer func() {
+		s.Require().NoError(theirMessenger.Shutdown())
+	}()
+
+	_, err := s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+	_, err = theirMessenger.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+
+	draft, err := s.m.SaveChatDraft(context.Background(), &requests.SaveChatDraft{
+		ChatID: publicChatName,
+		Text:   "half written",
+	})
+	s.Require().NoError(err)
+
+	_, err = WaitOnMessengerResponse(
+		theirMessenger,
+		func(r *MessengerResponse) bool { return len(r.ChatDrafts()) > 0 },
+		"draft not received",
+	)
+	s.Require().NoError(err)
+
+	theirDraft, err := theirMessenger.ChatDraft(publicChatName)
+	s.Require().NoError(err)
+	s.Require().NotNil(theirDraft)
+	s.Require().Equal("half written", theirDraft.Text)
+	s.Require().Equal(draft.Clock, theirDraft.Clock)
+
+	// An older draft doesn't overwrite the most recent one
+	saved, err := theirMessenger.persistence.SaveChatDraft(&ChatDraft{
+		ChatID: publicChatName,
+		Text:   "stale",
+		Clock:  draft.Clock - 1,
+	})
+	s.Require().NoError(err)
+	s.Require().False(saved)
+
+	// Clearing the draft is synced as well
+	_, err = s.m.SaveChatDraft(context.Background(), &requests.SaveChatDraft{ChatID: publicChatName})
+	s.Require().NoError(err)
+
+	_, err = WaitOnMessengerResponse(
+		theirMessenger,
+		func(r *MessengerResponse) bool { return len(r.ChatDrafts()) > 0 },
+		"cleared draft not received",
+	)
+	s.Require().NoError(err)
+
+	drafts, err := theirMessenger.ChatDrafts()
+	s.Require().NoError(err)
+	s.Require().Len(drafts, 0)
+}
@@ -382,6 +382,112 @@ func (s *MessengerSuite) TestMarkMessagesSeen() {
 	}
 }
 
+func (s *MessengerSuite) TestCheckReceivedThread() {
+	chat := CreatePublicChat("test-chat", s.m.transport)
+	s.Require().NoError(s.m.SaveChat(chat))
+	otherChat := CreatePublicChat("other-chat", s.m.transport)
+	s.Require().NoError(s.m.SaveChat(otherChat))
+
+	root := buildTestMessage(*chat)
+	root.ID = "root"
+	reply := buildTestMessage(*chat)
+	reply.ID = "reply"
+	reply.ThreadId = root.ID
+	s.Require().NoError(s.m.SaveMessages([]*common.Message{root, reply}))
+
+	state := &ReceivedMessageState{Response: &MessengerResponse{}}
+
+	message := buildTestMessage(*chat)
+	message.ThreadId = root.ID
+	s.Require().NoError(s.m.checkReceivedThread(state, message))
+	s.Require().Equal(root.ID, message.ThreadId)
+
+	// The root is in another chat
+	message = buildTestMessage(*otherChat)
+	message.ThreadId = root.ID
+	s.Require().NoError(s.m.checkReceivedThread(state, message))
+	s.Require().Empty(message.ThreadId)
+
+	// The root is a reply itself
+	message = buildTestMessage(*chat)
+	message.ThreadId = reply.ID
+	s.Require().NoError(s.m.checkReceivedThread(state, message))
+	s.Require().Empty(message.ThreadId)
+
+	// The root is unknown, it might be received later
+	message = buildTestMessage(*chat)
+	message.ThreadId = "unknown"
+	s.Require().NoError(s.m.checkReceivedThread(state, message))
+	s.Require().Equal("unknown", message.ThreadId)
+
+	// The root has been received in the same batch
+	batchRoot := buildTestMessage(*chat)
+	batchRoot.ID = "batch-root"
+	state.Response.AddMessage(batchRoot)
+	message = buildTestMessage(*chat)
+	message.ThreadId = batchRoot.ID
+	s.Require().NoError(s.m.checkReceivedThread(state, message))
+	s.Require().Equal(batchRoot.ID, message.ThreadId)
+}
+
+func (s *MessengerSuite) TestThreadReplyBeforeRoot() {
+	chat := CreatePublicChat("test-chat", s.m.transport)
+	s.Require().NoError(s.m.SaveChat(chat))
+	otherChat := CreatePublicChat("other-chat", s.m.transport)
+	s.Require().NoError(s.m.SaveChat(otherChat))
+
+	// The replies are delivered before their root
+	receiveReply := func(chat *Chat, id, threadID string) *common.Message {
+		reply := buildTestMessage(*chat)
+		reply.ID = id
+		reply.ThreadId = threadID
+		s.Require().NoError(s.m.checkReceivedThread(&ReceivedMessageState{Response: &MessengerResponse{}}, reply))
+		s.Require().Equal(threadID, reply.ThreadId)
+		s.Require().NoError(s.m.SaveMessages([]*common.Message{reply}))
+		return reply
+	}
+	receiveReply(chat, "reply", "root")
+	receiveReply(otherChat, "other-chat-reply", "root")
+	receiveReply(chat, "nested-reply", "reply-root")
+
+	threadID := func(id string) string {
+		message, err := s.m.persistence.MessageByID(id)
+		s.Require().NoError(err)
+		return message.ThreadId
+	}
+
+	// The replies in the chat of the root stay in its thread
+	root := buildTestMessage(*chat)
+	root.ID = "root"
+	state := &ReceivedMessageState{Response: &MessengerResponse{}}
+	s.Require().NoError(s.m.checkPendingThreadReplies(state, root))
+	s.Require().Equal("root", threadID("reply"))
+	s.Require().Equal("", threadID("other-chat-reply"))
+	s.Require().Len(state.Response.Messages(), 1)
+	s.Require().Equal("other-chat-reply", state.Response.Messages()[0].ID)
+	s.Require().Empty(state.Response.Messages()[0].ThreadId)
+
+	// A reply can't be the root of a thread
+	replyRoot := buildTestMessage(*chat)
+	replyRoot.ID = "reply-root"
+	replyRoot.ThreadId = "root"
+	state = &ReceivedMessageState{Response: &MessengerResponse{}}
+	s.Require().NoError(s.m.checkPendingThreadReplies(state, replyRoot))
+	s.Require().Equal("", threadID("nested-reply"))
+	s.Require().Len(state.Response.Messages(), 1)
+
+	// Replies received in the same batch are checked too
+	batchReply := buildTestMessage(*otherChat)
+	batchReply.ID = "batch-reply"
+	batchReply.ThreadId = "batch-root"
+	state = &ReceivedMessageState{Response: &MessengerResponse{}}
+	state.Response.AddMessage(batchReply)
+	batchRoot := buildTestMessage(*chat)
+	batchRoot.ID = "batch-root"
+	s.Require().NoError(s.m.checkPendingThreadReplies(state, batchRoot))
+	s.Require().Empty(batchReply.ThreadId)
+}
+
 func (s *MessengerSuite) TestMarkAllRead() {
 	chat := CreatePublicChat("test-chat", s.m.transport)
 	chat.UnviewedMessagesCount = 2
@@ -2237,75 +2343,6 @@ func (s *MessengerSuite) TestLastSentField() {
 	s.NotEqual(uint64(0), rawMessage.LastSent, "rawMessage.LastSent should be non-zero after sending")
 }
 
-func (s *MessengerSuite) TestShouldResendEmoji() {
-	// shouldn't try to resend non-emoji messages.
-	ok, err := shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_CONTACT_UPDATE,
-		Sent:        false,
-		SendCount:   2,
-	}, s.m.getTimesource())
-	s.Error(err)
-	s.False(ok)
-
-	// shouldn't try to resend already sent message
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        true,
-		SendCount:   1,
-	}, s.m.getTimesource())
-	s.Error(err)
-	s.False(ok)
-
-	// messages that already sent to many times shouldn't be resend
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        false,
-		SendCount:   messageResendMaxCount + 1,
-	}, s.m.getTimesource())
-	s.NoError(err)
-	s.False(ok)
-
-	// message sent one time CAN'T be resend in 15 seconds (only after 30)
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        false,
-		SendCount:   1,
-		LastSent:    s.m.getTimesource().GetCurrentTime() - 15*uint64(time.Second.Milliseconds()),
-	}, s.m.getTimesource())
-	s.NoError(err)
-	s.False(ok)
-
-	// message sent one time CAN be resend in 35 seconds
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        false,
-		SendCount:   1,
-		LastSent:    s.m.getTimesource().GetCurrentTime() - 35*uint64(time.Second.Milliseconds()),
-	}, s.m.getTimesource())
-	s.NoError(err)
-	s.True(ok)
-
-	// message sent three times CAN'T be resend in 100 seconds (only after 120)
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        false,
-		SendCount:   3,
-		LastSent:    s.m.getTimesource().GetCurrentTime() - 100*uint64(time.Second.Milliseconds()),
-	}, s.m.getTimesource())
-	s.NoError(err)
-	s.False(ok)
-
-	// message sent tow times CAN be resend in 65 seconds
-	ok, err = shouldResendMessage(&common.RawMessage{
-		MessageType: protobuf.ApplicationMetadataMessage_EMOJI_REACTION,
-		Sent:        false,
-		SendCount:   3,
-		LastSent:    s.m.getTimesource().GetCurrentTime() - 125*uint64(time.Second.Milliseconds()),
-	}, s.m.getTimesource())
-	s.NoError(err)
-	s.True(ok)
-}
-
 func (s *MessengerSuite) TestMessageSent() {
 	//send message
 	chat := CreatePublicChat("test-chat", s.m.transport)
@@ -2355,9 +2392,12 @@ func (s *MessengerSuite) TestResendExpiredEmojis() {
 	s.False(rawMessage.Sent)
 	s.Equal(1, rawMessage.SendCount)
 
-	//imitate that more than 30 seconds passed since message was sent
-	rawMessage.LastSent = rawMessage.LastSent - 35*uint64(time.Second.Milliseconds())
-	err = s.m.persistence.SaveRawMessage(rawMessage)
+	//imitate that the backoff elapsed without the emoji being acked
+	entry, err := s.m.outbox.Entry(emojiID)
+	s.Require().NoError(err)
+	s.Require().NotNil(entry)
+	s.Equal(common.OutboxStateSent, entry.State)
+	_, err = s.m.database.Exec(`UPDATE outbox SET next_attempt = ? WHERE id = ?`, entry.LastAttempt-1, emojiID)
 	s.NoError(err)
 	time.Sleep(2 * time.Second)
 
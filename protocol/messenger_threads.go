@@ -0,0 +1,132 @@
+package protocol
+
+import (
+	"github.com/planq-network/status-go/protocol/common"
+)
+
+// validateThread checks that the root message of the thread the message is
+// replying in exists in the same chat and is not a reply in a thread itself
+func (m *Messenger) validateThread(message *common.Message, chat *Chat) error {
+	if len(message.ThreadId) == 0 {
+		return nil
+	}
+
+	root, err := m.persistence.MessageByID(message.ThreadId)
+	if err == common.ErrRecordNotFound {
+		return ErrThreadNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if root.LocalChatID != chat.ID || len(root.ThreadId) != 0 || root.Deleted {
+		return ErrInvalidThread
+	}
+
+	return nil
+}
+
+// checkReceivedThread moves a received reply to the top level of the chat if
+// the root of its thread is in another chat or a reply itself. Replies are
+// kept in their thread while the root is unknown, as it might be received
+// later, they are checked by checkPendingThreadReplies once it is.
+func (m *Messenger) checkReceivedThread(state *ReceivedMessageState, message *common.Message) error {
+	if len(message.ThreadId) == 0 {
+		return nil
+	}
+
+	// The root might have been received in the same batch
+	root, ok := state.Response.messages[message.ThreadId]
+	if !ok {
+		var err error
+		root, err = m.persistence.MessageByID(message.ThreadId)
+		if err == common.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if root.LocalChatID != message.LocalChatID || len(root.ThreadId) != 0 {
+		message.ThreadId = ""
+	}
+	return nil
+}
+
+// checkPendingThreadReplies moves the replies received before the message to
+// the top level of their chat if the message can't be the root of their
+// thread, that is if they are in another chat or the message is a reply itself
+func (m *Messenger) checkPendingThreadReplies(state *ReceivedMessageState, root *common.Message) error {
+	isReply := len(root.ThreadId) != 0
+
+	// The replies received in the same batch haven't been saved yet
+	for _, reply := range state.Response.messages {
+		if reply.ThreadId == root.ID && (isReply || reply.LocalChatID != root.LocalChatID) {
+			reply.ThreadId = ""
+		}
+	}
+
+	ids, err := m.persistence.DetachThreadReplies(root.ID, root.LocalChatID, isReply)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	replies, err := m.persistence.MessagesByIDs(ids)
+	if err != nil {
+		return err
+	}
+	for _, reply := range replies {
+		if _, ok := state.Response.messages[reply.ID]; !ok {
+			state.Response.AddMessage(reply)
+		}
+	}
+	return nil
+}
+
+// ThreadMessages returns the replies in a thread, the most recent first
+func (m *Messenger) ThreadMessages(threadID, cursor string, limit int) ([]*common.Message, string, error) {
+	msgs, nextCursor, err := m.persistence.MessagesByThreadID(threadID, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for idx := range msgs {
+		msgs[idx].PrepareImageURL(m.imageServer.Port)
+	}
+
+	return msgs, nextCursor, nil
+}
+
+// ActiveThreads returns the root messages of the threads in a chat, the most
+// recently replied first
+func (m *Messenger) ActiveThreads(chatID, cursor string, limit int) ([]*common.Message, string, error) {
+	if _, ok := m.allChats.Load(chatID); !ok {
+		return nil, "", ErrChatNotFound
+	}
+
+	msgs, nextCursor, err := m.persistence.ActiveThreadsByChatID(chatID, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for idx := range msgs {
+		msgs[idx].PrepareImageURL(m.imageServer.Port)
+	}
+
+	return msgs, nextCursor, nil
+}
+
+// MarkThreadRead marks all the replies in a thread as seen
+func (m *Messenger) MarkThreadRead(chatID, threadID string) (uint64, uint64, error) {
+	count, countWithMentions, err := m.persistence.MarkThreadRead(chatID, threadID)
+	if err != nil {
+		return 0, 0, err
+	}
+	chat, err := m.persistence.Chat(chatID)
+	if err != nil {
+		return 0, 0, err
+	}
+	m.allChats.Store(chatID, chat)
+	return count, countWithMentions, nil
+}
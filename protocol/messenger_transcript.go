@@ -0,0 +1,298 @@
+package protocol
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/planq-network/status-go/images"
+	"github.com/planq-network/status-go/multiaccounts/accounts"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/identity/alias"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/transcript"
+)
+
+// transcriptPageSize is the number of messages loaded at once when
+// exporting a transcript
+const transcriptPageSize = 200
+
+var transcriptEmojis = map[protobuf.EmojiReaction_Type]string{
+	protobuf.EmojiReaction_LOVE:        "❤️",
+	protobuf.EmojiReaction_THUMBS_UP:   "👍",
+	protobuf.EmojiReaction_THUMBS_DOWN: "👎",
+	protobuf.EmojiReaction_LAUGH:       "😂",
+	protobuf.EmojiReaction_SAD:         "😢",
+	protobuf.EmojiReaction_ANGRY:       "😠",
+}
+
+// transcriptExporter holds the state of a transcript export
+type transcriptExporter struct {
+	m        *Messenger
+	chat     *Chat
+	settings accounts.Settings
+	authors  map[string]*transcript.Author
+	pins     map[string]*common.PinnedMessage
+	// mediaDir is the directory where images and audio are written, it's
+	// created on first use
+	mediaDir     string
+	mediaCreated bool
+}
+
+// ExportChatTranscript writes all the messages of a chat to a file, in the
+// requested format. Images and audio messages are written to a directory
+// named after the file, with a "_files" suffix
+func (m *Messenger) ExportChatTranscript(ctx context.Context, request *requests.ExportChatTranscript) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	chat, ok := m.allChats.Load(request.ChatID)
+	if !ok {
+		return ErrChatNotFound
+	}
+
+	settings, err := m.getSettings()
+	if err != nil {
+		return err
+	}
+
+	exporter := &transcriptExporter{
+		m:        m,
+		chat:     chat,
+		settings: settings,
+		authors:  make(map[string]*transcript.Author),
+		pins:     make(map[string]*common.PinnedMessage),
+		mediaDir: strings.TrimSuffix(request.Path, filepath.Ext(request.Path)) + "_files",
+	}
+
+	err = exporter.loadPins()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(request.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buffer := bufio.NewWriter(file)
+	writer, err := transcript.NewWriter(request.Format, buffer)
+	if err != nil {
+		return err
+	}
+
+	err = writer.WriteHeader(&transcript.Chat{
+		ID:          chat.ID,
+		Name:        chat.Name,
+		CommunityID: chat.CommunityID,
+		ExportedAt:  m.getTimesource().GetCurrentTime(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var cursor string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var messages []*common.Message
+		messages, cursor, err = m.persistence.MessagesByChatIDAscending(chat.ID, cursor, transcriptPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			transcriptMessage, err := exporter.message(message)
+			if err != nil {
+				return err
+			}
+
+			err = writer.WriteMessage(transcriptMessage)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cursor == "" {
+			break
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	err = buffer.Flush()
+	if err != nil {
+		return err
+	}
+
+	return file.Sync()
+}
+
+func (e *transcriptExporter) loadPins() error {
+	var cursor string
+	for {
+		pinnedMessages, nextCursor, err := e.m.persistence.PinnedMessageByChatID(e.chat.ID, cursor, transcriptPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, pinnedMessage := range pinnedMessages {
+			e.pins[pinnedMessage.Message.ID] = pinnedMessage
+		}
+
+		if nextCursor == "" {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (e *transcriptExporter) message(message *common.Message) (*transcript.Message, error) {
+	transcriptMessage := &transcript.Message{
+		ID:        message.ID,
+		Timestamp: message.Timestamp,
+		Author:    e.author(message.From),
+		Text:      message.Text,
+		ThreadID:  message.ThreadId,
+		EditedAt:  message.EditedAt,
+		Deleted:   message.Deleted,
+	}
+
+	if message.ResponseTo != "" {
+		transcriptMessage.ReplyTo = &transcript.Reply{ID: message.ResponseTo}
+		if message.QuotedMessage != nil {
+			transcriptMessage.ReplyTo.Author = e.author(message.QuotedMessage.From)
+			transcriptMessage.ReplyTo.Text = message.QuotedMessage.Text
+		}
+	}
+
+	if pinnedMessage, ok := e.pins[message.ID]; ok {
+		transcriptMessage.Pin = &transcript.Pin{
+			PinnedAt: pinnedMessage.PinnedAt,
+			PinnedBy: e.author(pinnedMessage.PinnedBy),
+		}
+	}
+
+	reactions, err := e.m.persistence.EmojiReactionsByChatIDMessageID(e.chat.ID, message.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		emoji, ok := transcriptEmojis[reaction.Type]
+		if !ok {
+			continue
+		}
+		transcriptMessage.Reactions = append(transcriptMessage.Reactions, &transcript.Reaction{
+			Emoji:  emoji,
+			Author: e.author(reaction.From),
+		})
+	}
+
+	// The content of deleted messages is not exported
+	if message.Deleted {
+		transcriptMessage.Text = ""
+		return transcriptMessage, nil
+	}
+
+	err = e.m.persistence.LoadMessageMedia(message)
+	if err != nil {
+		return nil, err
+	}
+
+	switch message.ContentType {
+	case protobuf.ChatMessage_IMAGE:
+		image := message.GetImage()
+		if image == nil || len(image.Payload) == 0 {
+			break
+		}
+		extension, err := images.GetMimeType(image.Payload)
+		if err != nil {
+			e.m.logger.Warn("unknown image type in transcript")
+			break
+		}
+		transcriptMessage.Image, err = e.writeMedia(message.ID, extension, image.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+	case protobuf.ChatMessage_AUDIO:
+		audio := message.GetAudio()
+		if audio == nil || len(audio.Payload) == 0 {
+			break
+		}
+		extension := strings.ToLower(audio.Type.String())
+		transcriptMessage.Audio, err = e.writeMedia(message.ID, extension, audio.Payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return transcriptMessage, nil
+}
+
+// writeMedia writes the payload of a message to the media directory and
+// returns its path relative to the transcript
+func (e *transcriptExporter) writeMedia(messageID, extension string, payload []byte) (string, error) {
+	if !e.mediaCreated {
+		err := os.MkdirAll(e.mediaDir, 0700)
+		if err != nil {
+			return "", err
+		}
+		e.mediaCreated = true
+	}
+
+	name := fmt.Sprintf("%s.%s", messageID, extension)
+	err := ioutil.WriteFile(filepath.Join(e.mediaDir, name), payload, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(filepath.Join(filepath.Base(e.mediaDir), name)), nil
+}
+
+// author resolves the names of the author of a message, nicknames and
+// verified ENS names take precedence over the generated names
+func (e *transcriptExporter) author(id string) *transcript.Author {
+	if author, ok := e.authors[id]; ok {
+		return author
+	}
+
+	author := &transcript.Author{ID: id}
+	if id == common.PubkeyToHex(&e.m.identity.PublicKey) {
+		if e.settings.PreferredName != nil {
+			author.ENSName = *e.settings.PreferredName
+			author.DisplayName = author.ENSName
+		} else {
+			author.DisplayName = e.settings.Name
+		}
+	} else if contact, ok := e.m.allContacts.Load(id); ok {
+		author.DisplayName = contact.CanonicalName()
+		if contact.ENSVerified {
+			author.ENSName = contact.Name
+		}
+	}
+
+	if author.DisplayName == "" {
+		generatedName, err := alias.GenerateFromPublicKeyString(id)
+		if err == nil {
+			author.DisplayName = generatedName
+		}
+	}
+
+	e.authors[id] = author
+	return author
+}
@@ -0,0 +1,189 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	gethbridge "github.com/planq-network/status-go/eth-node/bridge/geth"
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
+	"github.com/planq-network/status-go/protocol/transcript"
+	"github.com/planq-network/status-go/protocol/tt"
+	"github.com/planq-network/status-go/waku"
+)
+
+func TestMessengerTranscriptSuite(t *testing.T) {
+	suite.Run(t, new(MessengerTranscriptSuite))
+}
+
+type MessengerTranscriptSuite struct {
+	suite.Suite
+	m *Messenger // main instance of Messenger
+
+	// If one wants to send messages between different instances of Messenger,
+	// a single Waku service should be shared.
+	shh types.Waku
+
+	tmpDir string
+	logger *zap.Logger
+}
+
+func (s *MessengerTranscriptSuite) SetupTest() {
+	s.logger = tt.MustCreateTestLogger()
+
+	config := waku.DefaultConfig
+	config.MinimumAcceptedPoW = 0
+	shh := waku.New(&config, s.logger)
+	s.shh = gethbridge.NewGethWakuWrapper(shh)
+	s.Require().NoError(shh.Start())
+
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	s.m, err = newMessengerWithKey(s.shh, privateKey, s.logger, nil)
+	s.Require().NoError(err)
+	_, err = s.m.Start()
+	s.Require().NoError(err)
+
+	s.tmpDir, err = ioutil.TempDir("", "transcript-tests-")
+	s.Require().NoError(err)
+}
+
+func (s *MessengerTranscriptSuite) TearDownTest() {
+	s.Require().NoError(s.m.Shutdown())
+	s.Require().NoError(os.RemoveAll(s.tmpDir))
+}
+
+func (s *MessengerTranscriptSuite) TestExportChatTranscript() {
+	contactKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	contactID := types.EncodeHex(crypto.FromECDSAPub(&contactKey.PublicKey))
+	_, err = s.m.AddContact(context.Background(), &requests.AddContact{ID: types.Hex2Bytes(contactID)})
+	s.Require().NoError(err)
+	_, err = s.m.SetContactLocalNickname(&requests.SetContactLocalNickname{ID: types.Hex2Bytes(contactID), Nickname: "carol"})
+	s.Require().NoError(err)
+
+	_, err = s.m.CreatePublicChat(&requests.CreatePublicChat{ID: publicChatName})
+	s.Require().NoError(err)
+	chat, ok := s.m.allChats.Load(publicChatName)
+	s.Require().True(ok)
+
+	ourID := common.PubkeyToHex(&s.m.identity.PublicKey)
+
+	question := buildTestMessage(*chat)
+	question.ID = "0x01"
+	question.From = contactID
+	question.Text = "is anyone here?"
+	question.Clock = 1
+
+	answer := buildTestMessage(*chat)
+	answer.ID = "0x02"
+	answer.From = ourID
+	answer.Text = "yes"
+	answer.ResponseTo = question.ID
+	answer.Clock = 2
+
+	imagePayload, err := ioutil.ReadFile("../_assets/tests/test.jpg")
+	s.Require().NoError(err)
+	image := buildTestMessage(*chat)
+	image.ID = "0x03"
+	image.From = contactID
+	image.Clock = 3
+	image.ContentType = protobuf.ChatMessage_IMAGE
+	image.Payload = &protobuf.ChatMessage_Image{Image: &protobuf.ImageMessage{Payload: imagePayload, Type: protobuf.ImageType_JPEG}}
+
+	deleted := buildTestMessage(*chat)
+	deleted.ID = "0x04"
+	deleted.From = contactID
+	deleted.Text = "secret"
+	deleted.Deleted = true
+	deleted.Clock = 4
+
+	s.Require().NoError(s.m.SaveMessages([]*common.Message{question, answer, image, deleted}))
+
+	s.Require().NoError(s.m.persistence.SaveEmojiReaction(&EmojiReaction{
+		EmojiReaction: protobuf.EmojiReaction{
+			Clock:     5,
+			MessageId: question.ID,
+			ChatId:    chat.ID,
+			Type:      protobuf.EmojiReaction_THUMBS_UP,
+		},
+		From:        ourID,
+		LocalChatID: chat.ID,
+	}))
+
+	s.Require().NoError(s.m.persistence.SavePinMessages([]*common.PinMessage{{
+		PinMessage: protobuf.PinMessage{
+			Clock:     6,
+			MessageId: answer.ID,
+			ChatId:    chat.ID,
+			Pinned:    true,
+		},
+		ID:          "0x05",
+		From:        contactID,
+		LocalChatID: chat.ID,
+	}}))
+
+	path := filepath.Join(s.tmpDir, "transcript.json")
+	err = s.m.ExportChatTranscript(context.Background(), &requests.ExportChatTranscript{
+		ChatID: chat.ID,
+		Path:   path,
+		Format: transcript.FormatJSON,
+	})
+	s.Require().NoError(err)
+
+	data, err := ioutil.ReadFile(path)
+	s.Require().NoError(err)
+
+	var result struct {
+		Chat     *transcript.Chat      `json:"chat"`
+		Messages []*transcript.Message `json:"messages"`
+	}
+	s.Require().NoError(json.Unmarshal(data, &result))
+	s.Require().Equal(chat.ID, result.Chat.ID)
+	s.Require().Len(result.Messages, 4)
+
+	// Messages are in chronological order
+	s.Require().Equal(question.ID, result.Messages[0].ID)
+	s.Require().Equal("carol", result.Messages[0].Author.DisplayName)
+	s.Require().Len(result.Messages[0].Reactions, 1)
+	s.Require().Equal("👍", result.Messages[0].Reactions[0].Emoji)
+	s.Require().Equal(ourID, result.Messages[0].Reactions[0].Author.ID)
+
+	s.Require().Equal(answer.ID, result.Messages[1].ID)
+	s.Require().NotEmpty(result.Messages[1].Author.DisplayName)
+	s.Require().NotNil(result.Messages[1].ReplyTo)
+	s.Require().Equal(question.ID, result.Messages[1].ReplyTo.ID)
+	s.Require().Equal("is anyone here?", result.Messages[1].ReplyTo.Text)
+	s.Require().Equal("carol", result.Messages[1].ReplyTo.Author.DisplayName)
+	s.Require().NotNil(result.Messages[1].Pin)
+	s.Require().Equal("carol", result.Messages[1].Pin.PinnedBy.DisplayName)
+
+	s.Require().Equal("transcript_files/0x03.jpeg", result.Messages[2].Image)
+	exportedImage, err := ioutil.ReadFile(filepath.Join(s.tmpDir, result.Messages[2].Image))
+	s.Require().NoError(err)
+	s.Require().Equal(imagePayload, exportedImage)
+
+	// The content of deleted messages is not exported
+	s.Require().True(result.Messages[3].Deleted)
+	s.Require().Empty(result.Messages[3].Text)
+}
+
+func (s *MessengerTranscriptSuite) TestExportChatTranscriptUnknownChat() {
+	err := s.m.ExportChatTranscript(context.Background(), &requests.ExportChatTranscript{
+		ChatID: "unknown",
+		Path:   filepath.Join(s.tmpDir, "transcript.md"),
+		Format: transcript.FormatMarkdown,
+	})
+	s.Require().Equal(ErrChatNotFound, err)
+}
@@ -0,0 +1,134 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// typingIndicatorInterval is how often we let the members of a chat know
+// that we are still typing
+const typingIndicatorInterval = 3 * time.Second
+
+// typingIndicatorsEnabled returns whether we send typing indicators in a chat
+func (m *Messenger) typingIndicatorsEnabled(chat *Chat) bool {
+	if !chatPrivacySettingsAllowed(chat) {
+		return false
+	}
+
+	enabled, err := m.settings.SendTypingIndicators()
+	if err != nil {
+		m.logger.Error("failed to get typing indicators setting", zap.Error(err))
+		return false
+	}
+
+	return chat.TypingIndicators.Enabled(enabled)
+}
+
+// SendTypingIndicator lets the members of a chat that are online know that we
+// started or stopped typing. It can be called on each keystroke, as we only
+// send it again once typingIndicatorInterval elapsed
+func (m *Messenger) SendTypingIndicator(ctx context.Context, chatID string, typing bool) error {
+	chat, ok := m.allChats.Load(chatID)
+	if !ok {
+		return ErrChatNotFound
+	}
+
+	if !chatPrivacySettingsAllowed(chat) {
+		return ErrChatPrivacySettingsNotAllowed
+	}
+
+	// Typing indicators are not stored by mailservers, there is no point in
+	// sending them while offline
+	if !m.typingIndicatorsEnabled(chat) || !m.online() {
+		return nil
+	}
+
+	if !m.shouldSendTypingIndicator(chat.ID, typing) {
+		return nil
+	}
+
+	clock, _ := chat.NextClockAndTimestamp(m.getTimesource())
+
+	indicator := &TypingIndicator{
+		TypingIndicator: protobuf.TypingIndicator{
+			Clock:  clock,
+			ChatId: chat.ID,
+			Typing: typing,
+		},
+	}
+
+	encodedMessage, err := m.encodeChatEntity(chat, indicator)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.dispatchMessage(ctx, common.RawMessage{
+		LocalChatID:          chat.ID,
+		Payload:              encodedMessage,
+		SkipGroupMessageWrap: true,
+		MessageType:          protobuf.ApplicationMetadataMessage_TYPING_INDICATOR,
+		Ephemeral:            true,
+	})
+	return err
+}
+
+// shouldSendTypingIndicator throttles the typing indicators sent in a chat
+func (m *Messenger) shouldSendTypingIndicator(chatID string, typing bool) bool {
+	m.typingIndicatorsMutex.Lock()
+	defer m.typingIndicatorsMutex.Unlock()
+
+	lastSent, sent := m.typingIndicatorsSent[chatID]
+	if !typing {
+		// Nothing to stop if the others don't know we are typing
+		delete(m.typingIndicatorsSent, chatID)
+		return sent
+	}
+
+	now := m.getTimesource().GetCurrentTime()
+	if sent && now-lastSent < uint64(typingIndicatorInterval.Milliseconds()) {
+		return false
+	}
+
+	m.typingIndicatorsSent[chatID] = now
+	return true
+}
+
+// HandleTypingIndicator passes to the client that a member of a chat started
+// or stopped typing
+func (m *Messenger) HandleTypingIndicator(state *ReceivedMessageState, pbIndicator protobuf.TypingIndicator) error {
+	logger := m.logger.With(zap.String("site", "HandleTypingIndicator"))
+	if err := ValidateTypingIndicator(pbIndicator, state.CurrentMessageState.WhisperTimestamp); err != nil {
+		logger.Error("invalid typing indicator", zap.Error(err))
+		return err
+	}
+
+	indicator := &TypingIndicator{
+		TypingIndicator: pbIndicator,
+		SigPubKey:       state.CurrentMessageState.PublicKey,
+		From:            state.CurrentMessageState.Contact.ID,
+	}
+
+	if common.IsPubKeyEqual(indicator.SigPubKey, &m.identity.PublicKey) {
+		return nil
+	}
+
+	chat, err := m.matchChatEntity(indicator)
+	if err != nil {
+		return err // matchChatEntity returns a descriptive error message
+	}
+
+	// Typing indicators don't create chats
+	if _, ok := m.allChats.Load(chat.ID); !ok {
+		return nil
+	}
+
+	indicator.LocalChatID = chat.ID
+	state.Response.AddTypingIndicator(indicator)
+
+	return nil
+}
@@ -49,6 +49,16 @@
 // 1634896007_add_last_updated_locally_and_removed.up.sql (131B)
 // 1635840039_add_clock_read_at_column_in_chats.up.sql (245B)
 // 1637852321_add_received_invitation_admin_column_in_chats.up.sql (72B)
+// 1638189911_add_thread_id_to_user_messages.up.sql (167B)
+// 1638281113_add_polls.up.sql (610B)
+// 1638364827_add_files.up.sql (587B)
+// 1638450241_add_disappearing_messages.up.sql (307B)
+// 1638537600_add_messages_fts.up.sql (1.309kB)
+// 1638624000_add_scheduled_messages.up.sql (418B)
+// 1638710400_add_chat_drafts.up.sql (190B)
+// 1638796800_add_outbox.up.sql (510B)
+// 1638883200_add_read_receipts_typing_indicators.up.sql (138B)
+// 1638969600_add_communities_member_actions.up.sql (198B)
 // README.md (554B)
 // doc.go (850B)
 
@@ -1099,6 +1109,206 @@ func _1637852321_add_received_invitation_admin_column_in_chatsUpSql() (*asset, e
 	return a, nil
 }
 
+var __1638189911_add_thread_id_to_user_messagesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\xcc\xb1\x0a\xc2\x30\x10\x06\xe0\xbd\x4f\xf1\xd3\x49\xc1\x37\xe8\x74\x26\x27\x0a\x67\x02\x21\x11\xb7\x10\xda\x43\xc5\x8a\xd0\x58\x9f\xdf\xad\xd8\xfd\xe3\x23\x89\x1c\x10\x69\x2f\x8c\xb9\xea\x94\x5f\x5a\x6b\xb9\x69\x05\x59\x0b\xe3\x25\x9d\x1d\x3e\xf7\x49\xcb\x90\x1f\x03\x2e\x14\xcc\x91\x02\x9c\x8f\x70\x49\x04\x96\x0f\x94\x24\xa2\x6d\xbb\xc6\x04\xa6\xc8\x38\x39\xcb\xd7\x75\x96\x97\x21\xf7\xe3\xbb\x7f\xe6\x6f\x19\x67\x85\x77\x6b\xb6\x59\xd8\x0e\x7f\x6e\xdb\x35\xbf\x00\x00\x00\xff\xff\x86\x6d\x4e\x50\xa7\x00\x00\x00")
+
+func _1638189911_add_thread_id_to_user_messagesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638189911_add_thread_id_to_user_messagesUpSql,
+		"1638189911_add_thread_id_to_user_messages.up.sql",
+	)
+}
+
+func _1638189911_add_thread_id_to_user_messagesUpSql() (*asset, error) {
+	bytes, err := _1638189911_add_thread_id_to_user_messagesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638189911_add_thread_id_to_user_messages.up.sql", size: 167, mode: os.FileMode(0644), modTime: time.Unix(1792274132, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x1a, 0xff, 0x60, 0xcd, 0x92, 0xe6, 0x1, 0xb0, 0x45, 0x17, 0xe8, 0x5f, 0xb9, 0x65, 0x97, 0xa5, 0x63, 0xf2, 0xb3, 0x84, 0x76, 0x2d, 0x27, 0x39, 0x7d, 0x5b, 0x37, 0xeb, 0x46, 0xf3, 0x22}}
+	return a, nil
+}
+
+var __1638281113_add_pollsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xc1\x6e\x32\x21\x14\x85\xf7\xf3\x14\x27\xae\x34\xf1\x0d\xfe\x15\x33\x73\x27\x3f\x29\x82\x41\x6c\x74\x45\x08\x92\xd6\x94\x16\x33\x8c\x26\x7d\xfb\xc6\x76\x52\x69\x5a\x6d\xb7\x7c\x87\xcb\xe1\xbb\x4c\x18\xd2\x30\xac\x16\x84\x63\x0e\xbd\x7d\x0e\x39\xbb\x87\x90\xc1\xda\x16\x8d\x12\xeb\x85\xc4\x21\xc5\x68\x0f\xee\x35\x26\xb7\x43\x2d\x54\xfd\xaf\xaa\x1a\x4d\xcc\xd0\x78\x93\x77\x90\xca\x80\x36\x7c\x65\x56\x1f\xf1\x53\x1a\x42\xc6\xb4\x02\xf6\x3b\xdc\x33\xdd\xfc\x67\x1a\x4b\xcd\x17\x4c\x6f\x71\x47\x5b\x28\x89\x46\xc9\x4e\xf0\xc6\x40\xd3\x52\xb0\x86\xe6\x15\xe0\x63\xf2\x4f\xf6\xe4\xe2\x31\x80\x4b\xf3\x3e\x57\xae\x85\x38\xb3\x9c\x8e\xbd\x0f\x30\xb4\xf9\x7a\x3e\x76\xb6\xc5\x4b\x25\xf6\x8f\x6e\xb8\xc6\x62\xf2\x2e\xda\x5b\x89\x74\x18\xf6\xe9\x25\x7f\x63\x68\xa9\x63\x6b\x61\x30\x99\x9c\x63\x7d\x18\x7a\xe7\x87\xb0\x43\xad\x94\x20\x26\x3f\x79\xc7\xc4\x8a\xaa\xd9\xc5\x19\x97\x2d\x6d\x0a\x4b\xb6\xe8\xaf\x64\x01\xa6\x17\x30\xfb\x83\x72\x1f\x53\x1e\x9d\xff\x62\xe4\x9a\xc8\x5b\xf2\xcb\xdd\x15\xc5\xe6\xe3\xb0\xd9\x4f\x0b\x3d\xff\xfa\x2d\x00\x00\xff\xff\x0d\x75\xd0\x52\x62\x02\x00\x00")
+
+func _1638281113_add_pollsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638281113_add_pollsUpSql,
+		"1638281113_add_polls.up.sql",
+	)
+}
+
+func _1638281113_add_pollsUpSql() (*asset, error) {
+	bytes, err := _1638281113_add_pollsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638281113_add_polls.up.sql", size: 610, mode: os.FileMode(0644), modTime: time.Unix(1792274586, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9f, 0x79, 0xcc, 0x3f, 0x46, 0xd9, 0x3d, 0x65, 0x93, 0x21, 0xb0, 0x51, 0xb6, 0xeb, 0x79, 0x7b, 0xf8, 0xf3, 0x96, 0x8f, 0x23, 0x6f, 0xa6, 0xbd, 0xd9, 0x86, 0x76, 0x23, 0x93, 0xb5, 0x18, 0x5b}}
+	return a, nil
+}
+
+var __1638364827_add_filesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x41\x4f\x83\x30\x1c\xc5\xef\xfd\x14\x2f\x3b\xb1\x84\x83\xf7\x9d\x0a\x14\x6d\xec\x5a\xd3\x15\xb3\x9d\x1a\x02\x55\x88\x13\x0c\x15\xa3\xdf\xde\xb0\x99\x0d\x02\x26\x3b\xff\xfa\x7f\xef\xd7\x47\x85\x61\x1a\x86\x46\x82\xa1\xf7\xae\xb3\xef\xce\xfb\xfc\xd5\x79\xd0\x24\x41\xac\x44\xb6\x95\x78\xa9\x8f\xce\x7e\xe4\x3f\xc7\x36\x2f\x11\x09\x15\x6d\xc8\xed\x77\x55\xee\x2b\x3c\x53\x1d\x3f\x50\x0d\xa9\x0c\x64\x26\x04\x12\x96\xd2\x4c\x18\xac\x56\x1b\x42\x62\xcd\xa8\x61\xe0\x32\x61\xfb\x69\x9a\xbd\x46\x28\x39\x45\xc1\x05\xad\xaf\x11\x67\x21\x9e\x9e\x8a\xd8\x9e\xef\xcc\xee\x6c\x51\x54\x7d\xf3\xe6\x11\x10\x60\x51\x28\x24\x80\x6f\xfb\xae\x70\x8b\xe8\x74\x6e\xeb\xa6\x74\xdf\xe0\xd2\xcc\x99\xb7\x45\xdb\x37\x9f\x33\x38\x5e\x6d\x02\x3a\x57\xb8\xfa\xcb\x95\x36\x9f\x1e\x5d\x96\xb9\x1b\x5e\x3d\x69\xbe\xa5\xfa\x80\x47\x76\x40\x30\x88\x87\x7f\x96\xe1\x58\x69\x3d\x8c\x13\x2b\x99\x0a\x1e\x1b\xf0\x7b\xa9\x34\x23\x37\xac\xb2\xb0\xc7\xb8\x70\x1e\xfa\xef\x8f\x86\xb6\xdf\x00\x00\x00\xff\xff\x94\x69\xae\xee\x4b\x02\x00\x00")
+
+func _1638364827_add_filesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638364827_add_filesUpSql,
+		"1638364827_add_files.up.sql",
+	)
+}
+
+func _1638364827_add_filesUpSql() (*asset, error) {
+	bytes, err := _1638364827_add_filesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638364827_add_files.up.sql", size: 587, mode: os.FileMode(0644), modTime: time.Unix(1792275191, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x54, 0xd, 0xbc, 0x8d, 0x74, 0xa4, 0x3a, 0xd8, 0xab, 0x7e, 0xe2, 0x90, 0xcc, 0x5c, 0x3b, 0x4f, 0x14, 0x71, 0xe9, 0xd7, 0x39, 0x64, 0xa0, 0x75, 0xf0, 0x7f, 0xad, 0x97, 0x43, 0x34, 0xf8, 0xca}}
+	return a, nil
+}
+
+var __1638450241_add_disappearing_messagesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcd\x31\x0b\xc2\x30\x10\xc5\xf1\xbd\x9f\xe2\xb9\xe9\xe6\x2e\x0e\xb1\x39\xb1\x10\x53\x28\x29\xba\x1d\xa1\x1c\x2a\x56\x94\x5e\x2a\x7e\x7c\x71\x10\xdb\x41\xe8\xfc\xe7\xfd\x9e\x71\x81\x2a\x04\xb3\x71\x84\xe6\x1c\x93\xc2\x58\x8b\xbc\x74\xf5\xde\xe3\x26\xaa\xf1\x24\xca\x29\xb5\x28\x7c\x80\x2f\x03\x7c\xed\x1c\x2c\x6d\x4d\xed\x02\x96\xab\x6c\xb2\xc0\x4d\x7b\x6f\xae\xfc\x8c\x6d\x2f\x93\xb4\x5e\xa5\xe3\xaf\x30\x54\xe5\xf5\xb8\x74\xa2\x1c\xd3\x5f\x27\xcb\x2b\x32\x81\x50\x78\x4b\xc7\xb1\xc4\x83\x79\xe9\xc7\x6d\xfe\x6b\x0b\x1c\x76\x54\xd1\xf0\x6c\xb6\xfe\xd0\xef\x00\x00\x00\xff\xff\x4a\x81\x7c\xd4\x33\x01\x00\x00")
+
+func _1638450241_add_disappearing_messagesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638450241_add_disappearing_messagesUpSql,
+		"1638450241_add_disappearing_messages.up.sql",
+	)
+}
+
+func _1638450241_add_disappearing_messagesUpSql() (*asset, error) {
+	bytes, err := _1638450241_add_disappearing_messagesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638450241_add_disappearing_messages.up.sql", size: 307, mode: os.FileMode(0644), modTime: time.Unix(1792275700, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb2, 0xa3, 0xd4, 0x6, 0xdf, 0xc3, 0x1d, 0xe3, 0xa7, 0x73, 0x34, 0x26, 0xd6, 0xe0, 0xee, 0xed, 0x2b, 0x7f, 0x5a, 0x80, 0x5e, 0x88, 0x5b, 0xfb, 0x4d, 0x6, 0x3f, 0x91, 0xe5, 0x35, 0x5a, 0xb}}
+	return a, nil
+}
+
+var __1638537600_add_messages_ftsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x94\x4f\x6f\xda\x4c\x10\xc6\xef\xfe\x14\x8f\xb8\x04\x24\xf3\x4a\x79\x9b\xf6\x82\x38\x10\xb2\x50\x4b\x8e\x49\xcd\x92\x1e\x91\xe3\x1d\xe3\x51\x60\xd7\xdd\x5d\x87\xa8\x9f\xbe\xf2\x1f\x52\x45\x89\x9a\x54\x6a\xd5\x8b\x0f\xde\x79\x66\x7e\xf3\x93\xbd\xe3\x31\x64\x49\x58\x7f\x89\xe7\x5c\x95\x64\x71\x57\xf3\x5e\xe1\x48\xd8\xb3\xbe\x47\xb6\xcb\x58\x3b\x0f\x65\xc8\xe9\x33\x0f\xd6\xf9\xbe\x56\x84\x85\x5c\x7f\x0c\x9b\xe7\x05\x2a\x6b\x1e\x58\x91\x83\x2f\x29\x18\x8f\xe1\xb2\x03\xc1\x9b\x7b\xd2\xfc\x9d\x6c\x88\xca\x52\xc1\x8f\xf8\x56\x93\x65\x72\xc8\xb4\x82\xd3\x5c\x55\xe4\x5d\x30\x4f\xc5\x4c\x0a\xdc\x46\xa9\xdc\xcc\x62\xc8\xd9\x65\x2c\x50\x3b\xb2\xdb\x03\x39\x97\xed\xc8\x6d\x0b\xef\xb0\x59\x47\xc9\x12\x85\x77\x17\xc3\xdc\x68\x4f\xda\x4f\x07\xcf\xaa\x06\x21\x3c\x3d\xfa\xf0\x69\xee\xb4\xd6\x9c\x1b\x45\x9f\xce\x31\xb0\x74\x30\x0f\xb4\x55\x9c\xe5\x96\x3d\xe7\x6e\x7a\x3e\x38\x61\x4d\x07\xff\x87\x1f\x06\xa3\x49\xd0\x90\x5f\xf7\xdd\x90\x59\x02\x6b\x47\xd6\x93\xc2\x91\x7d\x89\x55\x82\xf9\x2a\x59\xc4\xd1\x5c\x22\x15\x37\xf1\x6c\x2e\x42\x1c\x4b\xce\xcb\x27\x35\x05\x5b\x3a\x39\x50\xb4\x27\x4f\xf0\x96\x77\x3b\xb2\x2e\x84\x33\xb0\x54\xed\xb3\x9c\x14\xac\x39\x76\x23\x3a\x30\x85\xc2\x9a\x43\x93\x04\x6b\x45\x8f\xb8\xa3\xc2\x58\x2a\x33\xad\x4e\x7e\x64\x1a\x2d\x97\x22\x7d\x69\x66\xdb\xd5\x6e\x3b\x58\x5c\x8a\xc5\x2a\x15\x88\x92\xb5\x48\x65\xc3\xfc\x2c\x80\x4b\xb1\x8c\x92\x00\xb8\x12\xb1\x90\x02\x8b\x74\x75\xfd\x8a\xec\xaf\x9f\x45\x2a\xa0\x4c\xce\x0a\x51\x82\xe1\x5a\xc4\x62\x2e\x1b\x6a\x56\xaf\x64\xfa\x7a\x56\x98\x42\xd3\xf1\x3f\x56\xa3\x49\x20\x92\xab\x49\xf0\x36\x7d\x56\x78\xb2\x27\xf8\xd9\x42\x8a\xf4\x4d\xf6\xfe\x3c\x4a\xe4\xea\x65\xc3\x61\x4b\xdd\x7d\x0b\x23\xdc\xce\xe2\x8d\x58\x63\xd8\x50\xb5\xf4\x61\x0b\xd8\x1e\xbe\x1b\xb1\x17\x5c\x57\x2a\xf3\x74\x12\xbc\xb9\xb9\x6a\x52\xab\x45\x3b\xe9\x4f\x88\x9e\xc2\xec\x55\x47\xf9\x9b\xf6\x7a\xb2\xce\xde\x7b\xc1\xfe\x91\xc5\xfe\xb7\xe8\x2d\xf6\x76\xfe\x8e\xbd\x5f\x6f\xf8\xe2\xcd\xcf\x3d\xcf\x2c\xb5\x57\xe0\xd9\x68\x12\xfc\x08\x00\x00\xff\xff\xb5\xfd\x2a\x0b\x1d\x05\x00\x00")
+
+func _1638537600_add_messages_ftsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638537600_add_messages_ftsUpSql,
+		"1638537600_add_messages_fts.up.sql",
+	)
+}
+
+func _1638537600_add_messages_ftsUpSql() (*asset, error) {
+	bytes, err := _1638537600_add_messages_ftsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638537600_add_messages_fts.up.sql", size: 1309, mode: os.FileMode(0644), modTime: time.Unix(1792276565, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x51, 0x52, 0xb1, 0xf1, 0xa2, 0x6e, 0x72, 0x27, 0x1f, 0xd0, 0xde, 0x72, 0xb6, 0x6, 0x7a, 0xd8, 0x18, 0x9f, 0x1a, 0xa0, 0xb4, 0xaa, 0x52, 0x91, 0x9c, 0xe0, 0xf6, 0xa, 0x97, 0x36, 0xb0, 0x13}}
+	return a, nil
+}
+
+var __1638624000_add_scheduled_messagesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\x41\x6a\xc3\x30\x14\x04\xd0\xbd\x4e\x31\x64\x95\x40\x6f\x90\x95\x9c\x28\x54\x54\x95\x8b\xa2\x94\x64\x25\x3e\x96\x1a\x0b\x14\x14\x2c\x7b\xd1\xdb\x97\x96\x42\x6d\x6a\x82\xd7\xf3\xfe\xc0\x9f\x9d\x11\xdc\x0a\x58\x5e\x29\x01\x79\x80\xae\x2d\xc4\x59\x1e\xed\x11\xa5\x69\x83\x1f\x52\xf0\xee\x16\x4a\xa1\x6b\x28\x58\x33\x20\x7a\xbc\x73\xb3\x7b\xe6\x06\x6f\x46\xbe\x72\x73\xc1\x8b\xb8\xfc\x1c\xea\x93\x52\x4f\x0c\x48\xb9\xa1\xe4\x9a\x96\x7a\x37\xd2\x63\xf1\xd7\x4d\x3d\xa4\xb6\x93\xf0\x4e\x9f\x29\x93\x47\xa5\xea\x6a\x12\xc4\x1b\x5d\x83\xbb\x53\xdf\xfe\x2b\xc5\x5e\x1c\xf8\x49\x59\xac\x56\xdf\x92\x06\x1f\xf3\x22\xf9\x11\xd3\xb2\xca\xd0\x75\xb9\x7b\x84\xd8\x66\xcb\xd8\xef\x9e\x52\xef\xc5\x79\x66\x41\x37\x79\xbc\xd6\x33\x64\x3d\x26\x9b\x2d\xfb\x0a\x00\x00\xff\xff\xf6\x1f\xc1\xc2\xa2\x01\x00\x00")
+
+func _1638624000_add_scheduled_messagesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638624000_add_scheduled_messagesUpSql,
+		"1638624000_add_scheduled_messages.up.sql",
+	)
+}
+
+func _1638624000_add_scheduled_messagesUpSql() (*asset, error) {
+	bytes, err := _1638624000_add_scheduled_messagesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638624000_add_scheduled_messages.up.sql", size: 418, mode: os.FileMode(0644), modTime: time.Unix(1792276981, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1a, 0x33, 0xd3, 0xa0, 0xd6, 0xa3, 0xfa, 0x66, 0x6d, 0x1e, 0x3b, 0xc1, 0xb9, 0xb5, 0x4f, 0x7d, 0xce, 0xcc, 0xda, 0x91, 0x67, 0x56, 0x97, 0xf8, 0x77, 0x27, 0x20, 0x72, 0x2f, 0x32, 0x2d, 0x7b}}
+	return a, nil
+}
+
+var __1638710400_add_chat_draftsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\xc1\xca\x82\x40\x14\xc5\xf1\xfd\x3c\xc5\xc1\xd5\xf7\x41\x6f\xd0\xea\x36\x5d\x69\x68\x1a\x65\xbc\x46\xae\x44\x74\xa2\x48\x32\x74\x8a\x1e\x3f\x24\x70\xd3\xf2\xf0\xff\x71\xb4\x67\x12\x86\xd0\xc6\x32\x4c\x0a\x97\x09\xf8\x64\x0a\x29\xd0\x5e\x9a\x58\x77\x63\x73\x8e\x13\xfe\x14\xbe\xfb\xda\xe1\x48\x5e\xef\xc8\x23\xf7\xe6\x40\xbe\xc2\x9e\x2b\x64\x0e\x3a\x73\xa9\x35\x5a\xe0\x39\xb7\xa4\x79\xa5\x80\x18\xde\x71\xf1\xf3\xb5\x2b\xad\x9d\xc3\x18\xa6\xc7\x70\x9f\x42\x1d\x87\x9f\x8e\x2d\xa7\x54\x5a\x41\x92\xcc\xb4\xed\x87\xf6\x56\xbf\x9a\xfe\x19\x60\x9c\x2c\x4c\xfd\xaf\xd5\x27\x00\x00\xff\xff\x52\x3c\x82\xff\xbe\x00\x00\x00")
+
+func _1638710400_add_chat_draftsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638710400_add_chat_draftsUpSql,
+		"1638710400_add_chat_drafts.up.sql",
+	)
+}
+
+func _1638710400_add_chat_draftsUpSql() (*asset, error) {
+	bytes, err := _1638710400_add_chat_draftsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638710400_add_chat_drafts.up.sql", size: 190, mode: os.FileMode(0644), modTime: time.Unix(1792277365, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8e, 0x4d, 0x61, 0x96, 0x5, 0x4, 0x6b, 0xe5, 0x6a, 0xaf, 0x20, 0x7, 0x67, 0xf, 0x5c, 0x63, 0x1d, 0xdd, 0xc5, 0x42, 0x61, 0xf, 0xf7, 0x1, 0x2b, 0x64, 0x68, 0xdc, 0x7e, 0x87, 0xc2, 0x3a}}
+	return a, nil
+}
+
+var __1638796800_add_outboxUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\x41\x6b\x02\x31\x10\x85\xef\xf9\x15\x83\x27\x05\x0f\xbd\xef\x29\xae\xb3\x34\x34\x4d\x24\x1b\x8b\x9e\x42\xba\x86\x56\xd8\x35\xb2\x19\x41\xff\x7d\x71\x59\xb6\x0d\x95\xd2\x6b\xde\x37\x6f\xde\xbc\x94\x06\xb9\x45\xb0\x7c\x25\x11\x44\x05\x4a\x5b\xc0\x9d\xa8\x6d\x0d\xf1\x42\xef\xf1\x0a\x73\x06\x70\x3c\xc0\x1b\x37\xe5\x33\x37\xb0\x31\xe2\x95\x9b\x3d\xbc\xe0\x1e\xb4\x82\x52\xab\x4a\x8a\xd2\x82\xc1\x8d\xe4\x25\x2e\x19\x40\x1b\x1b\xdf\xba\xe6\xd3\x93\xfb\x31\x78\x77\x56\x5b\x29\xef\x44\x17\x52\xf2\x1f\xc1\xd1\xed\x1c\x40\x28\x9b\x89\x89\x3c\x85\x87\x63\x7d\x48\xe1\x74\x70\xfe\x42\xb1\xf3\x74\x6c\x7c\xdb\xde\x60\xa5\xb5\x44\xae\x26\x10\xd6\x58\xf1\xad\xb4\x50\x71\x59\x0f\x79\x3c\x51\xe8\xce\x94\xb2\x4d\x13\xf6\x34\x44\xf6\x89\xdc\xc8\xfd\x81\x9d\xc2\xf5\x3f\x58\xe8\xfb\xd8\xff\xba\x60\x62\x66\x33\xb6\x28\x18\x1b\xab\x17\x6a\x8d\xbb\xb1\x6c\x37\xdc\xee\xb2\x35\x5a\x8d\xe2\x7c\x10\x97\x59\x88\x45\xf1\xd0\x26\xff\x81\x6f\x87\xec\x7d\x51\xb0\xaf\x00\x00\x00\xff\xff\xd7\x33\x9d\x09\xfe\x01\x00\x00")
+
+func _1638796800_add_outboxUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638796800_add_outboxUpSql,
+		"1638796800_add_outbox.up.sql",
+	)
+}
+
+func _1638796800_add_outboxUpSql() (*asset, error) {
+	bytes, err := _1638796800_add_outboxUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638796800_add_outbox.up.sql", size: 510, mode: os.FileMode(0644), modTime: time.Unix(1792280166, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x39, 0xb3, 0x30, 0xe5, 0xd0, 0xb8, 0x8c, 0xa2, 0x6a, 0xea, 0xba, 0xc3, 0x1d, 0xe0, 0x8b, 0x70, 0xfa, 0xfd, 0xc0, 0xbc, 0x56, 0x2e, 0x7f, 0x14, 0xf4, 0xd7, 0xd5, 0x15, 0x32, 0x5b, 0x67, 0x9f}}
+	return a, nil
+}
+
+var __1638883200_add_read_receipts_typing_indicatorsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\x2c\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x4a\x4d\x4c\x89\x2f\x4a\x4d\x4e\xcd\x2c\x28\x29\x56\xf0\xf4\x0b\x51\xf0\xf3\x0f\x51\xf0\x0b\xf5\xf1\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\x30\xb0\xe6\xc2\x6b\x44\x49\x65\x41\x66\x5e\x7a\x7c\x66\x5e\x4a\x66\x72\x62\x49\x7e\x11\x6e\x63\x00\x01\x00\x00\xff\xff\x2a\xa0\x88\xee\x8a\x00\x00\x00")
+
+func _1638883200_add_read_receipts_typing_indicatorsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638883200_add_read_receipts_typing_indicatorsUpSql,
+		"1638883200_add_read_receipts_typing_indicators.up.sql",
+	)
+}
+
+func _1638883200_add_read_receipts_typing_indicatorsUpSql() (*asset, error) {
+	bytes, err := _1638883200_add_read_receipts_typing_indicatorsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638883200_add_read_receipts_typing_indicators.up.sql", size: 138, mode: os.FileMode(0644), modTime: time.Unix(1792281555, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8e, 0xca, 0xca, 0x6f, 0xb4, 0x3, 0x82, 0x50, 0x1a, 0x4c, 0x65, 0xdd, 0xde, 0x9c, 0x41, 0xb3, 0x64, 0x24, 0xfb, 0xf7, 0xa4, 0xab, 0x31, 0x5f, 0xe7, 0x2f, 0x47, 0x6f, 0x20, 0x9b, 0x95, 0xe7}}
+	return a, nil
+}
+
+var __1638969600_add_communities_member_actionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8c\xb1\xaa\x83\x30\x18\x46\xf7\x3c\xc5\x37\x2a\xf8\x06\x77\x8a\xe1\x17\xc2\x4d\x13\x89\x7f\x41\x27\x69\xa3\x43\x68\x63\xa0\xda\xa1\x6f\x5f\x28\xa5\xad\xeb\x39\x9c\xa3\x3c\x49\x26\xb0\xac\x0d\x41\x37\xb0\x8e\x41\xbd\xee\xb8\x43\xc8\x29\xdd\x97\xb8\xc5\x79\x1d\xd3\x9c\xce\xf3\x6d\x3c\x85\x2d\xe6\x65\x45\x21\xf0\xd1\x8f\x31\x4e\xa8\x8d\xab\x5f\xad\x3d\x1a\x53\x09\xe0\x1d\xc4\x09\x4c\x3d\xef\x54\xb8\xe6\x70\x81\xb6\x7b\xda\x7a\x7d\x90\x7e\xc0\x3f\x0d\x28\x7e\xdf\xd5\xf7\x55\xc2\x59\x28\x67\x1b\xa3\x15\xc3\x53\x6b\xa4\x22\x51\xfe\x89\x67\x00\x00\x00\xff\xff\xd4\x17\xa1\xd8\xc6\x00\x00\x00")
+
+func _1638969600_add_communities_member_actionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1638969600_add_communities_member_actionsUpSql,
+		"1638969600_add_communities_member_actions.up.sql",
+	)
+}
+
+func _1638969600_add_communities_member_actionsUpSql() (*asset, error) {
+	bytes, err := _1638969600_add_communities_member_actionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1638969600_add_communities_member_actions.up.sql", size: 198, mode: os.FileMode(0644), modTime: time.Unix(1792288219, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0xb, 0x2f, 0x19, 0x36, 0xbb, 0xf6, 0x81, 0x2e, 0x28, 0x4f, 0xc1, 0x2c, 0x75, 0x8f, 0xcc, 0x19, 0x95, 0x35, 0x6b, 0xa9, 0x1b, 0x6e, 0x30, 0x43, 0xc1, 0x69, 0xb8, 0x21, 0xcd, 0x2, 0xa}}
+	return a, nil
+}
+
 var _readmeMd = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x91\xc1\xce\xd3\x30\x10\x84\xef\x7e\x8a\x91\x7a\x01\xa9\x2a\x8f\xc0\x0d\x71\x82\x03\x48\x1c\xc9\x36\x9e\x36\x96\x1c\x6f\xf0\xae\x93\xe6\xed\x91\xa3\xc2\xdf\xff\x66\xed\xd8\x33\xdf\x78\x4f\xa7\x13\xbe\xea\x06\x57\x6c\x35\x39\x31\xa7\x7b\x15\x4f\x5a\xec\x73\x08\xbf\x08\x2d\x79\x7f\x4a\x43\x5b\x86\x17\xfd\x8c\x21\xea\x56\x5e\x47\x90\x4a\x14\x75\x48\xde\x64\x37\x2c\x6a\x96\xae\x99\x48\x05\xf6\x27\x77\x13\xad\x08\xae\x8a\x51\xe7\x25\xf3\xf1\xa9\x9f\xf9\x58\x58\x2c\xad\xbc\xe0\x8b\x56\xf0\x21\x5d\xeb\x4c\x95\xb3\xae\x84\x60\xd4\xdc\xe6\x82\x5d\x1b\x36\x6d\x39\x62\x92\xf5\xb8\x11\xdb\x92\xd3\x28\xce\xe0\x13\xe1\x72\xcd\x3c\x63\xd4\x65\x87\xae\xac\xe8\xc3\x28\x2e\x67\x44\x66\x3a\x21\x25\xa2\x72\xac\x14\x67\xbc\x84\x9f\x53\x32\x8c\x52\x70\x25\x56\xd6\xfd\x8d\x05\x37\xad\x30\x9d\x9f\xa6\x86\x0f\xcd\x58\x7f\xcf\x34\x93\x3b\xed\x90\x9f\xa4\x1f\xcf\x30\x85\x4d\x07\x58\xaf\x7f\x25\xc4\x9d\xf3\x72\x64\x84\xd0\x7f\xf9\x9b\x3a\x2d\x84\xef\x85\x48\x66\x8d\xd8\x88\x9b\x8c\x8c\x98\x5b\xf6\x74\x14\x4e\x33\x0d\xc9\xe0\x93\x38\xda\x12\xc5\x69\xbd\xe4\xf0\x2e\x7a\x78\x07\x1c\xfe\x13\x9f\x91\x29\x31\x95\x7b\x7f\x62\x59\x37\xb4\xe5\x5e\x25\xfe\x33\xee\xd5\x53\x71\xd6\xda\x3a\xd8\xcb\xde\x2e\xf8\xa1\x90\x55\x53\x0c\xc7\xaa\x0d\xe9\x76\x14\x29\x1c\x7b\x68\xdd\x2f\xe1\x6f\x00\x00\x00\xff\xff\x3c\x0a\xc2\xfe\x2a\x02\x00\x00")
 
 func readmeMdBytes() ([]byte, error) {
@@ -1328,6 +1538,26 @@ var _bindata = map[string]func() (*asset, error){
 
 	"1637852321_add_received_invitation_admin_column_in_chats.up.sql": _1637852321_add_received_invitation_admin_column_in_chatsUpSql,
 
+	"1638189911_add_thread_id_to_user_messages.up.sql": _1638189911_add_thread_id_to_user_messagesUpSql,
+
+	"1638281113_add_polls.up.sql": _1638281113_add_pollsUpSql,
+
+	"1638364827_add_files.up.sql": _1638364827_add_filesUpSql,
+
+	"1638450241_add_disappearing_messages.up.sql": _1638450241_add_disappearing_messagesUpSql,
+
+	"1638537600_add_messages_fts.up.sql": _1638537600_add_messages_ftsUpSql,
+
+	"1638624000_add_scheduled_messages.up.sql": _1638624000_add_scheduled_messagesUpSql,
+
+	"1638710400_add_chat_drafts.up.sql": _1638710400_add_chat_draftsUpSql,
+
+	"1638796800_add_outbox.up.sql": _1638796800_add_outboxUpSql,
+
+	"1638883200_add_read_receipts_typing_indicators.up.sql": _1638883200_add_read_receipts_typing_indicatorsUpSql,
+
+	"1638969600_add_communities_member_actions.up.sql": _1638969600_add_communities_member_actionsUpSql,
+
 	"README.md": readmeMd,
 
 	"doc.go": docGo,
@@ -1423,6 +1653,16 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1634896007_add_last_updated_locally_and_removed.up.sql":                  &bintree{_1634896007_add_last_updated_locally_and_removedUpSql, map[string]*bintree{}},
 	"1635840039_add_clock_read_at_column_in_chats.up.sql":                     &bintree{_1635840039_add_clock_read_at_column_in_chatsUpSql, map[string]*bintree{}},
 	"1637852321_add_received_invitation_admin_column_in_chats.up.sql":         &bintree{_1637852321_add_received_invitation_admin_column_in_chatsUpSql, map[string]*bintree{}},
+	"1638189911_add_thread_id_to_user_messages.up.sql":                        &bintree{_1638189911_add_thread_id_to_user_messagesUpSql, map[string]*bintree{}},
+	"1638281113_add_polls.up.sql":                                             &bintree{_1638281113_add_pollsUpSql, map[string]*bintree{}},
+	"1638364827_add_files.up.sql":                                             &bintree{_1638364827_add_filesUpSql, map[string]*bintree{}},
+	"1638450241_add_disappearing_messages.up.sql":                             &bintree{_1638450241_add_disappearing_messagesUpSql, map[string]*bintree{}},
+	"1638537600_add_messages_fts.up.sql":                                      &bintree{_1638537600_add_messages_ftsUpSql, map[string]*bintree{}},
+	"1638624000_add_scheduled_messages.up.sql":                                &bintree{_1638624000_add_scheduled_messagesUpSql, map[string]*bintree{}},
+	"1638710400_add_chat_drafts.up.sql":                                       &bintree{_1638710400_add_chat_draftsUpSql, map[string]*bintree{}},
+	"1638796800_add_outbox.up.sql":                                            &bintree{_1638796800_add_outboxUpSql, map[string]*bintree{}},
+	"1638883200_add_read_receipts_typing_indicators.up.sql":                   &bintree{_1638883200_add_read_receipts_typing_indicatorsUpSql, map[string]*bintree{}},
+	"1638969600_add_communities_member_actions.up.sql":                        &bintree{_1638969600_add_communities_member_actionsUpSql, map[string]*bintree{}},
 	"README.md": &bintree{readmeMd, map[string]*bintree{}},
 	"doc.go":    &bintree{docGo, map[string]*bintree{}},
 }}
@@ -0,0 +1,2 @@
+ALTER TABLE user_messages ADD COLUMN thread_id VARCHAR NOT NULL DEFAULT "";
+CREATE INDEX user_messages_thread_id_clock_value ON user_messages(thread_id, clock_value);
@@ -0,0 +1,21 @@
+ALTER TABLE user_messages ADD COLUMN poll_payload BLOB;
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+  id VARCHAR PRIMARY KEY ON CONFLICT REPLACE,
+  clock_value INT NOT NULL,
+  source TEXT NOT NULL,
+  message_id VARCHAR NOT NULL,
+  chat_id VARCHAR NOT NULL,
+  local_chat_id VARCHAR NOT NULL,
+  options VARCHAR NOT NULL DEFAULT "",
+  retracted BOOLEAN DEFAULT FALSE
+);
+
+CREATE INDEX poll_votes_message_id ON poll_votes(message_id);
+
+CREATE TABLE IF NOT EXISTS poll_closes (
+  message_id VARCHAR NOT NULL,
+  source TEXT NOT NULL,
+  clock_value INT NOT NULL,
+  PRIMARY KEY (message_id, source) ON CONFLICT REPLACE
+);
@@ -0,0 +1,19 @@
+ALTER TABLE user_messages ADD COLUMN file_payload BLOB;
+ALTER TABLE user_messages ADD COLUMN file_hash VARCHAR NOT NULL DEFAULT "";
+
+CREATE INDEX user_messages_file_hash ON user_messages(file_hash);
+
+CREATE TABLE IF NOT EXISTS file_chunks (
+  hash VARCHAR NOT NULL,
+  source VARCHAR NOT NULL,
+  chunk_index INT NOT NULL,
+  chunks_count INT NOT NULL,
+  payload BLOB NOT NULL,
+  received_at INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (hash, source, chunk_index) ON CONFLICT IGNORE
+);
+
+CREATE TABLE IF NOT EXISTS files (
+  hash VARCHAR PRIMARY KEY ON CONFLICT IGNORE,
+  payload BLOB NOT NULL
+);
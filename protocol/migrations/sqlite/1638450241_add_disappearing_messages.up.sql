@@ -0,0 +1,5 @@
+ALTER TABLE chats ADD COLUMN messages_ttl INT NOT NULL DEFAULT 0;
+ALTER TABLE chats ADD COLUMN messages_ttl_clock_value INT NOT NULL DEFAULT 0;
+ALTER TABLE user_messages ADD COLUMN expires_at INT NOT NULL DEFAULT 0;
+
+CREATE INDEX user_messages_expires_at ON user_messages(expires_at) WHERE expires_at != 0;
@@ -0,0 +1,27 @@
+-- The SQLCipher build we link against doesn't include FTS5, FTS4 provides the
+-- same tokenizer, prefix queries and snippets
+CREATE VIRTUAL TABLE user_messages_fts USING fts4(content="user_messages", text, tokenize=unicode61 "remove_diacritics=1", prefix="2,3");
+
+-- Messages are inserted with ON CONFLICT REPLACE, which doesn't fire the
+-- delete triggers, so replaced rows are removed from the index beforehand
+CREATE TRIGGER user_messages_fts_before_insert BEFORE INSERT ON user_messages BEGIN
+  DELETE FROM user_messages_fts WHERE docid IN (SELECT rowid FROM user_messages WHERE id = new.id);
+END;
+
+CREATE TRIGGER user_messages_fts_after_insert AFTER INSERT ON user_messages BEGIN
+  INSERT INTO user_messages_fts(docid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER user_messages_fts_before_update BEFORE UPDATE OF text ON user_messages BEGIN
+  DELETE FROM user_messages_fts WHERE docid = old.rowid;
+END;
+
+CREATE TRIGGER user_messages_fts_after_update AFTER UPDATE OF text ON user_messages BEGIN
+  INSERT INTO user_messages_fts(docid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER user_messages_fts_before_delete BEFORE DELETE ON user_messages BEGIN
+  DELETE FROM user_messages_fts WHERE docid = old.rowid;
+END;
+
+INSERT INTO user_messages_fts(user_messages_fts) VALUES ('rebuild');
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+  id VARCHAR PRIMARY KEY NOT NULL,
+  local_chat_id VARCHAR NOT NULL,
+  scheduled_at INT NOT NULL,
+  payload BLOB NOT NULL,
+  image_path VARCHAR NOT NULL DEFAULT "",
+  audio_path VARCHAR NOT NULL DEFAULT "",
+  file_path VARCHAR NOT NULL DEFAULT "",
+  error VARCHAR NOT NULL DEFAULT ""
+);
+
+CREATE INDEX scheduled_messages_scheduled_at ON scheduled_messages(scheduled_at);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS chat_drafts (
+  chat_id VARCHAR PRIMARY KEY ON CONFLICT REPLACE,
+  text VARCHAR NOT NULL,
+  response_to VARCHAR NOT NULL DEFAULT "",
+  clock_value INT NOT NULL
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS outbox (
+  id VARCHAR PRIMARY KEY ON CONFLICT REPLACE,
+  local_chat_id VARCHAR NOT NULL,
+  message_type INT NOT NULL,
+  state VARCHAR NOT NULL,
+  resend_automatically BOOLEAN NOT NULL DEFAULT FALSE,
+  attempts INT NOT NULL DEFAULT 0,
+  last_attempt INT NOT NULL DEFAULT 0,
+  next_attempt INT NOT NULL DEFAULT 0,
+  error VARCHAR NOT NULL DEFAULT ""
+);
+
+CREATE INDEX outbox_state_next_attempt ON outbox(state, next_attempt);
+CREATE INDEX outbox_local_chat_id ON outbox(local_chat_id);
@@ -0,0 +1,2 @@
+ALTER TABLE chats ADD COLUMN read_receipts INT NOT NULL DEFAULT 0;
+ALTER TABLE chats ADD COLUMN typing_indicators INT NOT NULL DEFAULT 0;
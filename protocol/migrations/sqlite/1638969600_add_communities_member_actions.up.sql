@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS communities_member_actions (
+  community_id BLOB NOT NULL,
+  member_id TEXT NOT NULL,
+  clock INT NOT NULL,
+  PRIMARY KEY (community_id, member_id) ON CONFLICT REPLACE
+);
@@ -131,8 +131,8 @@ func (db sqlitePersistence) saveChat(tx *sql.Tx, chat Chat) error {
 	}
 
 	// Insert record
-	stmt, err := tx.Prepare(`INSERT INTO chats(id, name, color, emoji, active, type, timestamp,  deleted_at_clock_value, unviewed_message_count, unviewed_mentions_count, last_clock_value, last_message, members, membership_updates, muted, invitation_admin, profile, community_id, joined, synced_from, synced_to, description, highlight, read_messages_at_clock_value, received_invitation_admin)
-	    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,?, ?,?,?,?,?,?,?,?,?,?,?)`)
+	stmt, err := tx.Prepare(`INSERT INTO chats(id, name, color, emoji, active, type, timestamp,  deleted_at_clock_value, unviewed_message_count, unviewed_mentions_count, last_clock_value, last_message, members, membership_updates, muted, invitation_admin, profile, community_id, joined, synced_from, synced_to, description, highlight, read_messages_at_clock_value, received_invitation_admin, messages_ttl, messages_ttl_clock_value, read_receipts, typing_indicators)
+	    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?,?, ?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
 	if err != nil {
 		return err
 	}
@@ -164,6 +164,10 @@ func (db sqlitePersistence) saveChat(tx *sql.Tx, chat Chat) error {
 		chat.Highlight,
 		chat.ReadMessagesAtClockValue,
 		chat.ReceivedInvitationAdmin,
+		chat.MessagesTTL,
+		chat.MessagesTTLClockValue,
+		chat.ReadReceipts,
+		chat.TypingIndicators,
 	)
 
 	if err != nil {
@@ -259,7 +263,11 @@ func (db sqlitePersistence) chats(tx *sql.Tx) (chats []*Chat, err error) {
 		    chats.description,
 			contacts.alias,
                         chats.highlight,
-                        chats.received_invitation_admin
+                        chats.received_invitation_admin,
+			chats.messages_ttl,
+			chats.messages_ttl_clock_value,
+			chats.read_receipts,
+			chats.typing_indicators
 		FROM chats LEFT JOIN contacts ON chats.id = contacts.id
 		ORDER BY chats.timestamp DESC
 	`)
@@ -307,6 +315,10 @@ func (db sqlitePersistence) chats(tx *sql.Tx) (chats []*Chat, err error) {
 			&alias,
 			&chat.Highlight,
 			&chat.ReceivedInvitationAdmin,
+			&chat.MessagesTTL,
+			&chat.MessagesTTLClockValue,
+			&chat.ReadReceipts,
+			&chat.TypingIndicators,
 		)
 
 		if err != nil {
@@ -397,7 +409,11 @@ func (db sqlitePersistence) Chat(chatID string) (*Chat, error) {
                     highlight,
                     received_invitation_admin,
                     synced_from,
-                    synced_to
+                    synced_to,
+		    messages_ttl,
+		    messages_ttl_clock_value,
+		    read_receipts,
+		    typing_indicators
 		FROM chats
 		WHERE id = ?
 	`, chatID).Scan(&chat.ID,
@@ -425,6 +441,10 @@ func (db sqlitePersistence) Chat(chatID string) (*Chat, error) {
 		&chat.ReceivedInvitationAdmin,
 		&syncedFrom,
 		&syncedTo,
+		&chat.MessagesTTL,
+		&chat.MessagesTTLClockValue,
+		&chat.ReadReceipts,
+		&chat.TypingIndicators,
 	)
 	switch err {
 	case sql.ErrNoRows:
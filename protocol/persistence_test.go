@@ -17,6 +17,7 @@ import (
 	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/protocol/common"
 	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/requests"
 	"github.com/planq-network/status-go/protocol/sqlite"
 )
 
@@ -420,6 +421,121 @@ func TestMessageByChatIDWithTheSameClocks(t *testing.T) {
 	require.EqualValues(t, expectedClocks, resultClocks)
 }
 
+func TestMessagesByThreadID(t *testing.T) {
+	db, err := openTestDB()
+	require.NoError(t, err)
+	p := NewSQLitePersistence(db)
+	chatID := testPublicChatID
+	pageSize := 2
+
+	messages := []*common.Message{
+		{
+			ID:          "root-1",
+			LocalChatID: chatID,
+			ChatMessage: protobuf.ChatMessage{Clock: 1},
+			From:        "me",
+		},
+		{
+			ID:          "root-2",
+			LocalChatID: chatID,
+			ChatMessage: protobuf.ChatMessage{Clock: 2},
+			From:        "me",
+		},
+	}
+	for i := 0; i < 5; i++ {
+		messages = append(messages, &common.Message{
+			ID:          "reply-" + strconv.Itoa(i),
+			LocalChatID: chatID,
+			ChatMessage: protobuf.ChatMessage{
+				Clock:     uint64(10 + i),
+				Timestamp: uint64(100 + i),
+				ThreadId:  "root-1",
+			},
+			From: "them",
+		})
+	}
+	messages = append(messages, &common.Message{
+		ID:          "reply-root-2",
+		LocalChatID: chatID,
+		ChatMessage: protobuf.ChatMessage{
+			Clock:    20,
+			ThreadId: "root-2",
+		},
+		From: "me",
+		Seen: true,
+	})
+
+	err = p.SaveMessages(messages)
+	require.NoError(t, err)
+
+	// Replies are not in the chat timeline
+	timeline, _, err := p.MessageByChatID(chatID, "", 10)
+	require.NoError(t, err)
+	require.Len(t, timeline, 2)
+	require.Equal(t, "root-2", timeline[0].ID)
+	require.NotNil(t, timeline[0].ThreadParameters)
+	require.Equal(t, uint64(1), timeline[0].ThreadParameters.ReplyCount)
+	require.Equal(t, uint64(0), timeline[0].ThreadParameters.UnreadCount)
+
+	require.Equal(t, "root-1", timeline[1].ID)
+	require.NotNil(t, timeline[1].ThreadParameters)
+	require.Equal(t, uint64(5), timeline[1].ThreadParameters.ReplyCount)
+	require.Equal(t, uint64(5), timeline[1].ThreadParameters.UnreadCount)
+	require.Equal(t, "them", timeline[1].ThreadParameters.LastReplyFrom)
+	require.Equal(t, uint64(104), timeline[1].ThreadParameters.LastReplyTimestamp)
+
+	var (
+		result []*common.Message
+		cursor string
+		iter   int
+	)
+	for {
+		var items []*common.Message
+		items, cursor, err = p.MessagesByThreadID("root-1", cursor, pageSize)
+		require.NoError(t, err)
+		result = append(result, items...)
+
+		iter++
+		if cursor == "" || iter > 5 {
+			break
+		}
+	}
+	require.Len(t, result, 5)
+	require.Equal(t, "reply-4", result[0].ID)
+	require.Equal(t, "root-1", result[0].ThreadId)
+	require.Equal(t, "reply-0", result[4].ID)
+
+	// root-2 has been replied last
+	threads, cursor, err := p.ActiveThreadsByChatID(chatID, "", 1)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	require.Equal(t, "root-2", threads[0].ID)
+	require.NotEmpty(t, cursor)
+
+	threads, cursor, err = p.ActiveThreadsByChatID(chatID, cursor, 1)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	require.Equal(t, "root-1", threads[0].ID)
+	require.Empty(t, cursor)
+
+	participated, err := p.HasParticipatedInThread("root-1", "me")
+	require.NoError(t, err)
+	require.True(t, participated)
+
+	participated, err = p.HasParticipatedInThread("root-2", "them")
+	require.NoError(t, err)
+	require.False(t, participated)
+
+	count, countWithMentions, err := p.MarkThreadRead(chatID, "root-1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), count)
+	require.Equal(t, uint64(0), countWithMentions)
+
+	root, err := p.MessageByID("root-1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), root.ThreadParameters.UnreadCount)
+}
+
 func TestDeleteMessageByID(t *testing.T) {
 	db, err := openTestDB()
 	require.NoError(t, err)
@@ -464,6 +580,239 @@ func TestDeleteMessagesByChatID(t *testing.T) {
 
 }
 
+func TestDeleteExpiredMessages(t *testing.T) {
+	db, err := openTestDB()
+	require.NoError(t, err)
+	p := NewSQLitePersistence(db)
+
+	chat := CreatePublicChat(testPublicChatID, &testTimeSource{})
+	chat.MessagesTTL = 60
+	chat.MessagesTTLClockValue = 1
+	chat.UnviewedMessagesCount = 2
+	require.NoError(t, p.SaveChat(*chat))
+
+	retrievedChat, err := p.Chat(chat.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(60), retrievedChat.MessagesTTL)
+	require.Equal(t, uint64(1), retrievedChat.MessagesTTLClockValue)
+
+	fileHash := []byte{0x01, 0x02}
+	expired := &common.Message{
+		ID:          "expired",
+		LocalChatID: testPublicChatID,
+		ChatMessage: protobuf.ChatMessage{
+			Text:        "some-text",
+			ContentType: protobuf.ChatMessage_FILE,
+			Payload:     &protobuf.ChatMessage_File{File: &protobuf.FileMessage{Hash: fileHash}},
+			ExpiresAt:   100,
+		},
+		From: "me",
+	}
+	notExpired := &common.Message{
+		ID:          "not-expired",
+		LocalChatID: testPublicChatID,
+		ChatMessage: protobuf.ChatMessage{Text: "some-text", ExpiresAt: 200},
+		From:        "me",
+	}
+	require.NoError(t, p.SaveMessages([]*common.Message{expired, notExpired}))
+	require.NoError(t, insertMinimalMessage(p, "never-expires"))
+	require.NoError(t, p.SaveFile(types.EncodeHex(fileHash), []byte("content")))
+
+	require.NoError(t, p.SaveEmojiReaction(&EmojiReaction{
+		EmojiReaction: protobuf.EmojiReaction{
+			Clock:     1,
+			MessageId: expired.ID,
+			ChatId:    testPublicChatID,
+			Type:      protobuf.EmojiReaction_SAD,
+		},
+		LocalChatID: testPublicChatID,
+		From:        "me",
+	}))
+
+	pinMessage := &common.PinMessage{
+		ID:          "pin",
+		LocalChatID: testPublicChatID,
+		From:        "me",
+	}
+	pinMessage.MessageId = expired.ID
+	pinMessage.Clock = 1
+	pinMessage.Pinned = true
+	require.NoError(t, p.SavePinMessages([]*common.PinMessage{pinMessage}))
+
+	result, err := p.DeleteExpiredMessages(150)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, testPublicChatID, result[0].ChatID)
+	require.Equal(t, []string{expired.ID}, result[0].MessageIDs)
+	require.Equal(t, uint(2), result[0].UnviewedMessagesCount)
+
+	_, err = p.MessageByID(expired.ID)
+	require.EqualError(t, err, "record not found")
+
+	_, err = p.MessageByID(notExpired.ID)
+	require.NoError(t, err)
+
+	_, err = p.MessageByID("never-expires")
+	require.NoError(t, err)
+
+	reactions, err := p.EmojiReactionsByChatID(testPublicChatID, "", 10)
+	require.NoError(t, err)
+	require.Len(t, reactions, 0)
+
+	pinnedMessages, _, err := p.PinnedMessageByChatID(testPublicChatID, "", 10)
+	require.NoError(t, err)
+	require.Len(t, pinnedMessages, 0)
+
+	hasFile, err := p.HasFile(types.EncodeHex(fileHash))
+	require.NoError(t, err)
+	require.False(t, hasFile)
+
+	// Nothing else has expired
+	result, err = p.DeleteExpiredMessages(150)
+	require.NoError(t, err)
+	require.Len(t, result, 0)
+}
+
+func TestSearchMessages(t *testing.T) {
+	db, err := openTestDB()
+	require.NoError(t, err)
+	p := NewSQLitePersistence(db)
+
+	messages := []*common.Message{
+		{ID: "1", From: "alice", ChatMessage: protobuf.ChatMessage{Text: "the quick brown fox", Clock: 1, Timestamp: 1000}},
+		{ID: "2", From: "bob", ChatMessage: protobuf.ChatMessage{Text: "a fox, a fox and another fox", Clock: 2, Timestamp: 2000}},
+		{ID: "3", From: "alice", ChatMessage: protobuf.ChatMessage{Text: "brown bears are quick too", Clock: 3, Timestamp: 3000}},
+		{ID: "4", From: "bob", ChatMessage: protobuf.ChatMessage{Text: "Foxes everywhere", Clock: 4, Timestamp: 4000}},
+		{ID: "5", From: "carol", ChatMessage: protobuf.ChatMessage{Text: "nothing to see here", Clock: 5, Timestamp: 5000}},
+		{ID: "6", From: "carol", ChatMessage: protobuf.ChatMessage{Text: "or here", Clock: 6, Timestamp: 6000}},
+	}
+	for _, message := range messages {
+		message.LocalChatID = testPublicChatID
+	}
+	require.NoError(t, p.SaveMessages(messages))
+
+	search := func(request *requests.SearchMessages) ([]string, string) {
+		if request.Limit == 0 {
+			request.Limit = 10
+		}
+		results, cursor, err := p.SearchMessages(request)
+		require.NoError(t, err)
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.Message.ID)
+		}
+		return ids, cursor
+	}
+
+	// Ranked by relevance
+	ids, _ := search(&requests.SearchMessages{Query: "fox"})
+	require.Equal(t, []string{"2", "1"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "FOX", Prefix: true})
+	require.Equal(t, []string{"2", "4", "1"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "quick brown"})
+	require.ElementsMatch(t, []string{"1", "3"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "quick brown", Phrase: true})
+	require.Equal(t, []string{"1"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "fox", Senders: []string{"alice"}})
+	require.Equal(t, []string{"1"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "quick", Since: 2000, Until: 3000})
+	require.Equal(t, []string{"3"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "fox", ChatIDs: []string{"another-chat"}})
+	require.Len(t, ids, 0)
+
+	// Operators are searched as plain terms
+	ids, _ = search(&requests.SearchMessages{Query: "here OR"})
+	require.Equal(t, []string{"6"}, ids)
+
+	// Pagination
+	ids, cursor := search(&requests.SearchMessages{Query: "fox", Prefix: true, Limit: 2})
+	require.Equal(t, []string{"2", "4"}, ids)
+	require.NotEmpty(t, cursor)
+
+	ids, cursor = search(&requests.SearchMessages{Query: "fox", Prefix: true, Limit: 2, Cursor: cursor})
+	require.Equal(t, []string{"1"}, ids)
+	require.Empty(t, cursor)
+
+	// Snippets
+	results, _, err := p.SearchMessages(&requests.SearchMessages{Query: "bears", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "brown <b>bears</b> are quick too", results[0].Snippet)
+
+	// The text of the message is escaped
+	require.NoError(t, p.SaveMessages([]*common.Message{{
+		ID:          "7",
+		From:        "mallory",
+		LocalChatID: testPublicChatID,
+		ChatMessage: protobuf.ChatMessage{Text: "cats & dogs <img src=x onerror=alert(1)> \x03</b>", Clock: 7, Timestamp: 7000},
+	}}))
+	results, _, err = p.SearchMessages(&requests.SearchMessages{Query: "dogs", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "cats &amp; <b>dogs</b> &lt;img src=x onerror=alert(1)&gt; &lt;/b&gt;", results[0].Snippet)
+	require.NoError(t, p.DeleteMessage("7"))
+
+	// The index is kept up to date when messages are edited and deleted
+	require.NoError(t, p.SaveMessages([]*common.Message{{
+		ID:          "5",
+		From:        "carol",
+		LocalChatID: testPublicChatID,
+		ChatMessage: protobuf.ChatMessage{Text: "a bear", Clock: 5, Timestamp: 5000},
+	}}))
+	require.NoError(t, p.DeleteMessage("3"))
+
+	ids, _ = search(&requests.SearchMessages{Query: "bear", Prefix: true})
+	require.Equal(t, []string{"5"}, ids)
+
+	ids, _ = search(&requests.SearchMessages{Query: "nothing"})
+	require.Len(t, ids, 0)
+}
+
+func TestSearchMessagesCandidatesLimit(t *testing.T) {
+	db, err := openTestDB()
+	require.NoError(t, err)
+	p := NewSQLitePersistence(db)
+
+	// The oldest message is the most relevant, but only the most recent
+	// matches are ranked
+	messages := []*common.Message{
+		{ID: "oldest", ChatMessage: protobuf.ChatMessage{Text: "fox fox fox", Clock: 1}},
+	}
+	for i := 0; i < maxMessagesSearchCandidates; i++ {
+		messages = append(messages, &common.Message{
+			ID:          "recent-" + strconv.Itoa(i),
+			ChatMessage: protobuf.ChatMessage{Text: "a fox among many other words", Clock: uint64(i + 2)},
+		})
+	}
+	for _, message := range messages {
+		message.From = "alice"
+		message.LocalChatID = testPublicChatID
+	}
+	require.NoError(t, p.SaveMessages(messages))
+
+	var ids []string
+	cursor := ""
+	for {
+		results, next, err := p.SearchMessages(&requests.SearchMessages{Query: "fox", Limit: 100, Cursor: cursor})
+		require.NoError(t, err)
+		for _, result := range results {
+			ids = append(ids, result.Message.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	require.Len(t, ids, maxMessagesSearchCandidates)
+	require.NotContains(t, ids, "oldest")
+}
+
 func TestMarkMessageSeen(t *testing.T) {
 	chatID := "test-chat"
 	db, err := openTestDB()
@@ -538,7 +887,7 @@ func TestExpiredMessagesIDs(t *testing.T) {
 	require.NoError(t, err)
 	p := NewSQLitePersistence(db)
 
-	ids, err := p.ExpiredMessagesIDs(messageResendMaxCount)
+	ids, err := p.ExpiredMessagesIDs(outboxMaxAttempts)
 	require.NoError(t, err)
 	require.Empty(t, ids)
 
@@ -549,7 +898,7 @@ func TestExpiredMessagesIDs(t *testing.T) {
 	require.NoError(t, err)
 
 	//make sure it appered in expired emoji reactions list
-	ids, err = p.ExpiredMessagesIDs(messageResendMaxCount)
+	ids, err = p.ExpiredMessagesIDs(outboxMaxAttempts)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(ids))
 
@@ -560,7 +909,7 @@ func TestExpiredMessagesIDs(t *testing.T) {
 	require.NoError(t, err)
 
 	//make sure it didn't appear in expired emoji reactions list
-	ids, err = p.ExpiredMessagesIDs(messageResendMaxCount)
+	ids, err = p.ExpiredMessagesIDs(outboxMaxAttempts)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(ids))
 }
@@ -0,0 +1,188 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/eth-node/crypto"
+	"github.com/planq-network/status-go/eth-node/types"
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// PollVote represents the vote of a user on a poll in the application layer, used for persistence, querying and
+// signaling
+type PollVote struct {
+	protobuf.PollVote
+
+	// From is a public key of the author of the vote.
+	From string `json:"from,omitempty"`
+
+	// SigPubKey is the ecdsa encoded public key of the vote author
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+
+	// LocalChatID is the chatID of the local chat (one-to-one are not symmetric)
+	LocalChatID string `json:"localChatId"`
+}
+
+// ID is the Keccak256() contatenation of From-MessageID, as only the latest
+// vote of each voter is kept
+func (v PollVote) ID() string {
+	return types.EncodeHex(crypto.Keccak256([]byte(fmt.Sprintf("%s%s", v.From, v.MessageId))))
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (v PollVote) GetSigPubKey() *ecdsa.PublicKey {
+	return v.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (v PollVote) GetProtobuf() proto.Message {
+	return &v.PollVote
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (v *PollVote) SetMessageType(messageType protobuf.MessageType) {
+	v.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (v PollVote) WrapGroupMessage() bool {
+	return false
+}
+
+func (v PollVote) MarshalJSON() ([]byte, error) {
+	item := struct {
+		ID          string   `json:"id"`
+		Clock       uint64   `json:"clock,omitempty"`
+		ChatID      string   `json:"chatId,omitempty"`
+		LocalChatID string   `json:"localChatId,omitempty"`
+		From        string   `json:"from"`
+		MessageID   string   `json:"messageId,omitempty"`
+		Options     []uint32 `json:"options,omitempty"`
+		Retracted   bool     `json:"retracted,omitempty"`
+	}{
+		ID:          v.ID(),
+		Clock:       v.Clock,
+		ChatID:      v.ChatId,
+		LocalChatID: v.LocalChatID,
+		From:        v.From,
+		MessageID:   v.MessageId,
+		Options:     v.Options,
+		Retracted:   v.Retracted,
+	}
+
+	return json.Marshal(item)
+}
+
+// PollClose represents the closing of a poll by its author
+type PollClose struct {
+	protobuf.PollClose
+
+	// From is a public key of the author of the poll.
+	From string `json:"from,omitempty"`
+
+	// SigPubKey is the ecdsa encoded public key of the author of the poll
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (c PollClose) GetSigPubKey() *ecdsa.PublicKey {
+	return c.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (c PollClose) GetProtobuf() proto.Message {
+	return &c.PollClose
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (c *PollClose) SetMessageType(messageType protobuf.MessageType) {
+	c.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (c PollClose) WrapGroupMessage() bool {
+	return false
+}
+
+// PollResults is the tally of the votes of a poll
+type PollResults struct {
+	// MessageID is the ID of the poll message
+	MessageID string `json:"messageId"`
+	// Votes is the number of votes of each option
+	Votes []uint64 `json:"votes"`
+	// Voters is the number of users that have voted
+	Voters uint64 `json:"voters"`
+	// OwnVote are the options voted by the user
+	OwnVote []uint32 `json:"ownVote"`
+	// Closed is whether votes are not accepted anymore
+	Closed bool `json:"closed"`
+}
+
+// validPollVote returns whether the vote is counted in the results of the poll.
+// Votes sent after the poll was closed or expired, or with options that can't
+// be voted are ignored, so that every device converges to the same tally
+// regardless of the order the messages are received in
+func validPollVote(poll *common.Message, vote *PollVote) bool {
+	pollMessage := poll.GetPoll()
+	if pollMessage == nil || vote.Retracted {
+		return false
+	}
+
+	if poll.PollClosedAt != 0 && vote.Clock >= poll.PollClosedAt {
+		return false
+	}
+
+	if pollMessage.ExpiresAt != 0 && vote.Clock > pollMessage.ExpiresAt {
+		return false
+	}
+
+	if len(vote.Options) == 0 || (!pollMessage.MultipleChoice && len(vote.Options) > 1) {
+		return false
+	}
+
+	seen := make(map[uint32]bool)
+	for _, option := range vote.Options {
+		if int(option) >= len(pollMessage.Options) || seen[option] {
+			return false
+		}
+		seen[option] = true
+	}
+
+	return true
+}
+
+// tallyPollVotes counts the latest vote of each voter of the poll
+func tallyPollVotes(poll *common.Message, votes []*PollVote, ownPublicKey string) *PollResults {
+	results := &PollResults{
+		MessageID: poll.ID,
+		Votes:     make([]uint64, len(poll.GetPoll().GetOptions())),
+	}
+
+	for _, vote := range votes {
+		if !validPollVote(poll, vote) {
+			continue
+		}
+
+		results.Voters++
+		for _, option := range vote.Options {
+			results.Votes[option]++
+		}
+
+		if vote.From == ownPublicKey {
+			results.OwnVote = vote.Options
+		}
+	}
+
+	return results
+}
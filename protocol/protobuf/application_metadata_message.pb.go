@@ -65,6 +65,14 @@ const (
 	ApplicationMetadataMessage_SYNC_ACTIVITY_CENTER_DISMISSED          ApplicationMetadataMessage_Type = 39
 	ApplicationMetadataMessage_SYNC_BOOKMARK                           ApplicationMetadataMessage_Type = 40
 	ApplicationMetadataMessage_SYNC_CLEAR_HISTORY                      ApplicationMetadataMessage_Type = 41
+	ApplicationMetadataMessage_COMMUNITY_MEMBER_ACTION                 ApplicationMetadataMessage_Type = 42
+	ApplicationMetadataMessage_POLL_VOTE                               ApplicationMetadataMessage_Type = 43
+	ApplicationMetadataMessage_POLL_CLOSE                              ApplicationMetadataMessage_Type = 44
+	ApplicationMetadataMessage_FILE_CHUNK                              ApplicationMetadataMessage_Type = 45
+	ApplicationMetadataMessage_CHAT_MESSAGES_TTL                       ApplicationMetadataMessage_Type = 46
+	ApplicationMetadataMessage_SYNC_CHAT_DRAFT                         ApplicationMetadataMessage_Type = 47
+	ApplicationMetadataMessage_READ_RECEIPT                            ApplicationMetadataMessage_Type = 48
+	ApplicationMetadataMessage_TYPING_INDICATOR                        ApplicationMetadataMessage_Type = 49
 )
 
 var ApplicationMetadataMessage_Type_name = map[int32]string{
@@ -110,6 +118,14 @@ var ApplicationMetadataMessage_Type_name = map[int32]string{
 	39: "SYNC_ACTIVITY_CENTER_DISMISSED",
 	40: "SYNC_BOOKMARK",
 	41: "SYNC_CLEAR_HISTORY",
+	42: "COMMUNITY_MEMBER_ACTION",
+	43: "POLL_VOTE",
+	44: "POLL_CLOSE",
+	45: "FILE_CHUNK",
+	46: "CHAT_MESSAGES_TTL",
+	47: "SYNC_CHAT_DRAFT",
+	48: "READ_RECEIPT",
+	49: "TYPING_INDICATOR",
 }
 
 var ApplicationMetadataMessage_Type_value = map[string]int32{
@@ -155,6 +171,14 @@ var ApplicationMetadataMessage_Type_value = map[string]int32{
 	"SYNC_ACTIVITY_CENTER_DISMISSED":          39,
 	"SYNC_BOOKMARK":                           40,
 	"SYNC_CLEAR_HISTORY":                      41,
+	"COMMUNITY_MEMBER_ACTION":                 42,
+	"POLL_VOTE":                               43,
+	"POLL_CLOSE":                              44,
+	"FILE_CHUNK":                              45,
+	"CHAT_MESSAGES_TTL":                       46,
+	"SYNC_CHAT_DRAFT":                         47,
+	"READ_RECEIPT":                            48,
+	"TYPING_INDICATOR":                        49,
 }
 
 func (x ApplicationMetadataMessage_Type) String() string {
@@ -233,49 +257,55 @@ func init() {
 }
 
 var fileDescriptor_ad09a6406fcf24c7 = []byte{
-	// 704 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdb, 0x52, 0x23, 0x37,
-	0x10, 0x8d, 0x77, 0x09, 0x2c, 0x6d, 0x60, 0x85, 0x96, 0x8b, 0x31, 0x0b, 0x78, 0xbd, 0x1b, 0x2e,
-	0x49, 0x95, 0x53, 0x95, 0x3c, 0xa6, 0xf2, 0x20, 0x4b, 0x0d, 0x16, 0xf6, 0x48, 0x83, 0xa4, 0x71,
-	0xca, 0x79, 0x51, 0x0d, 0xc1, 0xa1, 0xa8, 0x02, 0xec, 0x02, 0xf3, 0xc0, 0x8f, 0xe4, 0x2b, 0xf2,
-	0x91, 0x29, 0xcd, 0xf8, 0x06, 0x98, 0xf0, 0x64, 0xab, 0xcf, 0x51, 0xb7, 0xfa, 0xf4, 0xe9, 0x81,
-	0x6a, 0xda, 0xef, 0x5f, 0x5f, 0xfd, 0x95, 0x0e, 0xae, 0x7a, 0xb7, 0xfe, 0xa6, 0x3b, 0x48, 0x2f,
-	0xd2, 0x41, 0xea, 0x6f, 0xba, 0xf7, 0xf7, 0xe9, 0x65, 0xb7, 0xd6, 0xbf, 0xeb, 0x0d, 0x7a, 0xf4,
-	0x43, 0xf6, 0x73, 0xfe, 0xf0, 0x77, 0xf5, 0x5f, 0x80, 0x32, 0x9b, 0x5c, 0x88, 0x86, 0xfc, 0x28,
-	0xa7, 0xd3, 0xcf, 0xb0, 0x78, 0x7f, 0x75, 0x79, 0x9b, 0x0e, 0x1e, 0xee, 0xba, 0xa5, 0x42, 0xa5,
-	0x70, 0xb8, 0x64, 0x26, 0x01, 0x5a, 0x82, 0x85, 0x7e, 0xfa, 0x78, 0xdd, 0x4b, 0x2f, 0x4a, 0xef,
-	0x32, 0x6c, 0x74, 0xa4, 0xbf, 0xc3, 0xdc, 0xe0, 0xb1, 0xdf, 0x2d, 0xbd, 0xaf, 0x14, 0x0e, 0x57,
-	0x7e, 0x39, 0xaa, 0x8d, 0xea, 0xd5, 0x5e, 0xaf, 0x55, 0x73, 0x8f, 0xfd, 0xae, 0xc9, 0xae, 0x55,
-	0xff, 0x59, 0x84, 0xb9, 0x70, 0xa4, 0x45, 0x58, 0x48, 0x54, 0x53, 0xe9, 0x3f, 0x14, 0xf9, 0x8e,
-	0x12, 0x58, 0xe2, 0x0d, 0xe6, 0x7c, 0x84, 0xd6, 0xb2, 0x13, 0x24, 0x05, 0x4a, 0x61, 0x85, 0x6b,
-	0xe5, 0x18, 0x77, 0x3e, 0x89, 0x05, 0x73, 0x48, 0xde, 0xd1, 0x1d, 0xd8, 0x8a, 0x30, 0xaa, 0xa3,
-	0xb1, 0x0d, 0x19, 0x0f, 0xc3, 0xe3, 0x2b, 0xef, 0xe9, 0x3a, 0xac, 0xc6, 0x4c, 0x1a, 0x2f, 0x95,
-	0x75, 0xac, 0xd5, 0x62, 0x4e, 0x6a, 0x45, 0xe6, 0x42, 0xd8, 0x76, 0x14, 0x7f, 0x1a, 0xfe, 0x9e,
-	0x7e, 0x85, 0x3d, 0x83, 0x67, 0x09, 0x5a, 0xe7, 0x99, 0x10, 0x06, 0xad, 0xf5, 0xc7, 0xda, 0x78,
-	0x67, 0x98, 0xb2, 0x8c, 0x67, 0xa4, 0x79, 0xfa, 0x23, 0xec, 0x33, 0xce, 0x31, 0x76, 0xfe, 0x2d,
-	0xee, 0x02, 0xfd, 0x09, 0x0e, 0x04, 0xf2, 0x96, 0x54, 0xf8, 0x26, 0xf9, 0x03, 0xdd, 0x84, 0x4f,
-	0x23, 0xd2, 0x34, 0xb0, 0x48, 0xd7, 0x80, 0x58, 0x54, 0xe2, 0x49, 0x14, 0xe8, 0x1e, 0x6c, 0x3f,
-	0xcf, 0x3d, 0x4d, 0x28, 0x06, 0x69, 0x5e, 0x34, 0xe9, 0x87, 0x02, 0x92, 0xa5, 0xd9, 0x30, 0xe3,
-	0x5c, 0x27, 0xca, 0x91, 0x65, 0xfa, 0x05, 0x76, 0x5e, 0xc2, 0x71, 0x52, 0x6f, 0x49, 0xee, 0xc3,
-	0x5c, 0xc8, 0x0a, 0xdd, 0x85, 0xf2, 0x68, 0x1e, 0x5c, 0x0b, 0xf4, 0x4c, 0xb4, 0xd1, 0x38, 0x69,
-	0x31, 0x42, 0xe5, 0xc8, 0x47, 0x5a, 0x85, 0xdd, 0x38, 0xb1, 0x0d, 0xaf, 0xb4, 0x93, 0xc7, 0x92,
-	0xe7, 0x29, 0x0c, 0x9e, 0x48, 0xeb, 0x4c, 0x2e, 0x39, 0x09, 0x0a, 0xfd, 0x3f, 0xc7, 0x1b, 0xb4,
-	0xb1, 0x56, 0x16, 0xc9, 0x2a, 0xdd, 0x86, 0xcd, 0x97, 0xe4, 0xb3, 0x04, 0x4d, 0x87, 0x50, 0xfa,
-	0x0d, 0x2a, 0xaf, 0x80, 0x93, 0x14, 0x9f, 0x42, 0xd7, 0xb3, 0xea, 0x65, 0xfa, 0x91, 0xb5, 0xd0,
-	0xd2, 0x2c, 0x78, 0x78, 0x7d, 0x3d, 0x58, 0x10, 0x23, 0x7d, 0x2a, 0xbd, 0xc1, 0xa1, 0xce, 0x1b,
-	0x74, 0x0b, 0xd6, 0x4f, 0x8c, 0x4e, 0xe2, 0x4c, 0x16, 0x2f, 0x55, 0x5b, 0xba, 0xbc, 0xbb, 0x4d,
-	0xba, 0x0a, 0xcb, 0x79, 0x50, 0xa0, 0x72, 0xd2, 0x75, 0x48, 0x29, 0xb0, 0xb9, 0x8e, 0xa2, 0x44,
-	0x49, 0xd7, 0xf1, 0x02, 0x2d, 0x37, 0x32, 0xce, 0xd8, 0x5b, 0xb4, 0x04, 0x6b, 0x13, 0x68, 0x2a,
-	0x4f, 0x39, 0xbc, 0x7a, 0x82, 0x8c, 0xa7, 0xad, 0xfd, 0xa9, 0x96, 0x8a, 0x6c, 0xd3, 0x8f, 0x50,
-	0x8c, 0xa5, 0x1a, 0xdb, 0xfe, 0x73, 0xd8, 0x1d, 0x14, 0x72, 0xb2, 0x3b, 0x3b, 0xe1, 0x25, 0xd6,
-	0x31, 0x97, 0xd8, 0xd1, 0xea, 0xec, 0x86, 0x5e, 0x04, 0xb6, 0x70, 0x6a, 0x5f, 0xf6, 0x82, 0xa9,
-	0x66, 0x79, 0x66, 0x58, 0x9a, 0x54, 0x68, 0x19, 0x36, 0x98, 0xd2, 0xaa, 0x13, 0xe9, 0xc4, 0xfa,
-	0x08, 0x9d, 0x91, 0xdc, 0xd7, 0x99, 0xe3, 0x0d, 0xf2, 0x65, 0xbc, 0x55, 0x59, 0xcb, 0x06, 0x23,
-	0xdd, 0x46, 0x41, 0xaa, 0x61, 0x6a, 0x93, 0xf0, 0xb0, 0x94, 0x0d, 0x02, 0x0a, 0xf2, 0x95, 0x02,
-	0xcc, 0xd7, 0x19, 0x6f, 0x26, 0x31, 0xf9, 0x36, 0x76, 0x64, 0x50, 0xb6, 0x1d, 0x3a, 0xe5, 0xa8,
-	0x1c, 0x9a, 0x9c, 0xfa, 0xc3, 0xd8, 0x91, 0xcf, 0xe1, 0x7c, 0x1b, 0x51, 0x90, 0xfd, 0xe0, 0xb8,
-	0x99, 0x14, 0x21, 0x6d, 0x24, 0xad, 0x45, 0x41, 0x0e, 0x32, 0x25, 0x02, 0xa7, 0xae, 0x75, 0x33,
-	0x62, 0xa6, 0x49, 0x0e, 0xe9, 0x06, 0xd0, 0xfc, 0x85, 0x2d, 0x64, 0xc6, 0x37, 0xa4, 0x75, 0xda,
-	0x74, 0xc8, 0x51, 0x7d, 0xf9, 0xcf, 0x62, 0xed, 0xe7, 0xdf, 0x46, 0x5f, 0xb3, 0xf3, 0xf9, 0xec,
-	0xdf, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x84, 0x31, 0xd9, 0x7d, 0x74, 0x05, 0x00, 0x00,
+	// 791 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5b, 0x77, 0x13, 0x37,
+	0x10, 0x6e, 0x20, 0x4d, 0xc8, 0x38, 0x17, 0x65, 0x72, 0x73, 0x12, 0x72, 0xc1, 0x50, 0x08, 0xd0,
+	0x9a, 0x5e, 0x1e, 0x7b, 0xfa, 0x20, 0x4b, 0x93, 0x58, 0x78, 0x57, 0x5a, 0x24, 0xad, 0x7b, 0xdc,
+	0x17, 0x1d, 0x53, 0x5c, 0x4e, 0xce, 0x01, 0xe2, 0x43, 0xcc, 0x43, 0xfe, 0x58, 0x7f, 0x45, 0x7f,
+	0x54, 0x8f, 0x76, 0x6d, 0x6f, 0x42, 0x0c, 0x3c, 0xed, 0x6a, 0xbe, 0x4f, 0x1a, 0xcd, 0x37, 0xdf,
+	0x08, 0x1a, 0xfd, 0xe1, 0xf0, 0xdd, 0xf9, 0xdf, 0xfd, 0xd1, 0xf9, 0xc5, 0x87, 0xf0, 0x7e, 0x30,
+	0xea, 0xbf, 0xe9, 0x8f, 0xfa, 0xe1, 0xfd, 0xe0, 0xf2, 0xb2, 0xff, 0x76, 0xd0, 0x1c, 0x7e, 0xbc,
+	0x18, 0x5d, 0xe0, 0xbd, 0xe2, 0xf3, 0xfa, 0xd3, 0x3f, 0x8d, 0xff, 0x6a, 0xb0, 0xc7, 0xab, 0x0d,
+	0xe9, 0x98, 0x9f, 0x96, 0x74, 0xbc, 0x0f, 0x4b, 0x97, 0xe7, 0x6f, 0x3f, 0xf4, 0x47, 0x9f, 0x3e,
+	0x0e, 0xea, 0x73, 0xc7, 0x73, 0x27, 0xcb, 0xb6, 0x0a, 0x60, 0x1d, 0x16, 0x87, 0xfd, 0xab, 0x77,
+	0x17, 0xfd, 0x37, 0xf5, 0x3b, 0x05, 0x36, 0x59, 0xe2, 0x1f, 0x30, 0x3f, 0xba, 0x1a, 0x0e, 0xea,
+	0x77, 0x8f, 0xe7, 0x4e, 0x56, 0x7f, 0x7d, 0xda, 0x9c, 0xe4, 0x6b, 0x7e, 0x39, 0x57, 0xd3, 0x5f,
+	0x0d, 0x07, 0xb6, 0xd8, 0xd6, 0xf8, 0x17, 0x60, 0x3e, 0x2e, 0xb1, 0x06, 0x8b, 0xb9, 0xee, 0x68,
+	0xf3, 0xa7, 0x66, 0xdf, 0x21, 0x83, 0x65, 0xd1, 0xe6, 0x3e, 0xa4, 0xe4, 0x1c, 0x3f, 0x23, 0x36,
+	0x87, 0x08, 0xab, 0xc2, 0x68, 0xcf, 0x85, 0x0f, 0x79, 0x26, 0xb9, 0x27, 0x76, 0x07, 0x0f, 0x60,
+	0x37, 0xa5, 0xb4, 0x45, 0xd6, 0xb5, 0x55, 0x36, 0x0e, 0x4f, 0xb7, 0xdc, 0xc5, 0x2d, 0x58, 0xcf,
+	0xb8, 0xb2, 0x41, 0x69, 0xe7, 0x79, 0x92, 0x70, 0xaf, 0x8c, 0x66, 0xf3, 0x31, 0xec, 0x7a, 0x5a,
+	0xdc, 0x0c, 0x7f, 0x8f, 0x0f, 0xe1, 0xc8, 0xd2, 0xab, 0x9c, 0x9c, 0x0f, 0x5c, 0x4a, 0x4b, 0xce,
+	0x85, 0x53, 0x63, 0x83, 0xb7, 0x5c, 0x3b, 0x2e, 0x0a, 0xd2, 0x02, 0x3e, 0x83, 0xc7, 0x5c, 0x08,
+	0xca, 0x7c, 0xf8, 0x16, 0x77, 0x11, 0x9f, 0xc3, 0x13, 0x49, 0x22, 0x51, 0x9a, 0xbe, 0x49, 0xbe,
+	0x87, 0x3b, 0xb0, 0x31, 0x21, 0x5d, 0x07, 0x96, 0x70, 0x13, 0x98, 0x23, 0x2d, 0x6f, 0x44, 0x01,
+	0x8f, 0x60, 0xff, 0xf3, 0xb3, 0xaf, 0x13, 0x6a, 0x51, 0x9a, 0x5b, 0x45, 0x86, 0xb1, 0x80, 0x6c,
+	0x79, 0x36, 0xcc, 0x85, 0x30, 0xb9, 0xf6, 0x6c, 0x05, 0x1f, 0xc0, 0xc1, 0x6d, 0x38, 0xcb, 0x5b,
+	0x89, 0x12, 0x21, 0xf6, 0x85, 0xad, 0xe2, 0x21, 0xec, 0x4d, 0xfa, 0x21, 0x8c, 0xa4, 0xc0, 0x65,
+	0x97, 0xac, 0x57, 0x8e, 0x52, 0xd2, 0x9e, 0xad, 0x61, 0x03, 0x0e, 0xb3, 0xdc, 0xb5, 0x83, 0x36,
+	0x5e, 0x9d, 0x2a, 0x51, 0x1e, 0x61, 0xe9, 0x4c, 0x39, 0x6f, 0x4b, 0xc9, 0x59, 0x54, 0xe8, 0xeb,
+	0x9c, 0x60, 0xc9, 0x65, 0x46, 0x3b, 0x62, 0xeb, 0xb8, 0x0f, 0x3b, 0xb7, 0xc9, 0xaf, 0x72, 0xb2,
+	0x3d, 0x86, 0xf8, 0x08, 0x8e, 0xbf, 0x00, 0x56, 0x47, 0x6c, 0xc4, 0xaa, 0x67, 0xe5, 0x2b, 0xf4,
+	0x63, 0x9b, 0xb1, 0xa4, 0x59, 0xf0, 0x78, 0xfb, 0x56, 0xb4, 0x20, 0xa5, 0xe6, 0xa5, 0x0a, 0x96,
+	0xc6, 0x3a, 0x6f, 0xe3, 0x2e, 0x6c, 0x9d, 0x59, 0x93, 0x67, 0x85, 0x2c, 0x41, 0xe9, 0xae, 0xf2,
+	0x65, 0x75, 0x3b, 0xb8, 0x0e, 0x2b, 0x65, 0x50, 0x92, 0xf6, 0xca, 0xf7, 0x58, 0x3d, 0xb2, 0x85,
+	0x49, 0xd3, 0x5c, 0x2b, 0xdf, 0x0b, 0x92, 0x9c, 0xb0, 0x2a, 0x2b, 0xd8, 0xbb, 0x58, 0x87, 0xcd,
+	0x0a, 0xba, 0x76, 0xce, 0x5e, 0xbc, 0x75, 0x85, 0x4c, 0xbb, 0x6d, 0xc2, 0x4b, 0xa3, 0x34, 0xdb,
+	0xc7, 0x35, 0xa8, 0x65, 0x4a, 0x4f, 0x6d, 0x7f, 0x3f, 0xce, 0x0e, 0x49, 0x55, 0xcd, 0xce, 0x41,
+	0xbc, 0x89, 0xf3, 0xdc, 0xe7, 0x6e, 0x32, 0x3a, 0x87, 0xb1, 0x16, 0x49, 0x09, 0x5d, 0x9b, 0x97,
+	0xa3, 0x68, 0xaa, 0x59, 0x9e, 0x19, 0xa7, 0x66, 0xc7, 0xb8, 0x07, 0xdb, 0x5c, 0x1b, 0xdd, 0x4b,
+	0x4d, 0xee, 0x42, 0x4a, 0xde, 0x2a, 0x11, 0x5a, 0xdc, 0x8b, 0x36, 0x7b, 0x30, 0x9d, 0xaa, 0xa2,
+	0x64, 0x4b, 0xa9, 0xe9, 0x92, 0x64, 0x8d, 0xd8, 0xb5, 0x2a, 0x3c, 0x4e, 0xe5, 0xa2, 0x80, 0x92,
+	0x3d, 0x44, 0x80, 0x85, 0x16, 0x17, 0x9d, 0x3c, 0x63, 0x8f, 0xa6, 0x8e, 0x8c, 0xca, 0x76, 0x63,
+	0xa5, 0x82, 0xb4, 0x27, 0x5b, 0x52, 0x7f, 0x98, 0x3a, 0xf2, 0x73, 0xb8, 0x9c, 0x46, 0x92, 0xec,
+	0x71, 0x74, 0xdc, 0x4c, 0x8a, 0x54, 0x2e, 0x55, 0xce, 0x91, 0x64, 0x4f, 0x0a, 0x25, 0x22, 0xa7,
+	0x65, 0x4c, 0x27, 0xe5, 0xb6, 0xc3, 0x4e, 0x70, 0x1b, 0xb0, 0xbc, 0x61, 0x42, 0xdc, 0x86, 0xb6,
+	0x72, 0xde, 0xd8, 0x1e, 0x7b, 0x1a, 0x6f, 0x5e, 0xc9, 0x5e, 0x3e, 0x33, 0x61, 0xdc, 0xf6, 0x67,
+	0xb8, 0x02, 0x4b, 0x99, 0x49, 0x92, 0xd0, 0x35, 0x9e, 0xd8, 0x73, 0x5c, 0x05, 0x28, 0x96, 0x22,
+	0x31, 0x8e, 0xd8, 0x8f, 0x71, 0x7d, 0xaa, 0x12, 0x0a, 0xa2, 0x9d, 0xeb, 0x0e, 0xfb, 0x29, 0x8a,
+	0x73, 0x53, 0x00, 0xef, 0x13, 0xd6, 0xc4, 0x0d, 0x58, 0xab, 0xc4, 0x91, 0x96, 0x9f, 0x7a, 0xf6,
+	0x22, 0xb6, 0x2f, 0xd6, 0x1c, 0x2c, 0x09, 0x52, 0x99, 0x67, 0x3f, 0xc7, 0x27, 0xc0, 0xf7, 0x32,
+	0xa5, 0xcf, 0x82, 0xd2, 0x32, 0xfa, 0xd2, 0x58, 0xf6, 0x4b, 0x6b, 0xe5, 0xaf, 0x5a, 0xf3, 0xc5,
+	0xef, 0x93, 0xd7, 0xf6, 0xf5, 0x42, 0xf1, 0xf7, 0xdb, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb7,
+	0x44, 0x91, 0xf7, 0x14, 0x06, 0x00, 0x00,
 }
@@ -64,6 +64,8 @@ const (
 	ChatMessage_COMMUNITY                            ChatMessage_ContentType = 9
 	// Only local
 	ChatMessage_SYSTEM_MESSAGE_GAP ChatMessage_ContentType = 10
+	ChatMessage_POLL               ChatMessage_ContentType = 11
+	ChatMessage_FILE               ChatMessage_ContentType = 12
 )
 
 var ChatMessage_ContentType_name = map[int32]string{
@@ -78,6 +80,8 @@ var ChatMessage_ContentType_name = map[int32]string{
 	8:  "AUDIO",
 	9:  "COMMUNITY",
 	10: "SYSTEM_MESSAGE_GAP",
+	11: "POLL",
+	12: "FILE",
 }
 
 var ChatMessage_ContentType_value = map[string]int32{
@@ -92,6 +96,8 @@ var ChatMessage_ContentType_value = map[string]int32{
 	"AUDIO":                                8,
 	"COMMUNITY":                            9,
 	"SYSTEM_MESSAGE_GAP":                   10,
+	"POLL":                                 11,
+	"FILE":                                 12,
 }
 
 func (x ChatMessage_ContentType) String() string {
@@ -99,7 +105,7 @@ func (x ChatMessage_ContentType) String() string {
 }
 
 func (ChatMessage_ContentType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_263952f55fd35689, []int{5, 0}
+	return fileDescriptor_263952f55fd35689, []int{11, 0}
 }
 
 type StickerMessage struct {
@@ -251,6 +257,436 @@ func (m *AudioMessage) GetDurationMs() uint64 {
 	return 0
 }
 
+type FileMessage struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MimeType string `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Size of the file in bytes
+	Size uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Sha256 hash of the content of the file
+	Hash []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Number of FileChunk messages the content of the file is sent in
+	ChunksCount          uint32   `protobuf:"varint,5,opt,name=chunks_count,json=chunksCount,proto3" json:"chunks_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileMessage) Reset()         { *m = FileMessage{} }
+func (m *FileMessage) String() string { return proto.CompactTextString(m) }
+func (*FileMessage) ProtoMessage()    {}
+func (*FileMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{3}
+}
+
+func (m *FileMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileMessage.Unmarshal(m, b)
+}
+func (m *FileMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileMessage.Marshal(b, m, deterministic)
+}
+func (m *FileMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileMessage.Merge(m, src)
+}
+func (m *FileMessage) XXX_Size() int {
+	return xxx_messageInfo_FileMessage.Size(m)
+}
+func (m *FileMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileMessage proto.InternalMessageInfo
+
+func (m *FileMessage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileMessage) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *FileMessage) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileMessage) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *FileMessage) GetChunksCount() uint32 {
+	if m != nil {
+		return m.ChunksCount
+	}
+	return 0
+}
+
+// FileChunk is a part of the content of a file, the file is reassembled and
+// checked against its hash once all the chunks have been received
+type FileChunk struct {
+	// Sha256 hash of the content of the file
+	Hash        []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Index       uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	ChunksCount uint32 `protobuf:"varint,3,opt,name=chunks_count,json=chunksCount,proto3" json:"chunks_count,omitempty"`
+	Payload     []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	ChatId      string `protobuf:"bytes,5,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Grant for community chat messages
+	Grant []byte `protobuf:"bytes,6,opt,name=grant,proto3" json:"grant,omitempty"`
+	// The type of message (public/one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,7,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FileChunk) Reset()         { *m = FileChunk{} }
+func (m *FileChunk) String() string { return proto.CompactTextString(m) }
+func (*FileChunk) ProtoMessage()    {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{4}
+}
+
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChunk.Unmarshal(m, b)
+}
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
+}
+func (m *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(m, src)
+}
+func (m *FileChunk) XXX_Size() int {
+	return xxx_messageInfo_FileChunk.Size(m)
+}
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
+
+func (m *FileChunk) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *FileChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *FileChunk) GetChunksCount() uint32 {
+	if m != nil {
+		return m.ChunksCount
+	}
+	return 0
+}
+
+func (m *FileChunk) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *FileChunk) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *FileChunk) GetGrant() []byte {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *FileChunk) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
+type PollMessage struct {
+	// Options that can be voted
+	Options []string `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	// Unix timestamp in milliseconds after which votes are not accepted,
+	// 0 if the poll doesn't expire
+	ExpiresAt uint64 `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Whether more than one option can be voted
+	MultipleChoice       bool     `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollMessage) Reset()         { *m = PollMessage{} }
+func (m *PollMessage) String() string { return proto.CompactTextString(m) }
+func (*PollMessage) ProtoMessage()    {}
+func (*PollMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{5}
+}
+
+func (m *PollMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollMessage.Unmarshal(m, b)
+}
+func (m *PollMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollMessage.Marshal(b, m, deterministic)
+}
+func (m *PollMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollMessage.Merge(m, src)
+}
+func (m *PollMessage) XXX_Size() int {
+	return xxx_messageInfo_PollMessage.Size(m)
+}
+func (m *PollMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollMessage proto.InternalMessageInfo
+
+func (m *PollMessage) GetOptions() []string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *PollMessage) GetExpiresAt() uint64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *PollMessage) GetMultipleChoice() bool {
+	if m != nil {
+		return m.MultipleChoice
+	}
+	return false
+}
+
+// ChatMessagesTTL sets the time after which the messages of the chat are deleted
+type ChatMessagesTTL struct {
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Number of seconds after which messages are deleted, 0 if messages don't expire
+	Ttl uint64 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Grant for community chat messages
+	Grant []byte `protobuf:"bytes,4,opt,name=grant,proto3" json:"grant,omitempty"`
+	// The type of message (public/one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,5,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChatMessagesTTL) Reset()         { *m = ChatMessagesTTL{} }
+func (m *ChatMessagesTTL) String() string { return proto.CompactTextString(m) }
+func (*ChatMessagesTTL) ProtoMessage()    {}
+func (*ChatMessagesTTL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{6}
+}
+
+func (m *ChatMessagesTTL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatMessagesTTL.Unmarshal(m, b)
+}
+func (m *ChatMessagesTTL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatMessagesTTL.Marshal(b, m, deterministic)
+}
+func (m *ChatMessagesTTL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatMessagesTTL.Merge(m, src)
+}
+func (m *ChatMessagesTTL) XXX_Size() int {
+	return xxx_messageInfo_ChatMessagesTTL.Size(m)
+}
+func (m *ChatMessagesTTL) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatMessagesTTL.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatMessagesTTL proto.InternalMessageInfo
+
+func (m *ChatMessagesTTL) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *ChatMessagesTTL) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *ChatMessagesTTL) GetTtl() uint64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *ChatMessagesTTL) GetGrant() []byte {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *ChatMessagesTTL) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
+type ReadReceipt struct {
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// The ids of the messages of the recipient that have been read
+	MessageIds []string `protobuf:"bytes,3,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	// The type of message (one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,4,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ReadReceipt) Reset()         { *m = ReadReceipt{} }
+func (m *ReadReceipt) String() string { return proto.CompactTextString(m) }
+func (*ReadReceipt) ProtoMessage()    {}
+func (*ReadReceipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{7}
+}
+
+func (m *ReadReceipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadReceipt.Unmarshal(m, b)
+}
+func (m *ReadReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadReceipt.Marshal(b, m, deterministic)
+}
+func (m *ReadReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadReceipt.Merge(m, src)
+}
+func (m *ReadReceipt) XXX_Size() int {
+	return xxx_messageInfo_ReadReceipt.Size(m)
+}
+func (m *ReadReceipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadReceipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadReceipt proto.InternalMessageInfo
+
+func (m *ReadReceipt) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *ReadReceipt) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *ReadReceipt) GetMessageIds() []string {
+	if m != nil {
+		return m.MessageIds
+	}
+	return nil
+}
+
+func (m *ReadReceipt) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
+type TypingIndicator struct {
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Whether the author started or stopped typing
+	Typing bool `protobuf:"varint,3,opt,name=typing,proto3" json:"typing,omitempty"`
+	// The type of message (one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,4,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TypingIndicator) Reset()         { *m = TypingIndicator{} }
+func (m *TypingIndicator) String() string { return proto.CompactTextString(m) }
+func (*TypingIndicator) ProtoMessage()    {}
+func (*TypingIndicator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_263952f55fd35689, []int{8}
+}
+
+func (m *TypingIndicator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TypingIndicator.Unmarshal(m, b)
+}
+func (m *TypingIndicator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TypingIndicator.Marshal(b, m, deterministic)
+}
+func (m *TypingIndicator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypingIndicator.Merge(m, src)
+}
+func (m *TypingIndicator) XXX_Size() int {
+	return xxx_messageInfo_TypingIndicator.Size(m)
+}
+func (m *TypingIndicator) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypingIndicator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypingIndicator proto.InternalMessageInfo
+
+func (m *TypingIndicator) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *TypingIndicator) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *TypingIndicator) GetTyping() bool {
+	if m != nil {
+		return m.Typing
+	}
+	return false
+}
+
+func (m *TypingIndicator) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
 type EditMessage struct {
 	Clock uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
 	// Text of the message
@@ -270,7 +706,7 @@ func (m *EditMessage) Reset()         { *m = EditMessage{} }
 func (m *EditMessage) String() string { return proto.CompactTextString(m) }
 func (*EditMessage) ProtoMessage()    {}
 func (*EditMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_263952f55fd35689, []int{3}
+	return fileDescriptor_263952f55fd35689, []int{9}
 }
 
 func (m *EditMessage) XXX_Unmarshal(b []byte) error {
@@ -350,7 +786,7 @@ func (m *DeleteMessage) Reset()         { *m = DeleteMessage{} }
 func (m *DeleteMessage) String() string { return proto.CompactTextString(m) }
 func (*DeleteMessage) ProtoMessage()    {}
 func (*DeleteMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_263952f55fd35689, []int{4}
+	return fileDescriptor_263952f55fd35689, []int{10}
 }
 
 func (m *DeleteMessage) XXX_Unmarshal(b []byte) error {
@@ -432,9 +868,16 @@ type ChatMessage struct {
 	//	*ChatMessage_Image
 	//	*ChatMessage_Audio
 	//	*ChatMessage_Community
+	//	*ChatMessage_Poll
+	//	*ChatMessage_File
 	Payload isChatMessage_Payload `protobuf_oneof:"payload"`
 	// Grant for community chat messages
-	Grant                []byte   `protobuf:"bytes,13,opt,name=grant,proto3" json:"grant,omitempty"`
+	Grant []byte `protobuf:"bytes,13,opt,name=grant,proto3" json:"grant,omitempty"`
+	// Id of the root message of the thread the message is part of
+	ThreadId string `protobuf:"bytes,14,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	// Unix timestamp in milliseconds after which the message is deleted,
+	// 0 if the message doesn't expire
+	ExpiresAt            uint64   `protobuf:"varint,17,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -444,7 +887,7 @@ func (m *ChatMessage) Reset()         { *m = ChatMessage{} }
 func (m *ChatMessage) String() string { return proto.CompactTextString(m) }
 func (*ChatMessage) ProtoMessage()    {}
 func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_263952f55fd35689, []int{5}
+	return fileDescriptor_263952f55fd35689, []int{11}
 }
 
 func (m *ChatMessage) XXX_Unmarshal(b []byte) error {
@@ -541,6 +984,14 @@ type ChatMessage_Community struct {
 	Community []byte `protobuf:"bytes,12,opt,name=community,proto3,oneof"`
 }
 
+type ChatMessage_Poll struct {
+	Poll *PollMessage `protobuf:"bytes,15,opt,name=poll,proto3,oneof"`
+}
+
+type ChatMessage_File struct {
+	File *FileMessage `protobuf:"bytes,16,opt,name=file,proto3,oneof"`
+}
+
 func (*ChatMessage_Sticker) isChatMessage_Payload() {}
 
 func (*ChatMessage_Image) isChatMessage_Payload() {}
@@ -549,6 +1000,10 @@ func (*ChatMessage_Audio) isChatMessage_Payload() {}
 
 func (*ChatMessage_Community) isChatMessage_Payload() {}
 
+func (*ChatMessage_Poll) isChatMessage_Payload() {}
+
+func (*ChatMessage_File) isChatMessage_Payload() {}
+
 func (m *ChatMessage) GetPayload() isChatMessage_Payload {
 	if m != nil {
 		return m.Payload
@@ -584,6 +1039,20 @@ func (m *ChatMessage) GetCommunity() []byte {
 	return nil
 }
 
+func (m *ChatMessage) GetPoll() *PollMessage {
+	if x, ok := m.GetPayload().(*ChatMessage_Poll); ok {
+		return x.Poll
+	}
+	return nil
+}
+
+func (m *ChatMessage) GetFile() *FileMessage {
+	if x, ok := m.GetPayload().(*ChatMessage_File); ok {
+		return x.File
+	}
+	return nil
+}
+
 func (m *ChatMessage) GetGrant() []byte {
 	if m != nil {
 		return m.Grant
@@ -591,6 +1060,20 @@ func (m *ChatMessage) GetGrant() []byte {
 	return nil
 }
 
+func (m *ChatMessage) GetThreadId() string {
+	if m != nil {
+		return m.ThreadId
+	}
+	return ""
+}
+
+func (m *ChatMessage) GetExpiresAt() uint64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ChatMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -598,6 +1081,8 @@ func (*ChatMessage) XXX_OneofWrappers() []interface{} {
 		(*ChatMessage_Image)(nil),
 		(*ChatMessage_Audio)(nil),
 		(*ChatMessage_Community)(nil),
+		(*ChatMessage_Poll)(nil),
+		(*ChatMessage_File)(nil),
 	}
 }
 
@@ -607,6 +1092,12 @@ func init() {
 	proto.RegisterType((*StickerMessage)(nil), "protobuf.StickerMessage")
 	proto.RegisterType((*ImageMessage)(nil), "protobuf.ImageMessage")
 	proto.RegisterType((*AudioMessage)(nil), "protobuf.AudioMessage")
+	proto.RegisterType((*FileMessage)(nil), "protobuf.FileMessage")
+	proto.RegisterType((*FileChunk)(nil), "protobuf.FileChunk")
+	proto.RegisterType((*PollMessage)(nil), "protobuf.PollMessage")
+	proto.RegisterType((*ChatMessagesTTL)(nil), "protobuf.ChatMessagesTTL")
+	proto.RegisterType((*ReadReceipt)(nil), "protobuf.ReadReceipt")
+	proto.RegisterType((*TypingIndicator)(nil), "protobuf.TypingIndicator")
 	proto.RegisterType((*EditMessage)(nil), "protobuf.EditMessage")
 	proto.RegisterType((*DeleteMessage)(nil), "protobuf.DeleteMessage")
 	proto.RegisterType((*ChatMessage)(nil), "protobuf.ChatMessage")
@@ -617,50 +1108,72 @@ func init() {
 }
 
 var fileDescriptor_263952f55fd35689 = []byte{
-	// 719 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x6f, 0x9b, 0x48,
-	0x14, 0x0e, 0x36, 0x18, 0xf3, 0xb0, 0x23, 0x34, 0xc9, 0x26, 0xec, 0x6a, 0x77, 0xe3, 0xb5, 0x56,
-	0x5a, 0x9f, 0xbc, 0x52, 0x9a, 0x4a, 0x91, 0x7a, 0x22, 0x36, 0x72, 0x68, 0x0a, 0x76, 0x07, 0xdc,
-	0x36, 0xbd, 0x20, 0x82, 0xa7, 0x31, 0x4a, 0x00, 0xcb, 0x8c, 0xa5, 0xfa, 0x47, 0xf5, 0xda, 0x6b,
-	0x7f, 0x48, 0x0f, 0xfd, 0x2b, 0xd5, 0x0c, 0xc6, 0x10, 0x4b, 0x4d, 0x72, 0xe2, 0xbd, 0xc7, 0xfb,
-	0xbe, 0xf9, 0xe6, 0x9b, 0x79, 0x03, 0x28, 0x9c, 0x07, 0xd4, 0x8f, 0x49, 0x96, 0x05, 0xb7, 0xa4,
-	0xbf, 0x58, 0xa6, 0x34, 0x45, 0x4d, 0xfe, 0xb9, 0x59, 0x7d, 0xfa, 0x43, 0x25, 0xc9, 0x2a, 0xce,
-	0xf2, 0x72, 0xf7, 0x1c, 0xf6, 0x5d, 0x1a, 0x85, 0x77, 0x64, 0x69, 0xe7, 0xed, 0x08, 0x81, 0x38,
-	0x0f, 0xb2, 0xb9, 0x2e, 0x74, 0x84, 0x9e, 0x82, 0x79, 0xcc, 0x6a, 0x8b, 0x20, 0xbc, 0xd3, 0x6b,
-	0x1d, 0xa1, 0x27, 0x61, 0x1e, 0x77, 0xdf, 0x42, 0xcb, 0x8a, 0x83, 0x5b, 0x52, 0xe0, 0x74, 0x90,
-	0x17, 0xc1, 0xfa, 0x3e, 0x0d, 0x66, 0x1c, 0xda, 0xc2, 0x45, 0x8a, 0xfe, 0x03, 0x91, 0xae, 0x17,
-	0x84, 0xa3, 0xf7, 0x4f, 0x0f, 0xfa, 0x85, 0x92, 0x3e, 0xc7, 0x7b, 0xeb, 0x05, 0xc1, 0xbc, 0xa1,
-	0xfb, 0x55, 0x80, 0x96, 0xb1, 0x9a, 0x45, 0xe9, 0xd3, 0x9c, 0x67, 0x0f, 0x38, 0x3b, 0x25, 0x67,
-	0x15, 0x9f, 0x27, 0xe5, 0x02, 0xe8, 0x04, 0xd4, 0xd9, 0x6a, 0x19, 0xd0, 0x28, 0x4d, 0xfc, 0x38,
-	0xd3, 0xeb, 0x1d, 0xa1, 0x27, 0x62, 0x28, 0x4a, 0x76, 0xd6, 0x7d, 0x09, 0xca, 0x16, 0x83, 0x8e,
-	0x00, 0x4d, 0x9d, 0x2b, 0x67, 0xfc, 0xde, 0xf1, 0x8d, 0xe9, 0xd0, 0x1a, 0xfb, 0xde, 0xf5, 0xc4,
-	0xd4, 0xf6, 0x90, 0x0c, 0x75, 0xc3, 0x18, 0x68, 0x02, 0x0f, 0x6c, 0xac, 0xd5, 0xba, 0xdf, 0x04,
-	0x50, 0xcd, 0x59, 0x44, 0x0b, 0xdd, 0x87, 0x20, 0x85, 0xf7, 0x69, 0x78, 0xc7, 0x55, 0x8b, 0x38,
-	0x4f, 0x98, 0x8b, 0x94, 0x7c, 0xa6, 0x5c, 0xb3, 0x82, 0x79, 0x8c, 0x8e, 0x41, 0xe6, 0x87, 0x15,
-	0xcd, 0xb8, 0x1a, 0x05, 0x37, 0x58, 0x6a, 0xcd, 0xd0, 0x5f, 0x00, 0x9b, 0x03, 0x64, 0xff, 0x44,
-	0xfe, 0x4f, 0xd9, 0x54, 0xac, 0x19, 0x5b, 0xe1, 0x76, 0x19, 0x24, 0x54, 0x97, 0xb8, 0x2f, 0x79,
-	0x82, 0xce, 0xa1, 0x55, 0x80, 0xb8, 0x3b, 0x0d, 0xee, 0xce, 0x6f, 0xa5, 0x3b, 0x1b, 0x81, 0xdc,
-	0x12, 0x35, 0x2e, 0x93, 0xee, 0x17, 0x01, 0xda, 0x43, 0x72, 0x4f, 0x28, 0x79, 0x7c, 0x0f, 0x15,
-	0xbd, 0xb5, 0x47, 0xf4, 0xd6, 0x7f, 0xa9, 0x57, 0x7c, 0x4c, 0xaf, 0xf4, 0x6c, 0xbd, 0x3f, 0x24,
-	0x50, 0x07, 0xf3, 0xe0, 0x09, 0xc7, 0xff, 0x04, 0x85, 0x46, 0x31, 0xc9, 0x68, 0x10, 0x2f, 0xb8,
-	0x5e, 0x11, 0x97, 0x85, 0xed, 0x79, 0xd4, 0x2b, 0xe7, 0x71, 0x02, 0xea, 0x92, 0x64, 0x8b, 0x34,
-	0xc9, 0x88, 0x4f, 0xd3, 0x8d, 0xef, 0x50, 0x94, 0xbc, 0x14, 0xfd, 0x0e, 0x4d, 0x92, 0x64, 0x7e,
-	0x12, 0xc4, 0xb9, 0x5c, 0x05, 0xcb, 0x24, 0xc9, 0x9c, 0x20, 0x26, 0x55, 0x6f, 0x1a, 0x0f, 0xbc,
-	0xd9, 0xdd, 0xa6, 0xfc, 0xdc, 0x6d, 0xa2, 0x21, 0xb4, 0xc2, 0x34, 0xa1, 0x24, 0xa1, 0x39, 0xb2,
-	0xc9, 0x91, 0xff, 0x94, 0xc8, 0x8a, 0x07, 0xfd, 0x41, 0xde, 0x99, 0xb3, 0x84, 0x65, 0x82, 0xce,
-	0x40, 0xce, 0xf2, 0x21, 0xd7, 0x95, 0x8e, 0xd0, 0x53, 0x4f, 0xf5, 0x92, 0xe0, 0xe1, 0xf4, 0x5f,
-	0xee, 0xe1, 0xa2, 0x15, 0xf5, 0x41, 0x8a, 0xd8, 0x80, 0xea, 0xc0, 0x31, 0x47, 0x3b, 0x73, 0x5b,
-	0x22, 0xf2, 0x36, 0xd6, 0x1f, 0xb0, 0xd9, 0xd1, 0xd5, 0xdd, 0xfe, 0xea, 0x4c, 0xb2, 0x7e, 0xde,
-	0x86, 0xfe, 0x06, 0x25, 0x4c, 0xe3, 0x78, 0x95, 0x44, 0x74, 0xad, 0xb7, 0xd8, 0xb5, 0xb8, 0xdc,
-	0xc3, 0x65, 0xa9, 0xbc, 0x32, 0xed, 0xca, 0x95, 0xe9, 0x7e, 0x17, 0x40, 0xad, 0x6c, 0x14, 0xe9,
-	0x70, 0x58, 0x0c, 0xe9, 0x60, 0xec, 0x78, 0xa6, 0xe3, 0x15, 0x63, 0xba, 0x0f, 0xe0, 0x99, 0x1f,
-	0x3c, 0x7f, 0xf2, 0xc6, 0xb0, 0x1c, 0x4d, 0x40, 0x2a, 0xc8, 0xae, 0x67, 0x0d, 0xae, 0x4c, 0xac,
-	0xd5, 0x10, 0x40, 0xc3, 0xf5, 0x0c, 0x6f, 0xea, 0x6a, 0x75, 0xa4, 0x80, 0x64, 0xda, 0xe3, 0xd7,
-	0x96, 0x26, 0xa2, 0x63, 0x38, 0xf0, 0xb0, 0xe1, 0xb8, 0xc6, 0xc0, 0xb3, 0xc6, 0x8c, 0xd1, 0xb6,
-	0x0d, 0x67, 0xa8, 0x49, 0xa8, 0x07, 0xff, 0xba, 0xd7, 0xae, 0x67, 0xda, 0xbe, 0x6d, 0xba, 0xae,
-	0x31, 0x32, 0xb7, 0xab, 0x4d, 0xb0, 0xf5, 0xce, 0xf0, 0x4c, 0x7f, 0x84, 0xc7, 0xd3, 0x89, 0xd6,
-	0x60, 0x6c, 0x96, 0x6d, 0x8c, 0x4c, 0x4d, 0x66, 0x21, 0x7f, 0x38, 0xb4, 0x26, 0x6a, 0x83, 0xc2,
-	0xc8, 0xa6, 0x8e, 0xe5, 0x5d, 0x6b, 0x0a, 0x7b, 0x5a, 0x76, 0xe8, 0x46, 0xc6, 0x44, 0x83, 0x0b,
-	0x65, 0xfb, 0xe0, 0x5d, 0xb4, 0x3f, 0xaa, 0xfd, 0xff, 0x5f, 0x15, 0x1e, 0xde, 0x34, 0x78, 0xf4,
-	0xe2, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1a, 0xb9, 0xa5, 0x8e, 0xdc, 0x05, 0x00, 0x00,
+	// 1066 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0x2d, 0xea, 0x87, 0x43, 0xc9, 0x66, 0x37, 0x69, 0xc2, 0x36, 0x4d, 0xad, 0x08, 0x05,
+	0x22, 0xa0, 0x80, 0x0a, 0xa4, 0x29, 0x10, 0xa0, 0x27, 0x46, 0x66, 0x6c, 0x36, 0x16, 0xa5, 0xae,
+	0xe8, 0xb6, 0xee, 0x85, 0x60, 0xc8, 0x8d, 0xb5, 0x30, 0xff, 0x20, 0xae, 0x00, 0xbb, 0x6f, 0x90,
+	0x6b, 0x2f, 0xbd, 0xf7, 0xde, 0x6b, 0xaf, 0x7d, 0x90, 0x3e, 0x44, 0x5f, 0xa1, 0xd8, 0x25, 0x29,
+	0x52, 0x32, 0xec, 0x3a, 0x6d, 0x4f, 0x9a, 0x19, 0xcc, 0xcc, 0x7e, 0xfb, 0x7d, 0xc3, 0x1d, 0x01,
+	0xf2, 0x17, 0x1e, 0x73, 0x23, 0x92, 0x65, 0xde, 0x39, 0x19, 0xa5, 0xcb, 0x84, 0x25, 0xa8, 0x23,
+	0x7e, 0xde, 0xac, 0xde, 0x7e, 0xac, 0x92, 0x78, 0x15, 0x65, 0x79, 0x78, 0xf0, 0x02, 0xf6, 0xe6,
+	0x8c, 0xfa, 0x17, 0x64, 0x39, 0xc9, 0xd3, 0x11, 0x02, 0x79, 0xe1, 0x65, 0x0b, 0x5d, 0xea, 0x4b,
+	0x43, 0x05, 0x0b, 0x9b, 0xc7, 0x52, 0xcf, 0xbf, 0xd0, 0x77, 0xfb, 0xd2, 0xb0, 0x89, 0x85, 0x3d,
+	0xf8, 0x16, 0xba, 0x56, 0xe4, 0x9d, 0x93, 0xb2, 0x4e, 0x87, 0x76, 0xea, 0x5d, 0x85, 0x89, 0x17,
+	0x88, 0xd2, 0x2e, 0x2e, 0x5d, 0xf4, 0x14, 0x64, 0x76, 0x95, 0x12, 0x51, 0xbd, 0xf7, 0xec, 0xde,
+	0xa8, 0x44, 0x32, 0x12, 0xf5, 0xce, 0x55, 0x4a, 0xb0, 0x48, 0x18, 0xfc, 0x2e, 0x41, 0xd7, 0x58,
+	0x05, 0x34, 0xf9, 0xe7, 0x9e, 0xcf, 0x37, 0x7a, 0xf6, 0xab, 0x9e, 0xf5, 0xfa, 0xdc, 0xa9, 0x0e,
+	0x40, 0x07, 0xa0, 0x06, 0xab, 0xa5, 0xc7, 0x68, 0x12, 0xbb, 0x51, 0xa6, 0x37, 0xfa, 0xd2, 0x50,
+	0xc6, 0x50, 0x86, 0x26, 0xd9, 0xe0, 0x2b, 0x50, 0xd6, 0x35, 0xe8, 0x01, 0xa0, 0x53, 0xfb, 0xb5,
+	0x3d, 0xfd, 0xde, 0x76, 0x8d, 0xd3, 0x43, 0x6b, 0xea, 0x3a, 0x67, 0x33, 0x53, 0xdb, 0x41, 0x6d,
+	0x68, 0x18, 0xc6, 0x58, 0x93, 0x84, 0x31, 0xc1, 0xda, 0xee, 0xe0, 0x9d, 0x04, 0xea, 0x2b, 0x1a,
+	0x92, 0x1a, 0x87, 0xb1, 0x17, 0x91, 0x92, 0x43, 0x6e, 0xa3, 0x47, 0xa0, 0x44, 0x34, 0x22, 0xee,
+	0x1a, 0xb6, 0x82, 0x3b, 0x3c, 0x20, 0x8e, 0x42, 0x20, 0x67, 0xf4, 0x27, 0x52, 0x20, 0x12, 0xf6,
+	0x5a, 0x08, 0x59, 0xdc, 0x3c, 0x17, 0xe2, 0x09, 0x74, 0xfd, 0xc5, 0x2a, 0xbe, 0xc8, 0x5c, 0x3f,
+	0x59, 0xc5, 0x4c, 0x6f, 0xf6, 0xa5, 0x61, 0x0f, 0xab, 0x79, 0x6c, 0xcc, 0x43, 0x83, 0x3f, 0x25,
+	0x50, 0x38, 0x96, 0x31, 0x8f, 0x6d, 0xa8, 0x59, 0x36, 0xb9, 0x0f, 0x4d, 0x1a, 0x07, 0xe4, 0x52,
+	0xa0, 0xe8, 0xe1, 0xdc, 0xb9, 0xd6, 0xba, 0x71, 0xad, 0x75, 0x5d, 0x0e, 0x79, 0x53, 0x8e, 0x87,
+	0xd0, 0x16, 0x33, 0x47, 0x03, 0x01, 0x49, 0xc1, 0x2d, 0xee, 0x5a, 0x01, 0x3f, 0xeb, 0x7c, 0xe9,
+	0xc5, 0x4c, 0x6f, 0x89, 0x82, 0xdc, 0x41, 0x2f, 0xa0, 0x5b, 0x4c, 0x67, 0x4e, 0x47, 0x5b, 0xa8,
+	0xf8, 0x61, 0xa5, 0x62, 0x41, 0xa4, 0x90, 0x4e, 0x8d, 0x2a, 0x67, 0x90, 0x80, 0x3a, 0x4b, 0xc2,
+	0xb0, 0x36, 0x20, 0x49, 0xca, 0xb5, 0xcb, 0x74, 0xa9, 0xdf, 0x18, 0x2a, 0xb8, 0x74, 0xd1, 0x63,
+	0x00, 0x72, 0x99, 0xd2, 0x25, 0xc9, 0x5c, 0x8f, 0x89, 0x9b, 0xca, 0x58, 0x29, 0x22, 0x06, 0x43,
+	0x4f, 0x61, 0x3f, 0x5a, 0x85, 0x8c, 0xa6, 0x21, 0x71, 0xfd, 0x45, 0x42, 0xfd, 0x9c, 0xfb, 0x0e,
+	0xde, 0x2b, 0xc3, 0x63, 0x11, 0x1d, 0xfc, 0x2a, 0xc1, 0xfe, 0x78, 0xe1, 0xb1, 0xe2, 0xc4, 0xcc,
+	0x71, 0x4e, 0xf8, 0xa5, 0xfc, 0x30, 0xf1, 0x2f, 0x04, 0xab, 0x32, 0xce, 0x9d, 0x3a, 0x07, 0xbb,
+	0x1b, 0x1c, 0x68, 0xd0, 0x60, 0x2c, 0x2c, 0xb4, 0xe5, 0x66, 0xc5, 0x8a, 0x7c, 0x1b, 0x2b, 0xcd,
+	0x3b, 0xb3, 0xf2, 0x8b, 0x04, 0x2a, 0x26, 0x5e, 0x80, 0x89, 0x4f, 0x68, 0xca, 0xde, 0x17, 0xe0,
+	0x01, 0x94, 0xdd, 0x5c, 0x1a, 0xf0, 0xcf, 0x82, 0x33, 0x09, 0x45, 0xc8, 0x0a, 0xb2, 0x6b, 0xc8,
+	0xe4, 0x3b, 0x23, 0xfb, 0x59, 0x82, 0x7d, 0xe7, 0x2a, 0xa5, 0xf1, 0xb9, 0x15, 0x07, 0xd4, 0xf7,
+	0x58, 0xb2, 0x7c, 0x5f, 0x74, 0x0f, 0xa0, 0xc5, 0x44, 0x87, 0x42, 0xa1, 0xc2, 0xfb, 0x0f, 0xa0,
+	0xfe, 0x90, 0x40, 0x35, 0x03, 0x5a, 0x6a, 0x7a, 0x03, 0x20, 0x04, 0x32, 0x23, 0x97, 0xac, 0x40,
+	0x23, 0xec, 0x3a, 0xc8, 0xc6, 0x06, 0xc8, 0xc7, 0x00, 0x15, 0x85, 0x02, 0x8a, 0x82, 0x95, 0x35,
+	0x83, 0x95, 0xe0, 0xcd, 0xdb, 0x04, 0x6f, 0xdd, 0xf9, 0x06, 0xbf, 0x49, 0xd0, 0x3b, 0x24, 0x21,
+	0x61, 0xe4, 0xf6, 0x3b, 0xdc, 0x48, 0xea, 0x26, 0xde, 0xc6, 0x8d, 0x78, 0xff, 0xa7, 0x01, 0x7d,
+	0xd7, 0x06, 0xb5, 0xf6, 0x15, 0xdd, 0x80, 0xf6, 0x13, 0x50, 0x18, 0x8d, 0x48, 0xc6, 0xbc, 0x28,
+	0x2d, 0x3f, 0xd9, 0x75, 0x60, 0xad, 0x47, 0xa3, 0xa6, 0xc7, 0x01, 0xa8, 0x4b, 0x92, 0xa5, 0x49,
+	0x9c, 0x11, 0x97, 0x25, 0x05, 0xef, 0x50, 0x86, 0x9c, 0x04, 0x7d, 0x04, 0x1d, 0x12, 0x67, 0xae,
+	0x78, 0x8d, 0xf3, 0x97, 0xa9, 0x4d, 0xe2, 0xcc, 0xe6, 0x0f, 0x72, 0x8d, 0x9b, 0xd6, 0x06, 0x37,
+	0xff, 0xfa, 0x75, 0x42, 0x87, 0xd0, 0xf5, 0x93, 0x98, 0x91, 0x98, 0xe5, 0x95, 0x1d, 0x51, 0xf9,
+	0xa4, 0xaa, 0xac, 0x71, 0x30, 0x1a, 0xe7, 0x99, 0x79, 0x17, 0xbf, 0x72, 0xd0, 0x73, 0x68, 0x67,
+	0xf9, 0x4e, 0xd6, 0x95, 0xbe, 0x34, 0x54, 0x9f, 0xe9, 0x55, 0x83, 0xcd, 0x65, 0x7d, 0xbc, 0x83,
+	0xcb, 0x54, 0x34, 0x82, 0x26, 0xe5, 0xfb, 0x54, 0x07, 0x51, 0xf3, 0x60, 0x6b, 0xcd, 0x56, 0x15,
+	0x79, 0x1a, 0xcf, 0xf7, 0xf8, 0xaa, 0xd3, 0xd5, 0xed, 0xfc, 0xfa, 0x0a, 0xe5, 0xf9, 0x22, 0x0d,
+	0x7d, 0x0a, 0x8a, 0x9f, 0x44, 0xd1, 0x2a, 0xa6, 0xec, 0x4a, 0xef, 0xf2, 0xb1, 0x38, 0xde, 0xc1,
+	0x55, 0x08, 0x7d, 0x0e, 0x72, 0x9a, 0x84, 0xa1, 0xbe, 0x2f, 0xda, 0xd5, 0xd8, 0xaa, 0xbd, 0xd7,
+	0xc7, 0x3b, 0x58, 0x24, 0xf1, 0xe4, 0xb7, 0x34, 0x24, 0xba, 0xb6, 0x9d, 0x5c, 0xdb, 0xa2, 0x3c,
+	0x99, 0x27, 0x55, 0xc3, 0xd8, 0xab, 0x0f, 0xe3, 0x23, 0x50, 0xd8, 0x62, 0x49, 0xbc, 0x80, 0x0b,
+	0xb8, 0x97, 0xef, 0xd3, 0x3c, 0x90, 0x8f, 0x77, 0xed, 0xf5, 0xff, 0x60, 0xeb, 0xf5, 0x1f, 0xfc,
+	0x25, 0x81, 0x5a, 0xa3, 0x1f, 0xe9, 0x70, 0xbf, 0xdc, 0xf4, 0xe3, 0xa9, 0xed, 0x98, 0xb6, 0x53,
+	0xee, 0xfa, 0x3d, 0x00, 0xc7, 0xfc, 0xc1, 0x71, 0x67, 0x27, 0x86, 0x65, 0x6b, 0x12, 0x52, 0xa1,
+	0x3d, 0x77, 0xac, 0xf1, 0x6b, 0x13, 0x6b, 0xbb, 0x08, 0xa0, 0x35, 0x77, 0x0c, 0xe7, 0x74, 0xae,
+	0x35, 0x90, 0x02, 0x4d, 0x73, 0x32, 0xfd, 0xc6, 0xd2, 0x64, 0xf4, 0x10, 0xee, 0x39, 0xd8, 0xb0,
+	0xe7, 0xc6, 0xd8, 0xb1, 0xa6, 0xbc, 0xe3, 0x64, 0x62, 0xd8, 0x87, 0x5a, 0x13, 0x0d, 0xe1, 0xb3,
+	0xf9, 0xd9, 0xdc, 0x31, 0x27, 0xee, 0xc4, 0x9c, 0xcf, 0x8d, 0x23, 0x73, 0x7d, 0xda, 0x0c, 0x5b,
+	0xdf, 0x19, 0x8e, 0xe9, 0x1e, 0xe1, 0xe9, 0xe9, 0x4c, 0x6b, 0xf1, 0x6e, 0xd6, 0xc4, 0x38, 0x32,
+	0xb5, 0x36, 0x37, 0xc5, 0xbf, 0x0f, 0xad, 0x83, 0x7a, 0xa0, 0xf0, 0x66, 0xa7, 0xb6, 0xe5, 0x9c,
+	0x69, 0x0a, 0xff, 0x7f, 0xb2, 0xd5, 0xee, 0xc8, 0x98, 0x69, 0x80, 0x3a, 0x20, 0xcf, 0xa6, 0x27,
+	0x27, 0x9a, 0xca, 0xad, 0x57, 0xd6, 0x89, 0xa9, 0x75, 0x5f, 0x2a, 0xeb, 0xd5, 0xfd, 0xb2, 0xf7,
+	0xa3, 0x3a, 0xfa, 0xe2, 0xeb, 0x92, 0xf1, 0x37, 0x2d, 0x61, 0x7d, 0xf9, 0x77, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x1c, 0x70, 0x13, 0x37, 0x35, 0x0a, 0x00, 0x00,
 }
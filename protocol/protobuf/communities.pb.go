@@ -48,6 +48,39 @@ func (CommunityMember_Roles) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_f937943d74c1cd8b, []int{1, 0}
 }
 
+// Each permission is a single bit in the permissions bitset,
+// values must be powers of two and must never change
+type CommunityRole_Permission int32
+
+const (
+	CommunityRole_UNKNOWN_PERMISSION CommunityRole_Permission = 0
+	CommunityRole_MANAGE_USERS       CommunityRole_Permission = 1
+	CommunityRole_BAN_USERS          CommunityRole_Permission = 2
+	CommunityRole_DELETE_MESSAGES    CommunityRole_Permission = 4
+)
+
+var CommunityRole_Permission_name = map[int32]string{
+	0: "UNKNOWN_PERMISSION",
+	1: "MANAGE_USERS",
+	2: "BAN_USERS",
+	4: "DELETE_MESSAGES",
+}
+
+var CommunityRole_Permission_value = map[string]int32{
+	"UNKNOWN_PERMISSION": 0,
+	"MANAGE_USERS":       1,
+	"BAN_USERS":          2,
+	"DELETE_MESSAGES":    4,
+}
+
+func (x CommunityRole_Permission) String() string {
+	return proto.EnumName(CommunityRole_Permission_name, int32(x))
+}
+
+func (CommunityRole_Permission) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{2, 0}
+}
+
 type CommunityPermissions_Access int32
 
 const (
@@ -76,7 +109,63 @@ func (x CommunityPermissions_Access) String() string {
 }
 
 func (CommunityPermissions_Access) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{2, 0}
+	return fileDescriptor_f937943d74c1cd8b, []int{4, 0}
+}
+
+type TokenRequirement_TokenType int32
+
+const (
+	TokenRequirement_UNKNOWN_TOKEN_TYPE TokenRequirement_TokenType = 0
+	TokenRequirement_ERC20              TokenRequirement_TokenType = 1
+	TokenRequirement_ERC721             TokenRequirement_TokenType = 2
+)
+
+var TokenRequirement_TokenType_name = map[int32]string{
+	0: "UNKNOWN_TOKEN_TYPE",
+	1: "ERC20",
+	2: "ERC721",
+}
+
+var TokenRequirement_TokenType_value = map[string]int32{
+	"UNKNOWN_TOKEN_TYPE": 0,
+	"ERC20":              1,
+	"ERC721":             2,
+}
+
+func (x TokenRequirement_TokenType) String() string {
+	return proto.EnumName(TokenRequirement_TokenType_name, int32(x))
+}
+
+func (TokenRequirement_TokenType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{5, 0}
+}
+
+type CommunityMemberAction_ActionType int32
+
+const (
+	CommunityMemberAction_UNKNOWN_ACTION_TYPE CommunityMemberAction_ActionType = 0
+	CommunityMemberAction_KICK                CommunityMemberAction_ActionType = 1
+	CommunityMemberAction_BAN                 CommunityMemberAction_ActionType = 2
+)
+
+var CommunityMemberAction_ActionType_name = map[int32]string{
+	0: "UNKNOWN_ACTION_TYPE",
+	1: "KICK",
+	2: "BAN",
+}
+
+var CommunityMemberAction_ActionType_value = map[string]int32{
+	"UNKNOWN_ACTION_TYPE": 0,
+	"KICK":                1,
+	"BAN":                 2,
+}
+
+func (x CommunityMemberAction_ActionType) String() string {
+	return proto.EnumName(CommunityMemberAction_ActionType_name, int32(x))
+}
+
+func (CommunityMemberAction_ActionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{13, 0}
 }
 
 type Grant struct {
@@ -143,10 +232,13 @@ func (m *Grant) GetClock() uint64 {
 }
 
 type CommunityMember struct {
-	Roles                []CommunityMember_Roles `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=protobuf.CommunityMember_Roles" json:"roles,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Roles []CommunityMember_Roles `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=protobuf.CommunityMember_Roles" json:"roles,omitempty"`
+	// IDs of the roles from CommunityDescription.roles assigned to the member.
+	// When the member is listed in a CommunityChat, the roles only apply to that chat
+	RoleIds              []string `protobuf:"bytes,2,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CommunityMember) Reset()         { *m = CommunityMember{} }
@@ -181,21 +273,134 @@ func (m *CommunityMember) GetRoles() []CommunityMember_Roles {
 	return nil
 }
 
+func (m *CommunityMember) GetRoleIds() []string {
+	if m != nil {
+		return m.RoleIds
+	}
+	return nil
+}
+
+type CommunityRole struct {
+	RoleId               string   `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions          uint64   `protobuf:"varint,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommunityRole) Reset()         { *m = CommunityRole{} }
+func (m *CommunityRole) String() string { return proto.CompactTextString(m) }
+func (*CommunityRole) ProtoMessage()    {}
+func (*CommunityRole) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{2}
+}
+
+func (m *CommunityRole) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommunityRole.Unmarshal(m, b)
+}
+func (m *CommunityRole) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommunityRole.Marshal(b, m, deterministic)
+}
+func (m *CommunityRole) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommunityRole.Merge(m, src)
+}
+func (m *CommunityRole) XXX_Size() int {
+	return xxx_messageInfo_CommunityRole.Size(m)
+}
+func (m *CommunityRole) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommunityRole.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommunityRole proto.InternalMessageInfo
+
+func (m *CommunityRole) GetRoleId() string {
+	if m != nil {
+		return m.RoleId
+	}
+	return ""
+}
+
+func (m *CommunityRole) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommunityRole) GetPermissions() uint64 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+// CommunityPermissionOverride overrides the permissions of a role in a specific chat,
+// deny takes precedence over allow
+type CommunityPermissionOverride struct {
+	Allow                uint64   `protobuf:"varint,1,opt,name=allow,proto3" json:"allow,omitempty"`
+	Deny                 uint64   `protobuf:"varint,2,opt,name=deny,proto3" json:"deny,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommunityPermissionOverride) Reset()         { *m = CommunityPermissionOverride{} }
+func (m *CommunityPermissionOverride) String() string { return proto.CompactTextString(m) }
+func (*CommunityPermissionOverride) ProtoMessage()    {}
+func (*CommunityPermissionOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{3}
+}
+
+func (m *CommunityPermissionOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommunityPermissionOverride.Unmarshal(m, b)
+}
+func (m *CommunityPermissionOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommunityPermissionOverride.Marshal(b, m, deterministic)
+}
+func (m *CommunityPermissionOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommunityPermissionOverride.Merge(m, src)
+}
+func (m *CommunityPermissionOverride) XXX_Size() int {
+	return xxx_messageInfo_CommunityPermissionOverride.Size(m)
+}
+func (m *CommunityPermissionOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommunityPermissionOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommunityPermissionOverride proto.InternalMessageInfo
+
+func (m *CommunityPermissionOverride) GetAllow() uint64 {
+	if m != nil {
+		return m.Allow
+	}
+	return 0
+}
+
+func (m *CommunityPermissionOverride) GetDeny() uint64 {
+	if m != nil {
+		return m.Deny
+	}
+	return 0
+}
+
 type CommunityPermissions struct {
 	EnsOnly bool `protobuf:"varint,1,opt,name=ens_only,json=ensOnly,proto3" json:"ens_only,omitempty"`
 	// https://gitlab.matrix.org/matrix-org/olm/blob/master/docs/megolm.md is a candidate for the algorithm to be used in case we want to have private communityal chats, lighter than pairwise encryption using the DR, less secure, but more efficient for large number of participants
-	Private              bool                        `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`
-	Access               CommunityPermissions_Access `protobuf:"varint,3,opt,name=access,proto3,enum=protobuf.CommunityPermissions_Access" json:"access,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	Private bool                        `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`
+	Access  CommunityPermissions_Access `protobuf:"varint,3,opt,name=access,proto3,enum=protobuf.CommunityPermissions_Access" json:"access,omitempty"`
+	// All the requirements must be met by the addresses of the user requesting to join
+	TokenRequirements    []*TokenRequirement `protobuf:"bytes,4,rep,name=token_requirements,json=tokenRequirements,proto3" json:"token_requirements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *CommunityPermissions) Reset()         { *m = CommunityPermissions{} }
 func (m *CommunityPermissions) String() string { return proto.CompactTextString(m) }
 func (*CommunityPermissions) ProtoMessage()    {}
 func (*CommunityPermissions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{2}
+	return fileDescriptor_f937943d74c1cd8b, []int{4}
 }
 
 func (m *CommunityPermissions) XXX_Unmarshal(b []byte) error {
@@ -237,6 +442,77 @@ func (m *CommunityPermissions) GetAccess() CommunityPermissions_Access {
 	return CommunityPermissions_UNKNOWN_ACCESS
 }
 
+func (m *CommunityPermissions) GetTokenRequirements() []*TokenRequirement {
+	if m != nil {
+		return m.TokenRequirements
+	}
+	return nil
+}
+
+type TokenRequirement struct {
+	ChainId         uint64                     `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ContractAddress string                     `protobuf:"bytes,2,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Type            TokenRequirement_TokenType `protobuf:"varint,3,opt,name=type,proto3,enum=protobuf.TokenRequirement_TokenType" json:"type,omitempty"`
+	// Minimum balance in the smallest unit of the token, as a base 10 string
+	Amount               string   `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenRequirement) Reset()         { *m = TokenRequirement{} }
+func (m *TokenRequirement) String() string { return proto.CompactTextString(m) }
+func (*TokenRequirement) ProtoMessage()    {}
+func (*TokenRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{5}
+}
+
+func (m *TokenRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenRequirement.Unmarshal(m, b)
+}
+func (m *TokenRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenRequirement.Marshal(b, m, deterministic)
+}
+func (m *TokenRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenRequirement.Merge(m, src)
+}
+func (m *TokenRequirement) XXX_Size() int {
+	return xxx_messageInfo_TokenRequirement.Size(m)
+}
+func (m *TokenRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenRequirement proto.InternalMessageInfo
+
+func (m *TokenRequirement) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *TokenRequirement) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *TokenRequirement) GetType() TokenRequirement_TokenType {
+	if m != nil {
+		return m.Type
+	}
+	return TokenRequirement_UNKNOWN_TOKEN_TYPE
+}
+
+func (m *TokenRequirement) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
 type CommunityDescription struct {
 	Clock                uint64                        `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
 	Members              map[string]*CommunityMember   `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -245,6 +521,7 @@ type CommunityDescription struct {
 	Chats                map[string]*CommunityChat     `protobuf:"bytes,6,rep,name=chats,proto3" json:"chats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	BanList              []string                      `protobuf:"bytes,7,rep,name=ban_list,json=banList,proto3" json:"ban_list,omitempty"`
 	Categories           map[string]*CommunityCategory `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Roles                map[string]*CommunityRole     `protobuf:"bytes,9,rep,name=roles,proto3" json:"roles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
 	XXX_unrecognized     []byte                        `json:"-"`
 	XXX_sizecache        int32                         `json:"-"`
@@ -254,7 +531,7 @@ func (m *CommunityDescription) Reset()         { *m = CommunityDescription{} }
 func (m *CommunityDescription) String() string { return proto.CompactTextString(m) }
 func (*CommunityDescription) ProtoMessage()    {}
 func (*CommunityDescription) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{3}
+	return fileDescriptor_f937943d74c1cd8b, []int{6}
 }
 
 func (m *CommunityDescription) XXX_Unmarshal(b []byte) error {
@@ -324,22 +601,30 @@ func (m *CommunityDescription) GetCategories() map[string]*CommunityCategory {
 	return nil
 }
 
+func (m *CommunityDescription) GetRoles() map[string]*CommunityRole {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
 type CommunityChat struct {
-	Members              map[string]*CommunityMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Permissions          *CommunityPermissions       `protobuf:"bytes,2,opt,name=permissions,proto3" json:"permissions,omitempty"`
-	Identity             *ChatIdentity               `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
-	CategoryId           string                      `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Position             int32                       `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	Members              map[string]*CommunityMember             `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Permissions          *CommunityPermissions                   `protobuf:"bytes,2,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Identity             *ChatIdentity                           `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	CategoryId           string                                  `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Position             int32                                   `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	RoleOverrides        map[string]*CommunityPermissionOverride `protobuf:"bytes,6,rep,name=role_overrides,json=roleOverrides,proto3" json:"role_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
+	XXX_unrecognized     []byte                                  `json:"-"`
+	XXX_sizecache        int32                                   `json:"-"`
 }
 
 func (m *CommunityChat) Reset()         { *m = CommunityChat{} }
 func (m *CommunityChat) String() string { return proto.CompactTextString(m) }
 func (*CommunityChat) ProtoMessage()    {}
 func (*CommunityChat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{4}
+	return fileDescriptor_f937943d74c1cd8b, []int{7}
 }
 
 func (m *CommunityChat) XXX_Unmarshal(b []byte) error {
@@ -395,6 +680,13 @@ func (m *CommunityChat) GetPosition() int32 {
 	return 0
 }
 
+func (m *CommunityChat) GetRoleOverrides() map[string]*CommunityPermissionOverride {
+	if m != nil {
+		return m.RoleOverrides
+	}
+	return nil
+}
+
 type CommunityCategory struct {
 	CategoryId           string   `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -408,7 +700,7 @@ func (m *CommunityCategory) Reset()         { *m = CommunityCategory{} }
 func (m *CommunityCategory) String() string { return proto.CompactTextString(m) }
 func (*CommunityCategory) ProtoMessage()    {}
 func (*CommunityCategory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{5}
+	return fileDescriptor_f937943d74c1cd8b, []int{8}
 }
 
 func (m *CommunityCategory) XXX_Unmarshal(b []byte) error {
@@ -464,7 +756,7 @@ func (m *CommunityInvitation) Reset()         { *m = CommunityInvitation{} }
 func (m *CommunityInvitation) String() string { return proto.CompactTextString(m) }
 func (*CommunityInvitation) ProtoMessage()    {}
 func (*CommunityInvitation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{6}
+	return fileDescriptor_f937943d74c1cd8b, []int{9}
 }
 
 func (m *CommunityInvitation) XXX_Unmarshal(b []byte) error {
@@ -514,20 +806,21 @@ func (m *CommunityInvitation) GetPublicKey() []byte {
 }
 
 type CommunityRequestToJoin struct {
-	Clock                uint64   `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
-	EnsName              string   `protobuf:"bytes,2,opt,name=ens_name,json=ensName,proto3" json:"ens_name,omitempty"`
-	ChatId               string   `protobuf:"bytes,3,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	CommunityId          []byte   `protobuf:"bytes,4,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Clock                uint64                   `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	EnsName              string                   `protobuf:"bytes,2,opt,name=ens_name,json=ensName,proto3" json:"ens_name,omitempty"`
+	ChatId               string                   `protobuf:"bytes,3,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	CommunityId          []byte                   `protobuf:"bytes,4,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	AddressProofs        []*AddressOwnershipProof `protobuf:"bytes,5,rep,name=address_proofs,json=addressProofs,proto3" json:"address_proofs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *CommunityRequestToJoin) Reset()         { *m = CommunityRequestToJoin{} }
 func (m *CommunityRequestToJoin) String() string { return proto.CompactTextString(m) }
 func (*CommunityRequestToJoin) ProtoMessage()    {}
 func (*CommunityRequestToJoin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{7}
+	return fileDescriptor_f937943d74c1cd8b, []int{10}
 }
 
 func (m *CommunityRequestToJoin) XXX_Unmarshal(b []byte) error {
@@ -576,6 +869,62 @@ func (m *CommunityRequestToJoin) GetCommunityId() []byte {
 	return nil
 }
 
+func (m *CommunityRequestToJoin) GetAddressProofs() []*AddressOwnershipProof {
+	if m != nil {
+		return m.AddressProofs
+	}
+	return nil
+}
+
+// AddressOwnershipProof proves that the user requesting to join owns the address,
+// the signature is a personal_sign signature of the message built by the community admin
+type AddressOwnershipProof struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressOwnershipProof) Reset()         { *m = AddressOwnershipProof{} }
+func (m *AddressOwnershipProof) String() string { return proto.CompactTextString(m) }
+func (*AddressOwnershipProof) ProtoMessage()    {}
+func (*AddressOwnershipProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{11}
+}
+
+func (m *AddressOwnershipProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressOwnershipProof.Unmarshal(m, b)
+}
+func (m *AddressOwnershipProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressOwnershipProof.Marshal(b, m, deterministic)
+}
+func (m *AddressOwnershipProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressOwnershipProof.Merge(m, src)
+}
+func (m *AddressOwnershipProof) XXX_Size() int {
+	return xxx_messageInfo_AddressOwnershipProof.Size(m)
+}
+func (m *AddressOwnershipProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressOwnershipProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressOwnershipProof proto.InternalMessageInfo
+
+func (m *AddressOwnershipProof) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddressOwnershipProof) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type CommunityRequestToJoinResponse struct {
 	Clock                uint64                `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
 	Community            *CommunityDescription `protobuf:"bytes,2,opt,name=community,proto3" json:"community,omitempty"`
@@ -590,7 +939,7 @@ func (m *CommunityRequestToJoinResponse) Reset()         { *m = CommunityRequest
 func (m *CommunityRequestToJoinResponse) String() string { return proto.CompactTextString(m) }
 func (*CommunityRequestToJoinResponse) ProtoMessage()    {}
 func (*CommunityRequestToJoinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f937943d74c1cd8b, []int{8}
+	return fileDescriptor_f937943d74c1cd8b, []int{12}
 }
 
 func (m *CommunityRequestToJoinResponse) XXX_Unmarshal(b []byte) error {
@@ -639,22 +988,95 @@ func (m *CommunityRequestToJoinResponse) GetGrant() []byte {
 	return nil
 }
 
+type CommunityMemberAction struct {
+	Clock                uint64                           `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	CommunityId          []byte                           `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	MemberId             string                           `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Type                 CommunityMemberAction_ActionType `protobuf:"varint,4,opt,name=type,proto3,enum=protobuf.CommunityMemberAction_ActionType" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CommunityMemberAction) Reset()         { *m = CommunityMemberAction{} }
+func (m *CommunityMemberAction) String() string { return proto.CompactTextString(m) }
+func (*CommunityMemberAction) ProtoMessage()    {}
+func (*CommunityMemberAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f937943d74c1cd8b, []int{13}
+}
+
+func (m *CommunityMemberAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommunityMemberAction.Unmarshal(m, b)
+}
+func (m *CommunityMemberAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommunityMemberAction.Marshal(b, m, deterministic)
+}
+func (m *CommunityMemberAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommunityMemberAction.Merge(m, src)
+}
+func (m *CommunityMemberAction) XXX_Size() int {
+	return xxx_messageInfo_CommunityMemberAction.Size(m)
+}
+func (m *CommunityMemberAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommunityMemberAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommunityMemberAction proto.InternalMessageInfo
+
+func (m *CommunityMemberAction) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *CommunityMemberAction) GetCommunityId() []byte {
+	if m != nil {
+		return m.CommunityId
+	}
+	return nil
+}
+
+func (m *CommunityMemberAction) GetMemberId() string {
+	if m != nil {
+		return m.MemberId
+	}
+	return ""
+}
+
+func (m *CommunityMemberAction) GetType() CommunityMemberAction_ActionType {
+	if m != nil {
+		return m.Type
+	}
+	return CommunityMemberAction_UNKNOWN_ACTION_TYPE
+}
+
 func init() {
 	proto.RegisterEnum("protobuf.CommunityMember_Roles", CommunityMember_Roles_name, CommunityMember_Roles_value)
+	proto.RegisterEnum("protobuf.CommunityRole_Permission", CommunityRole_Permission_name, CommunityRole_Permission_value)
 	proto.RegisterEnum("protobuf.CommunityPermissions_Access", CommunityPermissions_Access_name, CommunityPermissions_Access_value)
+	proto.RegisterEnum("protobuf.TokenRequirement_TokenType", TokenRequirement_TokenType_name, TokenRequirement_TokenType_value)
+	proto.RegisterEnum("protobuf.CommunityMemberAction_ActionType", CommunityMemberAction_ActionType_name, CommunityMemberAction_ActionType_value)
 	proto.RegisterType((*Grant)(nil), "protobuf.Grant")
 	proto.RegisterType((*CommunityMember)(nil), "protobuf.CommunityMember")
+	proto.RegisterType((*CommunityRole)(nil), "protobuf.CommunityRole")
+	proto.RegisterType((*CommunityPermissionOverride)(nil), "protobuf.CommunityPermissionOverride")
 	proto.RegisterType((*CommunityPermissions)(nil), "protobuf.CommunityPermissions")
+	proto.RegisterType((*TokenRequirement)(nil), "protobuf.TokenRequirement")
 	proto.RegisterType((*CommunityDescription)(nil), "protobuf.CommunityDescription")
 	proto.RegisterMapType((map[string]*CommunityCategory)(nil), "protobuf.CommunityDescription.CategoriesEntry")
 	proto.RegisterMapType((map[string]*CommunityChat)(nil), "protobuf.CommunityDescription.ChatsEntry")
 	proto.RegisterMapType((map[string]*CommunityMember)(nil), "protobuf.CommunityDescription.MembersEntry")
+	proto.RegisterMapType((map[string]*CommunityRole)(nil), "protobuf.CommunityDescription.RolesEntry")
 	proto.RegisterType((*CommunityChat)(nil), "protobuf.CommunityChat")
 	proto.RegisterMapType((map[string]*CommunityMember)(nil), "protobuf.CommunityChat.MembersEntry")
+	proto.RegisterMapType((map[string]*CommunityPermissionOverride)(nil), "protobuf.CommunityChat.RoleOverridesEntry")
 	proto.RegisterType((*CommunityCategory)(nil), "protobuf.CommunityCategory")
 	proto.RegisterType((*CommunityInvitation)(nil), "protobuf.CommunityInvitation")
 	proto.RegisterType((*CommunityRequestToJoin)(nil), "protobuf.CommunityRequestToJoin")
+	proto.RegisterType((*AddressOwnershipProof)(nil), "protobuf.AddressOwnershipProof")
 	proto.RegisterType((*CommunityRequestToJoinResponse)(nil), "protobuf.CommunityRequestToJoinResponse")
+	proto.RegisterType((*CommunityMemberAction)(nil), "protobuf.CommunityMemberAction")
 }
 
 func init() {
@@ -662,59 +1084,89 @@ func init() {
 }
 
 var fileDescriptor_f937943d74c1cd8b = []byte{
-	// 859 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x8f, 0xdb, 0x44,
-	0x10, 0xee, 0x26, 0x71, 0xe2, 0x4c, 0x72, 0x77, 0xbe, 0xbd, 0x6b, 0xeb, 0x5e, 0x45, 0x1b, 0x2c,
-	0x90, 0x82, 0x10, 0xa9, 0x48, 0x85, 0x84, 0x78, 0x29, 0xa4, 0x87, 0x55, 0x4c, 0x73, 0x4e, 0xbb,
-	0xc9, 0x81, 0xe8, 0x17, 0xcb, 0x71, 0x96, 0xb2, 0x6a, 0x62, 0x1b, 0xaf, 0x73, 0x52, 0x7e, 0x00,
-	0x12, 0x3f, 0x01, 0x89, 0xef, 0xfc, 0x27, 0xbe, 0xf1, 0x53, 0xd0, 0xee, 0xc6, 0x2f, 0xc9, 0x25,
-	0x6d, 0x25, 0xd4, 0x4f, 0xf1, 0xec, 0xee, 0x3c, 0xf3, 0xcc, 0x33, 0x93, 0x19, 0x38, 0x0e, 0xa2,
-	0xc5, 0x62, 0x19, 0xb2, 0x94, 0x51, 0xde, 0x8b, 0x93, 0x28, 0x8d, 0xb0, 0x2e, 0x7f, 0xa6, 0xcb,
-	0x5f, 0xce, 0x4e, 0x82, 0x5f, 0xfd, 0xd4, 0x63, 0x33, 0x1a, 0xa6, 0x2c, 0x5d, 0xa9, 0x6b, 0xeb,
-	0x0a, 0xb4, 0x27, 0x89, 0x1f, 0xa6, 0xf8, 0x7d, 0x68, 0x67, 0xce, 0x2b, 0x8f, 0xcd, 0x4c, 0xd4,
-	0x41, 0xdd, 0x36, 0x69, 0xe5, 0x67, 0xce, 0x0c, 0xdf, 0x85, 0xe6, 0x82, 0x2e, 0xa6, 0x34, 0x11,
-	0xf7, 0x15, 0x79, 0xaf, 0xab, 0x03, 0x67, 0x86, 0x6f, 0x43, 0x63, 0x8d, 0x6f, 0x56, 0x3b, 0xa8,
-	0xdb, 0x24, 0x75, 0x61, 0x3a, 0x33, 0x7c, 0x0a, 0x5a, 0x30, 0x8f, 0x82, 0x57, 0x66, 0xad, 0x83,
-	0xba, 0x35, 0xa2, 0x0c, 0xeb, 0x0f, 0x04, 0x47, 0xe7, 0x19, 0xf6, 0x85, 0x04, 0xc1, 0x9f, 0x81,
-	0x96, 0x44, 0x73, 0xca, 0x4d, 0xd4, 0xa9, 0x76, 0x0f, 0xfb, 0xf7, 0x7b, 0x19, 0xf5, 0xde, 0xd6,
-	0xcb, 0x1e, 0x11, 0xcf, 0x88, 0x7a, 0x6d, 0x3d, 0x02, 0x4d, 0xda, 0xd8, 0x80, 0xf6, 0xa5, 0xfb,
-	0xd4, 0x1d, 0xfd, 0xe4, 0x7a, 0x64, 0x34, 0xb4, 0x8d, 0x1b, 0xb8, 0x0d, 0xba, 0xf8, 0xf2, 0x06,
-	0xc3, 0xa1, 0x81, 0xf0, 0x4d, 0x38, 0x96, 0xd6, 0xc5, 0xc0, 0x1d, 0x3c, 0xb1, 0xbd, 0xcb, 0xb1,
-	0x4d, 0xc6, 0x46, 0xc5, 0xfa, 0x17, 0xc1, 0x69, 0x1e, 0xe0, 0x19, 0x4d, 0x16, 0x8c, 0x73, 0x16,
-	0x85, 0x1c, 0xdf, 0x01, 0x9d, 0x86, 0xdc, 0x8b, 0xc2, 0xf9, 0x4a, 0xca, 0xa1, 0x93, 0x06, 0x0d,
-	0xf9, 0x28, 0x9c, 0xaf, 0xb0, 0x09, 0x8d, 0x38, 0x61, 0x57, 0x7e, 0x4a, 0xa5, 0x10, 0x3a, 0xc9,
-	0x4c, 0xfc, 0x35, 0xd4, 0xfd, 0x20, 0xa0, 0x9c, 0x4b, 0x19, 0x0e, 0xfb, 0x1f, 0xee, 0xc8, 0xa2,
-	0x14, 0xa4, 0x37, 0x90, 0x8f, 0xc9, 0xda, 0xc9, 0x9a, 0x40, 0x5d, 0x9d, 0x60, 0x0c, 0x87, 0x59,
-	0x36, 0x83, 0xf3, 0x73, 0x7b, 0x3c, 0x36, 0x6e, 0xe0, 0x63, 0x38, 0x70, 0x47, 0xde, 0x85, 0x7d,
-	0xf1, 0xd8, 0x26, 0xe3, 0xef, 0x9d, 0x67, 0x06, 0xc2, 0x27, 0x70, 0xe4, 0xb8, 0x3f, 0x3a, 0x93,
-	0xc1, 0xc4, 0x19, 0xb9, 0xde, 0xc8, 0x1d, 0xfe, 0x6c, 0x54, 0xf0, 0x21, 0xc0, 0xc8, 0xf5, 0x88,
-	0xfd, 0xfc, 0xd2, 0x1e, 0x4f, 0x8c, 0xaa, 0xf5, 0x97, 0x56, 0x4a, 0xf1, 0x3b, 0xca, 0x83, 0x84,
-	0xc5, 0x29, 0x8b, 0xc2, 0xa2, 0x38, 0xa8, 0x54, 0x1c, 0x6c, 0x43, 0x43, 0xd5, 0x95, 0x9b, 0x95,
-	0x4e, 0xb5, 0xdb, 0xea, 0x7f, 0xbc, 0x23, 0x89, 0x12, 0x4c, 0x4f, 0x95, 0x85, 0xdb, 0x61, 0x9a,
-	0xac, 0x48, 0xe6, 0x8b, 0xbf, 0x85, 0x56, 0x5c, 0x64, 0x2a, 0xf5, 0x68, 0xf5, 0xef, 0xbd, 0x5e,
-	0x0f, 0x52, 0x76, 0xc1, 0x7d, 0xd0, 0xb3, 0x7e, 0x35, 0x35, 0xe9, 0x7e, 0xab, 0xe4, 0x2e, 0xfb,
-	0x4b, 0xdd, 0x92, 0xfc, 0x1d, 0xfe, 0x06, 0x34, 0xd1, 0x79, 0xdc, 0xac, 0x4b, 0xea, 0x1f, 0xbd,
-	0x81, 0xba, 0x40, 0x59, 0x13, 0x57, 0x7e, 0xa2, 0xec, 0x53, 0x3f, 0xf4, 0xe6, 0x8c, 0xa7, 0x66,
-	0xa3, 0x53, 0xed, 0x36, 0x49, 0x63, 0xea, 0x87, 0x43, 0xc6, 0x53, 0xec, 0x02, 0x04, 0x7e, 0x4a,
-	0x5f, 0x46, 0x09, 0xa3, 0xdc, 0xd4, 0x65, 0x80, 0xde, 0x9b, 0x02, 0xe4, 0x0e, 0x2a, 0x4a, 0x09,
-	0xe1, 0xec, 0x12, 0xda, 0x65, 0xe9, 0xb0, 0x01, 0xd5, 0x57, 0x54, 0x35, 0x5b, 0x93, 0x88, 0x4f,
-	0xfc, 0x00, 0xb4, 0x2b, 0x7f, 0xbe, 0x54, 0x6d, 0xd6, 0xea, 0xdf, 0xd9, 0xfb, 0x9f, 0x20, 0xea,
-	0xdd, 0x17, 0x95, 0xcf, 0xd1, 0xd9, 0x73, 0x80, 0x22, 0xad, 0x1d, 0xa0, 0x9f, 0x6c, 0x82, 0xde,
-	0xde, 0x01, 0x2a, 0xfc, 0xcb, 0x90, 0x2f, 0xe0, 0x68, 0x2b, 0x91, 0x1d, 0xb8, 0x9f, 0x6e, 0xe2,
-	0xde, 0xdd, 0x85, 0xab, 0x40, 0x56, 0x25, 0x6c, 0xeb, 0x9f, 0x0a, 0x1c, 0x6c, 0x04, 0xc6, 0x8f,
-	0x8a, 0x06, 0x44, 0x52, 0xe4, 0x0f, 0xf6, 0x50, 0x7c, 0xbb, 0xce, 0xab, 0xfc, 0xbf, 0xce, 0xab,
-	0xbe, 0x65, 0xe7, 0xdd, 0x87, 0xd6, 0xba, 0xb6, 0x72, 0x82, 0xd6, 0xa4, 0x30, 0x59, 0xb9, 0xc5,
-	0x00, 0x3d, 0x03, 0x3d, 0x8e, 0x38, 0x13, 0x6d, 0x21, 0xdb, 0x59, 0x23, 0xb9, 0xfd, 0x8e, 0x5a,
-	0xc1, 0x9a, 0xc1, 0xf1, 0x35, 0xed, 0xb7, 0x89, 0xa2, 0x6b, 0x44, 0x31, 0xd4, 0x42, 0x7f, 0xa1,
-	0x22, 0x35, 0x89, 0xfc, 0xde, 0x20, 0x5f, 0xdd, 0x24, 0x6f, 0xfd, 0x89, 0xe0, 0x24, 0x0f, 0xe3,
-	0x84, 0x57, 0x2c, 0xf5, 0xe5, 0x78, 0x79, 0x08, 0x37, 0x8b, 0xa5, 0x32, 0x2b, 0xfe, 0x14, 0xeb,
-	0xed, 0x72, 0x1a, 0xec, 0x99, 0x49, 0x2f, 0xc5, 0x4a, 0x5a, 0xaf, 0x18, 0x65, 0xec, 0xdf, 0x2f,
-	0xef, 0x01, 0xc4, 0xcb, 0xe9, 0x9c, 0x05, 0x9e, 0xd0, 0xab, 0x26, 0x7d, 0x9a, 0xea, 0xe4, 0x29,
-	0x5d, 0x59, 0xbf, 0x23, 0xb8, 0x95, 0x53, 0x23, 0xf4, 0xb7, 0x25, 0xe5, 0xe9, 0x24, 0xfa, 0x21,
-	0x62, 0xfb, 0x86, 0xdf, 0x7a, 0xea, 0x97, 0xf2, 0x17, 0x53, 0xdf, 0x15, 0x12, 0xec, 0xe5, 0xb0,
-	0xbd, 0x3c, 0x6b, 0xd7, 0x96, 0xa7, 0xf5, 0x37, 0x82, 0x7b, 0xbb, 0x79, 0x10, 0xca, 0xe3, 0x28,
-	0xe4, 0x74, 0x0f, 0x9f, 0xaf, 0xa0, 0x99, 0xe3, 0xbc, 0xa6, 0x93, 0x4b, 0x0a, 0x92, 0xc2, 0x41,
-	0x54, 0x4d, 0x6c, 0x96, 0x38, 0xa5, 0x8a, 0xb3, 0x4e, 0x72, 0xbb, 0x10, 0xba, 0x56, 0x12, 0xfa,
-	0xf1, 0xc1, 0x8b, 0x56, 0xef, 0xc1, 0x97, 0x59, 0x80, 0x69, 0x5d, 0x7e, 0x3d, 0xfc, 0x2f, 0x00,
-	0x00, 0xff, 0xff, 0x49, 0xd3, 0x7e, 0x3e, 0x5b, 0x08, 0x00, 0x00,
+	// 1332 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x0e, 0x25, 0xea, 0x87, 0x23, 0xff, 0xd0, 0xeb, 0xd8, 0x51, 0x9c, 0x36, 0x51, 0x89, 0x14,
+	0x70, 0x52, 0x54, 0x69, 0x14, 0x14, 0x0d, 0x92, 0x36, 0xad, 0xec, 0xb0, 0x2e, 0x6b, 0x9b, 0x74,
+	0x56, 0x72, 0x8b, 0xe4, 0x42, 0xd0, 0xe4, 0xc6, 0x21, 0x22, 0x91, 0x0c, 0x97, 0x72, 0xa0, 0x07,
+	0x29, 0xd0, 0x7b, 0x81, 0x5e, 0x7a, 0xe8, 0xbd, 0xef, 0xd0, 0xd7, 0x68, 0x5f, 0xa3, 0xd8, 0x5d,
+	0xfe, 0x59, 0x96, 0x9c, 0xa0, 0x45, 0x4f, 0xda, 0x59, 0xce, 0x7c, 0x33, 0xfb, 0xed, 0xcc, 0xec,
+	0x08, 0xd6, 0xdc, 0x70, 0x3c, 0x9e, 0x04, 0x7e, 0xe2, 0x13, 0xda, 0x8d, 0xe2, 0x30, 0x09, 0x51,
+	0x93, 0xff, 0x9c, 0x4c, 0x5e, 0x6e, 0xad, 0xbb, 0xaf, 0x9c, 0xc4, 0xf6, 0x3d, 0x12, 0x24, 0x7e,
+	0x32, 0x15, 0x9f, 0xb5, 0x33, 0xa8, 0xed, 0xc5, 0x4e, 0x90, 0xa0, 0x8f, 0x60, 0x29, 0x33, 0x9e,
+	0xda, 0xbe, 0xd7, 0x96, 0x3a, 0xd2, 0xf6, 0x12, 0x6e, 0xe5, 0x7b, 0x86, 0x87, 0x6e, 0x80, 0x32,
+	0x26, 0xe3, 0x13, 0x12, 0xb3, 0xef, 0x15, 0xfe, 0xbd, 0x29, 0x36, 0x0c, 0x0f, 0x5d, 0x83, 0x46,
+	0x8a, 0xdf, 0xae, 0x76, 0xa4, 0x6d, 0x05, 0xd7, 0x99, 0x68, 0x78, 0xe8, 0x2a, 0xd4, 0xdc, 0x51,
+	0xe8, 0xbe, 0x6e, 0xcb, 0x1d, 0x69, 0x5b, 0xc6, 0x42, 0xd0, 0x7e, 0x91, 0x60, 0x75, 0x37, 0xc3,
+	0x3e, 0xe4, 0x20, 0xe8, 0x73, 0xa8, 0xc5, 0xe1, 0x88, 0xd0, 0xb6, 0xd4, 0xa9, 0x6e, 0xaf, 0xf4,
+	0x6e, 0x75, 0xb3, 0xd0, 0xbb, 0x33, 0x9a, 0x5d, 0xcc, 0xd4, 0xb0, 0xd0, 0x46, 0xd7, 0xa1, 0xc9,
+	0x16, 0xb6, 0xef, 0xd1, 0x76, 0xa5, 0x53, 0xdd, 0x56, 0x70, 0x83, 0xc9, 0x86, 0x47, 0xb5, 0x27,
+	0x50, 0xe3, 0xaa, 0x48, 0x85, 0xa5, 0x63, 0x73, 0xdf, 0xb4, 0x7e, 0x34, 0x6d, 0x6c, 0x1d, 0xe8,
+	0xea, 0x15, 0xb4, 0x04, 0x4d, 0xb6, 0xb2, 0xfb, 0x07, 0x07, 0xaa, 0x84, 0x36, 0x60, 0x8d, 0x4b,
+	0x87, 0x7d, 0xb3, 0xbf, 0xa7, 0xdb, 0xc7, 0x03, 0x1d, 0x0f, 0xd4, 0x8a, 0xf6, 0x87, 0x04, 0xcb,
+	0xb9, 0x6f, 0x86, 0xc4, 0x8e, 0x99, 0x3a, 0xe3, 0x0c, 0x29, 0xb8, 0x2e, 0x7c, 0x21, 0x04, 0x72,
+	0xe0, 0x8c, 0x09, 0xe7, 0x45, 0xc1, 0x7c, 0x8d, 0x3a, 0xd0, 0x8a, 0x48, 0x3c, 0xf6, 0x29, 0xf5,
+	0xc3, 0x80, 0x72, 0x5e, 0x64, 0x5c, 0xde, 0xd2, 0x5e, 0x00, 0x1c, 0xe5, 0x22, 0xda, 0x04, 0x94,
+	0x45, 0x79, 0xa4, 0xe3, 0x43, 0x63, 0x30, 0x30, 0x2c, 0x53, 0xbd, 0xc2, 0xa2, 0x3f, 0x17, 0x98,
+	0x84, 0x96, 0x41, 0xd9, 0xe9, 0x9b, 0x59, 0x9c, 0x68, 0x1d, 0x56, 0x9f, 0xea, 0x07, 0xfa, 0x50,
+	0xb7, 0x0f, 0xf5, 0xc1, 0xa0, 0xbf, 0xa7, 0x0f, 0x54, 0x59, 0xdb, 0x83, 0x1b, 0x79, 0xec, 0x85,
+	0x13, 0xeb, 0x8c, 0xc4, 0xb1, 0xef, 0x11, 0x76, 0x2f, 0xce, 0x68, 0x14, 0xbe, 0xe5, 0xe7, 0x90,
+	0xb1, 0x10, 0xd8, 0x31, 0x3c, 0x12, 0x4c, 0xf9, 0x31, 0x64, 0xcc, 0xd7, 0xda, 0x6f, 0x15, 0xb8,
+	0x3a, 0x07, 0x89, 0x33, 0x4f, 0x02, 0x6a, 0x87, 0xc1, 0x68, 0xca, 0x51, 0x9a, 0xb8, 0x41, 0x02,
+	0x6a, 0x05, 0xa3, 0x29, 0x6a, 0x43, 0x23, 0x8a, 0xfd, 0x33, 0x27, 0x11, 0x8c, 0x34, 0x71, 0x26,
+	0xa2, 0xaf, 0xa0, 0xee, 0xb8, 0x2e, 0xa1, 0x82, 0x8f, 0x95, 0xde, 0xc7, 0x73, 0xae, 0xb9, 0xe4,
+	0xa4, 0xdb, 0xe7, 0xca, 0x38, 0x35, 0x42, 0x06, 0xa0, 0x24, 0x7c, 0x4d, 0x02, 0x3b, 0x26, 0x6f,
+	0x26, 0x7e, 0x4c, 0xc6, 0x24, 0x48, 0x68, 0x5b, 0xee, 0x54, 0xb7, 0x5b, 0xbd, 0xad, 0x02, 0x6a,
+	0xc8, 0x74, 0x70, 0xa1, 0x82, 0xd7, 0x92, 0x99, 0x1d, 0xaa, 0x0d, 0xa1, 0x2e, 0xc0, 0x11, 0x82,
+	0x95, 0x8c, 0xf8, 0xfe, 0xee, 0xae, 0x3e, 0x18, 0xa8, 0x57, 0xd0, 0x1a, 0x2c, 0x9b, 0x96, 0x7d,
+	0xa8, 0x1f, 0xee, 0xe8, 0x78, 0xf0, 0x9d, 0x71, 0xa4, 0x4a, 0x8c, 0x66, 0xc3, 0xfc, 0xc1, 0x18,
+	0xf6, 0x87, 0x86, 0x65, 0xda, 0x96, 0x79, 0xf0, 0x5c, 0xad, 0xa0, 0x15, 0x00, 0xcb, 0xb4, 0xb1,
+	0xfe, 0xec, 0x58, 0x1f, 0x0c, 0xd5, 0xaa, 0xf6, 0x97, 0x04, 0xea, 0xac, 0x77, 0xc6, 0x94, 0xfb,
+	0xca, 0xf1, 0x83, 0x2c, 0x6f, 0x64, 0xdc, 0xe0, 0xb2, 0xe1, 0xa1, 0x3b, 0xa0, 0xba, 0x61, 0x90,
+	0xc4, 0x8e, 0x9b, 0xd8, 0x8e, 0xe7, 0xc5, 0x8c, 0x19, 0x91, 0x44, 0xab, 0xd9, 0x7e, 0x5f, 0x6c,
+	0xa3, 0x87, 0x20, 0x27, 0xd3, 0x88, 0xa4, 0xc4, 0xdd, 0x5e, 0x7c, 0x5a, 0xb1, 0x31, 0x9c, 0x46,
+	0x04, 0x73, 0x0b, 0xb4, 0x09, 0x75, 0x67, 0x1c, 0x4e, 0x82, 0x84, 0x57, 0xa1, 0x82, 0x53, 0x49,
+	0x7b, 0x04, 0x4a, 0xae, 0x5a, 0x4e, 0xbf, 0xa1, 0xb5, 0xaf, 0x9b, 0xf6, 0xf0, 0xf9, 0x11, 0x2b,
+	0x15, 0x05, 0x6a, 0x3a, 0xde, 0xed, 0x7d, 0xa6, 0x4a, 0x08, 0xa0, 0xae, 0xe3, 0xdd, 0x2f, 0x7a,
+	0xf7, 0xd5, 0x8a, 0xf6, 0x7b, 0xbd, 0x94, 0x16, 0x4f, 0x09, 0x75, 0x63, 0x3f, 0x4a, 0x58, 0x1a,
+	0xe7, 0x15, 0x2f, 0x95, 0x2a, 0x1e, 0xe9, 0xd0, 0x10, 0xcd, 0x42, 0x54, 0x69, 0xab, 0xf7, 0xc9,
+	0x9c, 0x8b, 0x2f, 0xc1, 0x74, 0x45, 0xad, 0x53, 0x3d, 0x48, 0xe2, 0x29, 0xce, 0x6c, 0xd1, 0x37,
+	0x17, 0x6b, 0xaa, 0xd5, 0xbb, 0x79, 0x79, 0x0e, 0x9d, 0xab, 0x39, 0xd4, 0x83, 0x66, 0xd6, 0x04,
+	0xdb, 0x35, 0x6e, 0xbe, 0x59, 0x32, 0xe7, 0x4d, 0x4b, 0x7c, 0xc5, 0xb9, 0x1e, 0xfa, 0x1a, 0x6a,
+	0xac, 0x9d, 0xd1, 0x76, 0x9d, 0x87, 0x7e, 0xe7, 0x1d, 0xa1, 0x33, 0x94, 0x34, 0x70, 0x61, 0xc7,
+	0x12, 0xe0, 0xc4, 0x09, 0xec, 0x91, 0x4f, 0x93, 0x76, 0x43, 0x34, 0xa9, 0x13, 0x27, 0x38, 0xf0,
+	0x69, 0x82, 0x4c, 0x00, 0xd7, 0x49, 0xc8, 0x69, 0x18, 0xfb, 0x84, 0xb6, 0x9b, 0xdc, 0x41, 0xf7,
+	0x5d, 0x0e, 0x72, 0x03, 0xe1, 0xa5, 0x84, 0xc0, 0x62, 0x15, 0x6d, 0x54, 0x79, 0xaf, 0x58, 0x79,
+	0x83, 0x4c, 0x63, 0xe5, 0x76, 0x5b, 0xc7, 0xb0, 0x54, 0xe6, 0x1e, 0xa9, 0x50, 0x7d, 0x4d, 0xa6,
+	0x69, 0xbf, 0x63, 0x4b, 0x74, 0x0f, 0x6a, 0x67, 0xce, 0x68, 0x22, 0x6a, 0xbb, 0xd5, 0xbb, 0xbe,
+	0xb0, 0x53, 0x63, 0xa1, 0xf7, 0xa8, 0xf2, 0x50, 0xda, 0x7a, 0x06, 0x50, 0xf0, 0x32, 0x07, 0xf4,
+	0xd3, 0xf3, 0xa0, 0xd7, 0xe6, 0x80, 0x32, 0xfb, 0x32, 0xe4, 0x0b, 0x58, 0x9d, 0x61, 0x62, 0x0e,
+	0xee, 0xfd, 0xf3, 0xb8, 0x37, 0xe6, 0xe1, 0x0a, 0x90, 0xe9, 0x4c, 0xb8, 0x05, 0x35, 0xff, 0x2e,
+	0x5c, 0x66, 0x5f, 0x82, 0xd4, 0x7e, 0x92, 0x4b, 0xcf, 0x09, 0x3b, 0x0b, 0x7a, 0x52, 0x14, 0x85,
+	0xc4, 0x6f, 0xeb, 0xf6, 0x82, 0x53, 0xbf, 0x5f, 0x35, 0x54, 0xfe, 0x5b, 0x35, 0x54, 0xdf, 0xb3,
+	0x1a, 0x6e, 0x41, 0x2b, 0xcd, 0x37, 0x3e, 0x2a, 0x88, 0x96, 0x92, 0xa5, 0x20, 0x9b, 0x14, 0xb6,
+	0xa0, 0x19, 0x85, 0xd4, 0x67, 0xf9, 0xc5, 0x4b, 0xac, 0x86, 0x73, 0x19, 0x3d, 0x83, 0x15, 0xfe,
+	0x82, 0x86, 0xe9, 0x43, 0x94, 0xd5, 0xd4, 0xdd, 0x45, 0x27, 0x67, 0x2c, 0x66, 0xaf, 0x56, 0x7a,
+	0xfe, 0xe5, 0xb8, 0xbc, 0xf7, 0x7f, 0x25, 0xec, 0x29, 0xa0, 0x8b, 0xbe, 0xe7, 0x80, 0x3f, 0x3e,
+	0x0f, 0x7e, 0xf9, 0x83, 0x96, 0xa1, 0x95, 0xf3, 0xc2, 0x83, 0xb5, 0x0b, 0xa9, 0x38, 0x4b, 0xb2,
+	0x74, 0x81, 0xe4, 0x79, 0x13, 0x47, 0x99, 0xf8, 0xea, 0x79, 0xe2, 0xb5, 0x9f, 0x25, 0x58, 0xcf,
+	0xdd, 0x18, 0xc1, 0x99, 0x9f, 0x38, 0xfc, 0x42, 0x1e, 0xc0, 0x46, 0x31, 0xf9, 0x79, 0x45, 0x67,
+	0x48, 0x47, 0xc0, 0xab, 0xee, 0x82, 0x1e, 0x7f, 0xca, 0xe6, 0xc6, 0x74, 0x0e, 0x14, 0xc2, 0xe2,
+	0x21, 0xf0, 0x43, 0x80, 0x68, 0x72, 0x32, 0xf2, 0x5d, 0x9b, 0x71, 0x27, 0x73, 0x1b, 0x45, 0xec,
+	0xec, 0x93, 0xa9, 0xf6, 0xa7, 0x04, 0x9b, 0x45, 0xd5, 0x90, 0x37, 0x13, 0x42, 0x93, 0x61, 0xf8,
+	0x7d, 0xe8, 0x2f, 0x7a, 0x4c, 0xd2, 0xc9, 0xa3, 0x74, 0x7e, 0x36, 0x79, 0x98, 0x8c, 0x82, 0x85,
+	0x31, 0xcc, 0x4e, 0xb8, 0xf2, 0xc5, 0x09, 0xf7, 0x5b, 0x58, 0x49, 0x9f, 0x60, 0x3b, 0x8a, 0xc3,
+	0xf0, 0x25, 0x6d, 0xd7, 0x78, 0x6e, 0x96, 0x46, 0xd1, 0xf4, 0x2d, 0xb6, 0xde, 0x06, 0x24, 0xa6,
+	0xaf, 0xfc, 0xe8, 0x88, 0xe9, 0xe1, 0xe5, 0xd4, 0x8c, 0x4b, 0x54, 0xb3, 0x60, 0x63, 0xae, 0x1e,
+	0x1b, 0x8b, 0xb2, 0x37, 0x5e, 0x5c, 0x68, 0x26, 0xa2, 0x0f, 0x40, 0xa1, 0xfe, 0x69, 0xe0, 0x24,
+	0x93, 0x98, 0xa4, 0xa4, 0x16, 0x1b, 0xda, 0xaf, 0x12, 0xdc, 0x9c, 0x4f, 0x10, 0x26, 0x34, 0x0a,
+	0x03, 0x4a, 0x16, 0x10, 0xf5, 0x25, 0x28, 0xf9, 0x01, 0x2f, 0x69, 0x0f, 0xa5, 0xab, 0xc5, 0x85,
+	0x01, 0x4b, 0x27, 0x36, 0x76, 0x45, 0x09, 0x11, 0x64, 0x36, 0x71, 0x2e, 0x17, 0x19, 0x20, 0x97,
+	0x32, 0x40, 0xfb, 0x5b, 0x82, 0x8d, 0x99, 0x92, 0xea, 0xbb, 0x97, 0x4c, 0x05, 0xb3, 0x97, 0x52,
+	0x79, 0xc7, 0xdf, 0x0e, 0x71, 0xa5, 0xc5, 0xdf, 0x8e, 0x27, 0xe9, 0x48, 0x24, 0xf3, 0x91, 0xe8,
+	0xee, 0xc2, 0xba, 0x16, 0x41, 0x74, 0xc5, 0x4f, 0x31, 0x18, 0x69, 0x0f, 0x01, 0x8a, 0x3d, 0x74,
+	0x0d, 0xd6, 0x8b, 0x39, 0x90, 0x0f, 0x79, 0xe9, 0x08, 0xd4, 0x04, 0x79, 0xdf, 0xd8, 0xdd, 0x57,
+	0x25, 0xd4, 0x80, 0xea, 0x4e, 0xdf, 0x54, 0x2b, 0x3b, 0xcb, 0x2f, 0x5a, 0xdd, 0x7b, 0x8f, 0x33,
+	0x7f, 0x27, 0x75, 0xbe, 0x7a, 0xf0, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd7, 0x22, 0x01, 0xec,
+	0x83, 0x0d, 0x00, 0x00,
 }
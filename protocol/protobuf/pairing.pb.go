@@ -21,12 +21,25 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Backup struct {
-	Clock                uint64                       `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
-	Id                   string                       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Contacts             []*SyncInstallationContactV2 `protobuf:"bytes,3,rep,name=contacts,proto3" json:"contacts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	Clock    uint64                       `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	Id       string                       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Contacts []*SyncInstallationContactV2 `protobuf:"bytes,3,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	Drafts   []*SyncChatDraft             `protobuf:"bytes,4,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	// Version of the backup format used by the sender, sections added in later
+	// versions are unknown fields to older clients and are ignored
+	Version     uint32                        `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	PublicChats []*SyncInstallationPublicChat `protobuf:"bytes,6,rep,name=public_chats,json=publicChats,proto3" json:"public_chats,omitempty"`
+	// Communities include the private keys of the communities we control
+	Communities             []*SyncCommunity             `protobuf:"bytes,7,rep,name=communities,proto3" json:"communities,omitempty"`
+	Bookmarks               []*SyncBookmark              `protobuf:"bytes,8,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"`
+	Settings                []*BackupSetting             `protobuf:"bytes,9,rep,name=settings,proto3" json:"settings,omitempty"`
+	MutedChats              []string                     `protobuf:"bytes,10,rep,name=muted_chats,json=mutedChats,proto3" json:"muted_chats,omitempty"`
+	ActivityCenterRead      *SyncActivityCenterRead      `protobuf:"bytes,11,opt,name=activity_center_read,json=activityCenterRead,proto3" json:"activity_center_read,omitempty"`
+	ActivityCenterAccepted  *SyncActivityCenterAccepted  `protobuf:"bytes,12,opt,name=activity_center_accepted,json=activityCenterAccepted,proto3" json:"activity_center_accepted,omitempty"`
+	ActivityCenterDismissed *SyncActivityCenterDismissed `protobuf:"bytes,13,opt,name=activity_center_dismissed,json=activityCenterDismissed,proto3" json:"activity_center_dismissed,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                     `json:"-"`
+	XXX_unrecognized        []byte                       `json:"-"`
+	XXX_sizecache           int32                        `json:"-"`
 }
 
 func (m *Backup) Reset()         { *m = Backup{} }
@@ -75,6 +88,124 @@ func (m *Backup) GetContacts() []*SyncInstallationContactV2 {
 	return nil
 }
 
+func (m *Backup) GetDrafts() []*SyncChatDraft {
+	if m != nil {
+		return m.Drafts
+	}
+	return nil
+}
+
+func (m *Backup) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Backup) GetPublicChats() []*SyncInstallationPublicChat {
+	if m != nil {
+		return m.PublicChats
+	}
+	return nil
+}
+
+func (m *Backup) GetCommunities() []*SyncCommunity {
+	if m != nil {
+		return m.Communities
+	}
+	return nil
+}
+
+func (m *Backup) GetBookmarks() []*SyncBookmark {
+	if m != nil {
+		return m.Bookmarks
+	}
+	return nil
+}
+
+func (m *Backup) GetSettings() []*BackupSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *Backup) GetMutedChats() []string {
+	if m != nil {
+		return m.MutedChats
+	}
+	return nil
+}
+
+func (m *Backup) GetActivityCenterRead() *SyncActivityCenterRead {
+	if m != nil {
+		return m.ActivityCenterRead
+	}
+	return nil
+}
+
+func (m *Backup) GetActivityCenterAccepted() *SyncActivityCenterAccepted {
+	if m != nil {
+		return m.ActivityCenterAccepted
+	}
+	return nil
+}
+
+func (m *Backup) GetActivityCenterDismissed() *SyncActivityCenterDismissed {
+	if m != nil {
+		return m.ActivityCenterDismissed
+	}
+	return nil
+}
+
+type BackupSetting struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// JSON encoded value of the setting
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupSetting) Reset()         { *m = BackupSetting{} }
+func (m *BackupSetting) String() string { return proto.CompactTextString(m) }
+func (*BackupSetting) ProtoMessage()    {}
+func (*BackupSetting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d61ab7221f0b5518, []int{1}
+}
+
+func (m *BackupSetting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupSetting.Unmarshal(m, b)
+}
+func (m *BackupSetting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupSetting.Marshal(b, m, deterministic)
+}
+func (m *BackupSetting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupSetting.Merge(m, src)
+}
+func (m *BackupSetting) XXX_Size() int {
+	return xxx_messageInfo_BackupSetting.Size(m)
+}
+func (m *BackupSetting) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupSetting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupSetting proto.InternalMessageInfo
+
+func (m *BackupSetting) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BackupSetting) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 type PairInstallation struct {
 	Clock                uint64   `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
 	InstallationId       string   `protobuf:"bytes,2,opt,name=installation_id,json=installationId,proto3" json:"installation_id,omitempty"`
@@ -89,7 +220,7 @@ func (m *PairInstallation) Reset()         { *m = PairInstallation{} }
 func (m *PairInstallation) String() string { return proto.CompactTextString(m) }
 func (*PairInstallation) ProtoMessage()    {}
 func (*PairInstallation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{1}
+	return fileDescriptor_d61ab7221f0b5518, []int{2}
 }
 
 func (m *PairInstallation) XXX_Unmarshal(b []byte) error {
@@ -155,7 +286,7 @@ func (m *SyncInstallationContact) Reset()         { *m = SyncInstallationContact
 func (m *SyncInstallationContact) String() string { return proto.CompactTextString(m) }
 func (*SyncInstallationContact) ProtoMessage()    {}
 func (*SyncInstallationContact) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{2}
+	return fileDescriptor_d61ab7221f0b5518, []int{3}
 }
 
 func (m *SyncInstallationContact) XXX_Unmarshal(b []byte) error {
@@ -247,7 +378,7 @@ func (m *SyncInstallationContactV2) Reset()         { *m = SyncInstallationConta
 func (m *SyncInstallationContactV2) String() string { return proto.CompactTextString(m) }
 func (*SyncInstallationContactV2) ProtoMessage()    {}
 func (*SyncInstallationContactV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{3}
+	return fileDescriptor_d61ab7221f0b5518, []int{4}
 }
 
 func (m *SyncInstallationContactV2) XXX_Unmarshal(b []byte) error {
@@ -365,7 +496,7 @@ func (m *SyncInstallationAccount) Reset()         { *m = SyncInstallationAccount
 func (m *SyncInstallationAccount) String() string { return proto.CompactTextString(m) }
 func (*SyncInstallationAccount) ProtoMessage()    {}
 func (*SyncInstallationAccount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{4}
+	return fileDescriptor_d61ab7221f0b5518, []int{5}
 }
 
 func (m *SyncInstallationAccount) XXX_Unmarshal(b []byte) error {
@@ -419,7 +550,7 @@ func (m *SyncInstallationPublicChat) Reset()         { *m = SyncInstallationPubl
 func (m *SyncInstallationPublicChat) String() string { return proto.CompactTextString(m) }
 func (*SyncInstallationPublicChat) ProtoMessage()    {}
 func (*SyncInstallationPublicChat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{5}
+	return fileDescriptor_d61ab7221f0b5518, []int{6}
 }
 
 func (m *SyncInstallationPublicChat) XXX_Unmarshal(b []byte) error {
@@ -472,7 +603,7 @@ func (m *SyncCommunity) Reset()         { *m = SyncCommunity{} }
 func (m *SyncCommunity) String() string { return proto.CompactTextString(m) }
 func (*SyncCommunity) ProtoMessage()    {}
 func (*SyncCommunity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{6}
+	return fileDescriptor_d61ab7221f0b5518, []int{7}
 }
 
 func (m *SyncCommunity) XXX_Unmarshal(b []byte) error {
@@ -566,7 +697,7 @@ func (m *SyncCommunityRequestsToJoin) Reset()         { *m = SyncCommunityReques
 func (m *SyncCommunityRequestsToJoin) String() string { return proto.CompactTextString(m) }
 func (*SyncCommunityRequestsToJoin) ProtoMessage()    {}
 func (*SyncCommunityRequestsToJoin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{7}
+	return fileDescriptor_d61ab7221f0b5518, []int{8}
 }
 
 func (m *SyncCommunityRequestsToJoin) XXX_Unmarshal(b []byte) error {
@@ -650,7 +781,7 @@ func (m *SyncInstallation) Reset()         { *m = SyncInstallation{} }
 func (m *SyncInstallation) String() string { return proto.CompactTextString(m) }
 func (*SyncInstallation) ProtoMessage()    {}
 func (*SyncInstallation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{8}
+	return fileDescriptor_d61ab7221f0b5518, []int{9}
 }
 
 func (m *SyncInstallation) XXX_Unmarshal(b []byte) error {
@@ -711,7 +842,7 @@ func (m *SyncChatRemoved) Reset()         { *m = SyncChatRemoved{} }
 func (m *SyncChatRemoved) String() string { return proto.CompactTextString(m) }
 func (*SyncChatRemoved) ProtoMessage()    {}
 func (*SyncChatRemoved) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{9}
+	return fileDescriptor_d61ab7221f0b5518, []int{10}
 }
 
 func (m *SyncChatRemoved) XXX_Unmarshal(b []byte) error {
@@ -758,7 +889,7 @@ func (m *SyncChatMessagesRead) Reset()         { *m = SyncChatMessagesRead{} }
 func (m *SyncChatMessagesRead) String() string { return proto.CompactTextString(m) }
 func (*SyncChatMessagesRead) ProtoMessage()    {}
 func (*SyncChatMessagesRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{10}
+	return fileDescriptor_d61ab7221f0b5518, []int{11}
 }
 
 func (m *SyncChatMessagesRead) XXX_Unmarshal(b []byte) error {
@@ -805,7 +936,7 @@ func (m *SyncActivityCenterRead) Reset()         { *m = SyncActivityCenterRead{}
 func (m *SyncActivityCenterRead) String() string { return proto.CompactTextString(m) }
 func (*SyncActivityCenterRead) ProtoMessage()    {}
 func (*SyncActivityCenterRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{11}
+	return fileDescriptor_d61ab7221f0b5518, []int{12}
 }
 
 func (m *SyncActivityCenterRead) XXX_Unmarshal(b []byte) error {
@@ -852,7 +983,7 @@ func (m *SyncActivityCenterAccepted) Reset()         { *m = SyncActivityCenterAc
 func (m *SyncActivityCenterAccepted) String() string { return proto.CompactTextString(m) }
 func (*SyncActivityCenterAccepted) ProtoMessage()    {}
 func (*SyncActivityCenterAccepted) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{12}
+	return fileDescriptor_d61ab7221f0b5518, []int{13}
 }
 
 func (m *SyncActivityCenterAccepted) XXX_Unmarshal(b []byte) error {
@@ -899,7 +1030,7 @@ func (m *SyncActivityCenterDismissed) Reset()         { *m = SyncActivityCenterD
 func (m *SyncActivityCenterDismissed) String() string { return proto.CompactTextString(m) }
 func (*SyncActivityCenterDismissed) ProtoMessage()    {}
 func (*SyncActivityCenterDismissed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{13}
+	return fileDescriptor_d61ab7221f0b5518, []int{14}
 }
 
 func (m *SyncActivityCenterDismissed) XXX_Unmarshal(b []byte) error {
@@ -949,7 +1080,7 @@ func (m *SyncBookmark) Reset()         { *m = SyncBookmark{} }
 func (m *SyncBookmark) String() string { return proto.CompactTextString(m) }
 func (*SyncBookmark) ProtoMessage()    {}
 func (*SyncBookmark) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{14}
+	return fileDescriptor_d61ab7221f0b5518, []int{15}
 }
 
 func (m *SyncBookmark) XXX_Unmarshal(b []byte) error {
@@ -1017,7 +1148,7 @@ func (m *SyncClearHistory) Reset()         { *m = SyncClearHistory{} }
 func (m *SyncClearHistory) String() string { return proto.CompactTextString(m) }
 func (*SyncClearHistory) ProtoMessage()    {}
 func (*SyncClearHistory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d61ab7221f0b5518, []int{15}
+	return fileDescriptor_d61ab7221f0b5518, []int{16}
 }
 
 func (m *SyncClearHistory) XXX_Unmarshal(b []byte) error {
@@ -1052,8 +1183,421 @@ func (m *SyncClearHistory) GetClearedAt() uint64 {
 	return 0
 }
 
+type SyncChatDraft struct {
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// An empty text clears the draft
+	Text                 string   `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	ResponseTo           string   `protobuf:"bytes,4,opt,name=response_to,json=responseTo,proto3" json:"response_to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncChatDraft) Reset()         { *m = SyncChatDraft{} }
+func (m *SyncChatDraft) String() string { return proto.CompactTextString(m) }
+func (*SyncChatDraft) ProtoMessage()    {}
+func (*SyncChatDraft) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d61ab7221f0b5518, []int{17}
+}
+
+func (m *SyncChatDraft) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncChatDraft.Unmarshal(m, b)
+}
+func (m *SyncChatDraft) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncChatDraft.Marshal(b, m, deterministic)
+}
+func (m *SyncChatDraft) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncChatDraft.Merge(m, src)
+}
+func (m *SyncChatDraft) XXX_Size() int {
+	return xxx_messageInfo_SyncChatDraft.Size(m)
+}
+func (m *SyncChatDraft) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncChatDraft.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncChatDraft proto.InternalMessageInfo
+
+func (m *SyncChatDraft) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *SyncChatDraft) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *SyncChatDraft) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *SyncChatDraft) GetResponseTo() string {
+	if m != nil {
+		return m.ResponseTo
+	}
+	return ""
+}
+
+// Archive is the content of an archive file exported by the user,
+// it's encrypted with a key derived from a password
+type Archive struct {
+	// Version of the archive format used by the exporter
+	Version     uint32                       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Clock       uint64                       `protobuf:"varint,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Contacts    []*SyncInstallationContactV2 `protobuf:"bytes,3,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	Chats       []*ArchiveChat               `protobuf:"bytes,4,rep,name=chats,proto3" json:"chats,omitempty"`
+	Messages    []*ArchiveMessage            `protobuf:"bytes,5,rep,name=messages,proto3" json:"messages,omitempty"`
+	Communities []*SyncCommunity             `protobuf:"bytes,6,rep,name=communities,proto3" json:"communities,omitempty"`
+	Settings    []*BackupSetting             `protobuf:"bytes,7,rep,name=settings,proto3" json:"settings,omitempty"`
+	// Public key of the account that exported the archive, it can only be
+	// imported into the same account
+	PublicKey            string   `protobuf:"bytes,8,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Archive) Reset()         { *m = Archive{} }
+func (m *Archive) String() string { return proto.CompactTextString(m) }
+func (*Archive) ProtoMessage()    {}
+func (*Archive) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d61ab7221f0b5518, []int{18}
+}
+
+func (m *Archive) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Archive.Unmarshal(m, b)
+}
+func (m *Archive) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Archive.Marshal(b, m, deterministic)
+}
+func (m *Archive) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Archive.Merge(m, src)
+}
+func (m *Archive) XXX_Size() int {
+	return xxx_messageInfo_Archive.Size(m)
+}
+func (m *Archive) XXX_DiscardUnknown() {
+	xxx_messageInfo_Archive.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Archive proto.InternalMessageInfo
+
+func (m *Archive) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Archive) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *Archive) GetContacts() []*SyncInstallationContactV2 {
+	if m != nil {
+		return m.Contacts
+	}
+	return nil
+}
+
+func (m *Archive) GetChats() []*ArchiveChat {
+	if m != nil {
+		return m.Chats
+	}
+	return nil
+}
+
+func (m *Archive) GetMessages() []*ArchiveMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *Archive) GetCommunities() []*SyncCommunity {
+	if m != nil {
+		return m.Communities
+	}
+	return nil
+}
+
+func (m *Archive) GetSettings() []*BackupSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *Archive) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+type ArchiveChat struct {
+	Id                       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ChatType                 uint32 `protobuf:"varint,3,opt,name=chat_type,json=chatType,proto3" json:"chat_type,omitempty"`
+	Color                    string `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	Active                   bool   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Muted                    bool   `protobuf:"varint,6,opt,name=muted,proto3" json:"muted,omitempty"`
+	CommunityId              string `protobuf:"bytes,7,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Joined                   int64  `protobuf:"varint,8,opt,name=joined,proto3" json:"joined,omitempty"`
+	LastClockValue           uint64 `protobuf:"varint,9,opt,name=last_clock_value,json=lastClockValue,proto3" json:"last_clock_value,omitempty"`
+	DeletedAtClockValue      uint64 `protobuf:"varint,10,opt,name=deleted_at_clock_value,json=deletedAtClockValue,proto3" json:"deleted_at_clock_value,omitempty"`
+	ReadMessagesAtClockValue uint64 `protobuf:"varint,11,opt,name=read_messages_at_clock_value,json=readMessagesAtClockValue,proto3" json:"read_messages_at_clock_value,omitempty"`
+	MessagesTtl              uint64 `protobuf:"varint,12,opt,name=messages_ttl,json=messagesTtl,proto3" json:"messages_ttl,omitempty"`
+	MessagesTtlClockValue    uint64 `protobuf:"varint,13,opt,name=messages_ttl_clock_value,json=messagesTtlClockValue,proto3" json:"messages_ttl_clock_value,omitempty"`
+	// Signed membership update events of private group chats, in the same
+	// format as MembershipUpdateMessage.events
+	MembershipUpdateEvents [][]byte `protobuf:"bytes,14,rep,name=membership_update_events,json=membershipUpdateEvents,proto3" json:"membership_update_events,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ArchiveChat) Reset()         { *m = ArchiveChat{} }
+func (m *ArchiveChat) String() string { return proto.CompactTextString(m) }
+func (*ArchiveChat) ProtoMessage()    {}
+func (*ArchiveChat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d61ab7221f0b5518, []int{19}
+}
+
+func (m *ArchiveChat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveChat.Unmarshal(m, b)
+}
+func (m *ArchiveChat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveChat.Marshal(b, m, deterministic)
+}
+func (m *ArchiveChat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveChat.Merge(m, src)
+}
+func (m *ArchiveChat) XXX_Size() int {
+	return xxx_messageInfo_ArchiveChat.Size(m)
+}
+func (m *ArchiveChat) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveChat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveChat proto.InternalMessageInfo
+
+func (m *ArchiveChat) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ArchiveChat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArchiveChat) GetChatType() uint32 {
+	if m != nil {
+		return m.ChatType
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *ArchiveChat) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *ArchiveChat) GetMuted() bool {
+	if m != nil {
+		return m.Muted
+	}
+	return false
+}
+
+func (m *ArchiveChat) GetCommunityId() string {
+	if m != nil {
+		return m.CommunityId
+	}
+	return ""
+}
+
+func (m *ArchiveChat) GetJoined() int64 {
+	if m != nil {
+		return m.Joined
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetLastClockValue() uint64 {
+	if m != nil {
+		return m.LastClockValue
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetDeletedAtClockValue() uint64 {
+	if m != nil {
+		return m.DeletedAtClockValue
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetReadMessagesAtClockValue() uint64 {
+	if m != nil {
+		return m.ReadMessagesAtClockValue
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetMessagesTtl() uint64 {
+	if m != nil {
+		return m.MessagesTtl
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetMessagesTtlClockValue() uint64 {
+	if m != nil {
+		return m.MessagesTtlClockValue
+	}
+	return 0
+}
+
+func (m *ArchiveChat) GetMembershipUpdateEvents() [][]byte {
+	if m != nil {
+		return m.MembershipUpdateEvents
+	}
+	return nil
+}
+
+type ArchiveMessage struct {
+	Id                   string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LocalChatId          string       `protobuf:"bytes,2,opt,name=local_chat_id,json=localChatId,proto3" json:"local_chat_id,omitempty"`
+	From                 string       `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	WhisperTimestamp     uint64       `protobuf:"varint,4,opt,name=whisper_timestamp,json=whisperTimestamp,proto3" json:"whisper_timestamp,omitempty"`
+	Seen                 bool         `protobuf:"varint,5,opt,name=seen,proto3" json:"seen,omitempty"`
+	OutgoingStatus       string       `protobuf:"bytes,6,opt,name=outgoing_status,json=outgoingStatus,proto3" json:"outgoing_status,omitempty"`
+	EditedAt             uint64       `protobuf:"varint,7,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	Deleted              bool         `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Message              *ChatMessage `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ArchiveMessage) Reset()         { *m = ArchiveMessage{} }
+func (m *ArchiveMessage) String() string { return proto.CompactTextString(m) }
+func (*ArchiveMessage) ProtoMessage()    {}
+func (*ArchiveMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d61ab7221f0b5518, []int{20}
+}
+
+func (m *ArchiveMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveMessage.Unmarshal(m, b)
+}
+func (m *ArchiveMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveMessage.Marshal(b, m, deterministic)
+}
+func (m *ArchiveMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveMessage.Merge(m, src)
+}
+func (m *ArchiveMessage) XXX_Size() int {
+	return xxx_messageInfo_ArchiveMessage.Size(m)
+}
+func (m *ArchiveMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveMessage proto.InternalMessageInfo
+
+func (m *ArchiveMessage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ArchiveMessage) GetLocalChatId() string {
+	if m != nil {
+		return m.LocalChatId
+	}
+	return ""
+}
+
+func (m *ArchiveMessage) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ArchiveMessage) GetWhisperTimestamp() uint64 {
+	if m != nil {
+		return m.WhisperTimestamp
+	}
+	return 0
+}
+
+func (m *ArchiveMessage) GetSeen() bool {
+	if m != nil {
+		return m.Seen
+	}
+	return false
+}
+
+func (m *ArchiveMessage) GetOutgoingStatus() string {
+	if m != nil {
+		return m.OutgoingStatus
+	}
+	return ""
+}
+
+func (m *ArchiveMessage) GetEditedAt() uint64 {
+	if m != nil {
+		return m.EditedAt
+	}
+	return 0
+}
+
+func (m *ArchiveMessage) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *ArchiveMessage) GetMessage() *ChatMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Backup)(nil), "protobuf.Backup")
+	proto.RegisterType((*BackupSetting)(nil), "protobuf.BackupSetting")
 	proto.RegisterType((*PairInstallation)(nil), "protobuf.PairInstallation")
 	proto.RegisterType((*SyncInstallationContact)(nil), "protobuf.SyncInstallationContact")
 	proto.RegisterType((*SyncInstallationContactV2)(nil), "protobuf.SyncInstallationContactV2")
@@ -1069,6 +1613,10 @@ func init() {
 	proto.RegisterType((*SyncActivityCenterDismissed)(nil), "protobuf.SyncActivityCenterDismissed")
 	proto.RegisterType((*SyncBookmark)(nil), "protobuf.SyncBookmark")
 	proto.RegisterType((*SyncClearHistory)(nil), "protobuf.SyncClearHistory")
+	proto.RegisterType((*SyncChatDraft)(nil), "protobuf.SyncChatDraft")
+	proto.RegisterType((*Archive)(nil), "protobuf.Archive")
+	proto.RegisterType((*ArchiveChat)(nil), "protobuf.ArchiveChat")
+	proto.RegisterType((*ArchiveMessage)(nil), "protobuf.ArchiveMessage")
 }
 
 func init() {
@@ -1076,62 +1624,101 @@ func init() {
 }
 
 var fileDescriptor_d61ab7221f0b5518 = []byte{
-	// 897 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0x4f, 0x73, 0xdb, 0x44,
-	0x14, 0x1f, 0x59, 0x8e, 0x2d, 0x3f, 0xcb, 0x69, 0x66, 0x27, 0xd3, 0xa8, 0xed, 0x74, 0xea, 0xaa,
-	0x74, 0xc8, 0x29, 0x30, 0xe5, 0xc0, 0x30, 0x85, 0x01, 0xc7, 0x65, 0xc0, 0x05, 0x4a, 0x47, 0x24,
-	0x1c, 0xb8, 0x68, 0x36, 0xab, 0x8d, 0xb3, 0x58, 0xd2, 0x8a, 0xdd, 0x95, 0x19, 0x1d, 0xb9, 0x70,
-	0xe0, 0x08, 0x1f, 0x8c, 0xef, 0xc1, 0xa7, 0x60, 0x76, 0x57, 0x56, 0xe4, 0x04, 0x3b, 0xe6, 0xd8,
-	0x93, 0xf6, 0xfd, 0xde, 0xdf, 0xfd, 0xed, 0x7b, 0x4f, 0x30, 0x2a, 0x30, 0x13, 0x2c, 0x9f, 0x9f,
-	0x14, 0x82, 0x2b, 0x8e, 0x3c, 0xf3, 0xb9, 0x28, 0x2f, 0x43, 0x0e, 0xbd, 0x53, 0x4c, 0x16, 0x65,
-	0x81, 0x0e, 0x61, 0x8f, 0xa4, 0x9c, 0x2c, 0x02, 0x67, 0xec, 0x1c, 0x77, 0x23, 0x2b, 0xa0, 0x7d,
-	0xe8, 0xb0, 0x24, 0xe8, 0x8c, 0x9d, 0xe3, 0x41, 0xd4, 0x61, 0x09, 0xfa, 0x1c, 0x3c, 0xc2, 0x73,
-	0x85, 0x89, 0x92, 0x81, 0x3b, 0x76, 0x8f, 0x87, 0x2f, 0x9e, 0x9d, 0xac, 0x82, 0x9d, 0xfc, 0x50,
-	0xe5, 0x64, 0x96, 0x4b, 0x85, 0xd3, 0x14, 0x2b, 0xc6, 0xf3, 0xa9, 0xb5, 0xfc, 0xf1, 0x45, 0xd4,
-	0x38, 0x85, 0xbf, 0x3b, 0x70, 0xf0, 0x16, 0x33, 0xd1, 0xb6, 0xdb, 0x90, 0xfb, 0x7d, 0xb8, 0xc7,
-	0x5a, 0x56, 0x71, 0x53, 0xc8, 0x7e, 0x1b, 0x9e, 0x25, 0xe8, 0x09, 0x0c, 0x13, 0xba, 0x64, 0x84,
-	0xc6, 0xaa, 0x2a, 0x68, 0xe0, 0x1a, 0x23, 0xb0, 0xd0, 0x59, 0x55, 0x50, 0x84, 0xa0, 0x9b, 0xe3,
-	0x8c, 0x06, 0x5d, 0xa3, 0x31, 0xe7, 0xf0, 0x1f, 0x07, 0x8e, 0x36, 0x14, 0xbc, 0x23, 0x17, 0xcf,
-	0x60, 0x54, 0x08, 0x7e, 0xc9, 0x52, 0x1a, 0xb3, 0x0c, 0xcf, 0x57, 0x89, 0xfd, 0x1a, 0x9c, 0x69,
-	0x0c, 0x3d, 0x00, 0x8f, 0xe6, 0x32, 0x6e, 0xa5, 0xef, 0xd3, 0x5c, 0xbe, 0xc1, 0x19, 0x45, 0x4f,
-	0xc1, 0x4f, 0xb1, 0x54, 0x71, 0x59, 0x24, 0x58, 0xd1, 0x24, 0xd8, 0x33, 0xc9, 0x86, 0x1a, 0x3b,
-	0xb7, 0x90, 0xbe, 0x99, 0xac, 0xa4, 0xa2, 0x59, 0xac, 0xf0, 0x5c, 0x06, 0xbd, 0xb1, 0xab, 0x6f,
-	0x66, 0xa1, 0x33, 0x3c, 0x97, 0xe8, 0x39, 0xec, 0xa7, 0x9c, 0xe0, 0x34, 0xce, 0x19, 0x59, 0x98,
-	0x24, 0x7d, 0x93, 0x64, 0x64, 0xd0, 0x37, 0x35, 0x18, 0xfe, 0xe1, 0xc2, 0x83, 0x8d, 0xaf, 0x83,
-	0x3e, 0x84, 0xc3, 0x76, 0x21, 0xb1, 0xf1, 0x4d, 0xab, 0xfa, 0xf6, 0xa8, 0x55, 0xd0, 0xb7, 0x56,
-	0xf3, 0x0e, 0x53, 0xa1, 0xdf, 0x16, 0x27, 0x09, 0x4d, 0x82, 0xc1, 0xd8, 0x39, 0xf6, 0x22, 0x2b,
-	0xa0, 0x00, 0xfa, 0x17, 0xfa, 0x91, 0x69, 0x12, 0x80, 0xc1, 0x57, 0xa2, 0xb6, 0xcf, 0x4a, 0x5d,
-	0xd3, 0xd0, 0xda, 0x1b, 0x41, 0xdb, 0x0b, 0x9a, 0xf1, 0x25, 0x4d, 0x02, 0xdf, 0xda, 0xd7, 0x22,
-	0x1a, 0x83, 0x7f, 0x85, 0x65, 0x6c, 0xc2, 0xc6, 0xa5, 0x0c, 0x46, 0x46, 0x0d, 0x57, 0x58, 0x4e,
-	0x34, 0x74, 0x2e, 0xc3, 0x5f, 0x6f, 0x37, 0xde, 0x84, 0x10, 0x5e, 0xe6, 0x9b, 0x1a, 0xef, 0x16,
-	0xbb, 0x9d, 0xff, 0x60, 0xf7, 0x26, 0x85, 0xee, 0x2d, 0x0a, 0xc3, 0x53, 0x78, 0x78, 0x33, 0xf1,
-	0xdb, 0xf2, 0x22, 0x65, 0x64, 0x7a, 0x85, 0x77, 0x6c, 0xfa, 0xf0, 0xaf, 0x0e, 0x8c, 0x74, 0x90,
-	0x29, 0xcf, 0xb2, 0x32, 0x67, 0xaa, 0xba, 0xd3, 0xcf, 0x37, 0x1d, 0xf2, 0x04, 0x86, 0x85, 0x60,
-	0x4b, 0xac, 0x68, 0xbc, 0xa0, 0x95, 0xa9, 0xce, 0x8f, 0xa0, 0x86, 0xbe, 0xa1, 0x15, 0x1a, 0xeb,
-	0x21, 0x96, 0x44, 0xb0, 0x42, 0xd7, 0x65, 0x1a, 0xc4, 0x8f, 0xda, 0x10, 0xba, 0x0f, 0xbd, 0x9f,
-	0x39, 0xcb, 0xeb, 0xf6, 0xf0, 0xa2, 0x5a, 0x42, 0x0f, 0xc1, 0x5b, 0x52, 0xc1, 0x2e, 0x19, 0x4d,
-	0x82, 0x9e, 0xd1, 0x34, 0xf2, 0xf5, 0xeb, 0xf5, 0xdb, 0xaf, 0xf7, 0x3d, 0x1c, 0x08, 0xfa, 0x4b,
-	0x49, 0xa5, 0x92, 0xb1, 0xe2, 0xb1, 0x8e, 0x13, 0x78, 0x66, 0x9b, 0x3d, 0x5f, 0xdf, 0x66, 0xcd,
-	0x2d, 0xa3, 0xda, 0xfc, 0x8c, 0xbf, 0xe6, 0x2c, 0x8f, 0xf6, 0xc5, 0x9a, 0x1c, 0xfe, 0xed, 0xc0,
-	0xa3, 0x2d, 0xf6, 0x35, 0x1b, 0x4e, 0xc3, 0xc6, 0x63, 0x80, 0xc2, 0x30, 0x6f, 0xc8, 0xb0, 0xec,
-	0x0e, 0x2c, 0xa2, 0xb9, 0x68, 0x28, 0x75, 0xdb, 0x94, 0x6e, 0x99, 0x9f, 0x23, 0xe8, 0x93, 0x2b,
-	0xac, 0xf4, 0x8a, 0xdc, 0x33, 0x9a, 0x9e, 0x16, 0x67, 0x89, 0xee, 0x0a, 0xb2, 0xaa, 0x49, 0x6b,
-	0x7b, 0x96, 0xd6, 0x06, 0x9b, 0x19, 0x8a, 0xa4, 0xc2, 0xca, 0x8e, 0x4b, 0x37, 0xb2, 0x42, 0xf8,
-	0x67, 0x07, 0x0e, 0x6e, 0x36, 0x0b, 0xfa, 0xac, 0xb5, 0xfd, 0x1d, 0xc3, 0xd7, 0xd3, 0x3b, 0xb7,
-	0xff, 0xf5, 0xee, 0x47, 0x5f, 0x81, 0x5f, 0xdf, 0x5a, 0x57, 0x27, 0x83, 0x8e, 0x09, 0xf1, 0xde,
-	0xe6, 0x10, 0xd7, 0xdd, 0x19, 0x0d, 0x8b, 0xe6, 0x2c, 0xd1, 0x4b, 0xe8, 0x63, 0x3b, 0x31, 0x86,
-	0xa1, 0xad, 0x65, 0xd4, 0xa3, 0x15, 0xad, 0x3c, 0xd0, 0x27, 0xd0, 0x5c, 0x9f, 0x51, 0x19, 0x74,
-	0x4d, 0x11, 0x47, 0x9b, 0xde, 0xbd, 0x6d, 0x1b, 0x7e, 0x0c, 0xf7, 0x8c, 0x56, 0x17, 0x54, 0x8f,
-	0xfb, 0x6e, 0x53, 0xf3, 0x29, 0x1c, 0xae, 0x1c, 0xbf, 0xa3, 0x52, 0xe2, 0x39, 0x95, 0x11, 0xc5,
-	0xbb, 0x7a, 0x7f, 0x01, 0xf7, 0xb5, 0xf7, 0x84, 0x28, 0xb6, 0x64, 0xaa, 0x9a, 0xd2, 0x5c, 0x51,
-	0xb1, 0xc5, 0xff, 0x00, 0x5c, 0x96, 0x58, 0x7a, 0xfd, 0x48, 0x1f, 0xc3, 0x57, 0x76, 0xf2, 0xd7,
-	0x23, 0x4c, 0x08, 0xa1, 0x85, 0xa2, 0xbb, 0x47, 0xf9, 0xd2, 0x36, 0xf9, 0x7a, 0x94, 0x57, 0x4c,
-	0x66, 0x4c, 0xca, 0xff, 0x11, 0xe6, 0x37, 0x07, 0x7c, 0x1d, 0xe7, 0x94, 0xf3, 0x45, 0x86, 0xc5,
-	0x62, 0xb3, 0x63, 0x29, 0xd2, 0x9a, 0x06, 0x7d, 0x6c, 0x7e, 0xe3, 0xee, 0xf5, 0x6f, 0x1c, 0x3d,
-	0x82, 0x81, 0xd9, 0x89, 0xb1, 0xb6, 0xb5, 0x53, 0xe1, 0x19, 0xe0, 0x5c, 0xa4, 0xed, 0x2d, 0xbd,
-	0xb7, 0xb6, 0xa5, 0xc3, 0xd7, 0xb6, 0xbb, 0xa7, 0x29, 0xc5, 0xe2, 0x6b, 0x26, 0x15, 0x17, 0x55,
-	0x7b, 0x88, 0x9c, 0xb5, 0x21, 0x7a, 0x0c, 0x40, 0xb4, 0x21, 0x4d, 0x62, 0xac, 0x4c, 0x41, 0xdd,
-	0x68, 0x50, 0x23, 0x13, 0x75, 0x3a, 0xfa, 0x69, 0x78, 0xf2, 0xc1, 0xcb, 0x55, 0xff, 0x5c, 0xf4,
-	0xcc, 0xe9, 0xa3, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x77, 0xfe, 0x84, 0xb1, 0x74, 0x09, 0x00,
-	0x00,
+	// 1526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0xdd, 0x72, 0xdb, 0x36,
+	0x16, 0x1e, 0xfd, 0x58, 0xa2, 0x8e, 0x7e, 0xe2, 0xc5, 0x3a, 0x36, 0x93, 0x6c, 0x26, 0x0a, 0x93,
+	0xcc, 0x6a, 0x26, 0x33, 0xce, 0x4e, 0x92, 0x99, 0x6c, 0x26, 0xfb, 0x27, 0x3b, 0x99, 0x5d, 0x67,
+	0x77, 0xd3, 0x0c, 0xe2, 0xe4, 0xa2, 0x17, 0xe5, 0xc0, 0x24, 0x2c, 0xa3, 0xa6, 0x48, 0x96, 0x80,
+	0x94, 0xea, 0xb2, 0xbd, 0xe8, 0x45, 0x2f, 0xdb, 0xb7, 0xe8, 0xcb, 0xf4, 0x19, 0x7a, 0xdb, 0xe9,
+	0x43, 0x74, 0x70, 0x00, 0xd2, 0xa4, 0x6c, 0x39, 0x6a, 0x7b, 0xd5, 0x2b, 0x12, 0x1f, 0xbe, 0x83,
+	0x9f, 0x83, 0x73, 0x0e, 0x3e, 0x40, 0x3f, 0x65, 0x22, 0x13, 0xf1, 0x64, 0x37, 0xcd, 0x12, 0x95,
+	0x10, 0x07, 0x3f, 0x47, 0xb3, 0xe3, 0xeb, 0x24, 0x38, 0x61, 0xca, 0x9f, 0x72, 0x29, 0xd9, 0x84,
+	0x9b, 0x5e, 0xef, 0x87, 0x0d, 0x68, 0xed, 0xb1, 0xe0, 0x74, 0x96, 0x92, 0x2d, 0xd8, 0x08, 0xa2,
+	0x24, 0x38, 0x75, 0x6b, 0xc3, 0xda, 0xa8, 0x49, 0x4d, 0x83, 0x0c, 0xa0, 0x2e, 0x42, 0xb7, 0x3e,
+	0xac, 0x8d, 0x3a, 0xb4, 0x2e, 0x42, 0xf2, 0x4f, 0x70, 0x82, 0x24, 0x56, 0x2c, 0x50, 0xd2, 0x6d,
+	0x0c, 0x1b, 0xa3, 0xee, 0xc3, 0x3b, 0xbb, 0xf9, 0x0c, 0xbb, 0x6f, 0x16, 0x71, 0x70, 0x10, 0x4b,
+	0xc5, 0xa2, 0x88, 0x29, 0x91, 0xc4, 0xfb, 0x86, 0xf9, 0xee, 0x21, 0x2d, 0x8c, 0xc8, 0x03, 0x68,
+	0x85, 0x19, 0x3b, 0x56, 0xd2, 0x6d, 0xa2, 0xf9, 0x4e, 0xd5, 0x7c, 0xff, 0x84, 0xa9, 0xe7, 0xba,
+	0x9f, 0x5a, 0x1a, 0x71, 0xa1, 0x3d, 0xe7, 0x99, 0x14, 0x49, 0xec, 0x6e, 0x0c, 0x6b, 0xa3, 0x3e,
+	0xcd, 0x9b, 0xe4, 0xdf, 0xd0, 0x4b, 0x67, 0x47, 0x91, 0x08, 0x7c, 0xbd, 0x33, 0xe9, 0xb6, 0x70,
+	0xc0, 0xbb, 0xab, 0xd7, 0xf3, 0x1a, 0xd9, 0x7a, 0x0a, 0xda, 0x4d, 0x8b, 0x7f, 0x49, 0x9e, 0x42,
+	0x37, 0x48, 0xa6, 0xd3, 0x59, 0x2c, 0x94, 0xe0, 0xd2, 0x6d, 0x5f, 0xb8, 0x30, 0x4b, 0x58, 0xd0,
+	0x32, 0x97, 0x3c, 0x86, 0xce, 0x51, 0x92, 0x9c, 0x4e, 0x59, 0x76, 0x2a, 0x5d, 0x07, 0x0d, 0xb7,
+	0xab, 0x86, 0x7b, 0xb6, 0x9b, 0x9e, 0x11, 0xc9, 0x23, 0x70, 0x24, 0x57, 0x4a, 0xc4, 0x13, 0xe9,
+	0x76, 0x96, 0x67, 0x33, 0xe7, 0xf1, 0xc6, 0xf4, 0xd3, 0x82, 0x48, 0x6e, 0x41, 0x77, 0x3a, 0x53,
+	0x3c, 0xb4, 0xbb, 0x85, 0x61, 0x63, 0xd4, 0xa1, 0x80, 0x90, 0xd9, 0x06, 0x85, 0x2d, 0x16, 0x28,
+	0x31, 0x17, 0x6a, 0xe1, 0x07, 0x3c, 0x56, 0x3c, 0xf3, 0x33, 0xce, 0x42, 0xb7, 0x3b, 0xac, 0x8d,
+	0xba, 0x0f, 0x87, 0xd5, 0x65, 0x8d, 0x2d, 0x73, 0x1f, 0x89, 0x94, 0xb3, 0x90, 0x12, 0x76, 0x0e,
+	0x23, 0x9f, 0x80, 0xbb, 0x3c, 0x26, 0x0b, 0x02, 0x9e, 0x2a, 0x1e, 0xba, 0x3d, 0x1c, 0xf7, 0xee,
+	0x65, 0xe3, 0x8e, 0x2d, 0x97, 0x6e, 0xb3, 0x0b, 0x71, 0xc2, 0xe0, 0xda, 0xf2, 0xf8, 0xa1, 0x90,
+	0x53, 0x21, 0x25, 0x0f, 0xdd, 0x3e, 0x4e, 0x70, 0xef, 0xb2, 0x09, 0x9e, 0xe7, 0x64, 0xba, 0xc3,
+	0x2e, 0xee, 0xf0, 0x9e, 0x42, 0xbf, 0xe2, 0x52, 0x42, 0xa0, 0x19, 0xb3, 0x29, 0xc7, 0x40, 0xef,
+	0x50, 0xfc, 0xd7, 0xd1, 0x3f, 0x67, 0xd1, 0x8c, 0x63, 0xa8, 0xf7, 0xa8, 0x69, 0x78, 0x5f, 0xd5,
+	0x60, 0xf3, 0x35, 0x13, 0x59, 0x39, 0x88, 0x56, 0x24, 0xca, 0x9f, 0xe1, 0x8a, 0x28, 0xb1, 0xfc,
+	0x22, 0x6b, 0x06, 0x65, 0xf8, 0x20, 0xd4, 0xc7, 0x18, 0xf2, 0xb9, 0x08, 0xb8, 0xaf, 0x16, 0x29,
+	0x77, 0x1b, 0x48, 0x02, 0x03, 0x1d, 0x2e, 0x52, 0x5e, 0x2c, 0xaf, 0x79, 0xb6, 0x3c, 0xef, 0xc7,
+	0x1a, 0xec, 0xac, 0xc8, 0xae, 0x35, 0x13, 0xf7, 0x0e, 0xf4, 0xd3, 0x2c, 0x39, 0x16, 0x11, 0xf7,
+	0xc5, 0x94, 0x4d, 0xf2, 0x89, 0x7b, 0x16, 0x3c, 0xd0, 0x18, 0xb9, 0x06, 0x0e, 0x8f, 0xa5, 0x5f,
+	0x9a, 0xbe, 0xcd, 0x63, 0xf9, 0x4a, 0x3b, 0xe8, 0x36, 0xf4, 0x22, 0x26, 0x95, 0x3f, 0x4b, 0x43,
+	0xa6, 0x0f, 0x7f, 0x03, 0x27, 0xeb, 0x6a, 0xec, 0xad, 0x81, 0xf4, 0xce, 0xe4, 0x42, 0x2a, 0x3e,
+	0xf5, 0x15, 0x9b, 0x98, 0x74, 0xec, 0x50, 0x30, 0xd0, 0x21, 0x9b, 0x48, 0x72, 0x0f, 0x06, 0x51,
+	0x12, 0xb0, 0xc8, 0x8f, 0x45, 0x70, 0x8a, 0x93, 0xb4, 0x71, 0x92, 0x3e, 0xa2, 0xaf, 0x2c, 0xe8,
+	0x7d, 0xdd, 0x80, 0x6b, 0x2b, 0x4b, 0x09, 0xf9, 0x0b, 0x6c, 0x95, 0x17, 0xe2, 0xa3, 0x6d, 0xb4,
+	0xb0, 0xbb, 0x27, 0xa5, 0x05, 0xfd, 0xcf, 0xf4, 0xfc, 0x8e, 0x5d, 0xa1, 0xcf, 0x96, 0x85, 0x21,
+	0x0f, 0xdd, 0xce, 0xb0, 0x36, 0x72, 0xa8, 0x69, 0xe8, 0x92, 0x78, 0xa4, 0x0f, 0x99, 0x87, 0x2e,
+	0x20, 0x9e, 0x37, 0x35, 0x1f, 0x0b, 0x02, 0xe6, 0xbc, 0x43, 0x4d, 0x43, 0xf3, 0x33, 0x3e, 0x4d,
+	0xe6, 0x36, 0x67, 0x1d, 0x9a, 0x37, 0xc9, 0x10, 0x7a, 0x27, 0x4c, 0xfa, 0x38, 0xac, 0x3f, 0x93,
+	0x98, 0x71, 0x0e, 0x85, 0x13, 0x26, 0xc7, 0x1a, 0x7a, 0x2b, 0xbd, 0xf7, 0xe7, 0x03, 0x6f, 0x1c,
+	0x04, 0xc9, 0x2c, 0x5e, 0x15, 0x78, 0xe7, 0xbc, 0x5b, 0xbf, 0xc0, 0xbb, 0xcb, 0x2e, 0x6c, 0x9c,
+	0x73, 0xa1, 0xb7, 0x07, 0xd7, 0x57, 0xd7, 0xef, 0xf5, 0x82, 0xde, 0xfb, 0xb6, 0x0e, 0xfd, 0x4a,
+	0xf1, 0xfe, 0xa0, 0x5d, 0x0f, 0x23, 0xe4, 0x16, 0x74, 0xd3, 0x4c, 0xcc, 0x99, 0xe2, 0xfe, 0x29,
+	0x5f, 0xe0, 0xea, 0x7a, 0x14, 0x2c, 0xf4, 0x5f, 0xbe, 0x20, 0x43, 0x9d, 0xc4, 0x32, 0xc8, 0x44,
+	0xaa, 0xd7, 0x85, 0x01, 0xd2, 0xa3, 0x65, 0x88, 0x6c, 0x43, 0xeb, 0xd3, 0x44, 0xc4, 0x36, 0x3c,
+	0x1c, 0x6a, 0x5b, 0xe4, 0x3a, 0x38, 0x73, 0x9e, 0x89, 0x63, 0xc1, 0x43, 0xb7, 0x85, 0x3d, 0x45,
+	0xfb, 0xec, 0xf4, 0xda, 0xe5, 0xd3, 0xfb, 0x08, 0x36, 0x33, 0xfe, 0xd9, 0x8c, 0x4b, 0x25, 0x7d,
+	0x95, 0xf8, 0x7a, 0x1c, 0x7b, 0xd3, 0xdc, 0x5b, 0x75, 0x45, 0x59, 0xfa, 0x61, 0xf2, 0x32, 0x11,
+	0x31, 0x1d, 0x64, 0x95, 0xb6, 0xf7, 0x7d, 0x0d, 0x6e, 0x5c, 0xc2, 0xb7, 0xde, 0xa8, 0x15, 0xde,
+	0xb8, 0x09, 0x60, 0xef, 0x59, 0xed, 0x0c, 0xe3, 0xdd, 0x8e, 0x41, 0xb4, 0x2f, 0x0a, 0x97, 0x36,
+	0xca, 0x2e, 0xbd, 0x24, 0x7f, 0x76, 0xa0, 0x8d, 0x52, 0x44, 0x18, 0xdf, 0x74, 0x68, 0x4b, 0x37,
+	0x0f, 0x42, 0x1d, 0x15, 0xf9, 0xdd, 0xba, 0xd0, 0xbd, 0x2d, 0xe3, 0xd6, 0x02, 0x3b, 0x40, 0x17,
+	0x49, 0xc5, 0x94, 0x49, 0x97, 0x26, 0x35, 0x0d, 0xef, 0x9b, 0x3a, 0x6c, 0x2e, 0x07, 0x0b, 0xf9,
+	0x7b, 0x49, 0xaa, 0xd4, 0xd0, 0x5f, 0xb7, 0x3f, 0x28, 0x55, 0x4a, 0x42, 0x65, 0x59, 0x5d, 0xd4,
+	0x7f, 0xad, 0xba, 0x78, 0x06, 0x6d, 0x66, 0x32, 0x06, 0x3d, 0x74, 0xe9, 0x32, 0x6c, 0x6a, 0xd1,
+	0xdc, 0x62, 0x59, 0x9a, 0x34, 0xd7, 0x97, 0x26, 0xde, 0x13, 0xb8, 0x92, 0x2b, 0x2a, 0x6a, 0xd3,
+	0x7d, 0xbd, 0xac, 0xf9, 0x1b, 0x6c, 0xe5, 0x86, 0xff, 0x37, 0x6a, 0x51, 0xa2, 0x16, 0x58, 0xcf,
+	0xfa, 0x5f, 0xb0, 0x7d, 0xb1, 0xbe, 0x58, 0x61, 0xbf, 0x09, 0x0d, 0x11, 0x1a, 0xf7, 0xf6, 0xa8,
+	0xfe, 0xf5, 0x9e, 0x9b, 0xcc, 0xbf, 0x58, 0x49, 0xac, 0x3d, 0xca, 0x0b, 0x13, 0xe4, 0x2b, 0xe4,
+	0xc2, 0xda, 0xc3, 0x7c, 0x51, 0x83, 0x5e, 0x59, 0xc6, 0xad, 0x36, 0x9c, 0x65, 0x91, 0x75, 0x83,
+	0xfe, 0x2d, 0xae, 0xf1, 0x46, 0x49, 0x65, 0xdc, 0x80, 0x0e, 0xd6, 0x44, 0x5f, 0x73, 0x4d, 0x56,
+	0x38, 0x08, 0xbc, 0xcd, 0xa2, 0x72, 0x95, 0xde, 0xa8, 0x54, 0x69, 0xef, 0xa5, 0x89, 0xee, 0xfd,
+	0x88, 0xb3, 0xec, 0x3f, 0x42, 0xaa, 0x24, 0x5b, 0x94, 0x93, 0xa8, 0x56, 0x49, 0xa2, 0x9b, 0x00,
+	0x81, 0x26, 0xf2, 0xd0, 0x67, 0x0a, 0x17, 0xd4, 0xa4, 0x1d, 0x8b, 0x8c, 0x95, 0x27, 0x6d, 0x45,
+	0xcc, 0x75, 0xf6, 0x8a, 0xfd, 0x94, 0x86, 0xaf, 0x57, 0x86, 0x27, 0xd0, 0x54, 0xfc, 0x73, 0x95,
+	0x6f, 0x4b, 0xff, 0xeb, 0x72, 0x99, 0x71, 0x99, 0x26, 0xb1, 0xe4, 0xbe, 0x4a, 0xec, 0xc6, 0x20,
+	0x87, 0x0e, 0x13, 0xef, 0xa7, 0x3a, 0xb4, 0xc7, 0x59, 0x70, 0x22, 0xe6, 0xbc, 0xac, 0xe7, 0x6b,
+	0x55, 0x3d, 0x5f, 0xac, 0xa4, 0x5e, 0x5e, 0xc9, 0x6f, 0x7e, 0x71, 0xdc, 0x87, 0x0d, 0x93, 0xc1,
+	0x26, 0x79, 0xae, 0x9e, 0x59, 0xdb, 0x25, 0x61, 0x86, 0x18, 0x0e, 0x79, 0x0c, 0x8e, 0x7d, 0x21,
+	0x49, 0x77, 0x03, 0xf9, 0xee, 0x39, 0xbe, 0x4d, 0x0a, 0x5a, 0x30, 0x97, 0xb3, 0xb4, 0xf5, 0x0b,
+	0x1e, 0x10, 0xe5, 0xa7, 0x40, 0x7b, 0xdd, 0xa7, 0x40, 0xb5, 0x22, 0x3b, 0x4b, 0x15, 0xd9, 0xfb,
+	0xb2, 0x09, 0xdd, 0xd2, 0xde, 0x4a, 0x05, 0xdd, 0x08, 0xa0, 0x3c, 0x34, 0xeb, 0xd5, 0xd0, 0xc4,
+	0x03, 0x2f, 0x44, 0x69, 0x9f, 0x3a, 0x1a, 0x40, 0x49, 0xaa, 0x4f, 0x26, 0x89, 0x92, 0xcc, 0x1e,
+	0xad, 0x69, 0xe8, 0x2b, 0x0e, 0x35, 0x37, 0xcf, 0xaf, 0x38, 0xd3, 0x3a, 0xbb, 0xc6, 0x5a, 0xe5,
+	0x6b, 0x6c, 0xb9, 0xb8, 0x1b, 0xbd, 0x53, 0x29, 0xee, 0x67, 0x77, 0xa6, 0xde, 0x52, 0xa3, 0xb8,
+	0x33, 0x47, 0xb0, 0x89, 0x6a, 0x01, 0x03, 0xc2, 0x37, 0x3a, 0xbd, 0x83, 0x31, 0x32, 0xd0, 0xf8,
+	0xbe, 0x86, 0xdf, 0x69, 0x94, 0x3c, 0x82, 0xed, 0x90, 0x47, 0x5c, 0x61, 0xf0, 0x57, 0xf8, 0x80,
+	0xfc, 0x3f, 0xda, 0xde, 0x71, 0xd9, 0xe8, 0x1f, 0xf0, 0x27, 0xfd, 0x4e, 0xca, 0x9f, 0xc6, 0x72,
+	0xd9, 0xb4, 0x8b, 0xa6, 0xae, 0xe6, 0xe4, 0xf5, 0xb0, 0x62, 0x7f, 0x1b, 0x7a, 0x85, 0xa9, 0x52,
+	0x11, 0x6a, 0xac, 0x26, 0xed, 0xe6, 0xd8, 0xa1, 0x8a, 0xc8, 0x13, 0x70, 0xcb, 0x94, 0xca, 0xf0,
+	0x7d, 0xa4, 0x5f, 0x2d, 0xd1, 0x4b, 0x63, 0xff, 0x55, 0x1b, 0x4e, 0x8f, 0x78, 0x26, 0x4f, 0x44,
+	0x6a, 0xe5, 0x92, 0xcf, 0xe7, 0x3c, 0x56, 0xd2, 0x1d, 0x60, 0x95, 0xda, 0x3e, 0xeb, 0x37, 0xd2,
+	0xe9, 0x05, 0xf6, 0x7a, 0xdf, 0xd5, 0x61, 0x50, 0x0d, 0xd8, 0x73, 0x71, 0xe0, 0x81, 0x91, 0x9b,
+	0x7e, 0x35, 0xd5, 0xbb, 0x08, 0xee, 0x17, 0xf9, 0x7e, 0x9c, 0x25, 0xd3, 0x3c, 0xdf, 0xf5, 0x3f,
+	0xb9, 0x0f, 0x7f, 0x78, 0x7f, 0x22, 0x64, 0xca, 0x33, 0x5f, 0x89, 0x29, 0x97, 0x8a, 0x4d, 0x53,
+	0x0c, 0x8d, 0x26, 0xdd, 0xb4, 0x1d, 0x87, 0x39, 0xae, 0x07, 0x90, 0x9c, 0xc7, 0x36, 0x46, 0xf0,
+	0x5f, 0x3f, 0x96, 0x92, 0x99, 0x9a, 0x24, 0x22, 0x9e, 0xf8, 0xfa, 0x06, 0x9f, 0x49, 0x8c, 0x95,
+	0x0e, 0x1d, 0xe4, 0xf0, 0x1b, 0x44, 0x75, 0x54, 0xf2, 0x50, 0x98, 0xe3, 0xb4, 0x57, 0xbe, 0x63,
+	0x80, 0xb1, 0xd2, 0x95, 0xc4, 0x1e, 0x27, 0xc6, 0x8b, 0x43, 0xf3, 0x26, 0x79, 0x00, 0x6d, 0xeb,
+	0x4e, 0x8c, 0x93, 0x4a, 0xd2, 0x97, 0xae, 0x35, 0x9a, 0xb3, 0xf6, 0xfa, 0x1f, 0x77, 0x77, 0x1f,
+	0x3c, 0xcb, 0x39, 0x47, 0x2d, 0xfc, 0x7b, 0xf4, 0x73, 0x00, 0x00, 0x00, 0xff, 0xff, 0x81, 0xd7,
+	0xba, 0xa7, 0x4c, 0x11, 0x00, 0x00,
 }
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: poll.proto
+
+package protobuf
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PollVote struct {
+	// Lamport timestamp of the vote, only the latest vote of each voter is counted
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Id of the poll message
+	MessageId string `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Indexes of the voted options
+	Options []uint32 `protobuf:"varint,4,rep,packed,name=options,proto3" json:"options,omitempty"`
+	// Whether this is a retraction of a previously sent vote
+	Retracted bool `protobuf:"varint,5,opt,name=retracted,proto3" json:"retracted,omitempty"`
+	// Grant for community chat messages
+	Grant []byte `protobuf:"bytes,6,opt,name=grant,proto3" json:"grant,omitempty"`
+	// The type of message (public/one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,7,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PollVote) Reset()         { *m = PollVote{} }
+func (m *PollVote) String() string { return proto.CompactTextString(m) }
+func (*PollVote) ProtoMessage()    {}
+func (*PollVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5d64382c74eeea90, []int{0}
+}
+
+func (m *PollVote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollVote.Unmarshal(m, b)
+}
+func (m *PollVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollVote.Marshal(b, m, deterministic)
+}
+func (m *PollVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollVote.Merge(m, src)
+}
+func (m *PollVote) XXX_Size() int {
+	return xxx_messageInfo_PollVote.Size(m)
+}
+func (m *PollVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollVote proto.InternalMessageInfo
+
+func (m *PollVote) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *PollVote) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *PollVote) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *PollVote) GetOptions() []uint32 {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *PollVote) GetRetracted() bool {
+	if m != nil {
+		return m.Retracted
+	}
+	return false
+}
+
+func (m *PollVote) GetGrant() []byte {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *PollVote) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
+type PollClose struct {
+	Clock  uint64 `protobuf:"varint,1,opt,name=clock,proto3" json:"clock,omitempty"`
+	ChatId string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Id of the poll message
+	MessageId string `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Grant for community chat messages
+	Grant []byte `protobuf:"bytes,4,opt,name=grant,proto3" json:"grant,omitempty"`
+	// The type of message (public/one-to-one/private-group-chat)
+	MessageType          MessageType `protobuf:"varint,5,opt,name=message_type,json=messageType,proto3,enum=protobuf.MessageType" json:"message_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PollClose) Reset()         { *m = PollClose{} }
+func (m *PollClose) String() string { return proto.CompactTextString(m) }
+func (*PollClose) ProtoMessage()    {}
+func (*PollClose) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5d64382c74eeea90, []int{1}
+}
+
+func (m *PollClose) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollClose.Unmarshal(m, b)
+}
+func (m *PollClose) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollClose.Marshal(b, m, deterministic)
+}
+func (m *PollClose) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollClose.Merge(m, src)
+}
+func (m *PollClose) XXX_Size() int {
+	return xxx_messageInfo_PollClose.Size(m)
+}
+func (m *PollClose) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollClose.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollClose proto.InternalMessageInfo
+
+func (m *PollClose) GetClock() uint64 {
+	if m != nil {
+		return m.Clock
+	}
+	return 0
+}
+
+func (m *PollClose) GetChatId() string {
+	if m != nil {
+		return m.ChatId
+	}
+	return ""
+}
+
+func (m *PollClose) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *PollClose) GetGrant() []byte {
+	if m != nil {
+		return m.Grant
+	}
+	return nil
+}
+
+func (m *PollClose) GetMessageType() MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return MessageType_UNKNOWN_MESSAGE_TYPE
+}
+
+func init() {
+	proto.RegisterType((*PollVote)(nil), "protobuf.PollVote")
+	proto.RegisterType((*PollClose)(nil), "protobuf.PollClose")
+}
+
+func init() {
+	proto.RegisterFile("poll.proto", fileDescriptor_5d64382c74eeea90)
+}
+
+var fileDescriptor_5d64382c74eeea90 = []byte{
+	// 254 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x8f, 0x3f, 0x4b, 0xc4, 0x30,
+	0x18, 0xc6, 0x89, 0xd7, 0xbf, 0x6f, 0xef, 0x1c, 0x82, 0x62, 0x10, 0x85, 0x70, 0x53, 0xa6, 0x0a,
+	0xba, 0x08, 0x6e, 0x3a, 0xdd, 0x20, 0x48, 0x10, 0x07, 0x17, 0xe9, 0xb5, 0xf1, 0x3c, 0x4c, 0xfb,
+	0x86, 0xe6, 0xbd, 0xe1, 0x3e, 0x92, 0xdf, 0xca, 0x8f, 0x22, 0x6d, 0xad, 0xdd, 0xc4, 0xc1, 0x29,
+	0xf9, 0x3d, 0x4f, 0x20, 0xbf, 0x07, 0xc0, 0xa1, 0xb5, 0xb9, 0x6b, 0x91, 0x90, 0x27, 0xfd, 0xb1,
+	0xde, 0xbd, 0x9e, 0x66, 0xa6, 0xd9, 0xd5, 0x7e, 0x88, 0x97, 0x9f, 0x0c, 0x92, 0x07, 0xb4, 0xf6,
+	0x09, 0xc9, 0xf0, 0x23, 0x08, 0x4b, 0x8b, 0xe5, 0xbb, 0x60, 0x92, 0xa9, 0x40, 0x0f, 0xc0, 0x4f,
+	0x20, 0x2e, 0xdf, 0x0a, 0x7a, 0xd9, 0x56, 0xe2, 0x40, 0x32, 0x95, 0xea, 0xa8, 0xc3, 0x55, 0xc5,
+	0xcf, 0x01, 0x6a, 0xe3, 0x7d, 0xb1, 0x31, 0x5d, 0x37, 0xeb, 0xbb, 0xf4, 0x3b, 0x59, 0x55, 0x5c,
+	0x40, 0x8c, 0x8e, 0xb6, 0xd8, 0x78, 0x11, 0xc8, 0x99, 0x5a, 0xe8, 0x11, 0xf9, 0x19, 0xa4, 0xad,
+	0xa1, 0xb6, 0x28, 0xc9, 0x54, 0x22, 0x94, 0x4c, 0x25, 0x7a, 0x0a, 0x3a, 0x8b, 0x4d, 0x5b, 0x34,
+	0x24, 0x22, 0xc9, 0xd4, 0x5c, 0x0f, 0xc0, 0xaf, 0x61, 0x3e, 0x7e, 0x46, 0x7b, 0x67, 0x44, 0x2c,
+	0x99, 0x3a, 0xbc, 0x3c, 0xce, 0xc7, 0x59, 0xf9, 0xfd, 0xd0, 0x3e, 0xee, 0x9d, 0xd1, 0x59, 0x3d,
+	0xc1, 0xf2, 0x83, 0x41, 0xda, 0x4d, 0xbc, 0xb3, 0xe8, 0xff, 0x7b, 0xe3, 0x8f, 0x6b, 0xf0, 0x9b,
+	0x6b, 0xf8, 0x57, 0xd7, 0xdb, 0xc5, 0x73, 0x96, 0x5f, 0xdc, 0x8c, 0xef, 0xd6, 0x51, 0x7f, 0xbb,
+	0xfa, 0x0a, 0x00, 0x00, 0xff, 0xff, 0x58, 0xb1, 0x44, 0x3d, 0xc9, 0x01, 0x00, 0x00,
+}
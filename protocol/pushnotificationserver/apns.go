@@ -0,0 +1,253 @@
+package pushnotificationserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	apnsProductionURL  = "https://api.push.apple.com"
+	apnsDevelopmentURL = "https://api.sandbox.push.apple.com"
+	// apnsTokenLifetime is how long a provider token is used. APNs rejects
+	// tokens older than an hour and refreshed more often than every 20 minutes.
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+var errInvalidAPNsKey = errors.New("APNs key must be an ECDSA private key in PKCS #8 PEM format")
+
+// APNsConfig configures the delivery of the notifications of iOS devices
+// directly to APNs, authenticated with a provider token.
+type APNsConfig struct {
+	Enabled bool
+	// KeyFile is the path to the .p8 signing key downloaded from the Apple developer account
+	KeyFile string
+	// KeyID is the identifier of the signing key
+	KeyID string
+	// TeamID is the identifier of the Apple developer team
+	TeamID string
+	// Development sends the notifications to the sandbox environment
+	Development bool
+	// URL overrides the APNs server URL
+	URL string
+}
+
+type apnsAlert struct {
+	Body string `json:"body"`
+}
+
+type apnsAps struct {
+	Alert          apnsAlert `json:"alert"`
+	Sound          string    `json:"sound,omitempty"`
+	MutableContent int       `json:"mutable-content"`
+}
+
+// apnsPayload is the payload of an alert notification, the data of the
+// notification is sent as custom keys
+type apnsPayload struct {
+	Aps apnsAps `json:"aps"`
+	*GoRushRequestData
+}
+
+type apnsResponse struct {
+	Reason string `json:"reason"`
+}
+
+// apnsInvalidTokenReasons are the reasons of the rejection of a device token
+// which won't be accepted anymore
+var apnsInvalidTokenReasons = map[string]bool{
+	"BadDeviceToken": true,
+	"Unregistered":   true,
+}
+
+// apnsDispatcher sends notifications to APNs over HTTP/2, one request per device.
+type apnsDispatcher struct {
+	url    string
+	keyID  string
+	teamID string
+	key    *ecdsa.PrivateKey
+
+	client      *http.Client
+	retries     retryPolicy
+	concurrency int
+	logger      *zap.Logger
+
+	mu          sync.Mutex
+	token       string
+	tokenIssued time.Time
+}
+
+func newAPNsDispatcher(config APNsConfig, client *http.Client, logger *zap.Logger) (*apnsDispatcher, error) {
+	if len(config.KeyID) == 0 || len(config.TeamID) == 0 {
+		return nil, errors.New("APNs key id and team id are required")
+	}
+	data, err := ioutil.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parseAPNsKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	url := config.URL
+	if len(url) == 0 {
+		url = apnsProductionURL
+		if config.Development {
+			url = apnsDevelopmentURL
+		}
+	}
+	return &apnsDispatcher{
+		url:         url,
+		keyID:       config.KeyID,
+		teamID:      config.TeamID,
+		key:         key,
+		client:      client,
+		retries:     defaultRetryPolicy,
+		concurrency: dispatchConcurrency,
+		logger:      logger,
+	}, nil
+}
+
+func parseAPNsKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errInvalidAPNsKey
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errInvalidAPNsKey
+	}
+	return ecdsaKey, nil
+}
+
+func (d *apnsDispatcher) Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error) {
+	return dispatchEach(ctx, notifications, d.concurrency, func(ctx context.Context, notification *RequestAndRegistration) (bool, error) {
+		var invalid bool
+		err := d.retries.do(ctx, d.logger, func() error {
+			var err error
+			invalid, err = d.send(ctx, notification)
+			return err
+		})
+		return invalid, err
+	})
+}
+
+// send sends a notification and returns whether its device token was rejected
+func (d *apnsDispatcher) send(ctx context.Context, notification *RequestAndRegistration) (bool, error) {
+	payload, err := json.Marshal(&apnsPayload{
+		Aps: apnsAps{
+			Alert:          apnsAlert{Body: notificationText(notification.Request)},
+			Sound:          "default",
+			MutableContent: 1,
+		},
+		GoRushRequestData: notificationData(notification.Request),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	token, err := d.providerToken(time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, d.url+"/3/device/"+notification.Registration.DeviceToken, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Authorization", "bearer "+token)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("apns-push-type", "alert")
+	request.Header.Set("apns-priority", "10")
+	if len(notification.Registration.ApnTopic) > 0 {
+		request.Header.Set("apns-topic", notification.Registration.ApnTopic)
+	}
+
+	response, err := d.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return false, &retryableError{err: err}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusOK {
+		return false, nil
+	}
+
+	apnsResponse := &apnsResponse{}
+	body, _ := ioutil.ReadAll(response.Body)
+	_ = json.Unmarshal(body, apnsResponse)
+	err = fmt.Errorf("APNs responded with status %d: %s", response.StatusCode, apnsResponse.Reason)
+
+	switch {
+	case response.StatusCode == http.StatusGone || apnsInvalidTokenReasons[apnsResponse.Reason]:
+		d.logger.Debug("APNs rejected device token", zap.String("reason", apnsResponse.Reason))
+		return true, nil
+	case apnsResponse.Reason == "ExpiredProviderToken":
+		d.resetProviderToken()
+		return false, &retryableError{err: err}
+	case retryableStatus(response.StatusCode):
+		return false, &retryableError{err: err, after: retryAfter(response)}
+	}
+	return false, err
+}
+
+// providerToken returns the JWT authenticating the requests, a new one is
+// signed once the current one is too old
+func (d *apnsDispatcher) providerToken(now time.Time) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.token) > 0 && now.Sub(d.tokenIssued) < apnsTokenLifetime {
+		return d.token, nil
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "kid": d.keyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{"iss": d.teamID, "iat": now.Unix()})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, d.key, hash[:])
+	if err != nil {
+		return "", err
+	}
+	// ES256 signatures are the 32 bytes of r followed by the 32 bytes of s
+	signature := make([]byte, 64)
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	copy(signature[32-len(rBytes):32], rBytes)
+	copy(signature[64-len(sBytes):], sBytes)
+
+	d.token = signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	d.tokenIssued = now
+	return d.token, nil
+}
+
+func (d *apnsDispatcher) resetProviderToken() {
+	d.mu.Lock()
+	d.token = ""
+	d.mu.Unlock()
+}
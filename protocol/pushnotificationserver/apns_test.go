@@ -0,0 +1,150 @@
+package pushnotificationserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/tt"
+)
+
+// verifyES256 checks the signature of a JWT signed with ES256
+func verifyES256(t *testing.T, key *ecdsa.PublicKey, token string) map[string]interface{} {
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	require.True(t, ecdsa.Verify(key, hash[:], r, s))
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"alg":"ES256","kid":"key-id"}`, string(header))
+
+	var claims map[string]interface{}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &claims))
+	return claims
+}
+
+func writeAPNsKey(t *testing.T, dir string) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "AuthKey.p8")
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return key, path
+}
+
+func TestAPNsDispatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apns")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+	key, keyFile := writeAPNsKey(t, dir)
+
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, 2, r.ProtoMajor)
+		require.Equal(t, "im.status.ethereum", r.Header.Get("apns-topic"))
+		require.Equal(t, "alert", r.Header.Get("apns-push-type"))
+		claims := verifyES256(t, &key.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "bearer "))
+		require.Equal(t, "team-id", claims["iss"])
+
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Equal(t, map[string]interface{}{"body": defaultNewMessageNotificationText}, payload["aps"].(map[string]interface{})["alert"])
+		require.Equal(t, "0x6d657373616765", payload["encryptedMessage"])
+
+		token := strings.TrimPrefix(r.URL.Path, "/3/device/")
+		mu.Lock()
+		attempts[token]++
+		attempt := attempts[token]
+		mu.Unlock()
+
+		switch {
+		case token == "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		case token == "gone":
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered","timestamp":1600000000000}`))
+		case token == "busy" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"reason":"ServiceUnavailable"}`))
+		case token == "expired" && attempt == 1:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason":"ExpiredProviderToken"}`))
+		case token == "topic":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"TopicDisallowed"}`))
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	dispatcher, err := newAPNsDispatcher(APNsConfig{
+		Enabled: true,
+		KeyFile: keyFile,
+		KeyID:   "key-id",
+		TeamID:  "team-id",
+		URL:     server.URL,
+	}, server.Client(), tt.MustCreateTestLogger())
+	require.NoError(t, err)
+	dispatcher.retries = testRetryPolicy
+
+	var notifications []*RequestAndRegistration
+	for _, token := range []string{"good", "bad", "gone", "busy", "expired"} {
+		notifications = append(notifications, testNotification(token, protobuf.PushNotificationRegistration_APN_TOKEN))
+	}
+	invalid, err := dispatcher.Dispatch(context.Background(), notifications)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*RequestAndRegistration{notifications[1], notifications[2]}, invalid)
+	require.Equal(t, map[string]int{"good": 1, "bad": 1, "gone": 1, "busy": 2, "expired": 2}, attempts)
+
+	_, err = dispatcher.Dispatch(context.Background(), []*RequestAndRegistration{
+		testNotification("topic", protobuf.PushNotificationRegistration_APN_TOKEN),
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts["topic"])
+}
+
+func TestNewAPNsDispatcherInvalidKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apns")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	keyFile := filepath.Join(dir, "AuthKey.p8")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("not a key"), 0600))
+	_, err = newAPNsDispatcher(APNsConfig{KeyFile: keyFile, KeyID: "key-id", TeamID: "team-id"}, http.DefaultClient, tt.MustCreateTestLogger())
+	require.Equal(t, errInvalidAPNsKey, err)
+}
@@ -0,0 +1,205 @@
+package pushnotificationserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+const (
+	// dispatchTimeout is how long the notifications of a request are tried to be delivered
+	dispatchTimeout = 30 * time.Second
+	// dispatchRequestTimeout is the timeout of a single HTTP request to a push service
+	dispatchRequestTimeout = 10 * time.Second
+	// dispatchConcurrency is the number of notifications sent at once to the
+	// services accepting a single device per request
+	dispatchConcurrency = 20
+	// maxRetryDelay caps the delay asked by a push service before retrying
+	maxRetryDelay = 10 * time.Second
+)
+
+// Dispatcher delivers push notifications to devices.
+type Dispatcher interface {
+	// Dispatch sends the notifications and returns the ones whose device token
+	// was rejected by the push service, so that their registrations can be removed.
+	Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error)
+}
+
+// newDispatcher returns the dispatcher of the config. The notifications are
+// forwarded to gorush unless APNs or FCM are configured for their token type.
+func newDispatcher(config *Config, client *http.Client) (Dispatcher, error) {
+	gorush := newGorushDispatcher(config.GorushURL, client, config.Logger)
+	if !config.APNs.Enabled && !config.FCM.Enabled {
+		return gorush, nil
+	}
+
+	dispatcher := &platformDispatcher{
+		dispatchers: make(map[protobuf.PushNotificationRegistration_TokenType]Dispatcher),
+		fallback:    gorush,
+	}
+	if config.APNs.Enabled {
+		apns, err := newAPNsDispatcher(config.APNs, client, config.Logger)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.dispatchers[protobuf.PushNotificationRegistration_APN_TOKEN] = apns
+	}
+	if config.FCM.Enabled {
+		fcm, err := newFCMDispatcher(config.FCM, client, config.Logger)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.dispatchers[protobuf.PushNotificationRegistration_FIREBASE_TOKEN] = fcm
+	}
+	return dispatcher, nil
+}
+
+// platformDispatcher sends the notifications of each token type with its own
+// dispatcher, and the others with the fallback one.
+type platformDispatcher struct {
+	dispatchers map[protobuf.PushNotificationRegistration_TokenType]Dispatcher
+	fallback    Dispatcher
+}
+
+func (d *platformDispatcher) Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error) {
+	groups := make(map[Dispatcher][]*RequestAndRegistration)
+	var order []Dispatcher
+	for _, notification := range notifications {
+		dispatcher, ok := d.dispatchers[notification.Registration.TokenType]
+		if !ok {
+			dispatcher = d.fallback
+		}
+		if _, ok := groups[dispatcher]; !ok {
+			order = append(order, dispatcher)
+		}
+		groups[dispatcher] = append(groups[dispatcher], notification)
+	}
+
+	var (
+		invalid []*RequestAndRegistration
+		lastErr error
+	)
+	for _, dispatcher := range order {
+		rejected, err := dispatcher.Dispatch(ctx, groups[dispatcher])
+		invalid = append(invalid, rejected...)
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return invalid, lastErr
+}
+
+// dispatchEach sends the notifications one by one with send, concurrency of
+// them at once. It returns the notifications send reported as invalid.
+func dispatchEach(ctx context.Context, notifications []*RequestAndRegistration, concurrency int, send func(context.Context, *RequestAndRegistration) (bool, error)) ([]*RequestAndRegistration, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		invalid  []*RequestAndRegistration
+		failed   int
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, notification := range notifications {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(notification *RequestAndRegistration) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			rejected, err := send(ctx, notification)
+			mu.Lock()
+			defer mu.Unlock()
+			if rejected {
+				invalid = append(invalid, notification)
+			}
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}(notification)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return invalid, fmt.Errorf("failed to send %d of %d notifications: %v", failed, len(notifications), firstErr)
+	}
+	return invalid, nil
+}
+
+// retryableError is a failure of a push service after which the request is sent again.
+type retryableError struct {
+	err error
+	// after is the delay asked by the service, if any
+	after time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// retryableStatus returns whether a request may succeed later given the
+// status code of its response
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfter parses the Retry-After header of a response given in seconds
+func retryAfter(response *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// retryPolicy sends requests again after retryable errors, doubling the delay
+// between the attempts.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{attempts: 3, backoff: time.Second}
+
+func (p retryPolicy) do(ctx context.Context, logger *zap.Logger, send func() error) error {
+	backoff := p.backoff
+	for attempt := 1; ; attempt++ {
+		err := send()
+		retryable, ok := err.(*retryableError)
+		if !ok {
+			return err
+		}
+		if attempt >= p.attempts {
+			return retryable.err
+		}
+
+		delay := backoff
+		if retryable.after > 0 {
+			delay = retryable.after
+		}
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		logger.Debug("retrying push notification request", zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(retryable.err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
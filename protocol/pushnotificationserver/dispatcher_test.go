@@ -0,0 +1,125 @@
+package pushnotificationserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/tt"
+)
+
+var testRetryPolicy = retryPolicy{attempts: 3, backoff: time.Millisecond}
+
+func testNotification(token string, tokenType protobuf.PushNotificationRegistration_TokenType) *RequestAndRegistration {
+	return &RequestAndRegistration{
+		Request: &protobuf.PushNotification{
+			ChatId:         []byte("chat-id"),
+			Type:           protobuf.PushNotification_MESSAGE,
+			PublicKey:      []byte("public-key"),
+			InstallationId: "installation-" + token,
+			Message:        []byte("message"),
+		},
+		Registration: &protobuf.PushNotificationRegistration{
+			DeviceToken:    token,
+			TokenType:      tokenType,
+			ApnTopic:       "im.status.ethereum",
+			InstallationId: "installation-" + token,
+			Version:        1,
+		},
+	}
+}
+
+// testDispatcher records the notifications and rejects the tokens in invalid
+type testDispatcher struct {
+	notifications []*RequestAndRegistration
+	invalid       map[string]bool
+	err           error
+}
+
+func (d *testDispatcher) Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error) {
+	d.notifications = append(d.notifications, notifications...)
+	var invalid []*RequestAndRegistration
+	for _, notification := range notifications {
+		if d.invalid[notification.Registration.DeviceToken] {
+			invalid = append(invalid, notification)
+		}
+	}
+	return invalid, d.err
+}
+
+func TestRetryPolicy(t *testing.T) {
+	logger := tt.MustCreateTestLogger()
+	errFailed := errors.New("failed")
+
+	attempts := 0
+	err := testRetryPolicy.do(context.Background(), logger, func() error {
+		attempts++
+		if attempts < 3 {
+			return &retryableError{err: errFailed}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = testRetryPolicy.do(context.Background(), logger, func() error {
+		attempts++
+		return &retryableError{err: errFailed}
+	})
+	require.Equal(t, errFailed, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = testRetryPolicy.do(context.Background(), logger, func() error {
+		attempts++
+		return errFailed
+	})
+	require.Equal(t, errFailed, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestPlatformDispatcher(t *testing.T) {
+	apns := &testDispatcher{invalid: map[string]bool{"apn-2": true}}
+	fallback := &testDispatcher{err: errors.New("failed")}
+	dispatcher := &platformDispatcher{
+		dispatchers: map[protobuf.PushNotificationRegistration_TokenType]Dispatcher{
+			protobuf.PushNotificationRegistration_APN_TOKEN: apns,
+		},
+		fallback: fallback,
+	}
+
+	notifications := []*RequestAndRegistration{
+		testNotification("apn-1", protobuf.PushNotificationRegistration_APN_TOKEN),
+		testNotification("firebase-1", protobuf.PushNotificationRegistration_FIREBASE_TOKEN),
+		testNotification("apn-2", protobuf.PushNotificationRegistration_APN_TOKEN),
+	}
+	invalid, err := dispatcher.Dispatch(context.Background(), notifications)
+	require.Error(t, err)
+	require.Equal(t, []*RequestAndRegistration{notifications[2]}, invalid)
+	require.Equal(t, []*RequestAndRegistration{notifications[0], notifications[2]}, apns.notifications)
+	require.Equal(t, []*RequestAndRegistration{notifications[1]}, fallback.notifications)
+}
+
+func TestDispatchEach(t *testing.T) {
+	notifications := []*RequestAndRegistration{
+		testNotification("1", protobuf.PushNotificationRegistration_APN_TOKEN),
+		testNotification("2", protobuf.PushNotificationRegistration_APN_TOKEN),
+		testNotification("3", protobuf.PushNotificationRegistration_APN_TOKEN),
+	}
+	invalid, err := dispatchEach(context.Background(), notifications, 2, func(ctx context.Context, notification *RequestAndRegistration) (bool, error) {
+		switch notification.Registration.DeviceToken {
+		case "2":
+			return true, nil
+		case "3":
+			return false, errors.New("failed")
+		}
+		return false, nil
+	})
+	require.EqualError(t, err, "failed to send 1 of 3 notifications: failed")
+	require.Equal(t, []*RequestAndRegistration{notifications[1]}, invalid)
+}
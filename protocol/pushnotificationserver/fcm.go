@@ -0,0 +1,337 @@
+package pushnotificationserver
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	fcmURL = "https://fcm.googleapis.com"
+	// fcmScope is the OAuth 2.0 scope of the access tokens sending messages
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+	// fcmAssertionLifetime is how long the assertion exchanged for an access token is valid
+	fcmAssertionLifetime = time.Hour
+	// fcmTokenExpiryMargin renews an access token before it expires
+	fcmTokenExpiryMargin = time.Minute
+)
+
+var errInvalidFCMKey = errors.New("FCM service account key must be an RSA private key in PEM format")
+
+// errFCMSenderIDMismatch is a misconfiguration of the server, the registration
+// tokens are valid but were issued for the app of another Firebase project
+var errFCMSenderIDMismatch = errors.New("FCM service account key doesn't belong to the Firebase project of the registration tokens")
+
+// FCMConfig configures the delivery of the notifications of Android devices
+// directly to the Firebase Cloud Messaging HTTP v1 API.
+type FCMConfig struct {
+	Enabled bool
+	// CredentialsFile is the path to the JSON key of a service account of the Firebase project
+	CredentialsFile string
+	// URL overrides the FCM server URL
+	URL string
+}
+
+// fcmCredentials are the fields of the JSON key of a service account used
+// to get access tokens
+type fcmCredentials struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type fcmNotification struct {
+	Body string `json:"body"`
+}
+
+type fcmAndroidConfig struct {
+	Priority string `json:"priority"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data"`
+	Android      fcmAndroidConfig  `json:"android"`
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmErrorDetail struct {
+	Type      string `json:"@type"`
+	ErrorCode string `json:"errorCode"`
+}
+
+type fcmResponse struct {
+	Error struct {
+		Code    int              `json:"code"`
+		Message string           `json:"message"`
+		Status  string           `json:"status"`
+		Details []fcmErrorDetail `json:"details"`
+	} `json:"error"`
+}
+
+type fcmTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+const (
+	// fcmUnregistered is the error code of a registration token which won't be accepted anymore
+	fcmUnregistered = "UNREGISTERED"
+	// fcmSenderIDMismatch is the error code of a registration token of another Firebase project
+	fcmSenderIDMismatch = "SENDER_ID_MISMATCH"
+)
+
+// fcmDispatcher sends notifications with the FCM HTTP v1 API, one request per device.
+type fcmDispatcher struct {
+	url         string
+	credentials fcmCredentials
+	key         *rsa.PrivateKey
+
+	client      *http.Client
+	retries     retryPolicy
+	concurrency int
+	logger      *zap.Logger
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func newFCMDispatcher(config FCMConfig, client *http.Client, logger *zap.Logger) (*fcmDispatcher, error) {
+	data, err := ioutil.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	var credentials fcmCredentials
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, err
+	}
+	if len(credentials.ProjectID) == 0 || len(credentials.ClientEmail) == 0 || len(credentials.TokenURI) == 0 {
+		return nil, errors.New("FCM service account key is missing the project id, client email or token uri")
+	}
+	key, err := parseFCMKey([]byte(credentials.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	url := config.URL
+	if len(url) == 0 {
+		url = fcmURL
+	}
+	return &fcmDispatcher{
+		url:         url,
+		credentials: credentials,
+		key:         key,
+		client:      client,
+		retries:     defaultRetryPolicy,
+		concurrency: dispatchConcurrency,
+		logger:      logger,
+	}, nil
+}
+
+func parseFCMKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errInvalidFCMKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errInvalidFCMKey
+	}
+	return rsaKey, nil
+}
+
+func (d *fcmDispatcher) Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error) {
+	return dispatchEach(ctx, notifications, d.concurrency, func(ctx context.Context, notification *RequestAndRegistration) (bool, error) {
+		var invalid bool
+		err := d.retries.do(ctx, d.logger, func() error {
+			var err error
+			invalid, err = d.send(ctx, notification)
+			return err
+		})
+		return invalid, err
+	})
+}
+
+// send sends a notification and returns whether its registration token was rejected
+func (d *fcmDispatcher) send(ctx context.Context, notification *RequestAndRegistration) (bool, error) {
+	data := notificationData(notification.Request)
+	payload, err := json.Marshal(&fcmRequest{
+		Message: fcmMessage{
+			Token:        notification.Registration.DeviceToken,
+			Notification: fcmNotification{Body: notificationText(notification.Request)},
+			Data: map[string]string{
+				"encryptedMessage": data.EncryptedMessage,
+				"chatId":           data.ChatID,
+				"publicKey":        data.PublicKey,
+			},
+			Android: fcmAndroidConfig{Priority: "high"},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	token, err := d.accessToken(ctx, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, d.url+"/v1/projects/"+d.credentials.ProjectID+"/messages:send", bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := d.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return false, &retryableError{err: err}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusOK {
+		return false, nil
+	}
+
+	fcmResponse := &fcmResponse{}
+	body, _ := ioutil.ReadAll(response.Body)
+	_ = json.Unmarshal(body, fcmResponse)
+	err = fmt.Errorf("FCM responded with status %d: %s", response.StatusCode, fcmResponse.Error.Message)
+
+	for _, detail := range fcmResponse.Error.Details {
+		switch detail.ErrorCode {
+		case fcmUnregistered:
+			d.logger.Debug("FCM rejected registration token", zap.String("error", detail.ErrorCode))
+			return true, nil
+		case fcmSenderIDMismatch:
+			// the registration is kept, the token is valid once the server is configured
+			// with the key of the right project
+			d.logger.Error("FCM rejected registration token of another project", zap.String("project-id", d.credentials.ProjectID))
+			return false, errFCMSenderIDMismatch
+		}
+	}
+	switch {
+	case response.StatusCode == http.StatusUnauthorized:
+		d.resetAccessToken()
+		return false, &retryableError{err: err}
+	case retryableStatus(response.StatusCode):
+		return false, &retryableError{err: err, after: retryAfter(response)}
+	}
+	return false, err
+}
+
+// accessToken returns the OAuth 2.0 access token authenticating the requests,
+// a new one is requested with a signed assertion once it expires
+func (d *fcmDispatcher) accessToken(ctx context.Context, now time.Time) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.token) > 0 && now.Before(d.tokenExpiry) {
+		return d.token, nil
+	}
+
+	assertion, err := d.assertion(now)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	request, err := http.NewRequest(http.MethodPost, d.credentials.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := d.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return "", &retryableError{err: err}
+	}
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		err := fmt.Errorf("failed to get an FCM access token, status %d: %s", response.StatusCode, string(body))
+		if retryableStatus(response.StatusCode) {
+			return "", &retryableError{err: err, after: retryAfter(response)}
+		}
+		return "", err
+	}
+
+	tokenResponse := &fcmTokenResponse{}
+	if err := json.Unmarshal(body, tokenResponse); err != nil {
+		return "", err
+	}
+	if len(tokenResponse.AccessToken) == 0 {
+		return "", errors.New("empty FCM access token")
+	}
+
+	d.token = tokenResponse.AccessToken
+	d.tokenExpiry = now.Add(time.Duration(tokenResponse.ExpiresIn)*time.Second - fcmTokenExpiryMargin)
+	return d.token, nil
+}
+
+func (d *fcmDispatcher) resetAccessToken() {
+	d.mu.Lock()
+	d.token = ""
+	d.mu.Unlock()
+}
+
+// assertion returns the JWT signed by the service account which is exchanged
+// for an access token
+func (d *fcmDispatcher) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": d.credentials.PrivateKeyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   d.credentials.ClientEmail,
+		"scope": fcmScope,
+		"aud":   d.credentials.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(fcmAssertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, d.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
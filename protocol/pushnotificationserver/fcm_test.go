@@ -0,0 +1,183 @@
+package pushnotificationserver
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/tt"
+)
+
+// fcmTestServer stands in for the OAuth 2.0 token endpoint and the FCM API
+type fcmTestServer struct {
+	t   *testing.T
+	key *rsa.PublicKey
+
+	mu       sync.Mutex
+	tokens   int
+	attempts map[string]int
+	messages []fcmMessage
+}
+
+func (s *fcmTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		s.serveToken(w, r)
+		return
+	}
+
+	require.Equal(s.t, "/v1/projects/project-id/messages:send", r.URL.Path)
+	var request fcmRequest
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&request))
+	token := request.Message.Token
+	s.attempts[token]++
+	attempt := s.attempts[token]
+
+	// the access token of the first exchange is revoked for "revoked"
+	if r.Header.Get("Authorization") != fmt.Sprintf("Bearer access-%d", s.tokens) || (token == "revoked" && attempt == 1) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid credentials","status":"UNAUTHENTICATED"}}`))
+		return
+	}
+
+	switch {
+	case token == "unregistered":
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND","details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`))
+	case token == "mismatch":
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"SenderId mismatch","status":"PERMISSION_DENIED","details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"SENDER_ID_MISMATCH"}]}}`))
+	case token == "busy" && attempt == 1:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"code":503,"message":"unavailable","status":"UNAVAILABLE"}}`))
+	case token == "invalid":
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"invalid message","status":"INVALID_ARGUMENT"}}`))
+	default:
+		s.messages = append(s.messages, request.Message)
+		_, _ = w.Write([]byte(`{"name":"projects/project-id/messages/1"}`))
+	}
+}
+
+func (s *fcmTestServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	require.NoError(s.t, r.ParseForm())
+	require.Equal(s.t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+	parts := strings.Split(r.PostForm.Get("assertion"), ".")
+	require.Len(s.t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(s.t, err)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(s.t, rsa.VerifyPKCS1v15(s.key, crypto.SHA256, hash[:], signature))
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(s.t, err)
+	var claims map[string]interface{}
+	require.NoError(s.t, json.Unmarshal(data, &claims))
+	require.Equal(s.t, "sender@project-id.iam.gserviceaccount.com", claims["iss"])
+	require.Equal(s.t, fcmScope, claims["scope"])
+
+	s.tokens++
+	_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":3599,"token_type":"Bearer"}`, s.tokens)
+}
+
+func writeFCMCredentials(t *testing.T, dir string, tokenURI string) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "project-id",
+		"private_key_id": "key-id",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "sender@project-id.iam.gserviceaccount.com",
+		"token_uri":      tokenURI,
+	})
+	require.NoError(t, err)
+	path := filepath.Join(dir, "service-account.json")
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return key, path
+}
+
+func TestFCMDispatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fcm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	handler := &fcmTestServer{t: t, attempts: make(map[string]int)}
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	key, credentialsFile := writeFCMCredentials(t, dir, server.URL+"/token")
+	handler.key = &key.PublicKey
+
+	dispatcher, err := newFCMDispatcher(FCMConfig{
+		Enabled:         true,
+		CredentialsFile: credentialsFile,
+		URL:             server.URL,
+	}, server.Client(), tt.MustCreateTestLogger())
+	require.NoError(t, err)
+	dispatcher.retries = testRetryPolicy
+	// the retried request must use the renewed access token
+	dispatcher.concurrency = 1
+
+	var notifications []*RequestAndRegistration
+	for _, token := range []string{"good", "unregistered", "busy", "revoked"} {
+		notifications = append(notifications, testNotification(token, protobuf.PushNotificationRegistration_FIREBASE_TOKEN))
+	}
+	invalid, err := dispatcher.Dispatch(context.Background(), notifications)
+	require.NoError(t, err)
+	require.Equal(t, []*RequestAndRegistration{notifications[1]}, invalid)
+	require.Equal(t, map[string]int{"good": 1, "unregistered": 1, "busy": 2, "revoked": 2}, handler.attempts)
+	// the access token is renewed once it's rejected
+	require.Equal(t, 2, handler.tokens)
+
+	require.Len(t, handler.messages, 3)
+	message := handler.messages[0]
+	require.Equal(t, defaultNewMessageNotificationText, message.Notification.Body)
+	require.Equal(t, "high", message.Android.Priority)
+	require.Equal(t, map[string]string{
+		"encryptedMessage": "0x6d657373616765",
+		"chatId":           "0x636861742d6964",
+		"publicKey":        "0x7075626c69632d6b6579",
+	}, message.Data)
+
+	_, err = dispatcher.Dispatch(context.Background(), []*RequestAndRegistration{
+		testNotification("invalid", protobuf.PushNotificationRegistration_FIREBASE_TOKEN),
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, handler.attempts["invalid"])
+
+	// the registration of another project is kept, the server is misconfigured
+	invalid, err = dispatcher.Dispatch(context.Background(), []*RequestAndRegistration{
+		testNotification("mismatch", protobuf.PushNotificationRegistration_FIREBASE_TOKEN),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), errFCMSenderIDMismatch.Error())
+	require.Empty(t, invalid)
+	require.Equal(t, 1, handler.attempts["mismatch"])
+}
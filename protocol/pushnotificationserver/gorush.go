@@ -2,9 +2,12 @@ package pushnotificationserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -54,44 +57,176 @@ func PushNotificationRegistrationToGoRushRequest(requestAndRegistrations []*Requ
 	for _, requestAndRegistration := range requestAndRegistrations {
 		request := requestAndRegistration.Request
 		registration := requestAndRegistration.Registration
-		var text string
-		if request.Type == protobuf.PushNotification_MESSAGE {
-			text = defaultNewMessageNotificationText
-		} else if request.Type == protobuf.PushNotification_REQUEST_TO_JOIN_COMMUNITY {
-			text = defaultRequestToJoinCommunityNotificationText
-		} else {
-			text = defaultMentionNotificationText
-		}
 		goRushRequests.Notifications = append(goRushRequests.Notifications,
 			&GoRushRequestNotification{
 				Tokens:   []string{registration.DeviceToken},
 				Platform: tokenTypeToGoRushPlatform(registration.TokenType),
-				Message:  text,
+				Message:  notificationText(request),
 				Topic:    registration.ApnTopic,
-				Data: &GoRushRequestData{
-					EncryptedMessage: types.EncodeHex(request.Message),
-					ChatID:           types.EncodeHex(request.ChatId),
-					PublicKey:        types.EncodeHex(request.PublicKey),
-				},
+				Data:     notificationData(request),
 			})
 	}
 	return goRushRequests
 }
 
-func sendGoRushNotification(request *GoRushRequest, url string, logger *zap.Logger) error {
+// notificationText is the text displayed for a notification, its content is
+// encrypted for the device
+func notificationText(request *protobuf.PushNotification) string {
+	switch request.Type {
+	case protobuf.PushNotification_MESSAGE:
+		return defaultNewMessageNotificationText
+	case protobuf.PushNotification_REQUEST_TO_JOIN_COMMUNITY:
+		return defaultRequestToJoinCommunityNotificationText
+	}
+	return defaultMentionNotificationText
+}
+
+// notificationData is the custom data of a notification, the same for every push service
+func notificationData(request *protobuf.PushNotification) *GoRushRequestData {
+	return &GoRushRequestData{
+		EncryptedMessage: types.EncodeHex(request.Message),
+		ChatID:           types.EncodeHex(request.ChatId),
+		PublicKey:        types.EncodeHex(request.PublicKey),
+	}
+}
+
+type GoRushResponseLog struct {
+	Type     string `json:"type"`
+	Platform string `json:"platform"`
+	Token    string `json:"token"`
+	Message  string `json:"message"`
+	Error    string `json:"error"`
+}
+
+// GoRushResponse is the response to a push request, the failed notifications
+// are only logged when gorush runs in sync mode.
+type GoRushResponse struct {
+	Counts  int                  `json:"counts"`
+	Logs    []*GoRushResponseLog `json:"logs"`
+	Success string               `json:"success"`
+}
+
+// goRushInvalidTokenErrors are the errors of APNs and FCM relayed by gorush
+// for device tokens which are not valid anymore
+var goRushInvalidTokenErrors = []string{
+	"baddevicetoken",
+	"unregistered",
+	"notregistered",
+	"not-registered",
+	"invalidregistration",
+}
+
+func isGoRushInvalidTokenError(err string) bool {
+	err = strings.ToLower(err)
+	for _, invalid := range goRushInvalidTokenErrors {
+		if strings.Contains(err, invalid) {
+			return true
+		}
+	}
+	return false
+}
+
+// gorushBatchSize is the maximum number of notifications sent in a request,
+// the default limit of gorush
+const gorushBatchSize = 100
+
+// gorushDispatcher forwards the notifications to a gorush server.
+type gorushDispatcher struct {
+	url       string
+	client    *http.Client
+	retries   retryPolicy
+	batchSize int
+	logger    *zap.Logger
+}
+
+func newGorushDispatcher(url string, client *http.Client, logger *zap.Logger) *gorushDispatcher {
+	return &gorushDispatcher{
+		url:       url,
+		client:    client,
+		retries:   defaultRetryPolicy,
+		batchSize: gorushBatchSize,
+		logger:    logger,
+	}
+}
+
+func (d *gorushDispatcher) Dispatch(ctx context.Context, notifications []*RequestAndRegistration) ([]*RequestAndRegistration, error) {
+	var (
+		invalid []*RequestAndRegistration
+		lastErr error
+	)
+	for start := 0; start < len(notifications); start += d.batchSize {
+		end := start + d.batchSize
+		if end > len(notifications) {
+			end = len(notifications)
+		}
+		batch := notifications[start:end]
+
+		var response *GoRushResponse
+		err := d.retries.do(ctx, d.logger, func() error {
+			var err error
+			response, err = d.send(ctx, PushNotificationRegistrationToGoRushRequest(batch))
+			return err
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		invalid = append(invalid, goRushInvalidNotifications(batch, response)...)
+	}
+	return invalid, lastErr
+}
+
+func (d *gorushDispatcher) send(ctx context.Context, request *GoRushRequest) (*GoRushResponse, error) {
 	payload, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	response, err := http.Post(url+"/api/push", "application/json", bytes.NewReader(payload))
+	httpRequest, err := http.NewRequest(http.MethodPost, d.url+"/api/push", bytes.NewReader(payload))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response, err := d.client.Do(httpRequest.WithContext(ctx))
+	if err != nil {
+		return nil, &retryableError{err: err}
 	}
 	defer response.Body.Close()
 	body, _ := ioutil.ReadAll(response.Body)
 
-	logger.Info("Sent gorush request", zap.String("response", string(body)))
+	d.logger.Info("Sent gorush request", zap.String("response", string(body)))
 
-	return nil
+	if response.StatusCode != http.StatusOK {
+		err := fmt.Errorf("gorush responded with status %d", response.StatusCode)
+		if retryableStatus(response.StatusCode) {
+			return nil, &retryableError{err: err, after: retryAfter(response)}
+		}
+		return nil, err
+	}
+
+	goRushResponse := &GoRushResponse{}
+	if err := json.Unmarshal(body, goRushResponse); err != nil {
+		d.logger.Warn("failed to parse gorush response", zap.Error(err))
+	}
+	return goRushResponse, nil
+}
+
+// goRushInvalidNotifications returns the notifications whose token is logged
+// as invalid in the response
+func goRushInvalidNotifications(notifications []*RequestAndRegistration, response *GoRushResponse) []*RequestAndRegistration {
+	invalidTokens := make(map[string]bool)
+	for _, log := range response.Logs {
+		if log.Type == "failed-push" && isGoRushInvalidTokenError(log.Error) {
+			invalidTokens[log.Token] = true
+		}
+	}
+
+	var invalid []*RequestAndRegistration
+	for _, notification := range notifications {
+		if invalidTokens[notification.Registration.DeviceToken] {
+			invalid = append(invalid, notification)
+		}
+	}
+	return invalid
 }
@@ -1,12 +1,18 @@
 package pushnotificationserver
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/protocol/protobuf"
+	"github.com/planq-network/status-go/protocol/tt"
 )
 
 func TestPushNotificationRegistrationToGoRushRequest(t *testing.T) {
@@ -108,3 +114,71 @@ func TestPushNotificationRegistrationToGoRushRequest(t *testing.T) {
 	actualRequests := PushNotificationRegistrationToGoRushRequest(requestAndRegistrations)
 	require.Equal(t, expectedRequests, actualRequests)
 }
+
+func TestGorushDispatcher(t *testing.T) {
+	var (
+		requests int32
+		batches  [][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/push", r.URL.Path)
+		// the first attempt fails
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var request GoRushRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		var tokens []string
+		response := &GoRushResponse{Counts: len(request.Notifications), Success: "ok"}
+		for _, notification := range request.Notifications {
+			tokens = append(tokens, notification.Tokens...)
+			if notification.Tokens[0] == "token-2" {
+				response.Logs = append(response.Logs, &GoRushResponseLog{
+					Type:     "failed-push",
+					Platform: "ios",
+					Token:    notification.Tokens[0],
+					Error:    "Unregistered",
+				})
+			}
+		}
+		batches = append(batches, tokens)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	dispatcher := newGorushDispatcher(server.URL, server.Client(), tt.MustCreateTestLogger())
+	dispatcher.retries = testRetryPolicy
+	dispatcher.batchSize = 2
+
+	notifications := []*RequestAndRegistration{
+		testNotification("token-1", protobuf.PushNotificationRegistration_APN_TOKEN),
+		testNotification("token-2", protobuf.PushNotificationRegistration_APN_TOKEN),
+		testNotification("token-3", protobuf.PushNotificationRegistration_FIREBASE_TOKEN),
+	}
+	invalid, err := dispatcher.Dispatch(context.Background(), notifications)
+	require.NoError(t, err)
+	require.Equal(t, []*RequestAndRegistration{notifications[1]}, invalid)
+	require.Equal(t, [][]string{{"token-1", "token-2"}, {"token-3"}}, batches)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestGorushDispatcherFailure(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	dispatcher := newGorushDispatcher(server.URL, server.Client(), tt.MustCreateTestLogger())
+	dispatcher.retries = testRetryPolicy
+
+	_, err := dispatcher.Dispatch(context.Background(), []*RequestAndRegistration{
+		testNotification("token-1", protobuf.PushNotificationRegistration_APN_TOKEN),
+	})
+	require.Error(t, err)
+	// client errors are not retried
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -24,6 +24,8 @@ type Persistence interface {
 	GetPushNotificationRegistrationVersion(publicKey []byte, installationID string) (uint64, error)
 	// UnregisterPushNotificationRegistration unregister a given pk/installationID
 	UnregisterPushNotificationRegistration(publicKey []byte, installationID string, version uint64) error
+	// InvalidatePushNotificationRegistration unregister a given pk/installationID unless it was updated since the given version
+	InvalidatePushNotificationRegistration(publicKey []byte, installationID string, version uint64) error
 
 	// DeletePushNotificationRegistration deletes a push notification registration from storage given a public key and installation id
 	DeletePushNotificationRegistration(publicKey []byte, installationID string) error
@@ -155,6 +157,11 @@ func (p *SQLitePersistence) UnregisterPushNotificationRegistration(publicKey []b
 	return err
 }
 
+func (p *SQLitePersistence) InvalidatePushNotificationRegistration(publicKey []byte, installationID string, version uint64) error {
+	_, err := p.db.Exec(`UPDATE push_notification_server_registrations SET registration = NULL WHERE public_key = ? AND installation_id = ? AND version = ?`, publicKey, installationID, version)
+	return err
+}
+
 func (p *SQLitePersistence) DeletePushNotificationRegistration(publicKey []byte, installationID string) error {
 	_, err := p.db.Exec(`DELETE FROM push_notification_server_registrations WHERE public_key = ? AND installation_id = ?`, publicKey, installationID)
 	return err
@@ -56,6 +56,34 @@ func (s *SQLitePersistenceSuite) TestSaveAndRetrieve() {
 	s.Require().True(proto.Equal(registration, retrievedRegistration))
 }
 
+func (s *SQLitePersistenceSuite) TestInvalidateRegistration() {
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	publicKey := common.HashPublicKey(&key.PublicKey)
+	installationID := "54242d02-bb92-11ea-b3de-0242ac130004"
+
+	registration := &protobuf.PushNotificationRegistration{
+		InstallationId: installationID,
+		Version:        5,
+	}
+	s.Require().NoError(s.persistence.SavePushNotificationRegistration(publicKey, registration))
+
+	// The registration was updated since version 4
+	s.Require().NoError(s.persistence.InvalidatePushNotificationRegistration(publicKey, installationID, 4))
+	retrievedRegistration, err := s.persistence.GetPushNotificationRegistrationByPublicKeyAndInstallationID(publicKey, installationID)
+	s.Require().NoError(err)
+	s.Require().True(proto.Equal(registration, retrievedRegistration))
+
+	s.Require().NoError(s.persistence.InvalidatePushNotificationRegistration(publicKey, installationID, 5))
+	retrievedRegistration, err = s.persistence.GetPushNotificationRegistrationByPublicKeyAndInstallationID(publicKey, installationID)
+	s.Require().NoError(err)
+	s.Require().Nil(retrievedRegistration)
+
+	version, err := s.persistence.GetPushNotificationRegistrationVersion(publicKey, installationID)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(5), version)
+}
+
 func (s *SQLitePersistenceSuite) TestSaveAndRetrieveIdentity() {
 	retrievedKey, err := s.persistence.GetIdentity()
 	s.Require().NoError(err)
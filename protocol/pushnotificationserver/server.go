@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"net/http"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
@@ -29,6 +30,10 @@ type Config struct {
 	Identity *ecdsa.PrivateKey
 	// GorushUrl is the url for the gorush service
 	GorushURL string
+	// APNs sends the notifications of iOS devices to APNs instead of gorush
+	APNs APNsConfig
+	// FCM sends the notifications of Android devices to FCM instead of gorush
+	FCM FCMConfig
 
 	Logger *zap.Logger
 }
@@ -37,7 +42,8 @@ type Server struct {
 	persistence   Persistence
 	config        *Config
 	messageSender *common.MessageSender
-	// SentRequests keeps track of the requests sent to gorush, for testing only
+	dispatcher    Dispatcher
+	// SentRequests keeps track of the requests dispatched, for testing only
 	SentRequests int64
 }
 
@@ -78,6 +84,12 @@ func (s *Server) Start() error {
 		s.config.Identity = identity
 	}
 
+	dispatcher, err := newDispatcher(s.config, &http.Client{Timeout: dispatchRequestTimeout})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the push notification dispatcher")
+	}
+	s.dispatcher = dispatcher
+
 	pks, err := s.persistence.GetPushNotificationRegistrationPublicKeys()
 	if err != nil {
 		return err
@@ -139,7 +151,7 @@ func (s *Server) HandlePushNotificationQuery(publicKey *ecdsa.PublicKey, message
 	return err
 }
 
-// HandlePushNotificationRequest will send a gorush notification and send a response back to the user
+// HandlePushNotificationRequest will send a push notification and send a response back to the user
 func (s *Server) HandlePushNotificationRequest(publicKey *ecdsa.PublicKey,
 	messageID []byte,
 	request protobuf.PushNotificationRequest) error {
@@ -163,7 +175,7 @@ func (s *Server) HandlePushNotificationRequest(publicKey *ecdsa.PublicKey,
 	}
 	err = s.sendPushNotification(requestsAndRegistrations)
 	if err != nil {
-		s.config.Logger.Error("failed to send go rush notification", zap.Error(err))
+		s.config.Logger.Error("failed to send push notification", zap.Error(err))
 		return err
 	}
 	encodedMessage, err := proto.Marshal(response)
@@ -453,8 +465,23 @@ func (s *Server) sendPushNotification(requestAndRegistrations []*RequestAndRegis
 		return nil
 	}
 	s.SentRequests++
-	goRushRequest := PushNotificationRegistrationToGoRushRequest(requestAndRegistrations)
-	return sendGoRushNotification(goRushRequest, s.config.GorushURL, s.config.Logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+	defer cancel()
+	invalid, err := s.dispatcher.Dispatch(ctx, requestAndRegistrations)
+
+	// the device tokens rejected by the push services won't be accepted
+	// again, the clients register new ones. A registration updated while
+	// the notifications were sent is kept.
+	for _, requestAndRegistration := range invalid {
+		registration := requestAndRegistration.Registration
+		s.config.Logger.Info("removing registration with an invalid device token", zap.String("installation-id", registration.InstallationId))
+		if err := s.persistence.InvalidatePushNotificationRegistration(requestAndRegistration.Request.PublicKey, registration.InstallationId, registration.Version); err != nil {
+			s.config.Logger.Error("failed to remove registration", zap.Error(err))
+		}
+	}
+
+	return err
 }
 
 // listenToPublicKeyQueryTopic listen to a topic derived from the hashed public key
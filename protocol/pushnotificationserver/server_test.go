@@ -877,3 +877,52 @@ func (s *ServerSuite) TestBuildPushNotificationReport() {
 		})
 	}
 }
+
+func (s *ServerSuite) TestSendPushNotificationRemovesInvalidTokens() {
+	chatID := []byte("chat-id")
+	registration := &protobuf.PushNotificationRegistration{
+		DeviceToken:    "abc",
+		AccessToken:    s.accessToken,
+		Grant:          s.grant,
+		TokenType:      protobuf.PushNotificationRegistration_APN_TOKEN,
+		InstallationId: s.installationID,
+		Version:        1,
+	}
+	payload, err := proto.Marshal(registration)
+	s.Require().NoError(err)
+
+	cyphertext, err := common.Encrypt(payload, s.sharedKey, rand.Reader)
+	s.Require().NoError(err)
+	response := s.server.buildPushNotificationRegistrationResponse(&s.key.PublicKey, cyphertext)
+	s.Require().NotNil(response)
+	s.Require().True(response.Success)
+
+	pushNotificationRequest := &protobuf.PushNotificationRequest{
+		MessageId: []byte("message-id"),
+		Requests: []*protobuf.PushNotification{
+			{
+				AccessToken:    s.accessToken,
+				PublicKey:      common.HashPublicKey(&s.key.PublicKey),
+				ChatId:         chatID,
+				InstallationId: s.installationID,
+				Type:           protobuf.PushNotification_MESSAGE,
+			},
+		},
+	}
+	_, requestAndRegistrations := s.server.buildPushNotificationRequestResponse(pushNotificationRequest)
+	s.Require().Len(requestAndRegistrations, 1)
+
+	dispatcher := &testDispatcher{invalid: map[string]bool{"abc": true}}
+	s.server.dispatcher = dispatcher
+	s.Require().NoError(s.server.sendPushNotification(requestAndRegistrations))
+	s.Require().Len(dispatcher.notifications, 1)
+
+	// the registration is removed but its version is kept
+	retrievedRegistration, err := s.persistence.GetPushNotificationRegistrationByPublicKeyAndInstallationID(common.HashPublicKey(&s.key.PublicKey), s.installationID)
+	s.Require().NoError(err)
+	s.Require().Nil(retrievedRegistration)
+
+	version, err := s.persistence.GetPushNotificationRegistrationVersion(common.HashPublicKey(&s.key.PublicKey), s.installationID)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(1), version)
+}
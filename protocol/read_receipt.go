@@ -0,0 +1,46 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// ReadReceipt lets the author of some messages know that we read them
+type ReadReceipt struct {
+	protobuf.ReadReceipt
+
+	// SigPubKey is the ecdsa encoded public key of the reader
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (r ReadReceipt) GetSigPubKey() *ecdsa.PublicKey {
+	return r.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (r ReadReceipt) GetProtobuf() proto.Message {
+	return &r.ReadReceipt
+}
+
+// GetGrant returns no grant, as read receipts are not sent in community chats
+// this function is required to implement the ChatEntity interface
+func (r ReadReceipt) GetGrant() []byte {
+	return nil
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (r *ReadReceipt) SetMessageType(messageType protobuf.MessageType) {
+	r.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (r ReadReceipt) WrapGroupMessage() bool {
+	return false
+}
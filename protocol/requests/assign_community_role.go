@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrAssignCommunityRoleInvalidCommunityID = errors.New("assign-community-role: invalid community id")
+var ErrAssignCommunityRoleInvalidRoleID = errors.New("assign-community-role: invalid role id")
+var ErrAssignCommunityRoleInvalidUser = errors.New("assign-community-role: invalid user id")
+
+// AssignCommunityRole assigns a role to a member of the community,
+// if ChatID is set the role only applies to that chat
+type AssignCommunityRole struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	RoleID      string         `json:"roleId"`
+	User        types.HexBytes `json:"user"`
+	ChatID      string         `json:"chatId"`
+}
+
+func (j *AssignCommunityRole) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrAssignCommunityRoleInvalidCommunityID
+	}
+
+	if len(j.RoleID) == 0 {
+		return ErrAssignCommunityRoleInvalidRoleID
+	}
+
+	if len(j.User) == 0 {
+		return ErrAssignCommunityRoleInvalidUser
+	}
+
+	return nil
+}
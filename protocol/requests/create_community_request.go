@@ -28,6 +28,8 @@ type CreateCommunity struct {
 	ImageAy     int                                  `json:"imageAy"`
 	ImageBx     int                                  `json:"imageBx"`
 	ImageBy     int                                  `json:"imageBy"`
+	// TokenRequirements are checked before accepting a request to join
+	TokenRequirements []*protobuf.TokenRequirement `json:"tokenRequirements"`
 }
 
 func adaptIdentityImageToProtobuf(img *userimages.IdentityImage) *protobuf.IdentityImage {
@@ -83,8 +85,9 @@ func (c *CreateCommunity) ToCommunityDescription() (*protobuf.CommunityDescripti
 	description := &protobuf.CommunityDescription{
 		Identity: ci,
 		Permissions: &protobuf.CommunityPermissions{
-			Access:  c.Membership,
-			EnsOnly: c.EnsOnly,
+			Access:            c.Membership,
+			EnsOnly:           c.EnsOnly,
+			TokenRequirements: c.TokenRequirements,
 		},
 	}
 	return description, nil
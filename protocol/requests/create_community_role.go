@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrCreateCommunityRoleInvalidCommunityID = errors.New("create-community-role: invalid community id")
+var ErrCreateCommunityRoleInvalidName = errors.New("create-community-role: invalid role name")
+
+type CreateCommunityRole struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	Name        string         `json:"name"`
+	Permissions uint64         `json:"permissions"`
+}
+
+func (j *CreateCommunityRole) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrCreateCommunityRoleInvalidCommunityID
+	}
+
+	if len(j.Name) == 0 {
+		return ErrCreateCommunityRoleInvalidName
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrCreatePollInvalidChatID = errors.New("create-poll: invalid chat id")
+var ErrCreatePollInvalidQuestion = errors.New("create-poll: invalid question")
+var ErrCreatePollInvalidOptions = errors.New("create-poll: at least two options are required")
+
+type CreatePoll struct {
+	ChatID   string   `json:"chatId"`
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+	// ExpiresAt is the unix timestamp in milliseconds after which votes are
+	// not accepted, 0 if the poll doesn't expire
+	ExpiresAt      uint64 `json:"expiresAt"`
+	MultipleChoice bool   `json:"multipleChoice"`
+}
+
+func (c *CreatePoll) Validate() error {
+	if len(c.ChatID) == 0 {
+		return ErrCreatePollInvalidChatID
+	}
+
+	if len(c.Question) == 0 {
+		return ErrCreatePollInvalidQuestion
+	}
+
+	if len(c.Options) < 2 {
+		return ErrCreatePollInvalidOptions
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrDeleteCommunityRoleInvalidCommunityID = errors.New("delete-community-role: invalid community id")
+var ErrDeleteCommunityRoleInvalidRoleID = errors.New("delete-community-role: invalid role id")
+
+type DeleteCommunityRole struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	RoleID      string         `json:"roleId"`
+}
+
+func (j *DeleteCommunityRole) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrDeleteCommunityRoleInvalidCommunityID
+	}
+
+	if len(j.RoleID) == 0 {
+		return ErrDeleteCommunityRoleInvalidRoleID
+	}
+
+	return nil
+}
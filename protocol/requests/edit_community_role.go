@@ -0,0 +1,34 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrEditCommunityRoleInvalidCommunityID = errors.New("edit-community-role: invalid community id")
+var ErrEditCommunityRoleInvalidRoleID = errors.New("edit-community-role: invalid role id")
+var ErrEditCommunityRoleInvalidName = errors.New("edit-community-role: invalid role name")
+
+type EditCommunityRole struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	RoleID      string         `json:"roleId"`
+	Name        string         `json:"name"`
+	Permissions uint64         `json:"permissions"`
+}
+
+func (j *EditCommunityRole) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrEditCommunityRoleInvalidCommunityID
+	}
+
+	if len(j.RoleID) == 0 {
+		return ErrEditCommunityRoleInvalidRoleID
+	}
+
+	if len(j.Name) == 0 {
+		return ErrEditCommunityRoleInvalidName
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrEditScheduledMessageInvalidID = errors.New("edit-scheduled-message: invalid id")
+var ErrEditScheduledMessageNoChanges = errors.New("edit-scheduled-message: nothing to edit")
+
+type EditScheduledMessage struct {
+	ID string `json:"id"`
+	// Text replaces the text of the message if not empty
+	Text string `json:"text"`
+	// ScheduledAt reschedules the message if not 0
+	ScheduledAt uint64 `json:"scheduledAt"`
+}
+
+func (e *EditScheduledMessage) Validate() error {
+	if len(e.ID) == 0 {
+		return ErrEditScheduledMessageInvalidID
+	}
+
+	if len(e.Text) == 0 && e.ScheduledAt == 0 {
+		return ErrEditScheduledMessageNoChanges
+	}
+
+	return nil
+}
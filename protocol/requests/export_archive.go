@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrExportArchiveInvalidPath = errors.New("export-archive: invalid path")
+var ErrExportArchiveInvalidPassword = errors.New("export-archive: invalid password")
+var ErrExportArchiveInvalidRange = errors.New("export-archive: invalid date range")
+
+type ExportArchive struct {
+	// Path is the path of the archive file that is written
+	Path     string `json:"path"`
+	Password string `json:"password"`
+	// From and To restrict the exported messages to the ones sent in the
+	// given interval, as unix timestamps in milliseconds, 0 means unbounded
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+func (e *ExportArchive) Validate() error {
+	if len(e.Path) == 0 {
+		return ErrExportArchiveInvalidPath
+	}
+
+	if len(e.Password) == 0 {
+		return ErrExportArchiveInvalidPassword
+	}
+
+	if e.To != 0 && e.From > e.To {
+		return ErrExportArchiveInvalidRange
+	}
+
+	return nil
+}
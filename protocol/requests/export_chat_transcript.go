@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/protocol/transcript"
+)
+
+var ErrExportChatTranscriptInvalidChatID = errors.New("export-chat-transcript: invalid chat id")
+var ErrExportChatTranscriptInvalidPath = errors.New("export-chat-transcript: invalid path")
+var ErrExportChatTranscriptInvalidFormat = errors.New("export-chat-transcript: invalid format")
+
+type ExportChatTranscript struct {
+	ChatID string `json:"chatId"`
+	// Path is the path of the transcript file, images and audio messages
+	// are written in a directory next to it
+	Path   string            `json:"path"`
+	Format transcript.Format `json:"format"`
+}
+
+func (e *ExportChatTranscript) Validate() error {
+	if len(e.ChatID) == 0 {
+		return ErrExportChatTranscriptInvalidChatID
+	}
+
+	if len(e.Path) == 0 {
+		return ErrExportChatTranscriptInvalidPath
+	}
+
+	if !e.Format.Valid() {
+		return ErrExportChatTranscriptInvalidFormat
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrImportArchiveInvalidPath = errors.New("import-archive: invalid path")
+var ErrImportArchiveInvalidPassword = errors.New("import-archive: invalid password")
+
+type ImportArchive struct {
+	// Path is the path of the archive file that is read
+	Path     string `json:"path"`
+	Password string `json:"password"`
+}
+
+func (i *ImportArchive) Validate() error {
+	if len(i.Path) == 0 {
+		return ErrImportArchiveInvalidPath
+	}
+
+	if len(i.Password) == 0 {
+		return ErrImportArchiveInvalidPassword
+	}
+
+	return nil
+}
@@ -7,10 +7,19 @@ import (
 )
 
 var ErrRequestToJoinCommunityInvalidCommunityID = errors.New("request-to-join-community: invalid community id")
+var ErrRequestToJoinCommunityInvalidAddressProof = errors.New("request-to-join-community: invalid address proof")
+
+// AddressOwnershipProof is the personal_sign signature by the address of the
+// message returned by communities.AddressOwnershipProofMessage
+type AddressOwnershipProof struct {
+	Address   types.Address  `json:"address"`
+	Signature types.HexBytes `json:"signature"`
+}
 
 type RequestToJoinCommunity struct {
-	CommunityID types.HexBytes `json:"communityId"`
-	ENSName     string         `json:"ensName"`
+	CommunityID   types.HexBytes           `json:"communityId"`
+	ENSName       string                   `json:"ensName"`
+	AddressProofs []*AddressOwnershipProof `json:"addressProofs"`
 }
 
 func (j *RequestToJoinCommunity) Validate() error {
@@ -18,5 +27,11 @@ func (j *RequestToJoinCommunity) Validate() error {
 		return ErrRequestToJoinCommunityInvalidCommunityID
 	}
 
+	for _, proof := range j.AddressProofs {
+		if proof == nil || len(proof.Signature) != 65 {
+			return ErrRequestToJoinCommunityInvalidAddressProof
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrRevokeCommunityRoleInvalidCommunityID = errors.New("revoke-community-role: invalid community id")
+var ErrRevokeCommunityRoleInvalidRoleID = errors.New("revoke-community-role: invalid role id")
+var ErrRevokeCommunityRoleInvalidUser = errors.New("revoke-community-role: invalid user id")
+
+// RevokeCommunityRole revokes a role from a member of the community,
+// if ChatID is set only the role scoped to that chat is revoked
+type RevokeCommunityRole struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	RoleID      string         `json:"roleId"`
+	User        types.HexBytes `json:"user"`
+	ChatID      string         `json:"chatId"`
+}
+
+func (j *RevokeCommunityRole) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrRevokeCommunityRoleInvalidCommunityID
+	}
+
+	if len(j.RoleID) == 0 {
+		return ErrRevokeCommunityRoleInvalidRoleID
+	}
+
+	if len(j.User) == 0 {
+		return ErrRevokeCommunityRoleInvalidUser
+	}
+
+	return nil
+}
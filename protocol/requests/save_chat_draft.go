@@ -0,0 +1,22 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrSaveChatDraftInvalidChatID = errors.New("save-chat-draft: invalid chat id")
+
+type SaveChatDraft struct {
+	ChatID string `json:"chatId"`
+	// Text is the text of the draft, an empty text clears it
+	Text       string `json:"text"`
+	ResponseTo string `json:"responseTo"`
+}
+
+func (s *SaveChatDraft) Validate() error {
+	if len(s.ChatID) == 0 {
+		return ErrSaveChatDraftInvalidChatID
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/protocol/common"
+)
+
+var ErrScheduleMessageInvalidMessage = errors.New("schedule-message: invalid message")
+var ErrScheduleMessageInvalidChatID = errors.New("schedule-message: invalid chat id")
+var ErrScheduleMessageInvalidScheduledAt = errors.New("schedule-message: invalid scheduled at")
+
+type ScheduleMessage struct {
+	Message *common.Message `json:"message"`
+	// ScheduledAt is the unix timestamp in milliseconds at which the message
+	// is sent
+	ScheduledAt uint64 `json:"scheduledAt"`
+}
+
+func (s *ScheduleMessage) Validate() error {
+	if s.Message == nil {
+		return ErrScheduleMessageInvalidMessage
+	}
+
+	if len(s.Message.ChatId) == 0 {
+		return ErrScheduleMessageInvalidChatID
+	}
+
+	if s.ScheduledAt == 0 {
+		return ErrScheduleMessageInvalidScheduledAt
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrSearchMessagesInvalidQuery = errors.New("search-messages: invalid query")
+var ErrSearchMessagesInvalidRange = errors.New("search-messages: invalid date range")
+
+type SearchMessages struct {
+	Query string `json:"query"`
+	// ChatIDs and CommunityIDs restrict the search to the given chats and
+	// to the chats of the given communities, all chats are searched if empty
+	ChatIDs      []string `json:"chatIds"`
+	CommunityIDs []string `json:"communityIds"`
+	// Senders restricts the search to the messages sent by the given public keys
+	Senders []string `json:"senders"`
+	// Since and Until restrict the search to the messages sent in the given
+	// interval, as unix timestamps in milliseconds, 0 means unbounded
+	Since uint64 `json:"since"`
+	Until uint64 `json:"until"`
+	// Prefix matches the words starting with the last term of the query
+	Prefix bool `json:"prefix"`
+	// Phrase matches the terms of the query as an exact phrase
+	Phrase bool   `json:"phrase"`
+	Cursor string `json:"cursor"`
+	// Limit is the number of results returned, defaults to 20
+	Limit int `json:"limit"`
+}
+
+func (s *SearchMessages) Validate() error {
+	if len(strings.TrimSpace(s.Query)) == 0 {
+		return ErrSearchMessagesInvalidQuery
+	}
+
+	if s.Until != 0 && s.Since > s.Until {
+		return ErrSearchMessagesInvalidRange
+	}
+
+	return nil
+}
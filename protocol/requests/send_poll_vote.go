@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrSendPollVoteInvalidID = errors.New("send-poll-vote: invalid id")
+var ErrSendPollVoteInvalidOptions = errors.New("send-poll-vote: no option voted")
+
+type SendPollVote struct {
+	// ID is the ID of the poll message
+	ID      types.HexBytes `json:"id"`
+	Options []uint32       `json:"options"`
+}
+
+func (s *SendPollVote) Validate() error {
+	if len(s.ID) == 0 {
+		return ErrSendPollVoteInvalidID
+	}
+
+	if len(s.Options) == 0 {
+		return ErrSendPollVoteInvalidOptions
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package requests
+
+import (
+	"errors"
+)
+
+// MaxChatMessagesTTL is the longest time in seconds messages can be kept
+// before being deleted
+const MaxChatMessagesTTL = 4 * 7 * 24 * 60 * 60
+
+var ErrSetChatMessagesTTLInvalidChatID = errors.New("set-chat-messages-ttl: invalid chat id")
+var ErrSetChatMessagesTTLInvalidTTL = errors.New("set-chat-messages-ttl: ttl is too long")
+
+type SetChatMessagesTTL struct {
+	ChatID string `json:"chatId"`
+	// TTL is the number of seconds after which messages are deleted,
+	// 0 disables disappearing messages
+	TTL uint64 `json:"ttl"`
+}
+
+func (s *SetChatMessagesTTL) Validate() error {
+	if len(s.ChatID) == 0 {
+		return ErrSetChatMessagesTTLInvalidChatID
+	}
+
+	if s.TTL > MaxChatMessagesTTL {
+		return ErrSetChatMessagesTTLInvalidTTL
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"errors"
+)
+
+var ErrSetChatPrivacySettingsInvalidChatID = errors.New("set-chat-privacy-settings: invalid chat id")
+var ErrSetChatPrivacySettingsInvalidSetting = errors.New("set-chat-privacy-settings: invalid setting")
+
+// Chat privacy settings, a chat either follows the setting of the account or
+// overrides it
+const (
+	ChatPrivacySettingDefault = iota
+	ChatPrivacySettingEnabled
+	ChatPrivacySettingDisabled
+)
+
+type SetChatPrivacySettings struct {
+	ChatID string `json:"chatId"`
+	// ReadReceipts is whether we send read receipts in the chat
+	ReadReceipts int `json:"readReceipts"`
+	// TypingIndicators is whether we send typing indicators in the chat
+	TypingIndicators int `json:"typingIndicators"`
+}
+
+func (s *SetChatPrivacySettings) Validate() error {
+	if len(s.ChatID) == 0 {
+		return ErrSetChatPrivacySettingsInvalidChatID
+	}
+
+	if !validChatPrivacySetting(s.ReadReceipts) || !validChatPrivacySetting(s.TypingIndicators) {
+		return ErrSetChatPrivacySettingsInvalidSetting
+	}
+
+	return nil
+}
+
+func validChatPrivacySetting(setting int) bool {
+	return setting >= ChatPrivacySettingDefault && setting <= ChatPrivacySettingDisabled
+}
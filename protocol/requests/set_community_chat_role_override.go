@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"errors"
+
+	"github.com/planq-network/status-go/eth-node/types"
+)
+
+var ErrSetCommunityChatRoleOverrideInvalidCommunityID = errors.New("set-community-chat-role-override: invalid community id")
+var ErrSetCommunityChatRoleOverrideInvalidChatID = errors.New("set-community-chat-role-override: invalid chat id")
+var ErrSetCommunityChatRoleOverrideInvalidRoleID = errors.New("set-community-chat-role-override: invalid role id")
+var ErrSetCommunityChatRoleOverrideConflict = errors.New("set-community-chat-role-override: permission both allowed and denied")
+
+// SetCommunityChatRoleOverride overrides the permissions of a role in a chat,
+// passing no allowed and no denied permissions removes the override
+type SetCommunityChatRoleOverride struct {
+	CommunityID types.HexBytes `json:"communityId"`
+	ChatID      string         `json:"chatId"`
+	RoleID      string         `json:"roleId"`
+	Allow       uint64         `json:"allow"`
+	Deny        uint64         `json:"deny"`
+}
+
+func (j *SetCommunityChatRoleOverride) Validate() error {
+	if len(j.CommunityID) == 0 {
+		return ErrSetCommunityChatRoleOverrideInvalidCommunityID
+	}
+
+	if len(j.ChatID) == 0 {
+		return ErrSetCommunityChatRoleOverrideInvalidChatID
+	}
+
+	if len(j.RoleID) == 0 {
+		return ErrSetCommunityChatRoleOverrideInvalidRoleID
+	}
+
+	if j.Allow&j.Deny != 0 {
+		return ErrSetCommunityChatRoleOverrideConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package protocol
+
+import (
+	"database/sql"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/common"
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+const selectScheduledMessagesQuery = `SELECT id, local_chat_id, scheduled_at, payload, image_path, audio_path, file_path, error FROM scheduled_messages`
+
+// SaveScheduledMessage inserts a new scheduled message
+func (db sqlitePersistence) SaveScheduledMessage(scheduledMessage *ScheduledMessage) error {
+	message := scheduledMessage.Message
+	payload, err := proto.Marshal(&message.ChatMessage)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(`INSERT INTO scheduled_messages(id, local_chat_id, scheduled_at, payload, image_path, audio_path, file_path, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		scheduledMessage.ID,
+		scheduledMessage.ChatID,
+		scheduledMessage.ScheduledAt,
+		payload,
+		message.ImagePath,
+		message.AudioPath,
+		message.FilePath,
+		scheduledMessage.Error,
+	)
+	return err
+}
+
+// UpdateScheduledMessage changes a scheduled message, it returns
+// ErrScheduledMessageNotFound if it has been sent or cancelled in the meantime
+func (db sqlitePersistence) UpdateScheduledMessage(scheduledMessage *ScheduledMessage) error {
+	message := scheduledMessage.Message
+	payload, err := proto.Marshal(&message.ChatMessage)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.db.Exec(`UPDATE scheduled_messages SET scheduled_at = ?, payload = ?, error = ? WHERE id = ?`,
+		scheduledMessage.ScheduledAt,
+		payload,
+		scheduledMessage.Error,
+		scheduledMessage.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return scheduledMessageAffected(result)
+}
+
+// ScheduledMessage returns the scheduled message with the given id, nil if
+// it doesn't exist
+func (db sqlitePersistence) ScheduledMessage(id string) (*ScheduledMessage, error) {
+	rows, err := db.db.Query(selectScheduledMessagesQuery+` WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledMessages, err := db.scanScheduledMessages(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scheduledMessages) == 0 {
+		return nil, nil
+	}
+	return scheduledMessages[0], nil
+}
+
+// ScheduledMessages returns the messages scheduled in a chat, or in all the
+// chats if chatID is empty, ordered by the time they are sent at
+func (db sqlitePersistence) ScheduledMessages(chatID string) ([]*ScheduledMessage, error) {
+	var rows *sql.Rows
+	var err error
+	if chatID == "" {
+		rows, err = db.db.Query(selectScheduledMessagesQuery + ` ORDER BY scheduled_at ASC, id ASC`)
+	} else {
+		rows, err = db.db.Query(selectScheduledMessagesQuery+` WHERE local_chat_id = ? ORDER BY scheduled_at ASC, id ASC`, chatID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return db.scanScheduledMessages(rows)
+}
+
+// DueScheduledMessages returns the messages that are due at the given time
+// and that haven't failed to be sent
+func (db sqlitePersistence) DueScheduledMessages(timestamp uint64) ([]*ScheduledMessage, error) {
+	rows, err := db.db.Query(selectScheduledMessagesQuery+` WHERE scheduled_at <= ? AND error = "" ORDER BY scheduled_at ASC, id ASC`, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.scanScheduledMessages(rows)
+}
+
+// SetScheduledMessageError records the reason a scheduled message couldn't
+// be sent
+func (db sqlitePersistence) SetScheduledMessageError(id string, reason string) error {
+	_, err := db.db.Exec(`UPDATE scheduled_messages SET error = ? WHERE id = ?`, reason, id)
+	return err
+}
+
+// DeleteScheduledMessage removes a scheduled message, it returns
+// ErrScheduledMessageNotFound if it doesn't exist
+func (db sqlitePersistence) DeleteScheduledMessage(id string) error {
+	result, err := db.db.Exec(`DELETE FROM scheduled_messages WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	return scheduledMessageAffected(result)
+}
+
+func scheduledMessageAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrScheduledMessageNotFound
+	}
+	return nil
+}
+
+func (db sqlitePersistence) scanScheduledMessages(rows *sql.Rows) ([]*ScheduledMessage, error) {
+	defer rows.Close()
+
+	var result []*ScheduledMessage
+	for rows.Next() {
+		scheduledMessage := &ScheduledMessage{Message: &common.Message{}}
+		message := scheduledMessage.Message
+		var payload []byte
+		err := rows.Scan(
+			&scheduledMessage.ID,
+			&scheduledMessage.ChatID,
+			&scheduledMessage.ScheduledAt,
+			&payload,
+			&message.ImagePath,
+			&message.AudioPath,
+			&message.FilePath,
+			&scheduledMessage.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var chatMessage protobuf.ChatMessage
+		if err := proto.Unmarshal(payload, &chatMessage); err != nil {
+			return nil, err
+		}
+		message.ChatMessage = chatMessage
+		message.LocalChatID = scheduledMessage.ChatID
+
+		result = append(result, scheduledMessage)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,64 @@
+package transcript
+
+import (
+	"html/template"
+	"io"
+)
+
+var htmlTemplates = template.Must(template.New("header").Funcs(template.FuncMap{
+	"timestamp": formatTimestamp,
+	"name":      authorName,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<style>
+body { font-family: sans-serif; max-width: 800px; margin: auto; }
+.message { border-bottom: 1px solid #eee; padding: 8px 0; }
+.meta { color: #888; font-size: 0.8em; }
+.text { white-space: pre-wrap; }
+.reply { border-left: 3px solid #ccc; color: #666; margin: 4px 0; padding-left: 8px; }
+.deleted { color: #888; font-style: italic; }
+img { max-width: 400px; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p class="meta">Exported on {{timestamp .ExportedAt}}</p>
+`))
+
+func init() {
+	template.Must(htmlTemplates.New("message").Parse(`<div class="message" id="{{.ID}}">
+<div class="meta"><strong>{{name .Author}}</strong>{{with .Author.ENSName}} ({{.}}){{end}} &middot; {{timestamp .Timestamp}}{{if .EditedAt}} &middot; edited{{end}}{{with .Pin}} &middot; pinned by {{name .PinnedBy}}{{end}}{{if .ThreadID}} &middot; <a href="#{{.ThreadID}}">in thread</a>{{end}}</div>
+{{with .ReplyTo}}<div class="reply"><a href="#{{.ID}}">{{name .Author}}</a>: {{.Text}}</div>
+{{end}}{{if .Deleted}}<div class="deleted">This message was deleted</div>
+{{else}}{{with .Text}}<div class="text">{{.}}</div>
+{{end}}{{with .Image}}<img src="{{.}}">
+{{end}}{{with .Audio}}<audio controls src="{{.}}"></audio>
+{{end}}{{end}}{{with .Reactions}}<div class="meta">{{range .}}<span title="{{name .Author}}">{{.Emoji}}</span> {{end}}</div>
+{{end}}</div>
+`))
+}
+
+type htmlWriter struct {
+	w io.Writer
+}
+
+// NewHTMLWriter returns a Writer producing a standalone HTML page
+func NewHTMLWriter(w io.Writer) Writer {
+	return &htmlWriter{w: w}
+}
+
+func (h *htmlWriter) WriteHeader(chat *Chat) error {
+	return htmlTemplates.ExecuteTemplate(h.w, "header", chat)
+}
+
+func (h *htmlWriter) WriteMessage(message *Message) error {
+	return htmlTemplates.ExecuteTemplate(h.w, "message", message)
+}
+
+func (h *htmlWriter) Close() error {
+	_, err := io.WriteString(h.w, "</body>\n</html>\n")
+	return err
+}
@@ -0,0 +1,49 @@
+package transcript
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonWriter struct {
+	w        io.Writer
+	messages int
+}
+
+// NewJSONWriter returns a Writer producing a JSON object with the chat and
+// the list of its messages
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: w}
+}
+
+func (j *jsonWriter) WriteHeader(chat *Chat) error {
+	encodedChat, err := json.Marshal(chat)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(j.w, `{"chat":`+string(encodedChat)+`,"messages":[`)
+	return err
+}
+
+func (j *jsonWriter) WriteMessage(message *Message) error {
+	encodedMessage, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	if j.messages != 0 {
+		if _, err := io.WriteString(j.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	j.messages++
+
+	_, err = j.w.Write(encodedMessage)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
@@ -0,0 +1,84 @@
+package transcript
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type markdownWriter struct {
+	w io.Writer
+}
+
+// NewMarkdownWriter returns a Writer producing a Markdown document, the
+// text of the messages is kept as is, since it's already Markdown
+func NewMarkdownWriter(w io.Writer) Writer {
+	return &markdownWriter{w: w}
+}
+
+func (m *markdownWriter) WriteHeader(chat *Chat) error {
+	_, err := fmt.Fprintf(m.w, "# %s\n\n_Exported on %s_\n\n", chat.Name, formatTimestamp(chat.ExportedAt))
+	return err
+}
+
+func (m *markdownWriter) WriteMessage(message *Message) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "**%s**", authorName(message.Author))
+	if message.Author != nil && message.Author.ENSName != "" {
+		fmt.Fprintf(&b, " (%s)", message.Author.ENSName)
+	}
+	fmt.Fprintf(&b, " · %s", formatTimestamp(message.Timestamp))
+	if message.EditedAt != 0 {
+		b.WriteString(" · _edited_")
+	}
+	if message.Pin != nil {
+		fmt.Fprintf(&b, " · _pinned by %s_", authorName(message.Pin.PinnedBy))
+	}
+	if message.ThreadID != "" {
+		b.WriteString(" · _in thread_")
+	}
+	b.WriteString("\n\n")
+
+	if message.ReplyTo != nil {
+		fmt.Fprintf(&b, "> **%s**: %s\n\n", authorName(message.ReplyTo.Author), quote(message.ReplyTo.Text))
+	}
+
+	if message.Deleted {
+		b.WriteString("_This message was deleted_\n\n")
+	} else {
+		if message.Text != "" {
+			b.WriteString(message.Text)
+			b.WriteString("\n\n")
+		}
+		if message.Image != "" {
+			fmt.Fprintf(&b, "![image](%s)\n\n", message.Image)
+		}
+		if message.Audio != "" {
+			fmt.Fprintf(&b, "[audio](%s)\n\n", message.Audio)
+		}
+	}
+
+	if len(message.Reactions) != 0 {
+		var emojis []string
+		for _, reaction := range message.Reactions {
+			emojis = append(emojis, reaction.Emoji)
+		}
+		fmt.Fprintf(&b, "%s\n\n", strings.Join(emojis, " "))
+	}
+
+	b.WriteString("---\n\n")
+
+	_, err := m.w.Write(b.Bytes())
+	return err
+}
+
+func (m *markdownWriter) Close() error {
+	return nil
+}
+
+// quote makes a multiline text fit in a blockquote
+func quote(text string) string {
+	return strings.ReplaceAll(text, "\n", "\n> ")
+}
@@ -0,0 +1,138 @@
+// Package transcript writes human readable transcripts of chats.
+//
+// Messages are streamed to the writer one at a time, so that the whole
+// history of a chat never needs to be held in memory.
+package transcript
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// Format is the format of a transcript
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+)
+
+var ErrUnknownFormat = errors.New("unknown transcript format")
+
+// Valid returns whether the format is supported
+func (f Format) Valid() bool {
+	switch f {
+	case FormatJSON, FormatHTML, FormatMarkdown:
+		return true
+	}
+	return false
+}
+
+// Extension returns the file extension commonly used for the format
+func (f Format) Extension() string {
+	switch f {
+	case FormatHTML:
+		return ".html"
+	case FormatMarkdown:
+		return ".md"
+	}
+	return ".json"
+}
+
+// Chat describes the chat a transcript is made of
+type Chat struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// CommunityID is set for the chats of a community
+	CommunityID string `json:"communityId,omitempty"`
+	// ExportedAt is the time the transcript was made, in milliseconds
+	ExportedAt uint64 `json:"exportedAt"`
+}
+
+// Author is the sender of a message or a reaction
+type Author struct {
+	// ID is the hex encoded public key of the author
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	// ENSName is only set if it has been verified
+	ENSName string `json:"ensName,omitempty"`
+}
+
+// Reply is the message a message replies to
+type Reply struct {
+	ID     string  `json:"id"`
+	Author *Author `json:"author,omitempty"`
+	Text   string  `json:"text,omitempty"`
+}
+
+// Reaction is an emoji reaction to a message
+type Reaction struct {
+	Emoji  string  `json:"emoji"`
+	Author *Author `json:"author"`
+}
+
+// Pin describes who pinned a message
+type Pin struct {
+	PinnedAt uint64  `json:"pinnedAt"`
+	PinnedBy *Author `json:"pinnedBy"`
+}
+
+// Message is a message of a transcript
+type Message struct {
+	ID string `json:"id"`
+	// Timestamp is the time the message was sent, in milliseconds
+	Timestamp uint64  `json:"timestamp"`
+	Author    *Author `json:"author"`
+	Text      string  `json:"text,omitempty"`
+	// ThreadID is set for the replies in a thread
+	ThreadID string `json:"threadId,omitempty"`
+	ReplyTo  *Reply `json:"replyTo,omitempty"`
+	// EditedAt is the clock value of the last edit, 0 if never edited
+	EditedAt  uint64      `json:"editedAt,omitempty"`
+	Deleted   bool        `json:"deleted,omitempty"`
+	Pin       *Pin        `json:"pin,omitempty"`
+	Reactions []*Reaction `json:"reactions,omitempty"`
+	// Image and Audio are the paths of the media files, relative to the
+	// transcript
+	Image string `json:"image,omitempty"`
+	Audio string `json:"audio,omitempty"`
+}
+
+// Writer writes a transcript, WriteHeader must be called first and Close
+// last, Close doesn't close the underlying io.Writer
+type Writer interface {
+	WriteHeader(chat *Chat) error
+	WriteMessage(message *Message) error
+	Close() error
+}
+
+// NewWriter returns a Writer for the given format
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatJSON:
+		return NewJSONWriter(w), nil
+	case FormatHTML:
+		return NewHTMLWriter(w), nil
+	case FormatMarkdown:
+		return NewMarkdownWriter(w), nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// formatTimestamp formats a timestamp in milliseconds in UTC
+func formatTimestamp(timestamp uint64) string {
+	return time.Unix(0, int64(timestamp)*int64(time.Millisecond)).UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// authorName returns the name an author is displayed with
+func authorName(author *Author) string {
+	if author == nil {
+		return ""
+	}
+	if author.DisplayName != "" {
+		return author.DisplayName
+	}
+	return author.ID
+}
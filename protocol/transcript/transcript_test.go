@@ -0,0 +1,101 @@
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testChat = &Chat{
+		ID:         "test-chat",
+		Name:       "test-chat",
+		ExportedAt: 1633046400000,
+	}
+
+	alice = &Author{ID: "0x01", DisplayName: "alice.stateofus.eth", ENSName: "alice.stateofus.eth"}
+	bob   = &Author{ID: "0x02", DisplayName: "Bob"}
+
+	testMessages = []*Message{
+		{
+			ID:        "0xa",
+			Timestamp: 1633046400000,
+			Author:    alice,
+			Text:      "hello <b>world</b>",
+			Reactions: []*Reaction{{Emoji: "👍", Author: bob}},
+		},
+		{
+			ID:        "0xb",
+			Timestamp: 1633046460000,
+			Author:    bob,
+			Text:      "hi",
+			ReplyTo:   &Reply{ID: "0xa", Author: alice, Text: "hello <b>world</b>"},
+			EditedAt:  1633046470000,
+			Pin:       &Pin{PinnedAt: 1633046480000, PinnedBy: alice},
+			Image:     "transcript_files/0xb.jpeg",
+		},
+		{
+			ID:        "0xc",
+			Timestamp: 1633046520000,
+			Author:    bob,
+			Deleted:   true,
+		},
+	}
+)
+
+func writeTranscript(t *testing.T, format Format) string {
+	var b bytes.Buffer
+	writer, err := NewWriter(format, &b)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteHeader(testChat))
+	for _, message := range testMessages {
+		require.NoError(t, writer.WriteMessage(message))
+	}
+	require.NoError(t, writer.Close())
+
+	return b.String()
+}
+
+func TestJSONWriter(t *testing.T) {
+	var result struct {
+		Chat     *Chat      `json:"chat"`
+		Messages []*Message `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(writeTranscript(t, FormatJSON)), &result))
+
+	require.Equal(t, testChat, result.Chat)
+	require.Equal(t, testMessages, result.Messages)
+}
+
+func TestHTMLWriter(t *testing.T) {
+	output := writeTranscript(t, FormatHTML)
+
+	require.Contains(t, output, "<title>test-chat</title>")
+	require.Contains(t, output, "hello &lt;b&gt;world&lt;/b&gt;")
+	require.NotContains(t, output, "<b>world</b>")
+	require.Contains(t, output, "2021-10-01 00:00:00 UTC")
+	require.Contains(t, output, "pinned by alice.stateofus.eth")
+	require.Contains(t, output, `<img src="transcript_files/0xb.jpeg">`)
+	require.Contains(t, output, "This message was deleted")
+	require.Contains(t, output, "👍")
+	require.Contains(t, output, "</html>")
+}
+
+func TestMarkdownWriter(t *testing.T) {
+	output := writeTranscript(t, FormatMarkdown)
+
+	require.Contains(t, output, "# test-chat")
+	require.Contains(t, output, "**alice.stateofus.eth** (alice.stateofus.eth) · 2021-10-01 00:00:00 UTC")
+	require.Contains(t, output, "> **alice.stateofus.eth**: hello <b>world</b>")
+	require.Contains(t, output, "· _edited_ · _pinned by alice.stateofus.eth_")
+	require.Contains(t, output, "![image](transcript_files/0xb.jpeg)")
+	require.Contains(t, output, "_This message was deleted_")
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := NewWriter(Format("pdf"), &bytes.Buffer{})
+	require.Equal(t, ErrUnknownFormat, err)
+}
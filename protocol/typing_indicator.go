@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/planq-network/status-go/protocol/protobuf"
+)
+
+// TypingIndicator lets the members of a chat know that someone started or
+// stopped typing, it's never persisted
+type TypingIndicator struct {
+	protobuf.TypingIndicator
+
+	// From is a public key of the member typing
+	From string `json:"from,omitempty"`
+
+	// SigPubKey is the ecdsa encoded public key of the member typing
+	SigPubKey *ecdsa.PublicKey `json:"-"`
+
+	// LocalChatID is the chatID of the local chat (one-to-one are not symmetric)
+	LocalChatID string `json:"localChatId"`
+}
+
+// ID identifies the member typing in a chat, as only the latest indicator
+// of each member matters
+func (t TypingIndicator) ID() string {
+	return t.LocalChatID + t.From
+}
+
+// GetSigPubKey returns an ecdsa encoded public key
+// this function is required to implement the ChatEntity interface
+func (t TypingIndicator) GetSigPubKey() *ecdsa.PublicKey {
+	return t.SigPubKey
+}
+
+// GetProtoBuf returns the struct's embedded protobuf struct
+// this function is required to implement the ChatEntity interface
+func (t TypingIndicator) GetProtobuf() proto.Message {
+	return &t.TypingIndicator
+}
+
+// GetGrant returns no grant, as typing indicators are not sent in community chats
+// this function is required to implement the ChatEntity interface
+func (t TypingIndicator) GetGrant() []byte {
+	return nil
+}
+
+// SetMessageType a setter for the MessageType field
+// this function is required to implement the ChatEntity interface
+func (t *TypingIndicator) SetMessageType(messageType protobuf.MessageType) {
+	t.MessageType = messageType
+}
+
+// WrapGroupMessage indicates whether we should wrap this in membership information
+func (t TypingIndicator) WrapGroupMessage() bool {
+	return false
+}
+
+func (t TypingIndicator) MarshalJSON() ([]byte, error) {
+	item := struct {
+		Clock       uint64 `json:"clock,omitempty"`
+		LocalChatID string `json:"localChatId"`
+		From        string `json:"from"`
+		Typing      bool   `json:"typing"`
+	}{
+		Clock:       t.Clock,
+		LocalChatID: t.LocalChatID,
+		From:        t.From,
+		Typing:      t.Typing,
+	}
+
+	return json.Marshal(item)
+}
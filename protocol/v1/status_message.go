@@ -138,6 +138,10 @@ func (m *StatusMessage) HandleEncryption(myKey *ecdsa.PrivateKey, senderKey *ecd
 	}
 
 	m.DecryptedPayload = response.DecryptedMessage
+	// Hash ratchet keys are consumed by the encryption layer
+	if response.HashRatchetKeyReceived {
+		m.DecryptedPayload = nil
+	}
 	m.Installations = response.Installations
 	m.SharedSecrets = response.SharedSecrets
 	return nil
@@ -228,6 +232,18 @@ func (m *StatusMessage) HandleApplication() error {
 		return m.unmarshalProtobufData(new(protobuf.PushNotificationResponse))
 	case protobuf.ApplicationMetadataMessage_EMOJI_REACTION:
 		return m.unmarshalProtobufData(new(protobuf.EmojiReaction))
+	case protobuf.ApplicationMetadataMessage_POLL_VOTE:
+		return m.unmarshalProtobufData(new(protobuf.PollVote))
+	case protobuf.ApplicationMetadataMessage_POLL_CLOSE:
+		return m.unmarshalProtobufData(new(protobuf.PollClose))
+	case protobuf.ApplicationMetadataMessage_FILE_CHUNK:
+		return m.unmarshalProtobufData(new(protobuf.FileChunk))
+	case protobuf.ApplicationMetadataMessage_CHAT_MESSAGES_TTL:
+		return m.unmarshalProtobufData(new(protobuf.ChatMessagesTTL))
+	case protobuf.ApplicationMetadataMessage_READ_RECEIPT:
+		return m.unmarshalProtobufData(new(protobuf.ReadReceipt))
+	case protobuf.ApplicationMetadataMessage_TYPING_INDICATOR:
+		return m.unmarshalProtobufData(new(protobuf.TypingIndicator))
 	case protobuf.ApplicationMetadataMessage_GROUP_CHAT_INVITATION:
 		return m.unmarshalProtobufData(new(protobuf.GroupChatInvitation))
 	case protobuf.ApplicationMetadataMessage_COMMUNITY_DESCRIPTION:
@@ -236,6 +252,8 @@ func (m *StatusMessage) HandleApplication() error {
 		return m.unmarshalProtobufData(new(protobuf.CommunityInvitation))
 	case protobuf.ApplicationMetadataMessage_COMMUNITY_REQUEST_TO_JOIN:
 		return m.unmarshalProtobufData(new(protobuf.CommunityRequestToJoin))
+	case protobuf.ApplicationMetadataMessage_COMMUNITY_MEMBER_ACTION:
+		return m.unmarshalProtobufData(new(protobuf.CommunityMemberAction))
 	case protobuf.ApplicationMetadataMessage_EDIT_MESSAGE:
 		return m.unmarshalProtobufData(new(protobuf.EditMessage))
 	case protobuf.ApplicationMetadataMessage_DELETE_MESSAGE:
@@ -267,6 +285,8 @@ func (m *StatusMessage) HandleApplication() error {
 		return m.unmarshalProtobufData(new(protobuf.SyncBookmark))
 	case protobuf.ApplicationMetadataMessage_SYNC_CLEAR_HISTORY:
 		return m.unmarshalProtobufData(new(protobuf.SyncClearHistory))
+	case protobuf.ApplicationMetadataMessage_SYNC_CHAT_DRAFT:
+		return m.unmarshalProtobufData(new(protobuf.SyncChatDraft))
 	}
 	return nil
 }
@@ -139,7 +139,7 @@ type Bookmark struct {
 }
 
 func (db *Database) GetBookmarks() ([]*Bookmark, error) {
-	rows, err := db.db.Query(`SELECT url, name, image_url, removed FROM bookmarks`)
+	rows, err := db.db.Query(`SELECT url, name, image_url, removed, clock FROM bookmarks`)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +148,7 @@ func (db *Database) GetBookmarks() ([]*Bookmark, error) {
 	var rst []*Bookmark
 	for rows.Next() {
 		bookmark := &Bookmark{}
-		err := rows.Scan(&bookmark.URL, &bookmark.Name, &bookmark.ImageURL, &bookmark.Removed)
+		err := rows.Scan(&bookmark.URL, &bookmark.Name, &bookmark.ImageURL, &bookmark.Removed, &bookmark.Clock)
 		if err != nil {
 			return nil, err
 		}
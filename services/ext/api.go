@@ -416,6 +416,11 @@ func (api *PublicAPI) RemoveUserFromCommunity(communityID types.HexBytes, userPu
 	return api.service.messenger.RemoveUserFromCommunity(communityID, userPublicKey)
 }
 
+// RemoveUserFromCommunityChat removes the user with pk from a chat of the community with ID
+func (api *PublicAPI) RemoveUserFromCommunityChat(communityID types.HexBytes, chatID string, userPublicKey string) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.RemoveUserFromCommunityChat(communityID, chatID, userPublicKey)
+}
+
 // SetCommunityMuted sets the community's muted value
 func (api *PublicAPI) SetCommunityMuted(communityID types.HexBytes, muted bool) error {
 	return api.service.messenger.SetMuted(communityID, muted)
@@ -451,6 +456,11 @@ func (api *PublicAPI) RequestToJoinCommunity(request *requests.RequestToJoinComm
 	return api.service.messenger.RequestToJoinCommunity(request)
 }
 
+// CommunityAddressOwnershipProofMessage returns the message that addresses need to sign with personal_sign to prove ownership when requesting to join a token gated community
+func (api *PublicAPI) CommunityAddressOwnershipProofMessage(communityID types.HexBytes) string {
+	return api.service.messenger.CommunityAddressOwnershipProofMessage(communityID)
+}
+
 // CreateCommunityCategory creates a category within a particular community
 func (api *PublicAPI) CreateCommunityCategory(request *requests.CreateCommunityCategory) (*protocol.MessengerResponse, error) {
 	return api.service.messenger.CreateCommunityCategory(request)
@@ -476,11 +486,46 @@ func (api *PublicAPI) DeleteCommunityCategory(request *requests.DeleteCommunityC
 	return api.service.messenger.DeleteCommunityCategory(request)
 }
 
+// CreateCommunityRole creates a role with the given permissions within a particular community
+func (api *PublicAPI) CreateCommunityRole(request *requests.CreateCommunityRole) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.CreateCommunityRole(request)
+}
+
+// EditCommunityRole modifies the name and permissions of a role within a particular community
+func (api *PublicAPI) EditCommunityRole(request *requests.EditCommunityRole) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.EditCommunityRole(request)
+}
+
+// DeleteCommunityRole deletes a role within a particular community and revokes it from any member that has it
+func (api *PublicAPI) DeleteCommunityRole(request *requests.DeleteCommunityRole) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.DeleteCommunityRole(request)
+}
+
+// AssignCommunityRole assigns a role to a member of a community, optionally scoped to a chat
+func (api *PublicAPI) AssignCommunityRole(request *requests.AssignCommunityRole) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.AssignCommunityRole(request)
+}
+
+// RevokeCommunityRole revokes a role from a member of a community, optionally scoped to a chat
+func (api *PublicAPI) RevokeCommunityRole(request *requests.RevokeCommunityRole) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.RevokeCommunityRole(request)
+}
+
+// SetCommunityChatRoleOverride allows or denies permissions of a role within a particular community chat
+func (api *PublicAPI) SetCommunityChatRoleOverride(request *requests.SetCommunityChatRoleOverride) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.SetCommunityChatRoleOverride(request)
+}
+
 type ApplicationMessagesResponse struct {
 	Messages []*common.Message `json:"messages"`
 	Cursor   string            `json:"cursor"`
 }
 
+type SearchMessagesResponse struct {
+	Results []*protocol.MessageSearchResult `json:"results"`
+	Cursor  string                          `json:"cursor"`
+}
+
 type MarkMessagSeenResponse struct {
 	Count             uint64 `json:"count"`
 	CountWithMentions uint64 `json:"countWithMentions"`
@@ -507,6 +552,42 @@ func (api *PublicAPI) ChatMessages(chatID, cursor string, limit int) (*Applicati
 	}, nil
 }
 
+// ThreadMessages returns the replies in a thread, the most recent first
+func (api *PublicAPI) ThreadMessages(threadID, cursor string, limit int) (*ApplicationMessagesResponse, error) {
+	messages, cursor, err := api.service.messenger.ThreadMessages(threadID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApplicationMessagesResponse{
+		Messages: messages,
+		Cursor:   cursor,
+	}, nil
+}
+
+// ActiveThreads returns the root messages of the threads in a chat, the most recently replied first
+func (api *PublicAPI) ActiveThreads(chatID, cursor string, limit int) (*ApplicationMessagesResponse, error) {
+	messages, cursor, err := api.service.messenger.ActiveThreads(chatID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApplicationMessagesResponse{
+		Messages: messages,
+		Cursor:   cursor,
+	}, nil
+}
+
+// MarkThreadRead marks all the replies in a thread as seen
+func (api *PublicAPI) MarkThreadRead(chatID, threadID string) (*MarkMessagSeenResponse, error) {
+	count, withMentions, err := api.service.messenger.MarkThreadRead(chatID, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkMessagSeenResponse{Count: count, CountWithMentions: withMentions}, nil
+}
+
 func (api *PublicAPI) MessageByMessageID(messageID string) (*common.Message, error) {
 	return api.service.messenger.MessageByID(messageID)
 }
@@ -522,6 +603,19 @@ func (api *PublicAPI) AllMessagesFromChatWhichMatchTerm(chatID, searchTerm strin
 	}, nil
 }
 
+// SearchMessages returns the messages matching the query ranked by relevance, with highlighted snippets
+func (api *PublicAPI) SearchMessages(request *requests.SearchMessages) (*SearchMessagesResponse, error) {
+	results, cursor, err := api.service.messenger.SearchMessages(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchMessagesResponse{
+		Results: results,
+		Cursor:  cursor,
+	}, nil
+}
+
 func (api *PublicAPI) AllMessagesFromChatsAndCommunitiesWhichMatchTerm(communityIds []string, chatIds []string, searchTerm string, caseSensitive bool) (*ApplicationMessagesResponse, error) {
 	messages, err := api.service.messenger.AllMessagesFromChatsAndCommunitiesWhichMatchTerm(communityIds, chatIds, searchTerm, caseSensitive)
 	if err != nil {
@@ -836,6 +930,81 @@ func (api *PublicAPI) EmojiReactionsByChatIDMessageID(chatID string, messageID s
 	return api.service.messenger.EmojiReactionsByChatIDMessageID(chatID, messageID)
 }
 
+// CreatePoll sends a poll to a chat
+func (api *PublicAPI) CreatePoll(ctx context.Context, request *requests.CreatePoll) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.CreatePoll(ctx, request)
+}
+
+// SendPollVote votes on a poll, replacing any previous vote
+func (api *PublicAPI) SendPollVote(ctx context.Context, request *requests.SendPollVote) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.SendPollVote(ctx, request)
+}
+
+// RetractPollVote removes our vote from a poll
+func (api *PublicAPI) RetractPollVote(ctx context.Context, messageID string) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.RetractPollVote(ctx, messageID)
+}
+
+// ClosePoll stops accepting votes on a poll we created
+func (api *PublicAPI) ClosePoll(ctx context.Context, messageID string) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.ClosePoll(ctx, messageID)
+}
+
+// PollResults returns the tally of the votes of a poll
+func (api *PublicAPI) PollResults(messageID string) (*protocol.PollResults, error) {
+	return api.service.messenger.PollResults(messageID)
+}
+
+// SetChatMessagesTTL sets the time after which the messages of a chat are deleted, 0 disables it
+func (api *PublicAPI) SetChatMessagesTTL(ctx context.Context, request *requests.SetChatMessagesTTL) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.SetChatMessagesTTL(ctx, request)
+}
+
+// SaveChatDraft saves the draft of a chat and syncs it with the paired devices, an empty text clears it
+func (api *PublicAPI) SaveChatDraft(ctx context.Context, request *requests.SaveChatDraft) (*protocol.ChatDraft, error) {
+	return api.service.messenger.SaveChatDraft(ctx, request)
+}
+
+// ChatDraft returns the draft of a chat
+func (api *PublicAPI) ChatDraft(chatID string) (*protocol.ChatDraft, error) {
+	return api.service.messenger.ChatDraft(chatID)
+}
+
+// ChatDrafts returns the drafts of all the chats
+func (api *PublicAPI) ChatDrafts() ([]*protocol.ChatDraft, error) {
+	return api.service.messenger.ChatDrafts()
+}
+
+// ScheduleMessage stores a message to be sent at a later time
+func (api *PublicAPI) ScheduleMessage(request *requests.ScheduleMessage) (*protocol.ScheduledMessage, error) {
+	return api.service.messenger.ScheduleMessage(request)
+}
+
+// ScheduledMessages returns the messages scheduled in a chat, or in all the chats if chatID is empty
+func (api *PublicAPI) ScheduledMessages(chatID string) ([]*protocol.ScheduledMessage, error) {
+	return api.service.messenger.ScheduledMessages(chatID)
+}
+
+// EditScheduledMessage changes the text or the time of a scheduled message
+func (api *PublicAPI) EditScheduledMessage(request *requests.EditScheduledMessage) (*protocol.ScheduledMessage, error) {
+	return api.service.messenger.EditScheduledMessage(request)
+}
+
+// CancelScheduledMessage removes a scheduled message before it is sent
+func (api *PublicAPI) CancelScheduledMessage(id string) error {
+	return api.service.messenger.CancelScheduledMessage(id)
+}
+
+// SetChatPrivacySettings overrides for a chat whether read receipts and typing indicators are sent
+func (api *PublicAPI) SetChatPrivacySettings(request *requests.SetChatPrivacySettings) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.SetChatPrivacySettings(request)
+}
+
+// SendTypingIndicator lets the members of a chat know that we started or stopped typing
+func (api *PublicAPI) SendTypingIndicator(ctx context.Context, chatID string, typing bool) error {
+	return api.service.messenger.SendTypingIndicator(ctx, chatID, typing)
+}
+
 // Urls
 
 func (api *PublicAPI) GetLinkPreviewWhitelist() []urls.Site {
@@ -967,6 +1136,26 @@ func (api *PublicAPI) BackupData() (uint64, error) {
 	return api.service.messenger.BackupData(context.Background())
 }
 
+// ExportArchive writes the data of the account to a password encrypted archive
+func (api *PublicAPI) ExportArchive(ctx context.Context, request *requests.ExportArchive) error {
+	return api.service.messenger.ExportArchive(ctx, request)
+}
+
+// ImportArchive merges a password encrypted archive into the account
+func (api *PublicAPI) ImportArchive(ctx context.Context, request *requests.ImportArchive) (*protocol.MessengerResponse, error) {
+	return api.service.messenger.ImportArchive(ctx, request)
+}
+
+// OutboxEntries returns the delivery state of the messages of a chat that haven't been delivered yet
+func (api *PublicAPI) OutboxEntries(chatID string) ([]*common.OutboxEntry, error) {
+	return api.service.messenger.OutboxEntries(chatID)
+}
+
+// ExportChatTranscript writes the messages of a chat to a JSON, HTML or Markdown file
+func (api *PublicAPI) ExportChatTranscript(ctx context.Context, request *requests.ExportChatTranscript) error {
+	return api.service.messenger.ExportChatTranscript(ctx, request)
+}
+
 // -----
 // HELPER
 // -----
@@ -34,9 +34,11 @@ import (
 	"github.com/planq-network/status-go/params"
 	"github.com/planq-network/status-go/protocol"
 	"github.com/planq-network/status-go/protocol/anonmetrics"
+	"github.com/planq-network/status-go/protocol/communities"
 	"github.com/planq-network/status-go/protocol/pushnotificationclient"
 	"github.com/planq-network/status-go/protocol/pushnotificationserver"
 	"github.com/planq-network/status-go/protocol/transport"
+	statusrpc "github.com/planq-network/status-go/rpc"
 	"github.com/planq-network/status-go/services/ext/mailservers"
 	localnotifications "github.com/planq-network/status-go/services/local-notifications"
 	mailserversDB "github.com/planq-network/status-go/services/mailservers"
@@ -60,6 +62,7 @@ type Service struct {
 	cancelMessenger chan struct{}
 	storage         db.TransactionalStorage
 	n               types.Node
+	rpcClient       *statusrpc.Client
 	config          params.NodeConfig
 	mailMonitor     *MailRequestMonitor
 	server          *p2p.Server
@@ -75,6 +78,7 @@ var _ node.Lifecycle = (*Service)(nil)
 func New(
 	config params.NodeConfig,
 	n types.Node,
+	rpcClient *statusrpc.Client,
 	ldb *leveldb.DB,
 	mailMonitor *MailRequestMonitor,
 	eventSub mailservers.EnvelopeEventSubscriber,
@@ -84,6 +88,7 @@ func New(
 	return &Service{
 		storage:     db.NewLevelDBStorage(ldb),
 		n:           n,
+		rpcClient:   rpcClient,
 		config:      config,
 		mailMonitor: mailMonitor,
 		peerStore:   peerStore,
@@ -139,7 +144,7 @@ func (s *Service) InitProtocol(nodeName string, identity *ecdsa.PrivateKey, db *
 	s.multiAccountsDB = multiAccountDb
 	s.account = acc
 
-	options, err := buildMessengerOptions(s.config, identity, db, s.multiAccountsDB, acc, envelopesMonitorConfig, s.accountsDB, logger, &MessengerSignalsHandler{})
+	options, err := buildMessengerOptions(s.config, identity, db, s.rpcClient, s.multiAccountsDB, acc, envelopesMonitorConfig, s.accountsDB, logger, &MessengerSignalsHandler{})
 	if err != nil {
 		return err
 	}
@@ -399,6 +404,7 @@ func buildMessengerOptions(
 	config params.NodeConfig,
 	identity *ecdsa.PrivateKey,
 	db *sql.DB,
+	rpcClient *statusrpc.Client,
 	multiAccounts *multiaccounts.Database,
 	account *multiaccounts.Account,
 	envelopesMonitorConfig *transport.EnvelopesMonitorConfig,
@@ -493,6 +499,10 @@ func buildMessengerOptions(
 		options = append(options, protocol.WithVerifyTransactionClient(client))
 	}
 
+	if rpcClient != nil {
+		options = append(options, protocol.WithTokenBalanceChecker(communities.NewTokenBalanceChecker(rpcClient)))
+	}
+
 	return options, nil
 }
 
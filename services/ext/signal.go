@@ -3,6 +3,7 @@ package ext
 import (
 	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/protocol"
+	"github.com/planq-network/status-go/protocol/common"
 	"github.com/planq-network/status-go/protocol/communities"
 	"github.com/planq-network/status-go/signal"
 )
@@ -57,11 +58,21 @@ func (m MessengerSignalsHandler) MessageDelivered(chatID string, messageID strin
 	signal.SendMessageDelivered(chatID, messageID)
 }
 
+// MessageRead passes information that a message was read by a recipient
+func (m MessengerSignalsHandler) MessageRead(chatID string, messageID string, from string) {
+	signal.SendMessageRead(chatID, messageID, from)
+}
+
 // BackupPerformed passes information that a backup was performed
 func (m MessengerSignalsHandler) BackupPerformed(lastBackup uint64) {
 	signal.SendBackupPerformed(lastBackup)
 }
 
+// OutboxStateChanged passes the new delivery state of outgoing messages
+func (m MessengerSignalsHandler) OutboxStateChanged(entries []*common.OutboxEntry) {
+	signal.SendOutboxStateChanged(entries)
+}
+
 // MessageDelivered passes info about community that was requested before
 func (m MessengerSignalsHandler) CommunityInfoFound(community *communities.Community) {
 	signal.SendCommunityInfoFound(community)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/params"
+	statusrpc "github.com/planq-network/status-go/rpc"
 	"github.com/planq-network/status-go/services/ext"
 )
 
@@ -15,7 +16,7 @@ type Service struct {
 	w types.Waku
 }
 
-func New(config params.NodeConfig, n types.Node, handler ext.EnvelopeEventsHandler, ldb *leveldb.DB) *Service {
+func New(config params.NodeConfig, n types.Node, rpcClient *statusrpc.Client, handler ext.EnvelopeEventsHandler, ldb *leveldb.DB) *Service {
 	w, err := n.GetWaku(nil)
 	if err != nil {
 		panic(err)
@@ -27,7 +28,7 @@ func New(config params.NodeConfig, n types.Node, handler ext.EnvelopeEventsHandl
 	requestsRegistry := ext.NewRequestsRegistry(delay)
 	mailMonitor := ext.NewMailRequestMonitor(w, handler, requestsRegistry)
 	return &Service{
-		Service: ext.New(config, n, ldb, mailMonitor, w),
+		Service: ext.New(config, n, rpcClient, ldb, mailMonitor, w),
 		w:       w,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/planq-network/status-go/params"
 	"github.com/planq-network/status-go/services/wallet/chain"
 	"github.com/planq-network/status-go/services/wallet/transfer"
+	"github.com/planq-network/status-go/transactions"
 )
 
 func NewAPI(s *Service) *API {
@@ -79,6 +80,42 @@ func (api *API) GetTokensBalancesForChainIDs(ctx context.Context, chainIDs []uin
 	return api.s.tokenManager.getBalances(ctx, clients, accounts, addresses)
 }
 
+// GetBalanceHistory returns the balance of every token held by the accounts on the enabled chains over time
+func (api *API) GetBalanceHistory(ctx context.Context, addresses []common.Address, timeInterval BalanceHistoryTimeInterval) ([]*TokenBalanceHistory, error) {
+	log.Debug("call to get balance history")
+	networks, err := api.s.rpcClient.NetworkManager.Get(true)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.balanceHistory.History(networks, addresses, timeInterval)
+}
+
+// GetPortfolioHistory returns the value of the accounts on the enabled chains over time in a fiat currency
+func (api *API) GetPortfolioHistory(ctx context.Context, addresses []common.Address, timeInterval BalanceHistoryTimeInterval, currency string) ([]PricePoint, error) {
+	log.Debug("call to get portfolio history")
+	networks, err := api.s.rpcClient.NetworkManager.Get(true)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.balanceHistory.PortfolioHistory(ctx, networks, addresses, timeInterval, currency)
+}
+
+// GetMarketValues returns the current price, 24h change and market cap of tokens by symbol
+func (api *API) GetMarketValues(ctx context.Context, symbols []string, currency string) (map[string]TokenMarketValues, error) {
+	log.Debug("call to get market values")
+	return api.s.priceManager.FetchMarketValues(ctx, symbols, currency)
+}
+
+// GetMarketValuesByContract returns the current price, 24h change and market cap of tokens by contract across chains
+func (api *API) GetMarketValuesByContract(ctx context.Context, tokens []ChainToken, currency string) (map[uint64]map[common.Address]TokenMarketValues, error) {
+	log.Debug("call to get market values by contract")
+	networks, err := api.s.rpcClient.NetworkManager.Get(false)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.priceManager.FetchMarketValuesByContract(ctx, networks, tokens, currency)
+}
+
 func (api *API) GetTokens(ctx context.Context, chainID uint64) ([]*Token, error) {
 	log.Debug("call to get tokens")
 	rst, err := api.s.tokenManager.getTokens(chainID)
@@ -169,6 +206,11 @@ func (api *API) GetPendingOutboundTransactionsByAddressAndChainID(ctx context.Co
 	return rst, err
 }
 
+func (api *API) GetSuggestedFees(ctx context.Context, chainID uint64) (*transactions.SuggestedFees, error) {
+	log.Debug("call to get suggested fees")
+	return transactions.SuggestFees(ctx, api.s.rpcClient, chainID)
+}
+
 func (api *API) StorePendingTransaction(ctx context.Context, trx PendingTransaction) error {
 	log.Debug("call to create or edit pending transaction")
 	if trx.ChainID == 0 {
@@ -247,6 +289,16 @@ func (api *API) GetOpenseaAssetsByOwnerAndCollection(ctx context.Context, chainI
 	return client.fetchAllAssetsByOwnerAndCollection(owner, collectionSlug, limit)
 }
 
+func (api *API) GetCollectionsByOwner(ctx context.Context, chainID uint64, owner common.Address) ([]OpenseaCollection, error) {
+	log.Debug("call to get collections")
+	return api.s.collectiblesManager.GetCollectionsByOwner(ctx, chainID, owner)
+}
+
+func (api *API) GetCollectiblesByOwnerAndCollection(ctx context.Context, chainID uint64, owner common.Address, collectionSlug string, limit int) ([]OpenseaAsset, error) {
+	log.Debug("call to get collectibles")
+	return api.s.collectiblesManager.GetCollectiblesByOwnerAndCollection(ctx, chainID, owner, collectionSlug, limit)
+}
+
 func (api *API) AddEthereumChain(ctx context.Context, network params.Network) error {
 	log.Debug("call to AddEthereumChain")
 	return api.s.rpcClient.NetworkManager.Upsert(&network)
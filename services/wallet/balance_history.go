@@ -0,0 +1,490 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/planq-network/status-go/params"
+	"github.com/planq-network/status-go/services/wallet/bigint"
+	"github.com/planq-network/status-go/services/wallet/transfer"
+)
+
+type BalanceHistoryTimeInterval int
+
+const (
+	BalanceHistory1D BalanceHistoryTimeInterval = iota + 1
+	BalanceHistory1W
+	BalanceHistory1M
+	BalanceHistory1Y
+	BalanceHistoryAll
+)
+
+var ErrInvalidBalanceHistoryTimeInterval = errors.New("invalid balance history time interval")
+
+type balanceHistoryRange struct {
+	duration time.Duration
+	step     time.Duration
+}
+
+var balanceHistoryRanges = map[BalanceHistoryTimeInterval]balanceHistoryRange{
+	BalanceHistory1D: {24 * time.Hour, time.Hour},
+	BalanceHistory1W: {7 * 24 * time.Hour, 6 * time.Hour},
+	BalanceHistory1M: {30 * 24 * time.Hour, 24 * time.Hour},
+	BalanceHistory1Y: {365 * 24 * time.Hour, 7 * 24 * time.Hour},
+}
+
+// balanceHistoryAllSteps are the steps the whole history can be sampled
+// with, the first one that gives at most balanceHistoryAllMaxPoints is used
+var balanceHistoryAllSteps = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+const balanceHistoryAllMaxPoints = 100
+
+type BalanceState struct {
+	Timestamp uint64       `json:"time"`
+	Balance   *hexutil.Big `json:"balance"`
+}
+
+// TokenBalanceHistory is the balance of a token of an account over time,
+// the zero token address stands for the native currency of the chain
+type TokenBalanceHistory struct {
+	ChainID  uint64         `json:"chainId"`
+	Address  common.Address `json:"address"`
+	Token    common.Address `json:"token"`
+	Symbol   string         `json:"symbol"`
+	Decimals uint           `json:"decimals"`
+	History  []BalanceState `json:"history"`
+}
+
+// BalanceHistory reconstructs the balances of accounts from the blocks and
+// transfers downloaded by the transfer controller. The sampled balances are
+// cached until the transfers of the account change.
+type BalanceHistory struct {
+	db            *sql.DB
+	transfers     *transfer.Database
+	block         *transfer.Block
+	tokenManager  *TokenManager
+	priceProvider PriceProvider
+}
+
+func NewBalanceHistory(db *sql.DB, tokenManager *TokenManager, priceProvider PriceProvider) *BalanceHistory {
+	return &BalanceHistory{
+		db:            db,
+		transfers:     transfer.NewDB(db),
+		block:         transfer.NewBlock(db),
+		tokenManager:  tokenManager,
+		priceProvider: priceProvider,
+	}
+}
+
+// History returns the balance of every token held by the accounts on the
+// given chains, tokens that were never held in the interval are left out
+func (bh *BalanceHistory) History(networks []*params.Network, addresses []common.Address, interval BalanceHistoryTimeInterval) ([]*TokenBalanceHistory, error) {
+	timestamps, err := bh.sampleTimestamps(networks, addresses, interval, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var customs []*Token
+	if len(networks) > 0 {
+		customs, err = bh.tokenManager.getCustoms()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rst []*TokenBalanceHistory
+	for _, network := range networks {
+		for _, address := range addresses {
+			balances, err := bh.tokenBalances(network.ChainID, address, timestamps)
+			if err != nil {
+				return nil, err
+			}
+
+			for token, tokenBalances := range balances {
+				if allZero(tokenBalances) {
+					continue
+				}
+
+				history := &TokenBalanceHistory{
+					ChainID: network.ChainID,
+					Address: address,
+					Token:   token,
+					History: make([]BalanceState, len(timestamps)),
+				}
+				if token == (common.Address{}) {
+					history.Symbol = network.NativeCurrencySymbol
+					history.Decimals = uint(network.NativeCurrencyDecimals)
+				} else if details := findToken(network.ChainID, token, customs); details != nil {
+					history.Symbol = details.Symbol
+					history.Decimals = details.Decimals
+				}
+				for i, timestamp := range timestamps {
+					history.History[i] = BalanceState{Timestamp: timestamp, Balance: (*hexutil.Big)(tokenBalances[i])}
+				}
+				rst = append(rst, history)
+			}
+		}
+	}
+
+	sort.Slice(rst, func(i, j int) bool {
+		if rst[i].ChainID != rst[j].ChainID {
+			return rst[i].ChainID < rst[j].ChainID
+		}
+		if rst[i].Address != rst[j].Address {
+			return rst[i].Address.Hex() < rst[j].Address.Hex()
+		}
+		return rst[i].Token.Hex() < rst[j].Token.Hex()
+	})
+
+	return rst, nil
+}
+
+// PortfolioHistory returns the value in a fiat currency of all the tokens
+// held by the accounts on the given chains. Tokens we don't know the symbol of
+// can't be priced and are left out
+func (bh *BalanceHistory) PortfolioHistory(ctx context.Context, networks []*params.Network, addresses []common.Address, interval BalanceHistoryTimeInterval, currency string) ([]PricePoint, error) {
+	if bh.priceProvider == nil {
+		return nil, ErrNoPriceProvider
+	}
+
+	histories, err := bh.History(networks, addresses, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	var rst []PricePoint
+	prices := make(map[string][]PricePoint)
+	for _, history := range histories {
+		if history.Symbol == "" {
+			continue
+		}
+
+		if rst == nil {
+			rst = make([]PricePoint, len(history.History))
+			for i, state := range history.History {
+				rst[i].Timestamp = state.Timestamp
+			}
+		}
+
+		symbolPrices, ok := prices[history.Symbol]
+		if !ok {
+			from := history.History[0].Timestamp
+			to := history.History[len(history.History)-1].Timestamp
+			symbolPrices, err = bh.priceProvider.FetchHistoricalPrices(ctx, history.Symbol, currency, from, to)
+			if err != nil {
+				return nil, err
+			}
+			prices[history.Symbol] = symbolPrices
+		}
+
+		for i, state := range history.History {
+			rst[i].Value += tokenAmount(state.Balance.ToInt(), history.Decimals) * priceAt(symbolPrices, state.Timestamp)
+		}
+	}
+
+	return rst, nil
+}
+
+// sampleTimestamps returns the timestamps the balances are sampled at, they
+// are aligned on the step of the interval so that they can be cached, the
+// last one is always the current time
+func (bh *BalanceHistory) sampleTimestamps(networks []*params.Network, addresses []common.Address, interval BalanceHistoryTimeInterval, now time.Time) ([]uint64, error) {
+	var start time.Time
+	var step time.Duration
+	if interval == BalanceHistoryAll {
+		first, err := bh.firstTransferTime(networks, addresses)
+		if err != nil {
+			return nil, err
+		}
+		start = now
+		if first != nil {
+			start = *first
+		}
+		for _, step = range balanceHistoryAllSteps {
+			if now.Sub(start)/step <= balanceHistoryAllMaxPoints {
+				break
+			}
+		}
+	} else {
+		historyRange, ok := balanceHistoryRanges[interval]
+		if !ok {
+			return nil, ErrInvalidBalanceHistoryTimeInterval
+		}
+		start = now.Add(-historyRange.duration)
+		step = historyRange.step
+	}
+
+	stepSeconds := uint64(step.Seconds())
+	var timestamps []uint64
+	for timestamp := (uint64(start.Unix()) + stepSeconds - 1) / stepSeconds * stepSeconds; timestamp < uint64(now.Unix()); timestamp += stepSeconds {
+		timestamps = append(timestamps, timestamp)
+	}
+
+	return append(timestamps, uint64(now.Unix())), nil
+}
+
+func (bh *BalanceHistory) firstTransferTime(networks []*params.Network, addresses []common.Address) (*time.Time, error) {
+	var first *time.Time
+	for _, network := range networks {
+		for _, address := range addresses {
+			var timestamp sql.NullInt64
+			err := bh.db.QueryRow(`SELECT MIN(timestamp) FROM transfers WHERE network_id = ? AND address = ? AND loaded = 1`, network.ChainID, address).Scan(&timestamp)
+			if err != nil {
+				return nil, err
+			}
+			if !timestamp.Valid {
+				continue
+			}
+			t := time.Unix(timestamp.Int64, 0)
+			if first == nil || t.Before(*first) {
+				first = &t
+			}
+		}
+	}
+
+	return first, nil
+}
+
+// tokenBalances returns the balance of each token of an account at the
+// given timestamps, which must be ordered
+func (bh *BalanceHistory) tokenBalances(chainID uint64, address common.Address, timestamps []uint64) (map[common.Address][]*big.Int, error) {
+	fingerprint, lastTransfer, err := bh.fingerprint(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Balances don't change after the last transfer, all the timestamps
+	// past it share the same cache entry
+	keys := make([]uint64, len(timestamps))
+	for i, timestamp := range timestamps {
+		keys[i] = timestamp
+		if timestamp > lastTransfer {
+			keys[i] = lastTransfer
+		}
+	}
+
+	balances, err := bh.cachedBalances(chainID, address, fingerprint, keys)
+	if err != nil {
+		return nil, err
+	}
+	if balances != nil {
+		return balances, nil
+	}
+
+	balances, err = bh.reconstructBalances(chainID, address, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return balances, bh.cacheBalances(chainID, address, fingerprint, keys, balances)
+}
+
+// fingerprint identifies the state of the transfers and balance of an
+// account, the cached balances are valid as long as it doesn't change
+func (bh *BalanceHistory) fingerprint(chainID uint64, address common.Address) (string, uint64, error) {
+	var count, lastBlock, lastTransfer int64
+	err := bh.db.QueryRow(`SELECT COUNT(*), COALESCE(MAX(blk_number), 0), COALESCE(MAX(timestamp), 0) FROM transfers WHERE network_id = ? AND address = ? AND loaded = 1`, chainID, address).Scan(&count, &lastBlock, &lastTransfer)
+	if err != nil {
+		return "", 0, err
+	}
+
+	lastKnownBlock, err := bh.block.GetLastKnownBlockByAddress(chainID, address)
+	if err != nil {
+		return "", 0, err
+	}
+
+	fingerprint := fmt.Sprintf("%d-%d", count, lastBlock)
+	if lastKnownBlock != nil {
+		fingerprint = fmt.Sprintf("%s-%s-%s", fingerprint, lastKnownBlock.Number, lastKnownBlock.Balance)
+	}
+
+	return fingerprint, uint64(lastTransfer), nil
+}
+
+// cachedBalances returns nil unless the balances are cached for all the timestamps
+func (bh *BalanceHistory) cachedBalances(chainID uint64, address common.Address, fingerprint string, timestamps []uint64) (map[common.Address][]*big.Int, error) {
+	_, err := bh.db.Exec(`DELETE FROM balance_history WHERE network_id = ? AND address = ? AND fingerprint != ?`, chainID, address, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{chainID, address}
+	for _, timestamp := range timestamps {
+		args = append(args, timestamp)
+	}
+	inVector := strings.Repeat("?, ", len(timestamps)-1) + "?"
+	rows, err := bh.db.Query(`SELECT token, timestamp, balance FROM balance_history WHERE network_id = ? AND address = ? AND timestamp IN (`+inVector+`)`, args...) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cached := make(map[common.Address]map[uint64]*big.Int)
+	for rows.Next() {
+		var token common.Address
+		var timestamp uint64
+		balance := new(big.Int)
+		err := rows.Scan(&token, &timestamp, (*bigint.SQLBigIntBytes)(balance))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := cached[token]; !ok {
+			cached[token] = make(map[uint64]*big.Int)
+		}
+		cached[token][timestamp] = balance
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The native balance is stored for every timestamp sampled
+	for _, timestamp := range timestamps {
+		if _, ok := cached[common.Address{}][timestamp]; !ok {
+			return nil, nil
+		}
+	}
+
+	balances := make(map[common.Address][]*big.Int)
+	for token, tokenBalances := range cached {
+		balances[token] = make([]*big.Int, len(timestamps))
+		for i, timestamp := range timestamps {
+			balances[token][i] = tokenBalances[timestamp]
+			if balances[token][i] == nil {
+				balances[token][i] = new(big.Int)
+			}
+		}
+	}
+
+	return balances, nil
+}
+
+func (bh *BalanceHistory) cacheBalances(chainID uint64, address common.Address, fingerprint string, timestamps []uint64, balances map[common.Address][]*big.Int) (err error) {
+	tx, err := bh.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	insert, err := tx.Prepare(`INSERT INTO balance_history (network_id, address, token, timestamp, balance, fingerprint) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for token, tokenBalances := range balances {
+		for i, timestamp := range timestamps {
+			_, err = insert.Exec(chainID, address, token, timestamp, (*bigint.SQLBigIntBytes)(tokenBalances[i]), fingerprint)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconstructBalances replays the transfers of an account. The native
+// balance is anchored on the last balance fetched by the transfer controller,
+// token balances start from zero
+func (bh *BalanceHistory) reconstructBalances(chainID uint64, address common.Address, timestamps []uint64) (map[common.Address][]*big.Int, error) {
+	transfers, err := bh.transfers.GetTransfersInRange(chainID, address, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]transfer.BalanceChange, 0, len(transfers))
+	for _, t := range transfers {
+		changes = append(changes, t.BalanceChange())
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Timestamp != changes[j].Timestamp {
+			return changes[i].Timestamp < changes[j].Timestamp
+		}
+		return changes[i].BlockNumber.Cmp(changes[j].BlockNumber) < 0
+	})
+
+	current := map[common.Address]*big.Int{{}: new(big.Int)}
+	for _, change := range changes {
+		if _, ok := current[change.Token]; !ok {
+			current[change.Token] = new(big.Int)
+		}
+	}
+
+	lastKnownBlock, err := bh.block.GetLastKnownBlockByAddress(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	if lastKnownBlock != nil {
+		opening := current[common.Address{}].Set(lastKnownBlock.Balance)
+		for _, change := range changes {
+			if change.Token == (common.Address{}) && change.BlockNumber.Cmp(lastKnownBlock.Number) <= 0 {
+				opening.Sub(opening, change.Amount)
+			}
+		}
+	}
+
+	balances := make(map[common.Address][]*big.Int)
+	for token := range current {
+		balances[token] = make([]*big.Int, len(timestamps))
+	}
+
+	next := 0
+	for i, timestamp := range timestamps {
+		for ; next < len(changes) && changes[next].Timestamp <= timestamp; next++ {
+			current[changes[next].Token].Add(current[changes[next].Token], changes[next].Amount)
+		}
+		for token, balance := range current {
+			// Approximated fees can bring the balance below zero
+			if balance.Sign() < 0 {
+				balances[token][i] = new(big.Int)
+			} else {
+				balances[token][i] = new(big.Int).Set(balance)
+			}
+		}
+	}
+
+	return balances, nil
+}
+
+func findToken(chainID uint64, address common.Address, customs []*Token) *Token {
+	if token, ok := tokenStore[chainID][address]; ok {
+		return token
+	}
+	for _, token := range customs {
+		if token.ChainID == chainID && token.Address == address {
+			return token
+		}
+	}
+	return nil
+}
+
+func allZero(balances []*big.Int) bool {
+	for _, balance := range balances {
+		if balance.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenAmount converts a balance in the smallest unit of a token to its
+// amount of tokens
+func tokenAmount(balance *big.Int, decimals uint) float64 {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	amount, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), new(big.Float).SetInt(unit)).Float64()
+	return amount
+}
@@ -0,0 +1,234 @@
+package wallet
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/planq-network/status-go/appdatabase"
+	"github.com/planq-network/status-go/params"
+	"github.com/planq-network/status-go/services/wallet/transfer"
+)
+
+type fakePriceProvider struct {
+	prices map[string]float64
+	calls  int
+}
+
+func (p *fakePriceProvider) FetchMarketValues(ctx context.Context, symbols []string, currency string) (map[string]TokenMarketValues, error) {
+	rst := make(map[string]TokenMarketValues)
+	for _, symbol := range symbols {
+		rst[symbol] = TokenMarketValues{Price: p.prices[symbol]}
+	}
+	return rst, nil
+}
+
+func (p *fakePriceProvider) FetchHistoricalPrices(ctx context.Context, symbol string, currency string, from, to uint64) ([]PricePoint, error) {
+	p.calls++
+	return []PricePoint{{Timestamp: from, Value: p.prices[symbol]}}, nil
+}
+
+func setupTestBalanceHistoryDB(t *testing.T, priceProvider PriceProvider) (*BalanceHistory, *transfer.Database, func()) {
+	tmpfile, err := ioutil.TempFile("", "wallet-balance-history-tests-")
+	require.NoError(t, err)
+	db, err := appdatabase.InitializeDB(tmpfile.Name(), "wallet-balance-history-tests")
+	require.NoError(t, err)
+	return NewBalanceHistory(db, &TokenManager{db}, priceProvider), transfer.NewDB(db), func() {
+		require.NoError(t, db.Close())
+		require.NoError(t, os.Remove(tmpfile.Name()))
+	}
+}
+
+var (
+	testNetwork = &params.Network{
+		ChainID:                777,
+		NativeCurrencySymbol:   "ETH",
+		NativeCurrencyDecimals: 18,
+	}
+	testAccount = common.Address{1}
+	testToken   = Token{
+		Address:  common.Address{2},
+		Name:     "Token",
+		Symbol:   "TKN",
+		Decimals: 2,
+		ChainID:  777,
+	}
+	ether = big.NewInt(1000000000000000000)
+)
+
+func ethers(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), ether)
+}
+
+func saveTestTransfer(t *testing.T, db *transfer.Database, balance *big.Int, transfers ...transfer.Transfer) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	last := transfers[len(transfers)-1]
+	var headers []*transfer.DBHeader
+	var blocks []*big.Int
+	for i := range transfers {
+		signed, err := types.SignTx(transfers[i].Transaction, types.HomesteadSigner{}, key)
+		require.NoError(t, err)
+		transfers[i].Transaction = signed
+		if transfers[i].Receipt == nil {
+			transfers[i].Receipt = types.NewReceipt(nil, false, 0)
+		}
+		transfers[i].Receipt.Logs = []*types.Log{}
+		headers = append(headers, &transfer.DBHeader{Number: transfers[i].BlockNumber, Hash: transfers[i].BlockHash})
+		blocks = append(blocks, transfers[i].BlockNumber)
+	}
+
+	require.NoError(t, db.ProcessBlocks(testNetwork.ChainID, testAccount, big.NewInt(0), &transfer.LastKnownBlock{Number: last.BlockNumber, Balance: balance}, headers))
+	require.NoError(t, db.SaveTranfers(testNetwork.ChainID, testAccount, transfers, blocks))
+}
+
+func ethTransferAt(id byte, block int64, timestamp time.Time, from, to common.Address, value *big.Int, gasUsed uint64) transfer.Transfer {
+	receipt := types.NewReceipt(nil, false, gasUsed)
+	receipt.GasUsed = gasUsed
+	return transfer.Transfer{
+		Type:        transfer.Type("eth"),
+		ID:          common.Hash{id},
+		Address:     testAccount,
+		BlockNumber: big.NewInt(block),
+		BlockHash:   common.Hash{id},
+		Timestamp:   uint64(timestamp.Unix()),
+		Transaction: types.NewTransaction(0, to, value, gasUsed, big.NewInt(1000000000), nil),
+		From:        from,
+		Receipt:     receipt,
+	}
+}
+
+func erc20TransferAt(id byte, block int64, timestamp time.Time, from, to common.Address, amount int64) transfer.Transfer {
+	return transfer.Transfer{
+		Type:        transfer.Type("erc20"),
+		ID:          common.Hash{id},
+		Address:     testAccount,
+		BlockNumber: big.NewInt(block),
+		BlockHash:   common.Hash{id},
+		Timestamp:   uint64(timestamp.Unix()),
+		Transaction: types.NewTransaction(0, testToken.Address, nil, 0, big.NewInt(0), nil),
+		From:        from,
+		Log: &types.Log{
+			Address: testToken.Address,
+			Topics:  []common.Hash{{}, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+		},
+	}
+}
+
+func TestBalanceHistory(t *testing.T) {
+	priceProvider := &fakePriceProvider{prices: map[string]float64{"ETH": 1000, "TKN": 2}}
+	balanceHistory, db, stop := setupTestBalanceHistoryDB(t, priceProvider)
+	defer stop()
+	require.NoError(t, balanceHistory.tokenManager.upsertCustom(testToken))
+
+	now := time.Now()
+	other := common.Address{3}
+	fee := big.NewInt(21000 * 1000000000)
+	balance := new(big.Int).Sub(ethers(2), fee)
+	saveTestTransfer(t, db, balance,
+		ethTransferAt(1, 10, now.Add(-10*24*time.Hour), other, testAccount, ethers(3), 21000),
+		erc20TransferAt(2, 15, now.Add(-5*24*time.Hour), other, testAccount, 100),
+		ethTransferAt(3, 20, now.Add(-2*24*time.Hour), testAccount, other, ethers(1), 21000),
+	)
+
+	networks := []*params.Network{testNetwork}
+	histories, err := balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistory1M)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+
+	native := histories[0]
+	require.Equal(t, common.Address{}, native.Token)
+	require.Equal(t, "ETH", native.Symbol)
+	require.Equal(t, uint64(now.Unix()), native.History[len(native.History)-1].Timestamp)
+	for _, state := range native.History {
+		expected := new(big.Int)
+		switch {
+		case state.Timestamp >= uint64(now.Add(-2*24*time.Hour).Unix()):
+			expected = balance
+		case state.Timestamp >= uint64(now.Add(-10*24*time.Hour).Unix()):
+			expected = ethers(3)
+		}
+		require.Equal(t, expected, state.Balance.ToInt(), "balance at %d", state.Timestamp)
+	}
+
+	token := histories[1]
+	require.Equal(t, testToken.Address, token.Token)
+	require.Equal(t, "TKN", token.Symbol)
+	require.Equal(t, uint(2), token.Decimals)
+	require.Equal(t, big.NewInt(100), token.History[len(token.History)-1].Balance.ToInt())
+	require.Equal(t, big.NewInt(0), token.History[0].Balance.ToInt())
+
+	// The whole history starts with the first transfer
+	all, err := balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistoryAll)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, ethers(3), all[0].History[0].Balance.ToInt())
+
+	portfolio, err := balanceHistory.PortfolioHistory(context.Background(), networks, []common.Address{testAccount}, BalanceHistory1M, "usd")
+	require.NoError(t, err)
+	require.Len(t, portfolio, len(native.History))
+	require.Equal(t, float64(0), portfolio[0].Value)
+	require.InDelta(t, 1999.979+2, portfolio[len(portfolio)-1].Value, 0.0001)
+	require.Equal(t, 2, priceProvider.calls)
+
+	_, err = balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistoryTimeInterval(0))
+	require.Equal(t, ErrInvalidBalanceHistoryTimeInterval, err)
+
+	balanceHistory.priceProvider = nil
+	_, err = balanceHistory.PortfolioHistory(context.Background(), networks, []common.Address{testAccount}, BalanceHistory1M, "usd")
+	require.Equal(t, ErrNoPriceProvider, err)
+}
+
+func TestBalanceHistoryCache(t *testing.T) {
+	balanceHistory, db, stop := setupTestBalanceHistoryDB(t, nil)
+	defer stop()
+
+	now := time.Now()
+	other := common.Address{3}
+	saveTestTransfer(t, db, ethers(3),
+		ethTransferAt(1, 10, now.Add(-10*24*time.Hour), other, testAccount, ethers(3), 21000),
+	)
+
+	networks := []*params.Network{testNetwork}
+	_, err := balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistory1W)
+	require.NoError(t, err)
+
+	var cached int
+	require.NoError(t, balanceHistory.db.QueryRow(`SELECT COUNT(*) FROM balance_history`).Scan(&cached))
+	require.NotZero(t, cached)
+
+	// The cache is used as long as the transfers don't change
+	_, err = balanceHistory.db.Exec(`UPDATE balance_history SET balance = ?`, ethers(5).Bytes())
+	require.NoError(t, err)
+	histories, err := balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistory1W)
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	require.Equal(t, ethers(5), histories[0].History[len(histories[0].History)-1].Balance.ToInt())
+
+	saveTestTransfer(t, db, ethers(4),
+		ethTransferAt(2, 20, now.Add(-time.Hour), other, testAccount, ethers(1), 21000),
+	)
+	histories, err = balanceHistory.History(networks, []common.Address{testAccount}, BalanceHistory1W)
+	require.NoError(t, err)
+	require.Equal(t, ethers(3), histories[0].History[0].Balance.ToInt())
+	require.Equal(t, ethers(4), histories[0].History[len(histories[0].History)-1].Balance.ToInt())
+}
+
+func TestPriceAt(t *testing.T) {
+	prices := []PricePoint{{Timestamp: 10, Value: 1}, {Timestamp: 20, Value: 2}}
+	require.Equal(t, float64(1), priceAt(prices, 5))
+	require.Equal(t, float64(1), priceAt(prices, 15))
+	require.Equal(t, float64(2), priceAt(prices, 20))
+	require.Equal(t, float64(0), priceAt(nil, 20))
+}
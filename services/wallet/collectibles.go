@@ -0,0 +1,620 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/planq-network/status-go/rpc"
+	"github.com/planq-network/status-go/services/wallet/chain"
+	"github.com/planq-network/status-go/services/wallet/transfer"
+)
+
+const defaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+// collectibleMetadataRetryInterval is the time after which metadata that
+// couldn't be resolved is fetched again
+const collectibleMetadataRetryInterval = 24 * time.Hour
+
+// collectibleMetadataSizeLimit is the maximum size of a metadata document
+const collectibleMetadataSizeLimit = 1 << 20
+
+// collectibleMetadataTimeout is the time spent resolving the metadata of the
+// collectibles of a collection, metadata not resolved by then isn't cached
+const collectibleMetadataTimeout = 30 * time.Second
+
+// collectibleMetadataConcurrency is the number of metadata documents fetched at once
+const collectibleMetadataConcurrency = 5
+
+var ErrInvalidCollection = errors.New("invalid collection")
+
+var (
+	errForbiddenMetadataURI     = errors.New("token metadata must be at an https URL or on IPFS")
+	errForbiddenMetadataAddress = errors.New("token metadata can't be fetched from a private address")
+)
+
+// privateNetworks are the networks token metadata isn't fetched from, on top
+// of the loopback, link local and unspecified addresses
+var privateNetworks []*net.IPNet
+
+func init() {
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		privateNetworks = append(privateNetworks, network)
+	}
+}
+
+var (
+	erc721TokenURISelector = crypto.Keccak256([]byte("tokenURI(uint256)"))[:4]
+	erc1155URISelector     = crypto.Keccak256([]byte("uri(uint256)"))[:4]
+	contractNameSelector   = crypto.Keccak256([]byte("name()"))[:4]
+)
+
+var stringArguments abi.Arguments
+
+func init() {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	stringArguments = abi.Arguments{{Type: stringType}}
+}
+
+type contractCaller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// tokenMetadataAttribute is an attribute of the metadata JSON schema of
+// ERC-721 and ERC-1155, values are numbers or strings
+type tokenMetadataAttribute struct {
+	TraitType   string     `json:"trait_type"`
+	Value       TraitValue `json:"value"`
+	DisplayType string     `json:"display_type"`
+	MaxValue    TraitValue `json:"max_value"`
+}
+
+type tokenMetadata struct {
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description"`
+	Image           string                   `json:"image"`
+	ImageURL        string                   `json:"image_url"`
+	AnimationURL    string                   `json:"animation_url"`
+	BackgroundColor string                   `json:"background_color"`
+	ExternalURL     string                   `json:"external_url"`
+	Attributes      []tokenMetadataAttribute `json:"attributes"`
+}
+
+type collectibleMetadata struct {
+	TokenURI        string
+	Name            string
+	Description     string
+	ImageURL        string
+	AnimationURL    string
+	BackgroundColor string
+	ExternalURL     string
+	Traits          []OpenseaTrait
+	Resolved        bool
+	FetchedAt       int64
+}
+
+// CollectiblesManager indexes the collectibles of an account from its token
+// transfers, and resolves their metadata from the token contracts. OpenSea is
+// only used to enrich collections when an API key is configured.
+type CollectiblesManager struct {
+	db            *sql.DB
+	transfers     *transfer.Database
+	httpClient    *http.Client
+	ipfsGateway   string
+	retryInterval time.Duration
+	// metadataTimeout and metadataConcurrency bound the resolution of the
+	// metadata of a collection
+	metadataTimeout     time.Duration
+	metadataConcurrency int
+	callers             func(chainID uint64) (contractCaller, error)
+	opensea             func(chainID uint64) (*OpenseaClient, error)
+}
+
+func NewCollectiblesManager(db *sql.DB, rpcClient *rpc.Client, ipfsGateway string, openseaAPIKey string) *CollectiblesManager {
+	if ipfsGateway == "" {
+		ipfsGateway = defaultIPFSGateway
+	}
+	if !strings.HasSuffix(ipfsGateway, "/") {
+		ipfsGateway += "/"
+	}
+
+	return &CollectiblesManager{
+		db:                  db,
+		transfers:           transfer.NewDB(db),
+		httpClient:          newMetadataHTTPClient(ipfsGateway),
+		ipfsGateway:         ipfsGateway,
+		retryInterval:       collectibleMetadataRetryInterval,
+		metadataTimeout:     collectibleMetadataTimeout,
+		metadataConcurrency: collectibleMetadataConcurrency,
+		callers: func(chainID uint64) (contractCaller, error) {
+			return chain.NewClient(rpcClient, chainID)
+		},
+		opensea: func(chainID uint64) (*OpenseaClient, error) {
+			if openseaAPIKey == "" {
+				return nil, errors.New("no OpenSea API key")
+			}
+			return newOpenseaClient(chainID, openseaAPIKey)
+		},
+	}
+}
+
+// GetCollectionsByOwner returns the collections of the collectibles held by
+// an account, the slug of a collection is its contract address
+func (m *CollectiblesManager) GetCollectionsByOwner(ctx context.Context, chainID uint64, owner common.Address) ([]OpenseaCollection, error) {
+	balances, err := m.transfers.GetCollectibleBalances(chainID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := []OpenseaCollection{}
+	bySlug := map[string]int{}
+	for _, balance := range balances {
+		slug := collectionSlug(balance.Contract)
+		i, ok := bySlug[slug]
+		if !ok {
+			collections = append(collections, OpenseaCollection{
+				Name:                  m.contractName(ctx, chainID, balance.Contract),
+				Slug:                  slug,
+				Traits:                map[string]OpenseaCollectionTrait{},
+				PrimaryAssetContracts: []OpenseaContract{{Address: balance.Contract.Hex()}},
+			})
+			i = len(collections) - 1
+			bySlug[slug] = i
+		}
+		collections[i].OwnedAssetCount += int(balance.Balance.Int64())
+	}
+
+	m.enrichCollections(chainID, owner, collections, bySlug)
+
+	return collections, nil
+}
+
+// GetCollectiblesByOwnerAndCollection returns the collectibles of a collection
+// held by an account, with the metadata of their token URI
+func (m *CollectiblesManager) GetCollectiblesByOwnerAndCollection(ctx context.Context, chainID uint64, owner common.Address, slug string, limit int) ([]OpenseaAsset, error) {
+	if !common.IsHexAddress(slug) {
+		return nil, ErrInvalidCollection
+	}
+	contract := common.HexToAddress(slug)
+
+	balances, err := m.transfers.GetCollectibleBalances(chainID, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var collectibles []transfer.CollectibleBalance
+	for _, balance := range balances {
+		if balance.Contract != contract {
+			continue
+		}
+		if limit > 0 && len(collectibles) >= limit {
+			break
+		}
+		collectibles = append(collectibles, balance)
+	}
+
+	assets := []OpenseaAsset{}
+	if len(collectibles) == 0 {
+		return assets, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.metadataTimeout)
+	defer cancel()
+
+	collectionName := m.contractName(ctx, chainID, contract)
+	metadatas, err := m.collectiblesMetadata(ctx, chainID, collectibles)
+	if err != nil {
+		return nil, err
+	}
+	for i, balance := range collectibles {
+		metadata := metadatas[i]
+		assets = append(assets, OpenseaAsset{
+			TokenID:           balance.TokenID.String(),
+			Name:              metadata.Name,
+			Description:       metadata.Description,
+			Permalink:         metadata.ExternalURL,
+			ImageThumbnailURL: metadata.ImageURL,
+			ImageURL:          metadata.ImageURL,
+			AnimationURL:      metadata.AnimationURL,
+			Contract:          OpenseaContract{Address: contract.Hex()},
+			Collection:        OpenseaAssetCollection{Name: collectionName},
+			Traits:            metadata.Traits,
+			BackgroundColor:   metadata.BackgroundColor,
+		})
+	}
+
+	return assets, nil
+}
+
+// enrichCollections sets the names and images OpenSea has for the collections,
+// failures are not fatal as the collections are known already
+func (m *CollectiblesManager) enrichCollections(chainID uint64, owner common.Address, collections []OpenseaCollection, bySlug map[string]int) {
+	if len(collections) == 0 {
+		return
+	}
+	client, err := m.opensea(chainID)
+	if err != nil {
+		return
+	}
+
+	enrichments, err := client.fetchAllCollectionsByOwner(owner)
+	if err != nil {
+		log.Warn("failed to enrich collections", "chainID", chainID, "error", err)
+		return
+	}
+	for _, enrichment := range enrichments {
+		for _, contract := range enrichment.PrimaryAssetContracts {
+			i, ok := bySlug[strings.ToLower(contract.Address)]
+			if !ok {
+				continue
+			}
+			if enrichment.Name != "" {
+				collections[i].Name = enrichment.Name
+			}
+			collections[i].ImageURL = enrichment.ImageURL
+			if enrichment.Traits != nil {
+				collections[i].Traits = enrichment.Traits
+			}
+		}
+	}
+}
+
+// contractName returns the name of a token contract, or its address if it
+// has none
+func (m *CollectiblesManager) contractName(ctx context.Context, chainID uint64, contract common.Address) string {
+	var name string
+	var fetchedAt int64
+	err := m.db.QueryRow("SELECT name, fetched_at FROM collectibles_contracts WHERE network_id = ? AND contract = ?", chainID, contract).Scan(&name, &fetchedAt)
+	if err == nil && (name != "" || time.Since(time.Unix(fetchedAt, 0)) < m.retryInterval) {
+		return contractNameOrAddress(name, contract)
+	}
+	if err != nil && err != sql.ErrNoRows {
+		log.Error("failed to read collection name", "contract", contract, "error", err)
+	}
+
+	name, err = m.callString(ctx, chainID, contract, contractNameSelector)
+	if err != nil {
+		log.Debug("failed to fetch collection name", "contract", contract, "error", err)
+	}
+	_, err = m.db.Exec("INSERT INTO collectibles_contracts(network_id, contract, name, fetched_at) VALUES (?, ?, ?, ?)", chainID, contract, name, time.Now().Unix())
+	if err != nil {
+		log.Error("failed to save collection name", "contract", contract, "error", err)
+	}
+
+	return contractNameOrAddress(name, contract)
+}
+
+// collectiblesMetadata returns the metadata of collectibles, fetching at most
+// metadataConcurrency documents at once
+func (m *CollectiblesManager) collectiblesMetadata(ctx context.Context, chainID uint64, balances []transfer.CollectibleBalance) ([]*collectibleMetadata, error) {
+	metadatas := make([]*collectibleMetadata, len(balances))
+	errs := make([]error, len(balances))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, m.metadataConcurrency)
+	for i, balance := range balances {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, balance transfer.CollectibleBalance) {
+			defer wg.Done()
+			metadatas[i], errs[i] = m.metadata(ctx, chainID, balance)
+			<-slots
+		}(i, balance)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return metadatas, nil
+}
+
+// metadata returns the metadata of a collectible, metadata that couldn't be
+// resolved is retried once the retry interval elapsed
+func (m *CollectiblesManager) metadata(ctx context.Context, chainID uint64, balance transfer.CollectibleBalance) (*collectibleMetadata, error) {
+	cached, err := m.cachedMetadata(chainID, balance.Contract, balance.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && (cached.Resolved || time.Since(time.Unix(cached.FetchedAt, 0)) < m.retryInterval) {
+		return cached, nil
+	}
+
+	metadata := m.resolveMetadata(ctx, chainID, balance)
+	if !metadata.Resolved && ctx.Err() != nil {
+		// out of time, not a failure of the token URI
+		return metadata, nil
+	}
+	err = m.saveMetadata(chainID, balance.Contract, balance.TokenID, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (m *CollectiblesManager) resolveMetadata(ctx context.Context, chainID uint64, balance transfer.CollectibleBalance) *collectibleMetadata {
+	rst := &collectibleMetadata{FetchedAt: time.Now().Unix()}
+
+	selector := erc721TokenURISelector
+	if balance.IsERC1155() {
+		selector = erc1155URISelector
+	}
+	uri, err := m.callString(ctx, chainID, balance.Contract, selector, common.LeftPadBytes(balance.TokenID.Bytes(), 32))
+	if err != nil {
+		log.Debug("failed to fetch token uri", "contract", balance.Contract, "tokenID", balance.TokenID, "error", err)
+		return rst
+	}
+	if balance.IsERC1155() {
+		uri = strings.Replace(uri, "{id}", fmt.Sprintf("%064x", balance.TokenID), -1)
+	}
+	rst.TokenURI = uri
+
+	body, err := m.fetchURI(ctx, uri)
+	if err != nil {
+		log.Debug("failed to fetch token metadata", "uri", uri, "error", err)
+		return rst
+	}
+
+	metadata := tokenMetadata{}
+	err = json.Unmarshal(body, &metadata)
+	if err != nil {
+		log.Debug("invalid token metadata", "uri", uri, "error", err)
+		return rst
+	}
+
+	rst.Name = metadata.Name
+	rst.Description = metadata.Description
+	rst.ImageURL = m.resolveURI(metadata.Image)
+	if rst.ImageURL == "" {
+		rst.ImageURL = m.resolveURI(metadata.ImageURL)
+	}
+	rst.AnimationURL = m.resolveURI(metadata.AnimationURL)
+	rst.BackgroundColor = metadata.BackgroundColor
+	rst.ExternalURL = metadata.ExternalURL
+	rst.Traits = make([]OpenseaTrait, 0, len(metadata.Attributes))
+	for _, attribute := range metadata.Attributes {
+		rst.Traits = append(rst.Traits, OpenseaTrait{
+			TraitType:   attribute.TraitType,
+			Value:       attribute.Value,
+			DisplayType: attribute.DisplayType,
+			MaxValue:    string(attribute.MaxValue),
+		})
+	}
+	rst.Resolved = true
+
+	return rst
+}
+
+// resolveURI turns IPFS URIs into URLs of the configured gateway
+func (m *CollectiblesManager) resolveURI(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://ipfs/"):
+		return m.ipfsGateway + strings.TrimPrefix(uri, "ipfs://ipfs/")
+	case strings.HasPrefix(uri, "ipfs://"):
+		return m.ipfsGateway + strings.TrimPrefix(uri, "ipfs://")
+	}
+	return uri
+}
+
+// fetchURI returns the document at an URI, data URIs are decoded in place
+func (m *CollectiblesManager) fetchURI(ctx context.Context, uri string) ([]byte, error) {
+	if strings.HasPrefix(uri, "data:") {
+		return decodeDataURI(uri)
+	}
+
+	u := m.resolveURI(uri)
+	if !allowedMetadataURL(u, m.ipfsGateway) {
+		return nil, errForbiddenMetadataURI
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error("failed to close token metadata request body", "err", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token metadata request failed with status %d", resp.StatusCode)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, collectibleMetadataSizeLimit))
+}
+
+// newMetadataHTTPClient returns the client fetching token metadata. The URIs
+// are set by the token contracts, so the client refuses to connect to private
+// addresses, which would reach the services of the user's network, unless
+// they are the ones of the configured IPFS gateway.
+func newMetadataHTTPClient(ipfsGateway string) *http.Client {
+	var gatewayAddress string
+	if gateway, err := url.Parse(ipfsGateway); err == nil {
+		port := gateway.Port()
+		if port == "" {
+			port = "80"
+			if gateway.Scheme == "https" {
+				port = "443"
+			}
+		}
+		gatewayAddress = net.JoinHostPort(gateway.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	publicDialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || privateIP(ip) {
+				return errForbiddenMetadataAddress
+			}
+			return nil
+		},
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				if address == gatewayAddress {
+					return dialer.DialContext(ctx, network, address)
+				}
+				return publicDialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if !allowedMetadataURL(req.URL.String(), ipfsGateway) {
+			return errForbiddenMetadataURI
+		}
+		return nil
+	}
+	return client
+}
+
+// allowedMetadataURL returns whether token metadata can be fetched from an
+// URL, only https URLs and the IPFS gateway are
+func allowedMetadataURL(u string, ipfsGateway string) bool {
+	return strings.HasPrefix(u, "https://") || strings.HasPrefix(u, ipfsGateway)
+}
+
+func privateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *CollectiblesManager) callString(ctx context.Context, chainID uint64, contract common.Address, selector []byte, args ...[]byte) (string, error) {
+	caller, err := m.callers(chainID)
+	if err != nil {
+		return "", err
+	}
+
+	data := append([]byte{}, selector...)
+	for _, arg := range args {
+		data = append(data, arg...)
+	}
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := stringArguments.Unpack(out)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+func (m *CollectiblesManager) cachedMetadata(chainID uint64, contract common.Address, tokenID *big.Int) (*collectibleMetadata, error) {
+	rst := &collectibleMetadata{}
+	var traits []byte
+	err := m.db.QueryRow(`SELECT token_uri, name, description, image_url, animation_url, background_color, external_url, traits, resolved, fetched_at
+	FROM collectibles_metadata WHERE network_id = ? AND contract = ? AND token_id = ?`, chainID, contract, tokenID.String()).Scan(
+		&rst.TokenURI, &rst.Name, &rst.Description, &rst.ImageURL, &rst.AnimationURL, &rst.BackgroundColor, &rst.ExternalURL, &traits, &rst.Resolved, &rst.FetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(traits) > 0 {
+		err = json.Unmarshal(traits, &rst.Traits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rst, nil
+}
+
+func (m *CollectiblesManager) saveMetadata(chainID uint64, contract common.Address, tokenID *big.Int, metadata *collectibleMetadata) error {
+	traits, err := json.Marshal(metadata.Traits)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.Exec(`INSERT INTO collectibles_metadata(network_id, contract, token_id, token_uri, name, description, image_url, animation_url, background_color, external_url, traits, resolved, fetched_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		chainID, contract, tokenID.String(), metadata.TokenURI, metadata.Name, metadata.Description, metadata.ImageURL, metadata.AnimationURL, metadata.BackgroundColor, metadata.ExternalURL, traits, metadata.Resolved, metadata.FetchedAt)
+	return err
+}
+
+// decodeDataURI decodes the content of a data URI, either base64 or
+// percent encoded
+func decodeDataURI(uri string) ([]byte, error) {
+	separator := strings.Index(uri, ",")
+	if separator < 0 {
+		return nil, errors.New("invalid data uri")
+	}
+	header, data := uri[len("data:"):separator], uri[separator+1:]
+
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(decoded), nil
+}
+
+func collectionSlug(contract common.Address) string {
+	return strings.ToLower(contract.Hex())
+}
+
+func contractNameOrAddress(name string, contract common.Address) string {
+	if name == "" {
+		return contract.Hex()
+	}
+	return name
+}
@@ -0,0 +1,305 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/planq-network/status-go/appdatabase"
+	"github.com/planq-network/status-go/services/wallet/transfer"
+)
+
+var (
+	collectibleOwner   = common.Address{1}
+	erc721Contract     = common.Address{0xaa}
+	erc1155Contract    = common.Address{0xbb}
+	unresolvedContract = common.Address{0xcc}
+)
+
+// fakeContractCaller answers name, tokenURI and uri calls from fixed values
+type fakeContractCaller struct {
+	names map[common.Address]string
+	uris  map[common.Address]string
+	calls int32
+}
+
+func (c *fakeContractCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	atomic.AddInt32(&c.calls, 1)
+	var value string
+	var ok bool
+	switch string(call.Data[:4]) {
+	case string(contractNameSelector):
+		value, ok = c.names[*call.To]
+	case string(erc721TokenURISelector), string(erc1155URISelector):
+		value, ok = c.uris[*call.To]
+		value = strings.Replace(value, "%d", new(big.Int).SetBytes(call.Data[4:]).String(), -1)
+	}
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return stringArguments.Pack(value)
+}
+
+func saveCollectibleTransfer(t *testing.T, db *transfer.Database, number int64, transferType string, l *types.Log, tokenID, value int64) {
+	l.TxHash = common.BigToHash(big.NewInt(number))
+	header := &transfer.DBHeader{
+		Number: big.NewInt(number),
+		Hash:   common.BigToHash(big.NewInt(number)),
+		Erc20Transfers: []*transfer.Transfer{{
+			Type:       transfer.Type(transferType),
+			ID:         l.TxHash,
+			Address:    collectibleOwner,
+			Log:        l,
+			TokenID:    big.NewInt(tokenID),
+			TokenValue: big.NewInt(value),
+		}},
+	}
+	nonce := int64(0)
+	lastBlock := &transfer.LastKnownBlock{
+		Number:  big.NewInt(number),
+		Balance: big.NewInt(0),
+		Nonce:   &nonce,
+	}
+	require.NoError(t, db.ProcessBlocks(777, collectibleOwner, big.NewInt(number), lastBlock, []*transfer.DBHeader{header}))
+}
+
+func erc721ReceivedLog(contract common.Address, tokenID int64) *types.Log {
+	return &types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			{},
+			common.BytesToHash(collectibleOwner.Bytes()),
+			common.BigToHash(big.NewInt(tokenID)),
+		},
+	}
+}
+
+func erc1155ReceivedLog(contract common.Address, tokenID, value int64) *types.Log {
+	return &types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)")),
+			{},
+			{},
+			common.BytesToHash(collectibleOwner.Bytes()),
+		},
+		Data: append(common.LeftPadBytes(big.NewInt(tokenID).Bytes(), 32), common.LeftPadBytes(big.NewInt(value).Bytes(), 32)...),
+	}
+}
+
+func setupTestCollectiblesDB(t *testing.T) (*CollectiblesManager, *fakeContractCaller, func()) {
+	tmpfile, err := ioutil.TempFile("", "wallet-collectibles-tests-")
+	require.NoError(t, err)
+	db, err := appdatabase.InitializeDB(tmpfile.Name(), "wallet-collectibles-tests")
+	require.NoError(t, err)
+
+	var requests int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var metadata interface{}
+		switch r.URL.Path {
+		case "/ipfs/QmSlow/1":
+			<-r.Context().Done()
+			return
+		case "/ipfs/QmToken/7":
+			metadata = map[string]interface{}{
+				"name":       "Token 7",
+				"image":      "ipfs://ipfs/QmImage/7.png",
+				"attributes": []map[string]interface{}{{"trait_type": "Level", "value": 3, "max_value": 10}, {"trait_type": "Color", "value": "red"}},
+			}
+		case fmt.Sprintf("/metadata/%064x.json", 2):
+			metadata = map[string]interface{}{"name": "Potion", "image": "https://example.com/potion.png"}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := json.Marshal(metadata)
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}))
+
+	caller := &fakeContractCaller{
+		names: map[common.Address]string{erc721Contract: "Kitties"},
+		uris: map[common.Address]string{
+			erc721Contract:  "ipfs://QmToken/%d",
+			erc1155Contract: srv.URL + "/metadata/{id}.json",
+		},
+	}
+	manager := NewCollectiblesManager(db, nil, srv.URL+"/ipfs", "")
+	manager.httpClient = srv.Client()
+	manager.callers = func(chainID uint64) (contractCaller, error) {
+		return caller, nil
+	}
+
+	return manager, caller, func() {
+		srv.Close()
+		require.NoError(t, db.Close())
+		require.NoError(t, os.Remove(tmpfile.Name()))
+	}
+}
+
+func TestCollectiblesByOwner(t *testing.T) {
+	manager, caller, stop := setupTestCollectiblesDB(t)
+	defer stop()
+
+	saveCollectibleTransfer(t, manager.transfers, 1, "erc721", erc721ReceivedLog(erc721Contract, 7), 7, 1)
+	saveCollectibleTransfer(t, manager.transfers, 2, "erc1155", erc1155ReceivedLog(erc1155Contract, 2, 5), 2, 5)
+	saveCollectibleTransfer(t, manager.transfers, 3, "erc721", erc721ReceivedLog(unresolvedContract, 1), 1, 1)
+
+	collections, err := manager.GetCollectionsByOwner(context.Background(), 777, collectibleOwner)
+	require.NoError(t, err)
+	require.Len(t, collections, 3)
+	require.Equal(t, "Kitties", collections[0].Name)
+	require.Equal(t, collectionSlug(erc721Contract), collections[0].Slug)
+	require.Equal(t, erc1155Contract.Hex(), collections[1].Name)
+	require.Equal(t, 5, collections[1].OwnedAssetCount)
+
+	assets, err := manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collections[0].Slug, 10)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	require.Equal(t, "7", assets[0].TokenID)
+	require.Equal(t, "Token 7", assets[0].Name)
+	require.Equal(t, "Kitties", assets[0].Collection.Name)
+	require.Equal(t, manager.ipfsGateway+"QmImage/7.png", assets[0].ImageURL)
+	require.Equal(t, []OpenseaTrait{{TraitType: "Level", Value: "3.00", MaxValue: "10.00"}, {TraitType: "Color", Value: "red"}}, assets[0].Traits)
+
+	assets, err = manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collections[1].Slug, 10)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	require.Equal(t, "Potion", assets[0].Name)
+
+	assets, err = manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collections[2].Slug, 10)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	require.Equal(t, "", assets[0].Name)
+
+	// Metadata and names are cached, unresolved ones until the retry interval elapsed
+	calls := caller.calls
+	_, err = manager.GetCollectionsByOwner(context.Background(), 777, collectibleOwner)
+	require.NoError(t, err)
+	_, err = manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collections[2].Slug, 10)
+	require.NoError(t, err)
+	require.Equal(t, calls, caller.calls)
+
+	manager.retryInterval = 0
+	_, err = manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collections[2].Slug, 10)
+	require.NoError(t, err)
+	require.Equal(t, calls+2, caller.calls)
+
+	_, err = manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, "kitties", 10)
+	require.Equal(t, ErrInvalidCollection, err)
+}
+
+func TestCollectiblesMetadataTimeout(t *testing.T) {
+	manager, caller, stop := setupTestCollectiblesDB(t)
+	defer stop()
+	manager.metadataTimeout = 100 * time.Millisecond
+	caller.uris[unresolvedContract] = "ipfs://QmSlow/%d"
+
+	saveCollectibleTransfer(t, manager.transfers, 1, "erc721", erc721ReceivedLog(unresolvedContract, 1), 1, 1)
+
+	start := time.Now()
+	assets, err := manager.GetCollectiblesByOwnerAndCollection(context.Background(), 777, collectibleOwner, collectionSlug(unresolvedContract), 10)
+	require.NoError(t, err)
+	require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	require.Len(t, assets, 1)
+	require.Equal(t, "", assets[0].Name)
+
+	// Metadata that wasn't fetched in time is fetched again on the next call
+	cached, err := manager.cachedMetadata(777, unresolvedContract, big.NewInt(1))
+	require.NoError(t, err)
+	require.Nil(t, cached)
+}
+
+func TestFetchURIRestrictions(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"Local"}`))
+	}))
+	defer srv.Close()
+
+	manager := NewCollectiblesManager(nil, nil, "", "")
+	for _, uri := range []string{"http://example.com/metadata.json", "file:///etc/passwd", "ftp://example.com/metadata.json"} {
+		_, err := manager.fetchURI(context.Background(), uri)
+		require.Equal(t, errForbiddenMetadataURI, err, uri)
+	}
+
+	// Private addresses are refused, unless they are the IPFS gateway's
+	_, err := manager.fetchURI(context.Background(), srv.URL+"/metadata.json")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), errForbiddenMetadataAddress.Error())
+
+	manager = NewCollectiblesManager(nil, nil, srv.URL+"/ipfs", "")
+	manager.httpClient.Transport.(*http.Transport).TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig
+	body, err := manager.fetchURI(context.Background(), "ipfs://QmLocal")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Local"}`, string(body))
+
+	require.True(t, privateIP(net.ParseIP("192.168.1.1")))
+	require.True(t, privateIP(net.ParseIP("169.254.169.254")))
+	require.True(t, privateIP(net.ParseIP("::1")))
+	require.False(t, privateIP(net.ParseIP("93.184.216.34")))
+}
+
+func TestCollectiblesOpenseaEnrichment(t *testing.T) {
+	manager, _, stop := setupTestCollectiblesDB(t)
+	defer stop()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal([]OpenseaCollection{{
+			Name:                  "CryptoKitties",
+			Slug:                  "cryptokitties",
+			ImageURL:              "https://example.com/kitties.png",
+			PrimaryAssetContracts: []OpenseaContract{{Address: collectionSlug(erc721Contract)}},
+		}})
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	manager.opensea = func(chainID uint64) (*OpenseaClient, error) {
+		return &OpenseaClient{client: srv.Client(), url: srv.URL}, nil
+	}
+
+	saveCollectibleTransfer(t, manager.transfers, 1, "erc721", erc721ReceivedLog(erc721Contract, 7), 7, 1)
+	collections, err := manager.GetCollectionsByOwner(context.Background(), 777, collectibleOwner)
+	require.NoError(t, err)
+	require.Len(t, collections, 1)
+	require.Equal(t, "CryptoKitties", collections[0].Name)
+	require.Equal(t, collectionSlug(erc721Contract), collections[0].Slug)
+	require.Equal(t, "https://example.com/kitties.png", collections[0].ImageURL)
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	metadata := `{"name":"On chain"}`
+	decoded, err := decodeDataURI("data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(metadata)))
+	require.NoError(t, err)
+	require.Equal(t, metadata, string(decoded))
+
+	decoded, err = decodeDataURI("data:application/json,%7B%22name%22%3A%22On%20chain%22%7D")
+	require.NoError(t, err)
+	require.Equal(t, metadata, string(decoded))
+
+	_, err = decodeDataURI("data:application/json")
+	require.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const cryptoCompareURL = "https://min-api.cryptocompare.com"
+
+// cryptoCompareSymbolsLimit is the number of symbols fetched per request,
+// the API limits the length of the list
+const cryptoCompareSymbolsLimit = 50
+
+// cryptoCompareHistoryLimit is the maximum number of points of a history request
+const cryptoCompareHistoryLimit = 2000
+
+type cryptoCompareMarketValues struct {
+	Price     float64 `json:"PRICE"`
+	Change24h float64 `json:"CHANGEPCT24HOUR"`
+	MarketCap float64 `json:"MKTCAP"`
+}
+
+type cryptoCompareMarketValuesContainer struct {
+	Raw map[string]map[string]cryptoCompareMarketValues `json:"RAW"`
+}
+
+type cryptoCompareHistoryContainer struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+	Data     struct {
+		Data []struct {
+			Time  uint64  `json:"time"`
+			Close float64 `json:"close"`
+		} `json:"Data"`
+	} `json:"Data"`
+}
+
+// CryptoCompareClient fetches prices from the CryptoCompare API
+type CryptoCompareClient struct {
+	client *http.Client
+	url    string
+}
+
+func NewCryptoCompareClient() *CryptoCompareClient {
+	return &CryptoCompareClient{
+		client: &http.Client{Timeout: time.Second * 5},
+		url:    cryptoCompareURL,
+	}
+}
+
+func (c *CryptoCompareClient) FetchMarketValues(ctx context.Context, symbols []string, currency string) (map[string]TokenMarketValues, error) {
+	currency = strings.ToUpper(currency)
+	rst := make(map[string]TokenMarketValues)
+	for i := 0; i < len(symbols); i += cryptoCompareSymbolsLimit {
+		end := i + cryptoCompareSymbolsLimit
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		params := url.Values{}
+		params.Set("fsyms", strings.Join(symbols[i:end], ","))
+		params.Set("tsyms", currency)
+		body, err := c.doRequest(ctx, "/data/pricemultifull", params)
+		if err != nil {
+			return nil, err
+		}
+
+		container := cryptoCompareMarketValuesContainer{}
+		err = json.Unmarshal(body, &container)
+		if err != nil {
+			return nil, err
+		}
+
+		for symbol, values := range container.Raw {
+			if value, ok := values[currency]; ok {
+				rst[symbol] = TokenMarketValues{
+					Price:     value.Price,
+					Change24h: value.Change24h,
+					MarketCap: value.MarketCap,
+				}
+			}
+		}
+	}
+
+	return rst, nil
+}
+
+func (c *CryptoCompareClient) FetchHistoricalPrices(ctx context.Context, symbol string, currency string, from, to uint64) ([]PricePoint, error) {
+	step := historicalPricesStep(from, to)
+	endpoint := "/data/v2/histoday"
+	if step == uint64(time.Hour.Seconds()) {
+		endpoint = "/data/v2/histohour"
+	}
+
+	limit := (to-from)/step + 1
+	if limit > cryptoCompareHistoryLimit {
+		limit = cryptoCompareHistoryLimit
+	}
+
+	params := url.Values{}
+	params.Set("fsym", symbol)
+	params.Set("tsym", currency)
+	params.Set("limit", fmt.Sprint(limit))
+	params.Set("toTs", fmt.Sprint(to))
+	body, err := c.doRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	container := cryptoCompareHistoryContainer{}
+	err = json.Unmarshal(body, &container)
+	if err != nil {
+		return nil, err
+	}
+	if container.Response == "Error" {
+		return nil, fmt.Errorf("failed to fetch %s prices: %s", symbol, container.Message)
+	}
+
+	prices := make([]PricePoint, 0, len(container.Data.Data))
+	for _, point := range container.Data.Data {
+		prices = append(prices, PricePoint{Timestamp: point.Time, Value: point.Close})
+	}
+
+	return prices, nil
+}
+
+func (c *CryptoCompareClient) doRequest(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error("failed to close cryptocompare request body", "err", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cryptocompare request failed with status %d", resp.StatusCode)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
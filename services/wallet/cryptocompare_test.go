@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newCryptoCompareStandIn serves prices of one unit of currency per
+// character of the symbol, and counts the requests it gets
+func newCryptoCompareStandIn(t *testing.T, requests *int32) (*CryptoCompareClient, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		query := r.URL.Query()
+
+		var response interface{}
+		switch r.URL.Path {
+		case "/data/pricemultifull":
+			raw := make(map[string]map[string]cryptoCompareMarketValues)
+			for _, symbol := range strings.Split(query.Get("fsyms"), ",") {
+				if symbol == "UNKNOWN" {
+					continue
+				}
+				raw[symbol] = map[string]cryptoCompareMarketValues{
+					query.Get("tsyms"): {Price: float64(len(symbol)), Change24h: -1.5, MarketCap: 1000},
+				}
+			}
+			response = cryptoCompareMarketValuesContainer{Raw: raw}
+		case "/data/v2/histohour", "/data/v2/histoday":
+			if query.Get("fsym") == "UNKNOWN" {
+				response = map[string]string{"Response": "Error", "Message": "no data"}
+				break
+			}
+			step := uint64(time.Hour.Seconds())
+			if r.URL.Path == "/data/v2/histoday" {
+				step = uint64((24 * time.Hour).Seconds())
+			}
+			limit, err := strconv.ParseUint(query.Get("limit"), 10, 64)
+			require.NoError(t, err)
+			to, err := strconv.ParseUint(query.Get("toTs"), 10, 64)
+			require.NoError(t, err)
+			var data []map[string]interface{}
+			for i := limit; i > 0; i-- {
+				data = append(data, map[string]interface{}{"time": to/step*step - (i-1)*step, "close": float64(i)})
+			}
+			response = map[string]interface{}{"Response": "Success", "Data": map[string]interface{}{"Data": data}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, err := json.Marshal(response)
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}))
+
+	return &CryptoCompareClient{client: srv.Client(), url: srv.URL}, srv.Close
+}
+
+func TestCryptoCompareFetchMarketValues(t *testing.T) {
+	var requests int32
+	client, stop := newCryptoCompareStandIn(t, &requests)
+	defer stop()
+
+	symbols := []string{"UNKNOWN"}
+	for i := 0; i < cryptoCompareSymbolsLimit; i++ {
+		symbols = append(symbols, fmt.Sprintf("T%d", i))
+	}
+
+	values, err := client.FetchMarketValues(context.Background(), symbols, "usd")
+	require.NoError(t, err)
+	require.Len(t, values, cryptoCompareSymbolsLimit)
+	require.Equal(t, TokenMarketValues{Price: 2, Change24h: -1.5, MarketCap: 1000}, values["T1"])
+	require.Equal(t, int32(2), requests)
+}
+
+func TestCryptoCompareFetchHistoricalPrices(t *testing.T) {
+	var requests int32
+	client, stop := newCryptoCompareStandIn(t, &requests)
+	defer stop()
+
+	to := uint64(time.Now().Unix())
+	prices, err := client.FetchHistoricalPrices(context.Background(), "ETH", "USD", to-uint64((24*time.Hour).Seconds()), to)
+	require.NoError(t, err)
+	require.Len(t, prices, 25)
+	require.Equal(t, uint64(time.Hour.Seconds()), prices[1].Timestamp-prices[0].Timestamp)
+
+	prices, err = client.FetchHistoricalPrices(context.Background(), "ETH", "USD", to-uint64((30*24*time.Hour).Seconds()), to)
+	require.NoError(t, err)
+	require.Len(t, prices, 31)
+	require.Equal(t, uint64((24 * time.Hour).Seconds()), prices[1].Timestamp-prices[0].Timestamp)
+
+	_, err = client.FetchHistoricalPrices(context.Background(), "UNKNOWN", "USD", 0, to)
+	require.Error(t, err)
+}
+
+func TestCryptoCompareRequestFailure(t *testing.T) {
+	client := &CryptoCompareClient{client: http.DefaultClient, url: "http://127.0.0.1:1"}
+	_, err := client.FetchMarketValues(context.Background(), []string{"ETH"}, "USD")
+	require.Error(t, err)
+}
@@ -80,11 +80,13 @@ type OpenseaSellOrder struct {
 }
 type OpenseaAsset struct {
 	ID                int                    `json:"id"`
+	TokenID           string                 `json:"token_id"`
 	Name              string                 `json:"name"`
 	Description       string                 `json:"description"`
 	Permalink         string                 `json:"permalink"`
 	ImageThumbnailURL string                 `json:"image_thumbnail_url"`
 	ImageURL          string                 `json:"image_url"`
+	AnimationURL      string                 `json:"animation_url"`
 	Contract          OpenseaContract        `json:"asset_contract"`
 	Collection        OpenseaAssetCollection `json:"collection"`
 	Traits            []OpenseaTrait         `json:"traits"`
@@ -104,6 +106,8 @@ type OpenseaCollection struct {
 	ImageURL        string                            `json:"image_url"`
 	OwnedAssetCount int                               `json:"owned_asset_count"`
 	Traits          map[string]OpenseaCollectionTrait `json:"traits"`
+
+	PrimaryAssetContracts []OpenseaContract `json:"primary_asset_contracts"`
 }
 
 type OpenseaClient struct {
@@ -0,0 +1,352 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/planq-network/status-go/params"
+)
+
+var ErrNoPriceProvider = errors.New("no price provider")
+
+// marketValuesTTL is how long current prices are cached
+const marketValuesTTL = 5 * time.Minute
+
+// historicalPricesTTL is how long historical prices are cached
+const historicalPricesTTL = time.Hour
+
+type PricePoint struct {
+	Timestamp uint64  `json:"time"`
+	Value     float64 `json:"value"`
+}
+
+// TokenMarketValues are the current market data of a token in a fiat currency
+type TokenMarketValues struct {
+	Price float64 `json:"price"`
+	// Change24h is the change of the price over the last 24 hours, in percent
+	Change24h float64 `json:"change24h"`
+	MarketCap float64 `json:"marketCap"`
+}
+
+// ChainToken identifies a token by its contract, the zero address stands for
+// the native currency of the chain
+type ChainToken struct {
+	ChainID uint64         `json:"chainId"`
+	Address common.Address `json:"address"`
+}
+
+// PriceProvider is a source of token prices in fiat currencies
+type PriceProvider interface {
+	// FetchMarketValues returns the current market data of tokens by
+	// symbol, unknown symbols are left out
+	FetchMarketValues(ctx context.Context, symbols []string, currency string) (map[string]TokenMarketValues, error)
+	// FetchHistoricalPrices returns the prices of a token between two unix
+	// timestamps, ordered by time
+	FetchHistoricalPrices(ctx context.Context, symbol string, currency string, from, to uint64) ([]PricePoint, error)
+}
+
+// historicalPricesStep returns the resolution of the prices of a time
+// range, hourly up to a week and daily beyond
+func historicalPricesStep(from, to uint64) uint64 {
+	if to-from <= uint64((7 * 24 * time.Hour).Seconds()) {
+		return uint64(time.Hour.Seconds())
+	}
+	return uint64((24 * time.Hour).Seconds())
+}
+
+// priceAt returns the latest price known at a given time, prices are
+// expected to be ordered by time
+func priceAt(prices []PricePoint, timestamp uint64) float64 {
+	if len(prices) == 0 {
+		return 0
+	}
+
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Timestamp > timestamp
+	})
+	if i == 0 {
+		return prices[0].Value
+	}
+	return prices[i-1].Value
+}
+
+// PriceManager caches the prices of a provider, it's a PriceProvider itself
+type PriceManager struct {
+	db                  *sql.DB
+	provider            PriceProvider
+	tokenManager        *TokenManager
+	marketValuesTTL     time.Duration
+	historicalPricesTTL time.Duration
+}
+
+func NewPriceManager(db *sql.DB, tokenManager *TokenManager, provider PriceProvider) *PriceManager {
+	return &PriceManager{
+		db:                  db,
+		provider:            provider,
+		tokenManager:        tokenManager,
+		marketValuesTTL:     marketValuesTTL,
+		historicalPricesTTL: historicalPricesTTL,
+	}
+}
+
+// FetchMarketValues returns the cached market data of the tokens, the
+// expired or missing ones are fetched from the provider. Expired values are
+// still returned if the provider can't be reached
+func (pm *PriceManager) FetchMarketValues(ctx context.Context, symbols []string, currency string) (map[string]TokenMarketValues, error) {
+	currency = strings.ToUpper(currency)
+	symbols = uniqueSymbols(symbols)
+	if len(symbols) == 0 {
+		return map[string]TokenMarketValues{}, nil
+	}
+
+	rst, expired, err := pm.cachedMarketValues(symbols, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, symbol := range symbols {
+		if _, ok := rst[symbol]; !ok {
+			missing = append(missing, symbol)
+		}
+	}
+	if len(missing) == 0 {
+		return rst, nil
+	}
+
+	fetched, err := pm.provider.FetchMarketValues(ctx, missing, currency)
+	if err != nil {
+		if len(expired) == 0 {
+			return nil, err
+		}
+		log.Warn("failed to fetch market values, using expired ones", "err", err)
+		for symbol, values := range expired {
+			rst[symbol] = values
+		}
+		return rst, nil
+	}
+
+	err = pm.cacheMarketValues(fetched, currency)
+	if err != nil {
+		return nil, err
+	}
+	for symbol, values := range fetched {
+		rst[symbol] = values
+	}
+
+	return rst, nil
+}
+
+// FetchMarketValuesByContract returns the market data of tokens identified by
+// their contracts, tokens we don't know the symbol of are left out
+func (pm *PriceManager) FetchMarketValuesByContract(ctx context.Context, networks []*params.Network, tokens []ChainToken, currency string) (map[uint64]map[common.Address]TokenMarketValues, error) {
+	customs, err := pm.tokenManager.getCustoms()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make(map[ChainToken]string)
+	var toFetch []string
+	for _, token := range tokens {
+		var symbol string
+		if token.Address == (common.Address{}) {
+			for _, network := range networks {
+				if network.ChainID == token.ChainID {
+					symbol = network.NativeCurrencySymbol
+				}
+			}
+		} else if details := findToken(token.ChainID, token.Address, customs); details != nil {
+			symbol = details.Symbol
+		}
+
+		if symbol == "" {
+			continue
+		}
+		symbols[token] = strings.ToUpper(symbol)
+		toFetch = append(toFetch, symbol)
+	}
+
+	values, err := pm.FetchMarketValues(ctx, toFetch, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rst := make(map[uint64]map[common.Address]TokenMarketValues)
+	for token, symbol := range symbols {
+		tokenValues, ok := values[symbol]
+		if !ok {
+			continue
+		}
+		if _, ok := rst[token.ChainID]; !ok {
+			rst[token.ChainID] = make(map[common.Address]TokenMarketValues)
+		}
+		rst[token.ChainID][token.Address] = tokenValues
+	}
+
+	return rst, nil
+}
+
+// FetchHistoricalPrices returns the cached prices of a token, they are
+// fetched again from the provider once expired or when an older time range
+// is requested
+func (pm *PriceManager) FetchHistoricalPrices(ctx context.Context, symbol string, currency string, from, to uint64) ([]PricePoint, error) {
+	symbol = strings.ToUpper(symbol)
+	currency = strings.ToUpper(currency)
+	step := historicalPricesStep(from, to)
+
+	var fetchedFrom, fetchedAt int64
+	err := pm.db.QueryRow(`SELECT from_timestamp, fetched_at FROM price_history_fetches WHERE symbol = ? AND currency = ? AND step = ?`, symbol, currency, step).Scan(&fetchedFrom, &fetchedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if err == nil && uint64(fetchedFrom) <= from && time.Since(time.Unix(fetchedAt, 0)) < pm.historicalPricesTTL {
+		return pm.cachedHistoricalPrices(symbol, currency, step, from, to)
+	}
+
+	prices, err := pm.provider.FetchHistoricalPrices(ctx, symbol, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, pm.cacheHistoricalPrices(symbol, currency, step, from, prices)
+}
+
+func (pm *PriceManager) cachedMarketValues(symbols []string, currency string) (map[string]TokenMarketValues, map[string]TokenMarketValues, error) {
+	args := []interface{}{currency}
+	for _, symbol := range symbols {
+		args = append(args, symbol)
+	}
+	inVector := strings.Repeat("?, ", len(symbols)-1) + "?"
+	rows, err := pm.db.Query(`SELECT symbol, price, change_24h, market_cap, updated_at FROM price_cache WHERE currency = ? AND symbol IN (`+inVector+`)`, args...) // nolint: gosec
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	fresh := make(map[string]TokenMarketValues)
+	expired := make(map[string]TokenMarketValues)
+	for rows.Next() {
+		var symbol string
+		var updatedAt int64
+		values := TokenMarketValues{}
+		err := rows.Scan(&symbol, &values.Price, &values.Change24h, &values.MarketCap, &updatedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if time.Since(time.Unix(updatedAt, 0)) < pm.marketValuesTTL {
+			fresh[symbol] = values
+		} else {
+			expired[symbol] = values
+		}
+	}
+
+	return fresh, expired, rows.Err()
+}
+
+func (pm *PriceManager) cacheMarketValues(values map[string]TokenMarketValues, currency string) (err error) {
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	insert, err := tx.Prepare(`INSERT INTO price_cache (symbol, currency, price, change_24h, market_cap, updated_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	now := time.Now().Unix()
+	for symbol, value := range values {
+		_, err = insert.Exec(strings.ToUpper(symbol), currency, value.Price, value.Change24h, value.MarketCap, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pm *PriceManager) cachedHistoricalPrices(symbol, currency string, step, from, to uint64) ([]PricePoint, error) {
+	// The point before the time range is the price at its start
+	rows, err := pm.db.Query(`SELECT timestamp, price FROM price_history_cache WHERE symbol = ? AND currency = ? AND step = ? AND timestamp > ? AND timestamp <= ? ORDER BY timestamp`, symbol, currency, step, int64(from)-int64(step), to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []PricePoint
+	for rows.Next() {
+		price := PricePoint{}
+		err := rows.Scan(&price.Timestamp, &price.Value)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, rows.Err()
+}
+
+func (pm *PriceManager) cacheHistoricalPrices(symbol, currency string, step, from uint64, prices []PricePoint) (err error) {
+	tx, err := pm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM price_history_cache WHERE symbol = ? AND currency = ? AND step = ?`, symbol, currency, step)
+	if err != nil {
+		return err
+	}
+
+	insert, err := tx.Prepare(`INSERT INTO price_history_cache (symbol, currency, step, timestamp, price) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, price := range prices {
+		_, err = insert.Exec(symbol, currency, step, price.Timestamp, price.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO price_history_fetches (symbol, currency, step, from_timestamp, fetched_at) VALUES (?, ?, ?, ?, ?)`, symbol, currency, step, from, time.Now().Unix())
+	return err
+}
+
+func uniqueSymbols(symbols []string) []string {
+	seen := make(map[string]bool)
+	var rst []string
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		rst = append(rst, symbol)
+	}
+	return rst
+}
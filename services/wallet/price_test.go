@@ -0,0 +1,117 @@
+package wallet
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/planq-network/status-go/appdatabase"
+	"github.com/planq-network/status-go/params"
+)
+
+func setupTestPriceDB(t *testing.T, provider PriceProvider) (*PriceManager, func()) {
+	tmpfile, err := ioutil.TempFile("", "wallet-price-tests-")
+	require.NoError(t, err)
+	db, err := appdatabase.InitializeDB(tmpfile.Name(), "wallet-price-tests")
+	require.NoError(t, err)
+	return NewPriceManager(db, &TokenManager{db}, provider), func() {
+		require.NoError(t, db.Close())
+		require.NoError(t, os.Remove(tmpfile.Name()))
+	}
+}
+
+func TestPriceManagerMarketValues(t *testing.T) {
+	var requests int32
+	client, stopClient := newCryptoCompareStandIn(t, &requests)
+	manager, stop := setupTestPriceDB(t, client)
+	defer stop()
+
+	values, err := manager.FetchMarketValues(context.Background(), []string{"eth", "SNT", "ETH"}, "usd")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t, float64(3), values["ETH"].Price)
+	require.Equal(t, int32(1), requests)
+
+	// Only the missing symbols are fetched
+	values, err = manager.FetchMarketValues(context.Background(), []string{"ETH", "DAI"}, "USD")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t, int32(2), requests)
+
+	values, err = manager.FetchMarketValues(context.Background(), []string{"ETH", "DAI", "SNT"}, "USD")
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	require.Equal(t, int32(2), requests)
+
+	// Expired values are used when the provider can't be reached
+	stopClient()
+	manager.marketValuesTTL = 0
+	values, err = manager.FetchMarketValues(context.Background(), []string{"ETH"}, "USD")
+	require.NoError(t, err)
+	require.Equal(t, float64(3), values["ETH"].Price)
+
+	_, err = manager.FetchMarketValues(context.Background(), []string{"ETH"}, "EUR")
+	require.Error(t, err)
+}
+
+func TestPriceManagerMarketValuesByContract(t *testing.T) {
+	var requests int32
+	client, stopClient := newCryptoCompareStandIn(t, &requests)
+	defer stopClient()
+	manager, stop := setupTestPriceDB(t, client)
+	defer stop()
+
+	token := Token{Address: common.Address{2}, Name: "Token", Symbol: "TKN", Decimals: 2, ChainID: 777}
+	require.NoError(t, manager.tokenManager.upsertCustom(token))
+
+	networks := []*params.Network{{ChainID: 777, NativeCurrencySymbol: "ETH"}, {ChainID: 10, NativeCurrencySymbol: "ETH"}}
+	values, err := manager.FetchMarketValuesByContract(context.Background(), networks, []ChainToken{
+		{ChainID: 777, Address: common.Address{}},
+		{ChainID: 777, Address: token.Address},
+		{ChainID: 10, Address: common.Address{}},
+		{ChainID: 10, Address: common.Address{3}},
+	}, "USD")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	require.Len(t, values[777], 2)
+	require.Equal(t, float64(3), values[777][token.Address].Price)
+	require.Equal(t, values[777][common.Address{}], values[10][common.Address{}])
+	require.Equal(t, int32(1), requests)
+}
+
+func TestPriceManagerHistoricalPrices(t *testing.T) {
+	var requests int32
+	client, stopClient := newCryptoCompareStandIn(t, &requests)
+	defer stopClient()
+	manager, stop := setupTestPriceDB(t, client)
+	defer stop()
+
+	to := uint64(time.Now().Unix())
+	from := to - uint64((24 * time.Hour).Seconds())
+	prices, err := manager.FetchHistoricalPrices(context.Background(), "ETH", "USD", from, to)
+	require.NoError(t, err)
+	require.Len(t, prices, 25)
+	require.Equal(t, int32(1), requests)
+
+	// A shorter time range is served from the cache
+	cached, err := manager.FetchHistoricalPrices(context.Background(), "eth", "usd", from+uint64(time.Hour.Seconds()), to)
+	require.NoError(t, err)
+	require.Equal(t, prices[1:], cached)
+	require.Equal(t, int32(1), requests)
+
+	// An older time range is not
+	_, err = manager.FetchHistoricalPrices(context.Background(), "ETH", "USD", from-uint64(time.Hour.Seconds()), to)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests)
+
+	manager.historicalPricesTTL = 0
+	_, err = manager.FetchHistoricalPrices(context.Background(), "ETH", "USD", from, to)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), requests)
+}
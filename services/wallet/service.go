@@ -2,18 +2,24 @@ package wallet
 
 import (
 	"database/sql"
+	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/planq-network/status-go/rpc"
+	"github.com/planq-network/status-go/services/wallet/bigint"
 	"github.com/planq-network/status-go/services/wallet/transfer"
+	"github.com/planq-network/status-go/transactions"
 )
 
 // NewService initializes service instance.
-func NewService(db *sql.DB, rpcClient *rpc.Client, accountFeed *event.Feed, openseaAPIKey string) *Service {
+func NewService(db *sql.DB, rpcClient *rpc.Client, accountFeed *event.Feed, openseaAPIKey string, ipfsGateway string) *Service {
 	cryptoOnRampManager := NewCryptoOnRampManager(&CryptoOnRampOptions{
 		dataSourceType: DataSourceStatic,
 	})
@@ -22,6 +28,9 @@ func NewService(db *sql.DB, rpcClient *rpc.Client, accountFeed *event.Feed, open
 	transactionManager := &TransactionManager{db: db}
 	favouriteManager := &FavouriteManager{db: db}
 	transferController := transfer.NewTransferController(db, rpcClient, accountFeed)
+	priceManager := NewPriceManager(db, tokenManager, NewCryptoCompareClient())
+	balanceHistory := NewBalanceHistory(db, tokenManager, priceManager)
+	collectiblesManager := NewCollectiblesManager(db, rpcClient, ipfsGateway, openseaAPIKey)
 
 	return &Service{
 		rpcClient:             rpcClient,
@@ -31,6 +40,9 @@ func NewService(db *sql.DB, rpcClient *rpc.Client, accountFeed *event.Feed, open
 		transactionManager:    transactionManager,
 		transferController:    transferController,
 		cryptoOnRampManager:   cryptoOnRampManager,
+		priceManager:          priceManager,
+		balanceHistory:        balanceHistory,
+		collectiblesManager:   collectiblesManager,
 		openseaAPIKey:         openseaAPIKey,
 	}
 }
@@ -44,6 +56,9 @@ type Service struct {
 	favouriteManager      *FavouriteManager
 	cryptoOnRampManager   *CryptoOnRampManager
 	transferController    *transfer.Controller
+	priceManager          *PriceManager
+	balanceHistory        *BalanceHistory
+	collectiblesManager   *CollectiblesManager
 	started               bool
 	openseaAPIKey         string
 }
@@ -55,6 +70,38 @@ func (s *Service) Start() error {
 	return err
 }
 
+// ReplacePendingTransaction tracks a transaction sent with the nonce of a pending
+// transaction to speed it up or cancel it, in place of the replaced transaction.
+func (s *Service) ReplacePendingTransaction(chainID uint64, replaced common.Hash, hash common.Hash, args transactions.SendTxArgs, cancel bool) error {
+	nonce := uint64(*args.Nonce)
+	trx := PendingTransaction{
+		Hash:      hash,
+		Timestamp: uint64(time.Now().Unix()),
+		Value:     bigint.BigInt{Int: new(big.Int)},
+		From:      common.Address(args.From),
+		Data:      hexutil.Encode(args.GetInput()),
+		GasPrice:  bigint.BigInt{Int: new(big.Int)},
+		GasLimit:  bigint.BigInt{Int: new(big.Int).SetUint64(uint64(*args.Gas))},
+		Nonce:     &nonce,
+		Cancel:    cancel,
+	}
+	if args.To != nil {
+		trx.To = common.Address(*args.To)
+	}
+	if args.Value != nil {
+		trx.Value.Set(args.Value.ToInt())
+	}
+	if args.GasPrice != nil {
+		trx.GasPrice.Set(args.GasPrice.ToInt())
+	}
+	if args.IsDynamicFeeTx() {
+		trx.MaxFeePerGas = &bigint.BigInt{Int: args.MaxFeePerGas.ToInt()}
+		trx.MaxPriorityFeePerGas = &bigint.BigInt{Int: args.MaxPriorityFeePerGas.ToInt()}
+	}
+
+	return s.transactionManager.replacePending(chainID, replaced, trx)
+}
+
 // GetFeed returns signals feed.
 func (s *Service) GetFeed() *event.Feed {
 	return s.transferController.TransferFeed
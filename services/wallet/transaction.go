@@ -41,60 +41,57 @@ type PendingTransaction struct {
 	Type           PendingTrxType `json:"type"`
 	AdditionalData string         `json:"additionalData"`
 	ChainID        uint64         `json:"network_id"`
+	// Nonce and EIP-1559 fees are needed to replace the transaction
+	Nonce                *uint64        `json:"nonce,omitempty"`
+	MaxFeePerGas         *bigint.BigInt `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *bigint.BigInt `json:"maxPriorityFeePerGas,omitempty"`
+	// Replaces is the hash of the transaction this one replaces with the same nonce,
+	// Cancel is set if it replaces it by an empty transfer
+	Replaces *common.Hash `json:"replaces,omitempty"`
+	Cancel   bool         `json:"cancel"`
 }
 
-func (tm *TransactionManager) getAllPendings(chainID uint64) ([]*PendingTransaction, error) {
-	rows, err := tm.db.Query(`SELECT hash, timestamp, value, from_address, to_address, data,
+const selectPendingTransactions = `SELECT hash, timestamp, value, from_address, to_address, data,
                                          symbol, gas_price, gas_limit, type, additional_data,
-										 network_id
-                                  FROM pending_transactions
-                                  WHERE network_id = ?`, chainID)
+										 network_id, nonce, max_fee_per_gas, max_priority_fee_per_gas,
+										 replaces, cancel
+                                  FROM pending_transactions`
+
+func (tm *TransactionManager) getAllPendings(chainID uint64) ([]*PendingTransaction, error) {
+	rows, err := tm.db.Query(selectPendingTransactions+` WHERE network_id = ?`, chainID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var transactions []*PendingTransaction
-	for rows.Next() {
-		transaction := &PendingTransaction{
-			Value:    bigint.BigInt{Int: new(big.Int)},
-			GasPrice: bigint.BigInt{Int: new(big.Int)},
-			GasLimit: bigint.BigInt{Int: new(big.Int)},
-		}
-		err := rows.Scan(&transaction.Hash,
-			&transaction.Timestamp,
-			(*bigint.SQLBigIntBytes)(transaction.Value.Int),
-			&transaction.From,
-			&transaction.To,
-			&transaction.Data,
-			&transaction.Symbol,
-			(*bigint.SQLBigIntBytes)(transaction.GasPrice.Int),
-			(*bigint.SQLBigIntBytes)(transaction.GasLimit.Int),
-			&transaction.Type,
-			&transaction.AdditionalData,
-			&transaction.ChainID,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return scanPendingTransactions(rows)
+}
 
-		transactions = append(transactions, transaction)
+func (tm *TransactionManager) getPendingByAddress(chainID uint64, address common.Address) ([]*PendingTransaction, error) {
+	rows, err := tm.db.Query(selectPendingTransactions+` WHERE network_id = ? AND from_address = ?`, chainID, address)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return transactions, nil
+	return scanPendingTransactions(rows)
 }
 
-func (tm *TransactionManager) getPendingByAddress(chainID uint64, address common.Address) ([]*PendingTransaction, error) {
-	rows, err := tm.db.Query(`SELECT hash, timestamp, value, from_address, to_address, data,
-                                         symbol, gas_price, gas_limit, type, additional_data,
-										 network_id
-                                  FROM pending_transactions
-                                  WHERE network_id = ? AND from_address = ?`, chainID, address)
+func (tm *TransactionManager) getPending(chainID uint64, hash common.Hash) (*PendingTransaction, error) {
+	rows, err := tm.db.Query(selectPendingTransactions+` WHERE network_id = ? AND hash = ?`, chainID, hash)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	transactions, err := scanPendingTransactions(rows)
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	return transactions[0], nil
+}
+
+func scanPendingTransactions(rows *sql.Rows) ([]*PendingTransaction, error) {
 	var transactions []*PendingTransaction
 	for rows.Next() {
 		transaction := &PendingTransaction{
@@ -102,6 +99,12 @@ func (tm *TransactionManager) getPendingByAddress(chainID uint64, address common
 			GasPrice: bigint.BigInt{Int: new(big.Int)},
 			GasLimit: bigint.BigInt{Int: new(big.Int)},
 		}
+		var (
+			nonce                sql.NullInt64
+			maxFeePerGas         []byte
+			maxPriorityFeePerGas []byte
+			replaces             []byte
+		)
 		err := rows.Scan(&transaction.Hash,
 			&transaction.Timestamp,
 			(*bigint.SQLBigIntBytes)(transaction.Value.Int),
@@ -114,11 +117,31 @@ func (tm *TransactionManager) getPendingByAddress(chainID uint64, address common
 			&transaction.Type,
 			&transaction.AdditionalData,
 			&transaction.ChainID,
+			&nonce,
+			&maxFeePerGas,
+			&maxPriorityFeePerGas,
+			&replaces,
+			&transaction.Cancel,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		if nonce.Valid {
+			value := uint64(nonce.Int64)
+			transaction.Nonce = &value
+		}
+		if maxFeePerGas != nil {
+			transaction.MaxFeePerGas = &bigint.BigInt{Int: new(big.Int).SetBytes(maxFeePerGas)}
+		}
+		if maxPriorityFeePerGas != nil {
+			transaction.MaxPriorityFeePerGas = &bigint.BigInt{Int: new(big.Int).SetBytes(maxPriorityFeePerGas)}
+		}
+		if replaces != nil {
+			hash := common.BytesToHash(replaces)
+			transaction.Replaces = &hash
+		}
+
 		transactions = append(transactions, transaction)
 	}
 
@@ -126,15 +149,62 @@ func (tm *TransactionManager) getPendingByAddress(chainID uint64, address common
 }
 
 func (tm *TransactionManager) addPending(transaction PendingTransaction) error {
-	insert, err := tm.db.Prepare(`INSERT OR REPLACE INTO pending_transactions
-                                      (network_id, hash, timestamp, value, from_address, to_address,
-                                       data, symbol, gas_price, gas_limit, type, additional_data)
-                                      VALUES
-                                      (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return addPendingTransaction(tm.db, transaction)
+}
+
+// replacePending stores a transaction sent with the nonce of a pending transaction,
+// linked to it through Replaces. Both are kept as either of them can be confirmed,
+// the replacement keeps what the replaced transaction was sent for.
+func (tm *TransactionManager) replacePending(chainID uint64, replaced common.Hash, replacement PendingTransaction) (err error) {
+	tx, err := tm.db.Begin()
 	if err != nil {
 		return err
 	}
-	_, err = insert.Exec(
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	var (
+		symbol         sql.NullString
+		trxType        sql.NullString
+		additionalData sql.NullString
+	)
+	err = tx.QueryRow(`SELECT symbol, type, additional_data FROM pending_transactions WHERE network_id = ? AND hash = ?`, chainID, replaced).Scan(&symbol, &trxType, &additionalData)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	replacement.Symbol = symbol.String
+	replacement.Type = PendingTrxType(trxType.String)
+	replacement.AdditionalData = additionalData.String
+	replacement.ChainID = chainID
+	replacement.Replaces = &replaced
+
+	return addPendingTransaction(tx, replacement)
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func addPendingTransaction(db execer, transaction PendingTransaction) error {
+	var maxFeePerGas, maxPriorityFeePerGas *bigint.SQLBigIntBytes
+	if transaction.MaxFeePerGas != nil {
+		maxFeePerGas = (*bigint.SQLBigIntBytes)(transaction.MaxFeePerGas.Int)
+	}
+	if transaction.MaxPriorityFeePerGas != nil {
+		maxPriorityFeePerGas = (*bigint.SQLBigIntBytes)(transaction.MaxPriorityFeePerGas.Int)
+	}
+
+	_, err := db.Exec(`INSERT OR REPLACE INTO pending_transactions
+                                      (network_id, hash, timestamp, value, from_address, to_address,
+                                       data, symbol, gas_price, gas_limit, type, additional_data,
+                                       nonce, max_fee_per_gas, max_priority_fee_per_gas, replaces, cancel)
+                                      VALUES
+                                      (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		transaction.ChainID,
 		transaction.Hash,
 		transaction.Timestamp,
@@ -147,13 +217,75 @@ func (tm *TransactionManager) addPending(transaction PendingTransaction) error {
 		(*bigint.SQLBigIntBytes)(transaction.GasLimit.Int),
 		transaction.Type,
 		transaction.AdditionalData,
+		transaction.Nonce,
+		maxFeePerGas,
+		maxPriorityFeePerGas,
+		transaction.Replaces,
+		transaction.Cancel,
 	)
 	return err
 }
 
-func (tm *TransactionManager) deletePending(chainID uint64, hash common.Hash) error {
-	_, err := tm.db.Exec(`DELETE FROM pending_transactions WHERE network_id = ? AND hash = ?`, chainID, hash)
-	return err
+// deletePending removes a transaction once it's confirmed, along with the
+// transactions it replaces or that replace it, as they share its nonce and
+// can't be confirmed anymore
+func (tm *TransactionManager) deletePending(chainID uint64, hash common.Hash) (err error) {
+	tx, err := tm.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	linked := map[common.Hash]bool{hash: true}
+	for next := []common.Hash{hash}; len(next) > 0; {
+		current := next[0]
+		next = next[1:]
+
+		hashes, err := linkedPendingTransactions(tx, chainID, current)
+		if err != nil {
+			return err
+		}
+		for _, h := range hashes {
+			if !linked[h] {
+				linked[h] = true
+				next = append(next, h)
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM pending_transactions WHERE network_id = ? AND hash = ?`, chainID, current)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkedPendingTransactions returns the hashes of the transaction replaced by
+// a transaction and of the transactions replacing it
+func linkedPendingTransactions(tx *sql.Tx, chainID uint64, hash common.Hash) ([]common.Hash, error) {
+	rows, err := tx.Query(`SELECT replaces FROM pending_transactions WHERE network_id = ? AND hash = ? AND replaces IS NOT NULL
+	                       UNION
+	                       SELECT hash FROM pending_transactions WHERE network_id = ? AND replaces = ?`, chainID, hash, chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []common.Hash
+	for rows.Next() {
+		var h []byte
+		if err := rows.Scan(&h); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, common.BytesToHash(h))
+	}
+	return hashes, rows.Err()
 }
 
 func (tm *TransactionManager) watch(ctx context.Context, transactionHash common.Hash, client *chain.Client) error {
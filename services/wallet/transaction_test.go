@@ -9,9 +9,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/planq-network/status-go/appdatabase"
+	"github.com/planq-network/status-go/eth-node/types"
 	"github.com/planq-network/status-go/services/wallet/bigint"
+	"github.com/planq-network/status-go/transactions"
 )
 
 func setupTestTransactionDB(t *testing.T) (*TransactionManager, func()) {
@@ -77,3 +80,97 @@ func TestPendingTransactions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(rst))
 }
+
+func TestReplacePendingTransaction(t *testing.T) {
+	manager, stop := setupTestTransactionDB(t)
+	defer stop()
+
+	nonce := uint64(3)
+	trx := PendingTransaction{
+		Hash:                 common.Hash{1},
+		From:                 common.Address{1},
+		To:                   common.Address{2},
+		Type:                 BuyStickerPack,
+		AdditionalData:       "1",
+		Symbol:               "SNT",
+		Value:                bigint.BigInt{big.NewInt(123)},
+		GasLimit:             bigint.BigInt{big.NewInt(21000)},
+		GasPrice:             bigint.BigInt{big.NewInt(0)},
+		ChainID:              777,
+		Nonce:                &nonce,
+		MaxFeePerGas:         &bigint.BigInt{big.NewInt(10)},
+		MaxPriorityFeePerGas: &bigint.BigInt{big.NewInt(2)},
+	}
+	require.NoError(t, manager.addPending(trx))
+
+	service := &Service{transactionManager: manager}
+	to := types.Address{1}
+	gas := hexutil.Uint64(21000)
+	args := transactions.SendTxArgs{
+		From:                 types.Address{1},
+		To:                   &to,
+		Gas:                  &gas,
+		Value:                (*hexutil.Big)(big.NewInt(0)),
+		Nonce:                (*hexutil.Uint64)(&nonce),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(11)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(3)),
+	}
+	require.NoError(t, service.ReplacePendingTransaction(777, trx.Hash, common.Hash{2}, args, true))
+
+	// Both transactions are kept until one of them is confirmed
+	rst, err := manager.getAllPendings(777)
+	require.NoError(t, err)
+	require.Len(t, rst, 2)
+	replacement, err := manager.getPending(777, common.Hash{2})
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{2}, replacement.Hash)
+	require.Equal(t, trx.Hash, *replacement.Replaces)
+	require.True(t, replacement.Cancel)
+	require.Equal(t, nonce, *replacement.Nonce)
+	require.Equal(t, trx.From, replacement.To)
+	require.Equal(t, BuyStickerPack, replacement.Type)
+	require.Equal(t, "SNT", replacement.Symbol)
+	require.Equal(t, int64(11), replacement.MaxFeePerGas.Int64())
+	require.Equal(t, int64(0), replacement.Value.Int64())
+
+	// Replacements of untracked transactions are tracked too
+	require.NoError(t, service.ReplacePendingTransaction(777, common.Hash{3}, common.Hash{4}, args, false))
+	replacement, err = manager.getPending(777, common.Hash{4})
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{3}, *replacement.Replaces)
+	require.False(t, replacement.Cancel)
+}
+
+func TestDeleteReplacedPendingTransaction(t *testing.T) {
+	manager, stop := setupTestTransactionDB(t)
+	defer stop()
+
+	nonce := uint64(3)
+	trx := func(hash common.Hash, replaces *common.Hash) PendingTransaction {
+		return PendingTransaction{
+			Hash:     hash,
+			From:     common.Address{1},
+			To:       common.Address{2},
+			Value:    bigint.BigInt{big.NewInt(123)},
+			GasLimit: bigint.BigInt{big.NewInt(21000)},
+			GasPrice: bigint.BigInt{big.NewInt(1)},
+			ChainID:  777,
+			Nonce:    &nonce,
+			Replaces: replaces,
+		}
+	}
+
+	// The transaction is sped up twice
+	require.NoError(t, manager.addPending(trx(common.Hash{1}, nil)))
+	require.NoError(t, manager.replacePending(777, common.Hash{1}, trx(common.Hash{2}, nil)))
+	require.NoError(t, manager.replacePending(777, common.Hash{2}, trx(common.Hash{3}, nil)))
+	require.NoError(t, manager.addPending(trx(common.Hash{4}, nil)))
+
+	// The first speed up is confirmed, the other ones are dropped
+	require.NoError(t, manager.deletePending(777, common.Hash{2}))
+
+	rst, err := manager.getAllPendings(777)
+	require.NoError(t, err)
+	require.Len(t, rst, 1)
+	require.Equal(t, common.Hash{4}, rst[0].Hash)
+}
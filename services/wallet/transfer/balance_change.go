@@ -0,0 +1,63 @@
+package transfer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceChange is the amount of an asset that an account gained or lost with a transfer.
+type BalanceChange struct {
+	// Token is the contract of the asset, zero for the native currency of the chain.
+	Token       common.Address
+	BlockNumber *big.Int
+	Timestamp   uint64
+	// Amount is negative when the account lost some of the asset.
+	Amount *big.Int
+}
+
+// BalanceChange returns how the transfer changed the balance of its account.
+// Receipts don't carry the effective gas price, so the fees of dynamic fee
+// transactions are approximated with their fee cap.
+func (t Transfer) BalanceChange() BalanceChange {
+	change := BalanceChange{
+		BlockNumber: t.BlockNumber,
+		Timestamp:   t.Timestamp,
+		Amount:      new(big.Int),
+	}
+
+	switch t.Type {
+	case ethTransfer:
+		if t.Transaction == nil {
+			return change
+		}
+		if to := t.Transaction.To(); to != nil && *to == t.Address {
+			change.Amount.Add(change.Amount, t.Transaction.Value())
+		}
+		if t.From == t.Address {
+			change.Amount.Sub(change.Amount, t.Transaction.Value())
+			if t.Receipt != nil {
+				fee := new(big.Int).SetUint64(t.Receipt.GasUsed)
+				fee.Mul(fee, t.Transaction.GasPrice())
+				change.Amount.Sub(change.Amount, fee)
+			}
+		}
+	case erc20Transfer:
+		if t.Log == nil {
+			return change
+		}
+		change.Token = t.Log.Address
+		from, to, amount := parseLog(t.Log)
+		if amount == nil {
+			return change
+		}
+		if to == t.Address {
+			change.Amount.Add(change.Amount, amount)
+		}
+		if from == t.Address {
+			change.Amount.Sub(change.Amount, amount)
+		}
+	}
+
+	return change
+}
@@ -50,6 +50,10 @@ type Block struct {
 	db *sql.DB
 }
 
+func NewBlock(db *sql.DB) *Block {
+	return &Block{db}
+}
+
 // MergeBlocksRanges merge old blocks ranges if possible
 func (b *Block) mergeBlocksRanges(chainIDs []uint64, accounts []common.Address) error {
 	for _, chainID := range chainIDs {
@@ -0,0 +1,86 @@
+package transfer
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CollectibleBalance is the amount of an erc721 or erc1155 token held by an account.
+type CollectibleBalance struct {
+	Type     Type
+	Contract common.Address
+	TokenID  *big.Int
+	Balance  *big.Int
+}
+
+// IsERC1155 returns true if the token is an erc1155 token.
+func (c CollectibleBalance) IsERC1155() bool {
+	return c.Type == erc1155Transfer
+}
+
+// GetCollectibleBalances replays the erc721 and erc1155 transfers of an account to find
+// the tokens it holds. Transfers that are not loaded yet are used as well, as only their
+// logs are needed.
+func (db *Database) GetCollectibleBalances(chainID uint64, owner common.Address) ([]CollectibleBalance, error) {
+	query := newTransfersQuery().FilterNetwork(chainID).FilterAddress(owner).FilterTypes(erc721Transfer, erc1155Transfer)
+	rows, err := db.client.Query(query.String(), query.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	transfers, err := query.Scan(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		contract common.Address
+		tokenID  string
+	}
+	balances := map[key]*CollectibleBalance{}
+	for _, t := range transfers {
+		if t.TokenID == nil || t.TokenValue == nil {
+			continue
+		}
+		parsed := tokenLogTransferByID(t.Log, t.ID)
+		if parsed == nil {
+			continue
+		}
+
+		k := key{t.Log.Address, t.TokenID.String()}
+		balance, ok := balances[k]
+		if !ok {
+			balance = &CollectibleBalance{
+				Type:     t.Type,
+				Contract: t.Log.Address,
+				TokenID:  t.TokenID,
+				Balance:  new(big.Int),
+			}
+			balances[k] = balance
+		}
+		if parsed.To == owner {
+			balance.Balance.Add(balance.Balance, t.TokenValue)
+		}
+		if parsed.From == owner {
+			balance.Balance.Sub(balance.Balance, t.TokenValue)
+		}
+	}
+
+	rst := []CollectibleBalance{}
+	for _, balance := range balances {
+		if balance.Balance.Sign() > 0 {
+			rst = append(rst, *balance)
+		}
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		if rst[i].Contract != rst[j].Contract {
+			return bytes.Compare(rst[i].Contract.Bytes(), rst[j].Contract.Bytes()) < 0
+		}
+		return rst[i].TokenID.Cmp(rst[j].TokenID) < 0
+	})
+
+	return rst, nil
+}
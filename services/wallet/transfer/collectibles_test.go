@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func saveTokenLogs(t *testing.T, db *Database, account common.Address, number int64, logs ...*types.Log) {
+	header := &DBHeader{
+		Number: big.NewInt(number),
+		Hash:   common.BigToHash(big.NewInt(number)),
+	}
+	for _, l := range logs {
+		for _, parsed := range tokenLogTransfers(l) {
+			transfer := &Transfer{
+				Address: account,
+				ID:      parsed.ID,
+				Log:     l,
+			}
+			transfer.setTokenTransfer(&parsed)
+			header.Erc20Transfers = append(header.Erc20Transfers, transfer)
+		}
+	}
+	nonce := int64(0)
+	lastBlock := &LastKnownBlock{
+		Number:  big.NewInt(number),
+		Balance: big.NewInt(0),
+		Nonce:   &nonce,
+	}
+	require.NoError(t, db.ProcessBlocks(777, account, big.NewInt(number), lastBlock, []*DBHeader{header}))
+}
+
+func TestGetCollectibleBalances(t *testing.T) {
+	db, _, stop := setupTestDB(t)
+	defer stop()
+
+	received := erc721Log(5)
+	batch := erc1155BatchLog([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	saveTokenLogs(t, db, tokenTo, 1, received, batch)
+
+	// The erc721 token and part of an erc1155 token are sent back
+	sent := erc721Log(5)
+	sent.Topics[1], sent.Topics[2] = paddedTopic(tokenTo), paddedTopic(tokenFrom)
+	sent.TxHash = common.Hash{3}
+	single := &types.Log{
+		Address: tokenContract,
+		Topics:  []common.Hash{erc1155TransferSingleEventHash, paddedTopic(tokenOperator), paddedTopic(tokenTo), paddedTopic(tokenFrom)},
+		Data:    append(common.LeftPadBytes(big.NewInt(2).Bytes(), 32), common.LeftPadBytes(big.NewInt(15).Bytes(), 32)...),
+		TxHash:  common.Hash{4},
+	}
+	saveTokenLogs(t, db, tokenTo, 2, sent, single)
+
+	balances, err := db.GetCollectibleBalances(777, tokenTo)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	require.Equal(t, big.NewInt(1), balances[0].TokenID)
+	require.Equal(t, big.NewInt(10), balances[0].Balance)
+	require.True(t, balances[0].IsERC1155())
+	require.Equal(t, big.NewInt(2), balances[1].TokenID)
+	require.Equal(t, big.NewInt(5), balances[1].Balance)
+
+	balances, err = db.GetCollectibleBalances(777, tokenFrom)
+	require.NoError(t, err)
+	require.Len(t, balances, 0)
+}
@@ -389,8 +389,8 @@ func insertBlocksWithTransactions(chainID uint64, creator statementCreator, acco
 	}
 
 	insertTx, err := creator.Prepare(`INSERT OR IGNORE
-	INTO transfers (network_id, address, sender, hash, blk_number, blk_hash, type, timestamp, log, loaded)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0)`)
+	INTO transfers (network_id, address, sender, hash, blk_number, blk_hash, type, timestamp, log, token_id, token_value, loaded)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0)`)
 	if err != nil {
 		return err
 	}
@@ -414,7 +414,7 @@ func insertBlocksWithTransactions(chainID uint64, creator statementCreator, acco
 					continue
 				}
 
-				_, err = insertTx.Exec(chainID, account, account, transfer.ID, (*bigint.SQLBigInt)(header.Number), header.Hash, erc20Transfer, transfer.Timestamp, &JSONBlob{transfer.Log})
+				_, err = insertTx.Exec(chainID, account, account, transfer.ID, (*bigint.SQLBigInt)(header.Number), header.Hash, transfer.Type, transfer.Timestamp, &JSONBlob{transfer.Log}, bigIntString(transfer.TokenID), bigIntString(transfer.TokenValue))
 				if err != nil {
 					log.Error("error saving erc20transfer", "err", err)
 					return err
@@ -434,9 +434,9 @@ func updateOrInsertTransfers(chainID uint64, creator statementCreator, transfers
 	}
 
 	insert, err := creator.Prepare(`INSERT OR IGNORE INTO transfers
-	(network_id, hash, blk_hash, blk_number, timestamp, address, tx, sender, receipt, log, type, loaded)
+	(network_id, hash, blk_hash, blk_number, timestamp, address, tx, sender, receipt, log, type, token_id, token_value, loaded)
 	VALUES
-	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`)
+	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`)
 	if err != nil {
 		return err
 	}
@@ -454,7 +454,7 @@ func updateOrInsertTransfers(chainID uint64, creator statementCreator, transfers
 			continue
 		}
 
-		_, err = insert.Exec(chainID, t.ID, t.BlockHash, (*bigint.SQLBigInt)(t.BlockNumber), t.Timestamp, t.Address, &JSONBlob{t.Transaction}, t.From, &JSONBlob{t.Receipt}, &JSONBlob{t.Log}, t.Type)
+		_, err = insert.Exec(chainID, t.ID, t.BlockHash, (*bigint.SQLBigInt)(t.BlockNumber), t.Timestamp, t.Address, &JSONBlob{t.Transaction}, t.From, &JSONBlob{t.Receipt}, &JSONBlob{t.Log}, t.Type, bigIntString(t.TokenID), bigIntString(t.TokenValue))
 		if err != nil {
 			log.Error("can't save transfer", "b-hash", t.BlockHash, "b-n", t.BlockNumber, "a", t.Address, "h", t.ID)
 			return err
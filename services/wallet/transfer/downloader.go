@@ -2,7 +2,6 @@ package transfer
 
 import (
 	"context"
-	"encoding/binary"
 	"errors"
 	"math/big"
 	"time"
@@ -19,8 +18,10 @@ import (
 type Type string
 
 const (
-	ethTransfer   Type = "eth"
-	erc20Transfer Type = "erc20"
+	ethTransfer     Type = "eth"
+	erc20Transfer   Type = "erc20"
+	erc721Transfer  Type = "erc721"
+	erc1155Transfer Type = "erc1155"
 
 	erc20TransferEventSignature = "Transfer(address,address,uint256)"
 )
@@ -45,8 +46,19 @@ type Transfer struct {
 	// From is derived from tx signature in order to offload this computation from UI component.
 	From    common.Address `json:"from"`
 	Receipt *types.Receipt `json:"receipt"`
-	// Log that was used to generate erc20 transfer. Nil for eth transfer.
+	// Log that was used to generate token transfer. Nil for eth transfer.
 	Log *types.Log `json:"log"`
+	// TokenID is the id of the transferred erc721 or erc1155 token.
+	TokenID *big.Int `json:"tokenId"`
+	// TokenValue is the amount of tokens transferred. Nil for eth transfer.
+	TokenValue *big.Int `json:"tokenValue"`
+}
+
+// setTokenTransfer fills the details of a token transfer parsed from its log.
+func (t *Transfer) setTokenTransfer(parsed *tokenLogTransfer) {
+	t.Type = parsed.Type
+	t.TokenID = parsed.TokenID
+	t.TokenValue = parsed.Amount
 }
 
 // ETHDownloader downloads regular eth transfers.
@@ -103,18 +115,13 @@ func getTransferByHash(ctx context.Context, client *chain.Client, signer types.S
 
 	transactionLog := getTokenLog(receipt.Logs)
 
-	transferType := ethTransfer
-	if transactionLog != nil {
-		transferType = erc20Transfer
-	}
-
 	from, err := types.Sender(signer, transaction)
 
 	if err != nil {
 		return nil, err
 	}
 
-	transfer := &Transfer{Type: transferType,
+	transfer := &Transfer{Type: ethTransfer,
 		ID:          hash,
 		Address:     address,
 		BlockNumber: receipt.BlockNumber,
@@ -124,6 +131,9 @@ func getTransferByHash(ctx context.Context, client *chain.Client, signer types.S
 		From:        from,
 		Receipt:     receipt,
 		Log:         transactionLog}
+	if transactionLog != nil {
+		transfer.setTokenTransfer(&tokenLogTransfers(transactionLog)[0])
+	}
 
 	return transfer, nil
 }
@@ -137,7 +147,7 @@ func (d *ETHDownloader) getTransfersInBlock(ctx context.Context, blk *types.Bloc
 		for _, t := range preloadedTransfers {
 			transfer, err := d.transferFromLog(ctx, *t.Log, address, t.ID)
 			if err != nil {
-				log.Error("can't fetch erc20 transfer from log", "error", err)
+				log.Error("can't fetch token transfer from log", "error", err)
 				return nil, err
 			}
 			rst = append(rst, transfer)
@@ -185,23 +195,26 @@ func (d *ETHDownloader) getTransfersInBlock(ctx context.Context, blk *types.Bloc
 
 // NewERC20TransfersDownloader returns new instance.
 func NewERC20TransfersDownloader(client *chain.Client, accounts []common.Address, signer types.Signer) *ERC20TransfersDownloader {
-	signature := crypto.Keccak256Hash([]byte(erc20TransferEventSignature))
 	return &ERC20TransfersDownloader{
-		client:    client,
-		accounts:  accounts,
-		signature: signature,
-		signer:    signer,
+		client:            client,
+		accounts:          accounts,
+		signature:         erc20TransferEventHash,
+		erc1155Signatures: []common.Hash{erc1155TransferSingleEventHash, erc1155TransferBatchEventHash},
+		signer:            signer,
 	}
 }
 
-// ERC20TransfersDownloader is a downloader for erc20 tokens transfers.
+// ERC20TransfersDownloader is a downloader for erc20, erc721 and erc1155 tokens transfers.
 type ERC20TransfersDownloader struct {
 	client   *chain.Client
 	accounts []common.Address
 
-	// hash of the Transfer event signature
+	// hash of the Transfer event signature, shared by erc20 and erc721
 	signature common.Hash
 
+	// hashes of the TransferSingle and TransferBatch erc1155 event signatures
+	erc1155Signatures []common.Hash
+
 	// signer is used to derive tx sender from tx signature
 	signer types.Signer
 }
@@ -220,6 +233,36 @@ func (d *ERC20TransfersDownloader) outboundTopics(address common.Address) [][]co
 	return [][]common.Hash{{d.signature}, {d.paddedAddress(address)}, {}}
 }
 
+// erc1155 events index the operator first
+func (d *ERC20TransfersDownloader) inboundERC1155Topics(address common.Address) [][]common.Hash {
+	return [][]common.Hash{d.erc1155Signatures, {}, {}, {d.paddedAddress(address)}}
+}
+
+func (d *ERC20TransfersDownloader) outboundERC1155Topics(address common.Address) [][]common.Hash {
+	return [][]common.Hash{d.erc1155Signatures, {}, {d.paddedAddress(address)}, {}}
+}
+
+// filterLogs returns the token transfer logs that were sent or received by an account.
+func (d *ERC20TransfersDownloader) filterLogs(parent context.Context, query ethereum.FilterQuery, address common.Address) ([]types.Log, error) {
+	var logs []types.Log
+	for _, topics := range [][][]common.Hash{
+		d.outboundTopics(address),
+		d.inboundTopics(address),
+		d.outboundERC1155Topics(address),
+		d.inboundERC1155Topics(address),
+	} {
+		query.Topics = topics
+		ctx, cancel := context.WithTimeout(parent, 5*time.Second)
+		rst, err := d.client.FilterLogs(ctx, query)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, rst...)
+	}
+	return logs, nil
+}
+
 func (d *ETHDownloader) transferFromLog(parent context.Context, ethlog types.Log, address common.Address, id common.Hash) (Transfer, error) {
 	ctx, cancel := context.WithTimeout(parent, 3*time.Second)
 	tx, _, err := d.chainClient.TransactionByHash(ctx, ethlog.TxHash)
@@ -243,7 +286,7 @@ func (d *ETHDownloader) transferFromLog(parent context.Context, ethlog types.Log
 	if err != nil {
 		return Transfer{}, err
 	}
-	return Transfer{
+	transfer := Transfer{
 		Address:     address,
 		ID:          id,
 		Type:        erc20Transfer,
@@ -254,47 +297,57 @@ func (d *ETHDownloader) transferFromLog(parent context.Context, ethlog types.Log
 		Receipt:     receipt,
 		Timestamp:   blk.Time(),
 		Log:         &ethlog,
-	}, nil
+	}
+	if parsed := tokenLogTransferByID(&ethlog, id); parsed != nil {
+		transfer.setTokenTransfer(parsed)
+	}
+	return transfer, nil
 }
 
-func (d *ERC20TransfersDownloader) transferFromLog(parent context.Context, ethlog types.Log, address common.Address) (Transfer, error) {
+// transfersFromLog returns the transfers of a log, erc1155 batch transfers have several.
+func (d *ERC20TransfersDownloader) transfersFromLog(parent context.Context, ethlog types.Log, address common.Address) ([]Transfer, error) {
+	parsed := tokenLogTransfers(&ethlog)
+	if len(parsed) == 0 {
+		return nil, nil
+	}
 	ctx, cancel := context.WithTimeout(parent, 3*time.Second)
 	tx, _, err := d.client.TransactionByHash(ctx, ethlog.TxHash)
 	cancel()
 	if err != nil {
-		return Transfer{}, err
+		return nil, err
 	}
 	from, err := types.Sender(d.signer, tx)
 	if err != nil {
-		return Transfer{}, err
+		return nil, err
 	}
 	ctx, cancel = context.WithTimeout(parent, 3*time.Second)
 	receipt, err := d.client.TransactionReceipt(ctx, ethlog.TxHash)
 	cancel()
 	if err != nil {
-		return Transfer{}, err
+		return nil, err
 	}
 	ctx, cancel = context.WithTimeout(parent, 3*time.Second)
 	blk, err := d.client.BlockByHash(ctx, ethlog.BlockHash)
 	cancel()
 	if err != nil {
-		return Transfer{}, err
+		return nil, err
 	}
-	index := [4]byte{}
-	binary.BigEndian.PutUint32(index[:], uint32(ethlog.Index))
-	id := crypto.Keccak256Hash(ethlog.TxHash.Bytes(), index[:])
-	return Transfer{
-		Address:     address,
-		ID:          id,
-		Type:        erc20Transfer,
-		BlockNumber: new(big.Int).SetUint64(ethlog.BlockNumber),
-		BlockHash:   ethlog.BlockHash,
-		Transaction: tx,
-		From:        from,
-		Receipt:     receipt,
-		Timestamp:   blk.Time(),
-		Log:         &ethlog,
-	}, nil
+	transfers := make([]Transfer, len(parsed))
+	for i := range parsed {
+		transfers[i] = Transfer{
+			Address:     address,
+			ID:          parsed[i].ID,
+			BlockNumber: new(big.Int).SetUint64(ethlog.BlockNumber),
+			BlockHash:   ethlog.BlockHash,
+			Transaction: tx,
+			From:        from,
+			Receipt:     receipt,
+			Timestamp:   blk.Time(),
+			Log:         &ethlog,
+		}
+		transfers[i].setTokenTransfer(&parsed[i])
+	}
+	return transfers, nil
 }
 
 func (d *ERC20TransfersDownloader) transfersFromLogs(parent context.Context, logs []types.Log, address common.Address) ([]Transfer, error) {
@@ -305,11 +358,13 @@ func (d *ERC20TransfersDownloader) transfersFromLogs(parent context.Context, log
 			continue
 		}
 		concurrent.Add(func(ctx context.Context) error {
-			transfer, err := d.transferFromLog(ctx, l, address)
+			transfers, err := d.transfersFromLog(ctx, l, address)
 			if err != nil {
 				return err
 			}
-			concurrent.Push(transfer)
+			for _, transfer := range transfers {
+				concurrent.Push(transfer)
+			}
 			return nil
 		})
 	}
@@ -330,22 +385,27 @@ func (d *ERC20TransfersDownloader) blocksFromLogs(parent context.Context, logs [
 			continue
 		}
 
-		index := [4]byte{}
-		binary.BigEndian.PutUint32(index[:], uint32(l.Index))
-		id := crypto.Keccak256Hash(l.TxHash.Bytes(), index[:])
+		parsed := tokenLogTransfers(&l)
+		if len(parsed) == 0 {
+			continue
+		}
 
 		header := &DBHeader{
 			Number: big.NewInt(int64(l.BlockNumber)),
 			Hash:   l.BlockHash,
-			Erc20Transfers: []*Transfer{{
+		}
+		for i := range parsed {
+			transfer := &Transfer{
 				Address:     address,
 				BlockNumber: big.NewInt(int64(l.BlockNumber)),
 				BlockHash:   l.BlockHash,
-				ID:          id,
+				ID:          parsed[i].ID,
 				From:        address,
 				Loaded:      false,
-				Type:        erc20Transfer,
-				Log:         &l}}}
+				Log:         &l}
+			transfer.setTokenTransfer(&parsed[i])
+			header.Erc20Transfers = append(header.Erc20Transfers, transfer)
+		}
 
 		concurrent.Add(func(ctx context.Context) error {
 			concurrent.PushHeader(header)
@@ -360,26 +420,15 @@ func (d *ERC20TransfersDownloader) blocksFromLogs(parent context.Context, logs [
 	return concurrent.GetHeaders(), concurrent.Error()
 }
 
-// GetTransfers for erc20 uses eth_getLogs rpc with Transfer event signature and our address acount.
+// GetTransfers for tokens uses eth_getLogs rpc with transfer events signatures and our address acount.
 func (d *ERC20TransfersDownloader) GetTransfers(ctx context.Context, header *DBHeader) ([]Transfer, error) {
 	hash := header.Hash
 	transfers := []Transfer{}
 	for _, address := range d.accounts {
-		outbound, err := d.client.FilterLogs(ctx, ethereum.FilterQuery{
-			BlockHash: &hash,
-			Topics:    d.outboundTopics(address),
-		})
-		if err != nil {
-			return nil, err
-		}
-		inbound, err := d.client.FilterLogs(ctx, ethereum.FilterQuery{
-			BlockHash: &hash,
-			Topics:    d.inboundTopics(address),
-		})
+		logs, err := d.filterLogs(ctx, ethereum.FilterQuery{BlockHash: &hash}, address)
 		if err != nil {
 			return nil, err
 		}
-		logs := append(outbound, inbound...)
 		if len(logs) == 0 {
 			continue
 		}
@@ -396,30 +445,13 @@ func (d *ERC20TransfersDownloader) GetTransfers(ctx context.Context, header *DBH
 // time to get logs for 100000 blocks = 1.144686979s. with 249 events in the result set.
 func (d *ERC20TransfersDownloader) GetHeadersInRange(parent context.Context, from, to *big.Int) ([]*DBHeader, error) {
 	start := time.Now()
-	log.Debug("get erc20 transfers in range", "from", from, "to", to)
+	log.Debug("get token transfers in range", "from", from, "to", to)
 	headers := []*DBHeader{}
 	for _, address := range d.accounts {
-		ctx, cancel := context.WithTimeout(parent, 5*time.Second)
-		outbound, err := d.client.FilterLogs(ctx, ethereum.FilterQuery{
-			FromBlock: from,
-			ToBlock:   to,
-			Topics:    d.outboundTopics(address),
-		})
-		cancel()
-		if err != nil {
-			return nil, err
-		}
-		ctx, cancel = context.WithTimeout(parent, 5*time.Second)
-		inbound, err := d.client.FilterLogs(ctx, ethereum.FilterQuery{
-			FromBlock: from,
-			ToBlock:   to,
-			Topics:    d.inboundTopics(address),
-		})
-		cancel()
+		logs, err := d.filterLogs(parent, ethereum.FilterQuery{FromBlock: from, ToBlock: to}, address)
 		if err != nil {
 			return nil, err
 		}
-		logs := append(outbound, inbound...)
 		if len(logs) == 0 {
 			continue
 		}
@@ -429,7 +461,7 @@ func (d *ERC20TransfersDownloader) GetHeadersInRange(parent context.Context, fro
 		}
 		headers = append(headers, rst...)
 	}
-	log.Debug("found erc20 transfers between two blocks", "from", from, "to", to, "headers", len(headers), "took", time.Since(start))
+	log.Debug("found token transfers between two blocks", "from", from, "to", to, "headers", len(headers), "took", time.Since(start))
 	return headers, nil
 }
 
@@ -444,9 +476,8 @@ func IsTokenTransfer(logs []*types.Log) bool {
 }
 
 func getTokenLog(logs []*types.Log) *types.Log {
-	signature := crypto.Keccak256Hash([]byte(erc20TransferEventSignature))
 	for _, l := range logs {
-		if len(l.Topics) > 0 && l.Topics[0] == signature {
+		if len(tokenLogTransfers(l)) > 0 {
 			return l
 		}
 	}
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"database/sql"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/planq-network/status-go/services/wallet/bigint"
 )
 
-const baseTransfersQuery = "SELECT hash, type, blk_hash, blk_number, timestamp, address, tx, sender, receipt, log, network_id FROM transfers"
+const baseTransfersQuery = "SELECT hash, type, blk_hash, blk_number, timestamp, address, tx, sender, receipt, log, network_id, token_id, token_value FROM transfers"
 
 func newTransfersQuery() *transfersQuery {
 	buf := bytes.NewBuffer(nil)
@@ -77,6 +78,16 @@ func (q *transfersQuery) FilterAddress(address common.Address) *transfersQuery {
 	return q
 }
 
+func (q *transfersQuery) FilterTypes(types ...Type) *transfersQuery {
+	q.andOrWhere()
+	q.added = true
+	q.buf.WriteString(" type IN (" + strings.Repeat("?, ", len(types)-1) + "?)")
+	for _, t := range types {
+		q.args = append(q.args, t)
+	}
+	return q
+}
+
 func (q *transfersQuery) FilterBlockHash(blockHash common.Hash) *transfersQuery {
 	q.andOrWhere()
 	q.added = true
@@ -116,14 +127,38 @@ func (q *transfersQuery) Scan(rows *sql.Rows) (rst []Transfer, err error) {
 			Receipt:     &types.Receipt{},
 			Log:         &types.Log{},
 		}
+		var tokenID, tokenValue sql.NullString
 		err = rows.Scan(
 			&transfer.ID, &transfer.Type, &transfer.BlockHash,
 			(*bigint.SQLBigInt)(transfer.BlockNumber), &transfer.Timestamp, &transfer.Address,
-			&JSONBlob{transfer.Transaction}, &transfer.From, &JSONBlob{transfer.Receipt}, &JSONBlob{transfer.Log}, &transfer.NetworkID)
+			&JSONBlob{transfer.Transaction}, &transfer.From, &JSONBlob{transfer.Receipt}, &JSONBlob{transfer.Log}, &transfer.NetworkID,
+			&tokenID, &tokenValue)
 		if err != nil {
 			return nil, err
 		}
+		transfer.TokenID = parseBigIntString(tokenID)
+		transfer.TokenValue = parseBigIntString(tokenValue)
 		rst = append(rst, transfer)
 	}
 	return rst, nil
 }
+
+// bigIntString stores token ids and values as decimal strings, as they are
+// uint256 and zero is a valid token id
+func bigIntString(value *big.Int) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.String()
+}
+
+func parseBigIntString(value sql.NullString) *big.Int {
+	if !value.Valid {
+		return nil
+	}
+	rst, ok := new(big.Int).SetString(value.String, 10)
+	if !ok {
+		return nil
+	}
+	return rst
+}
@@ -0,0 +1,151 @@
+package transfer
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	erc1155TransferSingleEventSignature = "TransferSingle(address,address,address,uint256,uint256)"
+	erc1155TransferBatchEventSignature  = "TransferBatch(address,address,address,uint256[],uint256[])"
+)
+
+var (
+	erc20TransferEventHash         = crypto.Keccak256Hash([]byte(erc20TransferEventSignature))
+	erc1155TransferSingleEventHash = crypto.Keccak256Hash([]byte(erc1155TransferSingleEventSignature))
+	erc1155TransferBatchEventHash  = crypto.Keccak256Hash([]byte(erc1155TransferBatchEventSignature))
+)
+
+var erc1155BatchArguments abi.Arguments
+
+func init() {
+	uint256Array, err := abi.NewType("uint256[]", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	erc1155BatchArguments = abi.Arguments{{Type: uint256Array}, {Type: uint256Array}}
+}
+
+// tokenLogTransfer is a token transfer parsed from a log, a log of an
+// ERC-1155 batch transfer holds one for every token transferred
+type tokenLogTransfer struct {
+	ID      common.Hash
+	Type    Type
+	From    common.Address
+	To      common.Address
+	TokenID *big.Int
+	Amount  *big.Int
+}
+
+// tokenLogTransfers parses the token transfers of a log, it returns nil if
+// the log is not a token transfer. ERC-20 and ERC-721 share the Transfer
+// event, only ERC-721 indexes its third argument, the token id
+func tokenLogTransfers(l *types.Log) []tokenLogTransfer {
+	if len(l.Topics) == 0 {
+		return nil
+	}
+
+	switch l.Topics[0] {
+	case erc20TransferEventHash:
+		if len(l.Topics) == 4 {
+			return []tokenLogTransfer{{
+				ID:      tokenLogTransferID(l, -1),
+				Type:    erc721Transfer,
+				From:    common.BytesToAddress(l.Topics[1].Bytes()),
+				To:      common.BytesToAddress(l.Topics[2].Bytes()),
+				TokenID: l.Topics[3].Big(),
+				Amount:  big.NewInt(1),
+			}}
+		}
+		from, to, amount := parseLog(l)
+		if amount == nil {
+			return nil
+		}
+		return []tokenLogTransfer{{
+			ID:     tokenLogTransferID(l, -1),
+			Type:   erc20Transfer,
+			From:   from,
+			To:     to,
+			Amount: amount,
+		}}
+
+	case erc1155TransferSingleEventHash:
+		if len(l.Topics) != 4 || len(l.Data) != 64 {
+			log.Warn("invalid erc1155 single transfer", "topics", l.Topics, "data", l.Data)
+			return nil
+		}
+		return []tokenLogTransfer{{
+			ID:      tokenLogTransferID(l, -1),
+			Type:    erc1155Transfer,
+			From:    common.BytesToAddress(l.Topics[2].Bytes()),
+			To:      common.BytesToAddress(l.Topics[3].Bytes()),
+			TokenID: new(big.Int).SetBytes(l.Data[:32]),
+			Amount:  new(big.Int).SetBytes(l.Data[32:]),
+		}}
+
+	case erc1155TransferBatchEventHash:
+		if len(l.Topics) != 4 {
+			log.Warn("invalid erc1155 batch transfer", "topics", l.Topics)
+			return nil
+		}
+		values, err := erc1155BatchArguments.Unpack(l.Data)
+		if err != nil {
+			log.Warn("invalid erc1155 batch transfer", "data", l.Data, "error", err)
+			return nil
+		}
+		ids, _ := values[0].([]*big.Int)
+		amounts, _ := values[1].([]*big.Int)
+		if len(ids) != len(amounts) {
+			log.Warn("invalid erc1155 batch transfer", "ids", len(ids), "amounts", len(amounts))
+			return nil
+		}
+		rst := make([]tokenLogTransfer, len(ids))
+		for i := range ids {
+			rst[i] = tokenLogTransfer{
+				ID:      tokenLogTransferID(l, i),
+				Type:    erc1155Transfer,
+				From:    common.BytesToAddress(l.Topics[2].Bytes()),
+				To:      common.BytesToAddress(l.Topics[3].Bytes()),
+				TokenID: ids[i],
+				Amount:  amounts[i],
+			}
+		}
+		return rst
+	}
+
+	return nil
+}
+
+// tokenLogTransferID identifies a transfer by its log, the transfers of a
+// batch are identified by their position in the batch as well
+func tokenLogTransferID(l *types.Log, batchIndex int) common.Hash {
+	index := [4]byte{}
+	binary.BigEndian.PutUint32(index[:], uint32(l.Index))
+	if batchIndex < 0 {
+		return crypto.Keccak256Hash(l.TxHash.Bytes(), index[:])
+	}
+	position := [4]byte{}
+	binary.BigEndian.PutUint32(position[:], uint32(batchIndex))
+	return crypto.Keccak256Hash(l.TxHash.Bytes(), index[:], position[:])
+}
+
+// tokenLogTransferByID finds the transfer of a log with the given id. Transfers
+// loaded by transaction hash are identified by it, their first transfer is used
+func tokenLogTransferByID(l *types.Log, id common.Hash) *tokenLogTransfer {
+	transfers := tokenLogTransfers(l)
+	for i := range transfers {
+		if transfers[i].ID == id {
+			return &transfers[i]
+		}
+	}
+	if len(transfers) > 0 && l.TxHash == id {
+		return &transfers[0]
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package transfer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	tokenFrom     = common.Address{1}
+	tokenTo       = common.Address{2}
+	tokenOperator = common.Address{3}
+	tokenContract = common.Address{4}
+)
+
+func paddedTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func erc721Log(tokenID int64) *types.Log {
+	return &types.Log{
+		Address: tokenContract,
+		Topics:  []common.Hash{erc20TransferEventHash, paddedTopic(tokenFrom), paddedTopic(tokenTo), common.BigToHash(big.NewInt(tokenID))},
+		TxHash:  common.Hash{1},
+		Index:   1,
+	}
+}
+
+func erc1155BatchLog(ids, amounts []*big.Int) *types.Log {
+	data, err := erc1155BatchArguments.Pack(ids, amounts)
+	if err != nil {
+		panic(err)
+	}
+	return &types.Log{
+		Address: tokenContract,
+		Topics:  []common.Hash{erc1155TransferBatchEventHash, paddedTopic(tokenOperator), paddedTopic(tokenFrom), paddedTopic(tokenTo)},
+		Data:    data,
+		TxHash:  common.Hash{2},
+		Index:   2,
+	}
+}
+
+func TestTokenLogTransfersERC20(t *testing.T) {
+	l := &types.Log{
+		Topics: []common.Hash{erc20TransferEventHash, paddedTopic(tokenFrom), paddedTopic(tokenTo)},
+		Data:   common.LeftPadBytes(big.NewInt(10).Bytes(), 32),
+	}
+	transfers := tokenLogTransfers(l)
+	require.Len(t, transfers, 1)
+	require.Equal(t, erc20Transfer, transfers[0].Type)
+	require.Equal(t, big.NewInt(10), transfers[0].Amount)
+	require.Nil(t, transfers[0].TokenID)
+}
+
+func TestTokenLogTransfersERC721(t *testing.T) {
+	transfers := tokenLogTransfers(erc721Log(0))
+	require.Len(t, transfers, 1)
+	require.Equal(t, erc721Transfer, transfers[0].Type)
+	require.Equal(t, tokenFrom, transfers[0].From)
+	require.Equal(t, tokenTo, transfers[0].To)
+	require.Equal(t, int64(0), transfers[0].TokenID.Int64())
+	require.Equal(t, big.NewInt(1), transfers[0].Amount)
+}
+
+func TestTokenLogTransfersERC1155(t *testing.T) {
+	single := &types.Log{
+		Topics: []common.Hash{erc1155TransferSingleEventHash, paddedTopic(tokenOperator), paddedTopic(tokenFrom), paddedTopic(tokenTo)},
+		Data:   append(common.LeftPadBytes(big.NewInt(7).Bytes(), 32), common.LeftPadBytes(big.NewInt(5).Bytes(), 32)...),
+	}
+	transfers := tokenLogTransfers(single)
+	require.Len(t, transfers, 1)
+	require.Equal(t, erc1155Transfer, transfers[0].Type)
+	require.Equal(t, tokenFrom, transfers[0].From)
+	require.Equal(t, tokenTo, transfers[0].To)
+	require.Equal(t, big.NewInt(7), transfers[0].TokenID)
+	require.Equal(t, big.NewInt(5), transfers[0].Amount)
+
+	batch := erc1155BatchLog([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	transfers = tokenLogTransfers(batch)
+	require.Len(t, transfers, 2)
+	require.NotEqual(t, transfers[0].ID, transfers[1].ID)
+	require.Equal(t, big.NewInt(2), transfers[1].TokenID)
+	require.Equal(t, big.NewInt(20), transfers[1].Amount)
+	require.Equal(t, &transfers[1], tokenLogTransferByID(batch, transfers[1].ID))
+
+	// Invalid logs are not transfers
+	batch.Data = batch.Data[:40]
+	require.Nil(t, tokenLogTransfers(batch))
+	require.Nil(t, tokenLogTransfers(&types.Log{Topics: []common.Hash{erc1155TransferSingleEventHash}}))
+}
+
+func TestDBTokenTransfers(t *testing.T) {
+	db, _, stop := setupTestDB(t)
+	defer stop()
+
+	batch := erc1155BatchLog([]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	header := &DBHeader{
+		Number: big.NewInt(1),
+		Hash:   common.Hash{1},
+	}
+	for _, l := range []*types.Log{erc721Log(0), batch} {
+		for _, parsed := range tokenLogTransfers(l) {
+			transfer := &Transfer{
+				Address:     tokenTo,
+				BlockNumber: header.Number,
+				BlockHash:   header.Hash,
+				ID:          parsed.ID,
+				From:        tokenTo,
+				Log:         l,
+			}
+			transfer.setTokenTransfer(&parsed)
+			header.Erc20Transfers = append(header.Erc20Transfers, transfer)
+		}
+	}
+	nonce := int64(0)
+	lastBlock := &LastKnownBlock{
+		Number:  big.NewInt(1),
+		Balance: big.NewInt(0),
+		Nonce:   &nonce,
+	}
+	require.NoError(t, db.ProcessBlocks(777, tokenTo, big.NewInt(0), lastBlock, []*DBHeader{header}))
+
+	preloaded, err := db.GetPreloadedTransactions(777, tokenTo, header.Hash)
+	require.NoError(t, err)
+	require.Len(t, preloaded, 3)
+
+	tx := types.NewTransaction(1, tokenContract, nil, 10, big.NewInt(10), nil)
+	receipt := types.NewReceipt(nil, false, 100)
+	receipt.Logs = []*types.Log{}
+	for i := range preloaded {
+		preloaded[i].Transaction = tx
+		preloaded[i].Receipt = receipt
+		preloaded[i].Address = tokenTo
+	}
+	require.NoError(t, db.SaveTranfers(777, tokenTo, preloaded, []*big.Int{header.Number}))
+
+	transfers, err := db.GetTransfersByAddress(777, tokenTo, big.NewInt(1), 10)
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
+
+	views := map[Type][]View{}
+	for _, transfer := range castToTransferViews(transfers) {
+		views[transfer.Type] = append(views[transfer.Type], transfer)
+	}
+	require.Len(t, views[erc721Transfer], 1)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(0)), views[erc721Transfer][0].TokenID)
+	require.Equal(t, tokenFrom, views[erc721Transfer][0].From)
+	require.Equal(t, tokenContract, views[erc721Transfer][0].Contract)
+
+	require.Len(t, views[erc1155Transfer], 2)
+	for _, view := range views[erc1155Transfer] {
+		require.Equal(t, tokenTo, view.To)
+		require.Equal(t, view.TokenID.ToInt().Int64()*10, view.Value.ToInt().Int64())
+	}
+}
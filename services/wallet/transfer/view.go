@@ -32,6 +32,8 @@ type View struct {
 	To                   common.Address `json:"to"`
 	Contract             common.Address `json:"contract"`
 	NetworkID            uint64         `json:"networkId"`
+	// TokenID is the id of the transferred erc721 or erc1155 token
+	TokenID *hexutil.Big `json:"tokenId,omitempty"`
 }
 
 func castToTransferViews(transfers []Transfer) []View {
@@ -72,6 +74,13 @@ func CastToTransferView(t Transfer) View {
 		view.Contract = t.Log.Address
 		from, to, amount := parseLog(t.Log)
 		view.From, view.To, view.Value = from, to, (*hexutil.Big)(amount)
+	case erc721Transfer, erc1155Transfer:
+		view.Contract = t.Log.Address
+		if parsed := tokenLogTransferByID(t.Log, t.ID); parsed != nil {
+			view.From, view.To = parsed.From, parsed.To
+		}
+		view.Value = (*hexutil.Big)(t.TokenValue)
+		view.TokenID = (*hexutil.Big)(t.TokenID)
 	}
 	return view
 }
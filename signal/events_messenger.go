@@ -5,9 +5,15 @@ const (
 	// EventMesssageDelivered triggered when we got acknowledge from datasync level, that means peer got message
 	EventMesssageDelivered = "message.delivered"
 
+	// EventMessageRead triggered when a recipient let us know they read a message
+	EventMessageRead = "message.read"
+
 	// EventCommunityFound triggered when user requested info about some community and messenger successfully
 	// retrieved it from mailserver
 	EventCommunityInfoFound = "community.found"
+
+	// EventOutboxStateChanged triggered when the delivery state of outgoing messages changed
+	EventOutboxStateChanged = "outbox.state-changed"
 )
 
 // MessageDeliveredSignal specifies chat and message that was delivered
@@ -16,6 +22,13 @@ type MessageDeliveredSignal struct {
 	MessageID string `json:"messageID"`
 }
 
+// MessageReadSignal specifies chat and message that was read and by whom
+type MessageReadSignal struct {
+	ChatID    string `json:"chatID"`
+	MessageID string `json:"messageID"`
+	From      string `json:"from"`
+}
+
 // MessageDeliveredSignal specifies chat and message that was delivered
 type CommunityInfoFoundSignal struct {
 	Name         string `json:"name"`
@@ -29,7 +42,17 @@ func SendMessageDelivered(chatID string, messageID string) {
 	send(EventMesssageDelivered, MessageDeliveredSignal{ChatID: chatID, MessageID: messageID})
 }
 
+// SendMessageRead notifies about a message read by a recipient
+func SendMessageRead(chatID string, messageID string, from string) {
+	send(EventMessageRead, MessageReadSignal{ChatID: chatID, MessageID: messageID, From: from})
+}
+
 // SendMessageDelivered notifies about delivered message
 func SendCommunityInfoFound(community interface{}) {
 	send(EventCommunityInfoFound, community)
 }
+
+// SendOutboxStateChanged notifies about the new delivery state of outgoing messages
+func SendOutboxStateChanged(entries interface{}) {
+	send(EventOutboxStateChanged, entries)
+}
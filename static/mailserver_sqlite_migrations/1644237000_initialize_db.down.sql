@@ -0,0 +1,4 @@
+DROP INDEX timestamp_idx;
+DROP INDEX id_bloom_idx;
+DROP INDEX id_topic_idx;
+DROP TABLE envelopes;
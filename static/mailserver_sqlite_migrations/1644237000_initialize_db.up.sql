@@ -0,0 +1,13 @@
+CREATE TABLE envelopes (
+  id BLOB NOT NULL PRIMARY KEY,
+  data BLOB NOT NULL,
+  topic BLOB NOT NULL,
+  bloom0 INTEGER NOT NULL,
+  bloom1 INTEGER NOT NULL,
+  bloom2 INTEGER NOT NULL,
+  timestamp INTEGER NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX id_topic_idx ON envelopes (topic, id);
+CREATE INDEX id_bloom_idx ON envelopes (bloom0, bloom1, bloom2, id);
+CREATE INDEX timestamp_idx ON envelopes (timestamp);
@@ -97,3 +97,48 @@ func (mr *MockPublicTransactionPoolAPIMockRecorder) SendRawTransaction(ctx, enco
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransaction", reflect.TypeOf((*MockPublicTransactionPoolAPI)(nil).SendRawTransaction), ctx, encodedTx)
 }
+
+// GetTransactionByHash mocks base method
+func (m *MockPublicTransactionPoolAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByHash", ctx, hash)
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByHash indicates an expected call of GetTransactionByHash
+func (mr *MockPublicTransactionPoolAPIMockRecorder) GetTransactionByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockPublicTransactionPoolAPI)(nil).GetTransactionByHash), ctx, hash)
+}
+
+// MaxPriorityFeePerGas mocks base method
+func (m *MockPublicTransactionPoolAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxPriorityFeePerGas", ctx)
+	ret0, _ := ret[0].(*hexutil.Big)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MaxPriorityFeePerGas indicates an expected call of MaxPriorityFeePerGas
+func (mr *MockPublicTransactionPoolAPIMockRecorder) MaxPriorityFeePerGas(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxPriorityFeePerGas", reflect.TypeOf((*MockPublicTransactionPoolAPI)(nil).MaxPriorityFeePerGas), ctx)
+}
+
+// FeeHistory mocks base method
+func (m *MockPublicTransactionPoolAPI) FeeHistory(ctx context.Context, blockCount hexutil.Uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeHistory", ctx, blockCount, lastBlock, rewardPercentiles)
+	ret0, _ := ret[0].(*FeeHistoryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeeHistory indicates an expected call of FeeHistory
+func (mr *MockPublicTransactionPoolAPIMockRecorder) FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockPublicTransactionPoolAPI)(nil).FeeHistory), ctx, blockCount, lastBlock, rewardPercentiles)
+}
@@ -22,12 +22,22 @@ func NewTestServer(ctrl *gomock.Controller) (*rpc.Server, *MockPublicTransaction
 
 // CallArgs copied from module go-ethereum/internal/ethapi
 type CallArgs struct {
-	From     common.Address  `json:"from"`
-	To       *common.Address `json:"to"`
-	Gas      hexutil.Uint64  `json:"gas"`
-	GasPrice hexutil.Big     `json:"gasPrice"`
-	Value    hexutil.Big     `json:"value"`
-	Data     hexutil.Bytes   `json:"data"`
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             hexutil.Big     `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                hexutil.Big     `json:"value"`
+	Data                 hexutil.Bytes   `json:"data"`
+}
+
+// FeeHistoryResult copied from module go-ethereum/internal/ethapi
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
 }
 
 // PublicTransactionPoolAPI used to generate mock by mockgen util.
@@ -38,4 +48,7 @@ type PublicTransactionPoolAPI interface {
 	EstimateGas(ctx context.Context, args CallArgs) (hexutil.Uint64, error)
 	GetTransactionCount(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*hexutil.Uint64, error)
 	SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error)
+	GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error)
+	FeeHistory(ctx context.Context, blockCount hexutil.Uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error)
 }
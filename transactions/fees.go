@@ -0,0 +1,163 @@
+package transactions
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/planq-network/status-go/rpc"
+)
+
+const (
+	// feeHistoryBlockCount is the number of blocks fee suggestions are based on.
+	feeHistoryBlockCount = 20
+
+	// baseFeeMultiplier leaves room in the max fee for the base fee to double,
+	// which takes 6 full blocks.
+	baseFeeMultiplier = 2
+
+	// replacementFeeBump is the minimum fee increase, in percent, nodes accept to
+	// replace a pending transaction.
+	replacementFeeBump = 10
+)
+
+// feeHistoryPercentiles are the percentiles of the priority fees paid in the
+// latest blocks used for the slow, normal and fast tiers.
+var feeHistoryPercentiles = []float64{10, 50, 90}
+
+// FeeHistory is the result of eth_feeHistory.
+type FeeHistory struct {
+	OldestBlock   *hexutil.Big     `json:"oldestBlock"`
+	BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64        `json:"gasUsedRatio"`
+	Reward        [][]*hexutil.Big `json:"reward"`
+}
+
+// FeeTier holds the EIP-1559 fees of a transaction.
+type FeeTier struct {
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+}
+
+// SuggestedFees are the fees suggested for new transactions. Tiers are only set
+// on chains with EIP-1559, the gas price is always set.
+type SuggestedFees struct {
+	GasPrice       *hexutil.Big `json:"gasPrice"`
+	BaseFee        *hexutil.Big `json:"baseFee"`
+	EIP1559Enabled bool         `json:"eip1559Enabled"`
+	Slow           *FeeTier     `json:"slow"`
+	Normal         *FeeTier     `json:"normal"`
+	Fast           *FeeTier     `json:"fast"`
+}
+
+// FeeSuggester provides the data fee suggestions are based on.
+type FeeSuggester interface {
+	ethereum.GasPricer
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*FeeHistory, error)
+}
+
+// SuggestFees suggests fees for transactions on the given chain.
+func SuggestFees(ctx context.Context, rpcClient *rpc.Client, chainID uint64) (*SuggestedFees, error) {
+	return suggestFees(ctx, newRPCWrapper(rpcClient, chainID))
+}
+
+func suggestFees(ctx context.Context, suggester FeeSuggester) (*SuggestedFees, error) {
+	gasPrice, err := suggester.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rst := &SuggestedFees{GasPrice: (*hexutil.Big)(gasPrice)}
+
+	// chains without EIP-1559 don't support fee history, or have no base fee
+	history, err := suggester.FeeHistory(ctx, feeHistoryBlockCount, feeHistoryPercentiles)
+	if err != nil {
+		log.Debug("fee history not available", "error", err)
+		return rst, nil
+	}
+	if len(history.BaseFeePerGas) == 0 {
+		return rst, nil
+	}
+	// the last base fee is the one of the next block
+	baseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1]
+	if baseFee == nil || baseFee.ToInt().Sign() == 0 {
+		return rst, nil
+	}
+
+	tips := feeHistoryTips(history)
+	if tips == nil {
+		tip, err := suggester.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tips = []*big.Int{tip, tip, tip}
+	}
+
+	maxBaseFee := new(big.Int).Mul(baseFee.ToInt(), big.NewInt(baseFeeMultiplier))
+	rst.EIP1559Enabled = true
+	rst.BaseFee = baseFee
+	rst.Slow = newFeeTier(maxBaseFee, tips[0])
+	rst.Normal = newFeeTier(maxBaseFee, tips[1])
+	rst.Fast = newFeeTier(maxBaseFee, tips[2])
+
+	return rst, nil
+}
+
+// feeHistoryTips returns the median priority fee of every percentile, empty
+// blocks are left out. It returns nil if there are no rewards.
+func feeHistoryTips(history *FeeHistory) []*big.Int {
+	tips := make([]*big.Int, len(feeHistoryPercentiles))
+	for i := range feeHistoryPercentiles {
+		var rewards []*big.Int
+		for block, reward := range history.Reward {
+			if block < len(history.GasUsedRatio) && history.GasUsedRatio[block] == 0 {
+				continue
+			}
+			if i < len(reward) && reward[i] != nil {
+				rewards = append(rewards, reward[i].ToInt())
+			}
+		}
+		if len(rewards) == 0 {
+			return nil
+		}
+		sort.Slice(rewards, func(a, b int) bool {
+			return rewards[a].Cmp(rewards[b]) < 0
+		})
+		tips[i] = rewards[len(rewards)/2]
+
+		// a faster tier never pays less
+		if i > 0 && tips[i].Cmp(tips[i-1]) < 0 {
+			tips[i] = tips[i-1]
+		}
+	}
+	return tips
+}
+
+func newFeeTier(maxBaseFee *big.Int, tip *big.Int) *FeeTier {
+	return &FeeTier{
+		MaxFeePerGas:         (*hexutil.Big)(new(big.Int).Add(maxBaseFee, tip)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(new(big.Int).Set(tip)),
+	}
+}
+
+// minReplacementFee returns the lowest fee accepted to replace a pending
+// transaction that pays the given fee.
+func minReplacementFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBump))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
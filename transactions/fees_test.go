@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func rewards(values ...int64) []*hexutil.Big {
+	rst := make([]*hexutil.Big, len(values))
+	for i, value := range values {
+		rst[i] = (*hexutil.Big)(big.NewInt(value))
+	}
+	return rst
+}
+
+func TestFeeHistoryTips(t *testing.T) {
+	history := &FeeHistory{
+		GasUsedRatio: []float64{0.5, 0, 0.9, 0.3},
+		Reward: [][]*hexutil.Big{
+			rewards(1, 5, 9),
+			rewards(0, 0, 0),
+			rewards(3, 4, 20),
+			rewards(2, 6, 2),
+		},
+	}
+	tips := feeHistoryTips(history)
+	require.Equal(t, []*big.Int{big.NewInt(2), big.NewInt(5), big.NewInt(9)}, tips)
+
+	history.GasUsedRatio = []float64{0, 0, 0, 0}
+	require.Nil(t, feeHistoryTips(history))
+}
+
+func TestMinReplacementFee(t *testing.T) {
+	require.Equal(t, big.NewInt(110), minReplacementFee(big.NewInt(100)))
+	require.Equal(t, big.NewInt(2), minReplacementFee(big.NewInt(1)))
+	require.Equal(t, big.NewInt(1), minReplacementFee(big.NewInt(0)))
+}
@@ -2,6 +2,7 @@ package transactions
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -17,17 +18,35 @@ import (
 // rpcWrapper wraps provides convenient interface for ethereum RPC APIs we need for sending transactions
 type rpcWrapper struct {
 	rpcClient *rpc.Client
+	chainID   uint64
 }
 
-func newRPCWrapper(client *rpc.Client) *rpcWrapper {
-	return &rpcWrapper{rpcClient: client}
+func newRPCWrapper(client *rpc.Client, chainID uint64) *rpcWrapper {
+	return &rpcWrapper{rpcClient: client, chainID: chainID}
+}
+
+// rpcTransaction is a transaction with the block it is included in, if any
+type rpcTransaction struct {
+	tx *gethtypes.Transaction
+	txExtraInfo
+}
+
+type txExtraInfo struct {
+	BlockNumber *string `json:"blockNumber,omitempty"`
+}
+
+func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
+	if err := json.Unmarshal(msg, &tx.tx); err != nil {
+		return err
+	}
+	return json.Unmarshal(msg, &tx.txExtraInfo)
 }
 
 // PendingNonceAt returns the account nonce of the given account in the pending state.
 // This is the nonce that should be used for the next transaction.
 func (w *rpcWrapper) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	var result hexutil.Uint64
-	err := w.rpcClient.CallContext(ctx, &result, w.rpcClient.UpstreamChainID, "eth_getTransactionCount", account, "pending")
+	err := w.rpcClient.CallContext(ctx, &result, w.chainID, "eth_getTransactionCount", account, "pending")
 	return uint64(result), err
 }
 
@@ -35,19 +54,53 @@ func (w *rpcWrapper) PendingNonceAt(ctx context.Context, account common.Address)
 // execution of a transaction.
 func (w *rpcWrapper) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	var hex hexutil.Big
-	if err := w.rpcClient.CallContext(ctx, &hex, w.rpcClient.UpstreamChainID, "eth_gasPrice"); err != nil {
+	if err := w.rpcClient.CallContext(ctx, &hex, w.chainID, "eth_gasPrice"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&hex), nil
+}
+
+// SuggestGasTipCap retrieves the currently suggested priority fee of EIP-1559 transactions.
+func (w *rpcWrapper) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var hex hexutil.Big
+	if err := w.rpcClient.CallContext(ctx, &hex, w.chainID, "eth_maxPriorityFeePerGas"); err != nil {
 		return nil, err
 	}
 	return (*big.Int)(&hex), nil
 }
 
+// FeeHistory returns the base fees and the priority fees paid at the given
+// percentiles of the latest blocks.
+func (w *rpcWrapper) FeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*FeeHistory, error) {
+	var history FeeHistory
+	err := w.rpcClient.CallContext(ctx, &history, w.chainID, "eth_feeHistory", hexutil.Uint64(blockCount), "latest", rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// TransactionByHash returns the transaction with the given hash, and whether
+// it is still pending.
+func (w *rpcWrapper) TransactionByHash(ctx context.Context, hash common.Hash) (*gethtypes.Transaction, bool, error) {
+	var result *rpcTransaction
+	err := w.rpcClient.CallContext(ctx, &result, w.chainID, "eth_getTransactionByHash", hash)
+	if err != nil {
+		return nil, false, err
+	}
+	if result == nil || result.tx == nil {
+		return nil, false, ethereum.NotFound
+	}
+	return result.tx, result.BlockNumber == nil, nil
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific transaction based on
 // the current pending state of the backend blockchain. There is no guarantee that this is
 // the true gas limit requirement as other transactions may be added or removed by miners,
 // but it should provide a basis for setting a reasonable default.
 func (w *rpcWrapper) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
 	var hex hexutil.Uint64
-	err := w.rpcClient.CallContext(ctx, &hex, w.rpcClient.UpstreamChainID, "eth_estimateGas", toCallArg(msg))
+	err := w.rpcClient.CallContext(ctx, &hex, w.chainID, "eth_estimateGas", toCallArg(msg))
 	if err != nil {
 		return 0, err
 	}
@@ -63,7 +116,7 @@ func (w *rpcWrapper) SendTransaction(ctx context.Context, tx *gethtypes.Transact
 	if err != nil {
 		return err
 	}
-	return w.rpcClient.CallContext(ctx, nil, w.rpcClient.UpstreamChainID, "eth_sendRawTransaction", types.EncodeHex(data))
+	return w.rpcClient.CallContext(ctx, nil, w.chainID, "eth_sendRawTransaction", types.EncodeHex(data))
 }
 
 func toCallArg(msg ethereum.CallMsg) interface{} {
@@ -83,5 +136,11 @@ func toCallArg(msg ethereum.CallMsg) interface{} {
 	if msg.GasPrice != nil {
 		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
 	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
 	return arg
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethparams "github.com/ethereum/go-ethereum/params"
 
 	"github.com/planq-network/status-go/account"
 	"github.com/planq-network/status-go/eth-node/crypto"
@@ -48,6 +49,8 @@ type Transactor struct {
 	sender               ethereum.TransactionSender
 	pendingNonceProvider PendingNonceProvider
 	gasCalculator        GasCalculator
+	feeSuggester         FeeSuggester
+	transactionProvider  TransactionProvider
 	sendTxTimeout        time.Duration
 	rpcCallTimeout       time.Duration
 	networkID            uint64
@@ -72,12 +75,19 @@ func (t *Transactor) SetNetworkID(networkID uint64) {
 	t.networkID = networkID
 }
 
+// NetworkID returns the network transactions are sent to.
+func (t *Transactor) NetworkID() uint64 {
+	return t.networkID
+}
+
 // SetRPC sets RPC params, a client and a timeout
 func (t *Transactor) SetRPC(rpcClient *rpc.Client, timeout time.Duration) {
-	rpcWrapper := newRPCWrapper(rpcClient)
+	rpcWrapper := newRPCWrapper(rpcClient, rpcClient.UpstreamChainID)
 	t.sender = rpcWrapper
 	t.pendingNonceProvider = rpcWrapper
 	t.gasCalculator = rpcWrapper
+	t.feeSuggester = rpcWrapper
+	t.transactionProvider = rpcWrapper
 	t.rpcCallTimeout = timeout
 }
 
@@ -218,7 +228,7 @@ func (t *Transactor) HashTransaction(args SendTxArgs) (validatedArgs SendTxArgs,
 		validatedArgs.GasPrice = (*hexutil.Big)(gasPrice)
 	} else {
 		validatedArgs.MaxPriorityFeePerGas = (*hexutil.Big)(gasTipCap)
-		validatedArgs.MaxPriorityFeePerGas = (*hexutil.Big)(gasFeeCap)
+		validatedArgs.MaxFeePerGas = (*hexutil.Big)(gasFeeCap)
 	}
 	validatedArgs.Gas = &newGas
 
@@ -291,13 +301,12 @@ func (t *Transactor) validateAndPropagate(selectedAccount *account.SelectedExtKe
 		}
 	}
 
-	chainID := big.NewInt(int64(t.networkID))
 	value := (*big.Int)(args.Value)
 
 	var gas uint64
 	if args.Gas != nil {
 		gas = uint64(*args.Gas)
-	} else if args.Gas == nil && !args.IsDynamicFeeTx() {
+	} else {
 		ctx, cancel = context.WithTimeout(context.Background(), t.rpcCallTimeout)
 		defer cancel()
 
@@ -309,13 +318,19 @@ func (t *Transactor) validateAndPropagate(selectedAccount *account.SelectedExtKe
 			gethTo = common.Address(*args.To)
 			gethToPtr = &gethTo
 		}
-		gas, err = t.gasCalculator.EstimateGas(ctx, ethereum.CallMsg{
+		msg := ethereum.CallMsg{
 			From:     common.Address(args.From),
 			To:       gethToPtr,
 			GasPrice: gasPrice,
 			Value:    value,
 			Data:     args.GetInput(),
-		})
+		}
+		if args.IsDynamicFeeTx() {
+			msg.GasPrice = nil
+			msg.GasFeeCap = (*big.Int)(args.MaxFeePerGas)
+			msg.GasTipCap = (*big.Int)(args.MaxPriorityFeePerGas)
+		}
+		gas, err = t.gasCalculator.EstimateGas(ctx, msg)
 		if err != nil {
 			return hash, err
 		}
@@ -327,11 +342,136 @@ func (t *Transactor) validateAndPropagate(selectedAccount *account.SelectedExtKe
 
 	tx := t.buildTransactionWithOverrides(nonce, value, gas, gasPrice, args)
 
+	return t.signAndSend(tx, selectedAccount)
+}
+
+// SpeedUpTransaction replaces a pending transaction by the same transaction with higher fees.
+// It returns the arguments of the replacement transaction and its hash.
+func (t *Transactor) SpeedUpTransaction(args ReplaceTxArgs, selectedAccount *account.SelectedExtKey) (SendTxArgs, types.Hash, error) {
+	return t.replaceTransaction(args, selectedAccount, false)
+}
+
+// CancelTransaction replaces a pending transaction by an empty transfer to its sender with higher fees.
+// It returns the arguments of the replacement transaction and its hash.
+func (t *Transactor) CancelTransaction(args ReplaceTxArgs, selectedAccount *account.SelectedExtKey) (SendTxArgs, types.Hash, error) {
+	return t.replaceTransaction(args, selectedAccount, true)
+}
+
+func (t *Transactor) replaceTransaction(args ReplaceTxArgs, selectedAccount *account.SelectedExtKey, cancelTx bool) (sendArgs SendTxArgs, hash types.Hash, err error) {
+	if err = t.validateAccount(SendTxArgs{From: args.From}, selectedAccount); err != nil {
+		return sendArgs, hash, err
+	}
+
+	if !args.Valid() {
+		return sendArgs, hash, ErrInvalidSendTxArgs
+	}
+
+	t.addrLock.LockAddr(args.From)
+	defer t.addrLock.UnlockAddr(args.From)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	replaced, isPending, err := t.transactionProvider.TransactionByHash(ctx, common.Hash(args.Hash))
+	if err != nil {
+		return sendArgs, hash, err
+	}
+	if !isPending {
+		return sendArgs, hash, ErrTransactionNotPending
+	}
+
+	sender, err := gethtypes.Sender(gethtypes.NewLondonSigner(big.NewInt(int64(t.networkID))), replaced)
+	if err != nil {
+		return sendArgs, hash, err
+	}
+	if sender != common.Address(args.From) {
+		return sendArgs, hash, ErrInvalidTxSender
+	}
+
+	nonce := hexutil.Uint64(replaced.Nonce())
+	gas := hexutil.Uint64(replaced.Gas())
+	sendArgs = SendTxArgs{
+		From:  args.From,
+		Nonce: &nonce,
+		Gas:   &gas,
+		Value: (*hexutil.Big)(replaced.Value()),
+		Data:  types.HexBytes(replaced.Data()),
+	}
+	if replaced.To() != nil {
+		to := types.Address(*replaced.To())
+		sendArgs.To = &to
+	}
+	if cancelTx {
+		to := args.From
+		cancelGas := hexutil.Uint64(gethparams.TxGas)
+		sendArgs.To = &to
+		sendArgs.Gas = &cancelGas
+		sendArgs.Value = (*hexutil.Big)(big.NewInt(0))
+		sendArgs.Data = nil
+	}
+
+	if err = t.setReplacementFees(ctx, &sendArgs, args, replaced); err != nil {
+		return sendArgs, hash, err
+	}
+
+	hash, err = t.signAndSend(t.buildTransaction(sendArgs), selectedAccount)
+	return sendArgs, hash, err
+}
+
+// setReplacementFees sets the fees of a transaction replacing another one. The fees
+// must be enough higher than the fees of the replaced transaction for nodes to accept
+// it, when they are not given the fast fees are used if they are higher.
+func (t *Transactor) setReplacementFees(ctx context.Context, sendArgs *SendTxArgs, args ReplaceTxArgs, replaced *gethtypes.Transaction) error {
+	minFeeCap := minReplacementFee(replaced.GasFeeCap())
+	minTipCap := minReplacementFee(replaced.GasTipCap())
+
+	switch {
+	case args.IsDynamicFeeTx():
+		if args.MaxFeePerGas.ToInt().Cmp(minFeeCap) < 0 || args.MaxPriorityFeePerGas.ToInt().Cmp(minTipCap) < 0 {
+			return ErrReplacementUnderpriced
+		}
+		sendArgs.MaxFeePerGas = args.MaxFeePerGas
+		sendArgs.MaxPriorityFeePerGas = args.MaxPriorityFeePerGas
+
+	case args.GasPrice != nil:
+		// the gas price of a legacy transaction is both its fee cap and its tip cap
+		if args.GasPrice.ToInt().Cmp(minFeeCap) < 0 {
+			return ErrReplacementUnderpriced
+		}
+		sendArgs.GasPrice = args.GasPrice
+
+	case replaced.Type() == gethtypes.DynamicFeeTxType:
+		fees, err := suggestFees(ctx, t.feeSuggester)
+		if err != nil {
+			return err
+		}
+		feeCap, tipCap := minFeeCap, minTipCap
+		if fees.Fast != nil {
+			feeCap = maxBig(feeCap, fees.Fast.MaxFeePerGas.ToInt())
+			tipCap = maxBig(tipCap, fees.Fast.MaxPriorityFeePerGas.ToInt())
+		}
+		sendArgs.MaxFeePerGas = (*hexutil.Big)(feeCap)
+		sendArgs.MaxPriorityFeePerGas = (*hexutil.Big)(tipCap)
+
+	default:
+		gasPrice, err := t.feeSuggester.SuggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		sendArgs.GasPrice = (*hexutil.Big)(maxBig(minFeeCap, gasPrice))
+	}
+
+	return nil
+}
+
+func (t *Transactor) signAndSend(tx *gethtypes.Transaction, selectedAccount *account.SelectedExtKey) (hash types.Hash, err error) {
+	chainID := big.NewInt(int64(t.networkID))
 	signedTx, err := gethtypes.SignTx(tx, gethtypes.NewLondonSigner(chainID), selectedAccount.AccountKey.PrivateKey)
 	if err != nil {
 		return hash, err
 	}
-	ctx, cancel = context.WithTimeout(context.Background(), t.rpcCallTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
 	defer cancel()
 
 	if err := t.sender.SendTransaction(ctx, signedTx); err != nil {
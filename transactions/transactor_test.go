@@ -1,6 +1,8 @@
 package transactions
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -87,12 +89,12 @@ func (s *TransactorSuite) setupTransactionPoolAPI(args SendTxArgs, returnNonce,
 		} else {
 			usedGasPrice = (*big.Int)(args.GasPrice)
 		}
-		if args.Gas == nil {
-			s.txServiceMock.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(testGas, nil)
-			usedGas = testGas
-		} else {
-			usedGas = *args.Gas
-		}
+	}
+	if args.Gas == nil {
+		s.txServiceMock.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(testGas, nil)
+		usedGas = testGas
+	} else {
+		usedGas = *args.Gas
 	}
 	// Prepare the transaction and RLP encode it.
 	data := s.rlpEncodeTx(args, s.nodeConfig, account, &resultNonce, usedGas, usedGasPrice)
@@ -427,3 +429,167 @@ func (s *TransactorSuite) TestHashTransaction() {
 
 	s.NotEqual(common.Hash{}, hash)
 }
+
+// setupPendingTransaction signs a transaction of the account and serves it as the
+// result of eth_getTransactionByHash
+func (s *TransactorSuite) setupPendingTransaction(txData gethtypes.TxData, selectedAccount *account.SelectedExtKey, pending bool) *gethtypes.Transaction {
+	chainID := big.NewInt(int64(s.nodeConfig.NetworkID))
+	tx, err := gethtypes.SignTx(gethtypes.NewTx(txData), gethtypes.NewLondonSigner(chainID), selectedAccount.AccountKey.PrivateKey)
+	s.Require().NoError(err)
+
+	encoded, err := tx.MarshalJSON()
+	s.Require().NoError(err)
+	result := map[string]interface{}{}
+	s.Require().NoError(json.Unmarshal(encoded, &result))
+	if !pending {
+		result["blockNumber"] = "0x1"
+	}
+	s.txServiceMock.EXPECT().GetTransactionByHash(gomock.Any(), tx.Hash()).Return(result, nil)
+
+	return tx
+}
+
+func (s *TransactorSuite) expectSentTransaction(sent **gethtypes.Transaction) {
+	s.txServiceMock.EXPECT().SendRawTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+		tx := new(gethtypes.Transaction)
+		s.Require().NoError(tx.UnmarshalBinary(encodedTx))
+		*sent = tx
+		return tx.Hash(), nil
+	})
+}
+
+func (s *TransactorSuite) TestSpeedUpTransaction() {
+	key, _ := gethcrypto.GenerateKey()
+	selectedAccount := &account.SelectedExtKey{
+		Address:    types.Address(gethcrypto.PubkeyToAddress(key.PublicKey)),
+		AccountKey: &types.Key{PrivateKey: key},
+	}
+	to := common.Address(*account.ToAddress(utils.TestConfig.Account2.WalletAddress))
+
+	replaced := s.setupPendingTransaction(&gethtypes.DynamicFeeTx{
+		Nonce:     3,
+		Gas:       50000,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		To:        &to,
+		Value:     big.NewInt(7),
+		Data:      []byte{1},
+	}, selectedAccount, true)
+
+	// The fast max fee is higher than the bumped one, the bumped priority fee is higher than the fast one
+	s.txServiceMock.EXPECT().GasPrice(gomock.Any()).Return((*hexutil.Big)(big.NewInt(500)), nil)
+	s.txServiceMock.EXPECT().FeeHistory(gomock.Any(), hexutil.Uint64(feeHistoryBlockCount), gethrpc.LatestBlockNumber, feeHistoryPercentiles).Return(&fake.FeeHistoryResult{
+		BaseFee:      []*hexutil.Big{(*hexutil.Big)(big.NewInt(500)), (*hexutil.Big)(big.NewInt(600))},
+		GasUsedRatio: []float64{0.5},
+		Reward:       [][]*hexutil.Big{{(*hexutil.Big)(big.NewInt(10)), (*hexutil.Big)(big.NewInt(20)), (*hexutil.Big)(big.NewInt(30))}},
+	}, nil)
+	var sent *gethtypes.Transaction
+	s.expectSentTransaction(&sent)
+
+	args, hash, err := s.manager.SpeedUpTransaction(ReplaceTxArgs{From: selectedAccount.Address, Hash: types.Hash(replaced.Hash())}, selectedAccount)
+	s.Require().NoError(err)
+	s.Equal(types.Hash(sent.Hash()), hash)
+	s.Equal(uint64(3), sent.Nonce())
+	s.Equal(uint64(50000), sent.Gas())
+	s.Equal(replaced.Data(), sent.Data())
+	s.Equal(big.NewInt(7), sent.Value())
+	s.Equal(big.NewInt(1230), sent.GasFeeCap())
+	s.Equal(big.NewInt(110), sent.GasTipCap())
+	s.Equal(sent.GasFeeCap(), args.MaxFeePerGas.ToInt())
+
+	// Given fees must be high enough to replace the transaction
+	replaced = s.setupPendingTransaction(&gethtypes.LegacyTx{
+		Nonce:    4,
+		Gas:      50000,
+		GasPrice: big.NewInt(1000),
+		To:       &to,
+	}, selectedAccount, true)
+	_, _, err = s.manager.SpeedUpTransaction(ReplaceTxArgs{
+		From:     selectedAccount.Address,
+		Hash:     types.Hash(replaced.Hash()),
+		GasPrice: (*hexutil.Big)(big.NewInt(1050)),
+	}, selectedAccount)
+	s.Equal(ErrReplacementUnderpriced, err)
+}
+
+func (s *TransactorSuite) TestCancelTransaction() {
+	key, _ := gethcrypto.GenerateKey()
+	selectedAccount := &account.SelectedExtKey{
+		Address:    types.Address(gethcrypto.PubkeyToAddress(key.PublicKey)),
+		AccountKey: &types.Key{PrivateKey: key},
+	}
+	to := common.Address(*account.ToAddress(utils.TestConfig.Account2.WalletAddress))
+
+	replaced := s.setupPendingTransaction(&gethtypes.LegacyTx{
+		Nonce:    5,
+		Gas:      50000,
+		GasPrice: big.NewInt(1000),
+		To:       &to,
+		Value:    big.NewInt(7),
+	}, selectedAccount, true)
+
+	// The bumped gas price is used as it is higher than the suggested one
+	s.txServiceMock.EXPECT().GasPrice(gomock.Any()).Return((*hexutil.Big)(big.NewInt(500)), nil)
+	var sent *gethtypes.Transaction
+	s.expectSentTransaction(&sent)
+
+	_, _, err := s.manager.CancelTransaction(ReplaceTxArgs{From: selectedAccount.Address, Hash: types.Hash(replaced.Hash())}, selectedAccount)
+	s.Require().NoError(err)
+	s.Equal(uint64(5), sent.Nonce())
+	s.Equal(common.Address(selectedAccount.Address), *sent.To())
+	s.Equal(big.NewInt(0), sent.Value())
+	s.Equal(gethparams.TxGas, sent.Gas())
+	s.Equal(big.NewInt(1100), sent.GasPrice())
+
+	// Mined transactions can't be replaced
+	replaced = s.setupPendingTransaction(&gethtypes.LegacyTx{
+		Nonce:    6,
+		Gas:      50000,
+		GasPrice: big.NewInt(1000),
+		To:       &to,
+	}, selectedAccount, false)
+	_, _, err = s.manager.CancelTransaction(ReplaceTxArgs{From: selectedAccount.Address, Hash: types.Hash(replaced.Hash())}, selectedAccount)
+	s.Equal(ErrTransactionNotPending, err)
+
+	// Only the sender can replace a transaction
+	otherKey, _ := gethcrypto.GenerateKey()
+	otherAccount := &account.SelectedExtKey{
+		Address:    types.Address(gethcrypto.PubkeyToAddress(otherKey.PublicKey)),
+		AccountKey: &types.Key{PrivateKey: otherKey},
+	}
+	replaced = s.setupPendingTransaction(&gethtypes.LegacyTx{
+		Nonce:    7,
+		Gas:      50000,
+		GasPrice: big.NewInt(1000),
+		To:       &to,
+	}, otherAccount, true)
+	_, _, err = s.manager.CancelTransaction(ReplaceTxArgs{From: selectedAccount.Address, Hash: types.Hash(replaced.Hash())}, selectedAccount)
+	s.Equal(ErrInvalidTxSender, err)
+}
+
+func (s *TransactorSuite) TestSuggestFees() {
+	s.txServiceMock.EXPECT().GasPrice(gomock.Any()).Return((*hexutil.Big)(big.NewInt(500)), nil)
+	s.txServiceMock.EXPECT().FeeHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&fake.FeeHistoryResult{
+		BaseFee:      []*hexutil.Big{(*hexutil.Big)(big.NewInt(400)), (*hexutil.Big)(big.NewInt(450))},
+		GasUsedRatio: []float64{0},
+		Reward:       [][]*hexutil.Big{{(*hexutil.Big)(big.NewInt(0)), (*hexutil.Big)(big.NewInt(0)), (*hexutil.Big)(big.NewInt(0))}},
+	}, nil)
+	// Empty blocks are left out, the node suggestion is used instead
+	s.txServiceMock.EXPECT().MaxPriorityFeePerGas(gomock.Any()).Return((*hexutil.Big)(big.NewInt(5)), nil)
+
+	fees, err := suggestFees(context.Background(), s.manager.feeSuggester)
+	s.Require().NoError(err)
+	s.True(fees.EIP1559Enabled)
+	s.Equal(big.NewInt(450), fees.BaseFee.ToInt())
+	s.Equal(big.NewInt(905), fees.Fast.MaxFeePerGas.ToInt())
+	s.Equal(big.NewInt(5), fees.Slow.MaxPriorityFeePerGas.ToInt())
+
+	// Chains without EIP-1559 only have a gas price
+	s.txServiceMock.EXPECT().GasPrice(gomock.Any()).Return((*hexutil.Big)(big.NewInt(500)), nil)
+	s.txServiceMock.EXPECT().FeeHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("the method eth_feeHistory does not exist"))
+	fees, err = suggestFees(context.Background(), s.manager.feeSuggester)
+	s.Require().NoError(err)
+	s.False(fees.EIP1559Enabled)
+	s.Equal(big.NewInt(500), fees.GasPrice.ToInt())
+	s.Nil(fees.Normal)
+}
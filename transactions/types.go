@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/planq-network/status-go/eth-node/types"
 )
@@ -23,6 +24,10 @@ var (
 	ErrInvalidTxSender = errors.New("transaction can only be send by its creator")
 	//ErrAccountDoesntExist is sent when provided sub-account is not stored in database.
 	ErrAccountDoesntExist = errors.New("account doesn't exist")
+	// ErrTransactionNotPending is returned when replacing a transaction that is not pending anymore.
+	ErrTransactionNotPending = errors.New("transaction is not pending")
+	// ErrReplacementUnderpriced is returned when the fees of a replacement transaction are not high enough.
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
 )
 
 // PendingNonceProvider provides information about nonces.
@@ -30,6 +35,11 @@ type PendingNonceProvider interface {
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 }
 
+// TransactionProvider provides transactions, and whether they are still pending.
+type TransactionProvider interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *gethtypes.Transaction, isPending bool, err error)
+}
+
 // GasCalculator provides methods for estimating and pricing gas.
 type GasCalculator interface {
 	ethereum.GasEstimator
@@ -55,8 +65,22 @@ type SendTxArgs struct {
 	Data  types.HexBytes `json:"data"`
 }
 
+// ReplaceTxArgs identifies a pending transaction to replace by a transaction
+// with the same nonce. Fees are bumped automatically unless they are set.
+type ReplaceTxArgs struct {
+	From                 types.Address `json:"from"`
+	Hash                 types.Hash    `json:"hash"`
+	GasPrice             *hexutil.Big  `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big  `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big  `json:"maxPriorityFeePerGas"`
+}
+
 // Valid checks whether this structure is filled in correctly.
 func (args SendTxArgs) Valid() bool {
+	if !validFees(args.GasPrice, args.MaxFeePerGas, args.MaxPriorityFeePerGas) {
+		return false
+	}
+
 	// if at least one of the fields is empty, it is a valid struct
 	if isNilOrEmpty(args.Input) || isNilOrEmpty(args.Data) {
 		return true
@@ -71,6 +95,31 @@ func (args SendTxArgs) IsDynamicFeeTx() bool {
 	return args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas != nil
 }
 
+// Valid checks whether this structure is filled in correctly.
+func (args ReplaceTxArgs) Valid() bool {
+	return validFees(args.GasPrice, args.MaxFeePerGas, args.MaxPriorityFeePerGas)
+}
+
+// IsDynamicFeeTx checks whether dynamic fee parameters are set for the replacement
+func (args ReplaceTxArgs) IsDynamicFeeTx() bool {
+	return args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas != nil
+}
+
+// validFees checks that either the legacy gas price or both EIP-1559 fees are
+// used, and that the priority fee is not higher than the max fee
+func validFees(gasPrice, maxFeePerGas, maxPriorityFeePerGas *hexutil.Big) bool {
+	if (maxFeePerGas == nil) != (maxPriorityFeePerGas == nil) {
+		return false
+	}
+	if maxFeePerGas == nil {
+		return true
+	}
+	if gasPrice != nil {
+		return false
+	}
+	return maxPriorityFeePerGas.ToInt().Cmp(maxFeePerGas.ToInt()) <= 0
+}
+
 // GetInput returns either Input or Data field's value dependent on what is filled.
 func (args SendTxArgs) GetInput() types.HexBytes {
 	if !isNilOrEmpty(args.Input) {
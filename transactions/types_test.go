@@ -1,10 +1,13 @@
 package transactions
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
 	"github.com/planq-network/status-go/eth-node/types"
 )
 
@@ -24,6 +27,18 @@ func TestSendTxArgsValidity(t *testing.T) {
 	doSendTxValidityTest(t, SendTxArgs{Input: bytes1, Data: bytesEmpty}, true, bytes1)
 	doSendTxValidityTest(t, SendTxArgs{Input: bytesEmpty, Data: bytes1}, true, bytes1)
 	doSendTxValidityTest(t, SendTxArgs{Input: bytesEmpty, Data: bytesEmpty}, true, bytesEmpty)
+
+	// 2. Either the gas price or both EIP-1559 fees are set, the priority fee is not higher than the max fee
+
+	low := (*hexutil.Big)(big.NewInt(1))
+	high := (*hexutil.Big)(big.NewInt(2))
+
+	doSendTxValidityTest(t, SendTxArgs{GasPrice: low}, true, nil)
+	doSendTxValidityTest(t, SendTxArgs{MaxFeePerGas: high, MaxPriorityFeePerGas: low}, true, nil)
+	doSendTxValidityTest(t, SendTxArgs{MaxFeePerGas: high}, false, nil)
+	doSendTxValidityTest(t, SendTxArgs{MaxPriorityFeePerGas: low}, false, nil)
+	doSendTxValidityTest(t, SendTxArgs{GasPrice: low, MaxFeePerGas: high, MaxPriorityFeePerGas: low}, false, nil)
+	doSendTxValidityTest(t, SendTxArgs{MaxFeePerGas: low, MaxPriorityFeePerGas: high}, false, nil)
 }
 
 func doSendTxValidityTest(t *testing.T, args SendTxArgs, expectValid bool, expectValue types.HexBytes) {
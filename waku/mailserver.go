@@ -46,6 +46,14 @@ type MailServer interface {
 	Deliver(peerID []byte, request common.MessagesRequest)
 }
 
+// ReplicatingMailServer is a MailServer pulling the history of other mail
+// servers. Replicate must return true if the p2p envelopes of a peer are
+// consumed by the mail server, they are not delivered to the filters then.
+type ReplicatingMailServer interface {
+	MailServer
+	Replicate(peerID []byte, envelopes []*common.Envelope) bool
+}
+
 // MailServerResponse is the response payload sent by the mailserver.
 type MailServerResponse struct {
 	LastEnvelopeHash gethcommon.Hash
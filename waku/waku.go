@@ -1180,7 +1180,11 @@ func (w *Waku) OnNewEnvelopes(envelopes []*common.Envelope, peer common.Peer) ([
 	return envelopeErrors, nil
 }
 
-func (w *Waku) OnNewP2PEnvelopes(envelopes []*common.Envelope) error {
+func (w *Waku) OnNewP2PEnvelopes(envelopes []*common.Envelope, p common.Peer) error {
+	// envelopes requested by a mail server from another one are archived only
+	if replicating, ok := w.mailServer.(ReplicatingMailServer); ok && replicating.Replicate(p.ID(), envelopes) {
+		return nil
+	}
 	for _, envelope := range envelopes {
 		w.postP2P(envelope)
 	}